@@ -97,3 +97,7 @@ const StaticUnitCapability = "static_unit"
 const PubSubCapability = "pubsub"
 const ConfigCapability = "config"
 const InternalCapability = "internal"
+const ScheduleCapability = "schedule"
+const QueueCapability = "queue"
+const WorkflowCapability = "workflow"
+const FsEventCapability = "fs_event"
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/pkg/errors"
+)
+
+// ExplainUnit writes a report of the files and dependencies that make up the named execution unit, along with why
+// each file was included.
+func ExplainUnit(out io.Writer, constructs *construct.ConstructGraph, unitName string) error {
+	var unit *types.ExecutionUnit
+	for _, u := range construct.GetConstructsOfType[*types.ExecutionUnit](constructs) {
+		if u.Name == unitName {
+			unit = u
+			break
+		}
+	}
+	if unit == nil {
+		return errors.Errorf("execution unit '%s' not found", unitName)
+	}
+
+	fmt.Fprintf(out, "Execution unit '%s' (%s)\n", unit.Name, unit.Executable.Type)
+
+	fmt.Fprintln(out, "  Source files:")
+	for _, path := range sortedKeys(unit.Executable.SourceFiles) {
+		reason, ok := unit.Executable.InclusionReasons[path]
+		if !ok {
+			reason = "no reason recorded"
+		}
+		fmt.Fprintf(out, "    %s: %s\n", path, reason)
+	}
+
+	if len(unit.Executable.Resources) > 0 {
+		fmt.Fprintln(out, "  Resources:")
+		for _, path := range sortedKeys(unit.Executable.Resources) {
+			fmt.Fprintf(out, "    %s\n", path)
+		}
+	}
+
+	if len(unit.Executable.StaticAssets) > 0 {
+		fmt.Fprintln(out, "  Static assets:")
+		for _, path := range sortedKeys(unit.Executable.StaticAssets) {
+			fmt.Fprintf(out, "    %s: matched by @klotho::embed_assets\n", path)
+		}
+	}
+
+	if len(unit.Executable.PrunedDependencies) > 0 {
+		fmt.Fprintln(out, "  Removed unused dependencies:")
+		for _, path := range sortedKeys(unit.Executable.PrunedDependencies) {
+			for _, dep := range unit.Executable.PrunedDependencies[path] {
+				fmt.Fprintf(out, "    %s: %s\n", path, dep)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		unit    string
+		expect  string
+		wantErr bool
+	}{
+		{
+			name: "explains files and pruned dependencies",
+			unit: "main",
+			expect: `Execution unit 'main' (NodeJS)
+  Source files:
+    index.js: entrypoint
+    util.js: imported by index.js
+  Resources:
+    package.json
+  Removed unused dependencies:
+    package.json: lodash
+`,
+		},
+		{
+			name:    "unknown unit",
+			unit:    "missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := &types.ExecutionUnit{Name: "main", Executable: types.NewExecutable()}
+			unit.Executable.Type = types.ExecutableTypeNodeJS
+			unit.AddEntrypoint(&io.FileRef{FPath: "index.js"})
+			unit.AddSourceFile(&io.FileRef{FPath: "util.js"})
+			unit.Executable.SetInclusionReason("util.js", "imported by index.js")
+			unit.AddResource(&io.FileRef{FPath: "package.json"})
+			unit.Executable.AddPrunedDependencies("package.json", "lodash")
+
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			out := new(strings.Builder)
+			err := ExplainUnit(out, graph, tt.unit)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.expect, out.String())
+		})
+	}
+}
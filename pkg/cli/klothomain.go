@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -29,8 +30,12 @@ import (
 	"github.com/klothoplatform/klotho/pkg/closenicely"
 	"github.com/klothoplatform/klotho/pkg/compiler"
 	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/infra/compose"
 	"github.com/klothoplatform/klotho/pkg/input"
 	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/aws"
+	"github.com/klothoplatform/klotho/pkg/provider/imports"
 	"github.com/klothoplatform/klotho/pkg/updater"
 )
 
@@ -65,6 +70,8 @@ var cfg struct {
 	caps               bool
 	provider           string
 	appName            string
+	env                string
+	allEnvs            bool
 	strict             bool
 	disableLogo        bool
 	internalDebug      bool
@@ -79,6 +86,7 @@ var cfg struct {
 	skipVisualization  bool
 	skipUpdateCheck    bool
 	jsonLog            bool
+	explainUnit        string
 }
 
 const defaultDisableLogo = false
@@ -129,7 +137,9 @@ func (km KlothoMain) Main() {
 	flags.BoolVar(&cfg.caps, "caps", false, "Print the capabilities to a companion file")
 	flags.StringVarP(&cfg.cfgFormat, "cfg-format", "F", "yaml", "The format for the compiled config file (if --config is not specified). Supports: yaml, toml, json")
 	flags.StringVar(&cfg.appName, "app", "", "Application name")
-	flags.StringVarP(&cfg.provider, "provider", "p", "", fmt.Sprintf("Provider to compile to. Supported: %v", "aws"))
+	flags.StringVar(&cfg.env, "env", "", "Environment to compile for, whose overrides in the config's environments are applied. Each environment is compiled separately, as its own stack of the application's Pulumi project")
+	flags.BoolVar(&cfg.allEnvs, "all-envs", false, "Compile every environment in the config's environments, each into a directory of the output directory named after it")
+	flags.StringVarP(&cfg.provider, "provider", "p", "", fmt.Sprintf("Provider to compile to. Supported: %v", "aws, azure, gcp, kubernetes, local"))
 	flags.BoolVar(&cfg.strict, "strict", false, "Fail the compilation on warnings")
 	flags.BoolVar(&cfg.disableLogo, "disable-logo", defaultDisableLogo, "Disable printing the Klotho logo")
 	flags.BoolVar(&cfg.uploadSource, "upload-source", false, "Upload the compressed source folder for debugging")
@@ -143,6 +153,7 @@ func (km KlothoMain) Main() {
 	flags.BoolVar(&cfg.skipVisualization, "skip-visualization", false, "Skip Klotho's visualization stage.")
 	flags.BoolVar(&cfg.skipUpdateCheck, "skip-update-check", false, "Skip Klotho's update check.")
 	flags.BoolVar(&cfg.jsonLog, "json-log", false, "Output logs in JSON format.")
+	flags.StringVar(&cfg.explainUnit, "explain-unit", "", "Print why each file and dependency is included in the named execution unit.")
 
 	if authFlags, hasFlags := km.Authorizer.(FlagsProvider); hasFlags {
 		authFlags.SetUpCliFlags(flags)
@@ -186,7 +197,7 @@ func setupLogger(analyticsClient *analytics.Client) (*zap.Logger, error) {
 	}))
 }
 
-func readConfig(args []string) (appCfg config.Application, err error) {
+func readConfig(args []string, env string) (appCfg config.Application, err error) {
 	if cfg.config != "" {
 		appCfg, err = config.ReadConfig(cfg.config)
 		if err != nil {
@@ -196,6 +207,11 @@ func readConfig(args []string) (appCfg config.Application, err error) {
 		appCfg.Format = cfg.cfgFormat
 	}
 	appCfg.EnsureMapsExist()
+	if env != "" {
+		if err = appCfg.ApplyEnvironment(env); err != nil {
+			return
+		}
+	}
 	// TODO debug logging for when config file is overwritten by CLI flags
 	if cfg.appName != "" {
 		appCfg.AppName = cfg.appName
@@ -211,6 +227,11 @@ func readConfig(args []string) (appCfg config.Application, err error) {
 			appCfg.OutDir = cfg.outDir
 		}
 	}
+	if cfg.allEnvs {
+		// each environment's stack config and program are compiled into a directory of their own, since the program
+		// names the resources after the environment
+		appCfg.OutDir = filepath.Join(appCfg.OutDir, env)
+	}
 
 	return
 }
@@ -366,7 +387,43 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
-	appCfg, err := readConfig(args)
+	envs := []string{cfg.env}
+	if cfg.allEnvs {
+		envs, err = configEnvironments()
+		if err != nil {
+			return err
+		}
+	}
+	for _, env := range envs {
+		if err = km.compile(cmd, args, env, options, analyticsClient, errHandler, klothoName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configEnvironments returns the environments of the config, for --all-envs to compile each of.
+func configEnvironments() ([]string, error) {
+	if cfg.env != "" {
+		return nil, errors.New("--env and --all-envs cannot be used together")
+	}
+	if cfg.config == "" {
+		return nil, errors.New("--all-envs requires a config, using --config")
+	}
+	appCfg, err := config.ReadConfig(cfg.config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read config '%s'", cfg.config)
+	}
+	envs := appCfg.EnvironmentNames()
+	if len(envs) == 0 {
+		return nil, errors.Errorf("config '%s' does not define any environments", cfg.config)
+	}
+	return envs, nil
+}
+
+// compile compiles the application for the environment env, or for no environment if it is empty.
+func (km KlothoMain) compile(cmd *cobra.Command, args []string, env string, options Options, analyticsClient *analytics.Client, errHandler ErrorHandler, klothoName string) (err error) {
+	appCfg, err := readConfig(args, env)
 	if err != nil {
 		return errors.Wrapf(err, "could not read config '%s'", cfg.config)
 	}
@@ -486,12 +543,59 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 			return errors.Errorf("failed to load constraints: %s", err.Error())
 		}
 
-		klothoCompiler.Engine.LoadContext(document.Constructs, c, cfg.appName)
+		if appCfg.Network != nil {
+			if appCfg.Provider != provider.AWS {
+				return errors.Errorf("importing a network is not supported for provider %s", appCfg.Provider)
+			}
+			networkImports, err := aws.ImportNetwork(*appCfg.Network, document.Constructs)
+			if err != nil {
+				return errors.Errorf("failed to import network: %s", err.Error())
+			}
+			if appCfg.Imports == nil {
+				appCfg.Imports = make(map[construct.ResourceId]string)
+			}
+			for id, importId := range networkImports {
+				appCfg.Imports[id] = importId
+			}
+		}
+
+		klothoCompiler.Engine.LoadContext(document.Constructs, c, appCfg.QualifiedAppName())
+		klothoCompiler.Engine.LoadImports(appCfg.Imports)
 		dag, err := klothoCompiler.Engine.Run()
 		if err != nil {
 			return errors.Errorf("failed to run engine: %s", err.Error())
 		}
 		zap.S().Debugf("Finished running engine")
+		if appCfg.Observability != nil {
+			if appCfg.Provider != provider.AWS {
+				return errors.Errorf("observability is not supported for provider %s", appCfg.Provider)
+			}
+			err = aws.ObservabilityPlugin{Config: &appCfg}.Translate(document.Constructs, dag)
+			if err != nil {
+				return errors.Errorf("failed to add observability: %s", err.Error())
+			}
+		}
+		if appCfg.Regions != nil {
+			if appCfg.Provider != provider.AWS {
+				return errors.Errorf("regions are not supported for provider %s", appCfg.Provider)
+			}
+			err = aws.RegionsPlugin{Config: &appCfg}.Translate(document.Constructs, dag)
+			if err != nil {
+				return errors.Errorf("failed to apply regions: %s", err.Error())
+			}
+		}
+		err = imports.Plugin{Config: &appCfg}.Translate(document.Constructs, dag)
+		if err != nil {
+			return errors.Errorf("failed to apply imports: %s", err.Error())
+		}
+		if appCfg.Provider == provider.AWS {
+			err = aws.ResourcePolicyPlugin{Config: &appCfg, Templates: klothoCompiler.Engine.ResourceTemplates}.Translate(document.Constructs, dag)
+			if err != nil {
+				return errors.Errorf("failed to apply tags and naming: %s", err.Error())
+			}
+		} else if len(appCfg.Tags) > 0 || appCfg.Naming != "" {
+			return errors.Errorf("tags and naming are not supported for provider %s", appCfg.Provider)
+		}
 		files, err := klothoCompiler.Engine.VisualizeViews()
 		if err != nil {
 			return errors.Errorf("failed to run engine viz: %s", err.Error())
@@ -499,7 +603,7 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 		document.OutputFiles = append(document.OutputFiles, files...)
 		document.Resources = dag
 		document.DeploymentOrder = klothoCompiler.Engine.GetDeploymentOrderGraph(dag)
-		err = klothoCompiler.Document.Resources.OutputResourceGraph(cfg.outDir)
+		err = klothoCompiler.Document.Resources.OutputResourceGraph(appCfg.OutDir)
 		if err != nil {
 			return err
 		}
@@ -529,6 +633,12 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 		analyticsClient.UploadSource(input)
 	}
 
+	if cfg.explainUnit != "" {
+		if err = ExplainUnit(os.Stdout, document.Constructs, cfg.explainUnit); err != nil {
+			return err
+		}
+	}
+
 	resourceCounts, err := document.OutputResources()
 	if err != nil {
 		return err
@@ -546,7 +656,7 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 
 func filterInfrastructure(plugins []compiler.IaCPlugin) []compiler.IaCPlugin {
 	return filter.NewSimpleFilter[compiler.IaCPlugin](func(p compiler.IaCPlugin) bool {
-		return !strings.HasPrefix(p.Name(), "pulumi")
+		return !strings.HasPrefix(p.Name(), "pulumi") && p.Name() != compose.Plugin{}.Name()
 	}).Apply(plugins...)
 }
 
@@ -11,8 +11,13 @@ import (
 	"github.com/klothoplatform/klotho/pkg/engine"
 	envvar "github.com/klothoplatform/klotho/pkg/env_var"
 	execunit "github.com/klothoplatform/klotho/pkg/exec_unit"
+	exposeauth "github.com/klothoplatform/klotho/pkg/expose_auth"
+	exposedomain "github.com/klothoplatform/klotho/pkg/expose_domain"
+	"github.com/klothoplatform/klotho/pkg/infra/compose"
 	"github.com/klothoplatform/klotho/pkg/infra/iac2"
+	"github.com/klothoplatform/klotho/pkg/infra/manifests"
 	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/lang"
 	"github.com/klothoplatform/klotho/pkg/lang/csharp"
 	csRuntimes "github.com/klothoplatform/klotho/pkg/lang/csharp/runtimes"
 	"github.com/klothoplatform/klotho/pkg/lang/golang"
@@ -22,12 +27,15 @@ import (
 	"github.com/klothoplatform/klotho/pkg/lang/python"
 	pyRuntimes "github.com/klothoplatform/klotho/pkg/lang/python/runtimes"
 	"github.com/klothoplatform/klotho/pkg/multierr"
+	ormmigration "github.com/klothoplatform/klotho/pkg/orm_migration"
 	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/kubernetes"
 	kubernetesKb "github.com/klothoplatform/klotho/pkg/provider/kubernetes/knowledgebase"
 	"github.com/klothoplatform/klotho/pkg/provider/providers"
+	secretrotation "github.com/klothoplatform/klotho/pkg/secret_rotation"
 	staticunit "github.com/klothoplatform/klotho/pkg/static_unit"
 	"github.com/klothoplatform/klotho/pkg/visualizer"
+	"github.com/pkg/errors"
 )
 
 // PluginSetBuilder is a crude "plugin dependency" helper struct for managing the order of plugins via stages.
@@ -48,6 +56,10 @@ func (b *PluginSetBuilder) AddAll() error {
 		b.AddPython,
 		b.AddGo,
 		b.AddCSharp,
+		b.AddExposeAuth,
+		b.AddExposeDomain,
+		b.AddOrmMigrations,
+		b.AddSecretRotations,
 		b.AddPulumi,
 		b.AddVisualizerPlugin,
 		b.AddEngine,
@@ -66,17 +78,25 @@ func (b *PluginSetBuilder) AddEngine() error {
 	if err != nil {
 		return err
 	}
-	kb, err := knowledgebase.MergeKBs([]knowledgebase.EdgeKB{cloudkb, kubernetesKb.KubernetesKB})
-	if err != nil {
-		return err
-	}
-	kubernetesProvider := &kubernetes.KubernetesProvider{AppName: b.Cfg.AppName}
 	dockerProvider := &docker.DockerProvider{}
-	b.Engine = engine.NewEngine(map[string]provider.Provider{
-		cloudProvider.Name():      cloudProvider,
-		kubernetesProvider.Name(): kubernetesProvider,
-		dockerProvider.Name():     dockerProvider,
-	}, kb, types.ListAllConstructs())
+	providers := map[string]provider.Provider{
+		cloudProvider.Name():  cloudProvider,
+		dockerProvider.Name(): dockerProvider,
+	}
+	kb := cloudkb
+	// The local provider runs everything in its own containers and neither gcp nor azure has kubernetes resources to
+	// run them on, so kubernetes resources are not candidates for any of them. The kubernetes provider already is the
+	// provider of its own resources.
+	if cloudProvider.Name() != provider.LOCAL && cloudProvider.Name() != provider.GCP && cloudProvider.Name() != provider.AZURE &&
+		cloudProvider.Name() != provider.KUBERNETES {
+		kb, err = knowledgebase.MergeKBs([]knowledgebase.EdgeKB{cloudkb, kubernetesKb.KubernetesKB})
+		if err != nil {
+			return err
+		}
+		kubernetesProvider := &kubernetes.KubernetesProvider{AppName: b.Cfg.QualifiedAppName()}
+		providers[kubernetesProvider.Name()] = kubernetesProvider
+	}
+	b.Engine = engine.NewEngine(providers, kb, types.ListAllConstructs())
 	if b.GuardRails != nil {
 		err = b.Engine.LoadGuardrails(b.GuardRails)
 		if err != nil {
@@ -97,8 +117,8 @@ func (b *PluginSetBuilder) AddExecUnit() error {
 		execunit.ExecUnitPlugin{Config: b.Cfg},
 		// Configure executables and include assets after exec split
 		// to make sure all input files are in the proper units for the PostSplit plugins
-		javascript.NodeJSExecutable{},
-		python.PythonExecutable{},
+		javascript.NodeJSExecutable{Config: b.Cfg},
+		python.PythonExecutable{Config: b.Cfg},
 		golang.GolangExecutable{},
 		csharp.CSharpExecutable{Config: b.Cfg},
 		execunit.PruneUncategorizedFiles{},
@@ -119,6 +139,11 @@ func (b *PluginSetBuilder) AddJavascript() error {
 
 func (b *PluginSetBuilder) AddPython() error {
 	pyRuntime, err := pyRuntimes.GetRuntime(b.Cfg)
+	var unsupported *lang.UnsupportedProviderError
+	if errors.As(err, &unsupported) {
+		b.AnalysisAndTransform = append(b.AnalysisAndTransform, lang.UnsupportedExecutables{Type: types.ExecutableTypePython, Err: err})
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -129,6 +154,11 @@ func (b *PluginSetBuilder) AddPython() error {
 
 func (b *PluginSetBuilder) AddGo() error {
 	goRuntime, err := goRuntimes.GetRuntime(b.Cfg)
+	var unsupported *lang.UnsupportedProviderError
+	if errors.As(err, &unsupported) {
+		b.AnalysisAndTransform = append(b.AnalysisAndTransform, lang.UnsupportedExecutables{Type: types.ExecutableTypeGolang, Err: err})
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -139,6 +169,11 @@ func (b *PluginSetBuilder) AddGo() error {
 
 func (b *PluginSetBuilder) AddCSharp() error {
 	csRuntime, err := csRuntimes.GetRuntime(b.Cfg)
+	var unsupported *lang.UnsupportedProviderError
+	if errors.As(err, &unsupported) {
+		b.AnalysisAndTransform = append(b.AnalysisAndTransform, lang.UnsupportedExecutables{Type: types.ExecutableTypeCSharp, Err: err})
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -146,7 +181,41 @@ func (b *PluginSetBuilder) AddCSharp() error {
 	return nil
 }
 
+// AddExposeAuth adds the plugin which sets the auth of the gateways created by the language plugins, so it must be added after them.
+func (b *PluginSetBuilder) AddExposeAuth() error {
+	b.AnalysisAndTransform = append(b.AnalysisAndTransform, exposeauth.ExposeAuth{Config: b.Cfg})
+	return nil
+}
+
+// AddExposeDomain adds the plugin which sets the custom domains of the gateways created by the language plugins, so it must be added after them.
+func (b *PluginSetBuilder) AddExposeDomain() error {
+	b.AnalysisAndTransform = append(b.AnalysisAndTransform, exposedomain.ExposeDomain{Config: b.Cfg})
+	return nil
+}
+
+// AddOrmMigrations adds the plugin which adds the migrations of the orms created by the language plugins, so it must be added after them.
+func (b *PluginSetBuilder) AddOrmMigrations() error {
+	b.AnalysisAndTransform = append(b.AnalysisAndTransform, ormmigration.OrmMigrations{Config: b.Cfg})
+	return nil
+}
+
+// AddSecretRotations adds the plugin which configures the rotation of the secrets and orms created by the language plugins, so it must be added after them.
+func (b *PluginSetBuilder) AddSecretRotations() error {
+	b.AnalysisAndTransform = append(b.AnalysisAndTransform, secretrotation.SecretRotations{Config: b.Cfg})
+	return nil
+}
+
+// AddPulumi adds the plugins which generate the infrastructure of the application. The local provider runs it with
+// docker compose, and the kubernetes provider deploys it to an existing cluster with kubectl and helm, instead of pulumi.
 func (b *PluginSetBuilder) AddPulumi() error {
+	if b.Cfg.Provider == provider.LOCAL {
+		b.IaC = append(b.IaC, compose.Plugin{Config: b.Cfg})
+		return nil
+	}
+	if b.Cfg.Provider == provider.KUBERNETES {
+		b.IaC = append(b.IaC, manifests.Plugin{Config: b.Cfg})
+		return nil
+	}
 	b.IaC = append(b.IaC, iac2.ChartPlugin{Config: b.Cfg}, iac2.Plugin{Config: b.Cfg})
 	return nil
 }
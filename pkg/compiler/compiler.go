@@ -73,7 +73,7 @@ func (c *Compiler) Compile() error {
 		return err
 	}
 
-	c.Engine.LoadContext(c.Document.Constructs, make(map[constraints.ConstraintScope][]constraints.Constraint), c.Document.Configuration.AppName)
+	c.Engine.LoadContext(c.Document.Constructs, make(map[constraints.ConstraintScope][]constraints.Constraint), c.Document.Configuration.QualifiedAppName())
 
 	for _, p := range c.IaCPlugins {
 		// TODO logging
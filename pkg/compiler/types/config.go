@@ -10,8 +10,9 @@ import (
 
 type (
 	Config struct {
-		Name   string
-		Secret bool
+		Name     string
+		Secret   bool
+		Rotation *SecretRotation
 	}
 )
 
@@ -0,0 +1,27 @@
+package types
+
+import "strings"
+
+type (
+	// CustomDomain is a domain which a construct is served from over https, instead of the url generated by its provider
+	CustomDomain struct {
+		// Name is the fully qualified domain name, e.g. "api.example.com"
+		Name string
+		// HostedZone is the existing DNS zone which the domain's records are created in. If empty, it is the parent
+		// domain of Name, e.g. "example.com".
+		HostedZone string
+	}
+)
+
+// Zone returns the DNS zone which the domain's records are created in.
+func (d *CustomDomain) Zone() string {
+	if d.HostedZone != "" {
+		return strings.TrimSuffix(d.HostedZone, ".")
+	}
+	name := strings.TrimSuffix(d.Name, ".")
+	labels := strings.Split(name, ".")
+	if len(labels) <= 2 {
+		return name
+	}
+	return strings.Join(labels[1:], ".")
+}
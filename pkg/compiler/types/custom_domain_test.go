@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CustomDomainZone(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain CustomDomain
+		want   string
+	}{
+		{
+			name:   "subdomain",
+			domain: CustomDomain{Name: "api.example.com"},
+			want:   "example.com",
+		},
+		{
+			name:   "apex domain",
+			domain: CustomDomain{Name: "example.com"},
+			want:   "example.com",
+		},
+		{
+			name:   "explicit hosted zone",
+			domain: CustomDomain{Name: "api.dev.example.com", HostedZone: "example.com."},
+			want:   "example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.domain.Zone())
+		})
+	}
+}
@@ -62,6 +62,9 @@ var (
 	BUCKET_NAME            EnvironmentVariableValue = "bucket_name"
 	SECRET_NAME            EnvironmentVariableValue = "secret_name"
 	KV_DYNAMODB_TABLE_NAME EnvironmentVariableValue = "kv_dynamodb_table_name"
+	QUEUE_URL              EnvironmentVariableValue = "queue_url"
+	WEBSOCKET_ENDPOINT     EnvironmentVariableValue = "websocket_endpoint"
+	WORKFLOW_ARN           EnvironmentVariableValue = "workflow_arn"
 )
 
 var InternalStorageVariable = environmentVariable{
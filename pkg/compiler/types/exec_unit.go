@@ -45,6 +45,14 @@ type (
 		// SourceFiles is a set of paths to files in the Executable's owning ExecutionUnit that represent
 		// files that have been statically included for runtime use rather than active processing by the compiler.
 		StaticAssets map[string]struct{}
+
+		// InclusionReasons maps the paths of SourceFiles to a description of why each file was included
+		// (e.g. "entrypoint" or "imported by main.js"). It backs the `--explain-unit` report.
+		InclusionReasons map[string]string
+
+		// PrunedDependencies maps the paths of dependency manifests (e.g. package.json) to the dependencies
+		// that were removed from them because no source file in the Executable uses them.
+		PrunedDependencies map[string][]string
 	}
 
 	ExecutableType string
@@ -63,15 +71,22 @@ func ListAllConstructs() []construct.Construct {
 		&Config{},
 		&RedisCluster{},
 		&RedisNode{},
+		&Schedule{},
+		&Queue{},
+		&Workflow{},
+		&FsEvent{},
+		&OrmMigration{},
 	}
 }
 
 func NewExecutable() Executable {
 	return Executable{
-		Entrypoints:  map[string]struct{}{},
-		Resources:    map[string]struct{}{},
-		StaticAssets: map[string]struct{}{},
-		SourceFiles:  map[string]struct{}{},
+		Entrypoints:        map[string]struct{}{},
+		Resources:          map[string]struct{}{},
+		StaticAssets:       map[string]struct{}{},
+		SourceFiles:        map[string]struct{}{},
+		InclusionReasons:   map[string]string{},
+		PrunedDependencies: map[string][]string{},
 	}
 }
 
@@ -185,6 +200,9 @@ func (unit *ExecutionUnit) AddEntrypoint(f io.File) {
 		unit.files.Set(f.Path(), f)
 		unit.Executable.Entrypoints[f.Path()] = struct{}{}
 		unit.Executable.SourceFiles[f.Path()] = struct{}{}
+		if _, explained := unit.Executable.InclusionReasons[f.Path()]; !explained {
+			unit.Executable.SetInclusionReason(f.Path(), "entrypoint")
+		}
 	}
 }
 
@@ -251,6 +269,26 @@ func (e *Executable) RemoveFile(path string) {
 	delete(e.SourceFiles, path)
 	delete(e.Resources, path)
 	delete(e.StaticAssets, path)
+	delete(e.InclusionReasons, path)
+}
+
+// SetInclusionReason records why the file at path is part of the Executable.
+func (e *Executable) SetInclusionReason(path string, reason string) {
+	if e.InclusionReasons == nil {
+		e.InclusionReasons = make(map[string]string)
+	}
+	e.InclusionReasons[path] = reason
+}
+
+// AddPrunedDependencies records the dependencies removed from the manifest at path.
+func (e *Executable) AddPrunedDependencies(path string, dependencies ...string) {
+	if len(dependencies) == 0 {
+		return
+	}
+	if e.PrunedDependencies == nil {
+		e.PrunedDependencies = make(map[string][]string)
+	}
+	e.PrunedDependencies[path] = append(e.PrunedDependencies[path], dependencies...)
 }
 
 func InSameExecutionUnit(a, b *SourceFile) bool {
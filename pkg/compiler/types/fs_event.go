@@ -0,0 +1,105 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/pkg/errors"
+)
+
+type (
+	// FsEvent delivers the events of the persisted Fs with the same name to the functions subscribed to them.
+	FsEvent struct {
+		// Name is the id of the Fs whose events are delivered
+		Name          string
+		Subscriptions []FsSubscription
+	}
+
+	// FsSubscription is a function within an execution unit that is called for each matching event of an Fs.
+	FsSubscription struct {
+		ExecUnitName string
+		// ModuleName is the path of the module which defines the subscribed function, relative to the unit's root
+		ModuleName string
+		// FunctionName is the name of the subscribed function within ModuleName
+		FunctionName string
+		// Events are the kinds of event the function is called for: FsEventWrite and/or FsEventDelete
+		Events []string
+		// Prefix and Suffix limit the events to the files whose paths start and end with them
+		Prefix string
+		Suffix string
+	}
+)
+
+const (
+	FS_EVENT_TYPE = "fs_event"
+
+	FsEventWrite  = "write"
+	FsEventDelete = "delete"
+
+	FsEventEventsDirective = "events"
+	FsEventPrefixDirective = "prefix"
+	FsEventSuffixDirective = "suffix"
+)
+
+func (p *FsEvent) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     FS_EVENT_TYPE,
+		Name:     p.Name,
+	}
+}
+
+func (p *FsEvent) AnnotationCapability() string {
+	return annotation.FsEventCapability
+}
+
+func (p *FsEvent) Functionality() construct.Functionality {
+	return construct.Messaging
+}
+
+func (p *FsEvent) Attributes() map[string]any {
+	return map[string]any{
+		"fs_event": nil,
+	}
+}
+
+// Id identifies the subscription among those of its Fs. Events carry it so that they are passed to the subscribed function.
+func (s FsSubscription) Id() string {
+	return fmt.Sprintf("%s#%s", s.ModuleName, s.FunctionName)
+}
+
+// Subscribe adds the subscription, returning an error if its function is already subscribed to the Fs.
+func (p *FsEvent) Subscribe(sub FsSubscription) error {
+	for _, existing := range p.Subscriptions {
+		if existing.Id() == sub.Id() {
+			return errors.Errorf("function '%s' is already subscribed to the events of fs '%s'", sub.Id(), p.Name)
+		}
+	}
+	p.Subscriptions = append(p.Subscriptions, sub)
+	return nil
+}
+
+// NewFsSubscriptionFromAnnotation creates an FsSubscription from the directives of a `@klotho::fs_event` annotation,
+// whose id is the id of the subscribed Fs. Events default to FsEventWrite.
+// The caller is responsible for setting the unit, module and function that is subscribed.
+func NewFsSubscriptionFromAnnotation(cap *annotation.Capability) (FsSubscription, error) {
+	sub := FsSubscription{Events: []string{FsEventWrite}}
+	if cap.ID == "" {
+		return sub, errors.New("'id' is required and must be the id of a persisted fs")
+	}
+	if events, ok := cap.Directives.StringArray(FsEventEventsDirective); ok {
+		if len(events) == 0 {
+			return sub, errors.Errorf("'%s' must not be empty", FsEventEventsDirective)
+		}
+		for _, event := range events {
+			if event != FsEventWrite && event != FsEventDelete {
+				return sub, errors.Errorf("invalid event '%s': expected '%s' or '%s'", event, FsEventWrite, FsEventDelete)
+			}
+		}
+		sub.Events = events
+	}
+	sub.Prefix, _ = cap.Directives.String(FsEventPrefixDirective)
+	sub.Suffix, _ = cap.Directives.String(FsEventSuffixDirective)
+	return sub, nil
+}
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/construct"
 )
@@ -12,8 +15,30 @@ type (
 		// Map of gateway targets with the exec unit name as the key
 		DefinedIn     string
 		ExportVarName string
+		// Framework is the web framework that the exported app was created with (e.g. "express", "fastify", "koa").
+		// An empty value is treated as the language's default framework.
+		Framework string
+		// Auth is how requests to the gateway's routes are authorized. A nil value leaves the routes public.
+		Auth *GatewayAuth
+		// RouteAuth overrides Auth for individual routes. Keys are a route path (e.g. "/users/:id"),
+		// optionally prefixed by a verb (e.g. "POST /users").
+		RouteAuth map[string]*GatewayAuth
+		// Domain is the custom domain which the gateway is served from, if any
+		Domain *CustomDomain
+	}
+
+	// GatewayAuth declares how requests to a gateway are authorized
+	GatewayAuth struct {
+		Type GatewayAuthType
+		// Issuer and Audience are the expected claims of the tokens of a `jwt` authorizer
+		Issuer   string
+		Audience []string
+		// ExecUnitName is the execution unit which authorizes requests for a `lambda` authorizer
+		ExecUnitName string
 	}
 
+	GatewayAuthType string
+
 	Route struct {
 		// Path should be expressed using Express's route path syntax or a subset thereof
 		// (see: http://expressjs.com/en/4x/api.html#path-examples)
@@ -44,6 +69,17 @@ const (
 	VerbHead    = Verb("HEAD")
 
 	GATEWAY_TYPE = "expose"
+
+	// GatewayAuthNone explicitly leaves a route public, for example to override the gateway's auth
+	GatewayAuthNone = GatewayAuthType("none")
+	// GatewayAuthIam requires requests to be signed with cloud provider credentials
+	GatewayAuthIam = GatewayAuthType("iam")
+	// GatewayAuthJwt requires a bearer token from an external identity provider
+	GatewayAuthJwt = GatewayAuthType("jwt")
+	// GatewayAuthCognito requires a bearer token from a user pool created for the gateway
+	GatewayAuthCognito = GatewayAuthType("cognito")
+	// GatewayAuthLambda authorizes requests by invoking another execution unit
+	GatewayAuthLambda = GatewayAuthType("lambda")
 )
 
 var (
@@ -83,7 +119,9 @@ func (p *Gateway) Functionality() construct.Functionality {
 }
 
 func (p *Gateway) Attributes() map[string]any {
-	return map[string]any{}
+	return map[string]any{
+		"rest": nil,
+	}
 }
 
 func (gw *Gateway) AddRoute(route Route, unit *ExecutionUnit) string {
@@ -96,3 +134,92 @@ func (gw *Gateway) AddRoute(route Route, unit *ExecutionUnit) string {
 	gw.Routes = append(gw.Routes, route)
 	return ""
 }
+
+// AuthFor returns the auth of the route with the given path and verb, or nil if the route is public.
+func (gw *Gateway) AuthFor(path string, verb Verb) *GatewayAuth {
+	auth := gw.Auth
+	if routeAuth, ok := gw.RouteAuth[path]; ok {
+		auth = routeAuth
+	}
+	if routeAuth, ok := gw.RouteAuth[fmt.Sprintf("%s %s", strings.ToUpper(verb.String()), path)]; ok {
+		auth = routeAuth
+	}
+	if auth == nil || auth.Type == GatewayAuthNone {
+		return nil
+	}
+	return auth
+}
+
+// Validate returns an error if the auth is missing settings required by its type.
+func (auth *GatewayAuth) Validate() error {
+	switch auth.Type {
+	case GatewayAuthNone, GatewayAuthIam, GatewayAuthCognito:
+	case GatewayAuthJwt:
+		if auth.Issuer == "" || len(auth.Audience) == 0 {
+			return fmt.Errorf("'%s' auth requires an issuer and audience", auth.Type)
+		}
+	case GatewayAuthLambda:
+		if auth.ExecUnitName == "" {
+			return fmt.Errorf("'%s' auth requires an execution_unit", auth.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported auth type '%s'", auth.Type)
+	}
+	return nil
+}
+
+type (
+	// WebSocketGateway exposes the WebSocket handler of an `@klotho::expose` app. It is separate from the app's Gateway
+	// because providers serve WebSocket connections from a different kind of API than HTTP routes.
+	WebSocketGateway struct {
+		Name string
+		// Path is the path the handler was registered on. Providers may serve the handler from a single endpoint regardless of its path.
+		Path         string
+		ExecUnitName string
+		// HandledInFile is the path to the file which the handler is defined in
+		HandledInFile string
+		// Library is the library that the handler was created with (e.g. "ws", "fastapi").
+		Library string
+		// ModuleName and FunctionName identify the function which handles each connection, for libraries
+		// which register the handler as a function (e.g. FastAPI). They are empty for server objects (e.g. ws).
+		ModuleName   string
+		FunctionName string
+	}
+)
+
+const (
+	WEBSOCKET_GATEWAY_TYPE    = "expose_websocket"
+	WEBSOCKET_ENDPOINT_SUFFIX = "_WEBSOCKET_ENDPOINT"
+)
+
+func (gw *WebSocketGateway) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     WEBSOCKET_GATEWAY_TYPE,
+		Name:     gw.Name,
+	}
+}
+
+func (gw *WebSocketGateway) AnnotationCapability() string {
+	return annotation.ExposeCapability
+}
+
+func (gw *WebSocketGateway) Functionality() construct.Functionality {
+	return construct.Api
+}
+
+func (gw *WebSocketGateway) Attributes() map[string]any {
+	return map[string]any{
+		"websocket": nil,
+	}
+}
+
+// WebSocketEndpointEnvVarName is the name of the environment variable that holds the url which units post messages
+// to the clients of the WebSocket gateway with the given name to.
+func WebSocketEndpointEnvVarName(name string) string {
+	return GenerateWebSocketEndpointEnvVar(&WebSocketGateway{Name: name}).Name
+}
+
+func GenerateWebSocketEndpointEnvVar(cfg construct.Construct) environmentVariable {
+	return NewEnvironmentVariable(fmt.Sprintf("%s%s", strings.ToUpper(cfg.Id().Name), WEBSOCKET_ENDPOINT_SUFFIX), cfg, string(WEBSOCKET_ENDPOINT))
+}
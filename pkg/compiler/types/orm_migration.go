@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/pkg/errors"
+)
+
+type (
+	// OrmMigration runs the schema migrations of the Orm with the same name, from the image of the execution unit it
+	// depends on, before that unit is updated.
+	OrmMigration struct {
+		// Name is the id of the Orm whose schema is migrated
+		Name string
+		Tool string
+		// Command is the shell command which applies the migrations
+		Command string
+	}
+)
+
+const (
+	ORM_MIGRATION_TYPE = "orm_migration"
+
+	MigrationToolAlembic       = "alembic"
+	MigrationToolPrisma        = "prisma"
+	MigrationToolTypeOrm       = "typeorm"
+	MigrationToolEfCore        = "efcore"
+	MigrationToolGolangMigrate = "golang-migrate"
+)
+
+// migrationCommands are the default commands of each migration tool, given the environment variable which holds the
+// connection string of the migrated Orm
+var migrationCommands = map[string]func(connEnvVar string) string{
+	MigrationToolAlembic: func(string) string {
+		return "alembic upgrade head"
+	},
+	MigrationToolPrisma: func(connEnvVar string) string {
+		return fmt.Sprintf(`DATABASE_URL="$%s" npx prisma migrate deploy`, connEnvVar)
+	},
+	MigrationToolTypeOrm: func(string) string {
+		return "npx typeorm migration:run -d data-source.js"
+	},
+	MigrationToolEfCore: func(connEnvVar string) string {
+		return fmt.Sprintf(`./efbundle --connection "$%s"`, connEnvVar)
+	},
+	MigrationToolGolangMigrate: func(connEnvVar string) string {
+		return fmt.Sprintf(`migrate -path migrations -database "$%s" up`, connEnvVar)
+	},
+}
+
+// NewOrmMigration creates the OrmMigration of the orm, which runs `command` or, if it is empty, the default command of
+// the tool. The default commands read the orm's connection string from the environment variable Klotho injects for it.
+func NewOrmMigration(orm *Orm, tool string, command string) (*OrmMigration, error) {
+	defaultCommand, ok := migrationCommands[tool]
+	if !ok {
+		return nil, errors.Errorf("unsupported migration tool '%s': expected one of '%s', '%s', '%s', '%s' or '%s'", tool,
+			MigrationToolAlembic, MigrationToolPrisma, MigrationToolTypeOrm, MigrationToolEfCore, MigrationToolGolangMigrate)
+	}
+	if command == "" {
+		command = defaultCommand(GenerateOrmConnStringEnvVar(orm).GetName())
+	}
+	return &OrmMigration{
+		Name:    orm.Name,
+		Tool:    tool,
+		Command: command,
+	}, nil
+}
+
+func (p *OrmMigration) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     ORM_MIGRATION_TYPE,
+		Name:     p.Name,
+	}
+}
+
+func (p *OrmMigration) AnnotationCapability() string {
+	return annotation.PersistCapability
+}
+
+func (p *OrmMigration) Functionality() construct.Functionality {
+	return construct.Compute
+}
+
+func (p *OrmMigration) Attributes() map[string]any {
+	return map[string]any{
+		"orm_migration": nil,
+	}
+}
@@ -10,8 +10,9 @@ import (
 
 type (
 	Secrets struct {
-		Name    string
-		Secrets []string
+		Name     string
+		Secrets  []string
+		Rotation *SecretRotation
 	}
 
 	Fs struct {
@@ -24,6 +25,8 @@ type (
 
 	Orm struct {
 		Name string
+		// Rotation rotates the credentials of the orm's database
+		Rotation *SecretRotation
 	}
 
 	RedisNode struct {
@@ -37,6 +40,12 @@ type (
 
 const (
 	KLOTHO_KV_DYNAMODB_TABLE_NAME = "KLOTHO_KV_DYNAMODB_TABLE_NAME"
+	// KLOTHO_SECRETS_BACKEND is set to KLOTHO_SECRETS_BACKEND_SSM for the execution units which read their secrets from
+	// SSM Parameter Store instead of Secrets Manager, or to KLOTHO_SECRETS_BACKEND_FILE for those which read them from
+	// files mounted into their container
+	KLOTHO_SECRETS_BACKEND      = "KLOTHO_SECRETS_BACKEND"
+	KLOTHO_SECRETS_BACKEND_SSM  = "ssm"
+	KLOTHO_SECRETS_BACKEND_FILE = "file"
 
 	SECRETS_TYPE       = "secrets"
 	FS_TYPE            = "fs"
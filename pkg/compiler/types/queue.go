@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+type (
+	// Queue is a work queue which execution units send messages to, and which delivers each message to a single consumer function.
+	Queue struct {
+		Name string
+		// Fifo preserves the order of messages and delivers each message exactly once
+		Fifo bool
+		// VisibilityTimeout is the number of seconds that a received message is hidden from other receivers while it is processed
+		VisibilityTimeout int
+		// BatchSize is the maximum number of messages delivered to the consumer at once
+		BatchSize int
+		// MaxReceiveCount is the number of times a message is received before it is moved to the dead-letter queue.
+		// A value of 0 disables the dead-letter queue.
+		MaxReceiveCount int
+		// Consumer is the function that messages are delivered to, if any
+		Consumer *QueueConsumer
+	}
+
+	// QueueConsumer is a function within an execution unit that processes the messages of a Queue.
+	QueueConsumer struct {
+		ExecUnitName string
+		// ModuleName is the path of the module which defines the consumer function, relative to the unit's root
+		ModuleName string
+		// FunctionName is the name of the consumer function within ModuleName
+		FunctionName string
+	}
+)
+
+const (
+	QUEUE_TYPE = "queue"
+
+	QUEUE_URL_SUFFIX = "_QUEUE_URL"
+)
+
+func (q *Queue) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     QUEUE_TYPE,
+		Name:     q.Name,
+	}
+}
+
+func (q *Queue) AnnotationCapability() string {
+	return annotation.QueueCapability
+}
+
+func (q *Queue) Functionality() construct.Functionality {
+	return construct.Messaging
+}
+
+func (q *Queue) Attributes() map[string]any {
+	return map[string]any{
+		"queue": nil,
+	}
+}
+
+// QueueUrlEnvVarName is the name of the environment variable that holds the url of the queue with the given id.
+func QueueUrlEnvVarName(id string) string {
+	return GenerateQueueUrlEnvVar(&Queue{Name: id}).Name
+}
+
+func GenerateQueueUrlEnvVar(cfg construct.Construct) environmentVariable {
+	return NewEnvironmentVariable(fmt.Sprintf("%s%s", strings.ToUpper(cfg.Id().Name), QUEUE_URL_SUFFIX), cfg, string(QUEUE_URL))
+}
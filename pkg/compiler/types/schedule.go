@@ -0,0 +1,103 @@
+package types
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Schedule triggers a function within an execution unit on a recurring schedule.
+	Schedule struct {
+		Name string
+		// Cron is a cron expression with either 5 fields (minute hour day-of-month month day-of-week)
+		// or 6 fields (the 5 standard fields followed by year).
+		Cron string
+		// Rate is a fixed interval between invocations, for example "5 minutes".
+		Rate string
+		// ExecUnitName is the execution unit which runs the scheduled function
+		ExecUnitName string
+		// ModuleName is the path of the module which defines the scheduled function, relative to the unit's root
+		ModuleName string
+		// FunctionName is the name of the scheduled function within ModuleName
+		FunctionName string
+	}
+)
+
+const (
+	SCHEDULE_TYPE = "schedule"
+
+	ScheduleCronDirective = "cron"
+	ScheduleRateDirective = "rate"
+)
+
+var rateExpression = regexp.MustCompile(`^(\d+)\s+(minute|minutes|hour|hours|day|days)$`)
+
+func (p *Schedule) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     SCHEDULE_TYPE,
+		Name:     p.Name,
+	}
+}
+
+func (p *Schedule) AnnotationCapability() string {
+	return annotation.ScheduleCapability
+}
+
+func (p *Schedule) Functionality() construct.Functionality {
+	return construct.Messaging
+}
+
+func (p *Schedule) Attributes() map[string]any {
+	return map[string]any{
+		"schedule": nil,
+	}
+}
+
+// NewScheduleFromAnnotation creates a Schedule from the `cron` or `rate` directive of a `@klotho::schedule` annotation.
+// The caller is responsible for setting the unit, module and function that the schedule targets.
+func NewScheduleFromAnnotation(cap *annotation.Capability) (*Schedule, error) {
+	if cap.ID == "" {
+		return nil, errors.New("'id' is required")
+	}
+	cron, hasCron := cap.Directives.String(ScheduleCronDirective)
+	rate, hasRate := cap.Directives.String(ScheduleRateDirective)
+	switch {
+	case hasCron && hasRate:
+		return nil, errors.Errorf("only one of '%s' or '%s' may be specified", ScheduleCronDirective, ScheduleRateDirective)
+	case !hasCron && !hasRate:
+		return nil, errors.Errorf("one of '%s' or '%s' is required", ScheduleCronDirective, ScheduleRateDirective)
+	}
+
+	s := &Schedule{Name: cap.ID}
+	if hasCron {
+		s.Cron = strings.Join(strings.Fields(cron), " ")
+		if fields := len(strings.Fields(s.Cron)); fields != 5 && fields != 6 {
+			return nil, errors.Errorf("invalid cron expression '%s': expected 5 or 6 fields, got %d", cron, fields)
+		}
+	} else {
+		s.Rate = strings.Join(strings.Fields(rate), " ")
+		if _, _, err := ParseRate(s.Rate); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ParseRate splits a rate expression such as "5 minutes" into its value and singular unit ("minute", "hour" or "day").
+func ParseRate(rate string) (int, string, error) {
+	match := rateExpression.FindStringSubmatch(rate)
+	if match == nil {
+		return 0, "", errors.Errorf("invalid rate expression '%s': expected '<value> <minutes|hours|days>'", rate)
+	}
+	value, err := strconv.Atoi(match[1])
+	if err != nil || value <= 0 {
+		return 0, "", errors.Errorf("invalid rate expression '%s': value must be a positive integer", rate)
+	}
+	return value, strings.TrimSuffix(match[2], "s"), nil
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"github.com/pkg/errors"
+)
+
+type (
+	// SecretRotation rotates the secret of a construct every AfterDays days, using the lambda function LambdaArn.
+	// An empty LambdaArn is only valid for the credentials of an Orm, which are rotated by the function the provider
+	// hosts for the orm's database engine.
+	SecretRotation struct {
+		AfterDays int
+		LambdaArn string
+	}
+)
+
+// maxRotationDays is the longest interval between rotations that secrets can be configured with
+const maxRotationDays = 1000
+
+// NewSecretRotation validates and creates the rotation of a secret. `requireLambda` is whether the secret can only be
+// rotated by a user-provided lambda function.
+func NewSecretRotation(days int, lambdaArn string, requireLambda bool) (*SecretRotation, error) {
+	if days < 1 || days > maxRotationDays {
+		return nil, errors.Errorf("rotation days must be between 1 and %d, not %d", maxRotationDays, days)
+	}
+	if requireLambda && lambdaArn == "" {
+		return nil, errors.New("rotation requires the lambda_arn of the function which rotates the secret")
+	}
+	return &SecretRotation{
+		AfterDays: days,
+		LambdaArn: lambdaArn,
+	}, nil
+}
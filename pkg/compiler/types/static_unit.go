@@ -16,6 +16,8 @@ type (
 		IndexDocument string
 		StaticFiles   async.ConcurrentMap[string, io.File]
 		SharedFiles   async.ConcurrentMap[string, io.File]
+		// Domain is the custom domain which the unit's files are served from, if any
+		Domain *CustomDomain
 	}
 )
 
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+type (
+	// Workflow is an orchestrator function whose awaited calls to the functions of other execution units are the
+	// workflow's steps. The steps run in order, and each step is passed either the result of the previous one or the
+	// input of the workflow.
+	Workflow struct {
+		Name string
+		// ExecUnitName is the execution unit which defines the orchestrator function, and which starts the workflow
+		ExecUnitName string
+		// ModuleName is the path of the module which defines the orchestrator function, relative to the unit's root
+		ModuleName string
+		// FunctionName is the name of the orchestrator function within ModuleName
+		FunctionName string
+		Steps        []WorkflowStep
+	}
+
+	// WorkflowStep is a call of a Workflow to a function within another execution unit.
+	WorkflowStep struct {
+		ExecUnitName string
+		// ModuleName is the path of the module which defines the step's function, relative to the unit's root
+		ModuleName string
+		// FunctionName is the name of the step's function within ModuleName
+		FunctionName string
+		// TakesWorkflowInput is whether the step is passed the input of the workflow instead of the result of the
+		// previous step
+		TakesWorkflowInput bool
+	}
+)
+
+const (
+	WORKFLOW_TYPE = "workflow"
+
+	WORKFLOW_ARN_SUFFIX = "_WORKFLOW_ARN"
+)
+
+func (w *Workflow) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: construct.AbstractConstructProvider,
+		Type:     WORKFLOW_TYPE,
+		Name:     w.Name,
+	}
+}
+
+func (w *Workflow) AnnotationCapability() string {
+	return annotation.WorkflowCapability
+}
+
+func (w *Workflow) Functionality() construct.Functionality {
+	return construct.Compute
+}
+
+func (w *Workflow) Attributes() map[string]any {
+	return map[string]any{
+		"workflow": nil,
+	}
+}
+
+// StepName returns the name of the step at index i, which is unique within the workflow.
+func (w *Workflow) StepName(i int) string {
+	return fmt.Sprintf("%d-%s-%s", i+1, w.Steps[i].ExecUnitName, w.Steps[i].FunctionName)
+}
+
+// WorkflowArnEnvVarName is the name of the environment variable that holds the arn of the workflow with the given id.
+func WorkflowArnEnvVarName(id string) string {
+	return GenerateWorkflowArnEnvVar(&Workflow{Name: id}).Name
+}
+
+func GenerateWorkflowArnEnvVar(cfg construct.Construct) environmentVariable {
+	return NewEnvironmentVariable(fmt.Sprintf("%s%s", strings.ToUpper(cfg.Id().Name), WORKFLOW_ARN_SUFFIX), cfg, string(WORKFLOW_ARN))
+}
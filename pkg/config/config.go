@@ -38,7 +38,22 @@ type (
 		PersistRedisNode    map[string]*Persist             `json:"persist_redis_node,omitempty" yaml:"persist_redis_node,omitempty" toml:"persist_redis_node,omitempty"`
 		PersistRedisCluster map[string]*Persist             `json:"persist_redis_cluster,omitempty" yaml:"persist_redis_cluster,omitempty" toml:"persist_redis_cluster,omitempty"`
 		Config              map[string]*Config              `json:"config,omitempty" yaml:"config,omitempty" toml:"config,omitempty"`
+		Queues              map[string]*Queue               `json:"queues,omitempty" yaml:"queues,omitempty" toml:"queues,omitempty"`
 		Imports             map[construct.ResourceId]string `json:"imports,omitempty" yaml:"imports,omitempty" toml:"imports,omitempty"`
+		Network             *Network                        `json:"network,omitempty" yaml:"network,omitempty" toml:"network,omitempty"`
+		Observability       *Observability                  `json:"observability,omitempty" yaml:"observability,omitempty" toml:"observability,omitempty"`
+		Regions             *Regions                        `json:"regions,omitempty" yaml:"regions,omitempty" toml:"regions,omitempty"`
+
+		// Tags are applied to every taggable resource of the application, in addition to the tags which identify its
+		// application and environment
+		Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty"`
+		// Naming is the template which the application's resources are named by, such as "{{app}}-{{env}}-{{type}}-{{name}}"
+		Naming string `json:"naming,omitempty" yaml:"naming,omitempty" toml:"naming,omitempty"`
+
+		// Environment is the environment which the application is compiled for, whose overrides in Environments have
+		// been applied to the rest of the configuration
+		Environment  string                          `json:"environment,omitempty" yaml:"environment,omitempty" toml:"environment,omitempty"`
+		Environments map[string]EnvironmentOverrides `json:"environments,omitempty" yaml:"environments,omitempty" toml:"environments,omitempty"`
 	}
 
 	ContentDeliveryNetwork struct {
@@ -61,6 +76,7 @@ type (
 		PersistRedisCluster KindDefaults `json:"persist_redis_cluster,omitempty" yaml:"persist_redis_cluster,omitempty" toml:"persist_redis_cluster,omitempty"`
 		PubSub              KindDefaults `json:"pubsub" yaml:"pubsub" toml:"pubsub"`
 		Config              KindDefaults `json:"config" yaml:"config" toml:"config"`
+		Queue               KindDefaults `json:"queue" yaml:"queue" toml:"queue"`
 	}
 
 	KindDefaults struct {
@@ -100,6 +116,9 @@ func (appCfg *Application) EnsureMapsExist() {
 	if appCfg.Config == nil {
 		appCfg.Config = make(map[string]*Config)
 	}
+	if appCfg.Queues == nil {
+		appCfg.Queues = make(map[string]*Queue)
+	}
 }
 
 func ReadConfig(fpath string) (Application, error) {
@@ -193,6 +212,10 @@ func (a Application) GetResourceType(resource construct.Construct) string {
 	case *types.Config:
 		cfg := a.GetConfig(resource.Id().Name)
 		return cfg.Type
+
+	case *types.Queue:
+		cfg := a.GetQueue(resource.Id().Name)
+		return cfg.Type
 	}
 	return ""
 }
@@ -236,6 +259,10 @@ func (a *Application) UpdateForResources(res []construct.Construct) {
 		case *types.RedisNode:
 			cfg := a.GetPersistRedisNode(r.Id().Name)
 			a.PersistRedisNode[r.Id().Name] = &cfg
+
+		case *types.Queue:
+			cfg := a.GetQueue(r.Id().Name)
+			a.Queues[r.Id().Name] = &cfg
 		}
 	}
 }
@@ -278,6 +305,7 @@ func (a *Application) MergeDefaults(other Defaults) {
 	a.Defaults.PubSub.ApplyDefaults(other.PubSub)
 	a.Defaults.StaticUnit.ApplyDefaults(other.StaticUnit)
 	a.Defaults.Config.ApplyDefaults(other.Config)
+	a.Defaults.Queue.ApplyDefaults(other.Queue)
 }
 
 func (cfg *KindDefaults) ApplyDefaults(dflt KindDefaults) {
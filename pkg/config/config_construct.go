@@ -6,6 +6,8 @@ type (
 		Type        string      `json:"type" yaml:"type" toml:"type"`
 		Path        string      `json:"path,omitempty" yaml:"path,omitempty" toml:"path,omitempty"`
 		InfraParams InfraParams `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
+		// Rotation configures the automatic rotation of a secret config resource
+		Rotation *SecretRotation `json:"rotation,omitempty" yaml:"rotation,omitempty" toml:"rotation,omitempty"`
 	}
 )
 
@@ -20,6 +22,7 @@ func (a Application) GetConfig(id string) Config {
 		overrideValue(&cfg.Type, ecfg.Type)
 		overrideValue(&cfg.Path, ecfg.Path)
 		cfg.InfraParams = ecfg.InfraParams
+		cfg.Rotation = ecfg.Rotation
 	}
 	cfg.InfraParams.ApplyDefaults(a.Defaults.Config.InfraParamsByType[cfg.Type])
 
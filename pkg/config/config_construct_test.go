@@ -109,6 +109,26 @@ func Test_GetConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "get config with rotation",
+			cfg: Application{
+				Defaults: Defaults{
+					Config: KindDefaults{
+						Type: "secrets_manager",
+					},
+				},
+				Config: map[string]*Config{
+					"test": {
+						Rotation: &SecretRotation{Days: 30, LambdaArn: "arn:aws:lambda:us-east-1:123456789012:function:rotate"},
+					},
+				},
+			},
+			id: "test",
+			want: Config{
+				Type:     "secrets_manager",
+				Rotation: &SecretRotation{Days: 30, LambdaArn: "arn:aws:lambda:us-east-1:123456789012:function:rotate"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// EnvironmentOverrides layers the configuration of an environment onto that of the application. It holds the
+	// fields of the application's configuration which the environment overrides, named as they are in the configuration
+	// (such as `execution_units` or `defaults`). Only the fields which it sets replace those of the application's, and
+	// nested fields are overridden individually, so that an environment which sets the desired count of a unit keeps the
+	// rest of the unit's configuration.
+	EnvironmentOverrides map[string]any
+)
+
+// environmentOnlyFields are the fields of the application which an environment cannot override, since they identify
+// the application and its environments
+var environmentOnlyFields = []string{"app", "environment", "environments"}
+
+// EnvironmentNames returns the names of the environments which the application defines, in sorted order.
+func (a Application) EnvironmentNames() []string {
+	var names []string
+	for name := range a.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyEnvironment resolves the configuration of the application for the environment named env, layering the
+// environment's overrides onto the application's configuration. Since the resources are named after the environment
+// (see QualifiedAppName), the configuration, and so each compilation, is of a single environment, whose stack config
+// is output alongside its program. To compile every environment at once, use the `--all-envs` flag, which compiles
+// each into a directory of its own.
+func (a *Application) ApplyEnvironment(env string) error {
+	overrides, ok := a.Environments[env]
+	if !ok {
+		return fmt.Errorf("environment %s is not defined in the config (defined environments: %s)", env, strings.Join(a.EnvironmentNames(), ", "))
+	}
+	for _, field := range environmentOnlyFields {
+		if _, ok := overrides[field]; ok {
+			return fmt.Errorf("environment %s cannot override '%s'", env, field)
+		}
+	}
+
+	base, err := toFieldMap(a)
+	if err != nil {
+		return fmt.Errorf("could not read the config to apply environment %s: %w", env, err)
+	}
+	// round trip the overrides so that they do not share any maps with the config they were read from
+	resolved, err := toFieldMap(overrides)
+	if err != nil {
+		return fmt.Errorf("could not read the overrides of environment %s: %w", env, err)
+	}
+	merge(resolved, base, 0)
+
+	content, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	var resolvedCfg Application
+	if err := json.Unmarshal(content, &resolvedCfg); err != nil {
+		return fmt.Errorf("invalid overrides of environment %s: %w", env, err)
+	}
+	resolvedCfg.Format = a.Format
+	resolvedCfg.Environment = env
+	resolvedCfg.EnsureMapsExist()
+	*a = resolvedCfg
+	return nil
+}
+
+// QualifiedAppName is the name which the application's resources are named after. It is qualified by the environment
+// the application is compiled for, so that the resources of each environment can be deployed alongside each other.
+func (a Application) QualifiedAppName() string {
+	if a.Environment == "" {
+		return a.AppName
+	}
+	return fmt.Sprintf("%s-%s", a.AppName, a.Environment)
+}
+
+// StackName is the name of the stack which the application's infrastructure is deployed with. Each environment is
+// deployed by its own stack of the application's project.
+func (a Application) StackName() string {
+	if a.Environment == "" {
+		return a.AppName
+	}
+	return a.Environment
+}
+
+func toFieldMap(v any) (map[string]any, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	err = json.Unmarshal(content, &fields)
+	return fields, err
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const environmentsConfig = `
+app: my-app
+provider: aws
+defaults:
+  execution_unit:
+    type: ecs
+execution_units:
+  main:
+    type: ecs
+    environment_variables:
+      LOG_LEVEL: debug
+    infra_params:
+      desired_count: 1
+      memory: 512
+exposed:
+  api:
+    type: apigateway
+environments:
+  prod:
+    execution_units:
+      main:
+        infra_params:
+          desired_count: 3
+    exposed:
+      api:
+        domain: api.example.com
+  dev:
+    defaults:
+      execution_unit:
+        type: lambda
+  invalid:
+    app: other-app
+`
+
+func Test_ApplyEnvironment(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		check   func(assert *assert.Assertions, cfg Application)
+		wantErr bool
+	}{
+		{
+			name: "overrides nested fields",
+			env:  "prod",
+			check: func(assert *assert.Assertions, cfg Application) {
+				assert.Equal("prod", cfg.Environment)
+				assert.Equal("my-app", cfg.AppName)
+				unit := cfg.GetExecutionUnit("main")
+				assert.Equal("ecs", unit.Type)
+				assert.Equal(map[string]string{"LOG_LEVEL": "debug"}, unit.EnvironmentVariables)
+				assert.Equal(float64(3), unit.InfraParams["desired_count"])
+				assert.Equal(float64(512), unit.InfraParams["memory"])
+				assert.Equal("api.example.com", cfg.GetExpose("api").Domain)
+				assert.Equal("apigateway", cfg.GetExpose("api").Type)
+			},
+		},
+		{
+			name: "overrides defaults",
+			env:  "dev",
+			check: func(assert *assert.Assertions, cfg Application) {
+				assert.Equal("lambda", cfg.Defaults.ExecutionUnit.Type)
+				assert.Equal("ecs", cfg.GetExecutionUnit("main").Type)
+			},
+		},
+		{
+			name:    "cannot override app",
+			env:     "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "undefined environment",
+			env:     "staging",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg, err := ReadConfigReader("klotho.yaml", strings.NewReader(environmentsConfig))
+			if !assert.NoError(err) {
+				return
+			}
+			err = cfg.ApplyEnvironment(tt.env)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal("yaml", cfg.Format)
+			tt.check(assert, cfg)
+		})
+	}
+}
+
+func Test_QualifiedAppName(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Application{AppName: "my-app"}
+	assert.Equal("my-app", cfg.QualifiedAppName())
+	assert.Equal("my-app", cfg.StackName())
+
+	cfg.Environment = "prod"
+	assert.Equal("my-app-prod", cfg.QualifiedAppName())
+	assert.Equal("prod", cfg.StackName())
+}
+
+func Test_EnvironmentNames(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg, err := ReadConfigReader("klotho.yaml", strings.NewReader(environmentsConfig))
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal([]string{"dev", "invalid", "prod"}, cfg.EnvironmentNames())
+	assert.Empty(Application{AppName: "my-app"}.EnvironmentNames())
+}
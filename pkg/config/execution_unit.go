@@ -14,6 +14,12 @@ type (
 		EnvironmentVariables map[string]string `json:"environment_variables,omitempty" yaml:"environment_variables,omitempty" toml:"environment_variables,omitempty"`
 		HelmChartOptions     HelmChartOptions  `json:"helm_chart_options,omitempty" yaml:"helm_chart_options,omitempty" toml:"helm_chart_options,omitempty"`
 		InfraParams          InfraParams       `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
+		// PruneDependencies removes the package manager dependencies (package.json, requirements.txt) that are not
+		// imported by any of the unit's source files
+		PruneDependencies bool `json:"prune_dependencies,omitempty" yaml:"prune_dependencies,omitempty" toml:"prune_dependencies,omitempty"`
+		// KeepDependencies are kept when pruning even though no source file imports them, such as database drivers
+		// which are loaded by name or plugins which are loaded through entry points
+		KeepDependencies []string `json:"keep_dependencies,omitempty" yaml:"keep_dependencies,omitempty" toml:"keep_dependencies,omitempty"`
 	}
 
 	// HelmChartOptions represents configuration for execution units attempting to generate helm charts
@@ -97,6 +103,8 @@ func (a Application) GetExecutionUnit(id string) ExecutionUnit {
 		overrideValue(&cfg.HelmChartOptions.Directory, ecfg.HelmChartOptions.Directory)
 		cfg.HelmChartOptions.ValuesFiles = append(cfg.HelmChartOptions.ValuesFiles, ecfg.HelmChartOptions.ValuesFiles...)
 		cfg.InfraParams = ecfg.InfraParams
+		cfg.PruneDependencies = ecfg.PruneDependencies
+		cfg.KeepDependencies = ecfg.KeepDependencies
 	}
 	cfg.InfraParams.ApplyDefaults(a.Defaults.ExecutionUnit.InfraParamsByType[cfg.Type])
 
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"go.uber.org/zap"
 )
 
@@ -12,6 +13,29 @@ type (
 		Type                   string                 `json:"type" yaml:"type" toml:"type"`
 		ContentDeliveryNetwork ContentDeliveryNetwork `json:"content_delivery_network,omitempty" yaml:"content_delivery_network,omitempty" toml:"content_delivery_network,omitempty"`
 		InfraParams            InfraParams            `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
+		// Auth declares how requests to all of the gateway's routes are authorized, overriding the `auth` directive
+		Auth *ExposeAuth `json:"auth,omitempty" yaml:"auth,omitempty" toml:"auth,omitempty"`
+		// Routes overrides the settings of individual routes. Keys are a route path, optionally prefixed by a verb (e.g. "POST /users").
+		Routes map[string]ExposeRoute `json:"routes,omitempty" yaml:"routes,omitempty" toml:"routes,omitempty"`
+		// Domain is the custom domain which the gateway is served from, with a certificate created for it
+		Domain string `json:"domain,omitempty" yaml:"domain,omitempty" toml:"domain,omitempty"`
+		// HostedZone is the existing DNS zone which the domain's records are created in. It defaults to the parent domain of Domain.
+		HostedZone string `json:"hosted_zone,omitempty" yaml:"hosted_zone,omitempty" toml:"hosted_zone,omitempty"`
+	}
+
+	ExposeRoute struct {
+		Auth *ExposeAuth `json:"auth,omitempty" yaml:"auth,omitempty" toml:"auth,omitempty"`
+	}
+
+	// ExposeAuth is how gateway auth is represented in the klotho configuration
+	ExposeAuth struct {
+		// Type is one of "none", "iam", "jwt", "cognito" or "lambda"
+		Type string `json:"type" yaml:"type" toml:"type"`
+		// Issuer and Audience are the expected claims of the tokens of a "jwt" authorizer
+		Issuer   string   `json:"issuer,omitempty" yaml:"issuer,omitempty" toml:"issuer,omitempty"`
+		Audience []string `json:"audience,omitempty" yaml:"audience,omitempty" toml:"audience,omitempty"`
+		// ExecutionUnit is the execution unit which authorizes requests for a "lambda" authorizer
+		ExecutionUnit string `json:"execution_unit,omitempty" yaml:"execution_unit,omitempty" toml:"execution_unit,omitempty"`
 	}
 
 	GatewayTypeParams struct {
@@ -34,6 +58,10 @@ func (a Application) GetExpose(id string) Expose {
 		overrideValue(&cfg.Type, ecfg.Type)
 		overrideValue(&cfg.ContentDeliveryNetwork, ecfg.ContentDeliveryNetwork)
 		cfg.InfraParams = ecfg.InfraParams
+		cfg.Auth = ecfg.Auth
+		cfg.Routes = ecfg.Routes
+		cfg.Domain = ecfg.Domain
+		cfg.HostedZone = ecfg.HostedZone
 	}
 	cfg.InfraParams.ApplyDefaults(a.Defaults.Expose.InfraParamsByType[cfg.Type])
 
@@ -63,3 +91,21 @@ func (a Application) GetExposeKindParams(cfg Expose) interface{} {
 
 	return nil
 }
+
+// CustomDomain returns the gateway's custom domain, or nil if it is served from the url generated by its provider.
+func (cfg Expose) CustomDomain() *types.CustomDomain {
+	return customDomain(cfg.Domain, cfg.HostedZone)
+}
+
+// GatewayAuth returns the auth as a `types.GatewayAuth`, or nil if no auth is configured.
+func (auth *ExposeAuth) GatewayAuth() *types.GatewayAuth {
+	if auth == nil {
+		return nil
+	}
+	return &types.GatewayAuth{
+		Type:         types.GatewayAuthType(auth.Type),
+		Issuer:       auth.Issuer,
+		Audience:     auth.Audience,
+		ExecUnitName: auth.ExecutionUnit,
+	}
+}
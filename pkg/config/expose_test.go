@@ -109,6 +109,30 @@ func Test_GetExpose(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "get config with auth",
+			cfg: Application{
+				Defaults: Defaults{
+					Expose: KindDefaults{Type: "apigateway"},
+				},
+				Exposed: map[string]*Expose{
+					"test": {
+						Auth: &ExposeAuth{Type: "iam"},
+						Routes: map[string]ExposeRoute{
+							"/health": {Auth: &ExposeAuth{Type: "none"}},
+						},
+					},
+				},
+			},
+			id: "test",
+			want: Expose{
+				Type: "apigateway",
+				Auth: &ExposeAuth{Type: "iam"},
+				Routes: map[string]ExposeRoute{
+					"/health": {Auth: &ExposeAuth{Type: "none"}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
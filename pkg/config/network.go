@@ -0,0 +1,21 @@
+package config
+
+type (
+	// Network is the existing network which the application's resources are placed into, instead of the network which
+	// would otherwise be created for them
+	Network struct {
+		// Vpc is the id of the existing vpc
+		Vpc string `json:"vpc" yaml:"vpc" toml:"vpc"`
+		// Subnets are the subnets of the vpc which resources are placed in
+		Subnets []Subnet `json:"subnets,omitempty" yaml:"subnets,omitempty" toml:"subnets,omitempty"`
+		// SecurityGroups are the ids of the security groups of the vpc which resources are placed in
+		SecurityGroups []string `json:"security_groups,omitempty" yaml:"security_groups,omitempty" toml:"security_groups,omitempty"`
+	}
+
+	Subnet struct {
+		// Id is the id of the existing subnet
+		Id string `json:"id" yaml:"id" toml:"id"`
+		// Type is whether the subnet is private or public, which determines the resources which are placed in it
+		Type string `json:"type" yaml:"type" toml:"type"`
+	}
+)
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+)
+
+type (
+	// Observability configures the alarms which watch the application's resources, the dashboard which shows them and
+	// the tracing of the requests between its execution units
+	Observability struct {
+		// Alarms attaches the alarms of the provider's alarm rules to each resource they apply to
+		Alarms bool `json:"alarms" yaml:"alarms" toml:"alarms"`
+		// Dashboard generates a dashboard of the application's alarms, which requires Alarms
+		Dashboard bool `json:"dashboard" yaml:"dashboard" toml:"dashboard"`
+		// AlarmEmails are the email addresses which are subscribed to the topic that alarms notify
+		AlarmEmails []string `json:"alarm_emails,omitempty" yaml:"alarm_emails,omitempty" toml:"alarm_emails,omitempty"`
+		// Thresholds override the threshold of the alarm rules, keyed by the name of the rule
+		Thresholds map[string]float64 `json:"thresholds,omitempty" yaml:"thresholds,omitempty" toml:"thresholds,omitempty"`
+		// Tracing instruments the runtimes of the execution units with OpenTelemetry, propagating the trace context of
+		// requests through the calls and events between units
+		Tracing bool `json:"tracing" yaml:"tracing" toml:"tracing"`
+		// TracingEndpoint is the OTLP/HTTP endpoint which the units export their spans to. When it is not set, spans are
+		// exported to a collector which runs alongside each unit, as a sidecar of its task or an extension of its function.
+		TracingEndpoint string `json:"tracing_endpoint,omitempty" yaml:"tracing_endpoint,omitempty" toml:"tracing_endpoint,omitempty"`
+		// Collector overrides the collector which runs alongside each unit when no TracingEndpoint is set
+		Collector *TracingCollector `json:"collector,omitempty" yaml:"collector,omitempty" toml:"collector,omitempty"`
+	}
+
+	// TracingCollector overrides the OpenTelemetry collector which the units export their spans to. The provider's
+	// defaults are pinned to a single release of the collector, and each field overrides only the defaults it sets.
+	TracingCollector struct {
+		// Image is the collector's image, which runs as a sidecar of each task
+		Image string `json:"image,omitempty" yaml:"image,omitempty" toml:"image,omitempty"`
+		// Layers are the ARNs of the collector's lambda layer, whose extension is added to each function. They are keyed
+		// by the architecture of the functions (amd64 or arm64), or by "<region>/<architecture>" for the layer of a
+		// single region. "{region}" in an ARN is replaced by the region which the function is deployed to.
+		Layers map[string]string `json:"layers,omitempty" yaml:"layers,omitempty" toml:"layers,omitempty"`
+	}
+)
+
+// Validate checks that the observability configuration is consistent and that its thresholds override one of the
+// rules, named by ruleNames.
+func (o Observability) Validate(ruleNames []string) error {
+	if o.Dashboard && !o.Alarms {
+		return fmt.Errorf("observability dashboard requires alarms to be enabled")
+	}
+	if o.TracingEndpoint != "" {
+		if !o.Tracing {
+			return fmt.Errorf("observability tracing_endpoint requires tracing to be enabled")
+		}
+		if !strings.HasPrefix(o.TracingEndpoint, "http://") && !strings.HasPrefix(o.TracingEndpoint, "https://") {
+			return fmt.Errorf("invalid tracing endpoint '%s', must be an http or https url", o.TracingEndpoint)
+		}
+	}
+	if o.Collector != nil {
+		if !o.Tracing || o.TracingEndpoint != "" {
+			return fmt.Errorf("observability collector requires tracing to be enabled without a tracing_endpoint")
+		}
+		if err := o.Collector.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, email := range o.AlarmEmails {
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf("invalid alarm email '%s'", email)
+		}
+	}
+	known := make(map[string]bool)
+	for _, name := range ruleNames {
+		known[name] = true
+	}
+	var unknown []string
+	for name := range o.Thresholds {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		names := append([]string{}, ruleNames...)
+		sort.Strings(names)
+		return fmt.Errorf("thresholds for unknown alarm rules %s (known rules: %s)", strings.Join(unknown, ", "), strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// LambdaArchitectures are the architectures which the collector's lambda layers can be configured for
+var LambdaArchitectures = []string{"amd64", "arm64"}
+
+// Validate checks that each of the collector's layers is keyed by a known architecture.
+func (c TracingCollector) Validate() error {
+	for key, arn := range c.Layers {
+		_, arch, _ := strings.Cut(key, "/")
+		if arch == "" {
+			arch = key
+		}
+		if !collectionutil.Contains(LambdaArchitectures, arch) {
+			return fmt.Errorf("invalid collector layer '%s', must be keyed by one of the architectures %s", key, strings.Join(LambdaArchitectures, ", "))
+		}
+		if !strings.HasPrefix(arn, "arn:") {
+			return fmt.Errorf("invalid collector layer '%s' for %s, must be an ARN", arn, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObservabilityValidate(t *testing.T) {
+	rules := []string{"lambda_errors", "rds_cpu"}
+	tests := []struct {
+		name          string
+		observability Observability
+		wantErr       bool
+	}{
+		{
+			name:          "alarms with dashboard",
+			observability: Observability{Alarms: true, Dashboard: true, AlarmEmails: []string{"ops@example.com"}},
+		},
+		{
+			name:          "threshold of a known rule",
+			observability: Observability{Alarms: true, Thresholds: map[string]float64{"rds_cpu": 90}},
+		},
+		{
+			name:          "tracing with endpoint",
+			observability: Observability{Tracing: true, TracingEndpoint: "https://otel.example.com"},
+		},
+		{
+			name:          "tracing endpoint without tracing",
+			observability: Observability{TracingEndpoint: "https://otel.example.com"},
+			wantErr:       true,
+		},
+		{
+			name:          "invalid tracing endpoint",
+			observability: Observability{Tracing: true, TracingEndpoint: "otel.example.com:4318"},
+			wantErr:       true,
+		},
+		{
+			name: "tracing with collector",
+			observability: Observability{Tracing: true, Collector: &TracingCollector{
+				Image:  "example.com/otel-collector:1.0.0",
+				Layers: map[string]string{"arm64": "arn:aws:lambda:{region}:123456789012:layer:collector:1", "eu-west-1/amd64": "arn:aws:lambda:eu-west-1:123456789012:layer:collector:1"},
+			}},
+		},
+		{
+			name:          "collector with tracing endpoint",
+			observability: Observability{Tracing: true, TracingEndpoint: "https://otel.example.com", Collector: &TracingCollector{Image: "example.com/otel-collector:1.0.0"}},
+			wantErr:       true,
+		},
+		{
+			name:          "collector layer of unknown architecture",
+			observability: Observability{Tracing: true, Collector: &TracingCollector{Layers: map[string]string{"x86": "arn:aws:lambda:{region}:123456789012:layer:collector:1"}}},
+			wantErr:       true,
+		},
+		{
+			name:          "collector layer which is not an arn",
+			observability: Observability{Tracing: true, Collector: &TracingCollector{Layers: map[string]string{"amd64": "collector:1"}}},
+			wantErr:       true,
+		},
+		{
+			name:          "dashboard without alarms",
+			observability: Observability{Dashboard: true},
+			wantErr:       true,
+		},
+		{
+			name:          "invalid email",
+			observability: Observability{Alarms: true, AlarmEmails: []string{"ops"}},
+			wantErr:       true,
+		},
+		{
+			name:          "threshold of an unknown rule",
+			observability: Observability{Alarms: true, Thresholds: map[string]float64{"lambda_duration": 10}},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.observability.Validate(rules)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
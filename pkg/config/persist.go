@@ -7,6 +7,30 @@ type (
 		Type string `json:"type" yaml:"type" toml:"type"`
 		// KindParams represents the set of configuration to customize the kind of persist construct represented
 		InfraParams InfraParams `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
+		// Migrations configures the schema migrations which are run before the compute using a persist_orm resource is updated
+		Migrations *OrmMigrations `json:"migrations,omitempty" yaml:"migrations,omitempty" toml:"migrations,omitempty"`
+		// Rotation configures the automatic rotation of a persist_secrets resource, or of the credentials of a persist_orm resource
+		Rotation *SecretRotation `json:"rotation,omitempty" yaml:"rotation,omitempty" toml:"rotation,omitempty"`
+	}
+
+	// OrmMigrations is how the schema migrations of a persist_orm resource are represented in the klotho configuration
+	OrmMigrations struct {
+		// Tool is the migration tool: "alembic", "prisma", "typeorm", "efcore" or "golang-migrate"
+		Tool string `json:"tool" yaml:"tool" toml:"tool"`
+		// Command overrides the tool's default command, and is run by a shell from the root of the execution unit
+		Command string `json:"command,omitempty" yaml:"command,omitempty" toml:"command,omitempty"`
+		// ExecutionUnit is the execution unit whose image runs the migrations. It is only required when more than one
+		// execution unit uses the persist_orm resource.
+		ExecutionUnit string `json:"execution_unit,omitempty" yaml:"execution_unit,omitempty" toml:"execution_unit,omitempty"`
+	}
+
+	// SecretRotation is how the automatic rotation of a secret is represented in the klotho configuration
+	SecretRotation struct {
+		// Days is the number of days between rotations
+		Days int `json:"days" yaml:"days" toml:"days"`
+		// LambdaArn is the ARN of the lambda function which rotates the secret. It is required for secrets other than
+		// persist_orm credentials, which are rotated by the rotation function AWS provides for their database engine.
+		LambdaArn string `json:"lambda_arn,omitempty" yaml:"lambda_arn,omitempty" toml:"lambda_arn,omitempty"`
 	}
 )
 
@@ -19,6 +43,8 @@ func getPersist(id string, kindDefaults KindDefaults, overrides map[string]*Pers
 	if hasOverride {
 		overrideValue(&cfg.Type, ecfg.Type)
 		cfg.InfraParams = ecfg.InfraParams
+		cfg.Migrations = ecfg.Migrations
+		cfg.Rotation = ecfg.Rotation
 	}
 	cfg.InfraParams.ApplyDefaults(kindDefaults.InfraParamsByType[cfg.Type])
 
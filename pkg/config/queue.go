@@ -0,0 +1,68 @@
+package config
+
+import "github.com/klothoplatform/klotho/pkg/compiler/types"
+
+type (
+	// Queue is how queue Klotho constructs are represented in the klotho configuration
+	Queue struct {
+		// Type represents the service used for the queue
+		Type string `json:"type" yaml:"type" toml:"type"`
+		// InfraParams represents the set of configuration to customize the queue
+		InfraParams InfraParams `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
+	}
+
+	QueueTypeParams struct {
+		// Fifo specifies whether the queue preserves message order and delivers each message exactly once
+		Fifo bool `json:"fifo,omitempty" yaml:"fifo,omitempty" toml:"fifo,omitempty"`
+		// VisibilityTimeout specifies how long (in seconds) a received message is hidden from other consumers
+		VisibilityTimeout int `json:"visibility_timeout,omitempty" yaml:"visibility_timeout,omitempty" toml:"visibility_timeout,omitempty"`
+		// BatchSize specifies the maximum number of messages delivered to the consumer at once
+		BatchSize int `json:"batch_size,omitempty" yaml:"batch_size,omitempty" toml:"batch_size,omitempty"`
+		// DeadLetterQueue configures where messages are moved after they repeatedly fail to be processed
+		DeadLetterQueue DeadLetterQueueParams `json:"dead_letter_queue,omitempty" yaml:"dead_letter_queue,omitempty" toml:"dead_letter_queue,omitempty"`
+	}
+
+	DeadLetterQueueParams struct {
+		// Enabled specifies whether failed messages are moved to a dead-letter queue
+		Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`
+		// MaxReceiveCount specifies how many times a message is received before it is moved to the dead-letter queue
+		MaxReceiveCount int `json:"max_receive_count,omitempty" yaml:"max_receive_count,omitempty" toml:"max_receive_count,omitempty"`
+	}
+)
+
+// GetQueue returns the `Queue` config for the queue specified by `id`
+// merged with the defaults.
+func (a Application) GetQueue(id string) Queue {
+	cfg := Queue{
+		Type: a.Defaults.Queue.Type,
+	}
+
+	ecfg, hasOverride := a.Queues[id]
+	if hasOverride {
+		overrideValue(&cfg.Type, ecfg.Type)
+		cfg.InfraParams = ecfg.InfraParams
+	}
+	cfg.InfraParams.ApplyDefaults(a.Defaults.Queue.InfraParamsByType[cfg.Type])
+
+	return cfg
+}
+
+// GetQueueParams returns the queue's infra params as `QueueTypeParams`
+func (cfg Queue) GetQueueParams() QueueTypeParams {
+	return ConvertFromInfraParams[QueueTypeParams](cfg.InfraParams)
+}
+
+// NewQueue returns a `types.Queue` construct for `id` with the settings from its config
+func (a Application) NewQueue(id string) *types.Queue {
+	params := a.GetQueue(id).GetQueueParams()
+	queue := &types.Queue{
+		Name:              id,
+		Fifo:              params.Fifo,
+		VisibilityTimeout: params.VisibilityTimeout,
+		BatchSize:         params.BatchSize,
+	}
+	if params.DeadLetterQueue.Enabled {
+		queue.MaxReceiveCount = params.DeadLetterQueue.MaxReceiveCount
+	}
+	return queue
+}
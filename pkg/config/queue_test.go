@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewQueue(t *testing.T) {
+	defaults := Defaults{
+		Queue: KindDefaults{
+			Type: "sqs",
+			InfraParamsByType: map[string]InfraParams{
+				"sqs": {
+					"visibility_timeout": 30,
+					"batch_size":         10,
+					"dead_letter_queue":  map[string]any{"enabled": true, "max_receive_count": 5},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name   string
+		queues map[string]*Queue
+		id     string
+		want   *types.Queue
+	}{
+		{
+			name: "defaults",
+			id:   "orders",
+			want: &types.Queue{Name: "orders", VisibilityTimeout: 30, BatchSize: 10, MaxReceiveCount: 5},
+		},
+		{
+			name: "override",
+			queues: map[string]*Queue{
+				"orders": {InfraParams: InfraParams{"fifo": true, "batch_size": 1}},
+			},
+			id:   "orders",
+			want: &types.Queue{Name: "orders", Fifo: true, VisibilityTimeout: 30, BatchSize: 1, MaxReceiveCount: 5},
+		},
+		{
+			name: "dead-letter queue disabled",
+			queues: map[string]*Queue{
+				"orders": {InfraParams: InfraParams{"dead_letter_queue": map[string]any{"enabled": false}}},
+			},
+			id:   "orders",
+			want: &types.Queue{Name: "orders", VisibilityTimeout: 30, BatchSize: 10},
+		},
+		{
+			name: "override for other queue",
+			queues: map[string]*Queue{
+				"payments": {InfraParams: InfraParams{"fifo": true}},
+			},
+			id:   "orders",
+			want: &types.Queue{Name: "orders", VisibilityTimeout: 30, BatchSize: 10, MaxReceiveCount: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			cfg := Application{Defaults: defaults, Queues: tt.queues}
+			assert.Equal(tt.want, cfg.NewQueue(tt.id))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+type (
+	// Regions places the application into more than one region. The application is deployed into its Primary region
+	// and the data of its constructs is replicated into each of the Replicas. Deploying an environment of the
+	// application into another region, with Failover set on both environments, gives an active-passive deployment
+	// whose custom domains fail over to the secondary environment when the primary one is unhealthy.
+	// The databases of orms are replicated as Aurora global databases. In an active-passive deployment, the primary
+	// environment must be deployed first, since the secondary environment joins the global database it creates.
+	Regions struct {
+		// Primary is the region which the application is deployed into. When it is not set, the region is read from
+		// the stack's configuration.
+		Primary string `json:"primary,omitempty" yaml:"primary,omitempty" toml:"primary,omitempty"`
+		// Replicas are the regions which the data of the application's constructs is replicated into
+		Replicas []string `json:"replicas,omitempty" yaml:"replicas,omitempty" toml:"replicas,omitempty"`
+		// Constructs limits the replication to the listed constructs. When it is empty, every construct whose
+		// resources can be replicated is replicated.
+		Constructs []construct.ResourceId `json:"constructs,omitempty" yaml:"constructs,omitempty" toml:"constructs,omitempty"`
+		// Failover is the role, either primary or secondary, of the application in an active-passive deployment
+		Failover string `json:"failover,omitempty" yaml:"failover,omitempty" toml:"failover,omitempty"`
+		// HealthCheckPath is the path of the primary's exposed gateways which the failover health checks request
+		HealthCheckPath string `json:"health_check_path,omitempty" yaml:"health_check_path,omitempty" toml:"health_check_path,omitempty"`
+	}
+)
+
+const (
+	FailoverPrimary   = "primary"
+	FailoverSecondary = "secondary"
+)
+
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`)
+
+// Validate checks that the regions are valid region names, that the replicas are distinct from the primary region
+// and that the failover settings are consistent.
+func (r Regions) Validate() error {
+	if r.Primary != "" && !regionPattern.MatchString(r.Primary) {
+		return fmt.Errorf("invalid primary region '%s'", r.Primary)
+	}
+	seen := make(map[string]bool)
+	for _, region := range r.Replicas {
+		if !regionPattern.MatchString(region) {
+			return fmt.Errorf("invalid replica region '%s'", region)
+		}
+		if region == r.Primary {
+			return fmt.Errorf("replica region '%s' must not be the primary region", region)
+		}
+		if seen[region] {
+			return fmt.Errorf("replica region '%s' is listed more than once", region)
+		}
+		seen[region] = true
+	}
+	for _, id := range r.Constructs {
+		if id.Provider != construct.AbstractConstructProvider {
+			return fmt.Errorf("replicated construct '%s' must be a klotho construct", id)
+		}
+	}
+	if len(r.Constructs) > 0 && len(r.Replicas) == 0 {
+		return fmt.Errorf("replicated constructs require at least one replica region")
+	}
+	switch r.Failover {
+	case "", FailoverPrimary, FailoverSecondary:
+	default:
+		return fmt.Errorf("invalid failover '%s', must be one of %s or %s", r.Failover, FailoverPrimary, FailoverSecondary)
+	}
+	if r.HealthCheckPath != "" {
+		if r.Failover == "" {
+			return fmt.Errorf("health_check_path requires failover to be set")
+		}
+		if !strings.HasPrefix(r.HealthCheckPath, "/") {
+			return fmt.Errorf("health_check_path '%s' must start with '/'", r.HealthCheckPath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RegionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions Regions
+		wantErr bool
+	}{
+		{
+			name:    "replicas",
+			regions: Regions{Primary: "us-east-1", Replicas: []string{"us-west-2", "eu-west-1"}},
+		},
+		{
+			name: "replicated constructs and failover",
+			regions: Regions{
+				Replicas:        []string{"us-gov-west-1"},
+				Constructs:      []construct.ResourceId{{Provider: construct.AbstractConstructProvider, Type: "persist", Name: "users"}},
+				Failover:        FailoverPrimary,
+				HealthCheckPath: "/health",
+			},
+		},
+		{
+			name:    "invalid region",
+			regions: Regions{Primary: "us-east"},
+			wantErr: true,
+		},
+		{
+			name:    "replica is the primary",
+			regions: Regions{Primary: "us-east-1", Replicas: []string{"us-east-1"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate replica",
+			regions: Regions{Replicas: []string{"us-west-2", "us-west-2"}},
+			wantErr: true,
+		},
+		{
+			name:    "constructs without replicas",
+			regions: Regions{Constructs: []construct.ResourceId{{Provider: construct.AbstractConstructProvider, Type: "persist", Name: "users"}}},
+			wantErr: true,
+		},
+		{
+			name: "resource which is not a construct",
+			regions: Regions{
+				Replicas:   []string{"us-west-2"},
+				Constructs: []construct.ResourceId{{Provider: "aws", Type: "s3_bucket", Name: "users"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid failover",
+			regions: Regions{Failover: "active"},
+			wantErr: true,
+		},
+		{
+			name:    "health check path without failover",
+			regions: Regions{HealthCheckPath: "/health"},
+			wantErr: true,
+		},
+		{
+			name:    "relative health check path",
+			regions: Regions{Failover: FailoverSecondary, HealthCheckPath: "health"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := tt.regions.Validate()
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	NamingApp  = "app"
+	NamingEnv  = "env"
+	NamingType = "type"
+	NamingName = "name"
+
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+var (
+	namingPlaceholder = regexp.MustCompile(`\{\{\s*(\w*)\s*\}\}`)
+	// repeatedSeparators matches the separators which are left adjacent when a placeholder renders empty, such as
+	// {{env}} for an application without an environment
+	repeatedSeparators = regexp.MustCompile(`([-_.])[-_.]+`)
+)
+
+// ResourceTags are the tags which every taggable resource of the application is tagged with. They identify the
+// application and environment that the resource belongs to, along with the configured Tags which take precedence.
+func (a Application) ResourceTags() map[string]string {
+	tags := map[string]string{"app": a.AppName}
+	if a.Environment != "" {
+		tags["env"] = a.Environment
+	}
+	for k, v := range a.Tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// ValidateTagsAndNaming checks that the configured tags can be applied to the application's resources and that the
+// naming template only uses the known placeholders, including {{name}} which keeps the rendered names unique.
+func (a Application) ValidateTagsAndNaming() error {
+	for k, v := range a.Tags {
+		switch {
+		case k == "":
+			return fmt.Errorf("tag keys must not be empty")
+		case len(k) > maxTagKeyLength:
+			return fmt.Errorf("tag key '%s' is longer than %d characters", k, maxTagKeyLength)
+		case len(v) > maxTagValueLength:
+			return fmt.Errorf("value of tag '%s' is longer than %d characters", k, maxTagValueLength)
+		case strings.HasPrefix(strings.ToLower(k), "aws:"):
+			return fmt.Errorf("tag key '%s' must not use the reserved 'aws:' prefix", k)
+		}
+	}
+	if a.Naming == "" {
+		return nil
+	}
+	known := map[string]bool{NamingApp: true, NamingEnv: true, NamingType: true, NamingName: true}
+	hasName := false
+	for _, match := range namingPlaceholder.FindAllStringSubmatch(a.Naming, -1) {
+		if !known[match[1]] {
+			names := make([]string, 0, len(known))
+			for name := range known {
+				names = append(names, fmt.Sprintf("{{%s}}", name))
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown placeholder '%s' in naming template '%s' (known placeholders: %s)", match[0], a.Naming, strings.Join(names, ", "))
+		}
+		hasName = hasName || match[1] == NamingName
+	}
+	if rest := namingPlaceholder.ReplaceAllString(a.Naming, ""); strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+		return fmt.Errorf("malformed placeholder in naming template '%s'", a.Naming)
+	}
+	if !hasName {
+		return fmt.Errorf("naming template '%s' must contain {{%s}}", a.Naming, NamingName)
+	}
+	return nil
+}
+
+// ResourceName renders the naming template for the resource of type resourceType which is named name. Since resources
+// are named after the application by default, the application's qualified name is stripped from the front of name so
+// that it is only rendered by the template's own placeholders. Without a naming template, name is returned unchanged.
+func (a Application) ResourceName(resourceType string, name string) string {
+	if a.Naming == "" {
+		return name
+	}
+	for _, sep := range []string{"-", "_"} {
+		if trimmed := strings.TrimPrefix(name, a.QualifiedAppName()+sep); trimmed != name && trimmed != "" {
+			name = trimmed
+			break
+		}
+	}
+	values := map[string]string{
+		NamingApp:  a.AppName,
+		NamingEnv:  a.Environment,
+		NamingType: resourceType,
+		NamingName: name,
+	}
+	rendered := namingPlaceholder.ReplaceAllStringFunc(a.Naming, func(placeholder string) string {
+		return values[namingPlaceholder.FindStringSubmatch(placeholder)[1]]
+	})
+	rendered = repeatedSeparators.ReplaceAllString(rendered, "$1")
+	return strings.Trim(rendered, "-_.")
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResourceTags(t *testing.T) {
+	tests := []struct {
+		name string
+		app  Application
+		want map[string]string
+	}{
+		{
+			name: "application tag",
+			app:  Application{AppName: "app"},
+			want: map[string]string{"app": "app"},
+		},
+		{
+			name: "environment and configured tags",
+			app:  Application{AppName: "app", Environment: "prod", Tags: map[string]string{"team": "payments", "env": "production"}},
+			want: map[string]string{"app": "app", "env": "production", "team": "payments"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.want, tt.app.ResourceTags())
+		})
+	}
+}
+
+func Test_ValidateTagsAndNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     Application
+		wantErr bool
+	}{
+		{
+			name: "no policy",
+			app:  Application{AppName: "app"},
+		},
+		{
+			name: "tags and naming",
+			app:  Application{AppName: "app", Tags: map[string]string{"team": "payments"}, Naming: "{{app}}-{{ env }}-{{type}}-{{name}}"},
+		},
+		{
+			name:    "empty tag key",
+			app:     Application{AppName: "app", Tags: map[string]string{"": "payments"}},
+			wantErr: true,
+		},
+		{
+			name:    "reserved tag key",
+			app:     Application{AppName: "app", Tags: map[string]string{"aws:team": "payments"}},
+			wantErr: true,
+		},
+		{
+			name:    "tag value too long",
+			app:     Application{AppName: "app", Tags: map[string]string{"team": strings.Repeat("a", 257)}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown placeholder",
+			app:     Application{AppName: "app", Naming: "{{app}}-{{region}}-{{name}}"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed placeholder",
+			app:     Application{AppName: "app", Naming: "{{app}-{{name}}"},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			app:     Application{AppName: "app", Naming: "{{app}}-{{type}}"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := tt.app.ValidateTagsAndNaming()
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func Test_ResourceName(t *testing.T) {
+	tests := []struct {
+		name         string
+		app          Application
+		resourceType string
+		resourceName string
+		want         string
+	}{
+		{
+			name:         "no naming template",
+			app:          Application{AppName: "app"},
+			resourceType: "sqs_queue",
+			resourceName: "app-jobs",
+			want:         "app-jobs",
+		},
+		{
+			name:         "strips the application from the name",
+			app:          Application{AppName: "app", Environment: "prod", Naming: "{{app}}-{{env}}-{{type}}-{{name}}"},
+			resourceType: "sqs_queue",
+			resourceName: "app-prod-jobs",
+			want:         "app-prod-sqs_queue-jobs",
+		},
+		{
+			name:         "strips an underscore separated application",
+			app:          Application{AppName: "app", Naming: "{{type}}.{{name}}"},
+			resourceType: "vpc",
+			resourceName: "app_main",
+			want:         "vpc.main",
+		},
+		{
+			name:         "name without the application",
+			app:          Application{AppName: "app", Naming: "{{app}}-{{name}}"},
+			resourceType: "region",
+			resourceName: "region",
+			want:         "app-region",
+		},
+		{
+			name:         "empty environment",
+			app:          Application{AppName: "app", Naming: "{{app}}-{{env}}-{{name}}"},
+			resourceType: "s3_bucket",
+			resourceName: "app-payloads",
+			want:         "app-payloads",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.want, tt.app.ResourceName(tt.resourceType, tt.resourceName))
+		})
+	}
+}
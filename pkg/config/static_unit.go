@@ -1,11 +1,17 @@
 package config
 
+import "github.com/klothoplatform/klotho/pkg/compiler/types"
+
 type (
 	// StaticUnit is how static unit Klotho constructs are represented in the klotho configuration
 	StaticUnit struct {
 		Type                   string                 `json:"type" yaml:"type" toml:"type"`
 		InfraParams            InfraParams            `json:"infra_params,omitempty" yaml:"infra_params,omitempty" toml:"infra_params,omitempty"`
 		ContentDeliveryNetwork ContentDeliveryNetwork `json:"content_delivery_network,omitempty" yaml:"content_delivery_network,omitempty" toml:"content_delivery_network,omitempty"`
+		// Domain is the custom domain which the unit's files are served from, with a certificate created for it
+		Domain string `json:"domain,omitempty" yaml:"domain,omitempty" toml:"domain,omitempty"`
+		// HostedZone is the existing DNS zone which the domain's records are created in. It defaults to the parent domain of Domain.
+		HostedZone string `json:"hosted_zone,omitempty" yaml:"hosted_zone,omitempty" toml:"hosted_zone,omitempty"`
 	}
 )
 
@@ -21,8 +27,22 @@ func (a Application) GetStaticUnit(id string) StaticUnit {
 		overrideValue(&cfg.Type, ecfg.Type)
 		overrideValue(&cfg.ContentDeliveryNetwork, ecfg.ContentDeliveryNetwork)
 		cfg.InfraParams = ecfg.InfraParams
+		cfg.Domain = ecfg.Domain
+		cfg.HostedZone = ecfg.HostedZone
 	}
 	cfg.InfraParams.ApplyDefaults(a.Defaults.StaticUnit.InfraParamsByType[cfg.Type])
 
 	return cfg
 }
+
+// CustomDomain returns the unit's custom domain, or nil if it is served from the url generated by its provider.
+func (cfg StaticUnit) CustomDomain() *types.CustomDomain {
+	return customDomain(cfg.Domain, cfg.HostedZone)
+}
+
+func customDomain(domain string, hostedZone string) *types.CustomDomain {
+	if domain == "" {
+		return nil
+	}
+	return &types.CustomDomain{Name: domain, HostedZone: hostedZone}
+}
@@ -109,6 +109,22 @@ func Test_GetStaticUnit(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "get config with domain",
+			cfg: Application{
+				Defaults: Defaults{
+					StaticUnit: KindDefaults{Type: "s3"},
+				},
+				StaticUnit: map[string]*StaticUnit{
+					"test": {Domain: "www.example.com"},
+				},
+			},
+			id: "test",
+			want: StaticUnit{
+				Type:   "s3",
+				Domain: "www.example.com",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
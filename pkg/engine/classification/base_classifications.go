@@ -12,20 +12,57 @@ var BaseClassificationDocument = &ClassificationDocument{
 		"aws:efs_file_system:":         {Gives: []Gives{}, Is: []string{"storage", "filesystem"}},
 		"aws:eks_cluster:":             {Gives: []Gives{}, Is: []string{"cluster", "kubernetes"}},
 		"aws:elasticache_cluster:":     {Gives: []Gives{}, Is: []string{"storage", "redis", "cache"}},
+		"aws:event_bridge_rule:":       {Gives: []Gives{}, Is: []string{"messaging", "schedule"}},
+		"aws:http_api:":                {Gives: []Gives{}, Is: []string{"api"}},
 		"aws:lambda_function:":         {Gives: []Gives{}, Is: []string{"compute", "serverless"}},
+		"aws:sfn_state_machine:":       {Gives: []Gives{}, Is: []string{"compute", "workflow"}},
 		"aws:load_balancer:":           {Gives: []Gives{}, Is: []string{"network", "loadbalancer"}},
 		"aws:rds_instance:":            {Gives: []Gives{}, Is: []string{"storage", "relational"}},
 		"aws:rds_proxy:":               {Gives: []Gives{}, Is: []string{"proxy"}},
-		"aws:rest_api:":                {Gives: []Gives{}, Is: []string{"api"}},
+		"aws:rest_api:":                {Gives: []Gives{}, Is: []string{"api", "rest"}},
 		"aws:route53_hosted_zone:":     {Gives: []Gives{}, Is: []string{"network", "dns"}},
 		"aws:s3_bucket:":               {Gives: []Gives{}, Is: []string{"storage", "blob"}},
+		"aws:s3_bucket_notification:":  {Gives: []Gives{}, Is: []string{"messaging", "fs_event"}},
+		"aws:orm_migration:":           {Gives: []Gives{}, Is: []string{"compute", "orm_migration"}},
 		"aws:sns_topic:":               {Gives: []Gives{}, Is: []string{"messaging", "pubsub"}},
 		"aws:sqs_queue:":               {Gives: []Gives{}, Is: []string{"messaging", "queue"}},
 		"aws:secret:":                  {Gives: []Gives{}, Is: []string{"storage", "secret"}},
+		"aws:ssm_parameter:":           {Gives: []Gives{}, Is: []string{"storage"}},
 		"aws:vpc:":                     {Gives: []Gives{}, Is: []string{"network"}},
+		"aws:websocket_api:":           {Gives: []Gives{}, Is: []string{"api", "websocket"}},
+		"azure:container_app:":         {Gives: []Gives{}, Is: []string{"compute"}},
+		"azure:cosmosdb_account:":      {Gives: []Gives{}, Is: []string{"storage", "kv", "nosql"}},
+		"azure:front_door_endpoint:":   {Gives: []Gives{}, Is: []string{"api", "rest"}},
+		"azure:function_app:":          {Gives: []Gives{}, Is: []string{"compute", "serverless"}},
+		"azure:key_vault:":             {Gives: []Gives{}, Is: []string{"storage", "secrets"}},
+		"azure:postgres_flexible_server:": {Gives: []Gives{}, Is: []string{"storage", "relational"}},
+		"azure:redis_cache:":           {Gives: []Gives{}, Is: []string{"storage", "redis", "cache"}},
+		"azure:service_bus_topic:":     {Gives: []Gives{}, Is: []string{"messaging", "pubsub"}},
+		"azure:storage_container:":     {Gives: []Gives{}, Is: []string{"storage", "blob"}},
 		"docker:image:":                {Gives: []Gives{}, Is: []string{"container_image"}},
+		"gcp:cloud_function:":          {Gives: []Gives{}, Is: []string{"compute", "serverless"}},
+		"gcp:cloud_run_service:":       {Gives: []Gives{}, Is: []string{"compute"}},
+		"gcp:firestore_database:":      {Gives: []Gives{}, Is: []string{"storage", "kv", "nosql"}},
+		"gcp:pubsub_topic:":            {Gives: []Gives{}, Is: []string{"messaging", "pubsub"}},
+		"gcp:redis_instance:":          {Gives: []Gives{}, Is: []string{"storage", "redis", "cache"}},
+		"gcp:secret_manager_secrets:":  {Gives: []Gives{}, Is: []string{"storage", "secrets"}},
+		"gcp:sql_database_instance:":   {Gives: []Gives{}, Is: []string{"storage", "relational"}},
+		"gcp:storage_bucket:":          {Gives: []Gives{}, Is: []string{"storage", "blob"}},
+		"gcp:url_map:":                 {Gives: []Gives{}, Is: []string{"api", "rest"}},
+		"kubernetes:cluster:":          {Gives: []Gives{}, Is: []string{"cluster", "kubernetes"}},
 		"kubernetes:deployment:":       {Gives: []Gives{}, Is: []string{"compute", "kubernetes"}},
 		"kubernetes:helm_chart:":       {Gives: []Gives{}, Is: []string{"kubernetes"}},
+		"kubernetes:ingress:":          {Gives: []Gives{}, Is: []string{"api", "rest"}},
 		"kubernetes:pod:":              {Gives: []Gives{}, Is: []string{"compute", "kubernetes"}},
+		"kubernetes:postgres_stateful_set:": {Gives: []Gives{}, Is: []string{"storage", "relational"}},
+		"kubernetes:redis_stateful_set:":    {Gives: []Gives{}, Is: []string{"storage", "redis", "cache"}},
+		"kubernetes:secret:":           {Gives: []Gives{}, Is: []string{"storage", "secrets"}},
+		"local:container:":             {Gives: []Gives{}, Is: []string{"compute"}},
+		"local:dynamodb_local:":        {Gives: []Gives{}, Is: []string{"storage", "kv", "nosql"}},
+		"local:minio:":                 {Gives: []Gives{}, Is: []string{"storage", "blob"}},
+		"local:postgres:":              {Gives: []Gives{}, Is: []string{"storage", "relational"}},
+		"local:redis:":                 {Gives: []Gives{}, Is: []string{"storage", "redis", "cache"}},
+		"local:router:":                {Gives: []Gives{}, Is: []string{"api", "rest"}},
+		"local:secret_file:":           {Gives: []Gives{}, Is: []string{"storage", "secrets"}},
 	},
 }
@@ -22,6 +22,23 @@ type (
 	// type: rds_instance
 	//
 	// The end result of this should be that the orm construct is expanded into an rds instance + necessary resources
+	//
+	// Similarly, an expose construct is expanded into an API Gateway HTTP (v2) api, rather than a REST api, with
+	//
+	// - scope: construct
+	// operator: equals
+	// target: klotho:expose:my_gateway
+	// type: http_api
+	//
+	// where apigatewayv2 is accepted as an alias of http_api.
+	//
+	// and a persist_secrets construct is stored in an SSM Parameter Store SecureString parameter, rather than a Secrets
+	// Manager secret, with
+	//
+	// - scope: construct
+	// operator: equals
+	// target: klotho:secrets:my_secrets
+	// type: ssm_parameter
 	ConstructConstraint struct {
 		Operator   ConstraintOperator   `yaml:"operator"`
 		Target     construct.ResourceId `yaml:"target"`
@@ -30,6 +47,19 @@ type (
 	}
 )
 
+// constructTypeAliases maps the alternative names which a construct constraint's type may be given to the resource type
+var constructTypeAliases = map[string]string{
+	"apigatewayv2": "http_api",
+}
+
+// ResourceType returns the type of the resource which the constraint expands its target into, resolving aliases
+func (constraint *ConstructConstraint) ResourceType() string {
+	if resourceType, ok := constructTypeAliases[constraint.Type]; ok {
+		return resourceType
+	}
+	return constraint.Type
+}
+
 func (constraint *ConstructConstraint) Scope() ConstraintScope {
 	return ConstructConstraintScope
 }
@@ -39,10 +69,11 @@ func (constraint *ConstructConstraint) IsSatisfied(dag *construct.ResourceGraph,
 	case EqualsConstraintOperator:
 		// Well look at all resources to see if there is a resource matching the type, that references the base construct passed in
 		// Cuirrently attributes go unchecked
+		resourceType := constraint.ResourceType()
 		for _, res := range dag.ListResources() {
-			if constraint.Type != "" && res.Id().Type == constraint.Type && res.BaseConstructRefs().Has(constraint.Target) {
+			if resourceType != "" && res.Id().Type == resourceType && res.BaseConstructRefs().Has(constraint.Target) {
 				return true
-			} else if constraint.Type == "" && res.BaseConstructRefs().Has(constraint.Target) {
+			} else if resourceType == "" && res.BaseConstructRefs().Has(constraint.Target) {
 				return true
 			}
 		}
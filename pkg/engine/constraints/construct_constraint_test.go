@@ -63,6 +63,36 @@ func Test_ConstructConstraint_IsSatisfied(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "apigatewayv2 is satisfied by an http api",
+			constraint: ConstructConstraint{
+				Operator: EqualsConstraintOperator,
+				Target:   construct.ResourceId{Provider: construct.AbstractConstructProvider, Type: types.EXECUTION_UNIT_TYPE, Name: "compute"},
+				Type:     "apigatewayv2",
+			},
+			resources: []construct.Resource{
+				&resources.HttpApi{
+					Name:          "my_api",
+					ConstructRefs: construct.BaseConstructSetOf(eu),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "apigatewayv2 is not satisfied by a rest api",
+			constraint: ConstructConstraint{
+				Operator: EqualsConstraintOperator,
+				Target:   construct.ResourceId{Provider: construct.AbstractConstructProvider, Type: types.EXECUTION_UNIT_TYPE, Name: "compute"},
+				Type:     "apigatewayv2",
+			},
+			resources: []construct.Resource{
+				&resources.RestApi{
+					Name:          "my_api",
+					ConstructRefs: construct.BaseConstructSetOf(eu),
+				},
+			},
+			want: false,
+		},
 		{
 			name: "no equals is not satisfied and fails",
 			constraint: ConstructConstraint{
@@ -89,3 +119,21 @@ func Test_ConstructConstraint_IsSatisfied(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConstructConstraint_ResourceType(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint ConstructConstraint
+		want       string
+	}{
+		{name: "resource type", constraint: ConstructConstraint{Type: "http_api"}, want: "http_api"},
+		{name: "alias", constraint: ConstructConstraint{Type: "apigatewayv2"}, want: "http_api"},
+		{name: "no type", constraint: ConstructConstraint{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.want, tt.constraint.ResourceType())
+		})
+	}
+}
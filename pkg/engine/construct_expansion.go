@@ -56,13 +56,14 @@ func (e *Engine) ExpandConstructs() {
 				}
 
 				if constructConstraint.Target == construct.Id() {
-					if constructType != "" && constructType != constructConstraint.Type {
+					if constructType != "" && constructType != constructConstraint.ResourceType() {
 						e.Context.Errors = append(e.Context.Errors, &ConstructExpansionError{
 							Construct: res,
 							Cause:     fmt.Errorf("unable to expand construct %s, conflicting types in constraints", res.Id()),
 						})
 						break
 					}
+					constructType = constructConstraint.ResourceType()
 					for k, v := range constructConstraint.Attributes {
 						if val, ok := attributes[k]; ok {
 							if v != val {
@@ -78,17 +79,21 @@ func (e *Engine) ExpandConstructs() {
 				}
 			}
 
-			for k, v := range construct.Attributes() {
-				if val, ok := attributes[k]; ok {
-					if v != val {
-						e.Context.Errors = append(e.Context.Errors, &ConstructExpansionError{
-							Construct: res,
-							Cause:     fmt.Errorf("unable to expand construct %s, attribute %s has conflicting values", res.Id(), k),
-						})
-						break
+			// The construct's own attributes choose between the resources its functionality can expand to,
+			// so they only apply when a constraint has not already chosen the type of resource.
+			if constructType == "" {
+				for k, v := range construct.Attributes() {
+					if val, ok := attributes[k]; ok {
+						if v != val {
+							e.Context.Errors = append(e.Context.Errors, &ConstructExpansionError{
+								Construct: res,
+								Cause:     fmt.Errorf("unable to expand construct %s, attribute %s has conflicting values", res.Id(), k),
+							})
+							break
+						}
 					}
+					attributes[k] = v
 				}
-				attributes[k] = v
 			}
 			solutions, err := e.expandConstruct(constructType, attributes, construct)
 			if err != nil {
@@ -120,3 +120,36 @@ func Test_constructExpansion(t *testing.T) {
 		})
 	}
 }
+
+func Test_ExpandConstructs_constraintType(t *testing.T) {
+	assert := assert.New(t)
+	mp := &enginetesting.MockProvider{}
+	engine := NewEngine(map[string]provider.Provider{
+		mp.Name(): mp,
+	}, enginetesting.MockKB, types.ListAllConstructs())
+	engine.ClassificationDocument = enginetesting.BaseClassificationDocument
+
+	eu := &types.ExecutionUnit{Name: "eu_1"}
+	initialState := construct.NewConstructGraph()
+	initialState.AddConstruct(eu)
+	engine.LoadContext(initialState, map[constraints.ConstraintScope][]constraints.Constraint{
+		constraints.ConstructConstraintScope: {
+			&constraints.ConstructConstraint{
+				Operator: constraints.EqualsConstraintOperator,
+				Target:   eu.Id(),
+				Type:     "mock2",
+			},
+		},
+	}, "app")
+	engine.ExpandConstructs()
+
+	assert.Empty(engine.Context.Errors)
+	solutions := engine.Context.constructExpansionSolutions[eu.Id()]
+	if !assert.Len(solutions, 1) {
+		return
+	}
+	coretesting.ResourcesExpectation{
+		Nodes: []string{"mock:mock2:mock2-eu_1"},
+		Deps:  []coretesting.StringDep{},
+	}.Assert(t, solutions[0].Graph)
+}
@@ -47,6 +47,8 @@ type (
 		Errors                      []EngineError
 		constructExpansionSolutions map[construct.ResourceId][]*ExpansionSolution
 		AppName                     string
+		// Imports are the resources which already exist and are imported by their id, rather than created
+		Imports map[construct.ResourceId]string
 	}
 
 	// SolveContext is a struct that represents the context of one possible graph solution
@@ -139,6 +141,19 @@ func (e *Engine) LoadContext(initialState *construct.ConstructGraph, constraints
 	}
 }
 
+// LoadImports loads the resources which are imported into the context of the engine. Imported resources are used as they
+// are, so the engine does not make them operational, and does not create resources in them which must be imported along
+// with them.
+func (e *Engine) LoadImports(imports map[construct.ResourceId]string) {
+	e.Context.Imports = imports
+}
+
+// IsImported returns whether the resource is imported rather than created
+func (e *Engine) IsImported(resource construct.Resource) bool {
+	_, ok := e.Context.Imports[resource.Id()]
+	return ok
+}
+
 // Run invokes the engine workflow to translate the initial state construct graph into the end state resource graph
 //
 // The steps of the engine workflow are
@@ -385,7 +400,7 @@ func (e *Engine) SolveGraph(context *SolveContext) {
 			}
 
 			errs := e.configureEdge(dep, context)
-			if err != nil {
+			if errs != nil {
 				context.Errors = append(context.Errors, errs...)
 				continue
 			}
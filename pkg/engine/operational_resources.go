@@ -23,6 +23,10 @@ type OperationalResource interface {
 // The rules are defined in the knowledge base template for the resource and are applied to the resource graph.
 // all errors and decisions are recorded in the context.
 func (e *Engine) MakeResourceOperational(context *SolveContext, resource construct.Resource) bool {
+	if e.IsImported(resource) {
+		// imported resources already exist, so they are used as they are instead of being made operational
+		return true
+	}
 	var engineErrors []EngineError
 	template := e.GetTemplateForResource(resource)
 	if template != nil {
@@ -469,6 +473,20 @@ func (e *Engine) handleOperationalResourceError(err *OperationalResourceError, d
 			}
 		}
 	}
+	// resources cannot be created in an imported parent, so there must be enough of them imported with it which are not
+	// already used by the resource
+	if parent := e.importedParent(err, neededResource, dag); parent != nil {
+		numImported := numSatisfied
+		for _, res := range availableResources {
+			if getDependencyForDirection(dag, err.Direction, err.Resource, res) == nil {
+				numImported++
+			}
+		}
+		if numImported < err.Count {
+			return nil, fmt.Errorf("%s needs %d more %s, but only %d are available in %s, which is imported so they cannot be created in it",
+				err.Resource.Id(), err.Count, neededResource.Id().Type, numImported, parent.Id())
+		}
+	}
 	resourceIds := []string{}
 	for _, res := range availableResources {
 		resourceIds = append(resourceIds, res.Id().Name)
@@ -511,6 +529,33 @@ func (e *Engine) handleOperationalResourceError(err *OperationalResourceError, d
 	return decisions, nil
 }
 
+// importedParent returns the imported resource which the needed resource would be created in, if its template requires
+// it to be imported along with its parent. The parent is the parent of the error if it has one, otherwise it is any
+// resource of a type which the needed resource's template places it in.
+func (e *Engine) importedParent(err *OperationalResourceError, neededResource construct.Resource, dag *construct.ResourceGraph) construct.Resource {
+	template := e.GetTemplateForResource(neededResource)
+	if template == nil || !template.RequiresImportWithParent {
+		return nil
+	}
+	if err.Parent != nil {
+		if e.IsImported(err.Parent) {
+			return err.Parent
+		}
+		return nil
+	}
+	for _, rule := range template.Rules {
+		if rule.Direction != knowledgebase.Downstream || rule.SetField == "" {
+			continue
+		}
+		for _, res := range dag.ListResources() {
+			if collectionutil.Contains(rule.ResourceTypes, res.Id().Type) && e.IsImported(res) {
+				return res
+			}
+		}
+	}
+	return nil
+}
+
 func cloneResource(resource construct.Resource) construct.Resource {
 	newRes := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(construct.Resource)
 	for i := 0; i < reflect.ValueOf(newRes).Elem().NumField(); i++ {
@@ -188,6 +188,7 @@ func Test_handleOperationalResourceError(t *testing.T) {
 		name                 string
 		ore                  *OperationalResourceError
 		existingDependencies []graph.Edge[construct.Resource]
+		imports              map[construct.ResourceId]string
 		want                 []Decision
 		wantErr              bool
 	}{
@@ -347,6 +348,72 @@ func Test_handleOperationalResourceError(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "uses resources imported with imported parent",
+			ore: &OperationalResourceError{
+				Resource:  &enginetesting.MockResource5{Name: "this"},
+				Direction: knowledgebase.Downstream,
+				Needs:     []string{"mock1"},
+				Count:     1,
+				Parent:    &enginetesting.MockResource3{Name: "parent"},
+				Cause:     fmt.Errorf("0"),
+			},
+			existingDependencies: []graph.Edge[construct.Resource]{
+				{Source: &enginetesting.MockResource1{Name: "child"}, Destination: &enginetesting.MockResource3{Name: "parent"}},
+			},
+			imports: map[construct.ResourceId]string{
+				(&enginetesting.MockResource3{Name: "parent"}).Id(): "parent-id",
+				(&enginetesting.MockResource1{Name: "child"}).Id():  "child-id",
+			},
+			want: []Decision{
+				{
+					Action: ActionConnect,
+					Result: &DecisionResult{
+						Edge: &graph.Edge[construct.Resource]{Source: &enginetesting.MockResource5{Name: "this"}, Destination: &enginetesting.MockResource1{Name: "child"}},
+					},
+				},
+			},
+		},
+		{
+			name: "cannot create resources in imported parent",
+			ore: &OperationalResourceError{
+				Resource:  &enginetesting.MockResource5{Name: "this"},
+				Direction: knowledgebase.Downstream,
+				Needs:     []string{"mock1"},
+				Count:     2,
+				Parent:    &enginetesting.MockResource3{Name: "parent"},
+				Cause:     fmt.Errorf("0"),
+			},
+			existingDependencies: []graph.Edge[construct.Resource]{
+				{Source: &enginetesting.MockResource1{Name: "child"}, Destination: &enginetesting.MockResource3{Name: "parent"}},
+			},
+			imports: map[construct.ResourceId]string{
+				(&enginetesting.MockResource3{Name: "parent"}).Id(): "parent-id",
+				(&enginetesting.MockResource1{Name: "child"}).Id():  "child-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "cannot create resources in imported resource of template's parent type",
+			ore: &OperationalResourceError{
+				Resource:  &enginetesting.MockResource5{Name: "this"},
+				Direction: knowledgebase.Downstream,
+				Needs:     []string{"mock1"},
+				Count:     2,
+				Cause:     fmt.Errorf("0"),
+			},
+			existingDependencies: []graph.Edge[construct.Resource]{
+				{Source: &enginetesting.MockResource5{Name: "this"}, Destination: &enginetesting.MockResource1{Name: "child"}},
+				{Source: &enginetesting.MockResource1{Name: "child"}, Destination: &enginetesting.MockResource3{Name: "parent"}},
+				{Source: &enginetesting.MockResource1{Name: "child2"}, Destination: &enginetesting.MockResource3{Name: "parent"}},
+			},
+			imports: map[construct.ResourceId]string{
+				(&enginetesting.MockResource3{Name: "parent"}).Id(): "parent-id",
+				(&enginetesting.MockResource1{Name: "child"}).Id():  "child-id",
+				(&enginetesting.MockResource1{Name: "child2"}).Id(): "child2-id",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -356,6 +423,13 @@ func Test_handleOperationalResourceError(t *testing.T) {
 				mp.Name(): mp,
 			}, enginetesting.MockKB, types.ListAllConstructs())
 			engine.ClassificationDocument = enginetesting.BaseClassificationDocument
+			engine.ResourceTemplates[construct.ResourceId{Provider: "mock", Type: "mock1"}] = &knowledgebase.ResourceTemplate{
+				Rules: []knowledgebase.OperationalRule{
+					{Enforcement: knowledgebase.ExactlyOne, Direction: knowledgebase.Downstream, ResourceTypes: []string{"mock3"}, SetField: "Parent"},
+				},
+				RequiresImportWithParent: true,
+			}
+			engine.LoadImports(tt.imports)
 
 			dag := construct.NewResourceGraph()
 			for _, dep := range tt.existingDependencies {
@@ -25,7 +25,7 @@ func (p ExecUnitPlugin) Transform(input *types.InputFiles, fileDeps *types.FileD
 	}
 
 	// This set of environment variables is added to all Execution Units
-	unit.EnvironmentVariables.Add(types.NewEnvironmentVariable("APP_NAME", nil, p.Config.AppName))
+	unit.EnvironmentVariables.Add(types.NewEnvironmentVariable("APP_NAME", nil, p.Config.QualifiedAppName()))
 	unit.EnvironmentVariables.Add(types.NewEnvironmentVariable("EXECUNIT_NAME", nil, unit.Name))
 
 	for _, f := range input.Files() {
@@ -35,6 +35,7 @@ func (p ExecUnitPlugin) Transform(input *types.InputFiles, fileDeps *types.FileD
 			// as required by its features.
 			if sf.IsAnnotatedWith(annotation.ExecutionUnitCapability) {
 				unit.AddSourceFile(f.Clone())
+				unit.Executable.SetInclusionReason(f.Path(), "annotated with @klotho::execution_unit")
 			} else {
 				unit.Add(f.Clone())
 			}
@@ -2,6 +2,7 @@ package execunit
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 )
@@ -16,78 +17,90 @@ type (
 )
 
 func (resolver SourceFilesResolver) Resolve(unit *types.ExecutionUnit) (map[string]struct{}, error) {
+	reasons, err := resolver.ResolveWithReasons(unit)
+	if err != nil {
+		return nil, err
+	}
+	sourceFiles := make(map[string]struct{}, len(reasons))
+	for path := range reasons {
+		sourceFiles[path] = struct{}{}
+	}
+	return sourceFiles, nil
+}
 
+// ResolveWithReasons resolves the same files as Resolve, mapping each file to a description of why it was included.
+// Files reachable from several roots are explained by the shortest import chain found from any of them.
+func (resolver SourceFilesResolver) ResolveWithReasons(unit *types.ExecutionUnit) (map[string]string, error) {
 	var entrypoints []string
 	for entrypoint := range unit.Executable.Entrypoints {
 		entrypoints = append(entrypoints, entrypoint)
 	}
+	sort.Strings(entrypoints)
+
+	roots := make(map[string]string)
+	for _, entrypoint := range entrypoints {
+		roots[entrypoint] = "entrypoint"
+	}
 
 	// Including the upstream check here ensures that the files containing annotations
 	// representing upstream entrypoints relative to unit's existing entrypoints
 	// are always included in the dependency graph.
-	var upstreamFiles []string
 	for _, fileR := range unit.Files() {
 		for _, annotation := range resolver.UpstreamAnnotations {
 			if upstreamFile, ok := fileR.(*types.SourceFile); ok && upstreamFile.IsAnnotatedWith(annotation) {
-				upstreamDeps, err := resolver.resolveDependencies(unit, []string{upstreamFile.Path()})
+				upstreamDeps, err := resolver.resolveDependencies(unit, map[string]string{upstreamFile.Path(): ""})
 				if err != nil {
 					return nil, err
 				}
 				for _, entrypoint := range entrypoints {
 					if _, dependsOnEntrypoint := upstreamDeps[entrypoint]; dependsOnEntrypoint {
-						upstreamFiles = append(upstreamFiles, upstreamFile.Path())
+						if _, isRoot := roots[upstreamFile.Path()]; !isRoot {
+							roots[upstreamFile.Path()] = fmt.Sprintf("@klotho::%s imports entrypoint %s", annotation, entrypoint)
+						}
 					}
 				}
 			}
 		}
 	}
 
-	return resolver.resolveDependencies(unit, append(entrypoints, upstreamFiles...))
+	return resolver.resolveDependencies(unit, roots)
 }
 
-func (resolver SourceFilesResolver) resolveDependencies(unit *types.ExecutionUnit, entrypoints []string) (map[string]struct{}, error) {
+// resolveDependencies walks the unit's file dependencies breadth-first from the supplied roots (path -> reason),
+// returning every reachable file and the reason it was reached.
+func (resolver SourceFilesResolver) resolveDependencies(unit *types.ExecutionUnit, roots map[string]string) (map[string]string, error) {
 	fileDeps, err := resolver.UnitFileDependencyResolver(unit)
 	if err != nil {
 		return nil, err
 	}
-	resolvedDeps := map[string]struct{}{}
 
-	for _, entrypoint := range entrypoints {
-		resolvedDeps[entrypoint] = struct{}{}
+	resolvedDeps := make(map[string]string, len(roots))
+	var queue []string
+	for root, reason := range roots {
+		resolvedDeps[root] = reason
+		queue = append(queue, root)
 	}
+	sort.Strings(queue)
 
-	for _, fileR := range unit.Files() {
-		if _, ok := resolvedDeps[fileR.Path()]; ok {
-			continue
-		}
-
-		file := fileR
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
 
-		var unprocessedDeps []string
-		for dep := range resolvedDeps {
-			unprocessedDeps = append(unprocessedDeps, dep)
+		var imports []string
+		for importedFilePath := range fileDeps[dep] {
+			imports = append(imports, importedFilePath)
 		}
+		sort.Strings(imports)
 
-		processedDeps := make(map[string]struct{})
-		for len(unprocessedDeps) > 0 {
-
-			dep := unprocessedDeps[0]
-			unprocessedDeps = unprocessedDeps[1:]
-			imports := fileDeps[dep]
-
-			for importedFilePath := range imports {
-				if unit.Get(importedFilePath) == nil {
-					return nil, fmt.Errorf("file '%s' imported by '%s' not found", importedFilePath, dep)
-				}
-				if file.Path() == importedFilePath {
-					resolvedDeps[importedFilePath] = struct{}{}
-				}
-				// add any deps to the queue that haven't already been processed (avoiding circular imports)
-				if _, ok := processedDeps[importedFilePath]; !ok {
-					unprocessedDeps = append(unprocessedDeps, importedFilePath)
-				}
+		for _, importedFilePath := range imports {
+			if unit.Get(importedFilePath) == nil {
+				return nil, fmt.Errorf("file '%s' imported by '%s' not found", importedFilePath, dep)
+			}
+			if _, processed := resolvedDeps[importedFilePath]; processed {
+				continue
 			}
-			processedDeps[dep] = struct{}{}
+			resolvedDeps[importedFilePath] = fmt.Sprintf("imported by %s", dep)
+			queue = append(queue, importedFilePath)
 		}
 	}
 	return resolvedDeps, nil
@@ -100,6 +100,45 @@ func TestSourceFilesResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestSourceFilesResolver_ResolveWithReasons(t *testing.T) {
+	assert := assert.New(t)
+
+	testUnit := types.ExecutionUnit{Name: "main", Executable: types.NewExecutable()}
+	for path, unit := range map[string]string{
+		"main":       "execution_unit:main",
+		"expose":     "expose:gateway",
+		"dep1":       "",
+		"dep2":       "",
+		"expose_dep": "",
+	} {
+		f, err := types.NewSourceFile(path, strings.NewReader(unit), testAnnotationLang)
+		if assert.Nil(err) {
+			testUnit.Add(f)
+		}
+	}
+	testUnit.Executable.Entrypoints["main"] = struct{}{}
+
+	resolver := SourceFilesResolver{
+		UnitFileDependencyResolver: testFileDepResolver([]testFileDep{
+			{filePath: "expose", imports: map[string][]string{"main": {}, "expose_dep": {}}},
+			{filePath: "main", imports: map[string][]string{"dep1": {}, "dep2": {}}},
+			{filePath: "dep1", imports: map[string][]string{"dep2": {}}},
+		}),
+		UpstreamAnnotations: []string{annotation.ExposeCapability},
+	}
+	got, err := resolver.ResolveWithReasons(&testUnit)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(map[string]string{
+		"main":       "entrypoint",
+		"expose":     "@klotho::expose imports entrypoint main",
+		"dep1":       "imported by main",
+		"dep2":       "imported by main",
+		"expose_dep": "imported by expose",
+	}, got)
+}
+
 type testFileDep struct {
 	filePath string
 	imports  map[string][]string
@@ -0,0 +1,159 @@
+package exposeauth
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"go.uber.org/zap"
+)
+
+type (
+	// ExposeAuth sets the auth of each `types.Gateway` from the `auth` directive of its `@klotho::expose` annotations
+	// and from its `config.Expose`, which takes precedence over the directive.
+	//
+	// It must run after the language plugins, which create the gateways.
+	ExposeAuth struct {
+		Config *config.Application
+	}
+)
+
+const (
+	authDirective   = "auth"
+	routesDirective = "routes"
+)
+
+func (p ExposeAuth) Name() string { return "ExposeAuth" }
+
+func (p ExposeAuth) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+
+	gateways := make(map[string]*types.Gateway)
+	for _, gw := range construct.GetConstructsOfType[*types.Gateway](constructGraph) {
+		gateways[gw.Name] = gw
+	}
+
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			ast, ok := f.(*types.SourceFile)
+			if !ok {
+				continue
+			}
+			for _, annot := range ast.Annotations() {
+				cap := annot.Capability
+				if cap.Name != annotation.ExposeCapability {
+					continue
+				}
+				gw, ok := gateways[cap.ID]
+				if !ok {
+					continue
+				}
+				if err := applyDirectives(gw, cap.Directives); err != nil {
+					errs.Append(types.NewCompilerError(ast, annot, err))
+				}
+			}
+		}
+	}
+
+	for _, gw := range gateways {
+		cfg := p.Config.GetExpose(gw.Name)
+		if cfg.Auth != nil {
+			gw.Auth = cfg.Auth.GatewayAuth()
+		}
+		for route, routeCfg := range cfg.Routes {
+			if routeCfg.Auth == nil {
+				continue
+			}
+			if gw.RouteAuth == nil {
+				gw.RouteAuth = make(map[string]*types.GatewayAuth)
+			}
+			gw.RouteAuth[route] = routeCfg.Auth.GatewayAuth()
+		}
+
+		if err := validateAuth(gw, constructGraph); err != nil {
+			errs.Append(err)
+			continue
+		}
+		if gw.Auth != nil {
+			zap.L().Sugar().Debugf("Gateway %s uses %s auth", gw.Name, gw.Auth.Type)
+		}
+	}
+
+	return errs.ErrOrNil()
+}
+
+// applyDirectives sets the gateway's auth from the `auth` directive, which is either a type
+// (e.g. `auth = "iam"`) or a table with the type's settings and per-route overrides.
+func applyDirectives(gw *types.Gateway, directives annotation.Directives) error {
+	if _, ok := directives[authDirective]; !ok {
+		return nil
+	}
+	if authType, ok := directives.String(authDirective); ok {
+		gw.Auth = &types.GatewayAuth{Type: types.GatewayAuthType(authType)}
+		return nil
+	}
+
+	authDirectives := directives.Object(authDirective)
+	if len(authDirectives) == 0 {
+		return fmt.Errorf("'%s' must be a string or a table", authDirective)
+	}
+	if _, ok := authDirectives.String("type"); ok {
+		gw.Auth = parseAuth(authDirectives)
+	}
+	routeDirectives := authDirectives.Object(routesDirective)
+	for route := range routeDirectives {
+		if gw.RouteAuth == nil {
+			gw.RouteAuth = make(map[string]*types.GatewayAuth)
+		}
+		if authType, ok := routeDirectives.String(route); ok {
+			gw.RouteAuth[route] = &types.GatewayAuth{Type: types.GatewayAuthType(authType)}
+		} else {
+			gw.RouteAuth[route] = parseAuth(routeDirectives.Object(route))
+		}
+	}
+	return nil
+}
+
+func parseAuth(directives annotation.Directives) *types.GatewayAuth {
+	authType, _ := directives.String("type")
+	issuer, _ := directives.String("issuer")
+	audience, _ := directives.StringArray("audience")
+	unit, _ := directives.String("execution_unit")
+	return &types.GatewayAuth{
+		Type:         types.GatewayAuthType(authType),
+		Issuer:       issuer,
+		Audience:     audience,
+		ExecUnitName: unit,
+	}
+}
+
+func validateAuth(gw *types.Gateway, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	validate := func(auth *types.GatewayAuth, route string) {
+		if auth == nil {
+			return
+		}
+		if err := auth.Validate(); err != nil {
+			if route != "" {
+				err = fmt.Errorf("route '%s': %w", route, err)
+			}
+			errs.Append(fmt.Errorf("invalid auth for gateway %s: %w", gw.Name, err))
+			return
+		}
+		if auth.Type == types.GatewayAuthLambda {
+			unit := &types.ExecutionUnit{Name: auth.ExecUnitName}
+			if constructGraph.GetConstruct(unit.Id()) == nil {
+				errs.Append(fmt.Errorf("invalid auth for gateway %s: execution unit '%s' does not exist", gw.Name, auth.ExecUnitName))
+			}
+		}
+	}
+
+	validate(gw.Auth, "")
+	for route, auth := range gw.RouteAuth {
+		validate(auth, route)
+	}
+	return errs.ErrOrNil()
+}
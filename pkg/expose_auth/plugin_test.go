@@ -0,0 +1,148 @@
+package exposeauth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/lang/python"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExposeAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        string
+		cfg           map[string]*config.Expose
+		wantAuth      *types.GatewayAuth
+		wantRouteAuth map[string]*types.GatewayAuth
+		wantErr       bool
+	}{
+		{
+			name: "no auth",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   target = "public"
+# }
+app = FastAPI()`,
+		},
+		{
+			name: "auth type",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   auth = "iam"
+# }
+app = FastAPI()`,
+			wantAuth: &types.GatewayAuth{Type: types.GatewayAuthIam},
+		},
+		{
+			name: "jwt auth with route override",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   [auth]
+#   type = "jwt"
+#   issuer = "https://issuer.example.com"
+#   audience = ["api"]
+#   [auth.routes]
+#   "/health" = "none"
+# }
+app = FastAPI()`,
+			wantAuth:      &types.GatewayAuth{Type: types.GatewayAuthJwt, Issuer: "https://issuer.example.com", Audience: []string{"api"}},
+			wantRouteAuth: map[string]*types.GatewayAuth{"/health": {Type: types.GatewayAuthNone}},
+		},
+		{
+			name: "lambda auth",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   [auth]
+#   type = "lambda"
+#   execution_unit = "unit"
+# }
+app = FastAPI()`,
+			wantAuth: &types.GatewayAuth{Type: types.GatewayAuthLambda, ExecUnitName: "unit"},
+		},
+		{
+			name: "config overrides directive",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   auth = "iam"
+# }
+app = FastAPI()`,
+			cfg: map[string]*config.Expose{
+				"gw": {
+					Auth:   &config.ExposeAuth{Type: "cognito"},
+					Routes: map[string]config.ExposeRoute{"POST /users": {Auth: &config.ExposeAuth{Type: "iam"}}},
+				},
+			},
+			wantAuth:      &types.GatewayAuth{Type: types.GatewayAuthCognito},
+			wantRouteAuth: map[string]*types.GatewayAuth{"POST /users": {Type: types.GatewayAuthIam}},
+		},
+		{
+			name: "unknown lambda unit",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   [auth]
+#   type = "lambda"
+#   execution_unit = "other"
+# }
+app = FastAPI()`,
+			wantErr: true,
+		},
+		{
+			name: "jwt auth without issuer",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   auth = "jwt"
+# }
+app = FastAPI()`,
+			wantErr: true,
+		},
+		{
+			name: "unsupported auth type",
+			source: `
+# @klotho::expose {
+#   id = "gw"
+#   auth = "basic"
+# }
+app = FastAPI()`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := python.NewFile("main.py", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit := &types.ExecutionUnit{Name: "unit"}
+			unit.Add(f)
+			gw := types.NewGateway("gw")
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+			graph.AddConstruct(gw)
+
+			p := ExposeAuth{Config: &config.Application{Exposed: tt.cfg}}
+			err = p.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantAuth, gw.Auth)
+			assert.Equal(tt.wantRouteAuth, gw.RouteAuth)
+		})
+	}
+}
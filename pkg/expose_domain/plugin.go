@@ -0,0 +1,29 @@
+package exposedomain
+
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"go.uber.org/zap"
+)
+
+type (
+	// ExposeDomain sets the custom domain of each `types.Gateway` from its `config.Expose`.
+	//
+	// It must run after the language plugins, which create the gateways.
+	ExposeDomain struct {
+		Config *config.Application
+	}
+)
+
+func (p ExposeDomain) Name() string { return "ExposeDomain" }
+
+func (p ExposeDomain) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	for _, gw := range construct.GetConstructsOfType[*types.Gateway](constructGraph) {
+		gw.Domain = p.Config.GetExpose(gw.Name).CustomDomain()
+		if gw.Domain != nil {
+			zap.L().Sugar().Debugf("Gateway %s is served from %s", gw.Name, gw.Domain.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package exposedomain
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExposeDomain(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        map[string]*config.Expose
+		wantDomain *types.CustomDomain
+	}{
+		{
+			name: "no domain",
+		},
+		{
+			name:       "domain",
+			cfg:        map[string]*config.Expose{"gw": {Domain: "api.example.com"}},
+			wantDomain: &types.CustomDomain{Name: "api.example.com"},
+		},
+		{
+			name:       "domain in a hosted zone",
+			cfg:        map[string]*config.Expose{"gw": {Domain: "api.dev.example.com", HostedZone: "example.com"}},
+			wantDomain: &types.CustomDomain{Name: "api.dev.example.com", HostedZone: "example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			gw := types.NewGateway("gw")
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(gw)
+
+			p := ExposeDomain{Config: &config.Application{Exposed: tt.cfg}}
+			err := p.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantDomain, gw.Domain)
+		})
+	}
+}
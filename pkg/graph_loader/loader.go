@@ -10,8 +10,11 @@ import (
 	"github.com/klothoplatform/klotho/pkg/construct"
 	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/aws"
+	"github.com/klothoplatform/klotho/pkg/provider/azure"
 	"github.com/klothoplatform/klotho/pkg/provider/docker"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp"
 	"github.com/klothoplatform/klotho/pkg/provider/kubernetes"
+	"github.com/klothoplatform/klotho/pkg/provider/local"
 	"github.com/klothoplatform/klotho/pkg/yaml_util"
 
 	"github.com/pkg/errors"
@@ -35,6 +38,9 @@ func loadProviders() map[string]provider.Provider {
 		"aws":        &aws.AWS{},
 		"kubernetes": &kubernetes.KubernetesProvider{},
 		"docker":     &docker.DockerProvider{},
+		"local":      &local.LocalProvider{},
+		"gcp":        &gcp.GCP{},
+		"azure":      &azure.Azure{},
 	}
 	return providerMap
 }
@@ -0,0 +1,124 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/provider/local/resources"
+)
+
+// anyVerb is the verb of the routes which handle every method of their path
+const anyVerb = "ANY"
+
+var greedyParam = regexp.MustCompile(`^(:[^/]+)?\*$`)
+
+// nginxConfig returns the nginx server configuration of the router, which proxies each of its routes to the container
+// of the route's unit
+func nginxConfig(router *resources.Router) string {
+	byPath := map[string][]resources.RouterRoute{}
+	var paths []string
+	for _, route := range router.Routes {
+		if _, ok := byPath[route.Path]; !ok {
+			paths = append(paths, route.Path)
+		}
+		byPath[route.Path] = append(byPath[route.Path], route)
+	}
+	// nginx uses the first regex location which matches a request, so more specific paths must come first
+	sort.SliceStable(paths, func(i, j int) bool {
+		si, sj := pathSpecificity(paths[i]), pathSpecificity(paths[j])
+		if si != sj {
+			return si < sj
+		}
+		return paths[i] < paths[j]
+	})
+
+	sb := &strings.Builder{}
+	sb.WriteString("server {\n")
+	sb.WriteString("    listen 80;\n")
+	sb.WriteString("    proxy_set_header Host $host;\n")
+	sb.WriteString("    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n")
+	for _, path := range paths {
+		fmt.Fprintf(sb, "\n    location ~ %s {\n", pathRegex(path))
+		writeProxies(sb, byPath[path])
+		sb.WriteString("    }\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// writeProxies proxies the requests of a path to the containers of its routes. A path whose routes all have the same
+// container is proxied to it directly, otherwise each method is proxied to the container of its route.
+func writeProxies(sb *strings.Builder, routes []resources.RouterRoute) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Verb < routes[j].Verb
+	})
+	sameTarget := true
+	var fallback *resources.Container
+	for _, route := range routes {
+		if route.Target != routes[0].Target {
+			sameTarget = false
+		}
+		if route.Verb == anyVerb {
+			fallback = route.Target
+		}
+	}
+	if sameTarget {
+		fmt.Fprintf(sb, "        proxy_pass %s;\n", upstream(routes[0].Target))
+		return
+	}
+	for _, route := range routes {
+		if route.Verb == anyVerb {
+			continue
+		}
+		fmt.Fprintf(sb, "        if ($request_method = %s) {\n", route.Verb)
+		fmt.Fprintf(sb, "            proxy_pass %s;\n", upstream(route.Target))
+		sb.WriteString("        }\n")
+	}
+	if fallback != nil {
+		fmt.Fprintf(sb, "        proxy_pass %s;\n", upstream(fallback))
+	} else {
+		sb.WriteString("        return 404;\n")
+	}
+}
+
+func upstream(container *resources.Container) string {
+	return fmt.Sprintf("http://%s:%d", resources.ServiceName(container), container.Port)
+}
+
+// pathRegex converts an Express route path to the regex of an nginx location which matches the same requests
+func pathRegex(path string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		switch {
+		case segment == "":
+		case greedyParam.MatchString(segment):
+			sb.WriteString("(/.*)?")
+		case strings.HasPrefix(segment, ":") && strings.HasSuffix(segment, "?"):
+			sb.WriteString("(/[^/]+)?")
+		case strings.HasPrefix(segment, ":"):
+			sb.WriteString("/[^/]+")
+		default:
+			sb.WriteString("/" + regexp.QuoteMeta(segment))
+		}
+	}
+	sb.WriteString("/?$")
+	return sb.String()
+}
+
+// pathSpecificity orders paths from the most to the least specific: literal paths, then paths with parameters, then
+// paths with wildcards
+func pathSpecificity(path string) int {
+	specificity := 0
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case greedyParam.MatchString(segment):
+			return 2
+		case strings.HasPrefix(segment, ":"):
+			specificity = 1
+		}
+	}
+	return specificity
+}
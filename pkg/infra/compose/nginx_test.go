@@ -0,0 +1,94 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/provider/local/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pathRegex(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/", want: "^/?$"},
+		{path: "/users", want: "^/users/?$"},
+		{path: "/users/:id", want: "^/users/[^/]+/?$"},
+		{path: "/users/:id?", want: "^/users(/[^/]+)?/?$"},
+		{path: "/files/:rest*", want: "^/files(/.*)?/?$"},
+		{path: "/files/*", want: "^/files(/.*)?/?$"},
+		{path: "/v1.0/items", want: `^/v1\.0/items/?$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, pathRegex(tt.path))
+		})
+	}
+}
+
+func Test_nginxConfig(t *testing.T) {
+	main := &resources.Container{Name: "container-main", Port: 3000}
+	users := &resources.Container{Name: "container-users", Port: 3000}
+
+	tests := []struct {
+		name   string
+		routes []resources.RouterRoute
+		want   string
+	}{
+		{
+			name: "specific paths first",
+			routes: []resources.RouterRoute{
+				{Path: "/:rest*", Verb: "ANY", Target: main},
+				{Path: "/users/:id", Verb: "GET", Target: users},
+				{Path: "/users", Verb: "GET", Target: users},
+			},
+			want: `server {
+    listen 80;
+    proxy_set_header Host $host;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+
+    location ~ ^/users/?$ {
+        proxy_pass http://container-users:3000;
+    }
+
+    location ~ ^/users/[^/]+/?$ {
+        proxy_pass http://container-users:3000;
+    }
+
+    location ~ ^(/.*)?/?$ {
+        proxy_pass http://container-main:3000;
+    }
+}
+`,
+		},
+		{
+			name: "methods of a path to different containers",
+			routes: []resources.RouterRoute{
+				{Path: "/users", Verb: "POST", Target: users},
+				{Path: "/users", Verb: "GET", Target: main},
+			},
+			want: `server {
+    listen 80;
+    proxy_set_header Host $host;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+
+    location ~ ^/users/?$ {
+        if ($request_method = GET) {
+            proxy_pass http://container-main:3000;
+        }
+        if ($request_method = POST) {
+            proxy_pass http://container-users:3000;
+        }
+        return 404;
+    }
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nginxConfig(&resources.Router{Name: "router-api", Routes: tt.routes}))
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Package compose translates the resources of the local provider into a docker compose project, which runs the whole
+// application on the developer's machine.
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/provider/local/resources"
+	"github.com/klothoplatform/klotho/pkg/sanitization/docker"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	Plugin struct {
+		Config *config.Application
+	}
+
+	composeFile struct {
+		Services map[string]*service  `yaml:"services"`
+		Volumes  map[string]*struct{} `yaml:"volumes,omitempty"`
+		Secrets  map[string]*secret   `yaml:"secrets,omitempty"`
+	}
+
+	service struct {
+		Image       string                     `yaml:"image,omitempty"`
+		Build       *build                     `yaml:"build,omitempty"`
+		Entrypoint  []string                   `yaml:"entrypoint,omitempty"`
+		Command     []string                   `yaml:"command,omitempty"`
+		Environment map[string]string          `yaml:"environment,omitempty"`
+		Ports       []string                   `yaml:"ports,omitempty"`
+		Volumes     []string                   `yaml:"volumes,omitempty"`
+		Secrets     []serviceSecret            `yaml:"secrets,omitempty"`
+		Networks    map[string]*serviceNetwork `yaml:"networks,omitempty"`
+		DependsOn   map[string]dependency      `yaml:"depends_on,omitempty"`
+	}
+
+	build struct {
+		Context string `yaml:"context"`
+	}
+
+	serviceSecret struct {
+		Source string `yaml:"source"`
+		Target string `yaml:"target"`
+	}
+
+	serviceNetwork struct {
+		Aliases []string `yaml:"aliases,omitempty"`
+	}
+
+	dependency struct {
+		Condition string `yaml:"condition"`
+	}
+
+	secret struct {
+		File string `yaml:"file"`
+	}
+)
+
+const (
+	composeFileName = "docker-compose.yml"
+
+	// defaultNetwork is the network which compose connects every service of the project to
+	defaultNetwork = "default"
+
+	// firstRouterPort is the host port which the first router without a HostPort is published on. Each subsequent
+	// router is published on the next free port.
+	firstRouterPort = 8080
+
+	serviceStarted               = "service_started"
+	serviceCompletedSuccessfully = "service_completed_successfully"
+	initServiceSuffix            = "-init"
+	minioClientImage             = "minio/mc:latest"
+	awsCliImage                  = "amazon/aws-cli:latest"
+	nginxConfigDir               = "nginx"
+	nginxContainerConfigPath     = "/etc/nginx/conf.d/default.conf"
+)
+
+// dynamodbLocalCommand shares a single in-memory database between all clients, whatever their credentials and region
+var dynamodbLocalCommand = []string{"-jar", "DynamoDBLocal.jar", "-sharedDb", "-inMemory"}
+
+func (p Plugin) Name() string {
+	return "compose"
+}
+
+func (p Plugin) Translate(dag *construct.ResourceGraph) ([]io.File, error) {
+	project := &composeFile{
+		Services: map[string]*service{},
+		Volumes:  map[string]*struct{}{},
+		Secrets:  map[string]*secret{},
+	}
+	var files []io.File
+	var errs multierr.Error
+
+	for _, res := range dag.ListResources() {
+		switch res := res.(type) {
+		case *resources.Container:
+			svc, err := containerService(dag, res)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			project.Services[resources.ServiceName(res)] = svc
+		case *resources.Postgres:
+			project.Services[resources.ServiceName(res)] = postgresService(project, res)
+		case *resources.Redis:
+			project.Services[resources.ServiceName(res)] = &service{Image: res.Image}
+		case *resources.Minio:
+			svc, init := minioServices(project, res)
+			project.Services[resources.ServiceName(res)] = svc
+			project.Services[resources.ServiceName(res)+initServiceSuffix] = init
+		case *resources.DynamodbLocal:
+			svc, init := dynamodbLocalServices(res)
+			project.Services[resources.ServiceName(res)] = svc
+			project.Services[resources.ServiceName(res)+initServiceSuffix] = init
+		case *resources.SecretFile:
+			for _, path := range res.Paths {
+				project.Secrets[secretName(res, path)] = &secret{File: "./" + path}
+			}
+		case *resources.Router:
+			// routers are published once all of them are known, to assign their host ports
+		default:
+			errs.Append(fmt.Errorf("unsupported resource %s for the compose project", res.Id()))
+		}
+	}
+
+	for _, router := range routersByPort(dag) {
+		conf := nginxConfig(router.router)
+		confPath := fmt.Sprintf("%s/%s.conf", nginxConfigDir, resources.ServiceName(router.router))
+		files = append(files, &io.RawFile{FPath: confPath, Content: []byte(conf)})
+		project.Services[resources.ServiceName(router.router)] = &service{
+			Image:   router.router.Image,
+			Ports:   []string{fmt.Sprintf("%d:80", router.port)},
+			Volumes: []string{fmt.Sprintf("./%s:%s:ro", confPath, nginxContainerConfigPath)},
+		}
+	}
+
+	for _, res := range dag.ListResources() {
+		svc, ok := project.Services[resources.ServiceName(res)]
+		if !ok {
+			continue
+		}
+		for _, dep := range dag.GetDownstreamResources(res) {
+			depName := resources.ServiceName(dep)
+			if _, ok := project.Services[depName]; !ok {
+				continue
+			}
+			if svc.DependsOn == nil {
+				svc.DependsOn = map[string]dependency{}
+			}
+			svc.DependsOn[depName] = dependency{Condition: serviceStarted}
+			if _, ok := project.Services[depName+initServiceSuffix]; ok {
+				svc.DependsOn[depName+initServiceSuffix] = dependency{Condition: serviceCompletedSuccessfully}
+			}
+		}
+	}
+
+	if err := errs.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	content, err := yaml.Marshal(project)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, &io.RawFile{FPath: composeFileName, Content: content})
+	return files, nil
+}
+
+// containerService builds the unit of the container from its output directory, and sets the environment variables
+// which its runtime reads its dependencies from
+func containerService(dag *construct.ResourceGraph, container *resources.Container) (*service, error) {
+	svc := &service{
+		Build: &build{Context: "./" + container.Context},
+	}
+	if len(container.Aliases) > 0 {
+		svc.Networks = map[string]*serviceNetwork{defaultNetwork: {Aliases: container.Aliases}}
+	}
+	var errs multierr.Error
+	for name, value := range container.EnvironmentVariables {
+		resolved, err := resolveValue(dag, value)
+		if err != nil {
+			errs.Append(fmt.Errorf("could not resolve environment variable %s of %s: %w", name, container.Id(), err))
+			continue
+		}
+		if svc.Environment == nil {
+			svc.Environment = map[string]string{}
+		}
+		svc.Environment[name] = resolved
+	}
+	for _, secretFile := range container.Secrets {
+		for _, path := range secretFile.Paths {
+			svc.Secrets = append(svc.Secrets, serviceSecret{Source: secretName(secretFile, path), Target: path})
+		}
+	}
+	return svc, errs.ErrOrNil()
+}
+
+// resolveValue returns the value of the property of the resource which value references, or the property itself for a
+// value which does not reference a resource
+func resolveValue(dag *construct.ResourceGraph, value construct.IaCValue) (string, error) {
+	if value.ResourceId.IsZero() {
+		return value.Property, nil
+	}
+	res := dag.GetResource(value.ResourceId)
+	if res == nil {
+		return "", fmt.Errorf("resource %s does not exist", value.ResourceId)
+	}
+	propertyResource, ok := res.(resources.PropertyResource)
+	if !ok {
+		return "", fmt.Errorf("resource %s has no properties", value.ResourceId)
+	}
+	return propertyResource.GetProperty(value.Property)
+}
+
+func postgresService(project *composeFile, db *resources.Postgres) *service {
+	volume := resources.ServiceName(db) + "-data"
+	project.Volumes[volume] = nil
+	return &service{
+		Image: db.Image,
+		Environment: map[string]string{
+			"POSTGRES_USER":     db.Username,
+			"POSTGRES_PASSWORD": db.Password,
+			"POSTGRES_DB":       db.DatabaseName,
+		},
+		Volumes: []string{volume + ":/var/lib/postgresql/data"},
+	}
+}
+
+// minioServices returns the MinIO server, and the service which creates its bucket once it has started. The server's
+// domain lets it serve the bucket by virtual host, which is how the AWS SDKs address buckets, so the bucket's host is
+// an alias of the server.
+func minioServices(project *composeFile, minio *resources.Minio) (*service, *service) {
+	name := resources.ServiceName(minio)
+	volume := name + "-data"
+	project.Volumes[volume] = nil
+	svc := &service{
+		Image:   minio.Image,
+		Command: []string{"server", "/data"},
+		Environment: map[string]string{
+			"MINIO_ROOT_USER":     resources.LOCAL_ACCESS_KEY_ID,
+			"MINIO_ROOT_PASSWORD": resources.LOCAL_SECRET_ACCESS_KEY,
+			"MINIO_DOMAIN":        name,
+		},
+		Volumes:  []string{volume + ":/data"},
+		Networks: map[string]*serviceNetwork{defaultNetwork: {Aliases: []string{fmt.Sprintf("%s.%s", minio.BucketName, name)}}},
+	}
+	init := &service{
+		Image:      minioClientImage,
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Command: []string{fmt.Sprintf(
+			"until mc alias set local %s %s %s; do sleep 1; done && mc mb --ignore-existing local/%s",
+			minio.Endpoint(), resources.LOCAL_ACCESS_KEY_ID, resources.LOCAL_SECRET_ACCESS_KEY, minio.BucketName,
+		)},
+		DependsOn: map[string]dependency{name: {Condition: serviceStarted}},
+	}
+	return svc, init
+}
+
+// dynamodbLocalServices returns the DynamoDB Local server, and the service which creates its table once it has
+// started, keyed the way the kv runtimes key their items
+func dynamodbLocalServices(db *resources.DynamodbLocal) (*service, *service) {
+	name := resources.ServiceName(db)
+	svc := &service{
+		Image:   db.Image,
+		Command: dynamodbLocalCommand,
+	}
+	endpoint := db.Endpoint()
+	init := &service{
+		Image:      awsCliImage,
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Command: []string{fmt.Sprintf(
+			"until aws dynamodb list-tables --endpoint-url %[1]s > /dev/null; do sleep 1; done && "+
+				"(aws dynamodb describe-table --endpoint-url %[1]s --table-name %[2]s > /dev/null 2>&1 || "+
+				"aws dynamodb create-table --endpoint-url %[1]s --table-name %[2]s --billing-mode PAY_PER_REQUEST "+
+				"--attribute-definitions AttributeName=%[3]s,AttributeType=S AttributeName=%[4]s,AttributeType=S "+
+				"--key-schema AttributeName=%[3]s,KeyType=HASH AttributeName=%[4]s,KeyType=RANGE)",
+			endpoint, db.TableName, db.HashKey, db.RangeKey,
+		)},
+		Environment: map[string]string{
+			"AWS_REGION":            resources.LOCAL_REGION,
+			"AWS_ACCESS_KEY_ID":     resources.LOCAL_ACCESS_KEY_ID,
+			"AWS_SECRET_ACCESS_KEY": resources.LOCAL_SECRET_ACCESS_KEY,
+		},
+		DependsOn: map[string]dependency{name: {Condition: serviceStarted}},
+	}
+	return svc, init
+}
+
+// secretName is the name of the compose secret which holds the file at path of the secret
+func secretName(secretFile *resources.SecretFile, path string) string {
+	return docker.ServiceNameSanitizer.Apply(fmt.Sprintf("%s-%s", secretFile.Name, path))
+}
+
+type publishedRouter struct {
+	router *resources.Router
+	port   int
+}
+
+// routersByPort returns the routers of the graph with the host port each is published on. Routers without a HostPort
+// are assigned the free ports from firstRouterPort, in order of their names so that the ports are stable.
+func routersByPort(dag *construct.ResourceGraph) []publishedRouter {
+	routers := construct.GetResources[*resources.Router](dag)
+	sort.Slice(routers, func(i, j int) bool {
+		return routers[i].Name < routers[j].Name
+	})
+	used := map[int]bool{}
+	for _, router := range routers {
+		if router.HostPort != 0 {
+			used[router.HostPort] = true
+		}
+	}
+	var published []publishedRouter
+	next := firstRouterPort
+	for _, router := range routers {
+		port := router.HostPort
+		if port == 0 {
+			for used[next] {
+				next++
+			}
+			port = next
+			used[port] = true
+		}
+		published = append(published, publishedRouter{router: router, port: port})
+	}
+	return published
+}
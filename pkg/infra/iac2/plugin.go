@@ -10,6 +10,7 @@ import (
 	"github.com/klothoplatform/klotho/pkg/construct"
 	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
 	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
 	"github.com/klothoplatform/klotho/pkg/templateutils"
 )
@@ -30,6 +31,21 @@ var files embed.FS
 var pulumiBase = templateutils.MustTemplate(files, "Pulumi.yaml.tmpl")
 var pulumiStack = templateutils.MustTemplate(files, "Pulumi.dev.yaml.tmpl")
 
+func renderAwsPreamble(buf *bytes.Buffer, tc *TemplatesCompiler) error {
+	buf.WriteString(`
+const awsConfig = new pulumi.Config('aws')
+const awsProfile = awsConfig.get('profile')` + "\n")
+	if err := tc.renderResource(buf, resources.NewAccountId()); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	if err := tc.renderResource(buf, resources.NewRegion()); err != nil {
+		return err
+	}
+	buf.WriteString("\n\n")
+	return nil
+}
+
 func (p Plugin) Translate(cloudGraph *construct.ResourceGraph) ([]io.File, error) {
 
 	// TODO We'll eventually want to split the output into different files, but we don't know exactly what that looks
@@ -47,17 +63,13 @@ func (p Plugin) Translate(cloudGraph *construct.ResourceGraph) ([]io.File, error
 
 	buf.WriteString("const kloConfig: pulumi.Config = new pulumi.Config('klo')\n")
 	buf.WriteString("const protect = kloConfig.getBoolean('protect') ?? false")
-	buf.WriteString(`
-const awsConfig = new pulumi.Config('aws')
-const awsProfile = awsConfig.get('profile')` + "\n")
-	if err := tc.renderResource(buf, resources.NewAccountId()); err != nil {
+	// gcp and azure resources read their project or subscription and region from the stack's config, so only aws
+	// needs its account and region
+	if p.Config.Provider == provider.GCP || p.Config.Provider == provider.AZURE {
+		buf.WriteString("\n\n")
+	} else if err := renderAwsPreamble(buf, tc); err != nil {
 		return nil, err
 	}
-	buf.WriteString("\n")
-	if err := tc.renderResource(buf, resources.NewRegion()); err != nil {
-		return nil, err
-	}
-	buf.WriteString("\n\n")
 
 	if err := tc.RenderBody(buf); err != nil {
 		return nil, err
@@ -85,7 +97,7 @@ const awsProfile = awsConfig.get('profile')` + "\n")
 	if err != nil {
 		return nil, err
 	}
-	pulumiStack, err := addTemplate(fmt.Sprintf("Pulumi.%s.yaml", p.Config.AppName), pulumiStack, p.Config)
+	pulumiStack, err := addTemplate(fmt.Sprintf("Pulumi.%s.yaml", p.Config.StackName()), pulumiStack, p.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,21 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    DomainName: string
+    Region: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.acm.Certificate {
+    return new aws.acm.Certificate(
+        args.Name,
+        {
+            domainName: args.DomainName,
+            validationMethod: 'DNS',
+        },
+        //TMPL {{- if .Region.Raw }}
+        { provider: new aws.Provider(`${args.Name}-certificate`, { region: args.Region }) }
+        //TMPL {{- end }}
+    )
+}
@@ -0,0 +1,21 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Certificate: aws.acm.Certificate
+    Records: aws.route53.Record[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.acm.CertificateValidation {
+    return new aws.acm.CertificateValidation(
+        args.Name,
+        {
+            certificateArn: args.Certificate.arn,
+            validationRecordFqdns: args.Records.map((record) => record.fqdn),
+        },
+        //TMPL {{- if .Certificate.Raw.Region }}
+        //TMPL { provider: new aws.Provider(`${args.Name}-validation`, { region: '{{ .Certificate.Raw.Region }}' }) }
+        //TMPL {{- end }}
+    )
+}
@@ -0,0 +1,25 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    RestApi: aws.apigateway.RestApi
+    Type: string
+    UserPools: aws.cognito.UserPool[]
+    Function: aws.lambda.Function
+    IdentitySource: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.Authorizer {
+    return new aws.apigateway.Authorizer(args.Name, {
+        restApi: args.RestApi.id,
+        type: args.Type,
+        identitySource: args.IdentitySource,
+        //TMPL {{- if .UserPools.Raw }}
+        providerArns: args.UserPools.map((pool) => pool.arn),
+        //TMPL {{- end }}
+        //TMPL {{- if .Function.Raw }}
+        authorizerUri: args.Function.invokeArn,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,17 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    RestApi: aws.apigateway.RestApi
+    Stage: aws.apigateway.Stage
+    DomainName: aws.apigateway.DomainName
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.BasePathMapping {
+    return new aws.apigateway.BasePathMapping(args.Name, {
+        restApi: args.RestApi.id,
+        stageName: args.Stage.stageName,
+        domainName: args.DomainName.domainName,
+    })
+}
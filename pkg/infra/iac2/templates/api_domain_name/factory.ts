@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    DomainName: string
+    Certificate: aws.acm.CertificateValidation
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.DomainName {
+    return new aws.apigateway.DomainName(args.Name, {
+        domainName: args.DomainName,
+        regionalCertificateArn: args.Certificate.certificateArn,
+        endpointConfiguration: { types: 'REGIONAL' },
+    })
+}
@@ -7,6 +7,7 @@ interface Args {
     HttpMethod: string
     RequestParameters: Record<string, boolean>
     Authorization: string
+    Authorizer: aws.apigateway.Authorizer
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -22,6 +23,9 @@ function create(args: Args): aws.apigateway.Method {
             //TMPL {{- end }}
             httpMethod: args.HttpMethod,
             authorization: args.Authorization,
+            //TMPL {{- if .Authorizer.Raw }}
+            authorizerId: args.Authorizer.id,
+            //TMPL {{- end }}
             //TMPL {{- if .RequestParameters.Raw }}
             requestParameters: args.RequestParameters,
             //TMPL {{- end }}
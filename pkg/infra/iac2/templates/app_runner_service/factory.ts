@@ -7,6 +7,7 @@ interface Args {
     Image: docker.Image
     InstanceRole: aws.iam.Role
     EnvironmentVariables: Record<string, pulumi.Output<string>>
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -36,5 +37,8 @@ function create(args: Args): aws.apprunner.Service {
                 isPubliclyAccessible: true,
             },
         },
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
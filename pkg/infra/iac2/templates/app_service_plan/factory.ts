@@ -0,0 +1,22 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    SkuName: string
+    SkuTier: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.web.AppServicePlan {
+    return new azure.web.AppServicePlan(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        kind: 'functionapp',
+        // linux plans are reserved
+        reserved: true,
+        sku: {
+            name: args.SkuName,
+            tier: args.SkuTier,
+        },
+    })
+}
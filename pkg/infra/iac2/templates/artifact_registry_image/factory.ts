@@ -0,0 +1,38 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as docker from '@pulumi/docker'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    Name: string
+    Repository: gcp.artifactregistry.Repository
+    ImageName: string
+    Context: string
+    Dockerfile: string
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): docker.Image {
+    return (() => {
+        const registry = pulumi.interpolate`${args.Repository.location}-docker.pkg.dev`
+        return new docker.Image(
+            args.Name,
+            {
+                build: {
+                    context: args.Context,
+                    dockerfile: args.Dockerfile,
+                    platform: 'linux/amd64',
+                },
+                registry: {
+                    server: registry,
+                    username: 'oauth2accesstoken',
+                    password: gcp.organizations.getClientConfigOutput().accessToken,
+                },
+                imageName: pulumi.interpolate`${registry}/${args.Repository.project}/${args.Repository.repositoryId}/${args.ImageName}:latest`,
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+    })()
+}
@@ -0,0 +1,19 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    RepositoryId: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.artifactregistry.Repository {
+    return new gcp.artifactregistry.Repository(
+        args.ResourceName,
+        {
+            repositoryId: args.RepositoryId,
+            format: 'DOCKER',
+        },
+        { protect: args.protect }
+    )
+}
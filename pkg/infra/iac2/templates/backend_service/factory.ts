@@ -0,0 +1,17 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    LoadBalancingScheme: string
+    Protocol: string
+    Backends: gcp.compute.RegionNetworkEndpointGroup[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.BackendService {
+    return new gcp.compute.BackendService(args.ResourceName, {
+        loadBalancingScheme: args.LoadBalancingScheme,
+        protocol: args.Protocol,
+        backends: args.Backends.map((neg) => ({ group: neg.id })),
+    })
+}
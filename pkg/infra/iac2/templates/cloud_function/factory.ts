@@ -0,0 +1,67 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Runtime: string
+    EntryPoint: string
+    Source: gcp.storage.BucketObject
+    ServiceAccount: gcp.serviceaccount.Account
+    EnvironmentVariables: Record<string, pulumi.Input<string>>
+    BuildEnvironment: Record<string, string>
+    VpcConnector: gcp.vpcaccess.Connector
+    AvailableMemory: string
+    TimeoutSeconds: number
+    AllowUnauthenticated: boolean
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.cloudfunctionsv2.Function {
+    return (() => {
+        const fn = new gcp.cloudfunctionsv2.Function(
+            args.ResourceName,
+            {
+                location: gcp.config.region!,
+                buildConfig: {
+                    runtime: args.Runtime,
+                    entryPoint: args.EntryPoint,
+                    environmentVariables: args.BuildEnvironment,
+                    source: {
+                        storageSource: {
+                            bucket: args.Source.bucket,
+                            object: args.Source.name,
+                        },
+                    },
+                },
+                serviceConfig: {
+                    availableMemory: args.AvailableMemory,
+                    timeoutSeconds: args.TimeoutSeconds,
+                    serviceAccountEmail: args.ServiceAccount.email,
+                    environmentVariables: args.EnvironmentVariables,
+                    //TMPL {{- if .VpcConnector.Raw }}
+                    vpcConnector: args.VpcConnector.id,
+                    vpcConnectorEgressSettings: 'PRIVATE_RANGES_ONLY',
+                    //TMPL {{- end }}
+                },
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+        //TMPL {{- if .AllowUnauthenticated.Raw }}
+        // 2nd gen functions are served by a cloud run service of the same name
+        new gcp.cloudrun.IamMember(
+            `${args.ResourceName}-invoker`,
+            {
+                service: fn.name,
+                location: fn.location,
+                role: 'roles/run.invoker',
+                member: 'allUsers',
+            },
+            { parent: fn }
+        )
+        //TMPL {{- end }}
+        return fn
+    })()
+}
@@ -0,0 +1,85 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as docker from '@pulumi/docker'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Image: docker.Image
+    ServiceAccount: gcp.serviceaccount.Account
+    Port: number
+    EnvironmentVariables: Record<string, pulumi.Input<string>>
+    SqlInstances: gcp.sql.DatabaseInstance[]
+    VpcConnector: gcp.vpcaccess.Connector
+    AllowUnauthenticated: boolean
+    CpuAlwaysAllocated: boolean
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.cloudrunv2.Service {
+    return (() => {
+        const service = new gcp.cloudrunv2.Service(
+            args.ResourceName,
+            {
+                location: gcp.config.region!,
+                ingress: 'INGRESS_TRAFFIC_ALL',
+                template: {
+                    serviceAccount: args.ServiceAccount.email,
+                    containers: [
+                        {
+                            image: args.Image.repoDigest,
+                            ports: [{ containerPort: args.Port }],
+                            envs: Object.entries(args.EnvironmentVariables).map(([name, value]) => ({
+                                name,
+                                value,
+                            })),
+                            resources: {
+                                cpuIdle: !args.CpuAlwaysAllocated,
+                            },
+                            //TMPL {{- if .SqlInstances.Raw }}
+                            volumeMounts: [{ name: 'cloudsql', mountPath: '/cloudsql' }],
+                            //TMPL {{- end }}
+                        },
+                    ],
+                    //TMPL {{- if .SqlInstances.Raw }}
+                    volumes: [
+                        {
+                            name: 'cloudsql',
+                            cloudSqlInstance: {
+                                instances: args.SqlInstances.map((instance) => instance.connectionName),
+                            },
+                        },
+                    ],
+                    //TMPL {{- end }}
+                    //TMPL {{- if .VpcConnector.Raw }}
+                    vpcAccess: {
+                        connector: args.VpcConnector.id,
+                        egress: 'PRIVATE_RANGES_ONLY',
+                    },
+                    //TMPL {{- end }}
+                    //TMPL {{- if .CpuAlwaysAllocated.Raw }}
+                    scaling: {
+                        minInstanceCount: 1,
+                    },
+                    //TMPL {{- end }}
+                },
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+        //TMPL {{- if .AllowUnauthenticated.Raw }}
+        new gcp.cloudrunv2.ServiceIamMember(
+            `${args.ResourceName}-invoker`,
+            {
+                name: service.name,
+                location: service.location,
+                role: 'roles/run.invoker',
+                member: 'allUsers',
+            },
+            { parent: service }
+        )
+        //TMPL {{- end }}
+        return service
+    })()
+}
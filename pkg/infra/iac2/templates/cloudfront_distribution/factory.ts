@@ -9,17 +9,32 @@ interface Args {
     DefaultCacheBehavior: aws.types.input.cloudfront.DistributionDefaultCacheBehavior
     Restrictions: aws.types.input.cloudfront.DistributionRestrictions
     DefaultRootObject: string
+    Aliases: string[]
+    Certificate: aws.acm.CertificateValidation
+    Tags: Record<string, string>
 }
 
 function create(args: Args): aws.cloudfront.Distribution {
     return new aws.cloudfront.Distribution(args.Name, {
         origins: args.Origins,
         enabled: args.Enabled,
+        //TMPL {{- if .Certificate.Raw }}
+        aliases: args.Aliases,
         viewerCertificate: {
-            cloudfrontDefaultCertificate: args.CloudfrontDefaultCertificate,
+            acmCertificateArn: args.Certificate.certificateArn,
+            sslSupportMethod: 'sni-only',
+            minimumProtocolVersion: 'TLSv1.2_2021',
         },
+        //TMPL {{- else }}
+        //TMPL viewerCertificate: {
+        //TMPL     cloudfrontDefaultCertificate: args.CloudfrontDefaultCertificate,
+        //TMPL },
+        //TMPL {{- end }}
         defaultCacheBehavior: args.DefaultCacheBehavior,
         restrictions: args.Restrictions,
         defaultRootObject: args.DefaultRootObject,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
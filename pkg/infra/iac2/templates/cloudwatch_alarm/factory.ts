@@ -0,0 +1,43 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    AlarmDescription: string
+    Namespace: string
+    MetricName: string
+    Dimensions: Record<string, pulumi.Output<string>>
+    Statistic: string
+    Period: number
+    EvaluationPeriods: number
+    Threshold: number
+    ComparisonOperator: string
+    TreatMissingData?: string
+    AlarmActions: pulumi.Output<string>[]
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.MetricAlarm {
+    return new aws.cloudwatch.MetricAlarm(args.Name, {
+        alarmDescription: args.AlarmDescription,
+        namespace: args.Namespace,
+        metricName: args.MetricName,
+        dimensions: args.Dimensions,
+        statistic: args.Statistic,
+        period: args.Period,
+        evaluationPeriods: args.EvaluationPeriods,
+        threshold: args.Threshold,
+        comparisonOperator: args.ComparisonOperator,
+        //TMPL {{- if .TreatMissingData.Raw }}
+        treatMissingData: args.TreatMissingData,
+        //TMPL {{- end }}
+        //TMPL {{- if .AlarmActions.Raw }}
+        alarmActions: args.AlarmActions,
+        okActions: args.AlarmActions,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
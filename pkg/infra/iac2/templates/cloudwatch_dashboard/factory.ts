@@ -0,0 +1,46 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    DashboardName: string
+    Region: pulumi.Output<pulumi.UnwrappedObject<aws.GetRegionResult>>
+    Alarms: aws.cloudwatch.MetricAlarm[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.Dashboard {
+    return new aws.cloudwatch.Dashboard(args.Name, {
+        dashboardName: args.DashboardName,
+        dashboardBody: pulumi
+            .all([args.Region.name, pulumi.all(args.Alarms.map((alarm) => alarm.arn))])
+            .apply(([region, alarmArns]) =>
+                JSON.stringify({
+                    widgets: [
+                        {
+                            type: 'alarm',
+                            x: 0,
+                            y: 0,
+                            width: 24,
+                            height: 3,
+                            properties: { title: 'Alarms', alarms: alarmArns },
+                        },
+                        // a metric widget of an alarm shows the metric the alarm watches along with its threshold
+                        ...alarmArns.map((arn, i) => ({
+                            type: 'metric',
+                            x: (i % 3) * 8,
+                            y: 3 + Math.floor(i / 3) * 6,
+                            width: 8,
+                            height: 6,
+                            properties: {
+                                title: arn.split(':alarm:').pop(),
+                                annotations: { alarms: [arn] },
+                                view: 'timeSeries',
+                                region: region,
+                            },
+                        })),
+                    ],
+                })
+            ),
+    })
+}
@@ -0,0 +1,17 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cognito.UserPool {
+    return new aws.cognito.UserPool(args.Name, {
+        autoVerifiedAttributes: ['email'],
+        usernameAttributes: ['email'],
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
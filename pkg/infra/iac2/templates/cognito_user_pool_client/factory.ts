@@ -0,0 +1,14 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    UserPool: aws.cognito.UserPool
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cognito.UserPoolClient {
+    return new aws.cognito.UserPoolClient(args.Name, {
+        userPoolId: args.UserPool.id,
+        explicitAuthFlows: ['ALLOW_USER_SRP_AUTH', 'ALLOW_REFRESH_TOKEN_AUTH'],
+    })
+}
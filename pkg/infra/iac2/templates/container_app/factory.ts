@@ -0,0 +1,79 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as docker from '@pulumi/docker'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Environment: azure.app.ManagedEnvironment
+    Image: docker.Image
+    Registry: azure.containerregistry.Registry
+    Identity: azure.managedidentity.UserAssignedIdentity
+    Port: number
+    EnvironmentVariables: Record<string, pulumi.Input<string>>
+    ExternalIngress: boolean
+    MinReplicas: number
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.app.ContainerApp {
+    return (() => {
+        const credentials = azure.containerregistry.listRegistryCredentialsOutput({
+            resourceGroupName: args.ResourceGroup.name,
+            registryName: args.Registry.name,
+        })
+        return new azure.app.ContainerApp(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                managedEnvironmentId: args.Environment.id,
+                identity: {
+                    type: azure.app.ManagedServiceIdentityType.UserAssigned,
+                    userAssignedIdentities: [args.Identity.id],
+                },
+                configuration: {
+                    ingress: {
+                        external: args.ExternalIngress,
+                        targetPort: args.Port,
+                    },
+                    registries: [
+                        {
+                            server: args.Registry.loginServer,
+                            username: credentials.apply((c) => c.username!),
+                            passwordSecretRef: 'registry-password',
+                        },
+                    ],
+                    secrets: [
+                        {
+                            name: 'registry-password',
+                            value: credentials.apply((c) => c.passwords![0].value!),
+                        },
+                    ],
+                },
+                template: {
+                    containers: [
+                        {
+                            name: 'app',
+                            image: args.Image.repoDigest,
+                            env: [
+                                // the runtime's DefaultAzureCredential authenticates as the app's identity
+                                { name: 'AZURE_CLIENT_ID', value: args.Identity.clientId },
+                                ...Object.entries(args.EnvironmentVariables).map(([name, value]) => ({
+                                    name,
+                                    value,
+                                })),
+                            ],
+                        },
+                    ],
+                    scale: {
+                        minReplicas: args.MinReplicas,
+                    },
+                },
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+    })()
+}
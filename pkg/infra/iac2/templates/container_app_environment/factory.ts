@@ -0,0 +1,13 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.app.ManagedEnvironment {
+    return new azure.app.ManagedEnvironment(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+    })
+}
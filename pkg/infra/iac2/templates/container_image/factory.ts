@@ -0,0 +1,42 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as docker from '@pulumi/docker'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    Name: string
+    Registry: azure.containerregistry.Registry
+    ResourceGroup: azure.resources.ResourceGroup
+    ImageName: string
+    Context: string
+    Dockerfile: string
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): docker.Image {
+    return (() => {
+        const credentials = azure.containerregistry.listRegistryCredentialsOutput({
+            resourceGroupName: args.ResourceGroup.name,
+            registryName: args.Registry.name,
+        })
+        return new docker.Image(
+            args.Name,
+            {
+                build: {
+                    context: args.Context,
+                    dockerfile: args.Dockerfile,
+                    platform: 'linux/amd64',
+                },
+                registry: {
+                    server: args.Registry.loginServer,
+                    username: credentials.apply((c) => c.username!),
+                    password: pulumi.secret(credentials.apply((c) => c.passwords![0].value!)),
+                },
+                imageName: pulumi.interpolate`${args.Registry.loginServer}/${args.ImageName}:latest`,
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+    })()
+}
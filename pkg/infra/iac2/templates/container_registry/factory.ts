@@ -0,0 +1,15 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.containerregistry.Registry {
+    return new azure.containerregistry.Registry(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        sku: { name: 'Basic' },
+        adminUserEnabled: true,
+    })
+}
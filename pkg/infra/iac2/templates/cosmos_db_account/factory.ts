@@ -0,0 +1,68 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    DataContributors: azure.managedidentity.UserAssignedIdentity[]
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.documentdb.DatabaseAccount {
+    return (() => {
+        const account = new azure.documentdb.DatabaseAccount(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                databaseAccountOfferType: 'Standard',
+                locations: [{ locationName: args.ResourceGroup.location, failoverPriority: 0 }],
+                capabilities: [{ name: 'EnableServerless' }],
+                consistencyPolicy: { defaultConsistencyLevel: 'Session' },
+                disableLocalAuth: true,
+            },
+            { protect: args.protect }
+        )
+        const database = new azure.documentdb.SqlResourceSqlDatabase(
+            `${args.ResourceName}-klotho`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                accountName: account.name,
+                resource: { id: 'klotho' },
+            },
+            { parent: account }
+        )
+        new azure.documentdb.SqlResourceSqlContainer(
+            `${args.ResourceName}-kv`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                accountName: account.name,
+                databaseName: database.name,
+                resource: {
+                    id: 'kv',
+                    partitionKey: { paths: ['/map_id'], kind: 'Hash' },
+                    // entries only expire when the map they belong to has a ttl
+                    defaultTtl: -1,
+                },
+            },
+            { parent: database }
+        )
+        //TMPL {{- if .DataContributors.Raw }}
+        for (const [i, identity] of args.DataContributors.entries()) {
+            // 00000000-0000-0000-0000-000000000002 is the built-in Cosmos DB Built-in Data Contributor role
+            new azure.documentdb.SqlResourceSqlRoleAssignment(
+                `${args.ResourceName}-contributor-${i}`,
+                {
+                    resourceGroupName: args.ResourceGroup.name,
+                    accountName: account.name,
+                    roleDefinitionId: pulumi.interpolate`${account.id}/sqlRoleDefinitions/00000000-0000-0000-0000-000000000002`,
+                    principalId: identity.principalId,
+                    scope: account.id,
+                },
+                { parent: account }
+            )
+        }
+        //TMPL {{- end }}
+        return account
+    })()
+}
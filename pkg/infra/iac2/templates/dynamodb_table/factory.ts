@@ -8,7 +8,9 @@ interface Args {
     HashKey: string
     RangeKey: string
     BillingMode: string
+    Replicas: string[]
     protect: boolean
+    Tags: Record<string, string>
 }
 
 function create(args: Args): aws.dynamodb.Table {
@@ -21,6 +23,14 @@ function create(args: Args): aws.dynamodb.Table {
             rangeKey: args.RangeKey,
             //TMPL {{- end }}
             billingMode: args.BillingMode,
+            //TMPL {{- if .Replicas.Raw }}
+            streamEnabled: true,
+            streamViewType: 'NEW_AND_OLD_IMAGES',
+            replicas: args.Replicas.map((regionName) => ({ regionName })),
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
@@ -10,25 +10,60 @@ interface Args {
     Context: string
     Dockerfile: string
     BaseImage: string
+    LambdaLayers: {
+        buildArg: string
+        versions: { region?: string; architecture: string; arn: string }[]
+    }[]
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): docker.Image {
     return (() => {
+        const platform = 'linux/amd64'
         //TMPL {{- if .BaseImage.Raw }}
         const pullBaseImage = new command.local.Command(
             `${args.Name}-pull-base-image-${Date.now()}`,
             { create: pulumi.interpolate`docker pull ${args.BaseImage}` }
         )
         //TMPL {{- end }}
+        //TMPL {{- if .LambdaLayers.Raw }}
+        // the urls of the layers' content expire after a few minutes, so they are fetched from the deployment's region each time
+        const buildArgs = pulumi.output(aws.getRegion({})).apply(async (region) => {
+            const { LambdaClient, GetLayerVersionByArnCommand } = require('@aws-sdk/client-lambda')
+            const lambda = new LambdaClient({ region: region.name })
+            const urls: Record<string, string> = {}
+            // the layers must be built for the same architecture as the image they are added to
+            const architecture = platform.split('/')[1]
+            for (const layer of args.LambdaLayers) {
+                const layerVersion =
+                    layer.versions.find((v) => v.region === region.name && v.architecture === architecture) ??
+                    layer.versions.find((v) => !v.region && v.architecture === architecture)
+                if (layerVersion === undefined) {
+                    throw new Error(
+                        `no version of the layer for ${layer.buildArg} is configured for ${architecture} in ${region.name}`
+                    )
+                }
+                const version = await lambda.send(
+                    new GetLayerVersionByArnCommand({
+                        Arn: layerVersion.arn.replace('{region}', region.name),
+                    })
+                )
+                urls[layer.buildArg] = version.Content.Location
+            }
+            return urls
+        })
+        //TMPL {{- end }}
         const base = new docker.Image(
             `${args.Name}-base`,
             {
                 build: {
                     context: args.Context,
                     dockerfile: args.Dockerfile,
-                    platform: 'linux/amd64',
+                    platform: platform,
+                    //TMPL {{- if .LambdaLayers.Raw }}
+                    args: buildArgs,
+                    //TMPL {{- end }}
                 },
                 skipPush: true,
                 imageName: pulumi.interpolate`${args.Repo.repositoryUrl}:${args.TagBase}-base`,
@@ -52,7 +87,10 @@ function create(args: Args): docker.Image {
                 build: {
                     context: args.Context,
                     dockerfile: args.Dockerfile,
-                    platform: 'linux/amd64',
+                    platform: platform,
+                    //TMPL {{- if .LambdaLayers.Raw }}
+                    args: buildArgs,
+                    //TMPL {{- end }}
                 },
                 registry: aws.ecr
                     .getAuthorizationTokenOutput(
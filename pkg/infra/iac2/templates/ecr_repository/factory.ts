@@ -3,6 +3,7 @@ import * as aws from '@pulumi/aws'
 interface Args {
     Name: string
     SanitizedName: string
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -15,7 +16,9 @@ function create(args: Args): aws.ecr.Repository {
         forceDelete: true,
         encryptionConfigurations: [{ encryptionType: 'KMS' }],
         tags: {
-            env: 'production',
+            //TMPL {{- if .Tags.Raw }}
+            ...args.Tags,
+            //TMPL {{- end }}
             AppName: args.Name,
         },
     })
@@ -2,9 +2,14 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.ecs.Cluster {
-    return new aws.ecs.Cluster(args.Name, {})
+    return new aws.ecs.Cluster(args.Name, {
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
 }
@@ -19,6 +19,7 @@ interface Args {
     Name: string
     LoadBalancers: any[]
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -53,6 +54,9 @@ function create(args: Args): aws.ecs.Service {
             },
             //TMPL {{- end }}
             taskDefinition: args.TaskDefinition.arn,
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
         { dependsOn: args.dependsOn }
     )
@@ -16,6 +16,8 @@ interface Args {
     PortMappings?: Record<string, object>
     RequiresCompatibilities?: string[]
     EfsVolumes: awsInputs.ecs.TaskDefinitionVolumeEfsVolumeConfiguration[]
+    Sidecars: object[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -36,6 +38,10 @@ function create(args: Args): aws.ecs.TaskDefinition {
         //TMPL {{- end }}
         //TMPL {{- if .ExecutionRole.Raw }}
         executionRoleArn: args.ExecutionRole.arn,
+        //TMPL {{- if .Sidecars.Raw }}
+        // the execution role holds the permissions of the task, which its sidecars use through the task role
+        taskRoleArn: args.ExecutionRole.arn,
+        //TMPL {{- end }}
         //TMPL {{- end }}
         containerDefinitions: pulumi.jsonStringify([
             {
@@ -61,6 +67,12 @@ function create(args: Args): aws.ecs.TaskDefinition {
                 volumes: args.EfsVolumes,
                 //TMPL {{- end }}
             },
+            //TMPL {{- if .Sidecars.Raw }}
+            ...args.Sidecars,
+            //TMPL {{- end }}
         ]),
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
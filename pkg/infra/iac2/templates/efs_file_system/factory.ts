@@ -13,6 +13,7 @@ interface Args {
     Encrypted?: Promise<boolean> | pulumi.OutputInstance<boolean> | boolean
     CreationToken?: Promise<string> | pulumi.OutputInstance<string> | string
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -44,6 +45,9 @@ function create(args: Args): aws.efs.FileSystem {
             //TMPL {{- if .ThroughputMode.Raw }}
             throughputMode: args.ThroughputMode,
             //TMPL {{- end }}
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
         {
             dependsOn: args.dependsOn,
@@ -5,6 +5,7 @@ interface Args {
     Subnets: aws.ec2.Subnet[]
     SecurityGroups: aws.ec2.SecurityGroup[]
     ClusterRole: aws.iam.Role
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -17,5 +18,8 @@ function create(args: Args): aws.eks.Cluster {
             //TMPL {{- end }}
         },
         roleArn: args.ClusterRole.arn,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -2,9 +2,14 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.ec2.Eip {
-    return new aws.ec2.Eip(args.Name, {})
+    return new aws.ec2.Eip(args.Name, {
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
 }
@@ -8,6 +8,7 @@ interface Args {
     SecurityGroups: aws.ec2.SecurityGroup[]
     NodeType: string
     NumCacheNodes: number
+    Tags: Record<string, string>
 }
 
 function create(args: Args): aws.elasticache.Cluster {
@@ -31,5 +32,8 @@ function create(args: Args): aws.elasticache.Cluster {
         ],
         subnetGroupName: args.SubnetGroup.name,
         securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
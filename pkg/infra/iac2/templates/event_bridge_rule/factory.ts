@@ -0,0 +1,13 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    ScheduleExpression: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.EventRule {
+    return new aws.cloudwatch.EventRule(args.Name, {
+        scheduleExpression: args.ScheduleExpression,
+    })
+}
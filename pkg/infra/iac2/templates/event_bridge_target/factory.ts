@@ -0,0 +1,33 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Rule: aws.cloudwatch.EventRule
+    Function: aws.lambda.Function
+    EcsService: aws.ecs.Service
+    Role: aws.iam.Role
+    Input: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.EventTarget {
+    return new aws.cloudwatch.EventTarget(args.Name, {
+        rule: args.Rule.name,
+        //TMPL {{- if .EcsService.Raw }}
+        //TMPL arn: args.EcsService.cluster,
+        //TMPL roleArn: args.Role.arn,
+        //TMPL ecsTarget: {
+        //TMPL     taskDefinitionArn: args.EcsService.taskDefinition,
+        //TMPL     launchType: args.EcsService.launchType,
+        //TMPL     networkConfiguration: args.EcsService.networkConfiguration.apply((nc) => ({
+        //TMPL         subnets: nc!.subnets,
+        //TMPL         securityGroups: nc!.securityGroups,
+        //TMPL         assignPublicIp: nc!.assignPublicIp,
+        //TMPL     })),
+        //TMPL },
+        //TMPL {{- else }}
+        arn: args.Function.arn,
+        //TMPL {{- end }}
+        input: args.Input,
+    })
+}
@@ -0,0 +1,20 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    Name: string
+    DatabaseName: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.firestore.Database {
+    return new gcp.firestore.Database(
+        args.Name,
+        {
+            name: args.DatabaseName,
+            locationId: gcp.config.region!,
+            type: 'FIRESTORE_NATIVE',
+        },
+        { protect: args.protect }
+    )
+}
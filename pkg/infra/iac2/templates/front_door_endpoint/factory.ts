@@ -0,0 +1,88 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Profile: azure.cdn.Profile
+    Routes: { name: string; patterns: string[]; originHost: pulumi.Input<string> }[]
+    CustomDomain: string
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.cdn.AFDEndpoint {
+    return (() => {
+        const endpoint = new azure.cdn.AFDEndpoint(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                profileName: args.Profile.name,
+                location: 'Global',
+                enabledState: 'Enabled',
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+        //TMPL {{- if .CustomDomain.Raw }}
+        // the domain is served once its DNS records are validated with the domain's validation token
+        const domain = new azure.cdn.AFDCustomDomain(
+            `${args.ResourceName}-domain`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                profileName: args.Profile.name,
+                hostName: args.CustomDomain,
+                tlsSettings: {
+                    certificateType: 'ManagedCertificate',
+                    minimumTlsVersion: 'TLS12',
+                },
+            },
+            { parent: endpoint }
+        )
+        //TMPL {{- end }}
+        for (const route of args.Routes) {
+            const group = new azure.cdn.AFDOriginGroup(
+                `${args.ResourceName}-${route.name}`,
+                {
+                    resourceGroupName: args.ResourceGroup.name,
+                    profileName: args.Profile.name,
+                    loadBalancingSettings: { sampleSize: 4, successfulSamplesRequired: 3 },
+                },
+                { parent: endpoint }
+            )
+            const origin = new azure.cdn.AFDOrigin(
+                `${args.ResourceName}-${route.name}`,
+                {
+                    resourceGroupName: args.ResourceGroup.name,
+                    profileName: args.Profile.name,
+                    originGroupName: group.name,
+                    hostName: route.originHost,
+                    originHostHeader: route.originHost,
+                    httpsPort: 443,
+                    enabledState: 'Enabled',
+                },
+                { parent: group }
+            )
+            new azure.cdn.Route(
+                `${args.ResourceName}-${route.name}`,
+                {
+                    resourceGroupName: args.ResourceGroup.name,
+                    profileName: args.Profile.name,
+                    endpointName: endpoint.name,
+                    originGroup: { id: group.id },
+                    patternsToMatch: route.patterns,
+                    supportedProtocols: ['Https'],
+                    httpsRedirect: 'Enabled',
+                    forwardingProtocol: 'HttpsOnly',
+                    linkToDefaultDomain: 'Enabled',
+                    //TMPL {{- if .CustomDomain.Raw }}
+                    customDomains: [{ id: domain.id }],
+                    //TMPL {{- end }}
+                },
+                { parent: endpoint, dependsOn: [origin] }
+            )
+        }
+        return endpoint
+    })()
+}
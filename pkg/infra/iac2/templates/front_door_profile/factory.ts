@@ -0,0 +1,16 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    SkuName: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.cdn.Profile {
+    return new azure.cdn.Profile(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        location: 'Global',
+        sku: { name: args.SkuName },
+    })
+}
@@ -0,0 +1,83 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as azure from '@pulumi/azure-native'
+import * as child_process from 'child_process'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Plan: azure.web.AppServicePlan
+    StorageAccount: azure.storage.StorageAccount
+    Identity: azure.managedidentity.UserAssignedIdentity
+    Runtime: string
+    RuntimeVersion: string
+    Source: string
+    EnvironmentVariables: Record<string, pulumi.Input<string>>
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.web.WebApp {
+    return (() => {
+        const keys = azure.storage.listStorageAccountKeysOutput({
+            resourceGroupName: args.ResourceGroup.name,
+            accountName: args.StorageAccount.name,
+        })
+        const storageConnectionString = pulumi.interpolate`DefaultEndpointsProtocol=https;AccountName=${args.StorageAccount.name};AccountKey=${keys.keys[0].value};EndpointSuffix=core.windows.net`
+
+        // the app runs from a package of the unit's directory, which must include the unit's dependencies
+        child_process.execSync('npm install --omit=dev', { cwd: args.Source, stdio: 'inherit' })
+        const packages = new azure.storage.BlobContainer(`${args.ResourceName}-packages`, {
+            resourceGroupName: args.ResourceGroup.name,
+            accountName: args.StorageAccount.name,
+        })
+        const pkg = new azure.storage.Blob(`${args.ResourceName}-package`, {
+            resourceGroupName: args.ResourceGroup.name,
+            accountName: args.StorageAccount.name,
+            containerName: packages.name,
+            source: new pulumi.asset.FileArchive(args.Source),
+        })
+        const sas = azure.storage.listStorageAccountServiceSASOutput({
+            resourceGroupName: args.ResourceGroup.name,
+            accountName: args.StorageAccount.name,
+            protocols: azure.storage.HttpProtocol.Https,
+            sharedAccessStartTime: '2023-01-01',
+            sharedAccessExpiryTime: '2033-01-01',
+            resource: azure.storage.SignedResource.C,
+            permissions: azure.storage.Permissions.R,
+            canonicalizedResource: pulumi.interpolate`/blob/${args.StorageAccount.name}/${packages.name}`,
+        })
+        const packageUrl = pulumi.interpolate`https://${args.StorageAccount.name}.blob.core.windows.net/${packages.name}/${pkg.name}?${sas.serviceSasToken}`
+
+        return new azure.web.WebApp(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                serverFarmId: args.Plan.id,
+                kind: 'functionapp,linux',
+                httpsOnly: true,
+                identity: {
+                    type: azure.web.ManagedServiceIdentityType.UserAssigned,
+                    userAssignedIdentities: [args.Identity.id],
+                },
+                siteConfig: {
+                    linuxFxVersion: `${args.Runtime}|${args.RuntimeVersion}`,
+                    appSettings: [
+                        { name: 'AzureWebJobsStorage', value: storageConnectionString },
+                        { name: 'FUNCTIONS_EXTENSION_VERSION', value: '~4' },
+                        { name: 'FUNCTIONS_WORKER_RUNTIME', value: args.Runtime },
+                        { name: 'WEBSITE_RUN_FROM_PACKAGE', value: packageUrl },
+                        // the runtime's DefaultAzureCredential authenticates as the app's identity
+                        { name: 'AZURE_CLIENT_ID', value: args.Identity.clientId },
+                        ...Object.entries(args.EnvironmentVariables).map(([name, value]) => ({
+                            name,
+                            value,
+                        })),
+                    ],
+                },
+            },
+            {
+                dependsOn: args.dependsOn,
+            }
+        )
+    })()
+}
@@ -0,0 +1,11 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.GlobalAddress {
+    return new gcp.compute.GlobalAddress(args.ResourceName, {}, { protect: args.protect })
+}
@@ -0,0 +1,19 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    IpAddress: gcp.compute.GlobalAddress
+    Target: pulumi.Input<string>
+    PortRange: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.GlobalForwardingRule {
+    return new gcp.compute.GlobalForwardingRule(args.ResourceName, {
+        ipAddress: args.IpAddress.address,
+        target: args.Target,
+        portRange: args.PortRange,
+        loadBalancingScheme: 'EXTERNAL_MANAGED',
+    })
+}
@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Api {
+    return new aws.apigatewayv2.Api(args.Name, {
+        protocolType: 'HTTP',
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,40 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    Type: string
+    Issuer: string
+    Audience: string[]
+    UserPool: aws.cognito.UserPool
+    UserPoolClient: aws.cognito.UserPoolClient
+    Function: aws.lambda.Function
+    IdentitySources: string[]
+    PayloadFormatVersion: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Authorizer {
+    return new aws.apigatewayv2.Authorizer(args.Name, {
+        apiId: args.Api.id,
+        authorizerType: args.Type,
+        identitySources: args.IdentitySources,
+        //TMPL {{- if .UserPool.Raw }}
+        jwtConfiguration: {
+            issuer: pulumi.interpolate`https://${args.UserPool.endpoint}`,
+            audiences: [args.UserPoolClient.id],
+        },
+        //TMPL {{- else if .Issuer.Raw }}
+        //TMPL jwtConfiguration: {
+        //TMPL     issuer: args.Issuer,
+        //TMPL     audiences: args.Audience,
+        //TMPL },
+        //TMPL {{- end }}
+        //TMPL {{- if .Function.Raw }}
+        authorizerUri: args.Function.invokeArn,
+        authorizerPayloadFormatVersion: args.PayloadFormatVersion,
+        enableSimpleResponses: true,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,19 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    DomainName: string
+    Certificate: aws.acm.CertificateValidation
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.DomainName {
+    return new aws.apigatewayv2.DomainName(args.Name, {
+        domainName: args.DomainName,
+        domainNameConfiguration: {
+            certificateArn: args.Certificate.certificateArn,
+            endpointType: 'REGIONAL',
+            securityPolicy: 'TLS_1_2',
+        },
+    })
+}
@@ -0,0 +1,32 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    Type: string
+    IntegrationMethod: string
+    ConnectionType: string
+    VpcLink: aws.apigatewayv2.VpcLink
+    PayloadFormatVersion: string
+    Uri: pulumi.Output<string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Integration {
+    return new aws.apigatewayv2.Integration(args.Name, {
+        apiId: args.Api.id,
+        integrationType: args.Type,
+        integrationMethod: args.IntegrationMethod,
+        integrationUri: args.Uri,
+        //TMPL {{- if .ConnectionType.Raw }}
+        connectionType: args.ConnectionType,
+        //TMPL {{- end }}
+        //TMPL {{- if .VpcLink.Raw }}
+        connectionId: args.VpcLink.id,
+        //TMPL {{- end }}
+        //TMPL {{- if .PayloadFormatVersion.Raw }}
+        payloadFormatVersion: args.PayloadFormatVersion,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,17 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    Stage: aws.apigatewayv2.Stage
+    DomainName: aws.apigatewayv2.DomainName
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.ApiMapping {
+    return new aws.apigatewayv2.ApiMapping(args.Name, {
+        apiId: args.Api.id,
+        stage: args.Stage.name,
+        domainName: args.DomainName.domainName,
+    })
+}
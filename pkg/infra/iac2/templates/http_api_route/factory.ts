@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    RouteKey: string
+    Integration: aws.apigatewayv2.Integration
+    AuthorizationType: string
+    Authorizer: aws.apigatewayv2.Authorizer
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Route {
+    return new aws.apigatewayv2.Route(args.Name, {
+        apiId: args.Api.id,
+        routeKey: args.RouteKey,
+        target: pulumi.interpolate`integrations/${args.Integration.id}`,
+        //TMPL {{- if .AuthorizationType.Raw }}
+        authorizationType: args.AuthorizationType,
+        //TMPL {{- end }}
+        //TMPL {{- if .Authorizer.Raw }}
+        authorizerId: args.Authorizer.id,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    StageName: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Stage {
+    return new aws.apigatewayv2.Stage(args.Name, {
+        apiId: args.Api.id,
+        name: args.StageName,
+        autoDeploy: true,
+    })
+}
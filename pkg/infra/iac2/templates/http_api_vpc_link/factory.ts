@@ -0,0 +1,15 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Subnets: aws.ec2.Subnet[]
+    SecurityGroups: aws.ec2.SecurityGroup[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.VpcLink {
+    return new aws.apigatewayv2.VpcLink(args.Name, {
+        subnetIds: args.Subnets.map((subnet) => subnet.id),
+        securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+    })
+}
@@ -8,6 +8,7 @@ interface Args {
     InlinePolicies: pulumi.Input<pulumi.Input<awsInputs.iam.RoleInlinePolicy>[]>
     ManagedPolicies: pulumi.Output<string>[]
     AwsManagedPolicies: string[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -27,5 +28,8 @@ function create(args: Args): aws.iam.Role {
             //TMPL {{- end }}
         ],
         //TMPL {{- end }}
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
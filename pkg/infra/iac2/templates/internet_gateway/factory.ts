@@ -3,11 +3,15 @@ import * as aws from '@pulumi/aws'
 interface Args {
     Name: string
     Vpc: aws.ec2.Vpc
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.ec2.InternetGateway {
     return new aws.ec2.InternetGateway(args.Name, {
         vpcId: args.Vpc.id,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -0,0 +1,44 @@
+import * as azure from '@pulumi/azure-native'
+import * as fs from 'fs'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Secrets: { path: string; secretName: string }[]
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.keyvault.Vault {
+    return (() => {
+        const vault = new azure.keyvault.Vault(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                properties: {
+                    tenantId: azure.authorization.getClientConfigOutput().tenantId,
+                    sku: { family: 'A', name: 'standard' },
+                    enableRbacAuthorization: true,
+                },
+            },
+            { protect: args.protect }
+        )
+        //TMPL {{- if .Secrets.Raw }}
+        for (const s of args.Secrets) {
+            if (fs.existsSync(s.path)) {
+                new azure.keyvault.Secret(
+                    `${args.ResourceName}-${s.secretName}`,
+                    {
+                        resourceGroupName: args.ResourceGroup.name,
+                        vaultName: vault.name,
+                        secretName: s.secretName,
+                        properties: { value: fs.readFileSync(s.path, 'utf-8') },
+                    },
+                    { parent: vault }
+                )
+            }
+        }
+        //TMPL {{- end }}
+        return vault
+    })()
+}
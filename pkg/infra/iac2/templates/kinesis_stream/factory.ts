@@ -9,6 +9,7 @@ interface Args {
         EncryptionType: string
     }
     StreamModeDetails: aws.types.input.kinesis.StreamStreamModeDetails
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -22,5 +23,8 @@ function create(args: Args): aws.kinesis.Stream {
         //TMPL {{- end }}
         streamModeDetails: args.StreamModeDetails,
         enforceConsumerDeletion: true,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
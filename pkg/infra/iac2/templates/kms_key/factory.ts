@@ -11,6 +11,7 @@ interface Args {
     KeyUsage: string
     MultiRegion: boolean
     PendingWindowInDays: number
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -26,5 +27,8 @@ function create(args: Args): aws.kms.Key {
         policy: pulumi.jsonStringify(args.KeyPolicy),
         deletionWindowInDays: args.PendingWindowInDays,
         multiRegion: args.MultiRegion,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -0,0 +1,23 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Function: aws.lambda.Function
+    Queue: aws.sqs.Queue
+    BatchSize: number
+    FunctionResponseTypes: string[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.lambda.EventSourceMapping {
+    return new aws.lambda.EventSourceMapping(args.Name, {
+        eventSourceArn: args.Queue.arn,
+        functionName: args.Function.name,
+        //TMPL {{- if .BatchSize.Raw }}
+        batchSize: args.BatchSize,
+        //TMPL {{- end }}
+        //TMPL {{- if .FunctionResponseTypes.Raw }}
+        functionResponseTypes: args.FunctionResponseTypes,
+        //TMPL {{- end }}
+    })
+}
@@ -12,6 +12,7 @@ interface Args {
     MemorySize: pulumi.Input<number>
     Timeout: pulumi.Input<number>
     EfsAccessPoint: aws.efs.AccessPoint
+    Tags: Record<string, string>
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
 }
 
@@ -46,7 +47,9 @@ function create(args: Args): aws.lambda.Function {
                 variables: args.EnvironmentVariables,
             },
             tags: {
-                env: 'production',
+                //TMPL {{- if .Tags.Raw }}
+                ...args.Tags,
+                //TMPL {{- end }}
                 service: args.Name,
             },
         },
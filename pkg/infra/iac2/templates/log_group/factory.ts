@@ -4,6 +4,7 @@ interface Args {
     Name: string
     LogGroupName: string
     RetentionInDays: number
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -11,5 +12,8 @@ function create(args: Args): aws.cloudwatch.LogGroup {
     return new aws.cloudwatch.LogGroup(args.Name, {
         name: args.LogGroupName,
         retentionInDays: args.RetentionInDays,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -0,0 +1,33 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    RoleAssignments: { roleDefinitionId: string; scope: pulumi.Input<string> }[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.managedidentity.UserAssignedIdentity {
+    return (() => {
+        const identity = new azure.managedidentity.UserAssignedIdentity(args.ResourceName, {
+            resourceGroupName: args.ResourceGroup.name,
+        })
+        //TMPL {{- if .RoleAssignments.Raw }}
+        const subscriptionId = azure.authorization.getClientConfigOutput().subscriptionId
+        for (const [i, assignment] of args.RoleAssignments.entries()) {
+            new azure.authorization.RoleAssignment(
+                `${args.ResourceName}-${assignment.roleDefinitionId}-${i}`,
+                {
+                    principalId: identity.principalId,
+                    principalType: 'ServicePrincipal',
+                    roleDefinitionId: pulumi.interpolate`/subscriptions/${subscriptionId}/providers/Microsoft.Authorization/roleDefinitions/${assignment.roleDefinitionId}`,
+                    scope: assignment.scope,
+                },
+                { parent: identity }
+            )
+        }
+        //TMPL {{- end }}
+        return identity
+    })()
+}
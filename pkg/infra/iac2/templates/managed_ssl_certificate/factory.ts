@@ -0,0 +1,13 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Domains: string[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.ManagedSslCertificate {
+    return new gcp.compute.ManagedSslCertificate(args.ResourceName, {
+        managed: { domains: args.Domains },
+    })
+}
@@ -4,6 +4,7 @@ interface Args {
     Name: string
     ElasticIp: aws.ec2.Eip
     Subnet: aws.ec2.Subnet
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -11,5 +12,8 @@ function create(args: Args): aws.ec2.NatGateway {
     return new aws.ec2.NatGateway(args.Name, {
         allocationId: args.ElasticIp.id,
         subnetId: args.Subnet.id,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -0,0 +1,113 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Command: string
+    Region: pulumi.Output<pulumi.UnwrappedObject<aws.GetRegionResult>>
+    Function: aws.lambda.Function
+    Cluster: aws.ecs.Cluster
+    TaskDefinition: aws.ecs.TaskDefinition
+    ContainerName: string
+    Subnets: aws.ec2.Subnet[]
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    AssignPublicIp: boolean
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): pulumi.dynamic.Resource {
+    return new pulumi.dynamic.Resource(
+        {
+            // Runs the migrations each time the resource is created. Every change to the inputs (e.g. a new image of the
+            // function, or a new revision of the task definition) replaces the resource, so the migrations run again.
+            async create(inputs: any): Promise<pulumi.dynamic.CreateResult> {
+                if (inputs.functionName) {
+                    const { LambdaClient, InvokeCommand } = require('@aws-sdk/client-lambda')
+                    const lambda = new LambdaClient({ region: inputs.region })
+                    // the function runs the command of its environment, so only the call type is sent
+                    const result = await lambda.send(
+                        new InvokeCommand({
+                            FunctionName: inputs.functionName,
+                            Payload: Buffer.from(JSON.stringify({ __callType: 'migration' })),
+                        })
+                    )
+                    if (result.FunctionError) {
+                        const payload = Buffer.from(result.Payload ?? []).toString()
+                        throw new Error(`migrations of ${inputs.name} failed: ${payload}`)
+                    }
+                } else {
+                    const {
+                        ECSClient,
+                        RunTaskCommand,
+                        DescribeTasksCommand,
+                        waitUntilTasksStopped,
+                    } = require('@aws-sdk/client-ecs')
+                    const ecs = new ECSClient({ region: inputs.region })
+                    const run = await ecs.send(
+                        new RunTaskCommand({
+                            cluster: inputs.cluster,
+                            taskDefinition: inputs.taskDefinition,
+                            launchType: 'FARGATE',
+                            networkConfiguration: {
+                                awsvpcConfiguration: {
+                                    subnets: inputs.subnets,
+                                    securityGroups: inputs.securityGroups,
+                                    assignPublicIp: inputs.assignPublicIp ? 'ENABLED' : 'DISABLED',
+                                },
+                            },
+                            overrides: {
+                                containerOverrides: [
+                                    { name: inputs.containerName, command: ['sh', '-c', inputs.command] },
+                                ],
+                            },
+                        })
+                    )
+                    const taskArn = run.tasks?.[0]?.taskArn
+                    if (!taskArn) {
+                        throw new Error(`migrations of ${inputs.name} failed to start: ${JSON.stringify(run.failures)}`)
+                    }
+                    await waitUntilTasksStopped(
+                        { client: ecs, maxWaitTime: 3600 },
+                        { cluster: inputs.cluster, tasks: [taskArn] }
+                    )
+                    const described = await ecs.send(
+                        new DescribeTasksCommand({ cluster: inputs.cluster, tasks: [taskArn] })
+                    )
+                    const container = described.tasks?.[0]?.containers?.find(
+                        (c: any) => c.name === inputs.containerName
+                    )
+                    if (container?.exitCode !== 0) {
+                        throw new Error(
+                            `migrations of ${inputs.name} failed in task ${taskArn} with exit code ${container?.exitCode}: ${container?.reason}`
+                        )
+                    }
+                }
+                return { id: `${inputs.name}-${Date.now()}`, outs: inputs }
+            },
+        },
+        args.Name,
+        {
+            name: args.Name,
+            // quoted rather than a template literal, so that the shell expands the command's variables
+            //TMPL command: {{ printf "%q" .Command.Raw }},
+            region: args.Region.name,
+            //TMPL {{- if .Function.Raw }}
+            functionName: args.Function.name,
+            // the function is updated whenever its image changes
+            functionVersion: args.Function.lastModified,
+            //TMPL {{- else }}
+            cluster: args.Cluster.arn,
+            taskDefinition: args.TaskDefinition.arn,
+            containerName: args.ContainerName,
+            subnets: args.Subnets.map((subnet) => subnet.id),
+            securityGroups: args.SecurityGroups.map((sg) => sg.id),
+            assignPublicIp: args.AssignPublicIp,
+            //TMPL {{- end }}
+        },
+        {
+            dependsOn: args.dependsOn,
+            replaceOnChanges: ['*'],
+        }
+    )
+}
@@ -0,0 +1,58 @@
+import * as azure from '@pulumi/azure-native'
+import * as fs from 'fs'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Version: string
+    SkuName: string
+    SkuTier: string
+    StorageSizeGb: number
+    DatabaseName: string
+    CredentialsPath: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.dbforpostgresql.Server {
+    return (() => {
+        const server = new azure.dbforpostgresql.Server(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                version: args.Version,
+                sku: {
+                    name: args.SkuName,
+                    tier: args.SkuTier,
+                },
+                storage: {
+                    storageSizeGB: args.StorageSizeGb,
+                },
+                administratorLogin: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[1].split('"')[3],
+                administratorLoginPassword: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[2].split('"')[3],
+            },
+            { protect: args.protect }
+        )
+        new azure.dbforpostgresql.Database(
+            `${args.ResourceName}-db`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                serverName: server.name,
+                databaseName: args.DatabaseName,
+            },
+            { parent: server }
+        )
+        // the start and end address of 0.0.0.0 allows connections from the app's Azure services
+        new azure.dbforpostgresql.FirewallRule(
+            `${args.ResourceName}-azure-services`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                serverName: server.name,
+                startIpAddress: '0.0.0.0',
+                endIpAddress: '0.0.0.0',
+            },
+            { parent: server }
+        )
+        return server
+    })()
+}
@@ -0,0 +1,15 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Topic: gcp.pubsub.Topic
+    AckDeadlineSeconds: number
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.pubsub.Subscription {
+    return new gcp.pubsub.Subscription(args.ResourceName, {
+        topic: args.Topic.name,
+        ackDeadlineSeconds: args.AckDeadlineSeconds,
+    })
+}
@@ -0,0 +1,10 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.pubsub.Topic {
+    return new gcp.pubsub.Topic(args.ResourceName, {})
+}
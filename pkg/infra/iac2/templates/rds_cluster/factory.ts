@@ -0,0 +1,57 @@
+import * as aws from '@pulumi/aws'
+import * as fs from 'fs'
+
+interface Args {
+    Name: string
+    GlobalCluster: aws.rds.GlobalCluster
+    Engine: string
+    EngineVersion: string
+    DatabaseName: string
+    IamDatabaseAuthenticationEnabled: boolean
+    SubnetGroup: aws.rds.SubnetGroup
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    SkipFinalSnapshot: boolean
+    CredentialsPath: string
+    Provider: aws.Provider
+    protect: boolean
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.rds.Cluster {
+    return new aws.rds.Cluster(
+        args.Name,
+        {
+            clusterIdentifier: args.Name,
+            globalClusterIdentifier: args.GlobalCluster.id,
+            engine: args.Engine,
+            engineVersion: args.EngineVersion,
+            //TMPL {{- if .CredentialsPath.Raw }}
+            databaseName: args.DatabaseName,
+            masterUsername: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[1].split('"')[3],
+            masterPassword: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[2].split('"')[3],
+            //TMPL {{- end }}
+            iamDatabaseAuthenticationEnabled: args.IamDatabaseAuthenticationEnabled,
+            //TMPL {{- if .SubnetGroup.Raw }}
+            dbSubnetGroupName: args.SubnetGroup.name,
+            //TMPL {{- end }}
+            //TMPL {{- if .SecurityGroups.Raw }}
+            vpcSecurityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+            //TMPL {{- end }}
+            skipFinalSnapshot: args.SkipFinalSnapshot,
+            storageEncrypted: true,
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        {
+            protect: args.protect,
+            // a secondary cluster replicates its primary, and becomes the primary when the global database fails over
+            // to it, so neither its source nor its membership is managed after it is created
+            ignoreChanges: ['replicationSourceIdentifier', 'globalClusterIdentifier'],
+            //TMPL {{- if .Provider.Raw }}
+            provider: args.Provider,
+            //TMPL {{- end }}
+        }
+    )
+}
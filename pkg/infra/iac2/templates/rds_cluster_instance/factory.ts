@@ -0,0 +1,33 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Cluster: aws.rds.Cluster
+    Engine: string
+    EngineVersion: string
+    InstanceClass: string
+    Provider: aws.Provider
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.rds.ClusterInstance {
+    return new aws.rds.ClusterInstance(
+        args.Name,
+        {
+            identifier: args.Name,
+            clusterIdentifier: args.Cluster.id,
+            engine: args.Engine,
+            engineVersion: args.EngineVersion,
+            instanceClass: args.InstanceClass,
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        {
+            //TMPL {{- if .Provider.Raw }}
+            provider: args.Provider,
+            //TMPL {{- end }}
+        }
+    )
+}
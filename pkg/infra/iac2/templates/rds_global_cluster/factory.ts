@@ -0,0 +1,24 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Engine: string
+    EngineVersion: string
+    DatabaseName: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.rds.GlobalCluster {
+    return new aws.rds.GlobalCluster(
+        args.Name,
+        {
+            globalClusterIdentifier: args.Name,
+            engine: args.Engine,
+            engineVersion: args.EngineVersion,
+            databaseName: args.DatabaseName,
+            storageEncrypted: true,
+        },
+        { protect: args.protect }
+    )
+}
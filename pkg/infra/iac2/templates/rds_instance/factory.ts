@@ -14,6 +14,7 @@ interface Args {
     AllocatedStorage: number
     protect: boolean
     CredentialsPath: string
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -32,6 +33,9 @@ function create(args: Args): aws.rds.Instance {
             vpcSecurityGroupIds: args.SecurityGroups.map((sg) => sg.id),
             skipFinalSnapshot: args.SkipFinalSnapshot,
             allocatedStorage: args.AllocatedStorage,
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
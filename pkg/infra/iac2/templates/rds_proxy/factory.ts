@@ -10,6 +10,7 @@ interface Args {
     SecurityGroups: aws.ec2.SecurityGroup[]
     Subnets: aws.ec2.Subnet[]
     Auths: pulumi.Input<aws.types.input.rds.ProxyAuth>[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -23,5 +24,8 @@ function create(args: Args): aws.rds.Proxy {
         vpcSecurityGroupIds: args.SecurityGroups.map((sg) => sg.id),
         vpcSubnetIds: args.Subnets.map((subnet) => subnet.id),
         auths: args.Auths,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
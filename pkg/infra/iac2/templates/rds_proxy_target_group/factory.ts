@@ -3,6 +3,7 @@ import * as aws from '@pulumi/aws'
 interface Args {
     Name: string
     RdsInstance: aws.rds.Instance
+    RdsCluster: aws.rds.Cluster
     RdsProxy: aws.rds.Proxy
     TargetGroupName: string
 }
@@ -12,7 +13,11 @@ function create(args: Args): aws.rds.ProxyTarget {
     return new aws.rds.ProxyTarget(
         args.Name,
         {
+            //TMPL {{- if .RdsCluster.Raw }}
+            dbClusterIdentifier: args.RdsCluster.id,
+            //TMPL {{- else }}
             dbInstanceIdentifier: args.RdsInstance.id,
+            //TMPL {{- end }}
             dbProxyName: args.RdsProxy.name,
             targetGroupName: args.TargetGroupName,
         },
@@ -0,0 +1,31 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    ApplicationId: string
+    Region: pulumi.Output<pulumi.UnwrappedObject<aws.GetRegionResult>>
+    Subnets: aws.ec2.Subnet[]
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.serverlessrepository.CloudFormationStack {
+    return new aws.serverlessrepository.CloudFormationStack(
+        args.Name,
+        {
+            applicationId: args.ApplicationId,
+            capabilities: ['CAPABILITY_IAM', 'CAPABILITY_RESOURCE_POLICY'],
+            parameters: {
+                functionName: args.Name,
+                endpoint: pulumi.interpolate`https://secretsmanager.${args.Region.name}.amazonaws.com`,
+                vpcSubnetIds: pulumi.all(args.Subnets.map((subnet) => subnet.id)).apply((ids) => ids.join(',')),
+                vpcSecurityGroupIds: pulumi
+                    .all(args.SecurityGroups.map((sg) => sg.id))
+                    .apply((ids) => ids.join(',')),
+            },
+        },
+        { dependsOn: args.dependsOn }
+    )
+}
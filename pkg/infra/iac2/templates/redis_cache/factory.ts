@@ -0,0 +1,23 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    SkuName: string
+    Family: string
+    Capacity: number
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.cache.Redis {
+    return new azure.cache.Redis(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        sku: {
+            name: args.SkuName,
+            family: args.Family,
+            capacity: args.Capacity,
+        },
+        enableNonSslPort: false,
+        minimumTlsVersion: '1.2',
+    })
+}
@@ -0,0 +1,20 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Tier: string
+    MemorySizeGb: number
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.redis.Instance {
+    return new gcp.redis.Instance(
+        args.ResourceName,
+        {
+            tier: args.Tier,
+            memorySizeGb: args.MemorySizeGb,
+        },
+        { protect: args.protect }
+    )
+}
@@ -0,0 +1,21 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    CloudRun: gcp.cloudrunv2.Service
+    CloudFunction: gcp.cloudfunctionsv2.Function
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.RegionNetworkEndpointGroup {
+    return new gcp.compute.RegionNetworkEndpointGroup(args.ResourceName, {
+        region: gcp.config.region!,
+        networkEndpointType: 'SERVERLESS',
+        //TMPL {{- if .CloudRun.Raw }}
+        cloudRun: { service: args.CloudRun.name },
+        //TMPL {{- end }}
+        //TMPL {{- if .CloudFunction.Raw }}
+        cloudFunction: { function: args.CloudFunction.name },
+        //TMPL {{- end }}
+    })
+}
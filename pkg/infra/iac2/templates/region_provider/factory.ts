@@ -0,0 +1,11 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Region: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.Provider {
+    return new aws.Provider(args.Name, { region: args.Region })
+}
@@ -0,0 +1,10 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.resources.ResourceGroup {
+    return new azure.resources.ResourceGroup(args.ResourceName, {})
+}
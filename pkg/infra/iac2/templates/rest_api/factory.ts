@@ -3,11 +3,15 @@ import * as aws from '@pulumi/aws'
 interface Args {
     Name: string
     BinaryMediaTypes: string[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.apigateway.RestApi {
     return new aws.apigateway.RestApi(args.Name, {
         binaryMediaTypes: args.BinaryMediaTypes,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -1,4 +1,5 @@
 import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
 
 interface Args {
     Name: string
@@ -10,6 +11,7 @@ interface Args {
     Port: number
     RequestInterval: number
     ResourcePath: string
+    Endpoint: pulumi.Output<string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -19,6 +21,9 @@ function create(args: Args): aws.route53.HealthCheck {
         //TMPL {{- if .Fqdn.Raw }}
         fqdn: args.Fqdn,
         //TMPL {{- end}}
+        //TMPL {{- if .Endpoint.Raw.Property }}
+        fqdn: args.Endpoint,
+        //TMPL {{- end}}
         //TMPL {{- if .IpAddress.Raw }}
         ipAddress: args.IpAddress,
         //TMPL {{- end}}
@@ -4,16 +4,23 @@ interface Args {
     Name: string
     Vpcs: aws.ec2.Vpc[]
     ForceDestroy: boolean
+    ExistingZoneName: string
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.route53.Zone {
-    return new aws.route53.Zone(args.Name, {
-        //TMPL {{- if .Vpc.Raw }}
-        vpcs: args.Vpcs.map((vpc) => {
-            return { vpcId: vpc.id }
-        }),
-        //TMPL {{- end}}
-        forceDestroy: args.ForceDestroy,
-    })
+    return (
+        //TMPL {{- if .ExistingZoneName.Raw }}
+        aws.route53.Zone.get(args.Name, aws.route53.getZoneOutput({ name: args.ExistingZoneName }).zoneId)
+        //TMPL {{- else }}
+        //TMPL new aws.route53.Zone(args.Name, {
+        //TMPL     {{- if .Vpcs.Raw }}
+        //TMPL     vpcs: args.Vpcs.map((vpc) => {
+        //TMPL         return { vpcId: vpc.id }
+        //TMPL     }),
+        //TMPL     {{- end }}
+        //TMPL     forceDestroy: args.ForceDestroy,
+        //TMPL })
+        //TMPL {{- end }}
+    )
 }
@@ -8,6 +8,11 @@ interface Args {
     Records: pulumi.Output<string>[]
     HealthCheck: aws.route53.HealthCheck
     TTL: number
+    Certificate: aws.acm.Certificate
+    AliasTarget: aws.types.input.route53.RecordAlias
+    Failover: string
+    SetIdentifier: string
+    AllowOverwrite: boolean
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -16,10 +21,28 @@ function create(args: Args): aws.route53.Record {
         //TMPL {{- if .HealthCheck.Raw }}
         healthCheckId: args.HealthCheck.id,
         //TMPL {{- end}}
-        zoneId: args.Zone.id,
+        zoneId: args.Zone.zoneId,
+        //TMPL {{- if .Certificate.Raw }}
+        name: args.Certificate.domainValidationOptions[0].resourceRecordName,
+        type: args.Certificate.domainValidationOptions[0].resourceRecordType,
+        records: [args.Certificate.domainValidationOptions[0].resourceRecordValue],
+        ttl: args.TTL,
+        //TMPL {{- else if .AliasTarget.Raw.Property }}
+        name: args.DomainName,
+        type: args.Type,
+        aliases: [args.AliasTarget],
+        //TMPL {{- else }}
         type: args.Type,
         records: args.Records,
         ttl: args.TTL,
         name: args.DomainName,
+        //TMPL {{- end }}
+        //TMPL {{- if .Failover.Raw }}
+        setIdentifier: args.SetIdentifier,
+        failoverRoutingPolicies: [{ type: args.Failover }],
+        //TMPL {{- end }}
+        //TMPL {{- if .AllowOverwrite.Raw }}
+        allowOverwrite: true,
+        //TMPL {{- end }}
     })
 }
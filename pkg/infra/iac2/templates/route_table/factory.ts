@@ -4,6 +4,7 @@ interface Args {
     Name: string
     Vpc: aws.ec2.Vpc
     Routes: aws.types.input.ec2.RouteTableRoute[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -11,5 +12,8 @@ function create(args: Args): aws.ec2.RouteTable {
     return new aws.ec2.RouteTable(args.Name, {
         vpcId: args.Vpc.id,
         routes: args.Routes,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
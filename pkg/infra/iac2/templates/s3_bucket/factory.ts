@@ -4,7 +4,10 @@ interface Args {
     Name: string
     ForceDestroy: boolean
     IndexDocument: string
+    Versioning: boolean
+    Provider: aws.Provider
     protect: boolean
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -26,7 +29,20 @@ function create(args: Args): aws.s3.Bucket {
                 indexDocument: args.IndexDocument,
             },
             //TMPL {{ end }}
+            //TMPL {{- if .Versioning.Raw }}
+            versioning: {
+                enabled: true,
+            },
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
-        { protect: args.protect }
+        {
+            protect: args.protect,
+            //TMPL {{- if .Provider.Raw }}
+            provider: args.Provider,
+            //TMPL {{- end }}
+        }
     )
 }
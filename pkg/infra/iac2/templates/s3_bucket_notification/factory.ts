@@ -0,0 +1,27 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Bucket: aws.s3.Bucket
+    LambdaFunctions: pulumi.Input<aws.types.input.s3.BucketNotificationLambdaFunction>[]
+    Queues: pulumi.Input<aws.types.input.s3.BucketNotificationQueue>[]
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.s3.BucketNotification {
+    return new aws.s3.BucketNotification(
+        args.Name,
+        {
+            bucket: args.Bucket.id,
+            //TMPL {{- if .LambdaFunctions.Raw }}
+            lambdaFunctions: args.LambdaFunctions,
+            //TMPL {{- end }}
+            //TMPL {{- if .Queues.Raw }}
+            queues: args.Queues,
+            //TMPL {{- end }}
+        },
+        { dependsOn: args.dependsOn }
+    )
+}
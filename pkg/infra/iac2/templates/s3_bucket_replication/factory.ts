@@ -0,0 +1,40 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Bucket: aws.s3.Bucket
+    Destinations: aws.s3.Bucket[]
+    Role: aws.iam.Role
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.s3.BucketReplicationConfig {
+    return new aws.s3.BucketReplicationConfig(
+        args.Name,
+        {
+            bucket: args.Bucket.id,
+            role: args.Role.arn,
+            rules: args.Destinations.map((destination, priority) => ({
+                id: `${args.Name}-${priority}`,
+                priority,
+                status: 'Enabled',
+                filter: {},
+                deleteMarkerReplication: { status: 'Enabled' },
+                sourceSelectionCriteria: {
+                    sseKmsEncryptedObjects: { status: 'Enabled' },
+                },
+                destination: {
+                    bucket: destination.arn,
+                    encryptionConfiguration: {
+                        // the destination is encrypted with the AWS managed key of its own region
+                        replicaKmsKeyId: aws.kms.getAliasOutput(
+                            { name: 'alias/aws/s3' },
+                            { provider: destination.getProvider('aws:kms/getAlias:getAlias') }
+                        ).targetKeyArn,
+                    },
+                },
+            })),
+        },
+        { dependsOn: [args.Bucket, ...args.Destinations] }
+    )
+}
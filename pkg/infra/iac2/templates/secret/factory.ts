@@ -2,7 +2,9 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
+    Replicas: string[]
     protect: boolean
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -12,6 +14,12 @@ function create(args: Args): aws.secretsmanager.Secret {
         {
             name: args.Name,
             recoveryWindowInDays: 0,
+            //TMPL {{- if .Replicas.Raw }}
+            replicas: args.Replicas.map((region) => ({ region })),
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
@@ -0,0 +1,33 @@
+import * as gcp from '@pulumi/gcp'
+import * as fs from 'fs'
+
+interface Args {
+    Name: string
+    Secrets: { path: string; secretId: string }[]
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.secretmanager.Secret[] {
+    return args.Secrets.map((s) => {
+        const secret = new gcp.secretmanager.Secret(
+            `${args.Name}-${s.secretId}`,
+            {
+                secretId: s.secretId,
+                replication: { automatic: true },
+            },
+            { protect: args.protect }
+        )
+        if (fs.existsSync(s.path)) {
+            new gcp.secretmanager.SecretVersion(
+                `${args.Name}-${s.secretId}`,
+                {
+                    secret: secret.id,
+                    secretData: fs.readFileSync(s.path, 'utf-8'),
+                },
+                { parent: secret }
+            )
+        }
+        return secret
+    })
+}
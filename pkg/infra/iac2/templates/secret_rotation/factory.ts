@@ -0,0 +1,30 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Secret: aws.secretsmanager.Secret
+    RotationLambdaArn: string
+    RotationFunction: aws.serverlessrepository.CloudFormationStack
+    AutomaticallyAfterDays: number
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.secretsmanager.SecretRotation {
+    return new aws.secretsmanager.SecretRotation(
+        args.Name,
+        {
+            secretId: args.Secret.id,
+            //TMPL {{- if .RotationFunction.Raw }}
+            rotationLambdaArn: args.RotationFunction.outputs['RotationLambdaARN'],
+            //TMPL {{- else }}
+            rotationLambdaArn: args.RotationLambdaArn,
+            //TMPL {{- end }}
+            rotationRules: {
+                automaticallyAfterDays: args.AutomaticallyAfterDays,
+            },
+        },
+        { dependsOn: args.dependsOn }
+    )
+}
@@ -1,4 +1,5 @@
 import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
 import * as fs from 'fs'
 
 interface Args {
@@ -6,6 +7,8 @@ interface Args {
     Secret: aws.secretsmanager.Secret
     Path: string
     Type: string
+    RdsInstance: aws.rds.Instance
+    RdsCluster: aws.rds.Cluster
     protect: boolean
 }
 
@@ -16,7 +19,41 @@ function create(args: Args): void {
             args.Name,
             {
                 secretId: args.Secret.id,
-                //TMPL {{- if eq .Type.Raw "string" }}
+                //TMPL {{- if .RdsInstance.Raw }}
+                secretString: pulumi
+                    .all([
+                        args.RdsInstance.engine,
+                        args.RdsInstance.address,
+                        args.RdsInstance.port,
+                        args.RdsInstance.dbName,
+                    ])
+                    .apply(([engine, host, port, dbname]) =>
+                        JSON.stringify({
+                            ...JSON.parse(fs.readFileSync(args.Path, 'utf-8')),
+                            engine,
+                            host,
+                            port,
+                            dbname,
+                        })
+                    ),
+                //TMPL {{- else if .RdsCluster.Raw }}
+                secretString: pulumi
+                    .all([
+                        args.RdsCluster.engine,
+                        args.RdsCluster.endpoint,
+                        args.RdsCluster.port,
+                        args.RdsCluster.databaseName,
+                    ])
+                    .apply(([engine, host, port, dbname]) =>
+                        JSON.stringify({
+                            ...JSON.parse(fs.readFileSync(args.Path, 'utf-8')),
+                            engine,
+                            host,
+                            port,
+                            dbname,
+                        })
+                    ),
+                //TMPL {{- else if eq .Type.Raw "string" }}
                 secretString: fs.readFileSync(args.Path, 'utf-8').toString(),
                 //TMPL {{- else }}
                 //TMPL secretBinary: fs.readFileSync({{ .Path.Parse }}, 'base64').toString(),
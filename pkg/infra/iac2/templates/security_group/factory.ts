@@ -5,6 +5,7 @@ interface Args {
     Vpc: aws.ec2.Vpc
     IngressRules: aws.types.input.ec2.SecurityGroupIngress[]
     EgressRules: aws.types.input.ec2.SecurityGroupEgress[]
+    Tags: Record<string, string>
 }
 
 function create(args: Args): aws.ec2.SecurityGroup {
@@ -13,5 +14,8 @@ function create(args: Args): aws.ec2.SecurityGroup {
         vpcId: args.Vpc.id,
         egress: args.EgressRules,
         ingress: args.IngressRules,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
@@ -0,0 +1,31 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    AccountId: string
+    Roles: string[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.serviceaccount.Account {
+    return (() => {
+        const account = new gcp.serviceaccount.Account(args.ResourceName, {
+            accountId: args.AccountId,
+        })
+        //TMPL {{- if .Roles.Raw }}
+        for (const role of args.Roles) {
+            new gcp.projects.IAMMember(
+                `${args.ResourceName}-${role.split('/').pop()}`,
+                {
+                    project: account.project,
+                    role: role,
+                    member: pulumi.interpolate`serviceAccount:${account.email}`,
+                },
+                { parent: account }
+            )
+        }
+        //TMPL {{- end }}
+        return account
+    })()
+}
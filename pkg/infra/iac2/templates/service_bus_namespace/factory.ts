@@ -0,0 +1,15 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.servicebus.Namespace {
+    return new azure.servicebus.Namespace(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        // topics are not available in the basic tier
+        sku: { name: 'Standard', tier: 'Standard' },
+    })
+}
@@ -0,0 +1,25 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Namespace: azure.servicebus.Namespace
+    Topic: azure.servicebus.Topic
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.servicebus.Subscription {
+    return new azure.servicebus.Subscription(
+        args.ResourceName,
+        {
+            resourceGroupName: args.ResourceGroup.name,
+            namespaceName: args.Namespace.name,
+            topicName: args.Topic.name,
+        },
+        {
+            dependsOn: args.dependsOn,
+        }
+    )
+}
@@ -0,0 +1,15 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    Namespace: azure.servicebus.Namespace
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.servicebus.Topic {
+    return new azure.servicebus.Topic(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        namespaceName: args.Namespace.name,
+    })
+}
@@ -0,0 +1,21 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Type: string
+    Role: aws.iam.Role
+    Definition: string
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sfn.StateMachine {
+    return new aws.sfn.StateMachine(args.Name, {
+        type: args.Type,
+        roleArn: args.Role.arn,
+        definition: args.Definition,
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Topic: aws.sns.Topic
+    Protocol: string
+    Endpoint: pulumi.Input<string>
+    RawMessageDelivery: boolean
+    SubscriptionRoleArn: aws.iam.Role
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sns.TopicSubscription {
+    return new aws.sns.TopicSubscription(args.Name, {
+        topic: args.Topic.arn,
+        protocol: args.Protocol,
+        endpoint: args.Endpoint,
+        //TMPL {{- if .RawMessageDelivery.Raw }}
+        rawMessageDelivery: args.RawMessageDelivery,
+        //TMPL {{- end }}
+        //TMPL {{- if .SubscriptionRoleArn.Raw }}
+        subscriptionRoleArn: args.SubscriptionRoleArn.arn,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,21 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    FifoTopic: boolean
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sns.Topic {
+    return new aws.sns.Topic(args.Name, {
+        //TMPL {{- if .FifoTopic.Raw }}
+        // FIFO topic names must end with '.fifo'
+        name: `${args.Name}.fifo`,
+        fifoTopic: true,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,46 @@
+import * as gcp from '@pulumi/gcp'
+import * as fs from 'fs'
+
+interface Args {
+    ResourceName: string
+    DatabaseVersion: string
+    Tier: string
+    DatabaseName: string
+    CredentialsPath: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.sql.DatabaseInstance {
+    return (() => {
+        const instance = new gcp.sql.DatabaseInstance(
+            args.ResourceName,
+            {
+                databaseVersion: args.DatabaseVersion,
+                settings: {
+                    tier: args.Tier,
+                },
+                deletionProtection: args.protect,
+            },
+            { protect: args.protect }
+        )
+        new gcp.sql.Database(
+            `${args.ResourceName}-db`,
+            {
+                name: args.DatabaseName,
+                instance: instance.name,
+            },
+            { parent: instance }
+        )
+        new gcp.sql.User(
+            `${args.ResourceName}-user`,
+            {
+                name: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[1].split('"')[3],
+                password: fs.readFileSync(args.CredentialsPath, 'utf-8').split('\n')[2].split('"')[3],
+                instance: instance.name,
+            },
+            { parent: instance }
+        )
+        return instance
+    })()
+}
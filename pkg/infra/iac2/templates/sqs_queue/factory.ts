@@ -0,0 +1,41 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    FifoQueue: boolean
+    DelaySeconds: number
+    MaximumMessageSize: number
+    VisibilityTimeout: number
+    RedrivePolicy: {
+        deadLetterTargetArn: pulumi.Output<string>
+        maxReceiveCount: number
+    }
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sqs.Queue {
+    return new aws.sqs.Queue(args.Name, {
+        //TMPL {{- if .FifoQueue.Raw }}
+        // FIFO queue names must end with '.fifo'
+        name: `${args.Name}.fifo`,
+        fifoQueue: true,
+        //TMPL {{- end }}
+        //TMPL {{- if .DelaySeconds.Raw }}
+        delaySeconds: args.DelaySeconds,
+        //TMPL {{- end }}
+        //TMPL {{- if .MaximumMessageSize.Raw }}
+        maxMessageSize: args.MaximumMessageSize,
+        //TMPL {{- end }}
+        //TMPL {{- if .VisibilityTimeout.Raw }}
+        visibilityTimeoutSeconds: args.VisibilityTimeout,
+        //TMPL {{- end }}
+        //TMPL {{- if .RedrivePolicy.Raw }}
+        redrivePolicy: pulumi.output(args.RedrivePolicy).apply((policy) => JSON.stringify(policy)),
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,15 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Queues: aws.sqs.Queue[]
+    PolicyDocument: aws.iam.PolicyDocument
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sqs.QueuePolicy {
+    return new aws.sqs.QueuePolicy(args.Name, {
+        queueUrl: args.Queues[0].url,
+        policy: args.PolicyDocument,
+    })
+}
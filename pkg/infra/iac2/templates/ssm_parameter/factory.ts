@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as fs from 'fs'
+
+interface Args {
+    Name: string
+    ParameterName: string
+    Path: string
+    protect: boolean
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ssm.Parameter {
+    return new aws.ssm.Parameter(
+        args.Name,
+        {
+            name: args.ParameterName,
+            type: 'SecureString',
+            value: fs.readFileSync(args.Path, 'utf-8').toString(),
+            //TMPL {{- if .Tags.Raw }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        { protect: args.protect }
+    )
+}
@@ -0,0 +1,17 @@
+import * as azure from '@pulumi/azure-native'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.storage.StorageAccount {
+    return new azure.storage.StorageAccount(args.ResourceName, {
+        resourceGroupName: args.ResourceGroup.name,
+        kind: azure.storage.Kind.StorageV2,
+        sku: { name: azure.storage.SkuName.Standard_LRS },
+        allowBlobPublicAccess: false,
+        minimumTlsVersion: azure.storage.MinimumTlsVersion.TLS1_2,
+    })
+}
@@ -0,0 +1,21 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Location: string
+    ForceDestroy: boolean
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.storage.Bucket {
+    return new gcp.storage.Bucket(
+        args.ResourceName,
+        {
+            location: args.Location,
+            forceDestroy: args.ForceDestroy,
+            uniformBucketLevelAccess: true,
+        },
+        { protect: args.protect }
+    )
+}
@@ -0,0 +1,16 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    Name: string
+    Bucket: gcp.storage.Bucket
+    Source: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.storage.BucketObject {
+    return new gcp.storage.BucketObject(args.Name, {
+        bucket: args.Bucket.name,
+        source: new pulumi.asset.FileArchive(args.Source),
+    })
+}
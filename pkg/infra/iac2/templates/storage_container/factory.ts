@@ -0,0 +1,46 @@
+import * as azure from '@pulumi/azure-native'
+import * as synced from '@pulumi/synced-folder'
+
+interface Args {
+    ResourceName: string
+    ResourceGroup: azure.resources.ResourceGroup
+    StorageAccount: azure.storage.StorageAccount
+    StaticWebsite: boolean
+    IndexDocument: string
+    SourceDir: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): azure.storage.BlobContainer | azure.storage.StorageAccountStaticWebsite {
+    return (() => {
+        //TMPL {{- if .StaticWebsite.Raw }}
+        // the static website of an account is served from its $web container, which enabling the website creates
+        const website = new azure.storage.StorageAccountStaticWebsite(args.ResourceName, {
+            resourceGroupName: args.ResourceGroup.name,
+            accountName: args.StorageAccount.name,
+            indexDocument: args.IndexDocument,
+        })
+        new synced.AzureBlobFolder(
+            `${args.ResourceName}-files`,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                storageAccountName: args.StorageAccount.name,
+                containerName: website.containerName,
+                path: args.SourceDir,
+            },
+            { parent: website }
+        )
+        return website
+        //TMPL {{- else }}
+        return new azure.storage.BlobContainer(
+            args.ResourceName,
+            {
+                resourceGroupName: args.ResourceGroup.name,
+                accountName: args.StorageAccount.name,
+            },
+            { protect: args.protect }
+        )
+        //TMPL {{- end }}
+    })()
+}
@@ -7,6 +7,7 @@ interface Args {
     Vpc: aws.ec2.Vpc
     AvailabilityZone: pulumi.Output<string>
     MapPublicIpOnLaunch: boolean
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -17,6 +18,9 @@ function create(args: Args): aws.ec2.Subnet {
         availabilityZone: args.AvailabilityZone,
         mapPublicIpOnLaunch: args.MapPublicIpOnLaunch,
         tags: {
+            //TMPL {{- if .Tags.Raw }}
+            ...args.Tags,
+            //TMPL {{- end }}
             Name: args.Name,
         },
     })
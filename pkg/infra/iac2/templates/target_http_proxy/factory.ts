@@ -0,0 +1,13 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    UrlMap: gcp.compute.URLMap
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.TargetHttpProxy {
+    return new gcp.compute.TargetHttpProxy(args.ResourceName, {
+        urlMap: args.UrlMap.id,
+    })
+}
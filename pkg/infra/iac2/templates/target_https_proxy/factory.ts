@@ -0,0 +1,15 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    UrlMap: gcp.compute.URLMap
+    Certificate: gcp.compute.ManagedSslCertificate
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.TargetHttpsProxy {
+    return new gcp.compute.TargetHttpsProxy(args.ResourceName, {
+        urlMap: args.UrlMap.id,
+        sslCertificates: [args.Certificate.id],
+    })
+}
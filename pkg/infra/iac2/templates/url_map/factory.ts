@@ -0,0 +1,30 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    DefaultService: gcp.compute.BackendService
+    RouteRules: pulumi.Input<gcp.types.input.compute.URLMapPathMatcherRouteRule>[]
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.compute.URLMap {
+    return new gcp.compute.URLMap(
+        args.ResourceName,
+        {
+            defaultService: args.DefaultService.id,
+            hostRules: [{ hosts: ['*'], pathMatcher: 'routes' }],
+            pathMatchers: [
+                {
+                    name: 'routes',
+                    defaultService: args.DefaultService.id,
+                    routeRules: args.RouteRules,
+                },
+            ],
+        },
+        {
+            dependsOn: args.dependsOn,
+        }
+    )
+}
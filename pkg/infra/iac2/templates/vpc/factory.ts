@@ -5,6 +5,7 @@ interface Args {
     CidrBlock: string
     EnableDnsHostnames: boolean
     EnableDnsSupport: boolean
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -14,6 +15,9 @@ function create(args: Args): aws.ec2.Vpc {
         enableDnsHostnames: args.EnableDnsHostnames,
         enableDnsSupport: args.EnableDnsSupport,
         tags: {
+            //TMPL {{- if .Tags.Raw }}
+            ...args.Tags,
+            //TMPL {{- end }}
             Name: args.Name,
         },
     })
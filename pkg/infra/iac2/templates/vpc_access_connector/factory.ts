@@ -0,0 +1,16 @@
+import * as gcp from '@pulumi/gcp'
+
+interface Args {
+    ResourceName: string
+    Network: string
+    IpCidrRange: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): gcp.vpcaccess.Connector {
+    return new gcp.vpcaccess.Connector(args.ResourceName, {
+        name: args.ResourceName,
+        network: args.Network,
+        ipCidrRange: args.IpCidrRange,
+    })
+}
@@ -10,6 +10,7 @@ interface Args {
     Subnets: aws.ec2.Subnet[]
     SecurityGroupIds: pulumi.Input<string[]> | undefined
     RouteTables: aws.ec2.RouteTable[]
+    Tags: Record<string, string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -27,5 +28,8 @@ function create(args: Args): aws.ec2.VpcEndpoint {
         //TMPL {{- if eq .VpcEndpointType.Raw "Gateway"}}
         routeTableIds: args.RouteTables.map((rt) => rt.id),
         //TMPL {{- end}}
+        //TMPL {{- if .Tags.Raw }}
+        tags: args.Tags,
+        //TMPL {{- end }}
     })
 }
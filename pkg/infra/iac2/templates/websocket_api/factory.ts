@@ -0,0 +1,14 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    RouteSelectionExpression: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Api {
+    return new aws.apigatewayv2.Api(args.Name, {
+        protocolType: 'WEBSOCKET',
+        routeSelectionExpression: args.RouteSelectionExpression,
+    })
+}
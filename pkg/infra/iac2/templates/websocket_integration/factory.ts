@@ -0,0 +1,18 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    Type: string
+    Uri: pulumi.Output<string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Integration {
+    return new aws.apigatewayv2.Integration(args.Name, {
+        apiId: args.Api.id,
+        integrationType: args.Type,
+        integrationUri: args.Uri,
+    })
+}
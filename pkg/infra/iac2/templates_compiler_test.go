@@ -4,14 +4,20 @@ import (
 	"bytes"
 	"io/fs"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"testing/fstest"
 	"time"
 
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/construct"
 	"github.com/klothoplatform/klotho/pkg/graph"
+	"github.com/klothoplatform/klotho/pkg/provider/aws"
 	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	azureresources "github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+	gcpresources "github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
 	"github.com/klothoplatform/klotho/pkg/provider/imports"
 	"github.com/stretchr/testify/assert"
 )
@@ -243,6 +249,154 @@ func Test_renderGlueVars(t *testing.T) {
 	}
 }
 
+func TestRenderGcpResources(t *testing.T) {
+	repo := &gcpresources.ArtifactRegistryRepository{Name: "app", RepositoryId: "app"}
+	image := &gcpresources.ArtifactRegistryImage{Name: "api", Repository: repo, ImageName: "api", Context: "api", Dockerfile: "api/Dockerfile"}
+	sa := &gcpresources.ServiceAccount{Name: "api", AccountId: "app-api", Roles: []string{"roles/pubsub.publisher"}}
+	topic := &gcpresources.PubsubTopic{Name: "events"}
+	sub := &gcpresources.PubsubSubscription{Name: "events-api", Topic: topic, AckDeadlineSeconds: 60}
+	service := &gcpresources.CloudRunService{
+		Name:           "api",
+		Image:          image,
+		ServiceAccount: sa,
+		Port:           3000,
+		EnvironmentVariables: map[string]construct.IaCValue{
+			"KLOTHO_PUBSUB_TOPIC_EVENTS": {ResourceId: topic.Id(), Property: gcpresources.NAME_IAC_VALUE},
+		},
+		AllowUnauthenticated: true,
+		CpuAlwaysAllocated:   true,
+	}
+	address := &gcpresources.GlobalAddress{Name: "gw-address"}
+
+	graph := construct.NewResourceGraph()
+	graph.AddDependency(image, repo)
+	graph.AddDependency(service, image)
+	graph.AddDependency(service, sa)
+	graph.AddDependency(service, topic)
+	graph.AddDependency(sa, topic)
+	graph.AddDependency(sub, service)
+	graph.AddDependency(sub, topic)
+	graph.AddResource(address)
+
+	compiler := CreateTemplatesCompiler(graph)
+
+	t.Run("body", func(t *testing.T) {
+		assert := assert.New(t)
+		buf := bytes.Buffer{}
+		err := compiler.RenderBody(&buf)
+		if !assert.NoError(err) {
+			return
+		}
+		body := buf.String()
+		for _, want := range []string{
+			"const pubsubTopicEvents = new gcp.pubsub.Topic(`events`, {});",
+			"for (const role of [`roles/pubsub.publisher`]) {",
+			"imageName: pulumi.interpolate`${registry}/${artifactRegistryRepositoryApp.project}/${artifactRegistryRepositoryApp.repositoryId}/${`api`}:latest`,",
+			"serviceAccount: serviceAccountApi.email,",
+			"image: artifactRegistryImageApi.repoDigest,",
+			`envs: Object.entries({"KLOTHO_PUBSUB_TOPIC_EVENTS":pubsubTopicEvents.name})`,
+			"minInstanceCount: 1,",
+			"role: 'roles/run.invoker',",
+			"topic: pubsubTopicEvents.name,",
+			"const globalAddressGwAddress = new gcp.compute.GlobalAddress(`gw-address`, {}, { protect: protect });",
+		} {
+			assert.Contains(body, want)
+		}
+		assert.NotContains(body, "volumeMounts", "service without sql instances mounts /cloudsql")
+		// the order of a resource's dependencies is not deterministic
+		dependsOn := regexp.MustCompile(`dependsOn: \[([^\]]*),\]`).FindStringSubmatch(body[strings.Index(body, "const cloudRunServiceApi"):])
+		if assert.Len(dependsOn, 2) {
+			assert.ElementsMatch([]string{"artifactRegistryImageApi", "serviceAccountApi", "pubsubTopicEvents"}, strings.Split(dependsOn[1], ","))
+		}
+	})
+	t.Run("exports", func(t *testing.T) {
+		assert := assert.New(t)
+		buf := bytes.Buffer{}
+		err := compiler.RenderExports(&buf)
+		if !assert.NoError(err) {
+			return
+		}
+		assert.Contains(buf.String(), `"gw-address": globalAddressGwAddress.address,`)
+	})
+}
+
+func TestRenderAzureResources(t *testing.T) {
+	group := &azureresources.ResourceGroup{Name: "app"}
+	registry := &azureresources.ContainerRegistry{Name: "app", ResourceGroup: group}
+	image := &azureresources.ContainerImage{Name: "api", Registry: registry, ImageName: "api", Context: "api", Dockerfile: "api/Dockerfile"}
+	env := &azureresources.ContainerAppEnvironment{Name: "app", ResourceGroup: group}
+	vault := &azureresources.KeyVault{Name: "secrets", ResourceGroup: group, Secrets: []azureresources.KeyVaultSecret{{Path: "api_key.txt", SecretName: "api-key-txt"}}}
+	cosmos := &azureresources.CosmosDbAccount{Name: "kv", ResourceGroup: group}
+	namespace := &azureresources.ServiceBusNamespace{Name: "app", ResourceGroup: group}
+	topic := &azureresources.ServiceBusTopic{Name: "events", Namespace: namespace}
+	sub := &azureresources.ServiceBusSubscription{Name: "events-api", Topic: topic}
+	identity := &azureresources.ManagedIdentity{Name: "api", ResourceGroup: group}
+	identity.AddRoleAssignment(azureresources.KEY_VAULT_SECRETS_USER_ROLE, vault)
+	cosmos.AddDataContributor(identity)
+	app := &azureresources.ContainerApp{
+		Name:          "api",
+		ResourceGroup: group,
+		Environment:   env,
+		Image:         image,
+		Identity:      identity,
+		Port:          3000,
+		EnvironmentVariables: map[string]construct.IaCValue{
+			azureresources.KEY_VAULT_URL_ENV_VAR: {ResourceId: vault.Id(), Property: azureresources.VAULT_URI_IAC_VALUE},
+		},
+		ExternalIngress: true,
+		MinReplicas:     1,
+	}
+
+	graph := construct.NewResourceGraph()
+	for _, res := range []construct.Resource{registry, env, vault, cosmos, namespace, identity} {
+		graph.AddDependency(res, group)
+	}
+	graph.AddDependency(image, registry)
+	graph.AddDependency(topic, namespace)
+	graph.AddDependency(identity, vault)
+	// the deployment order of the identity's edge to the account is reversed, since the account references it
+	graph.AddDependency(cosmos, identity)
+	graph.AddDependency(app, image)
+	graph.AddDependency(app, env)
+	graph.AddDependency(app, identity)
+	graph.AddDependency(app, vault)
+	graph.AddDependency(app, cosmos)
+	graph.AddDependency(sub, app)
+	graph.AddDependency(sub, topic)
+
+	compiler := CreateTemplatesCompiler(graph)
+	assert := assert.New(t)
+	buf := bytes.Buffer{}
+	err := compiler.RenderBody(&buf)
+	if !assert.NoError(err) {
+		return
+	}
+	body := buf.String()
+	for _, want := range []string{
+		"const resourceGroupApp = new azure.resources.ResourceGroup(`app`, {});",
+		"for (const s of [{path: `api_key.txt`,\nsecretName: `api-key-txt`,\n}]) {",
+		"for (const [i, identity] of [managedIdentityApi].entries()) {",
+		"for (const [i, assignment] of [{roleDefinitionId: `4633458b-17de-408a-b874-0445c86b69e6`,\nscope: keyVaultSecrets.id,\n}].entries()) {",
+		"userAssignedIdentities: [managedIdentityApi.id],",
+		"external: true,",
+		`...Object.entries({"KLOTHO_KEY_VAULT_URL":keyVaultSecrets.properties.vaultUri})`,
+		"minReplicas: 1,",
+		"namespaceName: serviceBusNamespaceApp.name,",
+		"topicName: serviceBusTopicEvents.name,",
+	} {
+		assert.Contains(body, want)
+	}
+	// resources must be declared before the resources which reference them
+	for _, order := range [][2]string{
+		{"const managedIdentityApi =", "const cosmosdbAccountKv ="},
+		{"const keyVaultSecrets =", "const managedIdentityApi ="},
+		{"const containerImageApi =", "const containerAppApi ="},
+		{"const serviceBusTopicEvents =", "const serviceBusSubscriptionEventsApi ="},
+	} {
+		assert.Less(strings.Index(body, order[0]), strings.Index(body, order[1]), "%s must be declared before %s", order[0], order[1])
+	}
+}
+
 type (
 	DummyFizz struct {
 		Value string
@@ -411,3 +565,169 @@ func filesMapToFsMap(files map[string]string) fs.FS {
 func s(lines ...string) string {
 	return strings.Join(lines, "\n")
 }
+
+func TestRenderEcrImageLambdaLayers(t *testing.T) {
+	assert := assert.New(t)
+	repo := &resources.EcrRepository{Name: "app"}
+	image := &resources.EcrImage{
+		Name:       "app-main",
+		Repo:       repo,
+		Context:    "./main",
+		Dockerfile: "./main/Dockerfile",
+		LambdaLayers: []resources.LambdaLayer{{
+			BuildArg: "OTEL_COLLECTOR_LAYER_URL",
+			Versions: []resources.LambdaLayerVersion{
+				{Architecture: "amd64", Arn: "arn:aws:lambda:{region}:123456789012:layer:collector-amd64:1"},
+				{Region: "us-gov-west-1", Architecture: "amd64", Arn: "arn:aws-us-gov:lambda:us-gov-west-1:123456789012:layer:collector-amd64:2"},
+			},
+		}},
+	}
+	graph := construct.NewResourceGraph()
+	graph.AddDependency(image, repo)
+
+	buf := bytes.Buffer{}
+	err := CreateTemplatesCompiler(graph).RenderBody(&buf)
+	if !assert.NoError(err) {
+		return
+	}
+	body := buf.String()
+	for _, want := range []string{
+		"const platform = 'linux/amd64'",
+		"for (const layer of [{buildArg: `OTEL_COLLECTOR_LAYER_URL`,\nversions: [{architecture: `amd64`,\narn: `arn:aws:lambda:{region}:123456789012:layer:collector-amd64:1`,\n},{region: `us-gov-west-1`,\narchitecture: `amd64`,\narn: `arn:aws-us-gov:lambda:us-gov-west-1:123456789012:layer:collector-amd64:2`,\n}],\n}]) {",
+		"Arn: layerVersion.arn.replace('{region}', region.name),",
+		"args: buildArgs,",
+	} {
+		assert.Contains(body, want)
+	}
+}
+
+func TestRenderRenamedUnitEnvironmentVariables(t *testing.T) {
+	assert := assert.New(t)
+	queue := &resources.SqsQueue{Name: "app-jobs"}
+	table := &resources.DynamodbTable{Name: "app-kv"}
+	bucket := &resources.S3Bucket{Name: "app-files"}
+	machine := &resources.SfnStateMachine{Name: "app-orders"}
+	role := &resources.IamRole{Name: "app-main-role"}
+	repo := &resources.EcrRepository{Name: "app"}
+	image := &resources.EcrImage{Name: "app-main", Repo: repo, Context: "./main", Dockerfile: "./main/Dockerfile"}
+	function := &resources.LambdaFunction{
+		Name:  "app-main",
+		Role:  role,
+		Image: image,
+		EnvironmentVariables: map[string]construct.IaCValue{
+			"JOBS_QUEUE_URL":                {ResourceId: queue.Id(), Property: resources.QUEUE_URL_IAC_VALUE},
+			"KLOTHO_KV_DYNAMODB_TABLE_NAME": {ResourceId: table.Id(), Property: string(types.KV_DYNAMODB_TABLE_NAME)},
+			"FILES_BUCKET_NAME":             {ResourceId: bucket.Id(), Property: string(types.BUCKET_NAME)},
+			"ORDERS_STATE_MACHINE_ARN":      {ResourceId: machine.Id(), Property: resources.ARN_IAC_VALUE},
+		},
+	}
+	graph := construct.NewResourceGraph()
+	graph.AddDependency(image, repo)
+	for _, res := range []construct.Resource{role, image, queue, table, bucket, machine} {
+		graph.AddDependency(function, res)
+	}
+
+	cfg := &config.Application{AppName: "app", Environment: "prod", Naming: "{{env}}-{{name}}"}
+	plugin := aws.ResourcePolicyPlugin{Config: cfg, Templates: (&aws.AWS{}).GetOperationalTemplates()}
+	if !assert.NoError(plugin.Translate(construct.NewConstructGraph(), graph)) {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	err := CreateTemplatesCompiler(graph).RenderBody(&buf)
+	if !assert.NoError(err) {
+		return
+	}
+	body := buf.String()
+	for _, want := range []string{
+		`"JOBS_QUEUE_URL":sqsQueueProdAppJobs.url`,
+		`"KLOTHO_KV_DYNAMODB_TABLE_NAME":dynamodbTableProdAppKv.name`,
+		`"FILES_BUCKET_NAME":s3BucketProdAppFiles.bucket`,
+		`"ORDERS_STATE_MACHINE_ARN":sfnStateMachineProdAppOrders.arn`,
+	} {
+		assert.Contains(body, want)
+	}
+	// the function itself is found by its name at runtime, so it keeps it
+	assert.Contains(body, "new aws.lambda.Function(\n        `app-main`")
+}
+
+func TestRenderGlobalDatabase(t *testing.T) {
+	assert := assert.New(t)
+	subnetGroup := &resources.RdsSubnetGroup{Name: "app-orm"}
+	global := &resources.RdsGlobalCluster{Name: "app-orm", Engine: "aurora-postgresql", EngineVersion: "13.7", DatabaseName: "apporm"}
+	primary := &resources.RdsCluster{
+		Name:            "app-orm",
+		GlobalCluster:   global,
+		Engine:          "aurora-postgresql",
+		EngineVersion:   "13.7",
+		DatabaseName:    "apporm",
+		SubnetGroup:     subnetGroup,
+		CredentialsPath: "secrets/app-orm",
+	}
+	provider := resources.NewRegionProvider("us-west-2")
+	secondary := &resources.RdsCluster{
+		Name:          "app-orm-us-west-2",
+		GlobalCluster: global,
+		Engine:        "aurora-postgresql",
+		EngineVersion: "13.7",
+		DatabaseName:  "apporm",
+		Provider:      provider,
+	}
+	reader := &resources.RdsClusterInstance{Name: "app-orm-us-west-2-0", Cluster: secondary, Engine: "aurora-postgresql", EngineVersion: "13.7", InstanceClass: "db.r6g.large", Provider: provider}
+	proxy := &resources.RdsProxy{Name: "app-orm"}
+	targetGroup := &resources.RdsProxyTargetGroup{Name: "app-orm", RdsCluster: primary, RdsProxy: proxy, TargetGroupName: "default"}
+	graph := construct.NewResourceGraph()
+	graph.AddDependency(primary, global)
+	graph.AddDependency(primary, subnetGroup)
+	graph.AddDependency(secondary, global)
+	graph.AddDependency(secondary, primary)
+	graph.AddDependency(secondary, provider)
+	graph.AddDependency(reader, secondary)
+	graph.AddDependency(reader, provider)
+	graph.AddDependency(targetGroup, proxy)
+	graph.AddDependency(targetGroup, primary)
+
+	tc := CreateTemplatesCompiler(graph)
+	buf := bytes.Buffer{}
+	if !assert.NoError(tc.RenderBody(&buf)) {
+		return
+	}
+	body := buf.String()
+	for _, want := range []string{
+		"new aws.rds.GlobalCluster(\n        `app-orm`",
+		"globalClusterIdentifier: rdsGlobalClusterAppOrm.id,",
+		"masterUsername: fs.readFileSync(`secrets/app-orm`, 'utf-8')",
+		"dbSubnetGroupName: rdsSubnetGroupAppOrm.name,",
+		"provider: regionProviderUsWest2,",
+		"clusterIdentifier: rdsClusterAppOrmUsWest2.id,",
+		"instanceClass: `db.r6g.large`,",
+		"dbClusterIdentifier: rdsClusterAppOrm.id,",
+	} {
+		assert.Contains(body, want)
+	}
+	// the secondary cluster has neither credentials nor a database of its own
+	secondaryBody := body[strings.Index(body, "const rdsClusterAppOrmUsWest2"):]
+	secondaryBody = secondaryBody[:strings.Index(secondaryBody, ";")]
+	assert.NotContains(secondaryBody, "masterUsername")
+	assert.NotContains(secondaryBody, "dbSubnetGroupName")
+
+	connection, err := tc.handleSingleIaCValue(construct.IaCValue{ResourceId: primary.Id(), Property: string(types.CONNECTION_STRING)})
+	if assert.NoError(err) {
+		assert.Contains(connection, "@${rdsClusterAppOrm.endpoint}:5432/apporm`")
+	}
+	connection, err = tc.handleSingleIaCValue(construct.IaCValue{ResourceId: proxy.Id(), Property: string(types.CONNECTION_STRING)})
+	if assert.NoError(err) {
+		assert.Contains(connection, "fs.readFileSync('secrets/app-orm', 'utf-8')")
+		assert.Contains(connection, "@${rdsProxyAppOrm.endpoint}:5432/apporm`")
+	}
+
+	secondary.CredentialsSecret = "app-orm-credentials"
+	connection, err = tc.handleSingleIaCValue(construct.IaCValue{ResourceId: secondary.Id(), Property: string(types.CONNECTION_STRING)})
+	if assert.NoError(err) {
+		assert.Equal("pulumi.all([aws.secretsmanager.getSecretVersionOutput({ secretId: `app-orm-credentials` }).secretString, rdsClusterAppOrmUsWest2.endpoint]).apply(([credentials, host]) => { const { username, password } = JSON.parse(credentials); return `postgresql://${username}:${password}@${host}:5432/apporm` })", connection)
+	}
+	arn, err := tc.handleSingleIaCValue(construct.IaCValue{ResourceId: secondary.Id(), Property: resources.RDS_CONNECTION_ARN_IAC_VALUE})
+	if assert.NoError(err) {
+		assert.Contains(arn, ":dbuser:${rdsClusterAppOrmUsWest2.clusterResourceId}/*`")
+	}
+}
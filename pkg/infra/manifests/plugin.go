@@ -0,0 +1,319 @@
+// Package manifests translates the resources of the kubernetes provider into the manifests and helm charts of an app
+// which is deployed to an existing cluster, and the script which deploys them.
+package manifests
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+	"github.com/klothoplatform/klotho/pkg/sanitization/kubernetes"
+	"helm.sh/helm/v3/pkg/chart"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type (
+	Plugin struct {
+		Config *config.Application
+	}
+
+	// helmChart holds the objects of the units whose helm chart options place them in the same chart
+	helmChart struct {
+		directory   string
+		valuesFiles []string
+		objects     []object
+		values      map[string]string
+	}
+
+	object struct {
+		fileName string
+		object   any
+	}
+)
+
+const (
+	manifestsDir   = "manifests"
+	deployFileName = "deploy.sh"
+)
+
+func (p Plugin) Name() string {
+	return "manifests"
+}
+
+func (p Plugin) Translate(dag *construct.ResourceGraph) ([]io.File, error) {
+	clusters := construct.GetResources[*resources.Cluster](dag)
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+	if len(clusters) > 1 {
+		return nil, fmt.Errorf("an app can only be deployed to a single cluster, found %d", len(clusters))
+	}
+	cluster := clusters[0]
+
+	var manifests []object
+	var secrets []*resources.Secret
+	var images []unitImage
+	charts := map[string]*helmChart{}
+	var errs multierr.Error
+
+	for _, res := range dag.ListResources() {
+		switch res := res.(type) {
+		case *resources.Cluster:
+		case *resources.Deployment:
+			unit := resources.DeploymentUnit(res)
+			if unit == nil || res.Object == nil || len(res.Object.Spec.Template.Spec.Containers) == 0 {
+				errs.Append(fmt.Errorf("%s has no execution unit container", res.Id()))
+				continue
+			}
+			images = append(images, unitImage{context: unit.Name, image: res.Object.Spec.Template.Spec.Containers[0].Image})
+			env, err := unitEnvironment(dag, res)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+			deployment := res.Object.DeepCopy()
+			service := object{fileName: fmt.Sprintf("service_%s.yaml", resources.UnitServiceName(unit.Name)), object: resources.UnitService(res, unit)}
+
+			helmOptions := p.Config.GetExecutionUnit(unit.Name).HelmChartOptions
+			if helmOptions.Directory == "" {
+				deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, env...)
+				manifests = append(manifests, object{fileName: res.Path(), object: deployment}, service)
+				continue
+			}
+			c, ok := charts[helmOptions.Directory]
+			if !ok {
+				c = &helmChart{directory: helmOptions.Directory, values: map[string]string{}}
+				charts[helmOptions.Directory] = c
+			}
+			c.valuesFiles = append(c.valuesFiles, helmOptions.ValuesFiles...)
+			for _, envVar := range env {
+				_, key := resources.GenerateEnvVarKeyValue(envVar.Name)
+				if existing, ok := c.values[key]; ok && existing != envVar.Value {
+					errs.Append(fmt.Errorf("environment variable %s of %s conflicts with another unit of the helm chart in %s", envVar.Name, unit.Name, c.directory))
+					continue
+				}
+				c.values[key] = envVar.Value
+				deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env,
+					corev1.EnvVar{Name: envVar.Name, Value: fmt.Sprintf("{{ .Values.%s }}", key)})
+			}
+			c.objects = append(c.objects, object{fileName: res.Path(), object: deployment}, service)
+		case resources.StatefulService:
+			if isNil(res.GetObject()) {
+				errs.Append(fmt.Errorf("%s has no object", res.Id()))
+				continue
+			}
+			service := res.ServiceObject()
+			manifests = append(manifests,
+				object{fileName: res.Path(), object: res},
+				object{fileName: fmt.Sprintf("service_%s.yaml", service.Name), object: service},
+			)
+		case resources.ManifestFile:
+			if isNil(res.GetObject()) {
+				errs.Append(fmt.Errorf("%s has no object", res.Id()))
+				continue
+			}
+			manifests = append(manifests, object{fileName: res.Path(), object: res.GetObject()})
+		case *resources.Secret:
+			secrets = append(secrets, res)
+		default:
+			errs.Append(fmt.Errorf("unsupported resource %s for a cluster's manifests", res.Id()))
+		}
+	}
+	if err := errs.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	var files []io.File
+	for _, m := range manifests {
+		file, err := objectFile(path.Join(manifestsDir, m.fileName), m.object)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	var chartList []*helmChart
+	for _, c := range charts {
+		chartFiles, err := c.files()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, chartFiles...)
+		chartList = append(chartList, c)
+	}
+	sort.Slice(chartList, func(i, j int) bool { return chartList[i].directory < chartList[j].directory })
+	sort.Slice(images, func(i, j int) bool { return images[i].context < images[j].context })
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+
+	files = append(files, &io.RawFile{
+		FPath:   deployFileName,
+		Content: []byte(deployScript(p.Config.QualifiedAppName(), cluster, images, secrets, len(manifests) > 0, chartList)),
+	})
+	return files, nil
+}
+
+// unitEnvironment returns the environment variables which the runtime of the deployment's unit reads its dependencies
+// from, resolved to the properties of the resources they were expanded to
+func unitEnvironment(dag *construct.ResourceGraph, deployment *resources.Deployment) ([]corev1.EnvVar, error) {
+	unit := resources.DeploymentUnit(deployment)
+	var env []corev1.EnvVar
+	var errs multierr.Error
+	for _, envVar := range unit.EnvironmentVariables {
+		if envVar.GetConstruct() == nil {
+			continue
+		}
+		var value *string
+		for _, res := range dag.GetDownstreamResources(deployment) {
+			if !res.BaseConstructRefs().Has(envVar.GetConstruct().Id()) {
+				continue
+			}
+			service, ok := res.(resources.StatefulService)
+			if !ok {
+				continue
+			}
+			v, err := service.GetProperty(envVar.GetValue())
+			if err != nil {
+				errs.Append(err)
+				break
+			}
+			value = &v
+			break
+		}
+		if value == nil {
+			errs.Append(fmt.Errorf("%s has no resource for environment variable %s of %s", deployment.Id(), envVar.GetName(), envVar.GetConstruct().Id()))
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: envVar.GetName(), Value: *value})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+	return env, errs.ErrOrNil()
+}
+
+// files returns the chart's metadata, templates and the values of its templates
+func (c *helmChart) files() ([]io.File, error) {
+	metadata := &chart.Metadata{
+		Name:        c.name(),
+		APIVersion:  "v2",
+		AppVersion:  "0.0.1",
+		Version:     "0.0.1",
+		KubeVersion: ">= 1.22.0-0",
+		Type:        "application",
+	}
+	chartYaml, err := yaml.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	valuesYaml, err := yaml.Marshal(c.values)
+	if err != nil {
+		return nil, err
+	}
+	files := []io.File{
+		&io.RawFile{FPath: path.Join(c.directory, "Chart.yaml"), Content: chartYaml},
+		&io.RawFile{FPath: path.Join(c.directory, "values.yaml"), Content: valuesYaml},
+	}
+	for _, o := range c.objects {
+		file, err := objectFile(path.Join(c.directory, "templates", o.fileName), o.object)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// name returns the name of the chart, which is also the name of its release
+func (c *helmChart) name() string {
+	return kubernetes.MetadataNameSanitizer.Apply(path.Base(c.directory))
+}
+
+func objectFile(filePath string, o any) (io.File, error) {
+	if file, ok := o.(resources.ManifestFile); ok {
+		o = file.GetObject()
+	}
+	content, err := yaml.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return &io.RawFile{FPath: filePath, Content: content}, nil
+}
+
+// isNil returns whether the object of a manifest file is nil, which the interface of the object hides
+func isNil(o v1.Object) bool {
+	return o == nil || reflect.ValueOf(o).IsNil()
+}
+
+type unitImage struct {
+	context string
+	image   string
+}
+
+// deployScript returns the script which builds the images of the units, creates the secrets from their files and
+// applies the manifests and charts to the cluster
+func deployScript(appName string, cluster *resources.Cluster, images []unitImage, secrets []*resources.Secret, hasManifests bool, charts []*helmChart) string {
+	var kubeFlags, helmFlags []string
+	if cluster.Kubeconfig != "" {
+		kubeFlags = append(kubeFlags, "--kubeconfig", shellQuote(cluster.Kubeconfig))
+		helmFlags = append(helmFlags, "--kubeconfig", shellQuote(cluster.Kubeconfig))
+	}
+	if cluster.Context != "" {
+		kubeFlags = append(kubeFlags, "--context", shellQuote(cluster.Context))
+		helmFlags = append(helmFlags, "--kube-context", shellQuote(cluster.Context))
+	}
+	namespaceFlags := kubeFlags
+	if cluster.Namespace != "" {
+		namespaceFlags = append(append([]string{}, kubeFlags...), "--namespace", shellQuote(cluster.Namespace))
+		helmFlags = append(helmFlags, "--namespace", shellQuote(cluster.Namespace), "--create-namespace")
+	}
+	kubectl := strings.Join(append([]string{"kubectl"}, namespaceFlags...), " ")
+
+	script := &strings.Builder{}
+	fmt.Fprintf(script, "#!/bin/sh\n# Deploys %s to the cluster %s. Run it with sh from any directory.\nset -e\ncd \"$(dirname \"$0\")\"\n", appName, cluster.Name)
+
+	if len(images) > 0 {
+		script.WriteString("\n")
+	}
+	for _, image := range images {
+		fmt.Fprintf(script, "docker build -t %s ./%s\n", shellQuote(image.image), shellQuote(image.context))
+		if cluster.ImageRegistry != "" {
+			fmt.Fprintf(script, "docker push %s\n", shellQuote(image.image))
+		}
+	}
+
+	script.WriteString("\n")
+	if cluster.Namespace != "" {
+		fmt.Fprintf(script, "%s create namespace %s --dry-run=client -o yaml | %s apply -f -\n",
+			strings.Join(append([]string{"kubectl"}, kubeFlags...), " "), shellQuote(cluster.Namespace),
+			strings.Join(append([]string{"kubectl"}, kubeFlags...), " "))
+	}
+	for _, secret := range secrets {
+		fromFiles := make([]string, len(secret.Paths))
+		for i, p := range secret.Paths {
+			fromFiles[i] = "--from-file=" + shellQuote(fmt.Sprintf("%s=./%s", secret.Key(p), p))
+		}
+		fmt.Fprintf(script, "%s create secret generic %s %s --dry-run=client -o yaml | %s apply -f -\n",
+			kubectl, secret.ObjectName(), strings.Join(fromFiles, " "), kubectl)
+	}
+	if hasManifests {
+		fmt.Fprintf(script, "%s apply -f %s/\n", kubectl, manifestsDir)
+	}
+	for _, c := range charts {
+		args := append([]string{"helm", "upgrade", "--install", c.name(), "./" + shellQuote(c.directory)}, helmFlags...)
+		for _, valuesFile := range c.valuesFiles {
+			args = append(args, "-f", shellQuote(valuesFile))
+		}
+		script.WriteString(strings.Join(args, " ") + "\n")
+	}
+	return script.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -115,7 +115,7 @@ func splitPathRoot(cfgPath string) (root, path string) {
 func ReadDir(fsys fs.FS, cfg config.Application, cfgFilePath string) (*types.InputFiles, error) {
 	input := new(types.InputFiles)
 
-	// Need to check for tsconfig before WalkDir to make sure it's read first before any JS files.
+	// Need to check for tsconfig before WalkDir to make sure its outDir is known before walking into it.
 	tsConfigPath := filepath.Join(cfg.Path, "tsconfig.json")
 	var tsConfig struct {
 		CompilerOptions struct {
@@ -202,6 +202,11 @@ func ReadDir(fsys fs.FS, cfg config.Application, cfgFilePath string) (*types.Inp
 				// Don't let previous compiled output as input
 				return fs.SkipDir
 			}
+			if outDir := tsConfig.CompilerOptions.OutDir; outDir != "" && outDir != cfg.Path && path == outDir {
+				// TypeScript sources are read directly, so skip the JS emitted by `tsc`
+				zap.L().With(logging.FileField(f)).Debug("detected TS outDir, skipping directory")
+				return fs.SkipDir
+			}
 			if statFS, ok := fsys.(fs.StatFS); ok {
 				checkPath := filepath.Join(path, "resources.json")
 				if _, err = statFS.Stat(checkPath); err == nil {
@@ -223,14 +228,13 @@ func ReadDir(fsys fs.FS, cfg config.Application, cfgFilePath string) (*types.Inp
 		if !isProjectFile {
 			ext := filepath.Ext(info.Name())
 			switch ext {
-			case ".js":
-				if tsConfig.CompilerOptions.OutDir != "" {
-					tsPrefix := tsConfig.CompilerOptions.OutDir + string(os.PathSeparator)
-					newPath := strings.TrimPrefix(path, tsPrefix) // tsPrefix is already joined to cfg.Path, so use `path` not `relPath`
-					if relPath != newPath && newPath != path {
-						zap.S().Debugf("Removing TS outdir from %s -> %s", relPath, newPath)
-						relPath = newPath
-					}
+			case ".js", ".mjs", ".cjs":
+				f, err = addFile(fsys, path, relPath, javascript.NewFile)
+				jsLang.foundSources = true
+			case ".ts", ".tsx":
+				if !javascript.IsTypeScriptFile(path) {
+					// declaration files (.d.ts) only contain types
+					break
 				}
 				f, err = addFile(fsys, path, relPath, javascript.NewFile)
 				jsLang.foundSources = true
@@ -97,6 +97,25 @@ func TestReadDir(t *testing.T) {
 			rootPath: "parent/src",
 			want:     nil, // expect an err due to no package.json in parent/src
 		},
+		{
+			name: "ts: sources are read and outDir is skipped",
+			files: map[string]string{
+				"fizz/index.ts":      "",
+				"fizz/app.tsx":       "",
+				"fizz/types.d.ts":    "",
+				"fizz/dist/index.js": "",
+				"fizz/tsconfig.json": `{"compilerOptions": {"outDir": "dist"}}`,
+				"fizz/package.json":  "{}",
+			},
+			rootPath: "fizz",
+			want: []string{
+				"index.ts",
+				"app.tsx",
+				"types.d.ts",
+				"tsconfig.json",
+				"package.json",
+			},
+		},
 		{
 			name: "py: simple",
 			files: map[string]string{
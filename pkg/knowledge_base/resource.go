@@ -2,8 +2,10 @@ package knowledgebase
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/sanitization"
 )
 
 type (
@@ -23,6 +25,12 @@ type (
 		DeleteContext construct.DeleteContext `json:"delete_context" yaml:"delete_context"`
 		// Views defines the views that the resource should be added to as a distinct node
 		Views map[string]string `json:"views" yaml:"views"`
+		// RequiresImportWithParent defines that the resource cannot be created in a parent which is imported, such as a subnet
+		// of an imported vpc. The resource must instead be imported along with its parent.
+		RequiresImportWithParent bool `json:"requires_import_with_parent" yaml:"requires_import_with_parent"`
+		// PreserveName defines that the resource's name must not be changed by the application's naming template, such as
+		// when the runtime looks the resource up by the name it was given or the resource has no name of its own
+		PreserveName bool `json:"preserve_name" yaml:"preserve_name"`
 	}
 
 	// OperationalRule defines a rule that must pass checks and actions which must be carried out to make a resource operational
@@ -71,6 +79,8 @@ type (
 		ZeroValueAllowed bool `json:"zero_value_allowed" yaml:"zero_value_allowed"`
 	}
 
+	// Sanitization defines how a name is made valid for the resource, applying each rule's replacement in order and then
+	// shortening the name to fit its max length, which leaves room for the suffix that Pulumi adds to the name
 	Sanitization struct {
 		Rules     []SanitizationRule `json:"rules" yaml:"rules"`
 		MaxLength int                `json:"max_length" yaml:"max_length"`
@@ -79,6 +89,7 @@ type (
 	SanitizationRule struct {
 		Pattern     string `json:"pattern" yaml:"pattern"`
 		Replacement string `json:"replacement" yaml:"replacement"`
+		Lowercase   bool   `json:"lowercase" yaml:"lowercase"`
 	}
 
 	// OperationEnforcement defines how the rule should be enforced
@@ -114,3 +125,20 @@ func (or *OperationalRule) String() string {
 	}
 	return string(or.Enforcement)
 }
+
+// Apply sanitizes name according to the rules and length limits of the sanitization
+func (s Sanitization) Apply(name string) (string, error) {
+	rules := make([]sanitization.Rule, len(s.Rules))
+	for i, rule := range s.Rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid sanitization pattern '%s': %w", rule.Pattern, err)
+		}
+		rules[i] = sanitization.Rule{Pattern: pattern, Replacement: rule.Replacement, Lowercase: rule.Lowercase}
+	}
+	name = sanitization.NewSanitizer(rules, s.MaxLength).Apply(name)
+	if len(name) < s.MinLength {
+		return "", fmt.Errorf("sanitized name '%s' is shorter than %d characters", name, s.MinLength)
+	}
+	return name, nil
+}
@@ -0,0 +1,64 @@
+package knowledgebase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SanitizationApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		sanitization Sanitization
+		input        string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:  "no rules",
+			input: "app-prod_jobs",
+			want:  "app-prod_jobs",
+		},
+		{
+			name: "rules apply in order",
+			sanitization: Sanitization{Rules: []SanitizationRule{
+				{Pattern: `[^a-zA-Z0-9-]`, Replacement: "-", Lowercase: true},
+				{Pattern: `--+`, Replacement: "-"},
+			}},
+			input: "App_prod__DB",
+			want:  "app-prod-db",
+		},
+		{
+			name:         "shortened with a hash of the overflow, leaving room for the suffix",
+			sanitization: Sanitization{MaxLength: 20},
+			input:        "app-prod-sqs_queue-jobs",
+			want:         "app-e335bca5",
+		},
+		{
+			name:         "invalid pattern",
+			sanitization: Sanitization{Rules: []SanitizationRule{{Pattern: `[`}}},
+			input:        "app",
+			wantErr:      true,
+		},
+		{
+			name:         "too short",
+			sanitization: Sanitization{Rules: []SanitizationRule{{Pattern: `[^a-z]`}}, MinLength: 3},
+			input:        "a-1",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := tt.sanitization.Apply(tt.input)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -93,12 +93,15 @@ func refreshUpstreamEntrypoints(unit *types.ExecutionUnit) {
 }
 
 func refreshSourceFiles(unit *types.ExecutionUnit) error {
-	sourceFiles, err := upstreamDependencyResolver.Resolve(unit)
+	sourceFiles, err := upstreamDependencyResolver.ResolveWithReasons(unit)
 	if err != nil {
 		return errors.WrapErrf(err, "file dependency resolution failed for execution unit: %s", unit.Name)
 	}
-	for k, v := range sourceFiles {
-		unit.Executable.SourceFiles[k] = v
+	for path, reason := range sourceFiles {
+		unit.Executable.SourceFiles[path] = struct{}{}
+		if _, explained := unit.Executable.InclusionReasons[path]; !explained {
+			unit.Executable.SetInclusionReason(path, reason)
+		}
 	}
-	return err
+	return nil
 }
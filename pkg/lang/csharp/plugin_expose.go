@@ -235,6 +235,11 @@ func (h *aspDotNetCoreHandler) handleFile(f *types.SourceFile) (*types.SourceFil
 			}
 			h.RoutesByGateway[gwSpec] = []gatewayRouteDefinition{}
 
+			if strings.Contains(useEndpoint.UseExpression.Content(), endpointRouteBuilderName+".MapHub<") {
+				return nil, types.NewCompilerError(f, capAnnotation, errors.Errorf(
+					"SignalR hubs cannot be mapped to the WebSocket APIs of gateway %s: remove the MapHub call or expose the hub from a separate, unannotated app", capability.ID))
+			}
+
 			localRoutes, err := h.findLocallyMappedRoutes(f, endpointRouteBuilderName, "")
 			if err != nil {
 				return nil, types.NewCompilerError(f, capAnnotation, err)
@@ -712,3 +712,38 @@ func TestExpose_Transform(t *testing.T) {
 		})
 	}
 }
+
+func TestExpose_Transform_signalRHub(t *testing.T) {
+	assert := assert.New(t)
+	sf, err := types.NewSourceFile("Startup.cs", strings.NewReader(`
+	using Microsoft.AspNetconstruct.Builder;
+	using Microsoft.AspNetconstruct.Hosting;
+
+	namespace WebAPILambda
+	{
+		public class Startup
+		{
+			public void Configure(IApplicationBuilder app, IWebHostEnvironment env)
+			{
+				/**
+				 * @klotho::expose {
+				 *  id = "my-gateway"
+				 *  target = "public"
+				 * }
+				 */
+				app.UseEndpoints(endpoints => endpoints.MapHub<ChatHub>("/chat"));
+			}
+		}
+	}`), Language)
+	if !assert.NoError(err) {
+		return
+	}
+	unit := &types.ExecutionUnit{Executable: types.NewExecutable(), Name: "main"}
+	unit.AddSourceFile(sf)
+	result := construct.NewConstructGraph()
+	result.AddConstruct(unit)
+
+	expose := Expose{}
+	err = expose.Transform(&types.InputFiles{}, &types.FileDependencies{}, result)
+	assert.ErrorContains(err, "SignalR hubs cannot be mapped to the WebSocket APIs of gateway my-gateway")
+}
@@ -1,10 +1,12 @@
 package csharp
 
 import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/lang"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +19,8 @@ type (
 func NewCSharpPlugins(cfg *config.Application, runtime Runtime) *CSharpPlugins {
 	return &CSharpPlugins{
 		Plugins: []compiler.AnalysisAndTransformationPlugin{
+			// C# units run their own ASP.NET Core app, without a dispatcher to invoke scheduled functions or fs event subscribers
+			lang.UnsupportedCapabilities{Language: CSharp, Capabilities: []string{annotation.ScheduleCapability, annotation.FsEventCapability}},
 			&Expose{},
 			&AddExecRuntimeFiles{
 				runtime: runtime,
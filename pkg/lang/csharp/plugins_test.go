@@ -0,0 +1,73 @@
+package csharp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CSharpPlugins_unsupportedCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{
+			name: "schedule",
+			source: `public class Jobs
+{
+    /**
+     * @klotho::schedule {
+     *   id = "nightly"
+     *   cron = "0 0 * * *"
+     * }
+     */
+    public static void Nightly() {}
+}
+`,
+			wantErr: "@klotho::schedule is not supported in csharp",
+		},
+		{
+			name: "fs event",
+			source: `public class Uploads
+{
+    /**
+     * @klotho::fs_event {
+     *   id = "uploads"
+     * }
+     */
+    public static void OnUpload() {}
+}
+`,
+			wantErr: "@klotho::fs_event is not supported in csharp",
+		},
+		{
+			name: "no annotations",
+			source: `public class Program {}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			f, err := NewFile("Program.cs", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			input := &types.InputFiles{}
+			input.Add(f)
+
+			plugins := NewCSharpPlugins(&config.Application{AppName: "app"}, nil)
+			err = plugins.Plugins[0].Transform(input, &types.FileDependencies{}, construct.NewConstructGraph())
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/klothoplatform/klotho/pkg/lang/golang"
 	"github.com/klothoplatform/klotho/pkg/provider/aws"
 	kubernetes "github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+	"github.com/klothoplatform/klotho/pkg/provider/local"
 	"github.com/klothoplatform/klotho/pkg/runtime"
 	"github.com/pkg/errors"
 )
@@ -44,7 +45,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 	switch unitType {
 	case aws.Lambda:
 		DockerFile = dockerfileLambda
-	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE:
+	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, local.Container:
 		DockerFile = dockerfileExec
 	default:
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
@@ -68,6 +69,7 @@ var commentRegex = regexp.MustCompile(`(?m)^(\s*)`)
 
 func (r *AwsRuntime) ActOnExposeListener(unit *types.ExecutionUnit, f *types.SourceFile, listener *golang.HttpListener, routerName string) error {
 	unitType := r.Cfg.GetResourceType(unit)
+	tracing := r.Cfg.Observability != nil && r.Cfg.Observability.Tracing
 	//TODO: Move comment listen code to library logic like JS does eventually
 	if unitType == aws.Lambda {
 		nodeToComment := listener.Expression.Content()
@@ -87,6 +89,18 @@ func (r *AwsRuntime) ActOnExposeListener(unit *types.ExecutionUnit, f *types.Sou
 			}
 			lambda.StartWithContext(context.Background(), handler)
 			//End - Added by Klotho`, routerName)
+			if tracing {
+				// the invocation is frozen once it returns, so its spans are exported before then
+				dispatcherCode = fmt.Sprintf(`
+			// Begin - Added by Klotho
+			httpLambda := httpadapter.New(klothoTracedHandler(%s))
+			handler := func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				defer klothoFlushTraces(ctx)
+				return httpLambda.ProxyWithContext(ctx, req)
+			}
+			lambda.StartWithContext(context.Background(), handler)
+			//End - Added by Klotho`, routerName)
+			}
 
 			newNodeContent = newNodeContent + dispatcherCode
 
@@ -100,9 +114,13 @@ func (r *AwsRuntime) ActOnExposeListener(unit *types.ExecutionUnit, f *types.Sou
 			{Package: "context"},
 			{Package: "github.com/aws/aws-lambda-go/events"},
 			{Package: "github.com/aws/aws-lambda-go/lambda"},
-			{Package: "github.com/awslabs/aws-lambda-go-api-proxy/chi"},
 			{Package: "github.com/go-chi/chi/v5"},
 		}
+		if tracing {
+			handlerRequirements = append(handlerRequirements, golang.Import{Package: "github.com/awslabs/aws-lambda-go-api-proxy/httpadapter"})
+		} else {
+			handlerRequirements = append(handlerRequirements, golang.Import{Package: "github.com/awslabs/aws-lambda-go-api-proxy/chi"})
+		}
 
 		err := golang.UpdateImportsInFile(f, handlerRequirements, []golang.Import{{Package: "github.com/go-chi/chi"}})
 		if err != nil {
@@ -116,19 +134,100 @@ require (
 	github.com/go-chi/chi/v5 v5.0.7 // indirect
 )
 		`
-		for _, f := range unit.Files() {
-			// looking for the root go.mod that we copy to each exec unit
-			if f.Path() == "go.mod" {
-				modFile, ok := f.(*golang.GoMod)
-				if !ok {
-					return errors.Errorf("Unable to update %s with new requirements", f.Path())
-				}
-				// Some requires may be duplicated if the go.mod has similar existing modules but that shouldn't be an issue
-				modFile.AddLine(requireCode)
-			}
+		if err := addRequires(unit, requireCode); err != nil {
+			return err
+		}
+	} else if tracing {
+		err := f.ReplaceNodeContent(listener.Identifier, fmt.Sprintf("klothoTracedHandler(%s)", routerName))
+		if err != nil {
+			return errors.Wrap(err, "error reparsing after substitutions")
+		}
+	}
+	if tracing {
+		return r.addTracing(unit, f)
+	}
+	return nil
+}
+
+// tracingCode sets up the OpenTelemetry tracing of the unit's exposed router. The spans of its requests continue the
+// W3C trace context which callers send, and are exported to the OTLP endpoint which the unit is configured with, if any.
+// The trace context propagator is set globally, so that the unit's own instrumented clients send the context on.
+const tracingCode = `
+
+// Begin - Added by Klotho
+func klothoTracedHandler(handler http.Handler) http.Handler {
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithResource(resource.NewSchemaless(
+		attribute.String("service.name", %[1]q),
+		attribute.String("service.namespace", %[2]q),
+	)))
+	// Without an endpoint the unit still propagates the trace context it receives, but does not export its own spans
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		exporter, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			log.Fatalf("could not create the trace exporter: %%v", err)
 		}
+		tracerProvider.RegisterSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter))
+	}
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return otelhttp.NewHandler(handler, %[1]q)
+}
 
-		return nil
+func klothoFlushTraces(ctx context.Context) {
+	if tracerProvider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		_ = tracerProvider.ForceFlush(ctx)
+	}
+}
+
+//End - Added by Klotho
+`
+
+// addTracing adds the functions which trace the unit's exposed router to f, which wraps the router in
+// klothoTracedHandler.
+func (r *AwsRuntime) addTracing(unit *types.ExecutionUnit, f *types.SourceFile) error {
+	tracingContent := fmt.Sprintf(tracingCode, unit.Name, r.Cfg.QualifiedAppName())
+	err := f.Reparse(append(f.Program(), []byte(tracingContent)...))
+	if err != nil {
+		return errors.Wrap(err, "error reparsing after adding tracing")
+	}
+	err = golang.UpdateImportsInFile(f, []golang.Import{
+		{Package: "context"},
+		{Package: "log"},
+		{Package: "net/http"},
+		{Package: "os"},
+		{Package: "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"},
+		{Package: "go.opentelemetry.io/otel"},
+		{Package: "go.opentelemetry.io/otel/attribute"},
+		{Package: "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"},
+		{Package: "go.opentelemetry.io/otel/propagation"},
+		{Package: "go.opentelemetry.io/otel/sdk/resource"},
+		{Package: "go.opentelemetry.io/otel/sdk/trace", Alias: "sdktrace"},
+	}, nil)
+	if err != nil {
+		return errors.Wrap(err, "error updating imports")
+	}
+	return addRequires(unit, `
+require (
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.45.0 // indirect
+	go.opentelemetry.io/otel v1.19.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.19.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.19.0 // indirect
+)
+		`)
+}
+
+// addRequires adds the requireCode to the unit's root go.mod, which is copied to each exec unit
+func addRequires(unit *types.ExecutionUnit, requireCode string) error {
+	for _, f := range unit.Files() {
+		// looking for the root go.mod that we copy to each exec unit
+		if f.Path() == "go.mod" {
+			modFile, ok := f.(*golang.GoMod)
+			if !ok {
+				return errors.Errorf("Unable to update %s with new requirements", f.Path())
+			}
+			// Some requires may be duplicated if the go.mod has similar existing modules but that shouldn't be an issue
+			modFile.AddLine(requireCode)
+		}
 	}
 	return nil
 }
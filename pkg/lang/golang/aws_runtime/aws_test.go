@@ -0,0 +1,148 @@
+package aws_runtime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/lang/golang"
+	"github.com/klothoplatform/klotho/pkg/provider/aws"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ActOnExposeListener(t *testing.T) {
+	const source = `package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func main() {
+	r := chi.NewRouter()
+	http.ListenAndServe(":3000", r)
+}
+`
+	tests := []struct {
+		name       string
+		unitType   string
+		tracing    bool
+		want       []string
+		wantAbsent []string
+		wantGoMod  []string
+	}{
+		{
+			name:       "lambda",
+			unitType:   aws.Lambda,
+			want:       []string{"chiLambda := chiadapter.New(r)", `"github.com/awslabs/aws-lambda-go-api-proxy/chi"`},
+			wantAbsent: []string{"klothoTracedHandler", "go.opentelemetry.io"},
+			wantGoMod:  []string{"github.com/awslabs/aws-lambda-go-api-proxy v0.13.3"},
+		},
+		{
+			name:     "lambda with tracing",
+			unitType: aws.Lambda,
+			tracing:  true,
+			want: []string{
+				"httpLambda := httpadapter.New(klothoTracedHandler(r))",
+				"defer klothoFlushTraces(ctx)",
+				"func klothoTracedHandler(handler http.Handler) http.Handler {",
+				`attribute.String("service.name", "main")`,
+				`attribute.String("service.namespace", "app")`,
+				`"github.com/awslabs/aws-lambda-go-api-proxy/httpadapter"`,
+				`sdktrace "go.opentelemetry.io/otel/sdk/trace"`,
+			},
+			wantAbsent: []string{"chiadapter"},
+			wantGoMod:  []string{"github.com/awslabs/aws-lambda-go-api-proxy v0.13.3", "go.opentelemetry.io/otel v1.19.0"},
+		},
+		{
+			name:       "ecs",
+			unitType:   aws.Ecs,
+			want:       []string{`http.ListenAndServe(":3000", r)`},
+			wantAbsent: []string{"klothoTracedHandler", "go.opentelemetry.io"},
+		},
+		{
+			name:     "ecs with tracing",
+			unitType: aws.Ecs,
+			tracing:  true,
+			want: []string{
+				`http.ListenAndServe(":3000", klothoTracedHandler(r))`,
+				"func klothoTracedHandler(handler http.Handler) http.Handler {",
+				"otel.SetTextMapPropagator(propagation.TraceContext{})",
+				`"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"`,
+			},
+			wantGoMod: []string{"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.45.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			cfg := &config.Application{
+				AppName:        "app",
+				ExecutionUnits: map[string]*config.ExecutionUnit{"main": {Type: tt.unitType}},
+			}
+			if tt.tracing {
+				cfg.Observability = &config.Observability{Tracing: true}
+			}
+			r := &AwsRuntime{Cfg: cfg}
+
+			f, err := types.NewSourceFile("main.go", strings.NewReader(source), golang.Language)
+			if !assert.NoError(err) {
+				return
+			}
+			goMod, err := golang.NewGoMod("go.mod", strings.NewReader("module example.com/app\n"))
+			if !assert.NoError(err) {
+				return
+			}
+			unit := &types.ExecutionUnit{Name: "main"}
+			unit.Add(f)
+			unit.Add(goMod)
+
+			listener := findListener(f.Tree().RootNode())
+			if !assert.NotNil(listener.Expression) {
+				return
+			}
+			err = r.ActOnExposeListener(unit, f, &listener, "r")
+			if !assert.NoError(err) {
+				return
+			}
+
+			content := string(f.Program())
+			for _, want := range tt.want {
+				assert.Contains(content, want)
+			}
+			for _, absent := range tt.wantAbsent {
+				assert.NotContains(content, absent)
+			}
+			buf := new(bytes.Buffer)
+			_, err = goMod.WriteTo(buf)
+			if !assert.NoError(err) {
+				return
+			}
+			for _, want := range tt.wantGoMod {
+				assert.Contains(buf.String(), want)
+			}
+		})
+	}
+}
+
+// findListener returns the listener of the http.ListenAndServe call under node
+func findListener(node *sitter.Node) golang.HttpListener {
+	if node.Type() == "call_expression" && node.ChildByFieldName("function").Content() == "http.ListenAndServe" {
+		args := node.ChildByFieldName("arguments")
+		return golang.HttpListener{
+			Expression: node,
+			Address:    args.NamedChild(0),
+			Identifier: args.NamedChild(1),
+		}
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if listener := findListener(node.NamedChild(i)); listener.Expression != nil {
+			return listener
+		}
+	}
+	return golang.HttpListener{}
+}
@@ -1,7 +1,10 @@
 package golang
 
 import (
+	"bufio"
+	"bytes"
 	"io"
+	"strings"
 
 	klotho_io "github.com/klothoplatform/klotho/pkg/io"
 )
@@ -46,6 +49,18 @@ func (pf *GoMod) Path() string {
 	return pf.path
 }
 
+// ModulePath returns the module path declared by the `module` directive, or an empty string if there is none.
+func (pf *GoMod) ModulePath() string {
+	scanner := bufio.NewScanner(bytes.NewReader(pf.contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
 func (pf *GoMod) WriteTo(out io.Writer) (int64, error) {
 	write_count := 0
 	b, err := out.Write(pf.contents)
@@ -2,11 +2,14 @@ package golang
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	klotho_io "github.com/klothoplatform/klotho/pkg/io"
 	"github.com/pkg/errors"
 	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
 )
 
 type Import struct {
@@ -134,3 +137,66 @@ func DeleteImportNodes(f *types.SourceFile) error {
 	}
 	return nil
 }
+
+func UnitFileDependencyResolver(unit *types.ExecutionUnit) (types.FileDependencies, error) {
+	var goMod *GoMod
+	for _, f := range unit.Files() {
+		if mod, ok := f.(*GoMod); ok {
+			goMod = mod
+			break
+		}
+	}
+	return ResolveFileDependencies(unit.Files(), goMod)
+}
+
+// ResolveFileDependencies returns the dependencies of each Go file in files. A Go file depends on the other files of
+// its package (the files in the same directory) and on every file of each package it imports from the module
+// declared by goMod. Imports of packages outside the module are not tracked.
+func ResolveFileDependencies(files map[string]klotho_io.File, goMod *GoMod) (types.FileDependencies, error) {
+	packageFiles := make(map[string][]string) // package directory -> file paths
+	for filePath, file := range files {
+		if _, ok := goLang.CastFile(file); ok && !strings.HasSuffix(filePath, "_test.go") {
+			dir := path.Dir(filePath)
+			packageFiles[dir] = append(packageFiles[dir], filePath)
+		}
+	}
+
+	modulePath, moduleDir := "", "."
+	if goMod != nil {
+		modulePath = goMod.ModulePath()
+		moduleDir = path.Dir(goMod.Path())
+	}
+
+	fileDeps := make(types.FileDependencies)
+	for dir, paths := range packageFiles {
+		for _, filePath := range paths {
+			imported := make(types.Imported)
+			for _, other := range paths {
+				if other != filePath {
+					imported[other] = types.References{}
+				}
+			}
+
+			f, _ := goLang.CastFile(files[filePath])
+			for _, imp := range GetImportsInFile(f) {
+				if modulePath == "" || (imp.Package != modulePath && !strings.HasPrefix(imp.Package, modulePath+"/")) {
+					continue
+				}
+				importDir := path.Join(moduleDir, strings.TrimPrefix(imp.Package, modulePath))
+				if importDir == dir {
+					continue
+				}
+				deps, ok := packageFiles[importDir]
+				if !ok {
+					zap.S().Debugf("couldn't find files for package '%s' imported by '%s'", imp.Package, filePath)
+					continue
+				}
+				for _, dep := range deps {
+					imported[dep] = types.References{}
+				}
+			}
+			fileDeps[filePath] = imported
+		}
+	}
+	return fileDeps, nil
+}
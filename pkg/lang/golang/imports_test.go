@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/io"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -246,3 +247,50 @@ import (
 		})
 	}
 }
+
+func TestResolveFileDependencies(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := map[string]string{
+		"main.go": `package main
+import (
+	"fmt"
+	"example.com/app/handlers"
+)`,
+		"server.go":              "package main",
+		"handlers/users.go":      "package handlers\nimport \"example.com/app/db\"",
+		"handlers/orders.go":     "package handlers",
+		"handlers/users_test.go": "package handlers",
+		"db/db.go":               "package db",
+		"unused/unused.go":       "package unused",
+	}
+	files := make(map[string]io.File)
+	for path, source := range sources {
+		f, err := NewFile(path, strings.NewReader(source))
+		if !assert.NoError(err) {
+			return
+		}
+		files[path] = f
+	}
+	goMod, err := NewGoMod("go.mod", strings.NewReader("module example.com/app\n\ngo 1.19\n"))
+	if !assert.NoError(err) {
+		return
+	}
+	files["go.mod"] = goMod
+
+	deps, err := ResolveFileDependencies(files, goMod)
+	if !assert.NoError(err) {
+		return
+	}
+	imported := func(path string) []string {
+		var paths []string
+		for p := range deps[path] {
+			paths = append(paths, p)
+		}
+		return paths
+	}
+	assert.ElementsMatch([]string{"server.go", "handlers/users.go", "handlers/orders.go"}, imported("main.go"))
+	assert.ElementsMatch([]string{"handlers/orders.go", "db/db.go"}, imported("handlers/users.go"))
+	assert.Empty(imported("unused/unused.go"))
+	assert.NotContains(deps, "handlers/users_test.go")
+}
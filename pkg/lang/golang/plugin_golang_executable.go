@@ -4,9 +4,16 @@ import (
 	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	execunit "github.com/klothoplatform/klotho/pkg/exec_unit"
 	"go.uber.org/zap"
 )
 
+var upstreamDependencyResolver = execunit.SourceFilesResolver{
+	UnitFileDependencyResolver: UnitFileDependencyResolver,
+	UpstreamAnnotations:        []string{annotation.ExposeCapability},
+}
+
 type GolangExecutable struct {
 }
 
@@ -37,22 +44,41 @@ func (l GolangExecutable) Transform(input *types.InputFiles, fileDeps *types.Fil
 		unit.AddResource(goMod.Clone())
 		unit.Executable.Type = types.ExecutableTypeGolang
 
-		// TODO: get sourceFiles using a dependency resolver once we can generate FileDependencies for Golang
-		sourceFiles := unit.FilesOfLang(goLang)
-		for _, f := range sourceFiles {
-			unit.AddSourceFile(f)
-		}
-
-		for f := range unit.Executable.SourceFiles {
-			if file, ok := unit.Get(f).(*types.SourceFile); ok && file.IsAnnotatedWith(annotation.ExposeCapability) {
-				zap.L().Sugar().Debugf("Adding execution unit entrypoint: [@klotho::expose] -> [%s] -> %s", unit.Name, f)
-				unit.AddEntrypoint(file)
+		for _, f := range unit.FilesOfLang(goLang) {
+			for _, annot := range f.Annotations() {
+				cap := annot.Capability
+				if cap.Name == annotation.ExecutionUnitCapability && cap.ID == unit.Name {
+					unit.AddEntrypoint(f)
+				}
+			}
+			if f.IsAnnotatedWith(annotation.ExposeCapability) {
+				zap.L().Sugar().Debugf("Adding execution unit entrypoint: [@klotho::expose] -> [%s] -> %s", unit.Name, f.Path())
+				unit.AddEntrypoint(f)
 			}
 		}
 
 		if len(unit.Executable.Entrypoints) == 0 {
 			resolveDefaultEntrypoint(unit)
 		}
+
+		err := refreshSourceFiles(unit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func refreshSourceFiles(unit *types.ExecutionUnit) error {
+	sourceFiles, err := upstreamDependencyResolver.ResolveWithReasons(unit)
+	if err != nil {
+		return klotho_errors.WrapErrf(err, "file dependency resolution failed for execution unit: %s", unit.Name)
+	}
+	for path, reason := range sourceFiles {
+		unit.Executable.SourceFiles[path] = struct{}{}
+		if _, explained := unit.Executable.InclusionReasons[path]; !explained {
+			unit.Executable.SetInclusionReason(path, reason)
+		}
 	}
 	return nil
 }
@@ -1,10 +1,12 @@
 package golang
 
 import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/lang"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +19,8 @@ type (
 func NewGoPlugins(cfg *config.Application, runtime Runtime) *GoPlugins {
 	return &GoPlugins{
 		Plugins: []compiler.AnalysisAndTransformationPlugin{
+			// Go units run their own main function, without a dispatcher to invoke scheduled functions or fs event subscribers
+			lang.UnsupportedCapabilities{Language: goLang, Capabilities: []string{annotation.ScheduleCapability, annotation.FsEventCapability}},
 			&Expose{Config: cfg, runtime: runtime},
 			&AddExecRuntimeFiles{cfg: cfg, runtime: runtime},
 			&PersistFsPlugin{runtime: runtime},
@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GoPlugins_unsupportedCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{
+			name: "schedule",
+			source: `package main
+
+/**
+ * @klotho::schedule {
+ *   id = "nightly"
+ *   cron = "0 0 * * *"
+ * }
+ */
+func Nightly() {}
+`,
+			wantErr: "@klotho::schedule is not supported in go",
+		},
+		{
+			name: "fs event",
+			source: `package main
+
+/**
+ * @klotho::fs_event {
+ *   id = "uploads"
+ * }
+ */
+func OnUpload() {}
+`,
+			wantErr: "@klotho::fs_event is not supported in go",
+		},
+		{
+			name: "no annotations",
+			source: `package main
+
+func main() {}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			f, err := NewFile("main.go", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			input := &types.InputFiles{}
+			input.Add(f)
+
+			plugins := NewGoPlugins(&config.Application{AppName: "app"}, nil)
+			err = plugins.Plugins[0].Transform(input, &types.FileDependencies{}, construct.NewConstructGraph())
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -1,13 +1,24 @@
 import * as express from 'express'
 import * as path from 'path'
+//TMPL {{if .Tracing}}
+const tracing = require('./tracing')
+//TMPL {{end}}
 
 //TMPL {{if .Expose.AppModule}}
+//TMPL {{if .ESModule}}
+//TMPL import('../{{.Expose.AppModule}}')
+//TMPL {{else}}
 require('../{{.Expose.AppModule}}')
 //TMPL {{end}}
+//TMPL {{end}}
 
 //TMPL {{if .MainModule}}
+//TMPL {{if .ESModule}}
+//TMPL import('../{{.MainModule}}')
+//TMPL {{else}}
 require('../{{.MainModule}}')
 //TMPL {{end}}
+//TMPL {{end}}
 
 const app = express()
 const port = 3001
@@ -20,6 +31,7 @@ interface RPCParams {
     functionToCall: string
     moduleName: string
     params: any[]
+    traceContext?: Record<string, string>
 }
 
 app.get('/', (req: express.Request, res: express.Response) => {
@@ -35,9 +47,13 @@ app.post('/', async (req: express.Request, res: express.Response) => {
 
         switch (mode) {
             case 'rpc':
-                const result = await require(path.join('../', params.moduleName))[
-                    params.functionToCall
-                ].apply(null, params.params)
+                const call = () =>
+                    require(path.join('../', params.moduleName))[params.functionToCall].apply(null, params.params)
+                //TMPL {{if .Tracing}}
+                //TMPL const result = await tracing.withSpan(`${params.moduleName}.${params.functionToCall}`, tracing.SpanKind.SERVER, params.traceContext, call)
+                //TMPL {{else}}
+                const result = await call()
+                //TMPL {{end}}
                 res.send(result)
                 break
         }
@@ -51,6 +67,47 @@ function parseMode(__callType: string): string | undefined {
     if (__callType === 'rpc') return 'rpc'
 }
 
-app.listen(port, () => {
-    console.log(`Klotho RPC Proxy listening on: ${port}`)
-})
+/**
+ * Scheduled tasks are started with the module and function to run in their environment.
+ * They run that function to completion instead of serving requests.
+ */
+async function runScheduledFunction(moduleName: string, functionToCall: string) {
+    try {
+        //TMPL {{if .ESModule}}
+        //TMPL const scheduledModule = await import(path.join('../', moduleName))
+        //TMPL {{else}}
+        const scheduledModule = require(path.join('../', moduleName))
+        //TMPL {{end}}
+        await scheduledModule[functionToCall]()
+        process.exit(0)
+    } catch (err) {
+        console.error(`Scheduled function ${moduleName}.${functionToCall} failed`, err)
+        process.exit(1)
+    }
+}
+
+/**
+ * Starts polling each queue which delivers its messages to this unit, passing the messages to the queue's consumer.
+ */
+function startQueuePollers() {
+    //TMPL {{- range .QueueConsumers}}
+    //TMPL require('./queue').poll('{{.UrlEnvVar}}', {{.BatchSize}}, async (body, message) => {
+    //TMPL     {{- if $.ESModule}}
+    //TMPL     const consumerModule = await import(path.join('../', '{{.ModuleName}}'))
+    //TMPL     {{- else}}
+    //TMPL     const consumerModule = require(path.join('../', '{{.ModuleName}}'))
+    //TMPL     {{- end}}
+    //TMPL     return consumerModule['{{.FunctionName}}'](body, message)
+    //TMPL })
+    //TMPL {{- end}}
+}
+
+const scheduledModule = process.env['KLOTHO_SCHEDULE_MODULE']
+if (scheduledModule) {
+    runScheduledFunction(scheduledModule, process.env['KLOTHO_SCHEDULE_FUNCTION'] ?? '')
+} else {
+    startQueuePollers()
+    app.listen(port, () => {
+        console.log(`Klotho RPC Proxy listening on: ${port}`)
+    })
+}
@@ -3,11 +3,8 @@ import { configure as serverlessExpress } from '@vendia/serverless-express'
 const uuid = require('uuid')
 const _ = require('lodash')
 const path = require('path')
-//TMPL {{if .Datadog}}
-const { datadog } = require('datadog-lambda-js')
-//TMPL {{end}}
-//TMPL {{if .Lumigo}}
-const lumigo = require('@lumigo/tracer')({ token: process.env['LUMIGO_KEY'] })
+//TMPL {{if .Tracing}}
+const tracing = require('./tracing')
 //TMPL {{end}}
 
 const inflight = new Set<Promise<any>>()
@@ -45,7 +42,31 @@ async function lambdaHandler(event, context) {
                 response = await activate_emitter(event)
                 break
             case 'rpc':
+                //TMPL {{if .Tracing}}
+                //TMPL response = await tracing.withSpan(`${__moduleName}.${__functionToCall}`, tracing.SpanKind.SERVER, event.__traceContext, () =>
+                //TMPL     handle_rpc_call(__functionToCall, __moduleName, parameters)
+                //TMPL )
+                //TMPL {{else}}
                 response = await handle_rpc_call(__functionToCall, __moduleName, parameters)
+                //TMPL {{end}}
+                break
+            case 'schedule':
+                await handle_scheduled_call(__functionToCall, __moduleName)
+                break
+            case 'workflow':
+                response = await handle_workflow_step(__functionToCall, __moduleName, event.__input)
+                break
+            case 'migration':
+                handle_migration()
+                break
+            case 'queue':
+                response = await handle_queue_records(event.Records)
+                break
+            case 'fs_event':
+                await handle_fs_events(event.Records)
+                break
+            case 'websocket':
+                response = await handle_websocket_event(event)
                 break
             case 'keepWarm':
                 break
@@ -66,6 +87,9 @@ async function lambdaHandler(event, context) {
         } catch (err) {
             console.error('error waiting for inflight promises', err)
         }
+        //TMPL {{if .Tracing}}
+        //TMPL await tracing.flush()
+        //TMPL {{end}}
     }
 }
 
@@ -79,6 +103,139 @@ async function handle_rpc_call(__functionToCall, __moduleName, parameters) {
     return payloadKey
 }
 
+async function handle_scheduled_call(__functionToCall, __moduleName) {
+    //TMPL {{if .ESModule}}
+    //TMPL const scheduledModule = await import(path.join('../', __moduleName))
+    //TMPL {{else}}
+    const scheduledModule = require(path.join('../', __moduleName))
+    //TMPL {{end}}
+    await scheduledModule[__functionToCall]()
+}
+
+/**
+ * Runs a step of a workflow, which is passed the result of the previous step and returns its result to the workflow.
+ */
+async function handle_workflow_step(__functionToCall, __moduleName, input) {
+    //TMPL {{if .ESModule}}
+    //TMPL const stepModule = await import(path.join('../', __moduleName))
+    //TMPL {{else}}
+    const stepModule = require(path.join('../', __moduleName))
+    //TMPL {{end}}
+    return await stepModule[__functionToCall](input)
+}
+
+/**
+ * Runs the schema migrations of an orm with the shell command of its migration tool, from the root of the unit.
+ * The command is set in the environment of the function which runs the migrations, never taken from the event.
+ * The lambda's filesystem is read-only outside of /tmp, so tools which cache files (e.g. npx) use it as their home.
+ */
+function handle_migration() {
+    const command = process.env.KLOTHO_MIGRATION_COMMAND
+    if (!command) {
+        throw new Error('this function does not run migrations')
+    }
+    const { execSync } = require('child_process')
+    console.info(`running migrations: ${command}`)
+    execSync(command, {
+        cwd: path.join(__dirname, '..'),
+        env: { ...process.env, HOME: '/tmp' },
+        stdio: 'inherit',
+    })
+}
+
+/**
+ * The consumers of the queues which deliver their messages to this unit, keyed by the queue's id.
+ */
+const queueConsumers: Record<string, { moduleName: string; functionName: string }> = {
+    //TMPL {{- range .QueueConsumers}}
+    //TMPL '{{.QueueId}}': { moduleName: '{{.ModuleName}}', functionName: '{{.FunctionName}}' },
+    //TMPL {{- end}}
+}
+
+/**
+ * Passes each SQS message to its queue's consumer. Messages whose consumer fails are reported back to SQS
+ * so that only they are received again.
+ */
+async function handle_queue_records(records) {
+    const batchItemFailures: { itemIdentifier: string }[] = []
+    for (const record of records) {
+        const queueId = record.messageAttributes?.klotho_queue?.stringValue
+        const consumer = queueConsumers[queueId] ?? Object.values(queueConsumers)[0]
+        try {
+            const body = JSON.parse(record.body)
+            if (body?.Event === 's3:TestEvent') continue
+            if (body?.Records?.[0]?.eventSource === 'aws:s3') {
+                // fs events which are buffered by a queue rather than delivered to the function directly
+                await handle_fs_events(body.Records)
+                continue
+            }
+            if (!consumer) throw new Error(`No consumer for queue ${queueId}`)
+            //TMPL {{if .ESModule}}
+            //TMPL const consumerModule = await import(path.join('../', consumer.moduleName))
+            //TMPL {{else}}
+            const consumerModule = require(path.join('../', consumer.moduleName))
+            //TMPL {{end}}
+            await consumerModule[consumer.functionName](body, record)
+        } catch (err) {
+            console.error(`Failed to process message ${record.messageId}`, err)
+            batchItemFailures.push({ itemIdentifier: record.messageId })
+        }
+    }
+    return { batchItemFailures }
+}
+
+/**
+ * The functions subscribed to fs events, keyed by the id of their subscription.
+ */
+const fsEventSubscribers: Record<string, { moduleName: string; functionName: string }> = {
+    //TMPL {{- range .FsEventSubscribers}}
+    //TMPL '{{.SubscriptionId}}': { moduleName: '{{.ModuleName}}', functionName: '{{.FunctionName}}' },
+    //TMPL {{- end}}
+}
+
+/**
+ * Calls the subscribed function of each S3 event record with the write or delete of the file which the record describes.
+ */
+async function handle_fs_events(records) {
+    for (const record of records) {
+        const subscriptionId = record.s3?.configurationId
+        const subscriber = fsEventSubscribers[subscriptionId]
+        if (!subscriber) {
+            console.warn(`No subscriber for fs event ${subscriptionId}`)
+            continue
+        }
+        //TMPL {{if .ESModule}}
+        //TMPL const subscriberModule = await import(path.join('../', subscriber.moduleName))
+        //TMPL {{else}}
+        const subscriberModule = require(path.join('../', subscriber.moduleName))
+        //TMPL {{end}}
+        await subscriberModule[subscriber.functionName](
+            {
+                type: record.eventName.startsWith('ObjectRemoved') ? 'delete' : 'write',
+                // object keys are url-encoded in event notifications
+                path: decodeURIComponent(record.s3.object.key.replace(/\+/g, ' ')),
+                size: record.s3.object.size,
+                time: record.eventTime,
+            },
+            record
+        )
+    }
+}
+
+/**
+ * Passes a WebSocket API event to the WebSocket servers that the unit's modules create with the websocket runtime.
+ */
+async function handle_websocket_event(event) {
+    //TMPL {{- range .WebSocketModules}}
+    //TMPL {{- if $.ESModule}}
+    //TMPL await import('../{{.}}')
+    //TMPL {{- else}}
+    //TMPL require('../{{.}}')
+    //TMPL {{- end}}
+    //TMPL {{- end}}
+    return await require('./websocket').handle(event)
+}
+
 async function activate_emitter(event) {
     const p: Promise<any>[] = []
     for (const record of event.Records) {
@@ -88,38 +245,64 @@ async function activate_emitter(event) {
         const moduleName = sns.MessageAttributes.Path.Value
         const emitterName = sns.MessageAttributes.Name.Value
         const emitter = require(path.join('../', moduleName))[emitterName]
+        //TMPL {{if .Tracing}}
+        //TMPL // the emitter sends the trace context of the event as message attributes
+        //TMPL const traceContext = {
+        //TMPL     traceparent: sns.MessageAttributes.traceparent?.Value,
+        //TMPL     tracestate: sns.MessageAttributes.tracestate?.Value,
+        //TMPL }
+        //TMPL p.push(
+        //TMPL     tracing.withSpan(`${moduleName}.${emitterName} ${sns.MessageAttributes.Event?.Value}`, tracing.SpanKind.CONSUMER, traceContext, () =>
+        //TMPL         emitter.receive(record)
+        //TMPL     )
+        //TMPL )
+        //TMPL {{else}}
         p.push(emitter.receive(record))
+        //TMPL {{end}}
     }
     await Promise.all(p)
 }
 
 function parseMode(lambdaEvent, __callType, eventPathEntry) {
     if (lambdaEvent.Records?.length > 0 && lambdaEvent.Records[0].Sns) return 'emitter'
+    if (lambdaEvent.Records?.length > 0 && lambdaEvent.Records[0].eventSource === 'aws:sqs') return 'queue'
+    if (lambdaEvent.Records?.length > 0 && lambdaEvent.Records[0].eventSource === 'aws:s3') return 'fs_event'
+    if (lambdaEvent.requestContext?.connectionId && lambdaEvent.requestContext?.eventType) return 'websocket'
     if (eventPathEntry) return 'webserver'
     if (__callType === 'rpc') return 'rpc'
+    if (__callType === 'schedule') return 'schedule'
+    if (__callType === 'workflow') return 'workflow'
+    if (__callType === 'migration') return 'migration'
     if (lambdaEvent[0] == 'warmed up') return 'keepWarm'
 }
 
+let fastifyProxy
+
 async function webserverResponse(event, context) {
     //TMPL {{if and .Expose.AppModule .Expose.ExportedAppVar}}
+    //TMPL {{if .ESModule}}
+    //TMPL const app = await (await import('../{{.Expose.AppModule}}'))['{{.Expose.ExportedAppVar}}']
+    //TMPL {{else}}
     const app = await require('../{{.Expose.AppModule}}')['{{.Expose.ExportedAppVar}}']
+    //TMPL {{end}}
+    //TMPL {{if eq .Expose.AppFramework "fastify"}}
+    if (!fastifyProxy) {
+        const awsLambdaFastify = require('@fastify/aws-lambda')
+        fastifyProxy = awsLambdaFastify(app, { binaryMimeTypes: ['application/octet-stream', 'image/*'] })
+    }
+    return await fastifyProxy(event, context)
+    //TMPL {{else}}
+    // serverless-express supports express (including nestjs) and koa apps
     return await serverlessExpress({
         app: app,
         binarySettings: { contentTypes: ['application/octet-stream', 'image/*'] },
     }).apply(null, [event, context])
+    //TMPL {{end}}
     //TMPL {{else}}
     throw new Error('execution unit not configured to receive webserver payloads')
     //TMPL {{end}}
 }
 
 let handler = lambdaHandler
-//TMPL {{if .Datadog}}
-handler = datadog(handler)
-//TMPL {{end}}
-//TMPL {{if .Lumigo}}
-if (process.env['LUMIGO_KEY']) {
-    handler = lumigo.trace(handler)
-}
-//TMPL {{end}}
 
 exports.handler = handler
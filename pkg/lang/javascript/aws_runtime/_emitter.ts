@@ -11,6 +11,9 @@ import * as crypto from 'crypto'
 // @ts-ignore
 import { addInflight } from './dispatcher'
 import { Readable } from 'stream'
+//TMPL {{if .Tracing}}
+const tracing = require('./tracing')
+//TMPL {{end}}
 
 const payloadBucketPhysicalName = process.env.KLOTHO_PROXY_RESOURCE_NAME
 const appName = '{{.AppName}}'
@@ -95,6 +98,12 @@ export class Emitter extends events.EventEmitter {
                         DataType: 'String',
                         StringValue: event,
                     },
+                    //TMPL {{- if .Tracing}}
+                    //TMPL // the dispatchers of the subscribers continue the trace from these attributes
+                    //TMPL ...Object.fromEntries(
+                    //TMPL     Object.entries(tracing.injectContext()).map(([key, value]) => [key, { DataType: 'String', StringValue: value }])
+                    //TMPL ),
+                    //TMPL {{- end}}
                 },
             })
         )
@@ -1,4 +1,7 @@
 const axios = require('axios')
+//TMPL {{- if .Tracing}}
+const tracing = require('./tracing')
+//TMPL {{- end}}
 import { ServiceDiscoveryClient, DiscoverInstancesCommand } from '@aws-sdk/client-servicediscovery'
 
 const { APP_NAME } = process.env
@@ -21,6 +24,9 @@ export async function proxyCall(
                 functionToCall,
                 moduleName,
                 params,
+                //TMPL {{- if .Tracing}}
+                //TMPL traceContext: tracing.injectContext(),
+                //TMPL {{- end}}
             },
         })
         return res.data
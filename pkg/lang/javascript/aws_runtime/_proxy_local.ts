@@ -0,0 +1,34 @@
+const axios = require('axios')
+//TMPL {{- if .Tracing}}
+const tracing = require('./tracing')
+//TMPL {{- end}}
+
+export async function proxyCall(
+    callType: string,
+    execGroupName: string,
+    moduleName: string,
+    functionToCall: string,
+    params: any[]
+) {
+    try {
+        // locally, each unit runs as a compose service which resolves by the unit's name
+        const res = await axios({
+            method: 'post',
+            url: `http://${execGroupName.toLowerCase()}:3001`,
+            data: {
+                callType,
+                execGroupName,
+                functionToCall,
+                moduleName,
+                params,
+                //TMPL {{- if .Tracing}}
+                //TMPL traceContext: tracing.injectContext(),
+                //TMPL {{- end}}
+            },
+        })
+        return res.data
+    } catch (error) {
+        console.log(error)
+        throw error
+    }
+}
@@ -0,0 +1,98 @@
+import {
+    DeleteMessageCommand,
+    Message,
+    ReceiveMessageCommand,
+    SendMessageCommand,
+    SQSClient,
+} from '@aws-sdk/client-sqs'
+import { v4 as uuid } from 'uuid'
+
+/**
+ * The message attribute which identifies the queue that a message was sent to,
+ * so that a dispatcher consuming several queues can route each message to its consumer.
+ */
+export const QUEUE_ID_ATTRIBUTE = 'klotho_queue'
+
+const client = new SQSClient({})
+
+export interface SendOptions {
+    /** The group that ordering is preserved within, for FIFO queues. Defaults to the queue's id. */
+    groupId?: string
+    /** The id used to discard duplicate messages, for FIFO queues. Defaults to a random id. */
+    deduplicationId?: string
+    /** The number of seconds to delay delivery of the message, for standard queues. */
+    delaySeconds?: number
+}
+
+export class Queue {
+    constructor(private id: string, private urlEnvVar: string) {}
+
+    /**
+     * Sends `body` (serialized as JSON) to the queue.
+     * @returns the id of the sent message
+     */
+    public async send(body: unknown, options: SendOptions = {}): Promise<string | undefined> {
+        const url = queueUrl(this.urlEnvVar)
+        const fifo = url.endsWith('.fifo')
+        const resp = await client.send(
+            new SendMessageCommand({
+                QueueUrl: url,
+                MessageBody: JSON.stringify(body),
+                MessageAttributes: {
+                    [QUEUE_ID_ATTRIBUTE]: { DataType: 'String', StringValue: this.id },
+                },
+                DelaySeconds: fifo ? undefined : options.delaySeconds,
+                MessageGroupId: fifo ? options.groupId ?? this.id : undefined,
+                MessageDeduplicationId: fifo ? options.deduplicationId ?? uuid() : undefined,
+            })
+        )
+        console.info('Sent message', { queue: this.id, messageId: resp.MessageId })
+        return resp.MessageId
+    }
+}
+
+export type Consumer = (body: any, message: Message) => unknown
+
+/**
+ * Polls the queue whose url is in `urlEnvVar` and passes each message to `consumer`, deleting it once `consumer` succeeds.
+ * Messages that fail are received again after the queue's visibility timeout, until they are moved to its dead-letter queue.
+ */
+export async function poll(urlEnvVar: string, batchSize: number, consumer: Consumer) {
+    const url = queueUrl(urlEnvVar)
+    for (;;) {
+        let messages: Message[] = []
+        try {
+            const resp = await client.send(
+                new ReceiveMessageCommand({
+                    QueueUrl: url,
+                    MaxNumberOfMessages: Math.min(Math.max(batchSize, 1), 10),
+                    MessageAttributeNames: ['All'],
+                    WaitTimeSeconds: 20,
+                })
+            )
+            messages = resp.Messages ?? []
+        } catch (err) {
+            console.error(`Failed to receive messages from ${url}`, err)
+            await new Promise((resolve) => setTimeout(resolve, 5000))
+            continue
+        }
+        for (const message of messages) {
+            try {
+                await consumer(JSON.parse(message.Body ?? 'null'), message)
+                await client.send(
+                    new DeleteMessageCommand({ QueueUrl: url, ReceiptHandle: message.ReceiptHandle })
+                )
+            } catch (err) {
+                console.error(`Failed to process message ${message.MessageId}`, err)
+            }
+        }
+    }
+}
+
+function queueUrl(urlEnvVar: string): string {
+    const url = process.env[urlEnvVar]
+    if (!url) {
+        throw new Error(`Queue url is not set: expected it in the environment variable ${urlEnvVar}`)
+    }
+    return url
+}
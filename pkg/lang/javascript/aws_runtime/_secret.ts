@@ -2,13 +2,28 @@
 'use strict'
 
 import { GetSecretValueCommand, SecretsManagerClient } from '@aws-sdk/client-secrets-manager'
+import { GetParameterCommand, SSMClient } from '@aws-sdk/client-ssm'
+import * as fs from 'fs'
 
 const client = new SecretsManagerClient({})
+const ssmClient = new SSMClient({})
 
 const secretPrefix = '{{.AppName}}'
 
+// units whose secrets are stored in SSM Parameter Store have KLOTHO_SECRETS_BACKEND set to 'ssm'
+const useSsm = process.env.KLOTHO_SECRETS_BACKEND === 'ssm'
+// units which run locally have their secrets mounted as files in KLOTHO_SECRETS_DIR and KLOTHO_SECRETS_BACKEND set to 'file'
+const useFiles = process.env.KLOTHO_SECRETS_BACKEND === 'file'
+const secretsDir = process.env.KLOTHO_SECRETS_DIR || '/run/secrets'
+
 export async function readFile(path: string): Promise<Buffer> {
     try {
+        if (useSsm) {
+            return await readParameter(path)
+        }
+        if (useFiles) {
+            return await fs.promises.readFile(`${secretsDir}/${path}`)
+        }
         const cmd = new GetSecretValueCommand({ SecretId: `${secretPrefix}-${path}` })
 
         const data = await client.send(cmd)
@@ -24,3 +39,14 @@ export async function readFile(path: string): Promise<Buffer> {
         throw new Error(`Could not read secret '${path}'`)
     }
 }
+
+async function readParameter(path: string): Promise<Buffer> {
+    const cmd = new GetParameterCommand({ Name: `/${secretPrefix}/${path}`, WithDecryption: true })
+
+    const data = await ssmClient.send(cmd)
+
+    if (data.Parameter?.Value) {
+        return Buffer.from(data.Parameter.Value, 'utf-8')
+    }
+    throw new Error(`Empty secret for ${path}`)
+}
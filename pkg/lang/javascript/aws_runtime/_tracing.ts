@@ -0,0 +1,66 @@
+import { context, propagation, trace, SpanKind, SpanStatusCode } from '@opentelemetry/api'
+import { BatchSpanProcessor, NodeTracerProvider } from '@opentelemetry/sdk-trace-node'
+import { OTLPTraceExporter } from '@opentelemetry/exporter-trace-otlp-http'
+import { Resource } from '@opentelemetry/resources'
+import { SemanticResourceAttributes } from '@opentelemetry/semantic-conventions'
+
+/**
+ * The W3C trace context (`traceparent` and `tracestate`) which is passed along with the unit's calls and events.
+ */
+export type TraceCarrier = Record<string, string>
+
+const provider = new NodeTracerProvider({
+    resource: new Resource({
+        [SemanticResourceAttributes.SERVICE_NAME]: '{{.ExecUnitName}}',
+        [SemanticResourceAttributes.SERVICE_NAMESPACE]: '{{.AppName}}',
+    }),
+})
+// Without an endpoint the unit still propagates the trace context it receives, but does not export its own spans
+if (process.env['OTEL_EXPORTER_OTLP_ENDPOINT']) {
+    provider.addSpanProcessor(new BatchSpanProcessor(new OTLPTraceExporter()))
+}
+// registering the provider also registers the W3C trace context propagator
+provider.register()
+
+const tracer = trace.getTracer('klotho')
+
+/**
+ * Returns the trace context of the active span, to be sent with a call or event.
+ */
+export function injectContext(): TraceCarrier {
+    const carrier: TraceCarrier = {}
+    propagation.inject(context.active(), carrier)
+    return carrier
+}
+
+/**
+ * Runs `fn` in a span which continues the trace of `carrier`, or the active trace if there is no carrier.
+ */
+export async function withSpan<T>(
+    name: string,
+    kind: SpanKind,
+    carrier: TraceCarrier | undefined,
+    fn: () => Promise<T>
+): Promise<T> {
+    const parent = carrier ? propagation.extract(context.active(), carrier) : context.active()
+    return await tracer.startActiveSpan(name, { kind }, parent, async (span) => {
+        try {
+            return await fn()
+        } catch (err) {
+            span.recordException(err)
+            span.setStatus({ code: SpanStatusCode.ERROR })
+            throw err
+        } finally {
+            span.end()
+        }
+    })
+}
+
+/**
+ * Exports the ended spans, which must be done before a lambda invocation returns since it is frozen afterwards.
+ */
+export async function flush() {
+    await provider.forceFlush()
+}
+
+export { SpanKind }
@@ -0,0 +1,144 @@
+import {
+    ApiGatewayManagementApiClient,
+    DeleteConnectionCommand,
+    PostToConnectionCommand,
+} from '@aws-sdk/client-apigatewaymanagementapi'
+import { EventEmitter } from 'events'
+
+const OPEN = 1
+const CLOSED = 3
+
+/**
+ * The servers created by the application, keyed by the id of the gateway that they are exposed by.
+ */
+const servers = new Map<string, Server>()
+
+const clients = new Map<string, ApiGatewayManagementApiClient>()
+
+function managementClient(endpoint: string): ApiGatewayManagementApiClient {
+    let client = clients.get(endpoint)
+    if (!client) {
+        client = new ApiGatewayManagementApiClient({ endpoint })
+        clients.set(endpoint, client)
+    }
+    return client
+}
+
+function toData(data: unknown): Uint8Array {
+    if (data instanceof Uint8Array) return data
+    if (typeof data === 'string') return Buffer.from(data)
+    return Buffer.from(JSON.stringify(data))
+}
+
+/**
+ * Calls each of the emitter's listeners for `event` in turn, waiting for the ones that return a promise.
+ */
+async function emitAsync(emitter: EventEmitter, event: string, ...args: unknown[]) {
+    for (const listener of emitter.listeners(event)) {
+        await listener.apply(emitter, args)
+    }
+}
+
+/**
+ * A client connected to a WebSocket API. Sockets only live for the event that they were created for:
+ * the server's `connection` listeners are called for every event of the connection, before its `message` or `close` listeners.
+ */
+export class Socket extends EventEmitter {
+    public readyState = OPEN
+    private pending = new Set<Promise<unknown>>()
+
+    constructor(public readonly connectionId: string, private endpoint: string) {
+        super()
+    }
+
+    public send(data: unknown, cb?: (err?: Error) => void) {
+        const p = managementClient(this.endpoint)
+            .send(new PostToConnectionCommand({ ConnectionId: this.connectionId, Data: toData(data) }))
+            .then(
+                () => cb?.(),
+                (err) => {
+                    if (!cb) throw err
+                    cb(err)
+                }
+            )
+        this.track(p)
+    }
+
+    public close() {
+        if (this.readyState === CLOSED) return
+        this.readyState = CLOSED
+        this.track(managementClient(this.endpoint).send(new DeleteConnectionCommand({ ConnectionId: this.connectionId })))
+    }
+
+    public terminate() {
+        this.close()
+    }
+
+    /**
+     * Waits for the messages sent to the client during the event.
+     */
+    public async flush() {
+        while (this.pending.size > 0) {
+            const promises = Array.from(this.pending)
+            this.pending.clear()
+            await Promise.all(promises)
+        }
+    }
+
+    private track(p: Promise<unknown>) {
+        this.pending.add(p)
+        p.finally(() => this.pending.delete(p)).catch(() => {})
+    }
+}
+
+/**
+ * Replaces a `ws` WebSocketServer. The server emits `connection` with a `Socket` for each event of the WebSocket API
+ * that the dispatcher passes to `handle`.
+ */
+export class Server extends EventEmitter {
+    constructor(public readonly id: string, private endpointEnvVar: string) {
+        super()
+        servers.set(id, this)
+    }
+
+    /**
+     * Sends `data` to the client with the given connection id, such as one saved when the client connected.
+     */
+    public async send(connectionId: string, data: unknown) {
+        const endpoint = process.env[this.endpointEnvVar]
+        if (!endpoint) throw new Error(`WebSocket endpoint of ${this.id} is not set (${this.endpointEnvVar})`)
+        await managementClient(endpoint).send(new PostToConnectionCommand({ ConnectionId: connectionId, Data: toData(data) }))
+    }
+
+    public close(cb?: () => void) {
+        cb?.()
+    }
+}
+
+export function server(id: string, endpointEnvVar: string): Server {
+    return servers.get(id) ?? new Server(id, endpointEnvVar)
+}
+
+/**
+ * Passes a WebSocket API event ($connect, $disconnect or a message) to the application's servers.
+ */
+export async function handle(event) {
+    const { connectionId, eventType, domainName, stage } = event.requestContext
+    const socket = new Socket(connectionId, `https://${domainName}/${stage}`)
+    for (const server of servers.values()) {
+        await emitAsync(server, 'connection', socket, event)
+    }
+    switch (eventType) {
+        case 'MESSAGE': {
+            const isBinary = !!event.isBase64Encoded
+            await emitAsync(socket, 'message', Buffer.from(event.body ?? '', isBinary ? 'base64' : 'utf8'), isBinary)
+            break
+        }
+        case 'DISCONNECT':
+            socket.readyState = CLOSED
+            await emitAsync(socket, 'close', 1000, Buffer.alloc(0))
+            break
+    }
+    await socket.flush()
+    return { statusCode: 200 }
+}
@@ -0,0 +1,27 @@
+import { SFNClient, StartSyncExecutionCommand } from '@aws-sdk/client-sfn'
+
+const client = new SFNClient({})
+
+/**
+ * Returns whether the workflow whose state machine arn is held by `arnEnvVar` is deployed.
+ * Workflows which are not deployed run their steps in-process, by calling the orchestrator function's body.
+ */
+export function deployed(arnEnvVar: string): boolean {
+    return !!process.env[arnEnvVar]
+}
+
+/**
+ * Runs the deployed workflow with `input` and returns the result of its last step.
+ */
+export async function start(arnEnvVar: string, input: any): Promise<any> {
+    const resp = await client.send(
+        new StartSyncExecutionCommand({
+            stateMachineArn: process.env[arnEnvVar],
+            input: JSON.stringify(input === undefined ? null : input),
+        })
+    )
+    if (resp.status !== 'SUCCEEDED') {
+        throw new Error(`Workflow execution ${resp.executionArn} ${resp.status}: ${resp.error} ${resp.cause}`)
+    }
+    return resp.output === undefined ? undefined : JSON.parse(resp.output)
+}
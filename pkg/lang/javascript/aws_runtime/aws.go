@@ -3,10 +3,10 @@ package aws_runtime
 import (
 	"bytes"
 	"embed"
-	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
@@ -14,15 +14,17 @@ import (
 	"github.com/klothoplatform/klotho/pkg/construct"
 	"github.com/klothoplatform/klotho/pkg/io"
 	"github.com/klothoplatform/klotho/pkg/lang/javascript"
+	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/aws"
 	kubernetes "github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+	"github.com/klothoplatform/klotho/pkg/provider/local"
 	"github.com/klothoplatform/klotho/pkg/runtime"
 	"github.com/klothoplatform/klotho/pkg/sanitization"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-//go:generate ./compile_template.sh proxy_fargate proxy_eks dispatcher_lambda dispatcher_fargate secret keyvalue orm emitter redis_node redis_cluster fs
+//go:generate ./compile_template.sh proxy_fargate proxy_eks proxy_local dispatcher_lambda dispatcher_fargate secret keyvalue orm emitter redis_node redis_cluster fs queue websocket workflow tracing
 
 type (
 	AwsRuntime struct {
@@ -30,16 +32,51 @@ type (
 	}
 
 	TemplateData struct {
-		ExecUnitName       string
+		ExecUnitName string
+		// AppName is the name of the application, which the physical names of its resources are derived from
+		AppName            string
 		Expose             ExposeTemplateData
 		MainModule         string
 		ProjectFilePath    string
 		PayloadsBucketName string
+		// TypeScriptOutDir is the directory that a TypeScript unit is compiled to when its image is built.
+		// It is empty for JavaScript units.
+		TypeScriptOutDir string
+		// TypeScriptConfig is the path of the unit's own tsconfig.json, which the generated build config extends.
+		TypeScriptConfig string
+		// ESModule is true when the unit's modules are ES modules, which the dispatcher must load with `import()`.
+		ESModule bool
+		// QueueConsumers are the consumer functions in this unit of the queues that deliver their messages to it.
+		QueueConsumers []QueueConsumerTemplateData
+		// WebSocketModules are the modules which create the WebSocket servers of the gateways that target this unit.
+		WebSocketModules []string
+		// FsEventSubscribers are the functions in this unit subscribed to the events of persisted fs.
+		FsEventSubscribers []FsEventSubscriberTemplateData
+		// Tracing is true when the runtime propagates the trace context of the unit's calls and events with OpenTelemetry.
+		Tracing bool
 	}
 
 	ExposeTemplateData struct {
 		ExportedAppVar string
 		AppModule      string
+		// AppFramework is the web framework of the exported app, used to select the lambda adapter
+		AppFramework string
+	}
+
+	QueueConsumerTemplateData struct {
+		QueueId string
+		// UrlEnvVar is the environment variable which holds the queue's url, used by units which poll the queue
+		UrlEnvVar    string
+		BatchSize    int
+		ModuleName   string
+		FunctionName string
+	}
+
+	FsEventSubscriberTemplateData struct {
+		// SubscriptionId is the id of the subscription, which the fs events delivered to it carry
+		SubscriptionId string
+		ModuleName     string
+		FunctionName   string
 	}
 )
 
@@ -64,6 +101,18 @@ var redisClusterRuntimeFiles embed.FS
 //go:embed emitter.js.tmpl
 var pubsubRuntimeFiles embed.FS
 
+//go:embed queue.js.tmpl
+var queueRuntimeFiles embed.FS
+
+//go:embed websocket.js.tmpl
+var webSocketRuntimeFiles embed.FS
+
+//go:embed workflow.js.tmpl
+var workflowRuntimeFiles embed.FS
+
+//go:embed tracing.js.tmpl
+var tracingRuntimeFiles embed.FS
+
 // the fs template is added here since the dispatcher needs s3. This means it technically doesn't
 // need to be added later via persist or proxy as it already exists.
 //
@@ -79,6 +128,9 @@ var proxyFargate []byte
 //go:embed proxy_eks.js.tmpl
 var proxyEks []byte
 
+//go:embed proxy_local.js.tmpl
+var proxyLocal []byte
+
 //go:embed proxy_apprunner.js.tmpl
 var proxyApprunner []byte
 
@@ -94,6 +146,18 @@ var dockerfileLambda []byte
 //go:embed Fargate_Dockerfile.tmpl
 var dockerfileFargate []byte
 
+//go:embed tsconfig.klotho.json.tmpl
+var tsconfigKlotho []byte
+
+// tsRuntimeSources are the TypeScript sources that the `.js.tmpl` runtime files are compiled from. They are emitted
+// instead of the compiled templates for units written in TypeScript.
+//
+//go:embed _*.ts
+var tsRuntimeSources embed.FS
+
+// typeScriptOutDir is the directory that TypeScript units are compiled to in their image.
+const typeScriptOutDir = ".klotho_build"
+
 var sequelizeReplaceRE = regexp.MustCompile(`new (\w+\.|\b)Sequelize\(`)
 
 func (r *AwsRuntime) TransformPersist(file *types.SourceFile, annot *types.Annotation, construct construct.Construct) error {
@@ -161,7 +225,8 @@ func (r *AwsRuntime) AddFsRuntimeFiles(unit *types.ExecutionUnit, bucketNameEnvV
 	if err != nil {
 		return err
 	}
-	err = javascript.AddRuntimeFile(unit, templateData, fmt.Sprintf("fs_%s.js.tmpl", sanitization.IdentifierSanitizer.Apply(id)), content)
+	path, content := runtimeTemplate(unit, "fs", fmt.Sprintf("fs_%s", sanitization.IdentifierSanitizer.Apply(id)), content)
+	err = javascript.AddRuntimeFile(unit, templateData, path, content)
 	return err
 }
 
@@ -191,18 +256,43 @@ func (r *AwsRuntime) AddPubsubRuntimeFiles(unit *types.ExecutionUnit) error {
 	return r.AddRuntimeFiles(unit, pubsubRuntimeFiles)
 }
 
+func (r *AwsRuntime) AddQueueRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, queueRuntimeFiles)
+}
+
+func (r *AwsRuntime) AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, webSocketRuntimeFiles)
+}
+
+func (r *AwsRuntime) AddWorkflowRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, workflowRuntimeFiles)
+}
+
 func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType string) error {
 	var proxyFile []byte
+	var proxySource string
 	unitType := r.Config.GetResourceType(unit)
 	switch proxyType {
 	case kubernetes.DEPLOYMENT_TYPE:
 		proxyFile = proxyEks
+		proxySource = "proxy_eks"
+		if r.Config.Provider == provider.KUBERNETES {
+			// Units on an existing cluster call each other through the services named after them, like local containers
+			proxyFile = proxyLocal
+			proxySource = "proxy_local"
+		}
 	case aws.Ecs:
 		proxyFile = proxyFargate
+		proxySource = "proxy_fargate"
 	case aws.AppRunner:
 		proxyFile = proxyApprunner
+		proxySource = "proxy_apprunner"
+	case local.Container:
+		proxyFile = proxyLocal
+		proxySource = "proxy_local"
 	case aws.Lambda:
 		proxyFile = proxyLambda
+		proxySource = "proxy_lambda"
 
 		// We also need to add the Fs files because exec to exec calls in aws use s3
 		unit.EnvironmentVariables.Add(types.InternalStorageVariable)
@@ -214,7 +304,8 @@ func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
 	}
 
-	err := r.AddRuntimeFile(unit, proxyType+"_proxy.js.tmpl", proxyFile)
+	path, content := runtimeTemplate(unit, proxySource, proxyType+"_proxy", proxyFile)
+	err := r.AddRuntimeFile(unit, path, content)
 	if err != nil {
 		return err
 	}
@@ -224,14 +315,17 @@ func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 
 func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
 	var DockerFile, Dispatcher []byte
+	var dispatcherSource string
 	unitType := r.Config.GetResourceType(unit)
 	switch unitType {
-	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner:
+	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner, local.Container:
 		DockerFile = dockerfileFargate
 		Dispatcher = dispatcherFargate
+		dispatcherSource = "dispatcher_fargate"
 	case aws.Lambda:
 		DockerFile = dockerfileLambda
 		Dispatcher = dispatcherLambda
+		dispatcherSource = "dispatcher_lambda"
 
 		unit.EnvironmentVariables.Add(types.InternalStorageVariable)
 		err := r.AddFsRuntimeFiles(unit, types.InternalStorageVariable.Name, "payload")
@@ -242,8 +336,12 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
 	}
 
-	templateData := TemplateData{
-		ExecUnitName: unit.Name,
+	templateData := r.templateData(unit)
+	if templateData.Tracing {
+		err := r.AddRuntimeFiles(unit, tracingRuntimeFiles)
+		if err != nil {
+			return err
+		}
 	}
 
 	exposeData, err := getExposeTemplateData(unit, constructGraph)
@@ -251,6 +349,17 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		return err
 	}
 	templateData.Expose = exposeData
+	templateData.QueueConsumers = getQueueConsumerTemplateData(unit, constructGraph)
+	templateData.WebSocketModules = getWebSocketModules(unit, constructGraph)
+	templateData.FsEventSubscribers = getFsEventSubscriberTemplateData(unit, constructGraph)
+
+	isTypeScript := javascript.IsTypeScriptUnit(unit)
+	if isTypeScript {
+		templateData.TypeScriptOutDir = typeScriptOutDir
+		if tsconfig := unit.Get("tsconfig.json"); tsconfig != nil {
+			templateData.TypeScriptConfig = tsconfig.Path()
+		}
+	}
 
 	pjsonPath := ""
 	for path, f := range unit.Files() {
@@ -264,11 +373,12 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 	templateData.ProjectFilePath = pjsonPath
 	if pjson := unit.Get(pjsonPath); pjson != nil {
 		pfile := pjson.(*javascript.PackageFile)
-		if mainRaw, ok := pfile.Content.OtherFields["main"]; ok {
-			err := json.Unmarshal(mainRaw, &templateData.MainModule)
-			if err != nil {
-				return errors.Wrap(err, "could not unmarshal 'main' from package.json")
-			}
+		templateData.ESModule = pfile.Content.IsModule() || filepath.Ext(exposeData.AppModule) == ".mjs"
+		templateData.MainModule, err = pfile.Content.Entrypoint()
+		if err != nil {
+			return err
+		}
+		if templateData.MainModule != "" {
 			files := make(map[string]io.File)
 			for _, f := range unit.Files() {
 				files[f.Path()] = f
@@ -276,6 +386,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 			f, _ := javascript.FindFileForImport(files, ".", templateData.MainModule)
 			if f != nil {
 				zap.S().Debugf("Found 'main' from package.json: %s", templateData.MainModule)
+				templateData.MainModule = moduleForTemplate(f.Path(), templateData.MainModule)
 			} else {
 				// The main file isn't for this execution unit. This can happen if the main module
 				// has a specific execution unit annotation. In that case, just skip its import
@@ -291,6 +402,20 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		return err
 	}
 
+	if isTypeScript {
+		err = javascript.AddRuntimeFile(unit, templateData, "tsconfig.klotho.json.tmpl", tsconfigKlotho)
+		if err != nil {
+			return err
+		}
+	}
+	if templateData.ESModule {
+		// The runtime files are CommonJS, so override the unit's `"type": "module"` for them
+		unit.Add(&io.RawFile{
+			FPath:   "klotho_runtime/package.json",
+			Content: []byte(`{"type": "commonjs"}` + "\n"),
+		})
+	}
+
 	if runtime.ShouldOverrideDockerfile(unit) {
 		err = javascript.AddRuntimeFile(unit, templateData, "Dockerfile.tmpl", DockerFile)
 		if err != nil {
@@ -298,10 +423,32 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		}
 	}
 
-	err = javascript.AddRuntimeFile(unit, templateData, "dispatcher.js.tmpl", Dispatcher)
+	path, content := runtimeTemplate(unit, dispatcherSource, "dispatcher", Dispatcher)
+	err = javascript.AddRuntimeFile(unit, templateData, path, content)
 	return err
 }
 
+// runtimeTemplate returns the template path and content to emit for the runtime file `name` (the name of its `_<name>.ts` source),
+// written to `klotho_runtime/<outName>`. TypeScript units get the TypeScript source with its `//TMPL ` markers stripped, so that the
+// runtime is compiled with the unit's sources. Other units, or runtime files without a TypeScript source, get the compiled `jsContent`.
+func runtimeTemplate(unit *types.ExecutionUnit, name string, outName string, jsContent []byte) (string, []byte) {
+	if javascript.IsTypeScriptUnit(unit) {
+		if tsContent, err := tsRuntimeSources.ReadFile("_" + name + ".ts"); err == nil {
+			return outName + ".ts.tmpl", bytes.ReplaceAll(tsContent, []byte("//TMPL "), nil)
+		}
+	}
+	return outName + ".js.tmpl", jsContent
+}
+
+// moduleForTemplate returns the module to `require` from the runtime for the source file at `path`. TypeScript sources
+// are referred to without their extension so that they resolve to the compiled JavaScript.
+func moduleForTemplate(path string, module string) string {
+	if javascript.IsTypeScriptFile(path) {
+		return strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	return module
+}
+
 func getExposeTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) (ExposeTemplateData, error) {
 	upstreamConstructs := constructGraph.GetUpstreamConstructs(unit)
 	var upstreamGateways []*types.Gateway
@@ -324,24 +471,109 @@ func getExposeTemplateData(unit *types.ExecutionUnit, constructGraph *construct.
 
 	exposeData := ExposeTemplateData{}
 	if sourceGateway != nil {
-		exposeData.AppModule = sourceGateway.DefinedIn
+		exposeData.AppModule = moduleForTemplate(sourceGateway.DefinedIn, sourceGateway.DefinedIn)
 		exposeData.ExportedAppVar = sourceGateway.ExportVarName
+		exposeData.AppFramework = sourceGateway.Framework
 	}
 	return exposeData, nil
 }
 
+// getQueueConsumerTemplateData returns the consumers within `unit` of its upstream queues, sorted by queue id.
+func getQueueConsumerTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []QueueConsumerTemplateData {
+	var consumers []QueueConsumerTemplateData
+	for _, c := range constructGraph.GetUpstreamConstructs(unit) {
+		queue, ok := c.(*types.Queue)
+		if !ok || queue.Consumer == nil || queue.Consumer.ExecUnitName != unit.Name {
+			continue
+		}
+		consumers = append(consumers, QueueConsumerTemplateData{
+			QueueId:      queue.Name,
+			UrlEnvVar:    types.QueueUrlEnvVarName(queue.Name),
+			BatchSize:    queue.BatchSize,
+			ModuleName:   moduleForTemplate(queue.Consumer.ModuleName, queue.Consumer.ModuleName),
+			FunctionName: queue.Consumer.FunctionName,
+		})
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].QueueId < consumers[j].QueueId })
+	return consumers
+}
+
+// getFsEventSubscriberTemplateData returns the functions within `unit` subscribed to its upstream fs events, sorted by subscription id.
+func getFsEventSubscriberTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []FsEventSubscriberTemplateData {
+	var subscribers []FsEventSubscriberTemplateData
+	for _, c := range constructGraph.GetUpstreamConstructs(unit) {
+		event, ok := c.(*types.FsEvent)
+		if !ok {
+			continue
+		}
+		for _, sub := range event.Subscriptions {
+			if sub.ExecUnitName != unit.Name {
+				continue
+			}
+			subscribers = append(subscribers, FsEventSubscriberTemplateData{
+				SubscriptionId: sub.Id(),
+				ModuleName:     moduleForTemplate(sub.ModuleName, sub.ModuleName),
+				FunctionName:   sub.FunctionName,
+			})
+		}
+	}
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].SubscriptionId < subscribers[j].SubscriptionId })
+	return subscribers
+}
+
+// getWebSocketModules returns the modules within `unit` which create the WebSocket servers of its upstream gateways, sorted.
+func getWebSocketModules(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []string {
+	var modules []string
+	for _, c := range constructGraph.GetUpstreamConstructs(unit) {
+		gw, ok := c.(*types.WebSocketGateway)
+		if !ok || gw.ExecUnitName != unit.Name {
+			continue
+		}
+		modules = append(modules, moduleForTemplate(gw.HandledInFile, gw.HandledInFile))
+	}
+	sort.Strings(modules)
+	return modules
+}
+
 func (r *AwsRuntime) AddRuntimeFiles(unit *types.ExecutionUnit, files embed.FS) error {
-	templateData := TemplateData{
-		ExecUnitName: unit.Name,
+	templateData := r.templateData(unit)
+	if !javascript.IsTypeScriptUnit(unit) {
+		return javascript.AddRuntimeFiles(unit, files, templateData)
 	}
-	err := javascript.AddRuntimeFiles(unit, files, templateData)
-	return err
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		content, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return err
+		}
+		path := entry.Name()
+		if name := strings.TrimSuffix(path, ".js.tmpl"); name != path {
+			path, content = runtimeTemplate(unit, name, name, content)
+		}
+		err = javascript.AddRuntimeFile(unit, templateData, path, content)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *AwsRuntime) AddRuntimeFile(unit *types.ExecutionUnit, path string, content []byte) error {
+	err := javascript.AddRuntimeFile(unit, r.templateData(unit), path, content)
+	return err
+}
+
+// templateData returns the template data which is common to all of the runtime files of the unit
+func (r *AwsRuntime) templateData(unit *types.ExecutionUnit) TemplateData {
 	templateData := TemplateData{
 		ExecUnitName: unit.Name,
 	}
-	err := javascript.AddRuntimeFile(unit, templateData, path, content)
-	return err
+	if r.Config != nil {
+		templateData.AppName = r.Config.QualifiedAppName()
+		templateData.Tracing = r.Config.Observability != nil && r.Config.Observability.Tracing
+	}
+	return templateData
 }
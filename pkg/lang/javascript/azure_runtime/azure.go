@@ -0,0 +1,275 @@
+package azure_runtime
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/lang/javascript"
+	"github.com/klothoplatform/klotho/pkg/provider/azure"
+	"github.com/klothoplatform/klotho/pkg/runtime"
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	AzureRuntime struct {
+		Config *config.Application
+	}
+
+	TemplateData struct {
+		ExecUnitName    string
+		AppName         string
+		Expose          ExposeTemplateData
+		MainModule      string
+		ProjectFilePath string
+		// ESModule is true when the unit's modules are ES modules, which the dispatcher must load with `import()`.
+		ESModule bool
+	}
+
+	ExposeTemplateData struct {
+		ExportedAppVar string
+		AppModule      string
+		// AppFramework is the web framework of the exported app, used to serve it from the Azure Functions handler
+		AppFramework string
+	}
+
+	FsTemplateData struct {
+		BucketNameEnvVar string
+	}
+)
+
+//go:embed keyvalue.js.tmpl
+var kvRuntimeFiles embed.FS
+
+//go:embed fs.js.tmpl
+var fsRuntimeFile []byte
+
+//go:embed secret.js.tmpl
+var secretRuntimeFiles embed.FS
+
+//go:embed orm.js.tmpl
+var ormRuntimeFiles embed.FS
+
+//go:embed redis_node.js.tmpl
+var redisNodeRuntimeFiles embed.FS
+
+//go:embed emitter.js.tmpl
+var pubsubRuntimeFiles embed.FS
+
+//go:embed package.json
+var ExecRuntimeFiles embed.FS
+
+//go:embed dispatcher_container_app.js.tmpl
+var dispatcherContainerApp []byte
+
+//go:embed dispatcher_function.js.tmpl
+var dispatcherFunction []byte
+
+//go:embed host.json
+var functionHostJson []byte
+
+//go:embed Dockerfile.tmpl
+var dockerfileContainerApp []byte
+
+func (r *AzureRuntime) TransformPersist(file *types.SourceFile, annot *types.Annotation, construct construct.Construct) error {
+	importModule := ""
+	switch construct.(type) {
+	case *types.Fs:
+		importModule = sanitization.IdentifierSanitizer.Apply("fs_" + annot.Capability.ID)
+	case *types.Kv:
+		importModule = "keyvalue"
+	case *types.Secrets:
+		importModule = "secret"
+	case *types.Orm:
+		importModule = "orm"
+	case *types.RedisNode:
+		importModule = "redis_node"
+	default:
+		return fmt.Errorf("could not get runtime import file name for persist type: %v", construct.Id().Name)
+	}
+
+	return javascript.EnsureRuntimeImportFile(importModule, importModule, file)
+}
+
+func (r *AzureRuntime) AddKvRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, kvRuntimeFiles)
+}
+
+func (r *AzureRuntime) AddFsRuntimeFiles(unit *types.ExecutionUnit, bucketNameEnvVar string, id string) error {
+	templateData := FsTemplateData{BucketNameEnvVar: bucketNameEnvVar}
+	path := fmt.Sprintf("fs_%s.js.tmpl", sanitization.IdentifierSanitizer.Apply(id))
+	return javascript.AddRuntimeFile(unit, templateData, path, fsRuntimeFile)
+}
+
+func (r *AzureRuntime) AddSecretRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, secretRuntimeFiles)
+}
+
+func (r *AzureRuntime) AddOrmRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, ormRuntimeFiles)
+}
+
+func (r *AzureRuntime) AddRedisNodeRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, redisNodeRuntimeFiles)
+}
+
+func (r *AzureRuntime) AddRedisClusterRuntimeFiles(unit *types.ExecutionUnit) error {
+	return errors.Errorf("redis_cluster persist is not supported by the azure provider (execution unit %s)", unit.Name)
+}
+
+func (r *AzureRuntime) AddPubsubRuntimeFiles(unit *types.ExecutionUnit) error {
+	return r.AddRuntimeFiles(unit, pubsubRuntimeFiles)
+}
+
+func (r *AzureRuntime) AddQueueRuntimeFiles(unit *types.ExecutionUnit) error {
+	return errors.Errorf("queues are not supported by the azure provider (execution unit %s)", unit.Name)
+}
+
+func (r *AzureRuntime) AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error {
+	return errors.Errorf("websocket gateways are not supported by the azure provider (execution unit %s)", unit.Name)
+}
+
+func (r *AzureRuntime) AddWorkflowRuntimeFiles(unit *types.ExecutionUnit) error {
+	return errors.Errorf("workflows are not supported by the azure provider (execution unit %s)", unit.Name)
+}
+
+func (r *AzureRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType string) error {
+	return errors.Errorf("calls between execution units are not supported by the azure provider (execution unit %s)", unit.Name)
+}
+
+func (r *AzureRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
+	if javascript.IsTypeScriptUnit(unit) {
+		return errors.Errorf("TypeScript execution units are not supported by the azure provider (execution unit %s)", unit.Name)
+	}
+
+	var DockerFile, Dispatcher []byte
+	unitType := r.Config.GetResourceType(unit)
+	switch unitType {
+	case azure.ContainerApps, "":
+		// units without a configured type run on Container Apps, the default of the azure provider
+		DockerFile = dockerfileContainerApp
+		Dispatcher = dispatcherContainerApp
+	case azure.Functions:
+		// function apps are deployed from their sources, which the functions host loads the dispatcher from as
+		// the package's main module
+		Dispatcher = dispatcherFunction
+	default:
+		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
+	}
+
+	templateData := TemplateData{
+		ExecUnitName: unit.Name,
+		AppName:      r.Config.QualifiedAppName(),
+	}
+
+	exposeData, err := getExposeTemplateData(unit, constructGraph)
+	if err != nil {
+		return err
+	}
+	templateData.Expose = exposeData
+
+	pjsonPath := ""
+	for path, f := range unit.Files() {
+		if filepath.Base(f.Path()) == "package.json" {
+			pjsonPath = path
+		}
+	}
+	if pjsonPath == "" {
+		return errors.Errorf("No `package.json` found for execution unit %s", unit.Name)
+	}
+	templateData.ProjectFilePath = pjsonPath
+	if pjson := unit.Get(pjsonPath); pjson != nil {
+		pfile := pjson.(*javascript.PackageFile)
+		templateData.ESModule = pfile.Content.IsModule() || filepath.Ext(exposeData.AppModule) == ".mjs"
+		templateData.MainModule, err = pfile.Content.Entrypoint()
+		if err != nil {
+			return err
+		}
+		if templateData.MainModule != "" {
+			files := make(map[string]io.File)
+			for _, f := range unit.Files() {
+				files[f.Path()] = f
+			}
+			f, _ := javascript.FindFileForImport(files, ".", templateData.MainModule)
+			if f != nil {
+				zap.S().Debugf("Found 'main' from package.json: %s", templateData.MainModule)
+			} else {
+				// The main file isn't for this execution unit, so skip its import by zeroing out the field.
+				zap.S().Debugf("Skipping 'main' from package.json: %s due to not in unit %s", templateData.MainModule, unit.Name)
+				templateData.MainModule = ""
+			}
+		}
+		if unitType == azure.Functions {
+			if pfile.Content.OtherFields == nil {
+				pfile.Content.OtherFields = make(map[string]json.RawMessage)
+			}
+			pfile.Content.OtherFields["main"] = json.RawMessage(`"klotho_runtime/dispatcher.js"`)
+			// the functions host routes requests under `/api` unless the prefix is removed
+			unit.Add(&io.RawFile{
+				FPath:   "host.json",
+				Content: functionHostJson,
+			})
+		}
+	}
+
+	err = javascript.AddRuntimeFiles(unit, ExecRuntimeFiles, templateData)
+	if err != nil {
+		return err
+	}
+	if templateData.ESModule {
+		// The runtime files are CommonJS, so override the unit's `"type": "module"` for them
+		unit.Add(&io.RawFile{
+			FPath:   "klotho_runtime/package.json",
+			Content: []byte(`{"type": "commonjs"}` + "\n"),
+		})
+	}
+
+	if DockerFile != nil && runtime.ShouldOverrideDockerfile(unit) {
+		err = javascript.AddRuntimeFile(unit, templateData, "Dockerfile.tmpl", DockerFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return javascript.AddRuntimeFile(unit, templateData, "dispatcher.js.tmpl", Dispatcher)
+}
+
+func getExposeTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) (ExposeTemplateData, error) {
+	var sourceGateway *types.Gateway
+	for _, c := range constructGraph.GetUpstreamConstructs(unit) {
+		gw, ok := c.(*types.Gateway)
+		if !ok {
+			continue
+		}
+		if sourceGateway != nil && (sourceGateway.DefinedIn != gw.DefinedIn || sourceGateway.ExportVarName != gw.ExportVarName) {
+			return ExposeTemplateData{},
+				errors.Errorf("multiple gateways cannot target different web applications in the same execution unit: [%s -> %s],[%s -> %s]",
+					gw.Name, unit.Name,
+					sourceGateway.Name, unit.Name)
+		}
+		sourceGateway = gw
+	}
+
+	exposeData := ExposeTemplateData{}
+	if sourceGateway != nil {
+		exposeData.AppModule = sourceGateway.DefinedIn
+		exposeData.ExportedAppVar = sourceGateway.ExportVarName
+		exposeData.AppFramework = sourceGateway.Framework
+	}
+	return exposeData, nil
+}
+
+func (r *AzureRuntime) AddRuntimeFiles(unit *types.ExecutionUnit, files embed.FS) error {
+	templateData := TemplateData{
+		ExecUnitName: unit.Name,
+		AppName:      r.Config.QualifiedAppName(),
+	}
+	return javascript.AddRuntimeFiles(unit, files, templateData)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/logging"
 	"github.com/klothoplatform/klotho/pkg/query"
 	"github.com/pkg/errors"
 	sitter "github.com/smacker/go-tree-sitter"
@@ -14,6 +15,7 @@ type gatewaySpec struct {
 	FilePath   string
 	AppVarName string
 	gatewayId  string
+	framework  string
 }
 
 type gatewayRouteDefinition struct {
@@ -54,6 +56,61 @@ func findListener(cap *types.Annotation) exposeListenResult {
 	return exposeListenResult{}
 }
 
+// handleExposeListeners calls actOnAnnotation for the listen call of each public expose annotation in the file and
+// reparses the file whenever the file content is updated as a result.
+func handleExposeListeners(f *types.SourceFile, actOnAnnotation func(listen *exposeListenResult, fileContent string, annot *types.Annotation) (bool, string, error)) error {
+	fileContent := string(f.Program())
+	for _, annot := range f.Annotations() {
+		log := zap.L().With(logging.AnnotationField(annot), logging.FileField(f))
+		cap := annot.Capability
+		if annot.IsDetached() || cap.Name != annotation.ExposeCapability {
+			continue
+		}
+
+		if cap.ID == "" {
+			return types.NewCompilerError(f, annot, errors.New("'id' is required"))
+		}
+
+		target, ok := cap.Directives.String("target")
+		if !ok {
+			target = "private"
+		}
+		if target != "public" {
+			return types.NewCompilerError(f, annot, errors.New("expose capability must specify target = \"public\""))
+		}
+
+		listen := findListener(annot)
+		if listen.Expression == nil {
+			log.Debug("No listener found")
+			continue
+		}
+
+		actedOn, newFileContent, err := actOnAnnotation(&listen, fileContent, annot)
+		if err != nil {
+			return err
+		}
+		if actedOn {
+			fileContent = newFileContent
+			if err := f.Reparse([]byte(fileContent)); err != nil {
+				return errors.Wrap(err, "error reparsing after substitutions")
+			}
+		}
+	}
+	return nil
+}
+
+// listenStatement returns the statement containing the listen call so that the whole statement
+// (including any `await`) can be commented out.
+func listenStatement(listen *exposeListenResult) *sitter.Node {
+	if listen.Expression.Type() == "expression_statement" {
+		return listen.Expression
+	}
+	if stmt := query.FirstAncestorOfType(listen.Expression, "expression_statement"); stmt != nil {
+		return stmt
+	}
+	return listen.Expression
+}
+
 func handleGatewayRoutes(info *execUnitExposeInfo, constructGraph *construct.ConstructGraph, log *zap.Logger) {
 	for spec, routes := range info.RoutesByGateway {
 		gw := types.NewGateway(spec.gatewayId)
@@ -62,6 +119,7 @@ func handleGatewayRoutes(info *execUnitExposeInfo, constructGraph *construct.Con
 		} else {
 			gw.DefinedIn = spec.FilePath
 			gw.ExportVarName = spec.AppVarName
+			gw.Framework = spec.framework
 			constructGraph.AddConstruct(gw)
 		}
 		if len(routes) == 0 && len(gw.Routes) == 0 {
@@ -11,6 +11,7 @@ import (
 func Test_expose_findApp(t *testing.T) {
 	tests := []struct {
 		name      string
+		path      string
 		source    string
 		expect    string
 		expectErr bool
@@ -117,11 +118,20 @@ async function setup() {
 			`,
 			expectErr: true,
 		},
+		{
+			name: "typescript listen",
+			path: "app.ts",
+			source: `import express, { Express } from 'express';
+const app: Express = express();
+// @klotho::expose
+app.listen(3000 as number);`,
+			expect: "app",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
-			f, err := NewFile("", strings.NewReader(tt.source))
+			f, err := NewFile(tt.path, strings.NewReader(tt.source))
 			if !assert.NoError(err) {
 				return
 			}
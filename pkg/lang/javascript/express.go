@@ -172,8 +172,8 @@ func (h *ExpressHandler) actOnAnnotation(f *types.SourceFile, listen *exposeList
 		annotationId: annot.Capability.ID,
 	})
 	newfileContent += fmt.Sprintf(`
-	exports.%s = %s
-	`, strings.TrimPrefix(appName, "exports."), appName)
+	%s
+	`, ExportStatement(f, strings.TrimPrefix(appName, "exports."), appName))
 	actedOn = true
 	return
 }
@@ -0,0 +1,524 @@
+package javascript
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/query"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+const fastifyFramework = "fastify"
+
+type FastifyHandler struct {
+	log    *zap.Logger
+	Config *config.Application
+	apps   []fastifyServer
+}
+
+type fastifyServer struct {
+	f            *types.SourceFile
+	varName      string
+	appName      string
+	annotationId string
+}
+
+// fastifyScope is a Fastify instance as seen from a single encapsulation context: either the
+// root instance created by `fastify()`, or the instance passed as the first argument to a plugin function.
+type fastifyScope struct {
+	f       *types.SourceFile
+	node    *sitter.Node
+	varName string
+	prefix  string
+}
+
+func (p FastifyHandler) Name() string { return "Fastify" }
+
+func (p FastifyHandler) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		err := p.transformSingle(constructGraph, unit)
+		errs.Append(err)
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *FastifyHandler) transformSingle(constructGraph *construct.ConstructGraph, unit *types.ExecutionUnit) error {
+	execUnitInfo := execUnitExposeInfo{Unit: unit, RoutesByGateway: make(map[gatewaySpec][]gatewayRouteDefinition)}
+	p.apps = nil
+	p.log = zap.L().With(zap.String("unit", unit.Name))
+
+	var errs multierr.Error
+	for _, f := range unit.Files() {
+		js, ok := Language.ID.CastFile(f)
+		if !ok {
+			continue
+		}
+		err := handleExposeListeners(js, func(listen *exposeListenResult, fileContent string, annot *types.Annotation) (bool, string, error) {
+			return p.actOnAnnotation(js, listen, fileContent, p.Config.GetResourceType(unit), annot)
+		})
+		errs.Append(err)
+	}
+
+	for _, app := range p.apps {
+		gwSpec := gatewaySpec{
+			FilePath:   app.f.Path(),
+			AppVarName: app.appName,
+			gatewayId:  app.annotationId,
+			framework:  fastifyFramework,
+		}
+		scope := fastifyScope{f: app.f, node: app.f.Tree().RootNode(), varName: app.varName}
+		routes := p.findScopeRoutes(unit, scope, map[string]struct{}{})
+		p.log.Sugar().Infof("Found %d route(s) on Fastify server '%s'", len(routes), app.varName)
+		execUnitInfo.RoutesByGateway[gwSpec] = append(execUnitInfo.RoutesByGateway[gwSpec], routes...)
+	}
+
+	handleGatewayRoutes(&execUnitInfo, constructGraph, p.log)
+	return errs.ErrOrNil()
+}
+
+func (p *FastifyHandler) actOnAnnotation(f *types.SourceFile, listen *exposeListenResult, fileContent string, unitType string, annot *types.Annotation) (actedOn bool, newFileContent string, err error) {
+	newFileContent = fileContent
+	varName, isTopLevel := findFastify(f)
+	if varName == "" || listen.Identifier.Content() != varName {
+		return
+	}
+
+	appName := varName
+	if !isTopLevel {
+		appName, err = findApp(*listen)
+		if err != nil {
+			return false, fileContent, types.NewCompilerError(f, annot, errors.New("Couldn't find expose app creation"))
+		}
+	}
+
+	//TODO: look into moving this runtime-specific logic elsewhere
+	if unitType == "lambda" {
+		newFileContent = CommentNodes(fileContent, listenStatement(listen).Content())
+		annot.Detach() // prevents this annotation from being rebound to the next non-comment node in the file on reparse
+	}
+
+	p.apps = append(p.apps, fastifyServer{
+		f:            f,
+		varName:      varName,
+		appName:      appName,
+		annotationId: annot.Capability.ID,
+	})
+	newFileContent += fmt.Sprintf(`
+	%s
+	`, ExportStatement(f, strings.TrimPrefix(appName, "exports."), appName))
+	actedOn = true
+	return
+}
+
+// findFastify returns the name of the variable that holds the root Fastify instance in the file and whether
+// that variable is declared at the top level of the module.
+func findFastify(f *types.SourceFile) (varName string, isTopLevel bool) {
+	nextMatch := DoQuery(f.Tree().RootNode(), fastifyInstance)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+
+		v, factory, exports := match["var"], match["fastify"], match["exports"]
+		if exports != nil && !query.NodeContentEquals(exports, "exports") {
+			continue
+		}
+		if !isFastifyFactory(f, factory) {
+			continue
+		}
+
+		declaration := query.FirstAncestorOfType(v, "lexical_declaration")
+		if declaration == nil {
+			declaration = query.FirstAncestorOfType(v, "variable_declaration")
+		}
+		if declaration == nil {
+			declaration = query.FirstAncestorOfType(v, "expression_statement")
+		}
+		return v.Content(), declaration != nil && declaration.Parent() != nil && declaration.Parent().Type() == "program"
+	}
+	return "", false
+}
+
+// isFastifyFactory reports whether the function being invoked is the Fastify factory, as imported in any of these forms:
+//
+//	const fastify = require('fastify'); fastify()
+//	require('fastify')()
+//	const fastify_1 = require('fastify'); fastify_1.default() // or .fastify()
+func isFastifyFactory(f *types.SourceFile, fn *sitter.Node) bool {
+	root := f.Tree().RootNode()
+	switch fn.Type() {
+	case "identifier":
+		return FindImportForVar(root, fn.Content()).Source == fastifyFramework
+	case "member_expression":
+		obj := fn.ChildByFieldName("object")
+		return obj.Type() == "identifier" && FindImportForVar(root, obj.Content()).Source == fastifyFramework
+	case "call_expression":
+		return requiredModule(fn) == fastifyFramework
+	}
+	return false
+}
+
+// requiredModule returns the module name if the node is a `require('<module>')` call, or an empty string otherwise.
+func requiredModule(n *sitter.Node) string {
+	if n == nil || n.Type() != "call_expression" || !query.NodeContentEquals(n.ChildByFieldName("function"), "require") {
+		return ""
+	}
+	args := n.ChildByFieldName("arguments")
+	if args == nil || args.NamedChildCount() != 1 || args.NamedChild(0).Type() != "string" {
+		return ""
+	}
+	return StringLiteralContent(args.NamedChild(0))
+}
+
+func (p *FastifyHandler) findScopeRoutes(unit *types.ExecutionUnit, scope fastifyScope, visited map[string]struct{}) (routes []gatewayRouteDefinition) {
+	scopeKey := fmt.Sprintf("%s:%d-%d", scope.f.Path(), scope.node.StartByte(), scope.node.EndByte())
+	if _, ok := visited[scopeKey]; ok {
+		return
+	}
+	visited[scopeKey] = struct{}{}
+	log := p.log.With(logging.FileField(scope.f))
+
+	nextMatch := DoQuery(scope.node, methodInvocation)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		obj, method, call := match["var.name"], match["method.name"], match["full"]
+		if !query.NodeContentEquals(obj, scope.varName) || !isInFastifyScope(call, scope) {
+			continue
+		}
+		args := call.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 {
+			continue
+		}
+
+		switch methodName := method.Content(); methodName {
+		case "route":
+			options := args.NamedChild(0)
+			if options.Type() != "object" {
+				continue
+			}
+			url := objectStringProperty(options, "url")
+			if url == "" {
+				url = objectStringProperty(options, "path")
+			}
+			for _, verb := range fastifyRouteMethods(options) {
+				routes = append(routes, p.newRoute(scope, verb, url, unit.Name)...)
+			}
+
+		case "register":
+			prefix := ""
+			if args.NamedChildCount() > 1 && args.NamedChild(1).Type() == "object" {
+				prefix = objectStringProperty(args.NamedChild(1), "prefix")
+			}
+			childPrefix := path.Join("/", scope.prefix, prefix)
+			if childPrefix == "/" {
+				childPrefix = ""
+			}
+
+			pluginFile, plugin, isExternal := resolveFastifyPlugin(unit, scope.f, args.NamedChild(0))
+			if isExternal {
+				log.Sugar().Debugf("Skipping non-relative Fastify plugin '%s'", args.NamedChild(0).Content())
+				continue
+			}
+			instanceName := functionFirstParamName(plugin)
+			if plugin == nil || instanceName == "" {
+				if childPrefix == "" {
+					log.Sugar().Warnf("Could not resolve Fastify plugin '%s'", args.NamedChild(0).Content())
+					continue
+				}
+				log.Sugar().Infof("Adding in catchall route for prefix '%s' for unresolved plugin '%s'", childPrefix, args.NamedChild(0).Content())
+				routes = append(routes, catchallRoutes(childPrefix, unit.Name, scope.f.Path())...)
+				continue
+			}
+			child := fastifyScope{f: pluginFile, node: plugin, varName: instanceName, prefix: childPrefix}
+			childRoutes := p.findScopeRoutes(unit, child, visited)
+			if len(childRoutes) == 0 {
+				log.Sugar().Warnf("No routes found for Fastify plugin '%s'", args.NamedChild(0).Content())
+			}
+			routes = append(routes, childRoutes...)
+
+		default:
+			verb := methodName
+			if verb == "all" {
+				verb = "any"
+			}
+			if _, supported := types.Verbs[types.Verb(strings.ToUpper(verb))]; !supported {
+				continue
+			}
+			routePath := args.NamedChild(0)
+			if routePath.Type() != "string" {
+				continue
+			}
+			routes = append(routes, p.newRoute(scope, verb, StringLiteralContent(routePath), unit.Name)...)
+		}
+	}
+	return
+}
+
+func (p *FastifyHandler) newRoute(scope fastifyScope, verb string, routePath string, unitName string) []gatewayRouteDefinition {
+	fullPath := sanitizeExpressPath(path.Join("/", scope.prefix, routePath))
+	var routes []gatewayRouteDefinition
+	newDef := func(routePath string) gatewayRouteDefinition {
+		return gatewayRouteDefinition{
+			Route: types.Route{
+				Verb:          types.Verb(verb),
+				Path:          routePath,
+				ExecUnitName:  unitName,
+				HandledInFile: scope.f.Path(),
+			},
+			DefinedInPath: scope.f.Path(),
+		}
+	}
+	if fullPath == "/:rest*" {
+		routes = append(routes, newDef("/"))
+	}
+	p.log.Sugar().Debugf("Found route function %s %s for '%s'", verb, fullPath, scope.varName)
+	return append(routes, newDef(fullPath))
+}
+
+// isInFastifyScope reports whether the node refers to the scope's instance rather than to a shadowing
+// parameter of a nested plugin function with the same name.
+func isInFastifyScope(n *sitter.Node, scope fastifyScope) bool {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if p.StartByte() == scope.node.StartByte() && p.EndByte() == scope.node.EndByte() {
+			return true
+		}
+		if functionFirstParamName(p) == scope.varName {
+			return false
+		}
+	}
+	return true
+}
+
+// functionFirstParamName returns the name of the first parameter of a function-like node, or an empty string
+// if the node is not a function or has no simple first parameter.
+func functionFirstParamName(fn *sitter.Node) string {
+	if fn == nil {
+		return ""
+	}
+	switch fn.Type() {
+	case "function", "function_declaration", "arrow_function", "method_definition":
+	default:
+		return ""
+	}
+	if param := fn.ChildByFieldName("parameter"); param != nil {
+		return param.Content() // arrow function without parentheses
+	}
+	params := fn.ChildByFieldName("parameters")
+	if params == nil || params.NamedChildCount() == 0 {
+		return ""
+	}
+	first := params.NamedChild(0)
+	if first.Type() != "identifier" {
+		return ""
+	}
+	return first.Content()
+}
+
+// resolveFastifyPlugin finds the function node for the plugin passed to `register`. isExternal is true when the plugin
+// is imported from a package rather than a project-local module.
+func resolveFastifyPlugin(unit *types.ExecutionUnit, f *types.SourceFile, plugin *sitter.Node) (pluginFile *types.SourceFile, fn *sitter.Node, isExternal bool) {
+	root := f.Tree().RootNode()
+	switch plugin.Type() {
+	case "function", "arrow_function":
+		return f, plugin, false
+
+	case "identifier":
+		if local := findLocalFunction(root, plugin.Content()); local != nil {
+			return f, local, false
+		}
+		imp := FindImportForVar(root, plugin.Content())
+		if imp == (Import{}) {
+			return nil, nil, false
+		}
+		exportName := ""
+		if imp.Type == ImportTypeNamed || imp.Type == ImportTypeField {
+			exportName = imp.Name
+		}
+		return resolveImportedFunction(unit, f, imp.Source, exportName)
+
+	case "member_expression":
+		obj, prop := plugin.ChildByFieldName("object"), plugin.ChildByFieldName("property")
+		source := requiredModule(obj)
+		if source == "" && obj.Type() == "identifier" {
+			source = FindImportForVar(root, obj.Content()).Source
+		}
+		if source == "" {
+			return nil, nil, false
+		}
+		return resolveImportedFunction(unit, f, source, prop.Content())
+
+	case "call_expression":
+		if source := requiredModule(plugin); source != "" {
+			return resolveImportedFunction(unit, f, source, "")
+		}
+	}
+	return nil, nil, false
+}
+
+func resolveImportedFunction(unit *types.ExecutionUnit, f *types.SourceFile, source string, exportName string) (*types.SourceFile, *sitter.Node, bool) {
+	if !strings.HasPrefix(source, ".") {
+		return nil, nil, true
+	}
+	files := make(map[string]io.File)
+	for _, uf := range unit.Files() {
+		files[uf.Path()] = uf
+	}
+	imported, err := FindFileForImport(files, f.Path(), source)
+	if err != nil || imported == nil {
+		return nil, nil, false
+	}
+	importedJs, ok := Language.ID.CastFile(imported)
+	if !ok {
+		return nil, nil, false
+	}
+	return importedJs, findExportedFunction(importedJs.Tree().RootNode(), exportName), false
+}
+
+// findLocalFunction returns the function declared with the given name in the module, if any.
+func findLocalFunction(root *sitter.Node, name string) *sitter.Node {
+	nextMatch := DoQuery(root, fastifyPlugin)
+	for {
+		match, found := nextMatch()
+		if !found {
+			return nil
+		}
+		if query.NodeContentEquals(match["name"], name) {
+			return match["function"]
+		}
+	}
+}
+
+// findExportedFunction returns the function exported under exportName, or the default export (`module.exports`,
+// `exports.default` or `export default`) if exportName is empty.
+func findExportedFunction(root *sitter.Node, exportName string) *sitter.Node {
+	nextMatch := DoQuery(root, fastifyExport)
+	var last *sitter.Node
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		export, value, name := match["export"], match["value"], match["name"]
+
+		var exportedAs string
+		switch {
+		case export.Type() == "member_expression":
+			switch exported := export.Content(); {
+			case exported == "module.exports" || exported == "exports.default" || exported == "module.exports.default":
+				exportedAs = ""
+			case strings.HasPrefix(exported, "module.exports."):
+				exportedAs = strings.TrimPrefix(exported, "module.exports.")
+			case strings.HasPrefix(exported, "exports."):
+				exportedAs = strings.TrimPrefix(exported, "exports.")
+			default:
+				continue
+			}
+		case query.NodeContentStartWith(export, "export default"):
+			exportedAs = ""
+		case name != nil:
+			exportedAs = name.Content()
+		default:
+			continue
+		}
+		if exportedAs != exportName {
+			continue
+		}
+
+		if value.Type() == "identifier" {
+			value = findLocalFunction(root, value.Content())
+		}
+		if value != nil {
+			last = value
+		}
+	}
+	return last
+}
+
+func fastifyRouteMethods(options *sitter.Node) (verbs []string) {
+	method := objectProperty(options, "method")
+	if method == nil {
+		return nil
+	}
+	var methods []*sitter.Node
+	if method.Type() == "array" {
+		for i := 0; i < int(method.NamedChildCount()); i++ {
+			methods = append(methods, method.NamedChild(i))
+		}
+	} else {
+		methods = append(methods, method)
+	}
+	for _, m := range methods {
+		if m.Type() != "string" {
+			continue
+		}
+		verb := strings.ToLower(StringLiteralContent(m))
+		if _, supported := types.Verbs[types.Verb(strings.ToUpper(verb))]; supported {
+			verbs = append(verbs, verb)
+		}
+	}
+	return
+}
+
+// objectProperty returns the value of the property with the given key in an object literal.
+func objectProperty(object *sitter.Node, key string) *sitter.Node {
+	for i := 0; i < int(object.NamedChildCount()); i++ {
+		pair := object.NamedChild(i)
+		if pair.Type() != "pair" {
+			continue
+		}
+		k := pair.ChildByFieldName("key")
+		if k.Content() == key || (k.Type() == "string" && StringLiteralContent(k) == key) {
+			return pair.ChildByFieldName("value")
+		}
+	}
+	return nil
+}
+
+// objectStringProperty returns the string value of the property with the given key in an object literal, or an empty
+// string if the property is missing or not a string literal.
+func objectStringProperty(object *sitter.Node, key string) string {
+	value := objectProperty(object, key)
+	if value == nil || value.Type() != "string" {
+		return ""
+	}
+	return StringLiteralContent(value)
+}
+
+// catchallRoutes returns routes for the prefix and everything under it, used when the routes
+// defined beneath a prefix cannot be determined statically.
+func catchallRoutes(prefix string, unitName string, definedIn string) []gatewayRouteDefinition {
+	return []gatewayRouteDefinition{
+		{
+			Route: types.Route{
+				Path:          prefix,
+				ExecUnitName:  unitName,
+				Verb:          types.VerbAny,
+				HandledInFile: definedIn,
+			},
+			DefinedInPath: definedIn,
+		},
+		{
+			Route: types.Route{
+				Path:          path.Join(prefix, "/:rest*"),
+				ExecUnitName:  unitName,
+				Verb:          types.VerbAny,
+				HandledInFile: definedIn,
+			},
+			DefinedInPath: definedIn,
+		},
+	}
+}
@@ -0,0 +1,228 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func Test_findFastify(t *testing.T) {
+	tests := []struct {
+		name           string
+		source         string
+		expect         string
+		expectTopLevel bool
+	}{
+		{
+			name: "imported factory",
+			source: `const fastify = require('fastify');
+const app = fastify({ logger: true });`,
+			expect:         "app",
+			expectTopLevel: true,
+		},
+		{
+			name:           "inline require",
+			source:         `const fastify = require('fastify')({ logger: true });`,
+			expect:         "fastify",
+			expectTopLevel: true,
+		},
+		{
+			name: "typescript default import",
+			source: `const fastify_1 = require("fastify");
+const server = (0, fastify_1.default)();
+exports.server = fastify_1.default();`,
+			expect:         "exports.server",
+			expectTopLevel: true,
+		},
+		{
+			name: "created in function",
+			source: `const fastify = require('fastify');
+function build() {
+	const app = fastify();
+	return app;
+}`,
+			expect:         "app",
+			expectTopLevel: false,
+		},
+		{
+			name: "not fastify",
+			source: `const express = require('express');
+const app = express();`,
+			expect: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			f, err := NewFile("test.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			varName, isTopLevel := findFastify(f)
+			assert.Equal(tt.expect, varName)
+			if tt.expect != "" {
+				assert.Equal(tt.expectTopLevel, isTopLevel)
+			}
+		})
+	}
+}
+
+func Test_fastifyHandler_findScopeRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		files   map[string]string
+		varName string
+		expect  []types.Route
+	}{
+		{
+			name: "verbs",
+			source: `app.get('/users', async () => {});
+app.post('/users', async () => {});
+app.all('/any', async () => {});
+app.addHook('onRequest', async () => {});`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/users", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/users", Verb: "post", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/any", Verb: "any", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "route options",
+			source: `app.route({ method: 'GET', url: '/users/:id', handler: async () => {} });
+app.route({ method: ['PUT', 'DELETE'], url: '/users/:id', handler: async () => {} });`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/users/:id", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/users/:id", Verb: "put", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/users/:id", Verb: "delete", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "wildcard",
+			source: `app.get('/*', async () => {});
+app.get('/static/*', async () => {});`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/:rest*", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/static/:rest*", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "local plugin with prefix",
+			source: `async function routes(fastify, opts) {
+	fastify.get('/', async () => {});
+	fastify.get('/:id', async () => {});
+}
+app.register(routes, { prefix: '/v1' });`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/v1", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/v1/:id", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "plugin parameter shadows instance name",
+			source: `const fastify = require('fastify')();
+fastify.get('/health', async () => {});
+fastify.register(async (fastify) => {
+	fastify.get('/users', async () => {});
+}, { prefix: '/api' });`,
+			varName: "fastify",
+			expect: []types.Route{
+				{Path: "/health", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/api/users", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "nested plugins",
+			source: `app.register(async (api) => {
+	api.register(async (users) => {
+		users.get('/:id', async () => {});
+	}, { prefix: '/users' });
+}, { prefix: '/api' });`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/api/users/:id", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name:   "required plugin",
+			source: `app.register(require('./users'), { prefix: '/users' });`,
+			files: map[string]string{
+				"users.js": `module.exports = async function (fastify, opts) {
+	fastify.get('/', async () => {});
+}`,
+			},
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/users", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "users.js"},
+			},
+		},
+		{
+			name: "imported named plugin",
+			source: `const routes_1 = require('./routes');
+app.register(routes_1.orders, { prefix: '/orders' });`,
+			files: map[string]string{
+				"routes.js": `async function orders(f) {
+	f.post('/', async () => {});
+}
+exports.orders = orders;`,
+			},
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/orders", Verb: "post", ExecUnitName: "testUnit", HandledInFile: "routes.js"},
+			},
+		},
+		{
+			name: "unresolved plugin with prefix",
+			source: `const routes = require('./missing');
+app.register(routes, { prefix: '/v2' });`,
+			varName: "app",
+			expect: []types.Route{
+				{Path: "/v2", Verb: "ANY", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/v2/:rest*", Verb: "ANY", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "package plugins are ignored",
+			source: `const cors = require('@fastify/cors');
+app.register(cors, { prefix: '/v2' });`,
+			varName: "app",
+			expect:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			unit := &types.ExecutionUnit{Name: "testUnit"}
+			f, err := NewFile("test.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit.Add(f)
+			for path, content := range tt.files {
+				other, err := NewFile(path, strings.NewReader(content))
+				if !assert.NoError(err) {
+					return
+				}
+				unit.Add(other)
+			}
+
+			h := &FastifyHandler{log: zap.L()}
+			scope := fastifyScope{f: f, node: f.Tree().RootNode(), varName: tt.varName}
+			got := h.findScopeRoutes(unit, scope, map[string]struct{}{})
+
+			var gotRoutes []types.Route
+			for _, r := range got {
+				gotRoutes = append(gotRoutes, r.Route)
+			}
+			assert.Equal(tt.expect, gotRoutes)
+		})
+	}
+}
@@ -50,12 +50,19 @@ func localImports(input map[string]io.File, f *types.SourceFile) (types.Imported
 			continue
 		}
 
-		uses := ImportUsageQuery(f.Tree().RootNode(), imp.ImportedAs())
-
 		useNames := make(types.References)
-		for _, use := range uses {
-			name := use.Content()
-			useNames.Add(name)
+		switch {
+		case imp.Kind == ImportKindES && (imp.Type == ImportTypeNamed || imp.Type == ImportTypeDefault):
+			// ES named and default imports (and re-exports) reference the imported name directly
+			useNames.Add(imp.Name)
+		case imp.ReExport:
+			useNames.Add(imp.Name)
+		default:
+			uses := ImportUsageQuery(f.Tree().RootNode(), imp.ImportedAs())
+			for _, use := range uses {
+				name := use.Content()
+				useNames.Add(name)
+			}
 		}
 		refs, ok := imports[importFile.Path()]
 		if !ok {
@@ -72,6 +72,9 @@ type (
 		// Alias is the name with which this import is referred to in its enclosing Scope (i.e. module or local)
 		Alias string
 
+		// ReExport is true for ES re-exports (e.g. export { name } from "source"), which do not bind a local name
+		ReExport bool
+
 		Scope ImportScope
 		Type  ImportType
 		Kind  ImportKind
@@ -83,6 +86,9 @@ type FileImports map[string][]Import
 
 // ImportedAs returns the name of the import as it will be used locally (either the exported name or local alias).
 func (p *Import) ImportedAs() string {
+	if p.ReExport {
+		return ""
+	}
 	if p.Alias != "" {
 		return p.Alias
 	}
@@ -239,8 +245,12 @@ func parseImport(match query.MatchNodes) (Import, bool) {
 	var parsedImport Import
 	if (looksLikeCJSImport && invokesRequire) || isNestedMemberExprContainingRequire {
 		parsedImport = parseCjsImport(match)
-	} else if esImportStatement != nil {
+	} else if esImportStatement != nil && !isTypeOnlyImport(match) {
 		parsedImport = parseESImport(match)
+	} else if match["es.reexportStatement"] != nil && !isTypeOnlyImport(match) {
+		parsedImport = parseESReExport(match)
+	} else if match["es.dynamicImport"] != nil {
+		parsedImport = parseESDynamicImport(match)
 	}
 
 	if parsedImport.Type == "" {
@@ -250,6 +260,32 @@ func parseImport(match query.MatchNodes) (Import, bool) {
 	return parsedImport, true
 }
 
+// isTypeOnlyImport returns whether the ES import match is a TypeScript type-only import
+// (e.g. import type { X } from 'module' or import { type X } from 'module'), which is erased during compilation.
+func isTypeOnlyImport(match query.MatchNodes) bool {
+	if hasTypeModifier(match["es.importStatement"]) || hasTypeModifier(match["es.reexportStatement"]) {
+		return true
+	}
+	if export := match["export"]; export != nil {
+		return hasTypeModifier(export.Parent())
+	}
+	return false
+}
+
+// hasTypeModifier returns whether the node has an anonymous 'type' child (only present in TypeScript sources).
+func hasTypeModifier(node *sitter.Node) bool {
+	if node == nil {
+		return false
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if !child.IsNamed() && child.Type() == "type" {
+			return true
+		}
+	}
+	return false
+}
+
 func parseESImport(match query.MatchNodes) Import {
 
 	esImportStatement := match["es.importStatement"]
@@ -304,6 +340,76 @@ func parseESImport(match query.MatchNodes) Import {
 	return esImport
 }
 
+// parseESReExport parses an `export ... from <"source">` statement, which imports names from the source module
+// and exports them without binding them locally.
+func parseESReExport(match query.MatchNodes) Import {
+	statement := match["es.reexportStatement"]
+	source := match["source"]
+	export := match["export"]
+
+	reExport := Import{
+		Kind:       ImportKindES,
+		ImportNode: statement,
+		SourceNode: source,
+		Source:     StringLiteralContent(source),
+		Scope:      ImportScopeModule,
+		ReExport:   true,
+	}
+	if export == nil {
+		reExport.Name = "*"
+		reExport.Type = ImportTypeNamespace
+		return reExport
+	}
+
+	reExport.Name = StringLiteralContent(export)
+	if alias := match["alias"]; alias != nil {
+		reExport.Alias = StringLiteralContent(alias)
+	}
+	if reExport.Name == "default" {
+		reExport.Type = ImportTypeDefault
+	} else {
+		reExport.Type = ImportTypeNamed
+	}
+	return reExport
+}
+
+// parseESDynamicImport parses an `import(<"source">)` expression. The import is treated as a namespace import
+// when its result is assigned to a variable (e.g. const x = await import("source")), or a side effect import otherwise.
+func parseESDynamicImport(match query.MatchNodes) Import {
+	call := match["es.dynamicImport"]
+	source := match["source"]
+
+	dynamicImport := Import{
+		Kind:       ImportKindES,
+		ImportNode: call,
+		SourceNode: source,
+		Source:     StringLiteralContent(source),
+		Type:       ImportTypeSideEffect,
+	}
+
+	node := call
+	if node.Parent() != nil && node.Parent().Type() == "await_expression" {
+		node = node.Parent()
+	}
+	if parent := node.Parent(); parent != nil && parent.Type() == "variable_declarator" {
+		if name := parent.ChildByFieldName("name"); name != nil && name.Type() == "identifier" {
+			dynamicImport.Name = "*"
+			dynamicImport.Alias = name.Content()
+			dynamicImport.Type = ImportTypeNamespace
+		}
+		node = parent.Parent()
+	}
+	if node.Type() == "call_expression" || node.Type() == "await_expression" {
+		node = query.FirstAncestorOfType(node, "expression_statement")
+	}
+	if node != nil && node.Parent() != nil && node.Parent().Type() == "program" {
+		dynamicImport.Scope = ImportScopeModule
+	} else {
+		dynamicImport.Scope = ImportScopeLocal
+	}
+	return dynamicImport
+}
+
 func parseCjsImport(match query.MatchNodes) Import {
 	cjsDeclarativeRequireStatement := match["cjs.requireStatement"]
 	cjsSideEffectRequireStatement := match["cjs.sideEffect.requireStatement"]
@@ -494,9 +600,11 @@ func ResolveImportSourceType(source string, projectFilePaths []string, enableAbs
 	} else if enableAbsolute {
 		filePaths := make(map[string]struct{})
 
-		filePaths[source] = struct{}{}             // X
-		filePaths[source+".js"] = struct{}{}       // X.js
-		filePaths[source+"/index.js"] = struct{}{} // X/index.js
+		filePaths[source] = struct{}{} // X
+		for _, ext := range moduleExtensions {
+			filePaths[source+ext] = struct{}{}          // X.js
+			filePaths[source+"/index"+ext] = struct{}{} // X/index.js
+		}
 
 		for _, filePath := range projectFilePaths {
 			if _, ok := filePaths[filePath]; ok {
@@ -7,7 +7,6 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
-	"github.com/klothoplatform/klotho/pkg/compiler/types"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -640,13 +639,133 @@ const alias = require('module2').prop.field1.field2;
 				},
 			},
 		},
+		{
+			name: "ES: re-exports",
+			sourceFile: file{
+				Path: "my-module.js",
+				Content: `
+export * from "./module1";
+export { a, b as c } from "./module2";
+			`},
+			want: FileImports{
+				"./module1": []Import{
+					{
+						Source: "./module1",
+						Name:   "*",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamespace,
+						Kind:   ImportKindES,
+					},
+				},
+				"./module2": []Import{
+					{
+						Source: "./module2",
+						Name:   "a",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamed,
+						Kind:   ImportKindES,
+					},
+					{
+						Source: "./module2",
+						Name:   "b",
+						Alias:  "c",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamed,
+						Kind:   ImportKindES,
+					},
+				},
+			},
+		},
+		{
+			name: "ES: dynamic imports",
+			sourceFile: file{
+				Path: "my-module.js",
+				Content: `
+const mod = await import("./module1");
+async function load() {
+	await import("./module2");
+}
+			`},
+			want: FileImports{
+				"./module1": []Import{
+					{
+						Source: "./module1",
+						Name:   "*",
+						Alias:  "mod",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamespace,
+						Kind:   ImportKindES,
+					},
+				},
+				"./module2": []Import{
+					{
+						Source: "./module2",
+						Scope:  ImportScopeLocal,
+						Type:   ImportTypeSideEffect,
+						Kind:   ImportKindES,
+					},
+				},
+			},
+		},
+		{
+			name: "TS: type-only imports are ignored",
+			sourceFile: file{
+				Path: "my-module.ts",
+				Content: `
+import type { Request } from "express";
+import { type Response, Router } from "express";
+import * as path from "path";
+			`},
+			want: FileImports{
+				"express": []Import{
+					{
+						Source: "express",
+						Name:   "Router",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamed,
+						Kind:   ImportKindES,
+					},
+				},
+				"path": []Import{
+					{
+						Source: "path",
+						Name:   "*",
+						Alias:  "path",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeNamespace,
+						Kind:   ImportKindES,
+					},
+				},
+			},
+		},
+		{
+			name: "TSX: default import",
+			sourceFile: file{
+				Path: "my-module.tsx",
+				Content: `
+import React from "react";
+const el = <div>hello</div>;
+			`},
+			want: FileImports{
+				"react": []Import{
+					{
+						Source: "react",
+						Name:   "default",
+						Alias:  "React",
+						Scope:  ImportScopeModule,
+						Type:   ImportTypeDefault,
+						Kind:   ImportKindES,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
 
-			f, err := types.NewSourceFile(tt.sourceFile.Path, strings.NewReader(tt.sourceFile.Content), Language)
+			f, err := NewFile(tt.sourceFile.Path, strings.NewReader(tt.sourceFile.Content))
 			if !assert.NoError(err) {
 				return
 			}
@@ -1,6 +1,7 @@
 package javascript
 
 import (
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
@@ -12,15 +13,27 @@ import (
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
+// moduleExtensions are the extensions that are tried, in order, when resolving an extensionless module path to a file.
+// TypeScript extensions are included so that imports between TypeScript sources resolve the same way `tsc` does.
+var moduleExtensions = []string{".js", ".ts", ".tsx"}
+
 func GetFileForModule(constructGraph *construct.ConstructGraph, moduleName string) *types.SourceFile {
 	moduleName = strings.TrimPrefix(moduleName, "./") // Convert relative import to file name
 
 	original, f := types.GetExecUnitForPath(moduleName, constructGraph)
-	if original == nil && !strings.HasSuffix(moduleName, ".js") {
-		original, f = types.GetExecUnitForPath(moduleName+".js", constructGraph)
+	for _, ext := range moduleExtensions {
+		if original != nil {
+			break
+		}
+		if !strings.HasSuffix(moduleName, ext) {
+			original, f = types.GetExecUnitForPath(moduleName+ext, constructGraph)
+		}
 	}
-	if original == nil {
-		original, f = types.GetExecUnitForPath(path.Join(moduleName, "index.js"), constructGraph)
+	for _, ext := range moduleExtensions {
+		if original != nil {
+			break
+		}
+		original, f = types.GetExecUnitForPath(path.Join(moduleName, "index"+ext), constructGraph)
 	}
 	if original == nil {
 		return nil
@@ -39,23 +52,99 @@ func FindFileForImport(files map[string]io.File, importingFilePath string, modul
 		return nil, err
 	}
 	if path == "." {
-		if f, ok := files["index.js"]; ok {
-			return f, nil
+		for _, ext := range moduleExtensions {
+			if f, ok := files["index"+ext]; ok {
+				return f, nil
+			}
 		}
 	}
 	if f, ok := files[path]; ok {
 		return f, nil
 	}
-	if f, ok := files[path+".js"]; ok {
-		return f, nil
+	// TypeScript sources may import other sources with the extension of the compiled output (e.g. './users.js' for './users.ts')
+	path = strings.TrimSuffix(path, ".js")
+	for _, ext := range moduleExtensions {
+		if f, ok := files[path+ext]; ok {
+			return f, nil
+		}
 	}
-	if f, ok := files[path+"/index.js"]; ok {
-		return f, nil
+	for _, ext := range moduleExtensions {
+		if f, ok := files[path+"/index"+ext]; ok {
+			return f, nil
+		}
 	}
 	return nil, nil
 }
 
+// ESExport is an export from an ES module.
+type ESExport struct {
+	// Name is the name that the export is exported as ("default" for the default export).
+	Name string
+	// Local is the exported node: the local identifier or, for `export default <expression>`, the expression.
+	Local *sitter.Node
+	// Declarator is the variable declarator, function or class declaration when the export declares its value.
+	Declarator *sitter.Node
+	// ExportNode is the export statement.
+	ExportNode *sitter.Node
+}
+
+// FindESExports returns the ES module exports of the module at node n, excluding re-exports from other modules
+// (see FindImportsAtNode for those).
+func FindESExports(n *sitter.Node) []ESExport {
+	var exports []ESExport
+	nextMatch := DoQuery(n, modulesESExport)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		exportNode, name, local, value := match["export"], match["name"], match["local"], match["value"]
+		if exportNode.ChildByFieldName("source") != nil || hasTypeModifier(exportNode) {
+			continue
+		}
+
+		export := ESExport{ExportNode: exportNode, Declarator: match["declarator"]}
+		switch {
+		case value != nil:
+			export.Name = "default"
+			export.Local = value
+		case local != nil:
+			export.Local = local
+			export.Name = local.Content()
+			if name != nil {
+				export.Name = StringLiteralContent(name)
+			}
+		default:
+			export.Local = name
+			export.Name = name.Content()
+			if isDefaultExport(exportNode) {
+				export.Name = "default"
+			}
+		}
+		exports = append(exports, export)
+	}
+	return exports
+}
+
+func isDefaultExport(exportNode *sitter.Node) bool {
+	for i := 0; i < int(exportNode.ChildCount()); i++ {
+		child := exportNode.Child(i)
+		if !child.IsNamed() && child.Type() == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+// FindDefaultExport returns the node exported as the module's default export, either through `module.exports = <identifier>`
+// or `export default`.
 func FindDefaultExport(n *sitter.Node) *sitter.Node {
+	for _, export := range FindESExports(n) {
+		if export.Name == "default" {
+			return export.Local
+		}
+	}
+
 	nextMatch := DoQuery(n, modulesDefault)
 	var last *sitter.Node
 	for {
@@ -80,6 +169,12 @@ func FindDefaultExport(n *sitter.Node) *sitter.Node {
 
 // FindExportForVar returns the local variable that is exported as 'varName' (to handle cases where they don't match).
 func FindExportForVar(n *sitter.Node, varName string) *sitter.Node {
+	for _, export := range FindESExports(n) {
+		if export.Name == varName {
+			return export.Local
+		}
+	}
+
 	nextMatch := DoQuery(n, modulesExport)
 	var last *sitter.Node
 	for {
@@ -106,6 +201,43 @@ func FindExportForVar(n *sitter.Node, varName string) *sitter.Node {
 	return last
 }
 
+// IsESModule returns whether the file is an ES module: either a `.mjs` file or a JavaScript file that uses
+// `import`/`export` declarations. TypeScript files are compiled to CommonJS, so they are never ES modules.
+func IsESModule(f *types.SourceFile) bool {
+	switch filepath.Ext(f.Path()) {
+	case ".mjs":
+		return true
+	case ".cjs":
+		return false
+	}
+	if IsTypeScriptFile(f.Path()) {
+		return false
+	}
+	root := f.Tree().RootNode()
+	for _, imp := range FindImportsAtNode(root).AsSlice() {
+		// dynamic `import()` is also allowed in CommonJS modules
+		if imp.Kind == ImportKindES && imp.ImportNode.Type() != "call_expression" {
+			return true
+		}
+	}
+	return len(FindESExports(root)) > 0
+}
+
+// ExportStatement returns a statement that exports the local variable `varName` as `exportName` from the file,
+// in the module syntax of the file. It returns an empty string if an ES module already exports `exportName`.
+func ExportStatement(f *types.SourceFile, exportName string, varName string) string {
+	if !IsESModule(f) {
+		return fmt.Sprintf("exports.%s = %s", exportName, varName)
+	}
+	if FindExportForVar(f.Tree().RootNode(), exportName) != nil {
+		return ""
+	}
+	if exportName == varName {
+		return fmt.Sprintf("export { %s }", varName)
+	}
+	return fmt.Sprintf("export { %s as %s }", varName, exportName)
+}
+
 // FileToLocalModule removes all the extraneous parts of the file path while still being resolvable by node's require.
 // Also appends a leading `./` if the path is not already relative to convert from file paths in an execution unit
 // which do not have the leading `./` (required for relative imports by node).
@@ -127,7 +259,9 @@ func FileToLocalModule(path string) (module string) {
 // FileToModule removes all the extraneous parts of the file path while still being resolvable by node's require.
 func FileToModule(path string) (module string) {
 	module = path
-	module = strings.TrimSuffix(module, ".js")
+	for _, ext := range moduleExtensions {
+		module = strings.TrimSuffix(module, ext)
+	}
 	module = strings.TrimSuffix(module, "index")
 	module = strings.TrimSuffix(module, "/")
 	return
@@ -32,6 +32,16 @@ func TestFindDefaultExport(t *testing.T) {
 			source: `module.exports = a;`,
 			want:   "a",
 		},
+		{
+			name:   "ES default export",
+			source: `export default a;`,
+			want:   "a",
+		},
+		{
+			name:   "ES default export in clause",
+			source: `export { a as default };`,
+			want:   "a",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -76,6 +86,24 @@ func TestFindExportForVar(t *testing.T) {
 			varName: "a",
 			want:    "exports.a",
 		},
+		{
+			name:    "ES export declaration",
+			source:  `export const a = require('express').Router();`,
+			varName: "a",
+			want:    "a",
+		},
+		{
+			name:    "ES export clause",
+			source:  `const b = 1; export { b as a };`,
+			varName: "a",
+			want:    "b",
+		},
+		{
+			name:    "ES re-export is not a local export",
+			source:  `export { a } from './a';`,
+			varName: "a",
+			want:    "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -240,6 +268,21 @@ func TestFindFileForImport(t *testing.T) {
 			args:     args{files: []string{"index.js", "b.js"}, importedFrom: "./a/index.js", module: ".."},
 			wantPath: "index.js",
 		},
+		{
+			name:     "typescript no extension match",
+			args:     args{files: []string{"a.ts", "b.ts"}, importedFrom: "./index.ts", module: "./a"},
+			wantPath: "a.ts",
+		},
+		{
+			name:     "typescript compiled extension match",
+			args:     args{files: []string{"a.ts", "b.ts"}, importedFrom: "./index.ts", module: "./a.js"},
+			wantPath: "a.ts",
+		},
+		{
+			name:     "typescript folder match",
+			args:     args{files: []string{"a/index.tsx", "b.ts"}, importedFrom: "./index.ts", module: "./a"},
+			wantPath: "a/index.tsx",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -303,6 +346,16 @@ func TestFileToModule(t *testing.T) {
 			path:       "../index.js",
 			wantModule: "..",
 		},
+		{
+			name:       "typescript file",
+			path:       "a/mod.ts",
+			wantModule: "./a/mod",
+		},
+		{
+			name:       "tsx folder index",
+			path:       "a/index.tsx",
+			wantModule: "./a",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -313,3 +366,66 @@ func TestFileToModule(t *testing.T) {
 		})
 	}
 }
+
+func TestExportStatement(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		source     string
+		exportName string
+		varName    string
+		want       string
+	}{
+		{
+			name:       "commonjs",
+			path:       "app.js",
+			source:     `const app = express();`,
+			exportName: "app",
+			varName:    "app",
+			want:       "exports.app = app",
+		},
+		{
+			name:       "es module",
+			path:       "app.js",
+			source:     "import express from 'express';\nconst app = express();",
+			exportName: "app",
+			varName:    "app",
+			want:       "export { app }",
+		},
+		{
+			name:       "es module renamed",
+			path:       "app.mjs",
+			source:     `const appPromise = setup();`,
+			exportName: "server",
+			varName:    "appPromise",
+			want:       "export { appPromise as server }",
+		},
+		{
+			name:       "es module already exported",
+			path:       "app.js",
+			source:     `export const app = express();`,
+			exportName: "app",
+			varName:    "app",
+			want:       "",
+		},
+		{
+			name:       "typescript is compiled to commonjs",
+			path:       "app.ts",
+			source:     "import express from 'express';\nconst app = express();",
+			exportName: "app",
+			varName:    "app",
+			want:       "exports.app = app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := NewFile(tt.path, strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, ExportStatement(f, tt.exportName, tt.varName))
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package javascript
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/query"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+const koaFramework = "koa"
+
+// koaRouterModules are the packages that provide a Koa router with the `@koa/router` API.
+var koaRouterModules = map[string]struct{}{
+	"@koa/router": {},
+	"koa-router":  {},
+}
+
+type KoaHandler struct {
+	log    *zap.Logger
+	Config *config.Application
+	apps   []koaServer
+}
+
+type koaServer struct {
+	f            *types.SourceFile
+	varName      string
+	appName      string
+	annotationId string
+}
+
+// koaInstanceResult is a `new X(...)` assignment found in a file.
+type koaInstanceResult struct {
+	varName    string
+	isTopLevel bool
+	arguments  *sitter.Node
+}
+
+func (p KoaHandler) Name() string { return "Koa" }
+
+func (p KoaHandler) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		err := p.transformSingle(constructGraph, unit)
+		errs.Append(err)
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *KoaHandler) transformSingle(constructGraph *construct.ConstructGraph, unit *types.ExecutionUnit) error {
+	execUnitInfo := execUnitExposeInfo{Unit: unit, RoutesByGateway: make(map[gatewaySpec][]gatewayRouteDefinition)}
+	p.apps = nil
+	p.log = zap.L().With(zap.String("unit", unit.Name))
+
+	var errs multierr.Error
+	for _, f := range unit.Files() {
+		js, ok := Language.ID.CastFile(f)
+		if !ok {
+			continue
+		}
+		err := handleExposeListeners(js, func(listen *exposeListenResult, fileContent string, annot *types.Annotation) (bool, string, error) {
+			return p.actOnAnnotation(js, listen, fileContent, p.Config.GetResourceType(unit), annot)
+		})
+		errs.Append(err)
+	}
+
+	for _, app := range p.apps {
+		gwSpec := gatewaySpec{
+			FilePath:   app.f.Path(),
+			AppVarName: app.appName,
+			gatewayId:  app.annotationId,
+			framework:  koaFramework,
+		}
+		routes := p.findMountedRoutes(unit, app.f, app.varName, "", map[string]struct{}{})
+		p.log.Sugar().Infof("Found %d route(s) on Koa server '%s'", len(routes), app.varName)
+		execUnitInfo.RoutesByGateway[gwSpec] = append(execUnitInfo.RoutesByGateway[gwSpec], routes...)
+	}
+
+	handleGatewayRoutes(&execUnitInfo, constructGraph, p.log)
+	return errs.ErrOrNil()
+}
+
+func (p *KoaHandler) actOnAnnotation(f *types.SourceFile, listen *exposeListenResult, fileContent string, unitType string, annot *types.Annotation) (actedOn bool, newFileContent string, err error) {
+	newFileContent = fileContent
+	app, found := findKoaApp(f)
+	if !found || listen.Identifier.Content() != app.varName {
+		return
+	}
+
+	appName := app.varName
+	if !app.isTopLevel {
+		appName, err = findApp(*listen)
+		if err != nil {
+			return false, fileContent, types.NewCompilerError(f, annot, errors.New("Couldn't find expose app creation"))
+		}
+	}
+
+	//TODO: look into moving this runtime-specific logic elsewhere
+	if unitType == "lambda" {
+		newFileContent = CommentNodes(fileContent, listenStatement(listen).Content())
+		annot.Detach() // prevents this annotation from being rebound to the next non-comment node in the file on reparse
+	}
+
+	p.apps = append(p.apps, koaServer{
+		f:            f,
+		varName:      app.varName,
+		appName:      appName,
+		annotationId: annot.Capability.ID,
+	})
+	newFileContent += fmt.Sprintf(`
+	%s
+	`, ExportStatement(f, strings.TrimPrefix(appName, "exports."), appName))
+	actedOn = true
+	return
+}
+
+func findKoaInstances(f *types.SourceFile, isKoaType func(module string) bool) (instances []koaInstanceResult) {
+	root := f.Tree().RootNode()
+	nextMatch := DoQuery(root, koaInstance)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		v, ctor, newExpr, exports := match["var"], match["ctor"], match["new"], match["exports"]
+		if exports != nil && !query.NodeContentEquals(exports, "exports") {
+			continue
+		}
+
+		// new Koa(), new koa_1.default()
+		ctorImport := ctor
+		if ctor.Type() == "member_expression" {
+			ctorImport = ctor.ChildByFieldName("object")
+		}
+		if ctorImport.Type() != "identifier" || !isKoaType(FindImportForVar(root, ctorImport.Content()).Source) {
+			continue
+		}
+
+		statement := query.FirstAncestorOfType(v, "lexical_declaration")
+		if statement == nil {
+			statement = query.FirstAncestorOfType(v, "variable_declaration")
+		}
+		if statement == nil {
+			statement = query.FirstAncestorOfType(v, "expression_statement")
+		}
+		instances = append(instances, koaInstanceResult{
+			varName:    v.Content(),
+			isTopLevel: statement != nil && statement.Parent() != nil && statement.Parent().Type() == "program",
+			arguments:  newExpr.ChildByFieldName("arguments"),
+		})
+	}
+	return
+}
+
+func findKoaApp(f *types.SourceFile) (koaInstanceResult, bool) {
+	apps := findKoaInstances(f, func(module string) bool { return module == koaFramework })
+	if len(apps) == 0 {
+		return koaInstanceResult{}, false
+	}
+	return apps[0], true
+}
+
+func findKoaRouters(f *types.SourceFile) map[string]koaInstanceResult {
+	routers := make(map[string]koaInstanceResult)
+	for _, r := range findKoaInstances(f, func(module string) bool {
+		_, ok := koaRouterModules[module]
+		return ok
+	}) {
+		routers[r.varName] = r
+	}
+	return routers
+}
+
+// findMountedRoutes returns the routes for each router mounted on ownerVar via `ownerVar.use([path], router.routes())`.
+func (p *KoaHandler) findMountedRoutes(unit *types.ExecutionUnit, f *types.SourceFile, ownerVar string, prefix string, visited map[string]struct{}) (routes []gatewayRouteDefinition) {
+	log := p.log.With(logging.FileField(f))
+
+	nextMatch := DoQuery(f.Tree().RootNode(), methodInvocation)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		obj, method, call := match["var.name"], match["method.name"], match["full"]
+		if !query.NodeContentEquals(obj, ownerVar) || !query.NodeContentEquals(method, "use") {
+			continue
+		}
+		args := call.ChildByFieldName("arguments")
+		if args == nil {
+			continue
+		}
+
+		mountPath := ""
+		for i := 0; i < int(args.NamedChildCount()); i++ {
+			arg := args.NamedChild(i)
+			if i == 0 && arg.Type() == "string" {
+				mountPath = StringLiteralContent(arg)
+				continue
+			}
+			router := koaMiddlewareRouter(arg)
+			if router == nil {
+				continue
+			}
+			mountPrefix := path.Join("/", prefix, mountPath)
+
+			routerFile, routerVar := p.resolveRouter(unit, f, router)
+			if routerVar == "" {
+				if mountPrefix == "/" {
+					log.Sugar().Warnf("Could not resolve Koa router '%s'", router.Content())
+					continue
+				}
+				log.Sugar().Infof("Adding in catchall route for prefix '%s' for unresolved router '%s'", mountPrefix, router.Content())
+				routes = append(routes, catchallRoutes(mountPrefix, unit.Name, f.Path())...)
+				continue
+			}
+			routerRoutes := p.findRouterRoutes(unit, routerFile, routerVar, mountPrefix, visited)
+			if len(routerRoutes) == 0 {
+				log.Sugar().Warnf("No routes found for Koa router '%s'", router.Content())
+			}
+			routes = append(routes, routerRoutes...)
+		}
+	}
+	return
+}
+
+// koaMiddlewareRouter returns the router expression for `router.routes()` or `router.middleware()`, or nil if the
+// middleware is not a router.
+func koaMiddlewareRouter(arg *sitter.Node) *sitter.Node {
+	if arg.Type() != "call_expression" {
+		return nil
+	}
+	fn := arg.ChildByFieldName("function")
+	if fn.Type() != "member_expression" {
+		return nil
+	}
+	prop := fn.ChildByFieldName("property")
+	if !query.NodeContentEquals(prop, "routes") && !query.NodeContentEquals(prop, "middleware") {
+		return nil
+	}
+	return fn.ChildByFieldName("object")
+}
+
+// resolveRouter returns the file and local variable name that the router expression refers to.
+func (p *KoaHandler) resolveRouter(unit *types.ExecutionUnit, f *types.SourceFile, router *sitter.Node) (*types.SourceFile, string) {
+	root := f.Tree().RootNode()
+	var imp Import
+	exportName := ""
+	switch router.Type() {
+	case "identifier":
+		if _, ok := findKoaRouters(f)[router.Content()]; ok {
+			return f, router.Content()
+		}
+		imp = FindImportForVar(root, router.Content())
+		if imp.Type == ImportTypeNamed || imp.Type == ImportTypeField {
+			exportName = imp.Name
+		}
+	case "member_expression":
+		imp = FindImportForVar(root, router.ChildByFieldName("object").Content())
+		exportName = router.ChildByFieldName("property").Content()
+	default:
+		return nil, ""
+	}
+	if !strings.HasPrefix(imp.Source, ".") {
+		return nil, ""
+	}
+
+	files := make(map[string]io.File)
+	for _, uf := range unit.Files() {
+		files[uf.Path()] = uf
+	}
+	imported, err := FindFileForImport(files, f.Path(), imp.Source)
+	if err != nil || imported == nil {
+		return nil, ""
+	}
+	routerFile, ok := Language.ID.CastFile(imported)
+	if !ok {
+		return nil, ""
+	}
+
+	var exportNode *sitter.Node
+	if exportName == "" {
+		exportNode = FindDefaultExport(routerFile.Tree().RootNode())
+		if exportNode == nil {
+			exportNode = FindExportForVar(routerFile.Tree().RootNode(), "default")
+		}
+	} else {
+		exportNode = FindExportForVar(routerFile.Tree().RootNode(), exportName)
+	}
+	if exportNode == nil {
+		return nil, ""
+	}
+	if _, ok := findKoaRouters(routerFile)[exportNode.Content()]; !ok {
+		return nil, ""
+	}
+	return routerFile, exportNode.Content()
+}
+
+func (p *KoaHandler) findRouterRoutes(unit *types.ExecutionUnit, f *types.SourceFile, routerVar string, mountPrefix string, visited map[string]struct{}) (routes []gatewayRouteDefinition) {
+	routerKey := fmt.Sprintf("%s:%s", f.Path(), routerVar)
+	if _, ok := visited[routerKey]; ok {
+		return
+	}
+	visited[routerKey] = struct{}{}
+	defer delete(visited, routerKey)
+
+	prefix := path.Join("/", mountPrefix, koaRouterPrefix(f, routerVar))
+
+	nextMatch := DoQuery(f.Tree().RootNode(), methodInvocation)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		obj, method, call := match["var.name"], match["method.name"], match["full"]
+		if !query.NodeContentEquals(obj, routerVar) {
+			continue
+		}
+		verb := method.Content()
+		if verb == "all" {
+			verb = "any"
+		}
+		if verb == "del" {
+			verb = "delete"
+		}
+		if _, supported := types.Verbs[types.Verb(strings.ToUpper(verb))]; !supported {
+			continue
+		}
+		args := call.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 || args.NamedChild(0).Type() != "string" {
+			continue
+		}
+		routePath := args.NamedChild(0)
+		if args.NamedChildCount() > 1 && args.NamedChild(1).Type() == "string" {
+			// named route: router.get('user', '/users/:id', ...)
+			routePath = args.NamedChild(1)
+		}
+
+		fullPath := sanitizeKoaPath(path.Join(prefix, StringLiteralContent(routePath)))
+		if fullPath == "/:rest*" {
+			routes = append(routes, newKoaRoute(f, verb, "/", unit.Name))
+		}
+		p.log.Sugar().Debugf("Found route function %s %s for '%s'", verb, fullPath, routerVar)
+		routes = append(routes, newKoaRoute(f, verb, fullPath, unit.Name))
+	}
+
+	// nested routers: router.use('/nested', nested.routes())
+	routes = append(routes, p.findMountedRoutes(unit, f, routerVar, prefix, visited)...)
+	return
+}
+
+func newKoaRoute(f *types.SourceFile, verb string, routePath string, unitName string) gatewayRouteDefinition {
+	return gatewayRouteDefinition{
+		Route: types.Route{
+			Verb:          types.Verb(verb),
+			Path:          routePath,
+			ExecUnitName:  unitName,
+			HandledInFile: f.Path(),
+		},
+		DefinedInPath: f.Path(),
+	}
+}
+
+// koaRouterPrefix returns the prefix the router was configured with, either through the constructor
+// (`new Router({ prefix: '/users' })`) or via `router.prefix('/users')`.
+func koaRouterPrefix(f *types.SourceFile, routerVar string) string {
+	prefix := ""
+	if router, ok := findKoaRouters(f)[routerVar]; ok && router.arguments != nil && router.arguments.NamedChildCount() > 0 {
+		if options := router.arguments.NamedChild(0); options.Type() == "object" {
+			prefix = objectStringProperty(options, "prefix")
+		}
+	}
+
+	nextMatch := DoQuery(f.Tree().RootNode(), methodInvocation)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		obj, method, call := match["var.name"], match["method.name"], match["full"]
+		if !query.NodeContentEquals(obj, routerVar) || !query.NodeContentEquals(method, "prefix") {
+			continue
+		}
+		args := call.ChildByFieldName("arguments")
+		if args != nil && args.NamedChildCount() == 1 && args.NamedChild(0).Type() == "string" {
+			prefix = StringLiteralContent(args.NamedChild(0))
+		}
+	}
+	return prefix
+}
+
+var koaWildcardSuffixRegex = regexp.MustCompile(`/?\(\.\*\)$`)
+
+func sanitizeKoaPath(path string) string {
+	// replace '{prefix}/(.*)' with '{prefix}/:rest*'
+	path = koaWildcardSuffixRegex.ReplaceAllString(path, "/:rest*")
+	return sanitizeExpressPath(path)
+}
@@ -0,0 +1,166 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func Test_findKoaApp(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		expect string
+	}{
+		{
+			name: "new Koa",
+			source: `const Koa = require('koa');
+const app = new Koa();`,
+			expect: "app",
+		},
+		{
+			name: "typescript default import",
+			source: `const koa_1 = require("koa");
+const app = new koa_1.default();`,
+			expect: "app",
+		},
+		{
+			name: "router is not an app",
+			source: `const Router = require('@koa/router');
+const router = new Router();`,
+			expect: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			f, err := NewFile("test.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			app, _ := findKoaApp(f)
+			assert.Equal(tt.expect, app.varName)
+		})
+	}
+}
+
+func Test_koaHandler_findMountedRoutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		files  map[string]string
+		expect []types.Route
+	}{
+		{
+			name: "router with prefix",
+			source: `const Router = require('@koa/router');
+const router = new Router({ prefix: '/users' });
+router.get('/', (ctx) => {});
+router.post('/:id', (ctx) => {});
+router.del('/:id', (ctx) => {});
+app.use(router.routes()).use(router.allowedMethods());`,
+			expect: []types.Route{
+				{Path: "/users", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/users/:id", Verb: "post", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/users/:id", Verb: "delete", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "named route and prefix call",
+			source: `const Router = require('koa-router');
+const router = new Router();
+router.prefix('/v1');
+router.get('user', '/users/:id', (ctx) => {});
+app.use(router.routes());`,
+			expect: []types.Route{
+				{Path: "/v1/users/:id", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "nested routers",
+			source: `const Router = require('@koa/router');
+const api = new Router({ prefix: '/api' });
+const posts = new Router();
+posts.get('/', (ctx) => {});
+posts.all('/(.*)', (ctx) => {});
+api.use('/posts', posts.routes(), posts.allowedMethods());
+app.use(api.routes());`,
+			expect: []types.Route{
+				{Path: "/api/posts", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/api/posts/:rest*", Verb: "any", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name: "imported router",
+			source: `const users = require('./users');
+app.use(users.routes());`,
+			files: map[string]string{
+				"users.js": `const Router = require('@koa/router');
+const router = new Router({ prefix: '/users' });
+router.get('/', (ctx) => {});
+module.exports = router;`,
+			},
+			expect: []types.Route{
+				{Path: "/users", Verb: "get", ExecUnitName: "testUnit", HandledInFile: "users.js"},
+			},
+		},
+		{
+			name: "imported named router",
+			source: `const routes = require('./routes');
+app.use('/v2', routes.orders.routes());`,
+			files: map[string]string{
+				"routes.js": `const Router = require('@koa/router');
+const orders = new Router();
+orders.put('/orders/:id', (ctx) => {});
+exports.orders = orders;`,
+			},
+			expect: []types.Route{
+				{Path: "/v2/orders/:id", Verb: "put", ExecUnitName: "testUnit", HandledInFile: "routes.js"},
+			},
+		},
+		{
+			name: "unresolved router with mount path",
+			source: `const legacy = require('./missing');
+app.use('/legacy', legacy.routes());`,
+			expect: []types.Route{
+				{Path: "/legacy", Verb: "ANY", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+				{Path: "/legacy/:rest*", Verb: "ANY", ExecUnitName: "testUnit", HandledInFile: "test.js"},
+			},
+		},
+		{
+			name:   "non-router middleware",
+			source: `app.use(bodyParser());`,
+			expect: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			unit := &types.ExecutionUnit{Name: "testUnit"}
+			f, err := NewFile("test.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit.Add(f)
+			for path, content := range tt.files {
+				other, err := NewFile(path, strings.NewReader(content))
+				if !assert.NoError(err) {
+					return
+				}
+				unit.Add(other)
+			}
+
+			h := &KoaHandler{log: zap.L()}
+			got := h.findMountedRoutes(unit, f, "app", "", map[string]struct{}{})
+
+			var gotRoutes []types.Route
+			for _, r := range got {
+				gotRoutes = append(gotRoutes, r.Route)
+			}
+			assert.Equal(tt.expect, gotRoutes)
+		})
+	}
+}
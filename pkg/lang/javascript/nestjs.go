@@ -195,8 +195,8 @@ func (h *NestJsHandler) actOnAnnotation(f *types.SourceFile, listen *exposeListe
 	h.output.factories = append(h.output.factories, nestFactory)
 
 	newfileContent += fmt.Sprintf(`
-	exports.%s = %s
-	`, strings.TrimPrefix(appName, "exports."), appName)
+	%s
+	`, ExportStatement(f, strings.TrimPrefix(appName, "exports."), appName))
 	actedOn = true
 	return
 }
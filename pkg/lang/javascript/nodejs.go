@@ -130,6 +130,70 @@ Using version %s`, k, currentVersion, k, v, currentVersion)
 	// Ignore all other (non-supported / unmergeable) fields
 }
 
+// IsModule returns whether the package's `.js` files are ES modules (`"type": "module"`).
+func (n *NodePackageJson) IsModule() bool {
+	var packageType string
+	if raw, ok := n.OtherFields["type"]; ok {
+		_ = json.Unmarshal(raw, &packageType)
+	}
+	return packageType == "module"
+}
+
+// Scripts returns the package's `scripts`, keyed by the script's name.
+func (n *NodePackageJson) Scripts() map[string]string {
+	scripts := make(map[string]string)
+	if raw, ok := n.OtherFields["scripts"]; ok {
+		_ = json.Unmarshal(raw, &scripts)
+	}
+	return scripts
+}
+
+// packageEntrypointConditions are the `exports` conditions that are checked, in order, to resolve the package's entrypoint.
+var packageEntrypointConditions = []string{"node", "import", "require", "default"}
+
+// Entrypoint returns the package's entrypoint module. It is the "." entry of the `exports` map, if present
+// (see https://nodejs.org/api/packages.html#package-entry-points), or otherwise `main`.
+func (n *NodePackageJson) Entrypoint() (string, error) {
+	if raw, ok := n.OtherFields["exports"]; ok {
+		entrypoint, err := resolvePackageExport(raw, true)
+		if err != nil {
+			return "", errors.WrapErrf(err, "could not unmarshal 'exports' from package.json")
+		}
+		if entrypoint != "" {
+			return entrypoint, nil
+		}
+	}
+	main := ""
+	if raw, ok := n.OtherFields["main"]; ok {
+		if err := json.Unmarshal(raw, &main); err != nil {
+			return "", errors.WrapErrf(err, "could not unmarshal 'main' from package.json")
+		}
+	}
+	return main, nil
+}
+
+// resolvePackageExport resolves the "." export from an `exports` value, which may be a path, a map of subpaths
+// (only when isRoot, since subpath maps can't be nested) or a map of conditions.
+func resolvePackageExport(raw json.RawMessage, isRoot bool) (string, error) {
+	var path string
+	if err := json.Unmarshal(raw, &path); err == nil {
+		return path, nil
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return "", err
+	}
+	if root, ok := entries["."]; ok && isRoot {
+		return resolvePackageExport(root, false)
+	}
+	for _, condition := range packageEntrypointConditions {
+		if value, ok := entries[condition]; ok {
+			return resolvePackageExport(value, false)
+		}
+	}
+	return "", nil
+}
+
 func (n *NodePackageJson) MarshalJSON() ([]byte, error) {
 	m := map[string]interface{}{
 		"dependencies":    n.Dependencies,
@@ -2,12 +2,15 @@ package javascript
 
 import (
 	"io"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/lang"
 	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
 )
 
 var multilineCommentMarginRegexp = regexp.MustCompile(`(?m)^\s*[*]*[ \t]*`) // we need to use [ \t] instead of \s, because \s includes newlines in (?m) mode.
@@ -47,6 +50,49 @@ var Language = types.SourceLanguage{
 	ToLineComment: lang.MakeLineCommenter("// "),
 }
 
+// TypeScriptLanguage and TsxLanguage parse TypeScript sources. They share the javascript ID so that
+// the javascript plugins handle TypeScript files the same way as JavaScript files.
+var TypeScriptLanguage = types.SourceLanguage{
+	ID:               js,
+	Sitter:           typescript.GetLanguage(),
+	CapabilityFinder: Language.CapabilityFinder,
+	ToLineComment:    Language.ToLineComment,
+}
+
+var TsxLanguage = types.SourceLanguage{
+	ID:               js,
+	Sitter:           tsx.GetLanguage(),
+	CapabilityFinder: Language.CapabilityFinder,
+	ToLineComment:    Language.ToLineComment,
+}
+
+// NewFile parses the file using the grammar for its extension: TypeScript for `.ts`, TSX for `.tsx`,
+// and JavaScript otherwise.
 func NewFile(path string, content io.Reader) (f *types.SourceFile, err error) {
+	switch filepath.Ext(path) {
+	case ".ts":
+		return types.NewSourceFile(path, content, TypeScriptLanguage)
+	case ".tsx":
+		return types.NewSourceFile(path, content, TsxLanguage)
+	}
 	return types.NewSourceFile(path, content, Language)
 }
+
+// IsTypeScriptFile returns whether the path is a TypeScript source (`.ts` or `.tsx`), excluding declaration files.
+func IsTypeScriptFile(path string) bool {
+	if strings.HasSuffix(path, ".d.ts") {
+		return false
+	}
+	ext := filepath.Ext(path)
+	return ext == ".ts" || ext == ".tsx"
+}
+
+// IsTypeScriptUnit returns whether any of the unit's source files are TypeScript.
+func IsTypeScriptUnit(unit *types.ExecutionUnit) bool {
+	for path := range unit.Executable.SourceFiles {
+		if IsTypeScriptFile(path) {
+			return true
+		}
+	}
+	return false
+}
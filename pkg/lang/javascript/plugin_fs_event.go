@@ -0,0 +1,96 @@
+package javascript
+
+import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+)
+
+// FsEvent subscribes each exported function annotated with `@klotho::fs_event` to the events of the persisted fs
+// with the annotation's id. The unit's dispatcher calls the function with each write or delete of a file in the fs.
+type FsEvent struct {
+	Config *config.Application
+}
+
+func (p FsEvent) Name() string { return "FsEvent" }
+
+func (p FsEvent) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	events := make(map[string]*types.FsEvent)
+	subscribers := make(map[string]string)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			js, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(js); owner != "" && owner != unit.Name {
+				continue
+			}
+			for _, annot := range js.Annotations() {
+				if annot.Capability.Name != annotation.FsEventCapability {
+					continue
+				}
+				sub, err := p.newSubscription(js, annot, unit)
+				if err != nil {
+					errs.Append(types.NewCompilerError(js, annot, err))
+					continue
+				}
+				key := annot.Capability.ID + "/" + sub.Id()
+				if existing, ok := subscribers[key]; ok {
+					if existing != unit.Name {
+						errs.Append(types.NewCompilerError(js, annot, errors.Errorf(
+							"fs event subscriber is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+							existing, unit.Name,
+						)))
+					}
+					continue
+				}
+				subscribers[key] = unit.Name
+
+				event, ok := events[annot.Capability.ID]
+				if !ok {
+					event = &types.FsEvent{Name: annot.Capability.ID}
+					events[event.Name] = event
+					// the fs is added by the persist plugin of the unit which declares it; adding it here only links the two
+					fs := &types.Fs{Name: event.Name}
+					constructGraph.AddConstruct(event)
+					constructGraph.AddConstruct(fs)
+					constructGraph.AddDependency(event.Id(), fs.Id())
+				}
+				if err := event.Subscribe(sub); err != nil {
+					errs.Append(types.NewCompilerError(js, annot, err))
+					continue
+				}
+				constructGraph.AddDependency(event.Id(), unit.Id())
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p FsEvent) newSubscription(f *types.SourceFile, annot *types.Annotation, unit *types.ExecutionUnit) (types.FsSubscription, error) {
+	sub, err := types.NewFsSubscriptionFromAnnotation(annot.Capability)
+	if err != nil {
+		return sub, err
+	}
+	name := annotatedFunctionName(annot.Node)
+	if name == "" {
+		return sub, errors.New("@klotho::fs_event must annotate a function")
+	}
+	if !isExportedFunction(f, annot.Node, name) {
+		return sub, errors.Errorf("fs event subscriber '%s' must be exported", name)
+	}
+	if p.Config != nil {
+		if unitType := p.Config.GetResourceType(unit); unitType != "lambda" {
+			return sub, errors.Errorf("fs events can only be delivered to lambda execution units, not %s", unitType)
+		}
+	}
+	sub.ExecUnitName = unit.Name
+	sub.ModuleName = FileToModule(f.Path())
+	sub.FunctionName = name
+	return sub, nil
+}
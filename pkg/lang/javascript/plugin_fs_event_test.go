@@ -0,0 +1,118 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFsEvent_Transform(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  map[string]string
+		unitType string
+		want     []types.FsSubscription
+		wantErr  bool
+	}{
+		{
+			name: "write events by default",
+			sources: map[string]string{"src/uploads.js": `// @klotho::fs_event {
+//   id = "uploads"
+// }
+export async function onUpload(event) {}`},
+			unitType: "lambda",
+			want: []types.FsSubscription{
+				{ExecUnitName: "main", ModuleName: "src/uploads", FunctionName: "onUpload", Events: []string{"write"}},
+			},
+		},
+		{
+			name: "multiple subscribers with filters",
+			sources: map[string]string{
+				"src/uploads.js": `// @klotho::fs_event {
+//   id = "uploads"
+//   events = ["write", "delete"]
+//   prefix = "images/"
+//   suffix = ".png"
+// }
+exports.onImage = async (event) => {};`,
+				"src/audit.js": `// @klotho::fs_event {
+//   id = "uploads"
+//   events = "delete"
+// }
+exports.onDelete = async (event) => {};`,
+			},
+			unitType: "lambda",
+			want: []types.FsSubscription{
+				{ExecUnitName: "main", ModuleName: "src/uploads", FunctionName: "onImage", Events: []string{"write", "delete"}, Prefix: "images/", Suffix: ".png"},
+				{ExecUnitName: "main", ModuleName: "src/audit", FunctionName: "onDelete", Events: []string{"delete"}},
+			},
+		},
+		{
+			name: "invalid event",
+			sources: map[string]string{"src/uploads.js": `// @klotho::fs_event {
+//   id = "uploads"
+//   events = ["read"]
+// }
+export async function onUpload(event) {}`},
+			unitType: "lambda",
+			wantErr:  true,
+		},
+		{
+			name: "function is not exported",
+			sources: map[string]string{"src/uploads.js": `// @klotho::fs_event {
+//   id = "uploads"
+// }
+async function onUpload(event) {}`},
+			unitType: "lambda",
+			wantErr:  true,
+		},
+		{
+			name: "fargate unit",
+			sources: map[string]string{"src/uploads.js": `// @klotho::fs_event {
+//   id = "uploads"
+// }
+export async function onUpload(event) {}`},
+			unitType: "ecs",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := &types.ExecutionUnit{Name: "main"}
+			for path, source := range tt.sources {
+				f, err := NewFile(path, strings.NewReader(source))
+				if !assert.NoError(err) {
+					return
+				}
+				unit.Add(f)
+			}
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			cfg := &config.Application{Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: tt.unitType}}}
+			err := FsEvent{Config: cfg}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			events := construct.GetConstructsOfType[*types.FsEvent](graph)
+			if !assert.Len(events, 1) {
+				return
+			}
+			event := events[0]
+			assert.Equal("uploads", event.Name)
+			assert.ElementsMatch(tt.want, event.Subscriptions)
+			assert.NotNil(graph.GetDependency(event.Id(), unit.Id()))
+			assert.NotNil(graph.GetDependency(event.Id(), (&types.Fs{Name: "uploads"}).Id()))
+		})
+	}
+}
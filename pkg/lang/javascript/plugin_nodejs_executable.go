@@ -1,16 +1,16 @@
 package javascript
 
 import (
-	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/construct"
 	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
 	execunit "github.com/klothoplatform/klotho/pkg/exec_unit"
-	"github.com/klothoplatform/klotho/pkg/filter"
-	"github.com/klothoplatform/klotho/pkg/io"
-	"github.com/klothoplatform/klotho/pkg/logging"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +20,7 @@ var upstreamDependencyResolver = execunit.SourceFilesResolver{
 }
 
 type NodeJSExecutable struct {
+	Config *config.Application
 }
 
 func (l NodeJSExecutable) Name() string {
@@ -76,6 +77,12 @@ func (l NodeJSExecutable) Transform(input *types.InputFiles, fileDeps *types.Fil
 			return err
 		}
 		refreshUpstreamEntrypoints(unit)
+
+		if l.Config != nil {
+			if cfg := l.Config.GetExecutionUnit(unit.Name); cfg.PruneDependencies {
+				pruneUnusedDependencies(unit, cfg.KeepDependencies)
+			}
+		}
 	}
 	return nil
 }
@@ -90,51 +97,129 @@ func refreshUpstreamEntrypoints(unit *types.ExecutionUnit) {
 }
 
 func refreshSourceFiles(unit *types.ExecutionUnit) error {
-	sourceFiles, err := upstreamDependencyResolver.Resolve(unit)
+	sourceFiles, err := upstreamDependencyResolver.ResolveWithReasons(unit)
 	if err != nil {
 		return klotho_errors.WrapErrf(err, "file dependency resolution failed for execution unit: %s", unit.Name)
 	}
-	for k, v := range sourceFiles {
-		unit.Executable.SourceFiles[k] = v
-		warnIfContainsES6Import(unit.Get(k))
+	for path, reason := range sourceFiles {
+		unit.Executable.SourceFiles[path] = struct{}{}
+		if _, explained := unit.Executable.InclusionReasons[path]; !explained {
+			unit.Executable.SetInclusionReason(path, reason)
+		}
 	}
-	return err
+	return nil
 }
 
-func warnIfContainsES6Import(file io.File) {
-	jsF, ok := Language.ID.CastFile(file)
-	if !ok {
-		return
+// runtimeDependencies are used without being imported by the unit's source files: the database drivers which ORMs
+// (e.g. Sequelize, TypeORM and Knex) load by the name of their dialect. They are never pruned. To support another
+// driver, add its package name in sorted order.
+var runtimeDependencies = []string{
+	"better-sqlite3",
+	"mariadb",
+	"mysql",
+	"mysql2",
+	"oracledb",
+	"pg",
+	"pg-hstore",
+	"pg-native",
+	"pg-query-stream",
+	"sqlite3",
+	"tedious",
+}
+
+var scriptCommandSeparator = regexp.MustCompile(`[\s;&|()]+`)
+
+// pruneUnusedDependencies removes the dependencies from the unit's package.json that none of its source files import.
+// TypeScript compiler packages, dependencies which are used without being imported, those which the package's scripts
+// run and those in keep are always kept.
+func pruneUnusedDependencies(unit *types.ExecutionUnit, keep []string) {
+	used := make(map[string]struct{})
+	for path := range unit.Executable.SourceFiles {
+		f, ok := js.CastFile(unit.Get(path))
+		if !ok {
+			continue
+		}
+		for source := range FindImportsInFile(f) {
+			if name := nodePackageName(source); name != "" {
+				used[name] = struct{}{}
+			}
+		}
+	}
+
+	for _, dep := range keep {
+		used[dep] = struct{}{}
+	}
+	for _, dep := range runtimeDependencies {
+		used[dep] = struct{}{}
 	}
 
-	for _, p := range FindImportsInFile(jsF).Filter(filter.NewSimpleFilter(IsImportOfKind(ImportKindES))) {
-		if p.Kind == ImportKindES {
-			zap.L().Sugar().With(logging.FileField(jsF), logging.NodeField(p.ImportNode)).Warn(
-				"ES6 import statements are not yet supported: please use CommonJS 'require()' syntax instead")
+	for _, f := range unit.Files() {
+		packageJson, ok := f.(*PackageFile)
+		if !ok || packageJson.Content == nil {
+			continue
 		}
+		commands := make(map[string]struct{})
+		for _, script := range packageJson.Content.Scripts() {
+			for _, command := range scriptCommandSeparator.Split(script, -1) {
+				commands[command] = struct{}{}
+			}
+		}
+		var pruned []string
+		for dep := range packageJson.Content.Dependencies {
+			if _, isUsed := used[dep]; isUsed || dep == "typescript" || strings.HasPrefix(dep, "@types/") {
+				continue
+			}
+			if _, isRun := commands[dep]; isRun {
+				continue
+			}
+			pruned = append(pruned, dep)
+		}
+		sort.Strings(pruned)
+		for _, dep := range pruned {
+			zap.L().Sugar().Debugf("Removing unused dependency '%s' from %s in execution unit: %s", dep, packageJson.Path(), unit.Name)
+			delete(packageJson.Content.Dependencies, dep)
+		}
+		unit.Executable.AddPrunedDependencies(packageJson.Path(), pruned...)
+	}
+}
+
+// nodePackageName returns the name of the package that an import source refers to
+// (e.g. "lodash" for "lodash/fp" or "@aws-sdk/client-s3" for "@aws-sdk/client-s3/dist"),
+// or an empty string for relative and absolute file imports.
+func nodePackageName(source string) string {
+	if source == "" || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") {
+		return ""
 	}
+	parts := strings.Split(source, "/")
+	if strings.HasPrefix(source, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
 }
 
 func resolveDefaultEntrypoint(unit *types.ExecutionUnit) {
-	if indexJs := unit.Get("index.js"); indexJs != nil {
-		zap.L().Sugar().Debugf("Adding execution unit entrypoint: [default] -> [%s] -> %s", unit.Name, indexJs.Path())
-		unit.AddEntrypoint(indexJs)
+	for _, ext := range moduleExtensions {
+		if index := unit.Get("index" + ext); index != nil {
+			zap.L().Sugar().Debugf("Adding execution unit entrypoint: [default] -> [%s] -> %s", unit.Name, index.Path())
+			unit.AddEntrypoint(index)
+			return
+		}
 	}
 }
 
 func addEntrypointFromPackageJson(packageJson *PackageFile, unit *types.ExecutionUnit) error {
-	// if no other roots are detected, add the file indicated in the unit's package.json#main field
-	if mainRaw, ok := packageJson.Content.OtherFields["main"]; ok {
-		main := ""
-		err := json.Unmarshal(mainRaw, &main)
-		if err != nil {
-			return klotho_errors.WrapErrf(err, "could not unmarshal 'main' from package.json")
-		}
-		if mainFileR := unit.Get(main); mainFileR != nil {
-			if mainFile, ok := mainFileR.(*types.SourceFile); ok {
-				unit.AddEntrypoint(mainFile)
-				zap.L().Sugar().Debugf("Adding execution unit entrypoint: [package.json#main] -> [%s] -> %s", unit.Name, mainFile.Path())
-			}
+	// if no other roots are detected, add the file indicated by the unit's package.json#exports or package.json#main field
+	main, err := packageJson.Content.Entrypoint()
+	if err != nil {
+		return err
+	}
+	if main == "" {
+		return nil
+	}
+	if mainFileR := unit.Get(strings.TrimPrefix(main, "./")); mainFileR != nil {
+		if mainFile, ok := mainFileR.(*types.SourceFile); ok {
+			unit.AddEntrypoint(mainFile)
+			zap.L().Sugar().Debugf("Adding execution unit entrypoint: [package.json] -> [%s] -> %s", unit.Name, mainFile.Path())
 		}
 	}
 	return nil
@@ -1,6 +1,7 @@
 package javascript
 
 import (
+	"sort"
 	"strings"
 	"testing"
 
@@ -64,6 +65,28 @@ func TestNodeJSExecutable_Transform(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "default entrypoint is resolved from package.json#exports for ES modules",
+			otherFiles: map[string]string{"package.json": `{ "type": "module", "main": "other.js", "exports": { ".": { "import": "./myunit.js" } } }`},
+			units: []*types.ExecutionUnit{
+				execUnit("main",
+					taggedFile{path: "myunit.js", content: "import { handler } from './module.js'"},
+					taggedFile{path: "module.js", content: "export const handler = () => {}"},
+					taggedFile{path: "other.js"},
+				),
+			},
+			expectedUnits: map[string]expectedUnit{
+				"main": {
+					executableType: types.ExecutableTypeNodeJS,
+					expectedFiles: map[string][]string{
+						"allFiles":    {"package.json", "myunit.js", "module.js", "other.js"},
+						"resources":   {"package.json"},
+						"sourceFiles": {"myunit.js", "module.js"},
+						"entrypoints": {"myunit.js"},
+					},
+				},
+			},
+		},
 		{
 			name:       "default entrypoint is index.js when package.json#main is not set",
 			otherFiles: map[string]string{"package.json": `{ "main" : "" }`},
@@ -270,3 +293,47 @@ type taggedFile struct {
 	content string
 	tag     string
 }
+
+func Test_pruneUnusedDependencies(t *testing.T) {
+	assert := assert2.New(t)
+
+	unit := execUnit("main",
+		taggedFile{path: "package.json", tag: "resource", content: `{"dependencies": {
+			"express": "^4", "lodash": "^4", "@aws-sdk/client-s3": "^3", "@aws-sdk/client-sqs": "^3", "@types/node": "^18", "left-pad": "^1"
+		}}`},
+		taggedFile{path: "index.js", tag: "entrypoint", content: `const express = require('express');
+const fp = require('lodash/fp');
+const { S3Client } = require('@aws-sdk/client-s3');
+const util = require('./util');`},
+		taggedFile{path: "util.js", tag: "source"},
+		taggedFile{path: "unused.js", content: `require('left-pad');`},
+	)
+
+	pruneUnusedDependencies(unit, nil)
+
+	packageJson := unit.Get("package.json").(*PackageFile)
+	assert.ElementsMatch([]string{"express", "lodash", "@aws-sdk/client-s3", "@types/node"}, keys(packageJson.Content.Dependencies))
+	assert.Equal(map[string][]string{"package.json": {"@aws-sdk/client-sqs", "left-pad"}}, unit.Executable.PrunedDependencies)
+}
+
+func Test_pruneUnusedDependencies_notImported(t *testing.T) {
+	assert := assert2.New(t)
+
+	unit := execUnit("main",
+		taggedFile{path: "package.json", tag: "resource", content: `{
+			"dependencies": {"sequelize": "^6", "pg": "^8", "pg-hstore": "^2", "pm2": "^5", "pino": "^8", "pino-pretty": "^10", "left-pad": "^1"},
+			"scripts": {"start": "pm2 start index.js && pm2 logs"}
+		}`},
+		taggedFile{path: "index.js", tag: "entrypoint", content: `const { Sequelize } = require('sequelize');
+const logger = require('pino')({ transport: { target: 'pino-pretty' } });
+const sequelize = new Sequelize('postgres://db', { dialect: 'postgres' });`},
+	)
+
+	pruneUnusedDependencies(unit, []string{"pino-pretty"})
+
+	assert.Equal(map[string][]string{"package.json": {"left-pad"}}, unit.Executable.PrunedDependencies)
+}
+
+func Test_runtimeDependenciesSorted(t *testing.T) {
+	assert2.True(t, sort.StringsAreSorted(runtimeDependencies), "runtimeDependencies must be sorted")
+}
@@ -0,0 +1,211 @@
+package javascript
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Queue creates a `types.Queue` for each `@klotho::queue` annotation.
+	//
+	// An annotated `new Queue()` declaration is replaced by the runtime's queue, and any unit which calls `send` on it is a producer.
+	// An annotated exported function is the queue's consumer, which the unit's dispatcher passes each message to.
+	Queue struct {
+		Config  *config.Application
+		runtime Runtime
+
+		queues map[string]*types.Queue
+	}
+)
+
+const queueVarType = "Queue"
+const queueRTName = "queue"
+
+var queueRE = regexp.MustCompile(`(?:\w+\.)?\bQueue\(.*\)`)
+
+func (p Queue) Name() string { return "Queue" }
+
+func (p Queue) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	p.queues = make(map[string]*types.Queue)
+
+	var errs multierr.Error
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		errs.Append(p.findConsumers(unit, constructGraph))
+
+		vars := DiscoverDeclarations(unit.Files(), queueVarType, "", false, isQueueDeclaration)
+		for spec, value := range vars {
+			if value.Annotation.Capability.ID == "" {
+				errs.Append(types.NewCompilerError(value.File, value.Annotation, errors.New("'id' is required")))
+				delete(vars, spec)
+			}
+		}
+		if len(vars) == 0 {
+			continue
+		}
+
+		errs.Append(p.findProducers(unit, vars, constructGraph))
+
+		for path, fileVars := range vars.SplitByFile() {
+			js, ok := Language.ID.CastFile(unit.Get(path))
+			if !ok {
+				continue
+			}
+			if err := rewriteQueues(js, fileVars); err != nil {
+				errs.Append(klotho_errors.WrapErrf(err, "failed to handle queue in unit %s", unit.Name))
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *Queue) queue(id string, constructGraph *construct.ConstructGraph) *types.Queue {
+	if q, ok := p.queues[id]; ok {
+		return q
+	}
+	q := &types.Queue{Name: id}
+	if p.Config != nil {
+		q = p.Config.NewQueue(id)
+	}
+	p.queues[id] = q
+	constructGraph.AddConstruct(q)
+	return q
+}
+
+func (p *Queue) findConsumers(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, f := range unit.Files() {
+		js, ok := Language.ID.CastFile(f)
+		if !ok {
+			continue
+		}
+		if owner := types.FileExecUnitName(js); owner != "" && owner != unit.Name {
+			continue
+		}
+		for _, annot := range js.Annotations() {
+			if annot.Capability.Name != annotation.QueueCapability || isQueueDeclaration(js, annot) {
+				continue
+			}
+			if annot.Capability.ID == "" {
+				errs.Append(types.NewCompilerError(js, annot, errors.New("'id' is required")))
+				continue
+			}
+			name := annotatedFunctionName(annot.Node)
+			if name == "" {
+				errs.Append(types.NewCompilerError(js, annot, errors.New("@klotho::queue must annotate a `new Queue()` declaration or a consumer function")))
+				continue
+			}
+			if !isExportedFunction(js, annot.Node, name) {
+				errs.Append(types.NewCompilerError(js, annot, errors.Errorf("queue consumer '%s' must be exported", name)))
+				continue
+			}
+			consumer := &types.QueueConsumer{
+				ExecUnitName: unit.Name,
+				ModuleName:   FileToModule(js.Path()),
+				FunctionName: name,
+			}
+			queue := p.queue(annot.Capability.ID, constructGraph)
+			if err := addQueueConsumer(queue, consumer); err != nil {
+				errs.Append(types.NewCompilerError(js, annot, err))
+				continue
+			}
+			constructGraph.AddDependency(queue.Id(), unit.Id())
+			unit.EnvironmentVariables.Add(types.GenerateQueueUrlEnvVar(queue))
+			errs.Append(p.runtime.AddQueueRuntimeFiles(unit))
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *Queue) findProducers(unit *types.ExecutionUnit, vars VarDeclarations, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, f := range unit.Files() {
+		js, ok := Language.ID.CastFile(f)
+		if !ok {
+			continue
+		}
+		log := zap.L().With(logging.FileField(f)).Sugar()
+		for spec, value := range vars {
+			if !sendsToQueue(js, spec) {
+				continue
+			}
+			queue := p.queue(value.Annotation.Capability.ID, constructGraph)
+			log.Infof("Found producer for queue %s in %s#%s", queue.Name, spec.DefinedIn, spec.InternalName)
+			constructGraph.AddDependency(unit.Id(), queue.Id())
+			unit.EnvironmentVariables.Add(types.GenerateQueueUrlEnvVar(queue))
+			errs.Append(p.runtime.AddQueueRuntimeFiles(unit))
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+// addQueueConsumer sets the queue's consumer. Each queue delivers its messages to a single consumer.
+func addQueueConsumer(queue *types.Queue, consumer *types.QueueConsumer) error {
+	switch {
+	case queue.Consumer == nil:
+		queue.Consumer = consumer
+	case *queue.Consumer == *consumer:
+	case queue.Consumer.ModuleName == consumer.ModuleName && queue.Consumer.FunctionName == consumer.FunctionName:
+		return errors.Errorf(
+			"queue consumer is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+			queue.Consumer.ExecUnitName, consumer.ExecUnitName,
+		)
+	default:
+		return errors.Errorf("queue '%s' already has a consumer: %s#%s", queue.Name, queue.Consumer.ModuleName, queue.Consumer.FunctionName)
+	}
+	return nil
+}
+
+// isQueueDeclaration is an `AnnotationFilter` for the queue annotations on declarations, rather than on consumer functions.
+func isQueueDeclaration(_ *types.SourceFile, annot *types.Annotation) bool {
+	return annot.Capability.Name == annotation.QueueCapability && annotatedFunctionName(annot.Node) == ""
+}
+
+// sendsToQueue returns whether `f` calls `send` on the queue variable defined by `spec`.
+func sendsToQueue(f *types.SourceFile, spec VarSpec) bool {
+	varName := findVarName(f, spec)
+	if varName == "" {
+		return false
+	}
+	next := DoQuery(f.Tree().RootNode(), methodInvocation)
+	for {
+		match, found := next()
+		if !found {
+			return false
+		}
+		if match["var.name"].Content() == varName && match["method.name"].Content() == "send" {
+			return true
+		}
+	}
+}
+
+func rewriteQueues(f *types.SourceFile, vars VarDeclarations) error {
+	content := string(f.Program())
+	for spec, value := range vars {
+		id := value.Annotation.Capability.ID
+		expr := value.Annotation.Node.Content()
+		newExpr := queueRE.ReplaceAllString(
+			expr,
+			fmt.Sprintf(`%sRuntime.Queue("%s", "%s")`, queueRTName, id, types.QueueUrlEnvVarName(id)),
+		)
+		if newExpr == expr {
+			zap.S().Debugf("queue %s in %s already rewritten", spec.InternalName, f.Path())
+			continue
+		}
+		content = strings.ReplaceAll(content, expr, newExpr)
+	}
+	if err := f.Reparse([]byte(content)); err != nil {
+		return err
+	}
+	return EnsureRuntimeImportFile(queueRTName, queueRTName, f)
+}
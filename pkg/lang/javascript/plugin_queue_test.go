@@ -0,0 +1,136 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_Transform(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		want         *types.Queue
+		wantProducer bool
+		wantContent  string
+		wantErr      bool
+	}{
+		{
+			name: "producer",
+			source: `/* @klotho::queue {
+ *   id = "orders"
+ * }
+ */
+const orders = new Queue();
+exports.place = async (order) => orders.send(order);`,
+			want:         &types.Queue{Name: "orders"},
+			wantProducer: true,
+			wantContent:  `const orders = new queueRuntime.Queue("orders", "ORDERS_QUEUE_URL");`,
+		},
+		{
+			name: "consumer",
+			source: `// @klotho::queue {
+//   id = "orders"
+// }
+exports.fulfill = async (order) => {};`,
+			want: &types.Queue{Name: "orders", Consumer: &types.QueueConsumer{ExecUnitName: "main", ModuleName: "src/orders", FunctionName: "fulfill"}},
+		},
+		{
+			name: "declaration is not sent to",
+			source: `/* @klotho::queue {
+ *   id = "orders"
+ * }
+ */
+const orders = new Queue();`,
+			wantContent: `const orders = new queueRuntime.Queue("orders", "ORDERS_QUEUE_URL");`,
+		},
+		{
+			name: "consumer is not exported",
+			source: `// @klotho::queue {
+//   id = "orders"
+// }
+function fulfill(order) {}`,
+			wantErr: true,
+		},
+		{
+			name: "missing id",
+			source: `// @klotho::queue
+exports.fulfill = async (order) => {};`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := NewFile("src/orders.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit := &types.ExecutionUnit{Name: "main"}
+			unit.Add(f)
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			err = Queue{runtime: NoopRuntime{}}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			if tt.wantContent != "" {
+				assert.Contains(string(f.Program()), tt.wantContent)
+			}
+			queues := construct.GetConstructsOfType[*types.Queue](graph)
+			if tt.want == nil {
+				assert.Empty(queues)
+				return
+			}
+			if !assert.Len(queues, 1) {
+				return
+			}
+			assert.Equal(tt.want, queues[0])
+			if tt.wantProducer {
+				assert.NotNil(graph.GetDependency(unit.Id(), queues[0].Id()))
+			}
+			if tt.want.Consumer != nil {
+				assert.NotNil(graph.GetDependency(queues[0].Id(), unit.Id()))
+			}
+		})
+	}
+}
+
+func Test_addQueueConsumer(t *testing.T) {
+	consumer := func(unit string, function string) *types.QueueConsumer {
+		return &types.QueueConsumer{ExecUnitName: unit, ModuleName: "src/orders", FunctionName: function}
+	}
+	tests := []struct {
+		name     string
+		existing *types.QueueConsumer
+		consumer *types.QueueConsumer
+		wantErr  bool
+	}{
+		{name: "first consumer", consumer: consumer("main", "fulfill")},
+		{name: "same consumer", existing: consumer("main", "fulfill"), consumer: consumer("main", "fulfill")},
+		{name: "file shared by units", existing: consumer("main", "fulfill"), consumer: consumer("other", "fulfill"), wantErr: true},
+		{name: "second consumer", existing: consumer("main", "fulfill"), consumer: consumer("main", "refund"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			queue := &types.Queue{Name: "orders", Consumer: tt.existing}
+			err := addQueueConsumer(queue, tt.consumer)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.consumer, queue.Consumer)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package javascript
+
+import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Schedule creates a `types.Schedule` for each exported function annotated with `@klotho::schedule`.
+// The schedule targets the execution unit that owns the file, which invokes the function through its dispatcher.
+type Schedule struct{}
+
+func (p Schedule) Name() string { return "Schedule" }
+
+func (p Schedule) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	schedules := make(map[string]*types.Schedule)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			js, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(js); owner != "" && owner != unit.Name {
+				continue
+			}
+			for _, annot := range js.Annotations() {
+				if annot.Capability.Name != annotation.ScheduleCapability {
+					continue
+				}
+				schedule, err := newSchedule(js, annot, unit)
+				if err != nil {
+					errs.Append(types.NewCompilerError(js, annot, err))
+					continue
+				}
+				if existing, ok := schedules[schedule.Name]; ok {
+					if existing.ExecUnitName != unit.Name {
+						errs.Append(types.NewCompilerError(js, annot, errors.Errorf(
+							"schedule is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+							existing.ExecUnitName, unit.Name,
+						)))
+					}
+					continue
+				}
+				schedules[schedule.Name] = schedule
+				constructGraph.AddConstruct(schedule)
+				constructGraph.AddDependency(schedule.Id(), unit.Id())
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func newSchedule(f *types.SourceFile, annot *types.Annotation, unit *types.ExecutionUnit) (*types.Schedule, error) {
+	schedule, err := types.NewScheduleFromAnnotation(annot.Capability)
+	if err != nil {
+		return nil, err
+	}
+	name := annotatedFunctionName(annot.Node)
+	if name == "" {
+		return nil, errors.New("@klotho::schedule must annotate a function")
+	}
+	if !isExportedFunction(f, annot.Node, name) {
+		return nil, errors.Errorf("scheduled function '%s' must be exported", name)
+	}
+	schedule.ExecUnitName = unit.Name
+	schedule.ModuleName = FileToModule(f.Path())
+	schedule.FunctionName = name
+	return schedule, nil
+}
+
+// annotatedFunctionName returns the name of the function declared or assigned at the annotated node, supporting:
+//
+//	export function name() {}
+//	export const name = () => {}
+//	exports.name = function () {}
+//	function name() {}
+func annotatedFunctionName(n *sitter.Node) string {
+	if n == nil {
+		return ""
+	}
+	switch n.Type() {
+	case "export_statement":
+		return annotatedFunctionName(n.ChildByFieldName("declaration"))
+
+	case "function_declaration", "generator_function_declaration":
+		return n.ChildByFieldName("name").Content()
+
+	case "lexical_declaration", "variable_declaration":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			declarator := n.NamedChild(i)
+			if declarator.Type() != "variable_declarator" || !isFunctionNode(declarator.ChildByFieldName("value")) {
+				continue
+			}
+			return declarator.ChildByFieldName("name").Content()
+		}
+
+	case "expression_statement":
+		assignment := n.NamedChild(0)
+		if assignment == nil || assignment.Type() != "assignment_expression" || !isFunctionNode(assignment.ChildByFieldName("right")) {
+			return ""
+		}
+		left := assignment.ChildByFieldName("left")
+		if left.Type() != "member_expression" {
+			return ""
+		}
+		if obj := left.ChildByFieldName("object").Content(); obj == "exports" || obj == "module.exports" {
+			return left.ChildByFieldName("property").Content()
+		}
+	}
+	return ""
+}
+
+// isExportedFunction returns whether the function `name` declared at `n` is exported, either by its declaration or separately.
+func isExportedFunction(f *types.SourceFile, n *sitter.Node, name string) bool {
+	if n.Type() == "export_statement" || n.Type() == "expression_statement" {
+		return true
+	}
+	return SpecificExportQuery(f.Tree().RootNode(), name) != nil
+}
+
+func isFunctionNode(n *sitter.Node) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "function", "function_expression", "arrow_function", "generator_function":
+		return true
+	}
+	return false
+}
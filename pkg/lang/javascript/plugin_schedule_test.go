@@ -0,0 +1,106 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Transform(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    *types.Schedule
+		wantErr bool
+	}{
+		{
+			name: "exports assignment",
+			source: `// @klotho::schedule {
+//   id = "nightly"
+//   cron = "0 3 * * *"
+// }
+exports.cleanup = async () => {};`,
+			want: &types.Schedule{Name: "nightly", Cron: "0 3 * * *", ExecUnitName: "main", ModuleName: "src/jobs", FunctionName: "cleanup"},
+		},
+		{
+			name: "exported function declaration",
+			source: `/* @klotho::schedule {
+ *   id = "poll"
+ *   rate = "5 minutes"
+ * }
+ */
+export async function poll() {}`,
+			want: &types.Schedule{Name: "poll", Rate: "5 minutes", ExecUnitName: "main", ModuleName: "src/jobs", FunctionName: "poll"},
+		},
+		{
+			name: "function exported separately",
+			source: `// @klotho::schedule {
+//   id = "report"
+//   rate = "1 hour"
+// }
+function report() {}
+export { report };`,
+			want: &types.Schedule{Name: "report", Rate: "1 hour", ExecUnitName: "main", ModuleName: "src/jobs", FunctionName: "report"},
+		},
+		{
+			name: "function is not exported",
+			source: `// @klotho::schedule {
+//   id = "report"
+//   rate = "1 hour"
+// }
+function report() {}`,
+			wantErr: true,
+		},
+		{
+			name: "both cron and rate",
+			source: `// @klotho::schedule {
+//   id = "nightly"
+//   cron = "0 3 * * *"
+//   rate = "1 day"
+// }
+exports.cleanup = async () => {};`,
+			wantErr: true,
+		},
+		{
+			name: "not a function",
+			source: `// @klotho::schedule {
+//   id = "nightly"
+//   rate = "1 day"
+// }
+exports.value = 1;`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := NewFile("src/jobs.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit := &types.ExecutionUnit{Name: "main"}
+			unit.Add(f)
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			err = Schedule{}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			schedules := construct.GetConstructsOfType[*types.Schedule](graph)
+			if !assert.Len(schedules, 1) {
+				return
+			}
+			assert.Equal(tt.want, schedules[0])
+			assert.NotNil(graph.GetDependency(schedules[0].Id(), unit.Id()))
+		})
+	}
+}
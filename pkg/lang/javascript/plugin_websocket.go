@@ -0,0 +1,191 @@
+package javascript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/query"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+type (
+	// WebSocket creates a `types.WebSocketGateway` for the `ws` WebSocketServer created in the file of an exposed app.
+	//
+	// The server is replaced by the runtime's server, which the unit's dispatcher passes the WebSocket API's events to.
+	// It must run after the web framework handlers, which create the gateways of the exposed apps.
+	WebSocket struct {
+		Config  *config.Application
+		runtime Runtime
+	}
+
+	// webSocketServerResult is a `new WebSocketServer(...)` expression found in a file.
+	webSocketServerResult struct {
+		expression *sitter.Node
+		path       string
+	}
+)
+
+const (
+	webSocketRTName    = "websocket"
+	wsModule           = "ws"
+	socketIoModule     = "socket.io"
+	webSocketLibraryWs = "ws"
+)
+
+// wsServerExports are the exports of the `ws` module which create a WebSocket server (rather than a client).
+var wsServerExports = map[string]struct{}{
+	"Server":          {},
+	"WebSocketServer": {},
+}
+
+func (p WebSocket) Name() string { return "WebSocket" }
+
+func (p WebSocket) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, gw := range construct.GetConstructsOfType[*types.Gateway](constructGraph) {
+		for _, c := range constructGraph.GetDownstreamConstructs(gw) {
+			unit, ok := c.(*types.ExecutionUnit)
+			if !ok {
+				continue
+			}
+			js, ok := Language.ID.CastFile(unit.Get(gw.DefinedIn))
+			if !ok {
+				continue
+			}
+			if err := p.handleFile(js, gw, unit, constructGraph); err != nil {
+				errs.Append(klotho_errors.WrapErrf(err, "failed to handle websocket in unit %s", unit.Name))
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *WebSocket) handleFile(f *types.SourceFile, gw *types.Gateway, unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
+	log := zap.L().With(logging.FileField(f)).Sugar()
+
+	if len(FindImportsInFile(f)[socketIoModule]) > 0 {
+		return errors.Errorf("socket.io servers cannot be mapped to the WebSocket APIs of gateway %s: use a `ws` WebSocketServer instead", gw.Name)
+	}
+
+	servers := findWebSocketServers(f)
+	if len(servers) == 0 {
+		return nil
+	}
+	if len(servers) > 1 {
+		return errors.Errorf("only one WebSocket server may be created in the file of exposed app %s", gw.Name)
+	}
+	server := servers[0]
+
+	if unitType := p.Config.GetResourceType(unit); unitType != "lambda" {
+		return errors.Errorf("WebSocket server of gateway %s cannot be exposed: WebSocket APIs can only target lambda execution units, not %s", gw.Name, unitType)
+	}
+
+	wsGw := &types.WebSocketGateway{
+		Name:          gw.Name,
+		Path:          server.path,
+		ExecUnitName:  unit.Name,
+		HandledInFile: f.Path(),
+		Library:       webSocketLibraryWs,
+	}
+	if existing, ok := constructGraph.GetConstruct(wsGw.Id()).(*types.WebSocketGateway); ok {
+		if existing.ExecUnitName != wsGw.ExecUnitName {
+			return errors.Errorf(
+				"WebSocket server of gateway %s is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+				gw.Name, existing.ExecUnitName, wsGw.ExecUnitName,
+			)
+		}
+		wsGw = existing
+	} else {
+		constructGraph.AddConstruct(wsGw)
+	}
+	log.Infof("Found WebSocket server for gateway %s", gw.Name)
+	constructGraph.AddDependency(wsGw.Id(), unit.Id())
+	unit.EnvironmentVariables.Add(types.GenerateWebSocketEndpointEnvVar(wsGw))
+
+	var errs multierr.Error
+	errs.Append(p.runtime.AddWebSocketRuntimeFiles(unit))
+	errs.Append(rewriteWebSocketServer(f, server, wsGw))
+	return errs.ErrOrNil()
+}
+
+// findWebSocketServers returns the `ws` servers created in `f`, whether constructed through a named import
+// (`new WebSocketServer()`) or through the module (`new WebSocket.Server()`).
+func findWebSocketServers(f *types.SourceFile) (servers []webSocketServerResult) {
+	root := f.Tree().RootNode()
+	nextMatch := DoQuery(root, webSocketServer)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		ctor, newExpr := match["ctor"], match["new"]
+
+		exportName := ""
+		switch ctor.Type() {
+		case "identifier":
+			imp := FindImportForVar(root, ctor.Content())
+			if imp.Source != wsModule {
+				continue
+			}
+			exportName = imp.Name
+		case "member_expression":
+			obj := ctor.ChildByFieldName("object")
+			if obj.Type() != "identifier" || FindImportForVar(root, obj.Content()).Source != wsModule {
+				continue
+			}
+			exportName = ctor.ChildByFieldName("property").Content()
+		}
+		if _, ok := wsServerExports[exportName]; !ok {
+			continue
+		}
+
+		servers = append(servers, webSocketServerResult{
+			expression: newExpr,
+			path:       webSocketServerPath(newExpr.ChildByFieldName("arguments")),
+		})
+	}
+	return
+}
+
+// webSocketServerPath returns the `path` option of the server's options, or "/" if the server accepts connections on any path.
+func webSocketServerPath(args *sitter.Node) string {
+	if args == nil || args.NamedChildCount() == 0 {
+		return "/"
+	}
+	options := args.NamedChild(0)
+	if options.Type() != "object" {
+		return "/"
+	}
+	for i := 0; i < int(options.NamedChildCount()); i++ {
+		pair := options.NamedChild(i)
+		if pair.Type() != "pair" {
+			continue
+		}
+		key, value := pair.ChildByFieldName("key"), pair.ChildByFieldName("value")
+		if query.NodeContentEquals(key, "path") && value.Type() == "string" {
+			return StringLiteralContent(value)
+		}
+	}
+	return "/"
+}
+
+func rewriteWebSocketServer(f *types.SourceFile, server webSocketServerResult, gw *types.WebSocketGateway) error {
+	content := strings.Replace(
+		string(f.Program()),
+		server.expression.Content(),
+		fmt.Sprintf(`%sRuntime.server("%s", "%s")`, webSocketRTName, gw.Name, types.WebSocketEndpointEnvVarName(gw.Name)),
+		1,
+	)
+	if err := f.Reparse([]byte(content)); err != nil {
+		return errors.Wrap(err, "could not reparse WebSocket server transformation")
+	}
+	return EnsureRuntimeImportFile(webSocketRTName, webSocketRTName, f)
+}
@@ -0,0 +1,107 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSocket_Transform(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		unitType    string
+		want        *types.WebSocketGateway
+		wantContent string
+		wantErr     bool
+	}{
+		{
+			name: "named import",
+			source: `const { WebSocketServer } = require('ws');
+const wss = new WebSocketServer({ server, path: '/chat' });
+wss.on('connection', (ws) => ws.on('message', (data) => ws.send(data)));`,
+			unitType:    "lambda",
+			want:        &types.WebSocketGateway{Name: "gw", Path: "/chat", ExecUnitName: "main", HandledInFile: "index.js", Library: "ws"},
+			wantContent: `const wss = websocketRuntime.server("gw", "GW_WEBSOCKET_ENDPOINT");`,
+		},
+		{
+			name: "module import",
+			source: `const WebSocket = require('ws');
+const wss = new WebSocket.Server({ server });`,
+			unitType:    "lambda",
+			want:        &types.WebSocketGateway{Name: "gw", Path: "/", ExecUnitName: "main", HandledInFile: "index.js", Library: "ws"},
+			wantContent: `const wss = websocketRuntime.server("gw", "GW_WEBSOCKET_ENDPOINT");`,
+		},
+		{
+			name: "client is not a server",
+			source: `const WebSocket = require('ws');
+const client = new WebSocket('wss://example.com');`,
+			unitType: "lambda",
+		},
+		{
+			name: "fargate unit is rejected",
+			source: `const { WebSocketServer } = require('ws');
+const wss = new WebSocketServer({ server });`,
+			unitType: "ecs",
+			wantErr:  true,
+		},
+		{
+			name: "socket.io server",
+			source: `const { Server } = require('socket.io');
+const io = new Server(server);`,
+			unitType: "lambda",
+			wantErr:  true,
+		},
+		{
+			name: "multiple servers",
+			source: `const { WebSocketServer } = require('ws');
+const a = new WebSocketServer({ noServer: true });
+const b = new WebSocketServer({ noServer: true });`,
+			unitType: "lambda",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := NewFile("index.js", strings.NewReader(tt.source))
+			if !assert.NoError(err) {
+				return
+			}
+			unit := &types.ExecutionUnit{Name: "main"}
+			unit.Add(f)
+			gw := &types.Gateway{Name: "gw", DefinedIn: "index.js"}
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+			graph.AddConstruct(gw)
+			graph.AddDependency(gw.Id(), unit.Id())
+
+			cfg := &config.Application{Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: tt.unitType}}}
+			err = WebSocket{Config: cfg, runtime: NoopRuntime{}}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			gateways := construct.GetConstructsOfType[*types.WebSocketGateway](graph)
+			if tt.want == nil {
+				assert.Empty(gateways)
+				assert.NotContains(string(f.Program()), webSocketRTName+"Runtime")
+				return
+			}
+			if !assert.Len(gateways, 1) {
+				return
+			}
+			assert.Equal(tt.want, gateways[0])
+			assert.NotNil(graph.GetDependency(gateways[0].Id(), unit.Id()))
+			assert.Contains(string(f.Program()), tt.wantContent)
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package javascript
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+// Workflow creates a `types.Workflow` for each exported function annotated with `@klotho::workflow`.
+//
+// The awaited calls of the orchestrator function to the functions of other execution units are the workflow's steps, in
+// the order they are evaluated. The steps must form a straight chain: each is passed either the workflow's input or the
+// result of the previous step, is not within a conditional, loop or callback, and the result of the last is returned.
+// When the workflow is deployed, the orchestrator function starts it and returns its result instead of running its body,
+// so that without a deployed workflow (for example in tests) the function still runs its steps in-process.
+type Workflow struct {
+	runtime Runtime
+}
+
+const workflowRTName = "workflow"
+
+func (p Workflow) Name() string { return "Workflow" }
+
+func (p Workflow) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	workflows := make(map[string]*types.Workflow)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			js, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(js); owner != "" && owner != unit.Name {
+				continue
+			}
+			var rewrites []workflowRewrite
+			for _, annot := range js.Annotations() {
+				if annot.Capability.Name != annotation.WorkflowCapability {
+					continue
+				}
+				workflow, err := newWorkflow(input.Files(), js, annot, unit)
+				if err != nil {
+					errs.Append(types.NewCompilerError(js, annot, err))
+					continue
+				}
+				if existing, ok := workflows[workflow.Name]; ok {
+					if existing.ExecUnitName != unit.Name {
+						errs.Append(types.NewCompilerError(js, annot, errors.Errorf(
+							"workflow is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+							existing.ExecUnitName, unit.Name,
+						)))
+					}
+					continue
+				}
+				var stepErrs multierr.Error
+				for _, step := range workflow.Steps {
+					stepUnit := &types.ExecutionUnit{Name: step.ExecUnitName}
+					if constructGraph.GetConstruct(stepUnit.Id()) == nil {
+						stepErrs.Append(errors.Errorf("execution unit '%s' of workflow step %s#%s does not exist", step.ExecUnitName, step.ModuleName, step.FunctionName))
+					}
+				}
+				if err := stepErrs.ErrOrNil(); err != nil {
+					errs.Append(types.NewCompilerError(js, annot, err))
+					continue
+				}
+
+				workflows[workflow.Name] = workflow
+				constructGraph.AddConstruct(workflow)
+				constructGraph.AddDependency(unit.Id(), workflow.Id())
+				for _, step := range workflow.Steps {
+					constructGraph.AddDependency(workflow.Id(), (&types.ExecutionUnit{Name: step.ExecUnitName}).Id())
+				}
+				unit.EnvironmentVariables.Add(types.GenerateWorkflowArnEnvVar(workflow))
+				rewrites = append(rewrites, workflowRewrite{annot: annot, workflow: workflow})
+			}
+			if len(rewrites) == 0 {
+				continue
+			}
+			if err := rewriteWorkflows(js, rewrites); err != nil {
+				errs.Append(errors.Wrapf(err, "failed to handle workflow in unit %s", unit.Name))
+				continue
+			}
+			errs.Append(p.runtime.AddWorkflowRuntimeFiles(unit))
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func newWorkflow(files map[string]io.File, f *types.SourceFile, annot *types.Annotation, unit *types.ExecutionUnit) (*types.Workflow, error) {
+	if annot.Capability.ID == "" {
+		return nil, errors.New("'id' is required")
+	}
+	name := annotatedFunctionName(annot.Node)
+	if name == "" {
+		return nil, errors.New("@klotho::workflow must annotate a function")
+	}
+	if !isExportedFunction(f, annot.Node, name) {
+		return nil, errors.Errorf("workflow function '%s' must be exported", name)
+	}
+	workflow := &types.Workflow{
+		Name:         annot.Capability.ID,
+		ExecUnitName: unit.Name,
+		ModuleName:   FileToModule(f.Path()),
+		FunctionName: name,
+	}
+
+	function := annotatedFunction(annot.Node)
+	inputName := workflowInputName(function)
+	imports := FindImportsInFile(f)
+	// previous is the await expression of the previous step, whose result is passed to the next step
+	var previous *sitter.Node
+	for _, call := range calls(function) {
+		step, err := workflowStep(files, f, imports, call)
+		if err != nil {
+			return nil, err
+		}
+		if step == nil {
+			zap.S().Debugf("call '%s' of workflow %s is not to another execution unit, so is not a step", call.Content(), workflow.Name)
+			continue
+		}
+		if step.ExecUnitName == unit.Name {
+			return nil, errors.Errorf("step '%s' of workflow '%s' must be in another execution unit", call.Content(), workflow.Name)
+		}
+		awaited := call.Parent()
+		if awaited == nil || awaited.Type() != "await_expression" {
+			return nil, errors.Errorf("step '%s' of workflow '%s' must be awaited", call.Content(), workflow.Name)
+		}
+		if branch := enclosingBranch(awaited, function); branch != nil {
+			return nil, errors.Errorf("step '%s' of workflow '%s' must not be within a conditional, loop, try statement or callback (%s): the steps of a workflow run one after another", call.Content(), workflow.Name, branch.Type())
+		}
+		takesInput, err := stepTakesWorkflowInput(call, inputName, previous)
+		if err != nil {
+			return nil, errors.Wrapf(err, "step '%s' of workflow '%s'", call.Content(), workflow.Name)
+		}
+		step.TakesWorkflowInput = takesInput
+		workflow.Steps = append(workflow.Steps, *step)
+		previous = awaited
+	}
+	if len(workflow.Steps) == 0 {
+		return nil, errors.Errorf("workflow '%s' must await at least one function of another execution unit", workflow.Name)
+	}
+	if !isReturned(previous, function) {
+		return nil, errors.Errorf("workflow '%s' must return the result of its last step '%s'", workflow.Name, previous.Content())
+	}
+	return workflow, nil
+}
+
+// stepTakesWorkflowInput returns whether the step `call` is passed the input of the workflow (named inputName), or
+// otherwise the result of the `previous` step. It errors if the step is passed anything else, since the deployed
+// workflow can only pass one of those.
+func stepTakesWorkflowInput(call *sitter.Node, inputName string, previous *sitter.Node) (bool, error) {
+	args := call.ChildByFieldName("arguments")
+	if args == nil || args.Type() != "arguments" || args.NamedChildCount() != 1 {
+		return false, errors.New("must be passed one argument: the workflow's input or the result of the previous step")
+	}
+	arg := args.NamedChild(0)
+	if previous != nil && isResultOf(arg, previous) {
+		return false, nil
+	}
+	if inputName != "" && arg.Type() == "identifier" && arg.Content() == inputName {
+		return true, nil
+	}
+	return false, errors.Errorf("is passed '%s' instead of the workflow's input or the result of the previous step", arg.Content())
+}
+
+// isResultOf returns whether `n` is the result of the await expression `awaited`, either as the expression itself
+// (`await ship(await charge(order))`) or as the variable it is assigned to (`const charged = await charge(order)`).
+func isResultOf(n *sitter.Node, awaited *sitter.Node) bool {
+	if n.Equal(awaited) {
+		return true
+	}
+	name := resultVariable(awaited)
+	return name != "" && n.Type() == "identifier" && n.Content() == name
+}
+
+// resultVariable returns the name of the variable that the await expression `awaited` is assigned to, or "" if it is not.
+func resultVariable(awaited *sitter.Node) string {
+	parent := awaited.Parent()
+	var variable *sitter.Node
+	switch {
+	case parent == nil:
+		return ""
+	case parent.Type() == "variable_declarator" && awaited.Equal(parent.ChildByFieldName("value")):
+		variable = parent.ChildByFieldName("name")
+	case parent.Type() == "assignment_expression" && awaited.Equal(parent.ChildByFieldName("right")):
+		variable = parent.ChildByFieldName("left")
+	}
+	if variable == nil || variable.Type() != "identifier" {
+		return ""
+	}
+	return variable.Content()
+}
+
+// isReturned returns whether the result of the await expression `awaited` is returned by `function`.
+func isReturned(awaited *sitter.Node, function *sitter.Node) bool {
+	if awaited.Equal(function.ChildByFieldName("body")) {
+		return true
+	}
+	if parent := awaited.Parent(); parent != nil && parent.Type() == "return_statement" {
+		return true
+	}
+	name := resultVariable(awaited)
+	if name == "" {
+		return false
+	}
+	returned := false
+	var visit func(n *sitter.Node)
+	visit = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if child.Type() == "return_statement" {
+				value := child.NamedChild(0)
+				returned = returned || (value != nil && value.Type() == "identifier" && value.Content() == name)
+			} else if !isFunctionNode(child) {
+				visit(child)
+			}
+		}
+	}
+	visit(function.ChildByFieldName("body"))
+	return returned
+}
+
+// enclosingBranch returns the innermost node between `n` and the orchestrator `function` which may not run `n`, or
+// run it more than once: a conditional, a loop, a try statement or a nested function (such as a callback).
+func enclosingBranch(n *sitter.Node, function *sitter.Node) *sitter.Node {
+	for parent := n.Parent(); parent != nil && !parent.Equal(function); parent = parent.Parent() {
+		switch parent.Type() {
+		case "if_statement", "switch_statement", "for_statement", "for_in_statement", "while_statement", "do_statement",
+			"try_statement", "ternary_expression", "function_declaration", "generator_function_declaration", "method_definition":
+			return parent
+		case "binary_expression":
+			if operator := parent.ChildByFieldName("operator"); operator != nil {
+				switch operator.Type() {
+				case "&&", "||", "??":
+					return parent
+				}
+			}
+		}
+		if isFunctionNode(parent) {
+			return parent
+		}
+	}
+	return nil
+}
+
+// workflowStep returns the step which the awaited `call` is to, or nil if it is not to a function of an execution unit.
+// Calls are to either an imported function (`await charge(order)`) or a function of an imported module (`await billing.charge(order)`).
+func workflowStep(files map[string]io.File, f *types.SourceFile, imports FileImports, call *sitter.Node) (*types.WorkflowStep, error) {
+	var imp *Import
+	var functionName string
+	callee := call.ChildByFieldName("function")
+	switch callee.Type() {
+	case "identifier":
+		for _, i := range imports.AsSlice() {
+			if i.ImportedAs() == callee.Content() && i.Type != ImportTypeNamespace && i.Type != ImportTypeSideEffect {
+				i := i
+				imp, functionName = &i, i.Name
+			}
+		}
+	case "member_expression":
+		object := callee.ChildByFieldName("object")
+		for _, i := range imports.AsSlice() {
+			if i.ImportedAs() == object.Content() && i.Type == ImportTypeNamespace {
+				i := i
+				imp, functionName = &i, callee.ChildByFieldName("property").Content()
+			}
+		}
+	}
+	if imp == nil || !IsRelativeImport(*imp) {
+		return nil, nil
+	}
+	if imp.Type == ImportTypeDefault {
+		functionName = "default"
+	}
+
+	importFile, err := FindFileForImport(files, f.Path(), imp.Source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find file for module %s", imp.Source)
+	}
+	js, ok := Language.ID.CastFile(importFile)
+	if !ok {
+		return nil, nil
+	}
+	unitName := types.FileExecUnitName(js)
+	if unitName == "" {
+		return nil, nil
+	}
+	return &types.WorkflowStep{
+		ExecUnitName: unitName,
+		ModuleName:   FileToModule(js.Path()),
+		FunctionName: functionName,
+	}, nil
+}
+
+// annotatedFunction returns the function declared or assigned at the annotated node, for the same forms as `annotatedFunctionName`.
+func annotatedFunction(n *sitter.Node) *sitter.Node {
+	if n == nil {
+		return nil
+	}
+	switch n.Type() {
+	case "export_statement":
+		return annotatedFunction(n.ChildByFieldName("declaration"))
+
+	case "function_declaration", "generator_function_declaration":
+		return n
+
+	case "lexical_declaration", "variable_declaration":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			declarator := n.NamedChild(i)
+			if declarator.Type() == "variable_declarator" && isFunctionNode(declarator.ChildByFieldName("value")) {
+				return declarator.ChildByFieldName("value")
+			}
+		}
+
+	case "expression_statement":
+		assignment := n.NamedChild(0)
+		if assignment != nil && assignment.Type() == "assignment_expression" && isFunctionNode(assignment.ChildByFieldName("right")) {
+			return assignment.ChildByFieldName("right")
+		}
+	}
+	return nil
+}
+
+// calls returns the calls within the function `n` in the order they are evaluated, so a call comes after the calls in
+// its arguments.
+func calls(n *sitter.Node) []*sitter.Node {
+	var calls []*sitter.Node
+	var visit func(n *sitter.Node)
+	visit = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			visit(child)
+			if child.Type() == "call_expression" {
+				calls = append(calls, child)
+			}
+		}
+	}
+	if n != nil {
+		visit(n)
+	}
+	return calls
+}
+
+// workflowInputName returns the name of the first parameter of the orchestrator `function`, which is the input of the
+// workflow, or "" if it has none.
+func workflowInputName(function *sitter.Node) string {
+	var param *sitter.Node
+	if params := function.ChildByFieldName("parameters"); params != nil && params.NamedChildCount() > 0 {
+		param = params.NamedChild(0)
+	} else {
+		param = function.ChildByFieldName("parameter")
+	}
+	if param != nil && param.Type() == "assignment_pattern" {
+		param = param.ChildByFieldName("left")
+	}
+	if param == nil {
+		return ""
+	}
+	return param.Content()
+}
+
+type workflowRewrite struct {
+	annot    *types.Annotation
+	workflow *types.Workflow
+}
+
+// rewriteWorkflows makes each orchestrator function start its workflow and return the result when the workflow is deployed.
+// The function's first parameter is the input of the workflow.
+func rewriteWorkflows(f *types.SourceFile, rewrites []workflowRewrite) error {
+	type insertion struct {
+		offset uint32
+		text   string
+	}
+	var insertions []insertion
+	for _, rewrite := range rewrites {
+		function := annotatedFunction(rewrite.annot.Node)
+		body := function.ChildByFieldName("body")
+		if body == nil || body.Type() != "statement_block" {
+			return errors.Errorf("workflow function '%s' must have a block body", rewrite.workflow.FunctionName)
+		}
+		input := workflowInputName(function)
+		if input == "" {
+			input = "undefined"
+		}
+		insertions = append(insertions, insertion{
+			offset: body.StartByte() + 1,
+			text: fmt.Sprintf(
+				"\n"+`if (%[1]sRuntime.deployed("%[2]s")) return await %[1]sRuntime.start("%[2]s", %[3]s);`,
+				workflowRTName, types.WorkflowArnEnvVarName(rewrite.workflow.Name), input,
+			),
+		})
+	}
+	// insert from the end of the file so that the offsets of the earlier insertions stay valid
+	sort.Slice(insertions, func(i, j int) bool { return insertions[i].offset > insertions[j].offset })
+	content := f.Program()
+	for _, ins := range insertions {
+		next := make([]byte, 0, len(content)+len(ins.text))
+		next = append(next, content[:ins.offset]...)
+		next = append(next, ins.text...)
+		next = append(next, content[ins.offset:]...)
+		content = next
+	}
+	if err := f.Reparse(content); err != nil {
+		return err
+	}
+	return EnsureRuntimeImportFile(workflowRTName, workflowRTName, f)
+}
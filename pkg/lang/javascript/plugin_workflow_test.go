@@ -0,0 +1,265 @@
+package javascript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflow_Transform(t *testing.T) {
+	billing := `// @klotho::execution_unit {
+//   id = "billing"
+// }
+exports.charge = async (order) => order;`
+	shipping := `// @klotho::execution_unit {
+//   id = "shipping"
+// }
+export async function ship(order) { return order; }`
+
+	tests := []struct {
+		name        string
+		source      string
+		want        *types.Workflow
+		wantRewrite string
+		wantErr     string
+	}{
+		{
+			name: "imported functions",
+			source: `import { charge } from './billing';
+import { ship } from './shipping';
+
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    const charged = await charge(order);
+    console.log('charged', charged);
+    return await ship(charged);
+}`,
+			want: &types.Workflow{
+				Name:         "checkout",
+				ExecUnitName: "main",
+				ModuleName:   "src/checkout",
+				FunctionName: "checkout",
+				Steps: []types.WorkflowStep{
+					{ExecUnitName: "billing", ModuleName: "src/billing", FunctionName: "charge", TakesWorkflowInput: true},
+					{ExecUnitName: "shipping", ModuleName: "src/shipping", FunctionName: "ship"},
+				},
+			},
+			wantRewrite: `if (workflowRuntime.deployed("CHECKOUT_WORKFLOW_ARN")) return await workflowRuntime.start("CHECKOUT_WORKFLOW_ARN", order);`,
+		},
+		{
+			name: "functions of required modules",
+			source: `const billing = require('./billing');
+const shipping = require('./shipping');
+
+// @klotho::workflow {
+//   id = "checkout"
+// }
+exports.checkout = async (order = {}) => {
+    return await shipping.ship(await billing.charge(order));
+};`,
+			want: &types.Workflow{
+				Name:         "checkout",
+				ExecUnitName: "main",
+				ModuleName:   "src/checkout",
+				FunctionName: "checkout",
+				Steps: []types.WorkflowStep{
+					{ExecUnitName: "billing", ModuleName: "src/billing", FunctionName: "charge", TakesWorkflowInput: true},
+					{ExecUnitName: "shipping", ModuleName: "src/shipping", FunctionName: "ship"},
+				},
+			},
+			wantRewrite: `if (workflowRuntime.deployed("CHECKOUT_WORKFLOW_ARN")) return await workflowRuntime.start("CHECKOUT_WORKFLOW_ARN", order);`,
+		},
+		{
+			name: "step passed the workflow input",
+			source: `import { charge } from './billing';
+import { ship } from './shipping';
+
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    let result;
+    result = await charge(order);
+    result = await ship(order);
+    return result;
+}`,
+			want: &types.Workflow{
+				Name:         "checkout",
+				ExecUnitName: "main",
+				ModuleName:   "src/checkout",
+				FunctionName: "checkout",
+				Steps: []types.WorkflowStep{
+					{ExecUnitName: "billing", ModuleName: "src/billing", FunctionName: "charge", TakesWorkflowInput: true},
+					{ExecUnitName: "shipping", ModuleName: "src/shipping", FunctionName: "ship", TakesWorkflowInput: true},
+				},
+			},
+			wantRewrite: `if (workflowRuntime.deployed("CHECKOUT_WORKFLOW_ARN")) return await workflowRuntime.start("CHECKOUT_WORKFLOW_ARN", order);`,
+		},
+		{
+			name: "step in a conditional",
+			source: `import { charge } from './billing';
+import { ship } from './shipping';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    const charged = await charge(order);
+    if (charged.paid) {
+        return await ship(charged);
+    }
+    return charged;
+}`,
+			wantErr: "must not be within a conditional, loop, try statement or callback (if_statement)",
+		},
+		{
+			name: "step in a loop",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    for (const item of order.items) {
+        await charge(item);
+    }
+    return order;
+}`,
+			wantErr: "must not be within a conditional, loop, try statement or callback (for_in_statement)",
+		},
+		{
+			name: "step in a callback",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    return await Promise.all(order.items.map(async (item) => await charge(item)));
+}`,
+			wantErr: "must not be within a conditional, loop, try statement or callback (arrow_function)",
+		},
+		{
+			name: "step is not awaited",
+			source: `import { charge } from './billing';
+import { ship } from './shipping';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    return await Promise.all([charge(order), ship(order)]);
+}`,
+			wantErr: "must be awaited",
+		},
+		{
+			name: "step passed a result which is not the previous one",
+			source: `import { charge } from './billing';
+import { ship } from './shipping';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    const charged = await charge(order);
+    const shipped = await ship(charged);
+    return await ship(charged);
+}`,
+			wantErr: "is passed 'charged' instead of the workflow's input or the result of the previous step",
+		},
+		{
+			name: "step passed another value",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    return await charge({ ...order, paid: true });
+}`,
+			wantErr: "is passed '{ ...order, paid: true }'",
+		},
+		{
+			name: "last step result is not returned",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    await charge(order);
+    return order;
+}`,
+			wantErr: "must return the result of its last step",
+		},
+		{
+			name: "no steps",
+			source: `// @klotho::workflow {
+//   id = "checkout"
+// }
+export async function checkout(order) {
+    return await Promise.resolve(order);
+}`,
+			wantErr: "workflow 'checkout' must await at least one function",
+		},
+		{
+			name: "function is not exported",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+async function checkout(order) {
+    return await charge(order);
+}`,
+			wantErr: "workflow function 'checkout' must be exported",
+		},
+		{
+			name: "expression body",
+			source: `import { charge } from './billing';
+// @klotho::workflow {
+//   id = "checkout"
+// }
+export const checkout = async (order) => await charge(order);`,
+			wantErr: "workflow function 'checkout' must have a block body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			input := &types.InputFiles{}
+			graph := construct.NewConstructGraph()
+			main := &types.ExecutionUnit{Name: "main"}
+			for path, source := range map[string]string{"src/checkout.js": tt.source, "src/billing.js": billing, "src/shipping.js": shipping} {
+				f, err := NewFile(path, strings.NewReader(source))
+				if !assert.NoError(err) {
+					return
+				}
+				input.Add(f)
+				main.Add(f)
+			}
+			graph.AddConstruct(main)
+			graph.AddConstruct(&types.ExecutionUnit{Name: "billing"})
+			graph.AddConstruct(&types.ExecutionUnit{Name: "shipping"})
+
+			err := Workflow{runtime: NoopRuntime{}}.Transform(input, &types.FileDependencies{}, graph)
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			workflows := construct.GetConstructsOfType[*types.Workflow](graph)
+			if !assert.Len(workflows, 1) {
+				return
+			}
+			workflow := workflows[0]
+			assert.Equal(tt.want, workflow)
+			assert.NotNil(graph.GetDependency(main.Id(), workflow.Id()))
+			for _, step := range tt.want.Steps {
+				assert.NotNil(graph.GetDependency(workflow.Id(), (&types.ExecutionUnit{Name: step.ExecUnitName}).Id()))
+			}
+			assert.Contains(string(main.Get("src/checkout.js").(*types.SourceFile).Program()), tt.wantRewrite)
+			assert.Equal("CHECKOUT_WORKFLOW_ARN", main.EnvironmentVariables[0].Name)
+		})
+	}
+}
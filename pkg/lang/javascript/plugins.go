@@ -19,9 +19,16 @@ func NewJavascriptPlugins(cfg *config.Application, runtime Runtime) *JavascriptP
 		Plugins: []compiler.AnalysisAndTransformationPlugin{
 			ExpressHandler{Config: cfg},
 			NestJsHandler{Config: cfg},
+			FastifyHandler{Config: cfg},
+			KoaHandler{Config: cfg},
+			WebSocket{Config: cfg, runtime: runtime},
+			Queue{Config: cfg, runtime: runtime},
+			Workflow{runtime: runtime},
+			FsEvent{Config: cfg},
 			AddExecRuntimeFiles{runtime: runtime},
 			Persist{runtime: runtime},
 			Pubsub{runtime: runtime},
+			Schedule{},
 		},
 	}
 
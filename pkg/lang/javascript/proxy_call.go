@@ -6,9 +6,25 @@ import (
 )
 
 func SpecificExportQuery(n *sitter.Node, wantName string) *sitter.Node {
-	nextMatch := DoQuery(n, proxyExport)
-
 	var last *sitter.Node
+	for _, export := range FindESExports(n) {
+		if wantName != "" && export.Name != wantName {
+			continue
+		}
+		switch {
+		case export.Declarator == nil:
+			last = export.Local
+		case export.Declarator.Type() == "variable_declarator":
+			last = export.Declarator.ChildByFieldName("value")
+		default:
+			last = export.Declarator
+		}
+	}
+	if last != nil {
+		return last
+	}
+
+	nextMatch := DoQuery(n, proxyExport)
 	for {
 		match, found := nextMatch()
 		if !found || match == nil {
@@ -46,6 +46,18 @@ func TestSpecificExportQuery(t *testing.T) {
 			queryName:   "func",
 			matchString: "func",
 		},
+		{
+			name:        "match ES export",
+			source:      "export const a = 2;",
+			queryName:   "a",
+			matchString: "2",
+		},
+		{
+			name:        "match ES function export",
+			source:      "export async function a() {}",
+			queryName:   "a",
+			matchString: "async function a() {}",
+		},
 		{
 			name:        "match object",
 			source:      "exports.a = {b: 2};",
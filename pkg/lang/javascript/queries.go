@@ -1,8 +1,10 @@
 package javascript
 
 import (
+	"context"
 	_ "embed"
 
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/query"
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -44,6 +46,21 @@ var (
 	//go:embed queries/expose/nestJs/routes.scm
 	nestJsRoute string
 
+	//go:embed queries/expose/fastify/fastify.scm
+	fastifyInstance string
+
+	//go:embed queries/expose/fastify/plugin.scm
+	fastifyPlugin string
+
+	//go:embed queries/expose/fastify/export.scm
+	fastifyExport string
+
+	//go:embed queries/expose/koa/instance.scm
+	koaInstance string
+
+	//go:embed queries/expose/websocket/server.scm
+	webSocketServer string
+
 	//go:embed queries/proxy/usage.scm
 	proxyUsage string
 
@@ -62,6 +79,9 @@ var (
 	//go:embed queries/modules/default.scm
 	modulesDefault string
 
+	//go:embed queries/modules/es_export.scm
+	modulesESExport string
+
 	//go:embed queries/pubsub/publisher.scm
 	pubsubPublisher string
 
@@ -81,7 +101,36 @@ var (
 	functionInvocation string
 )
 
-// DoQuery is a thin wrapper around `query.Exec` to use javascript as the Language.
+// queryLanguages maps the symbol of each grammar's root `program` node to the language used to run queries
+// against trees produced by that grammar.
+var queryLanguages = map[sitter.Symbol]types.SourceLanguage{
+	programSymbol(Language):           Language,
+	programSymbol(TypeScriptLanguage): TypeScriptLanguage,
+	programSymbol(TsxLanguage):        TsxLanguage,
+}
+
+func programSymbol(lang types.SourceLanguage) sitter.Symbol {
+	parser := sitter.NewParser()
+	parser.SetLanguage(lang.Sitter)
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte{})
+	if err != nil {
+		panic(err)
+	}
+	return tree.RootNode().Symbol()
+}
+
+// DoQuery is a thin wrapper around `query.Exec` to use javascript (or typescript, for nodes parsed from
+// TypeScript sources) as the Language.
 func DoQuery(c *sitter.Node, q string) query.NextMatchFunc {
-	return query.Exec(Language, c, q)
+	lang := Language
+	if c != nil {
+		root := c
+		for root.Parent() != nil {
+			root = root.Parent()
+		}
+		if rootLang, ok := queryLanguages[root.Symbol()]; ok {
+			lang = rootLang
+		}
+	}
+	return query.Exec(lang, c, q)
 }
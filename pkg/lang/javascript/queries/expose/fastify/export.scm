@@ -0,0 +1,14 @@
+[
+    (assignment_expression ;; module.exports = routes; exports.routes = routes; module.exports.routes = async (fastify) => {}
+        left: (member_expression) @export
+        right: (_) @value
+    )
+    (export_statement ;; export default routes; export default async function (fastify) {}
+        value: (_) @value
+    ) @export
+    (export_statement ;; export async function routes(fastify) {}
+        declaration: (function_declaration
+            name: (identifier) @name
+        ) @value
+    ) @export
+]
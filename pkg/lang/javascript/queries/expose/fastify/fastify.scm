@@ -0,0 +1,20 @@
+[
+    (variable_declarator ;; const app = fastify(); const app = require('fastify')({ logger: true })
+        name: (identifier) @var
+        value: (call_expression
+            function: (_) @fastify
+        )
+    )
+    (assignment_expression ;; app = fastify(); exports.app = fastify()
+        left: [
+            (identifier)
+            (member_expression
+                object: (identifier) @exports
+                property: (property_identifier)
+            )
+        ] @var
+        right: (call_expression
+            function: (_) @fastify
+        )
+    )
+]
@@ -0,0 +1,12 @@
+[
+    (function_declaration ;; async function routes(fastify, opts) {}
+        name: (identifier) @name
+    ) @function
+    (variable_declarator ;; const routes = async (fastify, opts) => {}
+        name: (identifier) @name
+        value: [
+            (function)
+            (arrow_function)
+        ] @function
+    )
+]
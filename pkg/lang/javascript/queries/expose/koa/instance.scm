@@ -0,0 +1,20 @@
+[
+    (variable_declarator ;; const app = new Koa(); const router = new Router({ prefix: '/users' })
+        name: (identifier) @var
+        value: (new_expression
+            constructor: (_) @ctor
+        ) @new
+    )
+    (assignment_expression ;; app = new Koa(); exports.router = new Router()
+        left: [
+            (identifier)
+            (member_expression
+                object: (identifier) @exports
+                property: (property_identifier)
+            )
+        ] @var
+        right: (new_expression
+            constructor: (_) @ctor
+        ) @new
+    )
+]
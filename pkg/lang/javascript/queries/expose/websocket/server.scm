@@ -0,0 +1,3 @@
+(new_expression ;; new WebSocketServer({ server }), new WebSocket.Server({ port: 8080 })
+    constructor: (_) @ctor
+) @new
@@ -0,0 +1,26 @@
+;;; export [default] <const|let|var> <name> = <value>
+;;; export [default] <function|class> <name> ...
+(export_statement
+  declaration: [
+    (lexical_declaration (variable_declarator name: (identifier) @name) @declarator)
+    (variable_declaration (variable_declarator name: (identifier) @name) @declarator)
+    (function_declaration name: (_) @name) @declarator
+    (generator_function_declaration name: (_) @name) @declarator
+    (class_declaration name: (_) @name) @declarator
+    ]
+  ) @export
+
+;;; export default <value>
+(export_statement
+  value: (_) @value
+  ) @export
+
+;;; export { <local> [as <name>], ... } [from <"source">]
+(export_statement
+  (export_clause
+    (export_specifier
+      name: (_) @local
+      alias: (_) ? @name
+      )
+    )
+  ) @export
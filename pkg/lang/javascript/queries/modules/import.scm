@@ -91,3 +91,21 @@
     ) ?
   (string) @source
   ) @es.importStatement
+
+;;; ES re-export: export { <name> [as <alias>], ... } from <"source">
+(export_statement
+  (export_clause (export_specifier name: (_) @export alias: (_) ? @alias))
+  source: (string) @source
+  ) @es.reexportStatement
+
+;;; ES re-export of all names: export * from <"source">
+(export_statement
+  "*"
+  source: (string) @source
+  ) @es.reexportStatement
+
+;;; ES dynamic import: import(<"source">)
+(call_expression
+  function: (import)
+  arguments: (arguments . (string) @source .)
+  ) @es.dynamicImport
@@ -51,6 +51,9 @@ type Runtime interface {
 	AddRedisNodeRuntimeFiles(unit *types.ExecutionUnit) error
 	AddRedisClusterRuntimeFiles(unit *types.ExecutionUnit) error
 	AddPubsubRuntimeFiles(unit *types.ExecutionUnit) error
+	AddQueueRuntimeFiles(unit *types.ExecutionUnit) error
+	AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error
+	AddWorkflowRuntimeFiles(unit *types.ExecutionUnit) error
 	AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType string) error
 	AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error
 }
@@ -85,7 +88,7 @@ func AddRuntimeFile(unit *types.ExecutionUnit, templateData any, path string, co
 			pkg.Content.Merge(runtimePkg.Content)
 		}
 
-	case filepath.Ext(path) == ".js":
+	case filepath.Ext(path) == ".js" || IsTypeScriptFile(path):
 		path = filepath.Join("klotho_runtime", path)
 		f, err := NewFile(path, bytes.NewReader(content))
 		if err != nil {
@@ -136,7 +139,8 @@ func EnsureRuntimeImportFile(runtimePath string, varPrefix string, file *types.S
 	buf := new(bytes.Buffer)
 
 	rtimp := RuntimeImport{
-		VarName: varPrefix,
+		VarName:  varPrefix,
+		ESModule: IsESModule(file),
 	}
 	rtimp.FilePath, err = RuntimePath(file.Path(), runtimePath)
 	if err != nil {
@@ -68,6 +68,9 @@ func (NoopRuntime) AddOrmRuntimeFiles(unit *types.ExecutionUnit) error
 func (NoopRuntime) AddRedisNodeRuntimeFiles(unit *types.ExecutionUnit) error    { return nil }
 func (NoopRuntime) AddRedisClusterRuntimeFiles(unit *types.ExecutionUnit) error { return nil }
 func (NoopRuntime) AddPubsubRuntimeFiles(unit *types.ExecutionUnit) error       { return nil }
+func (NoopRuntime) AddQueueRuntimeFiles(unit *types.ExecutionUnit) error        { return nil }
+func (NoopRuntime) AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error    { return nil }
+func (NoopRuntime) AddWorkflowRuntimeFiles(unit *types.ExecutionUnit) error     { return nil }
 func (NoopRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType string) error {
 	return nil
 }
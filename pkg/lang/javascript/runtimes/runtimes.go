@@ -6,12 +6,27 @@ import (
 	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/lang/javascript"
 	"github.com/klothoplatform/klotho/pkg/lang/javascript/aws_runtime"
+	"github.com/klothoplatform/klotho/pkg/lang/javascript/azure_runtime"
+	"github.com/klothoplatform/klotho/pkg/lang/javascript/gcp_runtime"
 )
 
 func GetRuntime(cfg *config.Application) (javascript.Runtime, error) {
 	switch cfg.Provider {
-	case "gcp", "azure":
-		// TODO GCP and Azure is hacked to be the same as AWS so we can generate a topology diagram, but the compilation won't work.
+	case "gcp":
+		return &gcp_runtime.GcpRuntime{
+			Config: cfg,
+		}, nil
+	case "azure":
+		return &azure_runtime.AzureRuntime{
+			Config: cfg,
+		}, nil
+	case "local":
+		// The local provider runs units in containers, which use the same runtime as those of aws, pointed at local
+		// emulators of its services.
+		fallthrough
+	case "kubernetes":
+		// Units on an existing cluster run in the same containers as those of eks, and only use in-cluster services
+		// which the aws runtime's shims connect to as they would to aws' (such as postgres and redis).
 		fallthrough
 	case "aws":
 		return &aws_runtime.AwsRuntime{
@@ -16,6 +16,8 @@ var tmplPubsubProxy = templateutils.MustTemplate(templateFiles, "proxy_emitter.j
 type RuntimeImport struct {
 	VarName  string
 	FilePath string
+	// ESModule selects an `import` declaration instead of `require()`, for importing from ES modules.
+	ESModule bool
 }
 
 func NewRuntimeImport(ctx RuntimeImport, w io.Writer) error {
@@ -4,6 +4,7 @@ import (
 	"embed"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
@@ -12,12 +13,14 @@ import (
 	"github.com/klothoplatform/klotho/pkg/runtime"
 
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/aws"
 	kubernetes "github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+	"github.com/klothoplatform/klotho/pkg/provider/local"
 	"github.com/pkg/errors"
 )
 
-//go:generate ./compile_template.sh dispatcher_fargate dispatcher_lambda fs secret
+//go:generate ./compile_template.sh dispatcher_fargate dispatcher_lambda fs secret tracing proxy_eks proxy_fargate proxy_local proxy_lambda
 
 //go:embed Fargate_Dockerfile.tmpl
 var dockerfileFargate []byte
@@ -61,13 +64,34 @@ var secretRuntimeFiles embed.FS
 //go:embed persist_orm_requirements.txt
 var ormRequirements string
 
-//go:embed proxy_eks.py
+//go:embed queue_requirements.txt
+var queueRequirements string
+
+//go:embed queue.py
+var queueRuntimeFiles embed.FS
+
+//go:embed websocket_requirements.txt
+var webSocketRequirements string
+
+//go:embed websocket.py
+var webSocketRuntimeFiles embed.FS
+
+//go:embed tracing_requirements.txt
+var tracingRequirements string
+
+//go:embed tracing.py.tmpl
+var tracingRuntimeFiles embed.FS
+
+//go:embed proxy_eks.py.tmpl
 var proxyEksContents string
 
-//go:embed proxy_fargate.py
+//go:embed proxy_fargate.py.tmpl
 var proxyFargateContents string
 
-//go:embed proxy_lambda.py
+//go:embed proxy_local.py.tmpl
+var proxyLocalContents string
+
+//go:embed proxy_lambda.py.tmpl
 var proxyLambdaContents string
 
 type (
@@ -76,19 +100,50 @@ type (
 	}
 
 	TemplateData struct {
-		ExecUnitName    string
+		ExecUnitName string
+		// AppName is the name of the application, which the physical names of its resources are derived from
+		AppName         string
 		Expose          ExposeTemplateData
 		ProjectFilePath string
+		// QueueConsumers are the consumer functions in this unit of the queues that deliver their messages to it.
+		QueueConsumers []QueueConsumerTemplateData
+		// WebSocketHandlers are the functions in this unit which handle the connections of the WebSocket gateways that target it.
+		WebSocketHandlers []WebSocketHandlerTemplateData
+		// FsEventSubscribers are the functions in this unit subscribed to the events of persisted fs.
+		FsEventSubscribers []FsEventSubscriberTemplateData
+		// Tracing is true when the runtime propagates the trace context of the unit's calls with OpenTelemetry.
+		Tracing bool
 	}
 
 	ExposeTemplateData struct {
 		ExportedAppVar string
 		AppModule      string
 	}
+
+	WebSocketHandlerTemplateData struct {
+		ModuleName   string
+		FunctionName string
+	}
+
+	FsEventSubscriberTemplateData struct {
+		// SubscriptionId is the id of the subscription, which the fs events delivered to it carry
+		SubscriptionId string
+		ModuleName     string
+		FunctionName   string
+	}
+
+	QueueConsumerTemplateData struct {
+		QueueId string
+		// UrlEnvVar is the environment variable which holds the queue's url, used by units which poll the queue
+		UrlEnvVar    string
+		BatchSize    int
+		ModuleName   string
+		FunctionName string
+	}
 )
 
 func (r *AwsRuntime) GetAppName() string {
-	return r.Cfg.AppName
+	return r.Cfg.QualifiedAppName()
 }
 
 func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
@@ -107,7 +162,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		if err != nil {
 			return err
 		}
-	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner, aws.Ec2Instance:
+	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner, aws.Ec2Instance, local.Container:
 		dockerFile = dockerfileFargate
 		dispatcher = dispatcherFargate
 		requirements = execRequirementsFargate
@@ -115,8 +170,13 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
 	}
 
-	templateData := TemplateData{
-		ExecUnitName: unit.Name,
+	templateData := r.templateData(unit)
+	if templateData.Tracing {
+		python.AddRequirements(unit, tracingRequirements)
+		err := r.AddRuntimeFiles(unit, tracingRuntimeFiles)
+		if err != nil {
+			return err
+		}
 	}
 
 	var err error
@@ -133,6 +193,10 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 		}
 	}
 
+	templateData.QueueConsumers = getQueueConsumerTemplateData(unit, constructGraph)
+	templateData.WebSocketHandlers = getWebSocketHandlerTemplateData(unit, constructGraph)
+	templateData.FsEventSubscribers = getFsEventSubscriberTemplateData(unit, constructGraph)
+
 	reqTxtPath := ""
 	for path, f := range unit.Files() {
 		if filepath.Base(f.Path()) == "requirements.txt" {
@@ -197,6 +261,63 @@ func getExposeTemplateData(unit *types.ExecutionUnit, constructGraph *construct.
 	return exposeData, nil
 }
 
+// getQueueConsumerTemplateData returns the consumers within `unit` of its upstream queues, sorted by queue id.
+func getQueueConsumerTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []QueueConsumerTemplateData {
+	var consumers []QueueConsumerTemplateData
+	for _, res := range constructGraph.GetUpstreamConstructs(unit) {
+		queue, ok := res.(*types.Queue)
+		if !ok || queue.Consumer == nil || queue.Consumer.ExecUnitName != unit.Name {
+			continue
+		}
+		consumers = append(consumers, QueueConsumerTemplateData{
+			QueueId:      queue.Name,
+			UrlEnvVar:    types.QueueUrlEnvVarName(queue.Name),
+			BatchSize:    queue.BatchSize,
+			ModuleName:   queue.Consumer.ModuleName,
+			FunctionName: queue.Consumer.FunctionName,
+		})
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].QueueId < consumers[j].QueueId })
+	return consumers
+}
+
+// getFsEventSubscriberTemplateData returns the functions within `unit` subscribed to its upstream fs events, sorted by subscription id.
+func getFsEventSubscriberTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []FsEventSubscriberTemplateData {
+	var subscribers []FsEventSubscriberTemplateData
+	for _, res := range constructGraph.GetUpstreamConstructs(unit) {
+		event, ok := res.(*types.FsEvent)
+		if !ok {
+			continue
+		}
+		for _, sub := range event.Subscriptions {
+			if sub.ExecUnitName != unit.Name {
+				continue
+			}
+			subscribers = append(subscribers, FsEventSubscriberTemplateData{
+				SubscriptionId: sub.Id(),
+				ModuleName:     sub.ModuleName,
+				FunctionName:   sub.FunctionName,
+			})
+		}
+	}
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].SubscriptionId < subscribers[j].SubscriptionId })
+	return subscribers
+}
+
+// getWebSocketHandlerTemplateData returns the handlers within `unit` of its upstream WebSocket gateways, sorted by module.
+func getWebSocketHandlerTemplateData(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) []WebSocketHandlerTemplateData {
+	var handlers []WebSocketHandlerTemplateData
+	for _, res := range constructGraph.GetUpstreamConstructs(unit) {
+		gw, ok := res.(*types.WebSocketGateway)
+		if !ok || gw.ExecUnitName != unit.Name || gw.FunctionName == "" {
+			continue
+		}
+		handlers = append(handlers, WebSocketHandlerTemplateData{ModuleName: gw.ModuleName, FunctionName: gw.FunctionName})
+	}
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].ModuleName < handlers[j].ModuleName })
+	return handlers
+}
+
 func (r *AwsRuntime) AddExposeRuntimeFiles(unit *types.ExecutionUnit) error {
 	python.AddRequirements(unit, exposeRequirements)
 	return nil
@@ -218,6 +339,10 @@ func (r *AwsRuntime) GetSecretRuntimeImportClass(varName string) string {
 	return fmt.Sprintf("import klotho_runtime.secret as %s", varName)
 }
 
+func (r *AwsRuntime) GetProxyRuntimeImportClass(proxyType string, varName string) string {
+	return fmt.Sprintf("import klotho_runtime.%s_proxy as %s", proxyType, varName)
+}
+
 func (r *AwsRuntime) AddKvRuntimeFiles(unit *types.ExecutionUnit) error {
 	python.AddRequirements(unit, kvRequirements)
 	return r.AddRuntimeFiles(unit, kvRuntimeFiles)
@@ -243,6 +368,20 @@ func (r *AwsRuntime) AddSecretRuntimeFiles(unit *types.ExecutionUnit) error {
 	return r.AddRuntimeFiles(unit, secretRuntimeFiles)
 }
 
+func (r *AwsRuntime) AddQueueRuntimeFiles(unit *types.ExecutionUnit) error {
+	python.AddRequirements(unit, queueRequirements)
+	return r.AddRuntimeFiles(unit, queueRuntimeFiles)
+}
+
+func (r *AwsRuntime) GetQueueRuntimeImportClass(varName string) string {
+	return fmt.Sprintf("import klotho_runtime.queue as %s", varName)
+}
+
+func (r *AwsRuntime) AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error {
+	python.AddRequirements(unit, webSocketRequirements)
+	return r.AddRuntimeFiles(unit, webSocketRuntimeFiles)
+}
+
 func (r *AwsRuntime) AddOrmRuntimeFiles(unit *types.ExecutionUnit) error {
 	python.AddRequirements(unit, ormRequirements)
 	return nil
@@ -253,8 +392,14 @@ func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 	switch proxyType {
 	case kubernetes.DEPLOYMENT_TYPE:
 		fileContents = proxyEksContents
+		if r.Cfg.Provider == provider.KUBERNETES {
+			// Units on an existing cluster call each other through the services named after them, like local containers
+			fileContents = proxyLocalContents
+		}
 	case aws.Ecs:
 		fileContents = proxyFargateContents
+	case local.Container:
+		fileContents = proxyLocalContents
 	case aws.Lambda:
 		fileContents = proxyLambdaContents
 
@@ -268,7 +413,7 @@ func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 	default:
 		return errors.Errorf("unsupported execution unit type: '%s'", r.Cfg.GetResourceType(unit))
 	}
-	err := r.AddRuntimeFile(unit, proxyType+"_proxy.py", []byte(fileContents))
+	err := r.AddRuntimeFile(unit, proxyType+"_proxy.py.tmpl", []byte(fileContents))
 	if err != nil {
 		return err
 	}
@@ -276,17 +421,23 @@ func (r *AwsRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 }
 
 func (r *AwsRuntime) AddRuntimeFiles(unit *types.ExecutionUnit, files embed.FS) error {
-	templateData := TemplateData{
-		ExecUnitName: unit.Name,
-	}
-	err := python.AddRuntimeFiles(unit, files, templateData)
+	err := python.AddRuntimeFiles(unit, files, r.templateData(unit))
 	return err
 }
 
 func (r *AwsRuntime) AddRuntimeFile(unit *types.ExecutionUnit, path string, content []byte) error {
+	err := python.AddRuntimeFile(unit, r.templateData(unit), path, content)
+	return err
+}
+
+// templateData returns the template data which is common to all of the runtime files of the unit
+func (r *AwsRuntime) templateData(unit *types.ExecutionUnit) TemplateData {
 	templateData := TemplateData{
 		ExecUnitName: unit.Name,
 	}
-	err := python.AddRuntimeFile(unit, templateData, path, content)
-	return err
+	if r.Cfg != nil {
+		templateData.AppName = r.Cfg.QualifiedAppName()
+		templateData.Tracing = r.Cfg.Observability != nil && r.Cfg.Observability.Tracing
+	}
+	return templateData
 }
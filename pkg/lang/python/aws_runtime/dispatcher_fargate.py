@@ -1,8 +1,12 @@
+import asyncio
 import logging
 import os
 import multiprocessing
 import types
 import inspect
+{{- if .Tracing}}
+from klotho_runtime import tracing
+{{- end}}
 
 app_port = os.getenv("KLOTHO_APP_PORT", 3000)
 log_level = os.getenv("KLOTHO_LOG_LEVEL", "DEBUG").upper()
@@ -67,9 +71,16 @@ def start_proxy_server():
             param_kwargs, params = params[-1], params[:-1]
         if args_spec.varargs:
             param_args, params = params[-1], params[:-1]
+        {{- if .Tracing}}
+        with tracing.span(f"{module_name}.{function_name}", tracing.SpanKind.SERVER, obj.get('trace_context')):
+            result = function(*params, *param_args, **param_kwargs)
+            if isinstance(result, types.CoroutineType):
+                result = await result
+        {{- else}}
         result = function(*params, *param_args, **param_kwargs)
         if isinstance(result, types.CoroutineType):
             result = await result
+        {{- end}}
         return result
 
     uvicorn.run(
@@ -79,6 +90,42 @@ def start_proxy_server():
         log_level=uvicorn_log_level)
 
 
+def run_scheduled_function(module_name, function_name):
+    module_obj = try_import(module_name)
+    if not module_obj:
+        raise Exception(f"couldn't find module: {module_name}")
+    function = getattr(module_obj, function_name, None)
+    if not function:
+        raise Exception(f"couldn't find function: {module_name}.{function_name}")
+    result = function()
+    if isinstance(result, types.CoroutineType):
+        asyncio.run(result)
+
+
+def queue_pollers():
+    """Returns a function for each queue which delivers its messages to this unit, which polls the queue for its consumer."""
+    pollers = []
+    {{- range .QueueConsumers}}
+    pollers.append(lambda: poll_queue("{{.UrlEnvVar}}", {{.BatchSize}}, "{{.ModuleName}}", "{{.FunctionName}}"))
+    {{- end}}
+    return pollers
+
+
+def poll_queue(url_env_var, batch_size, module_name, function_name):
+    from klotho_runtime import queue
+    module_obj = try_import(module_name)
+    if not module_obj:
+        raise Exception(f"couldn't find module: {module_name}")
+    function = getattr(module_obj, function_name)
+
+    def consume(body, message):
+        result = function(body, message)
+        if isinstance(result, types.CoroutineType):
+            asyncio.run(result)
+
+    queue.poll(url_env_var, batch_size, consume)
+
+
 def try_import(module_name):
     from importlib import import_module
     try:
@@ -104,7 +151,13 @@ def run_fastapi_app_func(uvicorn, fastapi, entrypoint):
 
 
 if __name__ == "__main__":
-    subprocesses = [multiprocessing.Process(target=m) for m in [userland_main, start_proxy_server]]
+    scheduled_module = os.getenv("KLOTHO_SCHEDULE_MODULE")
+    if scheduled_module:
+        # scheduled tasks run a single function to completion instead of serving requests
+        run_scheduled_function(scheduled_module, os.getenv("KLOTHO_SCHEDULE_FUNCTION"))
+        exit(0)
+
+    subprocesses = [multiprocessing.Process(target=m) for m in [userland_main, start_proxy_server, *queue_pollers()]]
     for sp in subprocesses:
         sp.start()
     for sp in subprocesses:
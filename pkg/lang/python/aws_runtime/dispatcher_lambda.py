@@ -6,6 +6,9 @@ import os
 import types
 import uuid
 import inspect
+{{- if .Tracing}}
+from . import tracing
+{{- end}}
 
 log_level = os.getenv("KLOTHO_LOG_LEVEL", "DEBUG").upper()
 
@@ -21,10 +24,20 @@ def handler(event, context):
     if not request_handler:
         raise Exception("this request could not be handled: no handler found")
 
+    {{- if .Tracing}}
+    try:
+        result = request_handler(event, context)
+        if isinstance(result, types.CoroutineType):
+            result = asyncio.run(result)
+        return result
+    finally:
+        tracing.flush()
+    {{- else}}
     result = request_handler(event, context)
     if isinstance(result, types.CoroutineType):
         result = asyncio.run(result)
     return result
+    {{- end}}
 
 
 def init_asgi_handler():
@@ -60,9 +73,17 @@ async def rpc_handler(event, _context):
         param_kwargs, params = params[-1], params[:-1]
     if args_spec.varargs:
         param_args, params = params[-1], params[:-1]
+    {{- if .Tracing}}
+    with tracing.span(f"{event.get('module_name')}.{event.get('function_to_call')}", tracing.SpanKind.SERVER,
+                      event.get('trace_context')):
+        result = function(*params, *param_args, **param_kwargs)
+        if isinstance(result, types.CoroutineType):
+            result = await result
+    {{- else}}
     result = function(*params, *param_args, **param_kwargs)
     if isinstance(result, types.CoroutineType):
         result = await result
+    {{- end}}
 
     result_payload_key = str(uuid.uuid4())
     async with s3fs.open(result_payload_key, mode='w') as f:
@@ -70,11 +91,146 @@ async def rpc_handler(event, _context):
     return result_payload_key
 
 
+async def schedule_handler(event, _context):
+    module_name, function_name = event.get('__moduleName'), event.get('__functionToCall')
+    module_obj = try_import(module_name)
+    if not module_obj:
+        raise Exception(f"couldn't find module: {module_name}")
+    function = getattr(module_obj, function_name, None)
+    if not function:
+        raise Exception(f"couldn't find function: {module_name}.{function_name}")
+    result = function()
+    if isinstance(result, types.CoroutineType):
+        await result
+
+
+def migration_handler(event, _context):
+    """Runs the schema migrations of an orm with the shell command of its migration tool, from the root of the unit.
+
+    The command is set in the environment of the function which runs the migrations, never taken from the event.
+    The lambda's filesystem is read-only outside of /tmp, so tools which cache files use it as their home.
+    """
+    import subprocess
+    command = os.environ.get("KLOTHO_MIGRATION_COMMAND")
+    if not command:
+        raise Exception("this function does not run migrations")
+    log.info(f"running migrations: {command}")
+    subprocess.run(
+        command,
+        shell=True,
+        check=True,
+        cwd=os.path.dirname(os.path.dirname(os.path.abspath(__file__))),
+        env={**os.environ, "HOME": "/tmp"},
+    )
+
+
+# The consumers of the queues which deliver their messages to this unit, keyed by the queue's id
+queue_consumers = {
+    {{- range .QueueConsumers}}
+    "{{.QueueId}}": ("{{.ModuleName}}", "{{.FunctionName}}"),
+    {{- end}}
+}
+
+
+async def queue_handler(event, _context):
+    """Passes each SQS message to its queue's consumer.
+
+    Messages whose consumer fails are reported back to SQS so that only they are received again.
+    """
+    batch_item_failures = []
+    for record in event["Records"]:
+        queue_id = record.get("messageAttributes", {}).get("klotho_queue", {}).get("stringValue")
+        try:
+            consumer = queue_consumers.get(queue_id) or next(iter(queue_consumers.values()), None)
+            body = json.loads(record["body"])
+            if isinstance(body, dict) and body.get("Event") == "s3:TestEvent":
+                continue
+            if isinstance(body, dict) and body.get("Records") and body["Records"][0].get("eventSource") == "aws:s3":
+                # fs events which are buffered by a queue rather than delivered to the function directly
+                await fs_event_handler(body, _context)
+                continue
+            if not consumer:
+                raise Exception(f"no consumer for queue {queue_id}")
+            module_name, function_name = consumer
+            module_obj = try_import(module_name)
+            if not module_obj:
+                raise Exception(f"couldn't find module: {module_name}")
+            result = getattr(module_obj, function_name)(body, record)
+            if isinstance(result, types.CoroutineType):
+                await result
+        except Exception as err:
+            log.error(f"Failed to process message {record['messageId']}: {err}")
+            batch_item_failures.append({"itemIdentifier": record["messageId"]})
+    return {"batchItemFailures": batch_item_failures}
+
+
+# The functions subscribed to fs events, keyed by the id of their subscription
+fs_event_subscribers = {
+    {{- range .FsEventSubscribers}}
+    "{{.SubscriptionId}}": ("{{.ModuleName}}", "{{.FunctionName}}"),
+    {{- end}}
+}
+
+
+async def fs_event_handler(event, _context):
+    """Calls the subscribed function of each S3 event record with the write or delete of the file it describes."""
+    from urllib.parse import unquote_plus
+    for record in event["Records"]:
+        subscription_id = record.get("s3", {}).get("configurationId")
+        subscriber = fs_event_subscribers.get(subscription_id)
+        if not subscriber:
+            log.warning(f"no subscriber for fs event {subscription_id}")
+            continue
+        module_name, function_name = subscriber
+        module_obj = try_import(module_name)
+        if not module_obj:
+            raise Exception(f"couldn't find module: {module_name}")
+        result = getattr(module_obj, function_name)({
+            "type": "delete" if record["eventName"].startswith("ObjectRemoved") else "write",
+            # object keys are url-encoded in event notifications
+            "path": unquote_plus(record["s3"]["object"]["key"]),
+            "size": record["s3"]["object"].get("size"),
+            "time": record["eventTime"],
+        }, record)
+        if isinstance(result, types.CoroutineType):
+            await result
+
+
+# The WebSocket handlers of the gateways which target this unit
+websocket_handlers = [
+    {{- range .WebSocketHandlers}}
+    ("{{.ModuleName}}", "{{.FunctionName}}"),
+    {{- end}}
+]
+
+
+async def websocket_handler(event, _context):
+    """Runs the unit's WebSocket handler for an event of a WebSocket API."""
+    from . import websocket
+    if not websocket_handlers:
+        raise Exception("execution unit not configured to receive websocket events")
+    module_name, function_name = websocket_handlers[0]
+    module_obj = try_import(module_name)
+    if not module_obj:
+        raise Exception(f"couldn't find module: {module_name}")
+    return await websocket.handle(event, getattr(module_obj, function_name))
+
+
 def get_handler(event):
     if "httpMethod" in event:
         return asgi_handler if asgi_handler else init_asgi_handler()
     elif "module_name" in event:
         return rpc_handler
+    elif event.get("__callType") == "schedule":
+        return schedule_handler
+    elif event.get("__callType") == "migration":
+        return migration_handler
+    elif event.get("Records") and event["Records"][0].get("eventSource") == "aws:sqs":
+        return queue_handler
+    elif event.get("Records") and event["Records"][0].get("eventSource") == "aws:s3":
+        return fs_event_handler
+    elif event.get("requestContext", {}).get("connectionId"):
+        return websocket_handler
     else:
         raise Exception(f'unsupported invocation. event keys: {list(event.keys())}')
 
@@ -4,6 +4,9 @@ import os
 import requests
 
 import logging
+{{- if .Tracing}}
+from . import tracing
+{{- end}}
 
 sd_client = boto3.client("servicediscovery")
 APP_NAME = os.environ.get("APP_NAME")
@@ -17,6 +20,9 @@ async def proxy_call(exec_group_name, module_name, function_to_call, params):
             'function_to_call': function_to_call,
             'module_name': module_name,
             'params': params,
+            {{- if .Tracing}}
+            'trace_context': tracing.inject_context(),
+            {{- end}}
         })
         if res.content != "":
             return json.loads(res.content)
@@ -4,6 +4,9 @@ import os
 import logging
 import json
 import uuid
+{{- if .Tracing}}
+from . import tracing
+{{- end}}
 
 lambda_client = boto3.client("lambda")
 APP_NAME = os.environ.get("APP_NAME")
@@ -18,6 +21,9 @@ async def proxy_call(exec_group_name, module_name, function_name, params):
         "module_name": module_name,
         "function_to_call": function_name,
         "params": payload_key,
+        {{- if .Tracing}}
+        "trace_context": tracing.inject_context(),
+        {{- end}}
     }
     result = lambda_client.invoke(
         FunctionName=physical_address,
@@ -0,0 +1,27 @@
+import json
+import requests
+
+import logging
+{{- if .Tracing}}
+from . import tracing
+{{- end}}
+
+
+async def proxy_call(exec_group_name, module_name, function_to_call, params):
+    try:
+        # locally, each unit runs as a compose service which resolves by the unit's name
+        res = requests.post(f'http://{exec_group_name.lower()}:3001', json={
+            'exec_group_name': exec_group_name,
+            'function_to_call': function_to_call,
+            'module_name': module_name,
+            'params': params,
+            {{- if .Tracing}}
+            'trace_context': tracing.inject_context(),
+            {{- end}}
+        })
+        if res.content != "":
+            return json.loads(res.content)
+        return None
+    except Exception as e:
+        logging.error(e)
+        raise e
@@ -0,0 +1,82 @@
+import json
+import logging
+import os
+import time
+import uuid
+
+import boto3
+
+log = logging.getLogger("klotho")
+
+# The message attribute which identifies the queue that a message was sent to,
+# so that a dispatcher consuming several queues can route each message to its consumer.
+QUEUE_ID_ATTRIBUTE = "klotho_queue"
+
+_client = None
+
+
+def client():
+    global _client
+    if _client is None:
+        _client = boto3.client("sqs")
+    return _client
+
+
+class Queue:
+    def __init__(self, id: str, url_env_var: str):
+        self.id = id
+        self.url_env_var = url_env_var
+
+    def send(self, body, group_id: str = None, deduplication_id: str = None, delay_seconds: int = None) -> str:
+        """Sends `body` (serialized as JSON) to the queue and returns the id of the sent message.
+
+        `group_id` and `deduplication_id` apply to FIFO queues, and default to the queue's id and a random id.
+        `delay_seconds` applies to standard queues.
+        """
+        url = queue_url(self.url_env_var)
+        args = {
+            "QueueUrl": url,
+            "MessageBody": json.dumps(body),
+            "MessageAttributes": {QUEUE_ID_ATTRIBUTE: {"DataType": "String", "StringValue": self.id}},
+        }
+        if url.endswith(".fifo"):
+            args["MessageGroupId"] = group_id or self.id
+            args["MessageDeduplicationId"] = deduplication_id or str(uuid.uuid4())
+        elif delay_seconds is not None:
+            args["DelaySeconds"] = delay_seconds
+        resp = client().send_message(**args)
+        log.info(f"Sent message {resp['MessageId']} to queue {self.id}")
+        return resp["MessageId"]
+
+
+def poll(url_env_var: str, batch_size: int, consumer):
+    """Polls the queue whose url is in `url_env_var` and passes each message to `consumer`, deleting it once `consumer` succeeds.
+
+    Messages that fail are received again after the queue's visibility timeout, until they are moved to its dead-letter queue.
+    """
+    url = queue_url(url_env_var)
+    while True:
+        try:
+            resp = client().receive_message(
+                QueueUrl=url,
+                MaxNumberOfMessages=min(max(batch_size, 1), 10),
+                MessageAttributeNames=["All"],
+                WaitTimeSeconds=20,
+            )
+        except Exception as err:
+            log.error(f"Failed to receive messages from {url}: {err}")
+            time.sleep(5)
+            continue
+        for message in resp.get("Messages", []):
+            try:
+                consumer(json.loads(message["Body"]), message)
+                client().delete_message(QueueUrl=url, ReceiptHandle=message["ReceiptHandle"])
+            except Exception as err:
+                log.error(f"Failed to process message {message['MessageId']}: {err}")
+
+
+def queue_url(url_env_var: str) -> str:
+    url = os.getenv(url_env_var)
+    if not url:
+        raise Exception(f"Queue url is not set: expected it in the environment variable {url_env_var}")
+    return url
@@ -1,16 +1,36 @@
+import builtins
+import os
+
 import boto3
 
 secretPrefix = '{{.AppName}}'
 
+# units whose secrets are stored in SSM Parameter Store have KLOTHO_SECRETS_BACKEND set to 'ssm'
+useSsm = os.environ.get("KLOTHO_SECRETS_BACKEND") == "ssm"
+# units which run locally have their secrets mounted as files in KLOTHO_SECRETS_DIR and KLOTHO_SECRETS_BACKEND set to "file"
+useFiles = os.environ.get("KLOTHO_SECRETS_BACKEND") == "file"
+secretsDir = os.environ.get("KLOTHO_SECRETS_DIR", "/run/secrets")
+
 def open(path: str, **kwargs):
     return secretsContexManager(path)
 
 class secretsContexManager():
     def __init__(self, path: str):
-        self.client = boto3.client('secretsmanager')
-        self.secret_name = "{}-{}".format(secretPrefix, path)
+        if useFiles:
+            self.client = None
+            self.secret_name = os.path.join(secretsDir, path)
+        elif useSsm:
+            self.client = boto3.client('ssm')
+            self.secret_name = "/{}/{}".format(secretPrefix, path)
+        else:
+            self.client = boto3.client('secretsmanager')
+            self.secret_name = "{}-{}".format(secretPrefix, path)
 
     async def __aenter__(self):
+        if useFiles:
+            return FileItem(name=self.secret_name)
+        if useSsm:
+            return ParameterItem(name=self.secret_name, client=self.client)
         return SecretItem(name=self.secret_name, client=self.client)
 
     async def __aexit__(self, exc_type, exc_val, traceback):
@@ -28,3 +48,23 @@ class SecretItem():
         elif response.get("SecretString"):
             return response["SecretString"]
         raise Exception("Empty Secret")
+
+class ParameterItem():
+    def __init__(self, name: str, client: boto3.client, **kwargs):
+        self.client = client
+        self.name = name
+
+    async def read(self):
+        response = self.client.get_parameter(Name=self.name, WithDecryption=True)
+        value = response.get("Parameter", {}).get("Value")
+        if value:
+            return value
+        raise Exception("Empty Secret")
+
+class FileItem():
+    def __init__(self, name: str, **kwargs):
+        self.name = name
+
+    async def read(self):
+        with builtins.open(self.name) as f:
+            return f.read()
@@ -0,0 +1,43 @@
+import os
+from contextlib import contextmanager
+
+from opentelemetry import propagate, trace
+from opentelemetry.exporter.otlp.proto.http.trace_exporter import OTLPSpanExporter
+from opentelemetry.sdk.resources import Resource
+from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.trace.export import BatchSpanProcessor
+
+SpanKind = trace.SpanKind
+
+provider = TracerProvider(resource=Resource.create({
+    "service.name": "{{.ExecUnitName}}",
+    "service.namespace": "{{.AppName}}",
+}))
+# Without an endpoint the unit still propagates the trace context it receives, but does not export its own spans
+if os.environ.get("OTEL_EXPORTER_OTLP_ENDPOINT"):
+    provider.add_span_processor(BatchSpanProcessor(OTLPSpanExporter()))
+trace.set_tracer_provider(provider)
+tracer = trace.get_tracer("klotho")
+
+
+def inject_context():
+    """Returns the W3C trace context of the active span, to be sent with a call or event."""
+    carrier = {}
+    propagate.inject(carrier)
+    return carrier
+
+
+@contextmanager
+def span(name, kind, carrier=None):
+    """Runs the block in a span which continues the trace of `carrier`, or the active trace if there is no carrier.
+
+    The span records the exception which the block raises, if any.
+    """
+    parent = propagate.extract(carrier) if carrier else None
+    with tracer.start_as_current_span(name, context=parent, kind=kind) as s:
+        yield s
+
+
+def flush():
+    """Exports the ended spans, which must be done before a lambda invocation returns since it is frozen afterwards."""
+    provider.force_flush()
@@ -0,0 +1,122 @@
+import base64
+import json
+import logging
+import os
+
+import boto3
+
+log = logging.getLogger("klotho")
+
+try:
+    from starlette.websockets import WebSocketDisconnect
+except ImportError:
+    class WebSocketDisconnect(Exception):
+        def __init__(self, code: int = 1000, reason: str = None):
+            self.code = code
+            self.reason = reason
+
+_clients = {}
+
+
+def client(endpoint: str):
+    if endpoint not in _clients:
+        _clients[endpoint] = boto3.client("apigatewaymanagementapi", endpoint_url=endpoint)
+    return _clients[endpoint]
+
+
+class WebSocket:
+    """Stands in for a FastAPI WebSocket during one event of an API Gateway WebSocket API connection.
+
+    The handler runs for each event of the connection. Receiving returns the event's message,
+    if it has one, and raises WebSocketDisconnect after that.
+    """
+
+    def __init__(self, event, endpoint: str):
+        context = event["requestContext"]
+        self.connection_id = context["connectionId"]
+        self.endpoint = endpoint
+        self.query_params = event.get("queryStringParameters") or {}
+        self.headers = event.get("headers") or {}
+        self._message = None
+        if context["eventType"] == "MESSAGE":
+            self._message = {"binary": bool(event.get("isBase64Encoded")), "body": event.get("body") or ""}
+
+    async def accept(self, subprotocol: str = None, headers=None):
+        pass
+
+    async def receive(self):
+        if self._message is None:
+            raise WebSocketDisconnect(1000)
+        message, self._message = self._message, None
+        if message["binary"]:
+            return {"type": "websocket.receive", "bytes": base64.b64decode(message["body"])}
+        return {"type": "websocket.receive", "text": message["body"]}
+
+    async def receive_text(self) -> str:
+        message = await self.receive()
+        return message.get("text") or message["bytes"].decode()
+
+    async def receive_bytes(self) -> bytes:
+        message = await self.receive()
+        return message.get("bytes") or message["text"].encode()
+
+    async def receive_json(self, mode: str = "text"):
+        return json.loads(await self.receive_text() if mode == "text" else await self.receive_bytes())
+
+    async def iter_text(self):
+        try:
+            while True:
+                yield await self.receive_text()
+        except WebSocketDisconnect:
+            pass
+
+    async def iter_json(self):
+        try:
+            while True:
+                yield await self.receive_json()
+        except WebSocketDisconnect:
+            pass
+
+    async def send_text(self, data: str):
+        post_to_connection(self.endpoint, self.connection_id, data.encode())
+
+    async def send_bytes(self, data: bytes):
+        post_to_connection(self.endpoint, self.connection_id, data)
+
+    async def send_json(self, data, mode: str = "text"):
+        post_to_connection(self.endpoint, self.connection_id, json.dumps(data).encode())
+
+    async def close(self, code: int = 1000, reason: str = None):
+        client(self.endpoint).delete_connection(ConnectionId=self.connection_id)
+
+
+def post_to_connection(endpoint: str, connection_id: str, data: bytes):
+    client(endpoint).post_to_connection(ConnectionId=connection_id, Data=data)
+
+
+def send(endpoint_env_var: str, connection_id: str, data):
+    """Sends `data` to the client with the given connection id, such as one saved when the client connected.
+
+    `endpoint_env_var` is the environment variable of the gateway's endpoint, `<GATEWAY ID>_WEBSOCKET_ENDPOINT`.
+    """
+    endpoint = os.getenv(endpoint_env_var)
+    if not endpoint:
+        raise Exception(f"WebSocket endpoint is not set ({endpoint_env_var})")
+    if isinstance(data, str):
+        data = data.encode()
+    elif not isinstance(data, bytes):
+        data = json.dumps(data).encode()
+    post_to_connection(endpoint, connection_id, data)
+
+
+async def handle(event, handler):
+    """Runs the WebSocket handler for an event ($connect, $disconnect or a message) of a WebSocket API."""
+    context = event["requestContext"]
+    if context["eventType"] == "DISCONNECT":
+        return {"statusCode": 200}
+    websocket = WebSocket(event, f"https://{context['domainName']}/{context['stage']}")
+    try:
+        await handler(websocket)
+    except WebSocketDisconnect:
+        pass
+    return {"statusCode": 200}
@@ -0,0 +1,100 @@
+package python
+
+import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+)
+
+// FsEvent subscribes each module-level function annotated with `@klotho::fs_event` to the events of the persisted fs
+// with the annotation's id. The unit's dispatcher calls the function with each write or delete of a file in the fs.
+type FsEvent struct {
+	cfg *config.Application
+}
+
+func (p FsEvent) Name() string { return "FsEvent" }
+
+func (p FsEvent) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	events := make(map[string]*types.FsEvent)
+	subscribers := make(map[string]string)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			pySource, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(pySource); owner != "" && owner != unit.Name {
+				continue
+			}
+			for _, annot := range pySource.Annotations() {
+				if annot.Capability.Name != annotation.FsEventCapability {
+					continue
+				}
+				sub, err := p.newSubscription(pySource, annot, unit)
+				if err != nil {
+					errs.Append(types.NewCompilerError(pySource, annot, err))
+					continue
+				}
+				key := annot.Capability.ID + "/" + sub.Id()
+				if existing, ok := subscribers[key]; ok {
+					if existing != unit.Name {
+						errs.Append(types.NewCompilerError(pySource, annot, errors.Errorf(
+							"fs event subscriber is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+							existing, unit.Name,
+						)))
+					}
+					continue
+				}
+				subscribers[key] = unit.Name
+
+				event, ok := events[annot.Capability.ID]
+				if !ok {
+					event = &types.FsEvent{Name: annot.Capability.ID}
+					events[event.Name] = event
+					// the fs is added by the persist plugin of the unit which declares it; adding it here only links the two
+					fs := &types.Fs{Name: event.Name}
+					constructGraph.AddConstruct(event)
+					constructGraph.AddConstruct(fs)
+					constructGraph.AddDependency(event.Id(), fs.Id())
+				}
+				if err := event.Subscribe(sub); err != nil {
+					errs.Append(types.NewCompilerError(pySource, annot, err))
+					continue
+				}
+				constructGraph.AddDependency(event.Id(), unit.Id())
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p FsEvent) newSubscription(f *types.SourceFile, annot *types.Annotation, unit *types.ExecutionUnit) (types.FsSubscription, error) {
+	sub, err := types.NewFsSubscriptionFromAnnotation(annot.Capability)
+	if err != nil {
+		return sub, err
+	}
+	fn := scheduledFunction(annot.Node)
+	if fn == nil {
+		return sub, errors.New("@klotho::fs_event must annotate a function")
+	}
+	definition := fn
+	if parent := fn.Parent(); parent != nil && parent.Type() == "decorated_definition" {
+		definition = parent
+	}
+	if parent := definition.Parent(); parent == nil || parent.Type() != "module" {
+		return sub, errors.New("fs event subscribers must be defined at the module level")
+	}
+	if p.cfg != nil {
+		if unitType := p.cfg.GetResourceType(unit); unitType != "lambda" {
+			return sub, errors.Errorf("fs events can only be delivered to lambda execution units, not %s", unitType)
+		}
+	}
+	sub.ExecUnitName = unit.Name
+	sub.ModuleName = pathToPythonModule(f.Path())
+	sub.FunctionName = fn.ChildByFieldName("name").Content()
+	return sub, nil
+}
@@ -0,0 +1,126 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFsEvent_Transform(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []taggedFile
+		unitType string
+		want     []types.FsSubscription
+		wantErr  bool
+	}{
+		{
+			name: "write events by default",
+			files: []taggedFile{{path: "app/uploads.py", tag: "entrypoint", content: `# @klotho::fs_event {
+#   id = "uploads"
+# }
+async def on_upload(event):
+    pass
+`}},
+			unitType: "lambda",
+			want: []types.FsSubscription{
+				{ExecUnitName: "main", ModuleName: "app.uploads", FunctionName: "on_upload", Events: []string{"write"}},
+			},
+		},
+		{
+			name: "multiple subscribers with filters",
+			files: []taggedFile{
+				{path: "app/uploads.py", tag: "entrypoint", content: `# @klotho::fs_event {
+#   id = "uploads"
+#   events = ["write", "delete"]
+#   prefix = "images/"
+#   suffix = ".png"
+# }
+def on_image(event):
+    pass
+`},
+				{path: "app/audit.py", tag: "source", content: `import functools
+
+# @klotho::fs_event {
+#   id = "uploads"
+#   events = "delete"
+# }
+@functools.cache
+def on_delete(event):
+    pass
+`},
+			},
+			unitType: "lambda",
+			want: []types.FsSubscription{
+				{ExecUnitName: "main", ModuleName: "app.uploads", FunctionName: "on_image", Events: []string{"write", "delete"}, Prefix: "images/", Suffix: ".png"},
+				{ExecUnitName: "main", ModuleName: "app.audit", FunctionName: "on_delete", Events: []string{"delete"}},
+			},
+		},
+		{
+			name: "invalid event",
+			files: []taggedFile{{path: "app/uploads.py", tag: "entrypoint", content: `# @klotho::fs_event {
+#   id = "uploads"
+#   events = ["read"]
+# }
+def on_upload(event):
+    pass
+`}},
+			unitType: "lambda",
+			wantErr:  true,
+		},
+		{
+			name: "nested function",
+			files: []taggedFile{{path: "app/uploads.py", tag: "entrypoint", content: `def outer():
+    # @klotho::fs_event {
+    #   id = "uploads"
+    # }
+    def on_upload(event):
+        pass
+`}},
+			unitType: "lambda",
+			wantErr:  true,
+		},
+		{
+			name: "fargate unit",
+			files: []taggedFile{{path: "app/uploads.py", tag: "entrypoint", content: `# @klotho::fs_event {
+#   id = "uploads"
+# }
+def on_upload(event):
+    pass
+`}},
+			unitType: "ecs",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := execUnit("main", tt.files...)
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			cfg := &config.Application{Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: tt.unitType}}}
+			err := FsEvent{cfg: cfg}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			events := construct.GetConstructsOfType[*types.FsEvent](graph)
+			if !assert.Len(events, 1) {
+				return
+			}
+			event := events[0]
+			assert.Equal("uploads", event.Name)
+			assert.ElementsMatch(tt.want, event.Subscriptions)
+			assert.NotNil(graph.GetDependency(event.Id(), unit.Id()))
+			assert.NotNil(graph.GetDependency(event.Id(), (&types.Fs{Name: "uploads"}).Id()))
+		})
+	}
+}
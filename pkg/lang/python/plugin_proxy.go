@@ -0,0 +1,393 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+// Proxy rewrites calls to functions in modules owned by another execution unit (via `@klotho::execution_unit`)
+// into RPC calls through the proxy runtime, and records the unit -> unit dependency in the construct graph.
+type Proxy struct {
+	runtime Runtime
+	cfg     *config.Application
+}
+
+type (
+	// proxyTarget is an imported module (or a function imported from a module) that belongs to another execution unit.
+	proxyTarget struct {
+		unit *types.ExecutionUnit
+		// module is the module name that the target unit's dispatcher will import
+		module string
+		// function is set when the import binds the function itself (`from module import function`)
+		function string
+	}
+
+	proxyImport struct {
+		proxied, local bool
+	}
+
+	proxyEdit struct {
+		start, end uint32
+		content    string
+	}
+)
+
+func (p Proxy) Name() string { return "Proxy:Python" }
+
+func (p Proxy) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	units := make(map[string]*types.ExecutionUnit)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		units[unit.Name] = unit
+	}
+
+	var errs multierr.Error
+	for _, unit := range units {
+		if unit.Executable.Type != types.ExecutableTypePython {
+			continue
+		}
+		targets, err := p.proxyUnit(unit, units)
+		if err != nil {
+			errs.Append(klotho_errors.WrapErrf(err, "failed to proxy calls in unit %s", unit.Name))
+			continue
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		errs.Append(pruneProxiedFiles(unit))
+		for _, target := range targets {
+			zap.L().Sugar().Debugf("Adding execution unit dependency: %s -> %s", unit.Name, target.Name)
+			constructGraph.AddDependency(unit.Id(), target.Id())
+			errs.Append(p.runtime.AddProxyRuntimeFiles(unit, p.cfg.GetResourceType(target)))
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+// proxyUnit rewrites the cross-unit calls of every file in the unit, returning the units that are called.
+func (p Proxy) proxyUnit(unit *types.ExecutionUnit, units map[string]*types.ExecutionUnit) ([]*types.ExecutionUnit, error) {
+	targets := make(map[string]*types.ExecutionUnit)
+	var errs multierr.Error
+	for _, f := range unit.FilesOfLang(py) {
+		if _, isSource := unit.Executable.SourceFiles[f.Path()]; !isSource {
+			continue
+		}
+		if owner := types.FileExecUnitName(f); owner != "" && owner != unit.Name {
+			continue
+		}
+		called, err := p.proxyFile(unit, f, units)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		for _, target := range called {
+			targets[target.Name] = target
+		}
+	}
+
+	var result []*types.ExecutionUnit
+	for _, target := range targets {
+		result = append(result, target)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, errs.ErrOrNil()
+}
+
+// proxyFile rewrites the calls in f to modules owned by other units and removes the imports of those modules.
+// Each rewritten call must be awaited, since the proxied call is asynchronous.
+func (p Proxy) proxyFile(unit *types.ExecutionUnit, f *types.SourceFile, units map[string]*types.ExecutionUnit) ([]*types.ExecutionUnit, error) {
+	log := zap.L().With(logging.FileField(f)).Sugar()
+
+	// usedAs name -> target
+	targets := make(map[string]proxyTarget)
+	// import statement -> which of the names it imports are proxied
+	statements := make(map[*sitter.Node]*proxyImport)
+	// usedAs name -> the statement that binds it
+	bindings := make(map[string]*sitter.Node)
+	markStatement := func(node *sitter.Node, usedAs map[string]struct{}, proxied bool) {
+		stmt := importStatement(node)
+		if stmt == nil {
+			return
+		}
+		imp, seen := statements[stmt]
+		if !seen {
+			imp = &proxyImport{}
+			statements[stmt] = imp
+		}
+		if proxied {
+			imp.proxied = true
+		} else {
+			imp.local = true
+		}
+		for name := range usedAs {
+			bindings[name] = stmt
+		}
+	}
+
+	for _, spec := range FindFileImports(f) {
+		module := spec.FullyQualifiedModule()
+		moduleTarget, err := p.findTarget(unit, f, module, units)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Node != nil {
+			markStatement(spec.Node, spec.UsedAs, moduleTarget != nil)
+			if moduleTarget != nil {
+				for name := range spec.UsedAs {
+					targets[name] = *moduleTarget
+				}
+			}
+		}
+
+		for _, attr := range spec.ImportedAttributes {
+			attrModule := Import{ParentModule: module, Name: attr.Name}.FullyQualifiedModule()
+			target, err := p.findTarget(unit, f, attrModule, units)
+			if err != nil {
+				return nil, err
+			}
+			if target == nil && moduleTarget != nil {
+				target = &proxyTarget{unit: moduleTarget.unit, module: moduleTarget.module, function: attr.Name}
+			}
+			markStatement(attr.Node, attr.UsedAs, target != nil)
+			if target != nil {
+				for name := range attr.UsedAs {
+					targets[name] = *target
+				}
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	proxyVars := make(map[string]string)
+	var edits []proxyEdit
+	var errs multierr.Error
+	called := make(map[string]*types.ExecutionUnit)
+
+	nextMatch := DoQuery(f.Tree().RootNode(), proxyCall)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		call, obj, function, args := match["call"], match["obj"], match["function"], match["args"]
+
+		var target proxyTarget
+		var functionName string
+		if obj != nil {
+			t, ok := targets[obj.Content()]
+			if !ok || t.function != "" {
+				continue
+			}
+			target, functionName = t, function.Content()
+		} else {
+			t, ok := targets[function.Content()]
+			if !ok || t.function == "" {
+				continue
+			}
+			target, functionName = t, t.function
+		}
+
+		if parent := call.Parent(); parent == nil || parent.Type() != "await" {
+			errs.Append(errors.Errorf("%s:%d: call to '%s' in execution unit '%s' must be awaited",
+				f.Path(), call.StartPoint().Row+1, call.Child(0).Content(), target.unit.Name))
+			continue
+		}
+
+		var params []string
+		for i := 0; i < int(args.NamedChildCount()); i++ {
+			arg := args.NamedChild(i)
+			switch arg.Type() {
+			case "comment":
+				continue
+			case "keyword_argument", "list_splat", "dictionary_splat":
+				errs.Append(errors.Errorf("%s:%d: call to '%s' in execution unit '%s' only supports positional arguments",
+					f.Path(), call.StartPoint().Row+1, call.Child(0).Content(), target.unit.Name))
+			}
+			params = append(params, arg.Content())
+		}
+
+		proxyType := p.cfg.GetResourceType(target.unit)
+		proxyVar, ok := proxyVars[proxyType]
+		if !ok {
+			proxyVar = fmt.Sprintf("_klotho_%s_proxy", proxyType)
+			proxyVars[proxyType] = proxyVar
+		}
+
+		edits = append(edits, proxyEdit{
+			start: call.StartByte(),
+			end:   call.EndByte(),
+			content: fmt.Sprintf(`%s.proxy_call("%s", "%s", "%s", [%s])`,
+				proxyVar, target.unit.Name, target.module, functionName, strings.Join(params, ", ")),
+		})
+		called[target.unit.Name] = target.unit
+		log.Debugf("Proxying call to '%s.%s' to execution unit '%s'", target.module, functionName, target.unit.Name)
+	}
+	if err := errs.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	for stmt, imp := range statements {
+		switch {
+		case imp.proxied && imp.local:
+			// the statement also imports names that stay in this unit, so it is kept
+			log.Warnf("Keeping partially proxied import '%s'", stmt.Content())
+		case imp.proxied:
+			end := stmt.EndByte()
+			if program := f.Program(); int(end) < len(program) && program[end] == '\n' {
+				end++
+			}
+			edits = append(edits, proxyEdit{start: stmt.StartByte(), end: end})
+		}
+	}
+
+	// names that are still bound by a kept import can be used locally
+	removedNames := make(map[string]struct{})
+	for name := range targets {
+		if imp := statements[bindings[name]]; imp == nil || !imp.local {
+			removedNames[name] = struct{}{}
+		}
+	}
+
+	err := applyProxyEdits(f, edits)
+	if err != nil {
+		return nil, err
+	}
+
+	if remaining := findProxiedReferences(f, removedNames); len(remaining) > 0 {
+		return nil, errors.Errorf("%s: '%s' is imported from another execution unit and can only be used in awaited function calls",
+			f.Path(), strings.Join(remaining, "', '"))
+	}
+
+	var proxyTypes []string
+	for proxyType := range proxyVars {
+		proxyTypes = append(proxyTypes, proxyType)
+	}
+	sort.Strings(proxyTypes)
+	for _, proxyType := range proxyTypes {
+		err := AddRuntimeImport(p.runtime.GetProxyRuntimeImportClass(proxyType, proxyVars[proxyType]), f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result []*types.ExecutionUnit
+	for _, target := range called {
+		result = append(result, target)
+	}
+	return result, nil
+}
+
+// findTarget returns the proxy target for the module if the module's file is owned by another execution unit,
+// or nil if the module is not a file of the unit or belongs to the unit itself.
+func (p Proxy) findTarget(unit *types.ExecutionUnit, f *types.SourceFile, module string, units map[string]*types.ExecutionUnit) (*proxyTarget, error) {
+	modulePath, err := findImportedFile(module, f.Path(), unit.Files())
+	if err != nil || modulePath == "" {
+		return nil, err
+	}
+	moduleFile, ok := Language.ID.CastFile(unit.Get(modulePath))
+	if !ok {
+		return nil, nil
+	}
+	owner, ok := units[types.FileExecUnitName(moduleFile)]
+	if !ok || owner == unit {
+		return nil, nil
+	}
+	return &proxyTarget{unit: owner, module: pathToPythonModule(modulePath)}, nil
+}
+
+// pathToPythonModule converts a file path to the absolute module name used to import it.
+func pathToPythonModule(path string) string {
+	path = strings.TrimSuffix(path, ".py")
+	path = strings.TrimSuffix(path, "/__init__")
+	return strings.ReplaceAll(path, "/", ".")
+}
+
+func importStatement(node *sitter.Node) *sitter.Node {
+	for ; node != nil; node = node.Parent() {
+		if node.Type() == "import_statement" || node.Type() == "import_from_statement" {
+			return node
+		}
+	}
+	return nil
+}
+
+func applyProxyEdits(f *types.SourceFile, edits []proxyEdit) error {
+	if len(edits) == 0 {
+		return nil
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	content := string(f.Program())
+	for _, edit := range edits {
+		content = content[:edit.start] + edit.content + content[edit.end:]
+	}
+	return errors.Wrap(f.Reparse([]byte(content)), "could not reparse proxied calls")
+}
+
+// findProxiedReferences returns the names that are still referenced in f after their imports have been removed.
+func findProxiedReferences(f *types.SourceFile, names map[string]struct{}) []string {
+	found := make(map[string]struct{})
+	nextMatch := DoQuery(f.Tree().RootNode(), proxyReference)
+	for {
+		match, ok := nextMatch()
+		if !ok {
+			break
+		}
+		ref := match["reference"]
+		// `obj.attr` only refers to the import when `obj` is not itself an attribute (eg: `self.obj`)
+		if parent := ref.Parent(); parent != nil && parent.Type() == "attribute" && parent.ChildByFieldName("attribute") == ref {
+			continue
+		}
+		if _, isProxied := names[ref.Content()]; isProxied {
+			found[ref.Content()] = struct{}{}
+		}
+	}
+	var result []string
+	for name := range found {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// pruneProxiedFiles removes the source files that the unit no longer depends on now that calls to other units are
+// proxied, along with any entrypoints owned by other units.
+func pruneProxiedFiles(unit *types.ExecutionUnit) error {
+	for path := range unit.Executable.Entrypoints {
+		f, ok := Language.ID.CastFile(unit.Get(path))
+		if !ok {
+			continue
+		}
+		if owner := types.FileExecUnitName(f); owner != "" && owner != unit.Name {
+			delete(unit.Executable.Entrypoints, path)
+		}
+	}
+
+	sourceFiles, err := upstreamDependencyResolver.Resolve(unit)
+	if err != nil {
+		return klotho_errors.WrapErrf(err, "file dependency resolution failed for execution unit: %s", unit.Name)
+	}
+	for path := range unit.Executable.SourceFiles {
+		if _, ok := sourceFiles[path]; ok {
+			continue
+		}
+		if _, ok := Language.ID.CastFile(unit.Get(path)); !ok {
+			continue
+		}
+		zap.L().Sugar().Debugf("Removing proxied file '%s' from execution unit: %s", path, unit.Name)
+		unit.Remove(path)
+	}
+	return nil
+}
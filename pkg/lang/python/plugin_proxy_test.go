@@ -0,0 +1,156 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy_Transform(t *testing.T) {
+	const worker = `# @klotho::execution_unit { id = "worker" }
+import app.util
+
+async def process(item, count):
+    return app.util.double(item) * count
+`
+	tests := []struct {
+		name          string
+		source        string
+		expect        string
+		expectFiles   []string
+		expectProxied bool
+		wantErr       bool
+	}{
+		{
+			name: "module import",
+			source: `import os
+import app.worker
+
+async def handler(item):
+    return await app.worker.process(item, 2)
+`,
+			expect: `import os
+import klotho_runtime.lambda_proxy as _klotho_lambda_proxy
+
+async def handler(item):
+    return await _klotho_lambda_proxy.proxy_call("worker", "app.worker", "process", [item, 2])
+`,
+			expectFiles:   []string{"app/main.py"},
+			expectProxied: true,
+		},
+		{
+			name: "aliased function import",
+			source: `from app.worker import process as run
+
+async def handler(item):
+    return await run(item, 2)
+`,
+			expect: `
+import klotho_runtime.lambda_proxy as _klotho_lambda_proxy
+async def handler(item):
+    return await _klotho_lambda_proxy.proxy_call("worker", "app.worker", "process", [item, 2])
+`,
+			expectFiles:   []string{"app/main.py"},
+			expectProxied: true,
+		},
+		{
+			name: "relative module import",
+			source: `from . import worker as w
+
+async def handler(item):
+    return await w.process(item, 2)
+`,
+			expect: `
+import klotho_runtime.lambda_proxy as _klotho_lambda_proxy
+async def handler(item):
+    return await _klotho_lambda_proxy.proxy_call("worker", "app.worker", "process", [item, 2])
+`,
+			expectFiles:   []string{"app/main.py"},
+			expectProxied: true,
+		},
+		{
+			name: "local import is unchanged",
+			source: `import app.util
+
+def handler(item):
+    return app.util.double(item)
+`,
+			expect: `import app.util
+
+def handler(item):
+    return app.util.double(item)
+`,
+			expectFiles: []string{"app/main.py", "app/worker.py", "app/util.py"},
+		},
+		{
+			name: "call must be awaited",
+			source: `import app.worker
+
+def handler(item):
+    return app.worker.process(item, 2)
+`,
+			wantErr: true,
+		},
+		{
+			name: "keyword arguments are not supported",
+			source: `import app.worker
+
+async def handler(item):
+    return await app.worker.process(item, count=2)
+`,
+			wantErr: true,
+		},
+		{
+			name: "proxied module can only be called",
+			source: `import app.worker
+
+async def handler(item):
+    fn = app.worker.process
+    return await app.worker.process(item, 2)
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			api := execUnit("api",
+				taggedFile{path: "app/main.py", content: tt.source, tag: "entrypoint"},
+				taggedFile{path: "app/worker.py", content: worker, tag: "source"},
+				taggedFile{path: "app/util.py", content: "def double(x):\n    return x * 2\n", tag: "source"},
+			)
+			workerUnit := execUnit("worker",
+				taggedFile{path: "app/worker.py", content: worker, tag: "entrypoint"},
+				taggedFile{path: "app/util.py", content: "def double(x):\n    return x * 2\n", tag: "source"},
+			)
+			api.Executable.Type = types.ExecutableTypePython
+			workerUnit.Executable.Type = types.ExecutableTypePython
+
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(api)
+			graph.AddConstruct(workerUnit)
+
+			p := Proxy{
+				runtime: NoopRuntime{},
+				cfg:     &config.Application{Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: "lambda"}}},
+			}
+			err := p.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+
+			main, _ := Language.ID.CastFile(api.Get("app/main.py"))
+			assert.Equal(tt.expect, string(main.Program()))
+			assert.ElementsMatch(tt.expectFiles, keys(api.Executable.SourceFiles))
+			assert.Equal(tt.expectProxied, graph.GetDependency(api.Id(), workerUnit.Id()) != nil)
+		})
+	}
+}
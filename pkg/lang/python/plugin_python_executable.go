@@ -1,8 +1,11 @@
 package python
 
 import (
+	"strings"
+
 	"github.com/klothoplatform/klotho/pkg/annotation"
 	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
 	"github.com/klothoplatform/klotho/pkg/construct"
 	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
 	execunit "github.com/klothoplatform/klotho/pkg/exec_unit"
@@ -15,6 +18,7 @@ var upstreamDependencyResolver = execunit.SourceFilesResolver{
 }
 
 type PythonExecutable struct {
+	Config *config.Application
 }
 
 func (l PythonExecutable) Name() string {
@@ -62,6 +66,12 @@ func (l PythonExecutable) Transform(input *types.InputFiles, fileDeps *types.Fil
 			return err
 		}
 		refreshUpstreamEntrypoints(unit)
+
+		if l.Config != nil {
+			if cfg := l.Config.GetExecutionUnit(unit.Name); cfg.PruneDependencies {
+				pruneUnusedRequirements(unit, cfg.KeepDependencies)
+			}
+		}
 	}
 	return nil
 }
@@ -76,14 +86,57 @@ func refreshUpstreamEntrypoints(unit *types.ExecutionUnit) {
 }
 
 func refreshSourceFiles(unit *types.ExecutionUnit) error {
-	sourceFiles, err := upstreamDependencyResolver.Resolve(unit)
+	sourceFiles, err := upstreamDependencyResolver.ResolveWithReasons(unit)
 	if err != nil {
 		return klotho_errors.WrapErrf(err, "file dependency resolution failed for execution unit: %s", unit.Name)
 	}
-	for k, v := range sourceFiles {
-		unit.Executable.SourceFiles[k] = v
+	for path, reason := range sourceFiles {
+		unit.Executable.SourceFiles[path] = struct{}{}
+		if _, explained := unit.Executable.InclusionReasons[path]; !explained {
+			unit.Executable.SetInclusionReason(path, reason)
+		}
+	}
+	return nil
+}
+
+// pruneUnusedRequirements removes the requirements that none of the unit's source files import. Requirements whose
+// modules are unknown, those which are used without being imported and those in keep are never removed.
+func pruneUnusedRequirements(unit *types.ExecutionUnit, keep []string) {
+	imported := make(map[string]struct{})
+	for path := range unit.Executable.SourceFiles {
+		f, ok := py.CastFile(unit.Get(path))
+		if !ok {
+			continue
+		}
+		for _, spec := range FindFileImports(f) {
+			module := spec.FullyQualifiedModule()
+			if strings.HasPrefix(module, ".") {
+				continue
+			}
+			if local, _ := findImportedFile(module, path, unit.Files()); local != "" {
+				continue
+			}
+			imported[strings.Split(module, ".")[0]] = struct{}{}
+		}
+	}
+	kept := make(map[string]struct{})
+	for _, requirement := range keep {
+		kept[normalizeRequirementName(requirement)] = struct{}{}
+	}
+
+	for _, f := range unit.Files() {
+		requirementsTxt, ok := f.(*RequirementsTxt)
+		if !ok {
+			continue
+		}
+		pruned := requirementsTxt.PruneRequirements(func(requirement string) bool {
+			return requirementIsUsed(requirement, imported, kept)
+		})
+		for _, requirement := range pruned {
+			zap.L().Sugar().Debugf("Removing unused requirement '%s' from %s in execution unit: %s", requirement, requirementsTxt.Path(), unit.Name)
+		}
+		unit.Executable.AddPrunedDependencies(requirementsTxt.Path(), pruned...)
 	}
-	return err
 }
 
 func resolveDefaultEntrypoint(unit *types.ExecutionUnit) {
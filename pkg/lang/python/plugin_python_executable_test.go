@@ -243,3 +243,57 @@ type taggedFile struct {
 	content string
 	tag     string
 }
+
+func Test_pruneUnusedRequirements(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements string
+		source       string
+		keep         []string
+		want         []string
+	}{
+		{
+			name:         "database driver loaded from a connection url",
+			requirements: "SQLAlchemy==2.0.0\npsycopg2-binary==2.9.5\nPyMySQL\nrequests\n",
+			source:       "from sqlalchemy import create_engine\nengine = create_engine('postgresql+psycopg2://db')",
+			want:         []string{"requests"},
+		},
+		{
+			name:         "distribution whose module has another name",
+			requirements: "PyYAML\nbeautifulsoup4\npython-dateutil\n",
+			source:       "import yaml\nfrom dateutil import parser",
+			want:         []string{"beautifulsoup4"},
+		},
+		{
+			name:         "server started by a command",
+			requirements: "fastapi\nuvicorn[standard]\ngunicorn\n",
+			source:       "from fastapi import FastAPI",
+		},
+		{
+			name:         "short module names do not keep other distributions",
+			requirements: "six\nsimplejson\nrequests\n",
+			source:       "import six",
+			want:         []string{"simplejson", "requests"},
+		},
+		{
+			name:         "unknown and configured distributions are kept",
+			requirements: "some-internal-lib\nrequests\nredis\n",
+			source:       "import os",
+			keep:         []string{"Redis"},
+			want:         []string{"requests"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert2.New(t)
+
+			unit := execUnit("main",
+				taggedFile{path: "requirements.txt", tag: "resource", content: tt.requirements},
+				taggedFile{path: "main.py", tag: "entrypoint", content: tt.source},
+			)
+			pruneUnusedRequirements(unit, tt.keep)
+
+			assert.Equal(tt.want, unit.Executable.PrunedDependencies["requirements.txt"])
+		})
+	}
+}
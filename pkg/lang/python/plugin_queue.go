@@ -0,0 +1,148 @@
+package python
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Queue creates a `types.Queue` for each `@klotho::queue` annotation.
+//
+// An annotated `Queue()` assignment is replaced by the runtime's queue, and the execution units which include its file are producers.
+// An annotated module-level function is the queue's consumer, which the unit's dispatcher passes each message to.
+type Queue struct {
+	cfg     *config.Application
+	runtime Runtime
+
+	queues map[string]*types.Queue
+}
+
+const queueRuntimeVar = "klotho_queue"
+
+var queueRE = regexp.MustCompile(`(?:\w+\.)?\bQueue\(.*\)`)
+
+func (p Queue) Name() string { return "Queue" }
+
+func (p Queue) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	p.queues = make(map[string]*types.Queue)
+
+	var errs multierr.Error
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			pySource, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(pySource); owner != "" && owner != unit.Name {
+				continue
+			}
+			if err := p.handleFile(pySource, unit, constructGraph); err != nil {
+				errs.Append(klotho_errors.WrapErrf(err, "failed to handle queue in unit %s", unit.Name))
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *Queue) handleFile(f *types.SourceFile, unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	content := string(f.Program())
+	rewritten := false
+	for _, annot := range f.Annotations() {
+		if annot.Capability.Name != annotation.QueueCapability {
+			continue
+		}
+		id := annot.Capability.ID
+		if id == "" {
+			errs.Append(types.NewCompilerError(f, annot, errors.New("'id' is required")))
+			continue
+		}
+
+		if fn := scheduledFunction(annot.Node); fn != nil {
+			if err := p.addConsumer(f, fn, unit, id, constructGraph); err != nil {
+				errs.Append(types.NewCompilerError(f, annot, err))
+			}
+			continue
+		}
+
+		expr := annot.Node.Content()
+		if !queueRE.MatchString(expr) {
+			errs.Append(types.NewCompilerError(f, annot, errors.New("@klotho::queue must annotate a `Queue()` assignment or a consumer function")))
+			continue
+		}
+		newExpr := queueRE.ReplaceAllString(expr, fmt.Sprintf(`%s.Queue("%s", "%s")`, queueRuntimeVar, id, types.QueueUrlEnvVarName(id)))
+		content = strings.Replace(content, expr, newExpr, 1)
+		rewritten = true
+
+		queue := p.queue(id, constructGraph)
+		constructGraph.AddDependency(unit.Id(), queue.Id())
+		unit.EnvironmentVariables.Add(types.GenerateQueueUrlEnvVar(queue))
+		errs.Append(p.runtime.AddQueueRuntimeFiles(unit))
+	}
+	if !rewritten {
+		return errs.ErrOrNil()
+	}
+
+	if err := f.Reparse([]byte(content)); err != nil {
+		errs.Append(errors.Wrap(err, "could not reparse Queue transformation"))
+		return errs.ErrOrNil()
+	}
+	importString := p.runtime.GetQueueRuntimeImportClass(queueRuntimeVar)
+	if !strings.Contains(content, importString) {
+		errs.Append(AddRuntimeImport(importString, f))
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *Queue) addConsumer(f *types.SourceFile, fn *sitter.Node, unit *types.ExecutionUnit, id string, constructGraph *construct.ConstructGraph) error {
+	definition := fn
+	if parent := fn.Parent(); parent != nil && parent.Type() == "decorated_definition" {
+		definition = parent
+	}
+	if parent := definition.Parent(); parent == nil || parent.Type() != "module" {
+		return errors.New("queue consumers must be defined at the module level")
+	}
+	consumer := &types.QueueConsumer{
+		ExecUnitName: unit.Name,
+		ModuleName:   pathToPythonModule(f.Path()),
+		FunctionName: fn.ChildByFieldName("name").Content(),
+	}
+	queue := p.queue(id, constructGraph)
+	switch {
+	case queue.Consumer == nil:
+		queue.Consumer = consumer
+	case *queue.Consumer == *consumer:
+	case queue.Consumer.ModuleName == consumer.ModuleName && queue.Consumer.FunctionName == consumer.FunctionName:
+		return errors.Errorf(
+			"queue consumer is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+			queue.Consumer.ExecUnitName, consumer.ExecUnitName,
+		)
+	default:
+		return errors.Errorf("queue '%s' already has a consumer: %s.%s", queue.Name, queue.Consumer.ModuleName, queue.Consumer.FunctionName)
+	}
+	constructGraph.AddDependency(queue.Id(), unit.Id())
+	unit.EnvironmentVariables.Add(types.GenerateQueueUrlEnvVar(queue))
+	return p.runtime.AddQueueRuntimeFiles(unit)
+}
+
+func (p *Queue) queue(id string, constructGraph *construct.ConstructGraph) *types.Queue {
+	if q, ok := p.queues[id]; ok {
+		return q
+	}
+	q := &types.Queue{Name: id}
+	if p.cfg != nil {
+		q = p.cfg.NewQueue(id)
+	}
+	p.queues[id] = q
+	constructGraph.AddConstruct(q)
+	return q
+}
@@ -0,0 +1,111 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_Transform(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		want         *types.Queue
+		wantProducer bool
+		wantContent  []string
+		wantErr      bool
+	}{
+		{
+			name: "producer",
+			source: `from queues import Queue
+
+# @klotho::queue {
+#   id = "orders"
+# }
+orders = Queue()
+
+def place(order):
+    orders.send(order)
+`,
+			want:         &types.Queue{Name: "orders"},
+			wantProducer: true,
+			wantContent: []string{
+				"import klotho_runtime.queue as klotho_queue",
+				`orders = klotho_queue.Queue("orders", "ORDERS_QUEUE_URL")`,
+			},
+		},
+		{
+			name: "consumer",
+			source: `# @klotho::queue {
+#   id = "orders"
+# }
+async def fulfill(order, message):
+    pass
+`,
+			want: &types.Queue{Name: "orders", Consumer: &types.QueueConsumer{ExecUnitName: "main", ModuleName: "app.orders", FunctionName: "fulfill"}},
+		},
+		{
+			name: "nested consumer",
+			source: `def outer():
+    # @klotho::queue {
+    #   id = "orders"
+    # }
+    def fulfill(order, message):
+        pass
+`,
+			wantErr: true,
+		},
+		{
+			name: "not a queue",
+			source: `# @klotho::queue {
+#   id = "orders"
+# }
+orders = []
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing id",
+			source: `# @klotho::queue
+def fulfill(order, message):
+    pass
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := execUnit("main", taggedFile{path: "app/orders.py", content: tt.source, tag: "entrypoint"})
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			err := Queue{runtime: NoopRuntime{}}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			content := string(unit.Get("app/orders.py").(*types.SourceFile).Program())
+			for _, want := range tt.wantContent {
+				assert.Contains(content, want)
+			}
+			queues := construct.GetConstructsOfType[*types.Queue](graph)
+			if !assert.Len(queues, 1) {
+				return
+			}
+			assert.Equal(tt.want, queues[0])
+			if tt.wantProducer {
+				assert.NotNil(graph.GetDependency(unit.Id(), queues[0].Id()))
+			}
+			if tt.want.Consumer != nil {
+				assert.NotNil(graph.GetDependency(queues[0].Id(), unit.Id()))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package python
+
+import (
+	"github.com/klothoplatform/klotho/pkg/annotation"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Schedule creates a `types.Schedule` for each module-level function annotated with `@klotho::schedule`.
+// The schedule targets the execution unit that owns the file, which invokes the function through its dispatcher.
+type Schedule struct{}
+
+func (p Schedule) Name() string { return "Schedule" }
+
+func (p Schedule) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	schedules := make(map[string]*types.Schedule)
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		for _, f := range unit.Files() {
+			pySource, ok := Language.ID.CastFile(f)
+			if !ok {
+				continue
+			}
+			if owner := types.FileExecUnitName(pySource); owner != "" && owner != unit.Name {
+				continue
+			}
+			for _, annot := range pySource.Annotations() {
+				if annot.Capability.Name != annotation.ScheduleCapability {
+					continue
+				}
+				schedule, err := newSchedule(pySource, annot, unit)
+				if err != nil {
+					errs.Append(types.NewCompilerError(pySource, annot, err))
+					continue
+				}
+				if existing, ok := schedules[schedule.Name]; ok {
+					if existing.ExecUnitName != unit.Name {
+						errs.Append(types.NewCompilerError(pySource, annot, errors.Errorf(
+							"schedule is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+							existing.ExecUnitName, unit.Name,
+						)))
+					}
+					continue
+				}
+				schedules[schedule.Name] = schedule
+				constructGraph.AddConstruct(schedule)
+				constructGraph.AddDependency(schedule.Id(), unit.Id())
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func newSchedule(f *types.SourceFile, annot *types.Annotation, unit *types.ExecutionUnit) (*types.Schedule, error) {
+	schedule, err := types.NewScheduleFromAnnotation(annot.Capability)
+	if err != nil {
+		return nil, err
+	}
+	fn := scheduledFunction(annot.Node)
+	if fn == nil {
+		return nil, errors.New("@klotho::schedule must annotate a function")
+	}
+	definition := fn
+	if parent := fn.Parent(); parent != nil && parent.Type() == "decorated_definition" {
+		definition = parent
+	}
+	if parent := definition.Parent(); parent == nil || parent.Type() != "module" {
+		return nil, errors.New("scheduled functions must be defined at the module level")
+	}
+	schedule.ExecUnitName = unit.Name
+	schedule.ModuleName = pathToPythonModule(f.Path())
+	schedule.FunctionName = fn.ChildByFieldName("name").Content()
+	return schedule, nil
+}
+
+// scheduledFunction returns the function definition at the annotated node, unwrapping any decorators.
+func scheduledFunction(n *sitter.Node) *sitter.Node {
+	if n == nil {
+		return nil
+	}
+	switch n.Type() {
+	case "function_definition":
+		return n
+	case "decorated_definition":
+		return scheduledFunction(n.ChildByFieldName("definition"))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Transform(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    *types.Schedule
+		wantErr bool
+	}{
+		{
+			name: "cron schedule",
+			source: `# @klotho::schedule {
+#   id = "nightly"
+#   cron = "0 3 * * *"
+# }
+def cleanup():
+    pass
+`,
+			want: &types.Schedule{Name: "nightly", Cron: "0 3 * * *", ExecUnitName: "main", ModuleName: "app.jobs", FunctionName: "cleanup"},
+		},
+		{
+			name: "rate schedule on decorated async function",
+			source: `import functools
+
+# @klotho::schedule {
+#   id = "poll"
+#   rate = "5 minutes"
+# }
+@functools.cache
+async def poll():
+    pass
+`,
+			want: &types.Schedule{Name: "poll", Rate: "5 minutes", ExecUnitName: "main", ModuleName: "app.jobs", FunctionName: "poll"},
+		},
+		{
+			name: "missing expression",
+			source: `# @klotho::schedule {
+#   id = "nightly"
+# }
+def cleanup():
+    pass
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid rate",
+			source: `# @klotho::schedule {
+#   id = "nightly"
+#   rate = "fortnightly"
+# }
+def cleanup():
+    pass
+`,
+			wantErr: true,
+		},
+		{
+			name: "not a function",
+			source: `# @klotho::schedule {
+#   id = "nightly"
+#   rate = "1 day"
+# }
+x = 1
+`,
+			wantErr: true,
+		},
+		{
+			name: "nested function",
+			source: `def outer():
+    # @klotho::schedule {
+    #   id = "nightly"
+    #   rate = "1 day"
+    # }
+    def inner():
+        pass
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := execUnit("main", taggedFile{path: "app/jobs.py", content: tt.source, tag: "entrypoint"})
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+
+			err := Schedule{}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			schedules := construct.GetConstructsOfType[*types.Schedule](graph)
+			if !assert.Len(schedules, 1) {
+				return
+			}
+			assert.Equal(tt.want, schedules[0])
+			assert.NotNil(graph.GetDependency(schedules[0].Id(), unit.Id()))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package python
+
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	klotho_errors "github.com/klothoplatform/klotho/pkg/errors"
+	"github.com/klothoplatform/klotho/pkg/logging"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/query"
+	"github.com/pkg/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"go.uber.org/zap"
+)
+
+// WebSocket creates a `types.WebSocketGateway` for the `@app.websocket` handler of an exposed FastAPI app.
+//
+// The unit's dispatcher runs the handler for each event of the gateway's WebSocket API.
+// It must run after `Expose`, which creates the gateways of the exposed apps.
+type WebSocket struct {
+	cfg     *config.Application
+	runtime Runtime
+}
+
+const webSocketLibraryFastAPI = "fastapi"
+
+func (p WebSocket) Name() string { return "WebSocket" }
+
+func (p WebSocket) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, gw := range construct.GetConstructsOfType[*types.Gateway](constructGraph) {
+		for _, c := range constructGraph.GetDownstreamConstructs(gw) {
+			unit, ok := c.(*types.ExecutionUnit)
+			if !ok {
+				continue
+			}
+			pySource, ok := Language.ID.CastFile(unit.Get(gw.DefinedIn))
+			if !ok {
+				continue
+			}
+			if err := p.handleFile(pySource, gw, unit, constructGraph); err != nil {
+				errs.Append(klotho_errors.WrapErrf(err, "failed to handle websocket in unit %s", unit.Name))
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
+
+func (p *WebSocket) handleFile(f *types.SourceFile, gw *types.Gateway, unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
+	log := zap.L().With(logging.FileField(f)).Sugar()
+
+	handlers, err := findWebSocketHandlers(f, gw.ExportVarName)
+	if err != nil || len(handlers) == 0 {
+		return err
+	}
+	if len(handlers) > 1 {
+		return errors.Errorf("only one WebSocket route may be defined on exposed app %s", gw.Name)
+	}
+	handler := handlers[0]
+
+	if unitType := p.cfg.GetResourceType(unit); unitType != "lambda" {
+		log.Warnf("WebSocket route of gateway %s is not exposed: WebSocket APIs can only target lambda execution units, not %s", gw.Name, unitType)
+		return nil
+	}
+
+	wsGw := &types.WebSocketGateway{
+		Name:          gw.Name,
+		Path:          sanitizeFastapiPath(handler.path),
+		ExecUnitName:  unit.Name,
+		HandledInFile: f.Path(),
+		Library:       webSocketLibraryFastAPI,
+		ModuleName:    pathToPythonModule(f.Path()),
+		FunctionName:  handler.functionName,
+	}
+	if existing, ok := constructGraph.GetConstruct(wsGw.Id()).(*types.WebSocketGateway); ok {
+		if existing.ExecUnitName != wsGw.ExecUnitName {
+			return errors.Errorf(
+				"WebSocket route of gateway %s is in a file shared by execution units '%s' and '%s'; annotate the file with @klotho::execution_unit to choose one",
+				gw.Name, existing.ExecUnitName, wsGw.ExecUnitName,
+			)
+		}
+	} else {
+		constructGraph.AddConstruct(wsGw)
+	}
+	log.Infof("Found WebSocket route %s for gateway %s", wsGw.Path, gw.Name)
+	constructGraph.AddDependency(wsGw.Id(), unit.Id())
+	unit.EnvironmentVariables.Add(types.GenerateWebSocketEndpointEnvVar(wsGw))
+	return p.runtime.AddWebSocketRuntimeFiles(unit)
+}
+
+type webSocketHandler struct {
+	path         string
+	functionName string
+}
+
+// findWebSocketHandlers finds the module-level functions decorated with `@<appVarName>.websocket(path)`.
+func findWebSocketHandlers(f *types.SourceFile, appVarName string) ([]webSocketHandler, error) {
+	var handlers []webSocketHandler
+	nextMatch := DoQuery(f.Tree().RootNode(), exposeVerb)
+	for {
+		match, found := nextMatch()
+		if !found {
+			break
+		}
+		appName, verb, routePath := match["appName"], match["verb"], match["path"]
+		if !query.NodeContentEquals(appName, appVarName) || !query.NodeContentEquals(verb, "websocket") {
+			continue
+		}
+		if argname := match["argname"]; argname != nil && !query.NodeContentEquals(argname, "path") {
+			continue
+		}
+		path, err := stringLiteralContent(routePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid websocket path")
+		}
+		fn := decoratedFunction(query.FirstAncestorOfType(verb, "decorator"))
+		if fn == nil {
+			continue
+		}
+		if definition := fn.Parent(); definition.Parent() == nil || definition.Parent().Type() != "module" {
+			return nil, errors.Errorf("WebSocket route %s must be handled by a module-level function", path)
+		}
+		handlers = append(handlers, webSocketHandler{path: path, functionName: fn.ChildByFieldName("name").Content()})
+	}
+	return handlers, nil
+}
+
+// decoratedFunction returns the function definition that `decorator` decorates.
+func decoratedFunction(decorator *sitter.Node) *sitter.Node {
+	if decorator == nil || decorator.Parent() == nil || decorator.Parent().Type() != "decorated_definition" {
+		return nil
+	}
+	return scheduledFunction(decorator.Parent())
+}
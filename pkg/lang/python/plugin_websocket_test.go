@@ -0,0 +1,128 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSocket_Transform(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		unitType string
+		want     *types.WebSocketGateway
+		wantErr  bool
+	}{
+		{
+			name: "websocket route",
+			source: `from fastapi import FastAPI, WebSocket
+
+app = FastAPI()
+
+@app.websocket("/ws/{room}")
+async def chat(websocket: WebSocket, room: str):
+    await websocket.accept()
+`,
+			unitType: "lambda",
+			want: &types.WebSocketGateway{
+				Name:          "gw",
+				Path:          "/ws/:room",
+				ExecUnitName:  "main",
+				HandledInFile: "app/main.py",
+				Library:       "fastapi",
+				ModuleName:    "app.main",
+				FunctionName:  "chat",
+			},
+		},
+		{
+			name: "path keyword",
+			source: `@app.websocket(path="/ws")
+async def chat(websocket):
+    pass
+`,
+			unitType: "lambda",
+			want: &types.WebSocketGateway{
+				Name:          "gw",
+				Path:          "/ws",
+				ExecUnitName:  "main",
+				HandledInFile: "app/main.py",
+				Library:       "fastapi",
+				ModuleName:    "app.main",
+				FunctionName:  "chat",
+			},
+		},
+		{
+			name: "http routes only",
+			source: `@app.get("/")
+async def root():
+    pass
+`,
+			unitType: "lambda",
+		},
+		{
+			name: "other app",
+			source: `@other.websocket("/ws")
+async def chat(websocket):
+    pass
+`,
+			unitType: "lambda",
+		},
+		{
+			name: "not exposed from fargate",
+			source: `@app.websocket("/ws")
+async def chat(websocket):
+    pass
+`,
+			unitType: "ecs",
+		},
+		{
+			name: "multiple routes",
+			source: `@app.websocket("/a")
+async def a(websocket):
+    pass
+
+@app.websocket("/b")
+async def b(websocket):
+    pass
+`,
+			unitType: "lambda",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unit := execUnit("main", taggedFile{path: "app/main.py", content: tt.source, tag: "entrypoint"})
+			gw := &types.Gateway{Name: "gw", DefinedIn: "app/main.py", ExportVarName: "app"}
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(unit)
+			graph.AddConstruct(gw)
+			graph.AddDependency(gw.Id(), unit.Id())
+
+			cfg := &config.Application{Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: tt.unitType}}}
+			err := WebSocket{cfg: cfg, runtime: NoopRuntime{}}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			gateways := construct.GetConstructsOfType[*types.WebSocketGateway](graph)
+			if tt.want == nil {
+				assert.Empty(gateways)
+				return
+			}
+			if !assert.Len(gateways, 1) {
+				return
+			}
+			assert.Equal(tt.want, gateways[0])
+			assert.NotNil(graph.GetDependency(gateways[0].Id(), unit.Id()))
+		})
+	}
+}
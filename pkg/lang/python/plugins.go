@@ -18,8 +18,13 @@ func NewPythonPlugins(cfg *config.Application, runtime Runtime) *PythonPlugins {
 	return &PythonPlugins{
 		Plugins: []compiler.AnalysisAndTransformationPlugin{
 			&Expose{},
+			&WebSocket{cfg: cfg, runtime: runtime},
+			&Queue{cfg: cfg, runtime: runtime},
+			&FsEvent{cfg: cfg},
 			&AddExecRuntimeFiles{cfg: cfg, runtime: runtime},
 			&Persist{runtime: runtime},
+			&Proxy{cfg: cfg, runtime: runtime},
+			&Schedule{},
 		},
 	}
 }
@@ -34,6 +34,12 @@ var (
 
 	//go:embed queries/find_qualified_attr_usage.scm
 	FindQualifiedAttrUsage string
+
+	//go:embed queries/proxy/call.scm
+	proxyCall string
+
+	//go:embed queries/proxy/reference.scm
+	proxyReference string
 )
 
 // DoQuery is a thin wrapper around `query.Exec` to use python as the Language.
@@ -0,0 +1,12 @@
+; Finds calls to functions that may belong to another execution unit:
+; • module.function(args)
+; • package.module.function(args)
+; • function(args)
+(call
+  function: [
+              (attribute
+                object: (_) @obj
+                attribute: (identifier) @function)
+              (identifier) @function
+            ]
+  arguments: (argument_list) @args) @call
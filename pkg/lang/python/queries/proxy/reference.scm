@@ -0,0 +1,5 @@
+; Finds all names and qualified names that may refer to an imported module or function
+[
+  (identifier)
+  (attribute)
+] @reference
@@ -0,0 +1,129 @@
+package python
+
+import (
+	"sort"
+	"strings"
+)
+
+type requirementModule struct {
+	requirement string
+	modules     []string
+}
+
+// requirementModules lists, by normalized distribution name, the top-level modules which popular distributions install
+// (the modules in the distribution's top_level.txt, e.g. from `pip show -f <distribution>`). Only the requirements in
+// this table can be pruned, since the modules of any other distribution are unknown and it may be imported under a
+// different name. To support another distribution, add it in sorted order: lookups use a binary search.
+var requirementModules = []requirementModule{
+	{"aiohttp", []string{"aiohttp"}},
+	{"alembic", []string{"alembic"}},
+	{"attrs", []string{"attr", "attrs"}},
+	{"aws_xray_sdk", []string{"aws_xray_sdk"}},
+	{"azure_identity", []string{"azure"}},
+	{"azure_storage_blob", []string{"azure"}},
+	{"beautifulsoup4", []string{"bs4"}},
+	{"boto3", []string{"boto3"}},
+	{"botocore", []string{"botocore"}},
+	{"celery", []string{"celery"}},
+	{"click", []string{"click"}},
+	{"cryptography", []string{"cryptography"}},
+	{"django", []string{"django"}},
+	{"djangorestframework", []string{"rest_framework"}},
+	{"elasticsearch", []string{"elasticsearch"}},
+	{"fastapi", []string{"fastapi"}},
+	{"flask", []string{"flask"}},
+	{"flask_sqlalchemy", []string{"flask_sqlalchemy"}},
+	{"google_api_python_client", []string{"googleapiclient", "apiclient"}},
+	{"google_cloud_pubsub", []string{"google"}},
+	{"google_cloud_storage", []string{"google"}},
+	{"httpx", []string{"httpx"}},
+	{"jinja2", []string{"jinja2"}},
+	{"jsonschema", []string{"jsonschema"}},
+	{"mangum", []string{"mangum"}},
+	{"marshmallow", []string{"marshmallow"}},
+	{"numpy", []string{"numpy"}},
+	{"opencv_python", []string{"cv2"}},
+	{"opentelemetry_api", []string{"opentelemetry"}},
+	{"opentelemetry_sdk", []string{"opentelemetry"}},
+	{"pandas", []string{"pandas"}},
+	{"pillow", []string{"PIL"}},
+	{"protobuf", []string{"google"}},
+	{"pydantic", []string{"pydantic"}},
+	{"pyjwt", []string{"jwt"}},
+	{"pymongo", []string{"pymongo", "bson", "gridfs"}},
+	{"pynamodb", []string{"pynamodb"}},
+	{"python_dateutil", []string{"dateutil"}},
+	{"python_dotenv", []string{"dotenv"}},
+	{"python_multipart", []string{"multipart"}},
+	{"pytz", []string{"pytz"}},
+	{"pyyaml", []string{"yaml"}},
+	{"redis", []string{"redis"}},
+	{"requests", []string{"requests"}},
+	{"scikit_learn", []string{"sklearn"}},
+	{"scipy", []string{"scipy"}},
+	{"sentry_sdk", []string{"sentry_sdk"}},
+	{"simplejson", []string{"simplejson"}},
+	{"six", []string{"six"}},
+	{"sqlalchemy", []string{"sqlalchemy"}},
+	{"starlette", []string{"starlette"}},
+	{"tenacity", []string{"tenacity"}},
+	{"typing_extensions", []string{"typing_extensions"}},
+	{"ujson", []string{"ujson"}},
+	{"urllib3", []string{"urllib3"}},
+	{"websockets", []string{"websockets"}},
+	{"werkzeug", []string{"werkzeug"}},
+}
+
+// runtimeRequirements are used without being imported by the unit's source files: database drivers which ORMs load
+// from a connection url, servers which are started by a command and plugins which are loaded through entry points.
+// They are never pruned. Like requirementModules, the list is sorted by normalized name.
+var runtimeRequirements = []string{
+	"aiomysql",
+	"aiosqlite",
+	"asyncpg",
+	"cx_oracle",
+	"daphne",
+	"eventlet",
+	"gevent",
+	"gunicorn",
+	"httptools",
+	"hypercorn",
+	"mysqlclient",
+	"oracledb",
+	"psycopg",
+	"psycopg2",
+	"psycopg2_binary",
+	"psycopg_binary",
+	"pymssql",
+	"pymysql",
+	"pyodbc",
+	"uvicorn",
+	"uvloop",
+	"waitress",
+}
+
+// requirementIsUsed reports whether the requirement distribution must be kept, given the top-level modules which the
+// unit's source files import and the configured requirements which are always kept.
+func requirementIsUsed(requirement string, imported map[string]struct{}, keep map[string]struct{}) bool {
+	name := normalizeRequirementName(requirement)
+	if _, ok := keep[name]; ok {
+		return true
+	}
+	if i := sort.SearchStrings(runtimeRequirements, name); i < len(runtimeRequirements) && runtimeRequirements[i] == name {
+		return true
+	}
+	i := sort.Search(len(requirementModules), func(i int) bool { return requirementModules[i].requirement >= name })
+	if i == len(requirementModules) || requirementModules[i].requirement != name {
+		return true
+	}
+	for _, module := range requirementModules[i].modules {
+		if _, ok := imported[module]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeRequirementName(name string) string {
+	return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,53 @@
+package python
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_requirementTablesSorted(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(sort.SliceIsSorted(requirementModules, func(i, j int) bool {
+		return requirementModules[i].requirement < requirementModules[j].requirement
+	}), "requirementModules must be sorted by requirement")
+	assert.True(sort.StringsAreSorted(runtimeRequirements), "runtimeRequirements must be sorted")
+	for _, entry := range requirementModules {
+		assert.Equal(normalizeRequirementName(entry.requirement), entry.requirement, "requirementModules must use normalized names")
+	}
+	for _, requirement := range runtimeRequirements {
+		assert.Equal(normalizeRequirementName(requirement), requirement, "runtimeRequirements must use normalized names")
+	}
+}
+
+func Test_requirementIsUsed(t *testing.T) {
+	tests := []struct {
+		name        string
+		requirement string
+		imported    []string
+		keep        []string
+		want        bool
+	}{
+		{name: "imported module", requirement: "PyYAML", imported: []string{"yaml"}, want: true},
+		{name: "module which is not imported", requirement: "pyyaml", want: false},
+		{name: "first entry of the table", requirement: "aiohttp", want: false},
+		{name: "last entry of the table", requirement: "websockets", want: false},
+		{name: "unknown distribution", requirement: "my-internal-lib", want: true},
+		{name: "runtime requirement", requirement: "psycopg2-binary", want: true},
+		{name: "kept requirement", requirement: "requests", keep: []string{"requests"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := make(map[string]struct{})
+			for _, module := range tt.imported {
+				imported[module] = struct{}{}
+			}
+			keep := make(map[string]struct{})
+			for _, requirement := range tt.keep {
+				keep[requirement] = struct{}{}
+			}
+			assert.Equal(t, tt.want, requirementIsUsed(tt.requirement, imported, keep))
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package python
 
 import (
+	"bufio"
+	"bytes"
 	"io"
+	"strings"
 
 	klotho_io "github.com/klothoplatform/klotho/pkg/io"
 )
@@ -31,6 +34,43 @@ func (pf *RequirementsTxt) AddLine(text string) {
 	pf.extras = append(pf.extras, text)
 }
 
+// PruneRequirements removes the requirement lines whose distribution name is not kept by keep, returning the
+// removed names. Options (e.g. `-r other.txt`) and URL or path requirements are always kept.
+func (pf *RequirementsTxt) PruneRequirements(keep func(name string) bool) []string {
+	var pruned []string
+	var kept bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(pf.contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name := requirementName(line); name != "" && !keep(name) {
+			pruned = append(pruned, name)
+			continue
+		}
+		kept.WriteString(line + "\n")
+	}
+	if len(pruned) > 0 {
+		// contents may be shared with the original file, so replace it rather than modifying it
+		pf.contents = kept.Bytes()
+	}
+	return pruned
+}
+
+// requirementName returns the distribution name of a requirement specifier line (e.g. "requests" for
+// "requests[socks]>=2.0 ; python_version > '3'"), or an empty string if the line does not name a distribution.
+func requirementName(line string) string {
+	line = strings.TrimSpace(line)
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") || strings.ContainsAny(line[:1], "./") {
+		return ""
+	}
+	if i := strings.IndexAny(line, "=<>!~;[ @"); i >= 0 {
+		line = line[:i]
+	}
+	return line
+}
+
 func (pf *RequirementsTxt) Clone() klotho_io.File {
 	clone := &RequirementsTxt{
 		contents: make([]byte, len(pf.contents)),
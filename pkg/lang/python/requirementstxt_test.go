@@ -109,3 +109,29 @@ two
 
 	})
 }
+
+func TestRequirementsTxt_PruneRequirements(t *testing.T) {
+	assert := assert.New(t)
+
+	pip := &RequirementsTxt{
+		path: "requirements.txt",
+		contents: []byte(`# web
+fastapi==0.88.0
+uvicorn[standard] >= 0.20 ; python_version > "3.7"
+PyYAML
+-r extra-requirements.txt
+./local-package
+boto3
+`),
+	}
+	used := map[string]bool{"fastapi": true, "PyYAML": true}
+	pruned := pip.PruneRequirements(func(name string) bool { return used[name] })
+
+	assert.Equal([]string{"uvicorn", "boto3"}, pruned)
+	assert.Equal(`# web
+fastapi==0.88.0
+PyYAML
+-r extra-requirements.txt
+./local-package
+`, string(pip.contents))
+}
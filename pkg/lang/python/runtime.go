@@ -27,9 +27,13 @@ type (
 		AddOrmRuntimeFiles(unit *types.ExecutionUnit) error
 		AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType string) error
 		AddSecretRuntimeFiles(unit *types.ExecutionUnit) error
+		AddQueueRuntimeFiles(unit *types.ExecutionUnit) error
+		AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error
 		GetKvRuntimeConfig() KVConfig
 		GetFsRuntimeImportClass(id string, varName string) string
 		GetSecretRuntimeImportClass(varName string) string
+		GetQueueRuntimeImportClass(varName string) string
+		GetProxyRuntimeImportClass(proxyType string, varName string) string
 		GetAppName() string
 	}
 )
@@ -139,13 +143,15 @@ func AddRuntimeImport(importString string, file *types.SourceFile) error {
 
 	content := file.Program()
 	insertionPoint := uint32(0)
+	insertion := "\n" + importString
 	if lastImport != nil {
 		insertionPoint = lastImport.EndByte()
 	} else if firstExpression != nil {
 		insertionPoint = firstExpression.StartByte()
+		insertion = importString + "\n"
 	}
 
-	contentStr := string(content[0:insertionPoint]) + "\n" + importString + string(content[insertionPoint:])
+	contentStr := string(content[0:insertionPoint]) + insertion + string(content[insertionPoint:])
 	err := file.Reparse([]byte(contentStr))
 	if err != nil {
 		return errors.Wrap(err, "could not reparse inserted import")
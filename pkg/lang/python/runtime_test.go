@@ -60,6 +60,10 @@ func (n NoopRuntime) AddSecretRuntimeFiles(unit *types.ExecutionUnit) error { re
 
 func (n NoopRuntime) AddOrmRuntimeFiles(unit *types.ExecutionUnit) error { return nil }
 
+func (n NoopRuntime) AddQueueRuntimeFiles(unit *types.ExecutionUnit) error { return nil }
+
+func (n NoopRuntime) AddWebSocketRuntimeFiles(unit *types.ExecutionUnit) error { return nil }
+
 func (n NoopRuntime) GetFsRuntimeImportClass(id string, varName string) string {
 	return fmt.Sprintf("import klotho_runtime.fs_%s as %s", id, varName)
 }
@@ -68,10 +72,18 @@ func (n NoopRuntime) AddProxyRuntimeFiles(unit *types.ExecutionUnit, proxyType s
 	return nil
 }
 
+func (n NoopRuntime) GetProxyRuntimeImportClass(proxyType string, varName string) string {
+	return fmt.Sprintf("import klotho_runtime.%s_proxy as %s", proxyType, varName)
+}
+
 func (n NoopRuntime) GetSecretRuntimeImportClass(varName string) string {
 	return fmt.Sprintf("import klotho_runtime.secret as %s", varName)
 }
 
+func (n NoopRuntime) GetQueueRuntimeImportClass(varName string) string {
+	return fmt.Sprintf("import klotho_runtime.queue as %s", varName)
+}
+
 func (n NoopRuntime) GetKvRuntimeConfig() KVConfig {
 	return KVConfig{
 		Imports: "import keyvalue",
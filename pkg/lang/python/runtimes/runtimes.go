@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/lang"
 	"github.com/klothoplatform/klotho/pkg/lang/python"
 	"github.com/klothoplatform/klotho/pkg/lang/python/aws_runtime"
 )
@@ -11,7 +12,15 @@ import (
 func GetRuntime(cfg *config.Application) (python.Runtime, error) {
 	switch cfg.Provider {
 	case "gcp", "azure":
-		// TODO GCP and Azure is hacked to be the same as AWS so we can generate a topology diagram, but the compilation won't work.
+		// Only JavaScript units have GCP and Azure runtimes.
+		return nil, &lang.UnsupportedProviderError{Language: "Python", Provider: cfg.Provider}
+	case "local":
+		// The local provider runs units in containers, which use the same runtime as those of aws, pointed at local
+		// emulators of its services.
+		fallthrough
+	case "kubernetes":
+		// Units on an existing cluster run in the same containers as those of eks, and only use in-cluster services
+		// which the aws runtime's shims connect to as they would to aws' (such as postgres and redis).
 		fallthrough
 	case "aws":
 		return &aws_runtime.AwsRuntime{
@@ -0,0 +1,32 @@
+package lang
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+)
+
+// UnsupportedCapabilities fails the compilation on the annotations of the capabilities which the runtimes of a language
+// do not implement, instead of compiling them into resources which never reach the annotated code (e.g. a schedule
+// for a unit which has no dispatcher to run the scheduled function).
+type UnsupportedCapabilities struct {
+	Language     types.LanguageId
+	Capabilities []string
+}
+
+func (p UnsupportedCapabilities) Name() string { return "UnsupportedCapabilities" }
+
+func (p UnsupportedCapabilities) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, f := range input.FilesOfLang(p.Language) {
+		for _, annot := range f.Annotations() {
+			if collectionutil.Contains(p.Capabilities, annot.Capability.Name) {
+				errs.Append(types.NewCompilerError(f, annot, fmt.Errorf("@klotho::%s is not supported in %s", annot.Capability.Name, p.Language)))
+			}
+		}
+	}
+	return errs.ErrOrNil()
+}
@@ -0,0 +1,41 @@
+package lang
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+)
+
+type (
+	// UnsupportedProviderError is returned when a language has no runtime for the provider, so that its execution
+	// units cannot be deployed to it.
+	UnsupportedProviderError struct {
+		Language string
+		Provider string
+	}
+
+	// UnsupportedExecutables fails the compilation on each execution unit of Type, whose language has no runtime for
+	// the provider, instead of failing the compilation of applications which have no such units.
+	UnsupportedExecutables struct {
+		Type types.ExecutableType
+		Err  error
+	}
+)
+
+func (err *UnsupportedProviderError) Error() string {
+	return fmt.Sprintf("%s execution units are not supported by the %s provider", err.Language, err.Provider)
+}
+
+func (p UnsupportedExecutables) Name() string { return "UnsupportedExecutables" }
+
+func (p UnsupportedExecutables) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, unit := range construct.GetConstructsOfType[*types.ExecutionUnit](constructGraph) {
+		if unit.Executable.Type == p.Type {
+			errs.Append(fmt.Errorf("execution unit %s: %w", unit.Name, p.Err))
+		}
+	}
+	return errs.ErrOrNil()
+}
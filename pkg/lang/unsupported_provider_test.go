@@ -0,0 +1,51 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsupportedExecutables(t *testing.T) {
+	tests := []struct {
+		name    string
+		units   map[string]types.ExecutableType
+		wantErr []string
+	}{
+		{
+			name:  "no units of the type",
+			units: map[string]types.ExecutableType{"web": types.ExecutableTypeNodeJS},
+		},
+		{
+			name:    "units of the type",
+			units:   map[string]types.ExecutableType{"web": types.ExecutableTypeNodeJS, "api": types.ExecutableTypePython, "jobs": types.ExecutableTypePython},
+			wantErr: []string{"execution unit api: Python execution units are not supported by the gcp provider", "execution unit jobs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			graph := construct.NewConstructGraph()
+			for name, executableType := range tt.units {
+				unit := &types.ExecutionUnit{Name: name, Executable: types.NewExecutable()}
+				unit.Executable.Type = executableType
+				graph.AddConstruct(unit)
+			}
+
+			p := UnsupportedExecutables{
+				Type: types.ExecutableTypePython,
+				Err:  &UnsupportedProviderError{Language: "Python", Provider: "gcp"},
+			}
+			err := p.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if len(tt.wantErr) == 0 {
+				assert.NoError(err)
+				return
+			}
+			for _, want := range tt.wantErr {
+				assert.ErrorContains(err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ormmigration
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"go.uber.org/zap"
+)
+
+type (
+	// OrmMigrations adds a `types.OrmMigration` for each `types.Orm` whose `config.Persist` has migrations. The migration
+	// depends on the orm, so that it runs once the database exists, and on the execution unit whose image runs it.
+	//
+	// It must run after the language plugins, which create the orms.
+	OrmMigrations struct {
+		Config *config.Application
+	}
+)
+
+// migrationUnitTypes are the execution unit types which can run a one-off migration from their image
+var migrationUnitTypes = map[string]bool{
+	"lambda": true,
+	"ecs":    true,
+}
+
+func (p OrmMigrations) Name() string { return "OrmMigrations" }
+
+func (p OrmMigrations) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, orm := range construct.GetConstructsOfType[*types.Orm](constructGraph) {
+		cfg := p.Config.GetPersistOrm(orm.Name)
+		if cfg.Migrations == nil {
+			continue
+		}
+		unit, err := p.migrationUnit(orm, cfg.Migrations, constructGraph)
+		if err != nil {
+			errs.Append(fmt.Errorf("invalid migrations for orm %s: %w", orm.Name, err))
+			continue
+		}
+		migration, err := types.NewOrmMigration(orm, cfg.Migrations.Tool, cfg.Migrations.Command)
+		if err != nil {
+			errs.Append(fmt.Errorf("invalid migrations for orm %s: %w", orm.Name, err))
+			continue
+		}
+		constructGraph.AddConstruct(migration)
+		constructGraph.AddDependency(migration.Id(), orm.Id())
+		constructGraph.AddDependency(migration.Id(), unit.Id())
+		zap.S().Debugf("Migrations of orm %s run '%s' in execution unit %s", orm.Name, migration.Command, unit.Name)
+	}
+	return errs.ErrOrNil()
+}
+
+// migrationUnit returns the execution unit which runs the orm's migrations: the configured one, or the only unit which
+// uses the orm.
+func (p OrmMigrations) migrationUnit(orm *types.Orm, cfg *config.OrmMigrations, constructGraph *construct.ConstructGraph) (*types.ExecutionUnit, error) {
+	var units []*types.ExecutionUnit
+	for _, upstream := range constructGraph.GetUpstreamConstructs(orm) {
+		if unit, ok := upstream.(*types.ExecutionUnit); ok {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Name < units[j].Name })
+
+	var unit *types.ExecutionUnit
+	switch {
+	case cfg.ExecutionUnit != "":
+		for _, u := range units {
+			if u.Name == cfg.ExecutionUnit {
+				unit = u
+			}
+		}
+		if unit == nil {
+			return nil, fmt.Errorf("execution unit '%s' does not use the orm", cfg.ExecutionUnit)
+		}
+	case len(units) == 1:
+		unit = units[0]
+	case len(units) == 0:
+		return nil, fmt.Errorf("no execution unit uses the orm")
+	default:
+		var names []string
+		for _, u := range units {
+			names = append(names, u.Name)
+		}
+		return nil, fmt.Errorf("execution_unit must be one of %v, the execution units which use the orm", names)
+	}
+
+	unitType := p.Config.GetResourceType(unit)
+	if !migrationUnitTypes[unitType] {
+		return nil, fmt.Errorf("migrations can only run in lambda or ecs execution units, not %s (%s)", unitType, unit.Name)
+	}
+	// lambda functions run the migration command from their dispatcher, which only the javascript and python runtimes have
+	if unitType == "lambda" && unit.Executable.Type != types.ExecutableTypeNodeJS && unit.Executable.Type != types.ExecutableTypePython {
+		return nil, fmt.Errorf("migrations can only run in lambda execution units written in javascript or python, not %s (%s)", unit.Executable.Type, unit.Name)
+	}
+	return unit, nil
+}
@@ -0,0 +1,127 @@
+package ormmigration
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OrmMigrations(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations *config.OrmMigrations
+		units      []string
+		unitType   string
+		executable types.ExecutableType
+		want       *types.OrmMigration
+		wantUnit   string
+		wantErr    bool
+	}{
+		{
+			name:     "no migrations",
+			units:    []string{"api"},
+			unitType: "lambda",
+		},
+		{
+			name:       "default command",
+			migrations: &config.OrmMigrations{Tool: "prisma"},
+			units:      []string{"api"},
+			unitType:   "lambda",
+			executable: types.ExecutableTypeNodeJS,
+			want:       &types.OrmMigration{Name: "db", Tool: "prisma", Command: `DATABASE_URL="$DB_PERSIST_ORM_CONNECTION" npx prisma migrate deploy`},
+			wantUnit:   "api",
+		},
+		{
+			name:       "command override in configured unit",
+			migrations: &config.OrmMigrations{Tool: "golang-migrate", Command: "migrate -path db/migrations up", ExecutionUnit: "worker"},
+			units:      []string{"api", "worker"},
+			unitType:   "ecs",
+			executable: types.ExecutableTypeGolang,
+			want:       &types.OrmMigration{Name: "db", Tool: "golang-migrate", Command: "migrate -path db/migrations up"},
+			wantUnit:   "worker",
+		},
+		{
+			name:       "ambiguous unit",
+			migrations: &config.OrmMigrations{Tool: "alembic"},
+			units:      []string{"api", "worker"},
+			unitType:   "ecs",
+			executable: types.ExecutableTypePython,
+			wantErr:    true,
+		},
+		{
+			name:       "unit does not use the orm",
+			migrations: &config.OrmMigrations{Tool: "alembic", ExecutionUnit: "other"},
+			units:      []string{"api"},
+			unitType:   "ecs",
+			executable: types.ExecutableTypePython,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported tool",
+			migrations: &config.OrmMigrations{Tool: "flyway"},
+			units:      []string{"api"},
+			unitType:   "ecs",
+			executable: types.ExecutableTypeNodeJS,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported unit type",
+			migrations: &config.OrmMigrations{Tool: "typeorm"},
+			units:      []string{"api"},
+			unitType:   "eks",
+			executable: types.ExecutableTypeNodeJS,
+			wantErr:    true,
+		},
+		{
+			name:       "lambda without dispatcher",
+			migrations: &config.OrmMigrations{Tool: "efcore"},
+			units:      []string{"api"},
+			unitType:   "lambda",
+			executable: types.ExecutableTypeCSharp,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			graph := construct.NewConstructGraph()
+			orm := &types.Orm{Name: "db"}
+			graph.AddConstruct(orm)
+			for _, name := range tt.units {
+				unit := &types.ExecutionUnit{Name: name, Executable: types.Executable{Type: tt.executable}}
+				graph.AddConstruct(unit)
+				graph.AddDependency(unit.Id(), orm.Id())
+			}
+
+			cfg := &config.Application{
+				Defaults: config.Defaults{ExecutionUnit: config.KindDefaults{Type: tt.unitType}},
+			}
+			if tt.migrations != nil {
+				cfg.PersistOrm = map[string]*config.Persist{"db": {Migrations: tt.migrations}}
+			}
+			err := OrmMigrations{Config: cfg}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			migrations := construct.GetConstructsOfType[*types.OrmMigration](graph)
+			if tt.want == nil {
+				assert.Empty(migrations)
+				return
+			}
+			if !assert.Len(migrations, 1) {
+				return
+			}
+			assert.Equal(tt.want, migrations[0])
+			assert.NotNil(graph.GetDependency(tt.want.Id(), orm.Id()))
+			assert.NotNil(graph.GetDependency(tt.want.Id(), (&types.ExecutionUnit{Name: tt.wantUnit}).Id()))
+		})
+	}
+}
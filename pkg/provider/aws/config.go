@@ -17,6 +17,7 @@ const (
 	Elasticache            = "elasticache"
 	Memorydb               = "memorydb"
 	Sns                    = "sns"
+	Sqs                    = "sqs"
 	Cockroachdb_serverless = "cockroachdb_serverless"
 	ApiGateway             = "apigateway"
 	Alb                    = "alb"
@@ -44,6 +45,15 @@ var (
 		Timeout: 180,
 		Memory:  512,
 	}
+
+	queueDefaults = config.QueueTypeParams{
+		VisibilityTimeout: 30,
+		BatchSize:         10,
+		DeadLetterQueue: config.DeadLetterQueueParams{
+			Enabled:         true,
+			MaxReceiveCount: 5,
+		},
+	}
 )
 
 var defaultConfig = config.Defaults{
@@ -109,6 +119,12 @@ var defaultConfig = config.Defaults{
 			Memorydb: config.ConvertToInfraParams(config.InfraParams{}),
 		},
 	},
+	Queue: config.KindDefaults{
+		Type: Sqs,
+		InfraParamsByType: map[string]config.InfraParams{
+			Sqs: config.ConvertToInfraParams(queueDefaults),
+		},
+	},
 }
 
 func (a *AWS) GetDefaultConfig() config.Defaults {
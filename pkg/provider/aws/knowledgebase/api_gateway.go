@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/construct"
 	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
 	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
@@ -28,6 +29,36 @@ var ApiGatewayKB = knowledgebase.Build(
 			return configureIntegration(integration, dag)
 		},
 	},
+	knowledgebase.EdgeBuilder[*resources.WebSocketStage, *resources.WebSocketApi]{
+		Configure: func(stage *resources.WebSocketStage, api *resources.WebSocketApi, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			gw := api.GatewayConstruct()
+			if gw == nil {
+				return nil
+			}
+			// the functions integrated with the api post to its connections through the stage's management endpoint
+			for _, integration := range construct.GetDownstreamResourcesOfType[*resources.WebSocketIntegration](dag, api) {
+				for _, function := range construct.GetDownstreamResourcesOfType[*resources.LambdaFunction](dag, integration) {
+					if function.EnvironmentVariables == nil {
+						function.EnvironmentVariables = map[string]construct.IaCValue{}
+					}
+					function.EnvironmentVariables[types.WebSocketEndpointEnvVarName(gw.Name)] = construct.IaCValue{ResourceId: stage.Id(), Property: resources.WEBSOCKET_MANAGEMENT_ENDPOINT_IAC_VALUE}
+					dag.AddDependency(function, stage)
+				}
+			}
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.IamRole, *resources.WebSocketApi]{
+		Configure: func(role *resources.IamRole, api *resources.WebSocketApi, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			actions := []string{"execute-api:ManageConnections"}
+			policyResources := []construct.IaCValue{{ResourceId: api.Id(), Property: resources.API_GATEWAY_EXECUTION_CHILD_RESOURCES_IAC_VALUE}}
+			doc := resources.CreateAllowPolicyDocument(actions, policyResources)
+			inlinePol := resources.NewIamInlinePolicy(fmt.Sprintf("%s-connections-policy", api.Name), role.ConstructRefs.CloneWith(api.ConstructRefs), doc)
+			role.InlinePolicies = append(role.InlinePolicies, inlinePol)
+			return nil
+		},
+		DirectEdgeOnly: true,
+	},
 )
 
 func configureIntegration(integration *resources.ApiIntegration, dag *construct.ResourceGraph) error {
@@ -0,0 +1,146 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+type (
+	// AlarmRule describes the CloudWatch alarm which is attached to each resource of ResourceType that it applies to
+	AlarmRule struct {
+		// Name identifies the rule, and is used to override its threshold in the config
+		Name               string
+		ResourceType       string
+		Description        string
+		Namespace          string
+		MetricName         string
+		Statistic          string
+		Period             int
+		EvaluationPeriods  int
+		Threshold          float64
+		ComparisonOperator string
+		// Dimensions returns the dimensions which select the metric of the resource, or nil if the rule does not apply to
+		// the resource
+		Dimensions func(res construct.Resource) map[string]construct.IaCValue
+	}
+)
+
+// AlarmRules are the alarms which are attached to the resources of an application with alarms enabled
+var AlarmRules = []AlarmRule{
+	{
+		Name:               "lambda_errors",
+		ResourceType:       resources.LAMBDA_FUNCTION_TYPE,
+		Description:        "invocations of the function failed",
+		Namespace:          "AWS/Lambda",
+		MetricName:         "Errors",
+		Statistic:          "Sum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          1,
+		ComparisonOperator: "GreaterThanOrEqualToThreshold",
+		Dimensions:         dimension("FunctionName", resources.NAME_IAC_VALUE),
+	},
+	{
+		Name:               "lambda_throttles",
+		ResourceType:       resources.LAMBDA_FUNCTION_TYPE,
+		Description:        "invocations of the function were throttled",
+		Namespace:          "AWS/Lambda",
+		MetricName:         "Throttles",
+		Statistic:          "Sum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          1,
+		ComparisonOperator: "GreaterThanOrEqualToThreshold",
+		Dimensions:         dimension("FunctionName", resources.NAME_IAC_VALUE),
+	},
+	{
+		Name:               "alb_5xx",
+		ResourceType:       resources.LOAD_BALANCER_TYPE,
+		Description:        "the load balancer responded with 5xx errors",
+		Namespace:          "AWS/ApplicationELB",
+		MetricName:         "HTTPCode_ELB_5XX_Count",
+		Statistic:          "Sum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          10,
+		ComparisonOperator: "GreaterThanOrEqualToThreshold",
+		Dimensions:         applicationLoadBalancerDimension,
+	},
+	{
+		Name:               "alb_target_5xx",
+		ResourceType:       resources.LOAD_BALANCER_TYPE,
+		Description:        "the targets of the load balancer responded with 5xx errors",
+		Namespace:          "AWS/ApplicationELB",
+		MetricName:         "HTTPCode_Target_5XX_Count",
+		Statistic:          "Sum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          10,
+		ComparisonOperator: "GreaterThanOrEqualToThreshold",
+		Dimensions:         applicationLoadBalancerDimension,
+	},
+	{
+		Name:               "rds_cpu",
+		ResourceType:       resources.RDS_INSTANCE_TYPE,
+		Description:        "the CPU utilization of the database is high",
+		Namespace:          "AWS/RDS",
+		MetricName:         "CPUUtilization",
+		Statistic:          "Average",
+		Period:             300,
+		EvaluationPeriods:  3,
+		Threshold:          80,
+		ComparisonOperator: "GreaterThanThreshold",
+		Dimensions:         dimension("DBInstanceIdentifier", resources.ID_IAC_VALUE),
+	},
+	{
+		Name:               "rds_free_storage",
+		ResourceType:       resources.RDS_INSTANCE_TYPE,
+		Description:        "the database is running out of storage",
+		Namespace:          "AWS/RDS",
+		MetricName:         "FreeStorageSpace",
+		Statistic:          "Minimum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          2 * 1024 * 1024 * 1024,
+		ComparisonOperator: "LessThanThreshold",
+		Dimensions:         dimension("DBInstanceIdentifier", resources.ID_IAC_VALUE),
+	},
+	{
+		Name:               "sqs_oldest_message_age",
+		ResourceType:       resources.SQS_QUEUE_TYPE,
+		Description:        "messages of the queue are not being consumed",
+		Namespace:          "AWS/SQS",
+		MetricName:         "ApproximateAgeOfOldestMessage",
+		Statistic:          "Maximum",
+		Period:             300,
+		EvaluationPeriods:  1,
+		Threshold:          900,
+		ComparisonOperator: "GreaterThanOrEqualToThreshold",
+		Dimensions:         dimension("QueueName", resources.NAME_IAC_VALUE),
+	},
+}
+
+// AlarmRuleNames returns the names of the alarm rules
+func AlarmRuleNames() []string {
+	names := make([]string, len(AlarmRules))
+	for i, rule := range AlarmRules {
+		names[i] = rule.Name
+	}
+	return names
+}
+
+func dimension(name string, property string) func(res construct.Resource) map[string]construct.IaCValue {
+	return func(res construct.Resource) map[string]construct.IaCValue {
+		return map[string]construct.IaCValue{name: {ResourceId: res.Id(), Property: property}}
+	}
+}
+
+// applicationLoadBalancerDimension selects the metrics of application load balancers, since network load balancers
+// do not serve http responses
+func applicationLoadBalancerDimension(res construct.Resource) map[string]construct.IaCValue {
+	lb, ok := res.(*resources.LoadBalancer)
+	if !ok || lb.Type != "application" {
+		return nil
+	}
+	return map[string]construct.IaCValue{"LoadBalancer": {ResourceId: lb.Id(), Property: resources.ARN_SUFFIX_IAC_VALUE}}
+}
@@ -10,10 +10,14 @@ func GetAwsKnowledgeBase() (knowledgebase.EdgeKB, error) {
 		CloudfrontKB,
 		EcsKB,
 		ElasticacheKB,
+		EventBridgeKB,
 		IamKB,
 		LambdaKB,
 		Ec2KB,
 		EksKB,
+		SqsKB,
+		SsmKB,
+		StepFunctionsKB,
 	}
 	return knowledgebase.MergeKBs(kbsToUse)
 }
@@ -0,0 +1,118 @@
+package knowledgebase
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+const (
+	// scheduleModuleEnvVar and scheduleFunctionEnvVar tell a scheduled ECS task which function to run instead of starting its server
+	scheduleModuleEnvVar   = "KLOTHO_SCHEDULE_MODULE"
+	scheduleFunctionEnvVar = "KLOTHO_SCHEDULE_FUNCTION"
+)
+
+var EventBridgeKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.EventBridgeTarget, *resources.LambdaFunction]{
+		Configure: func(target *resources.EventBridgeTarget, function *resources.LambdaFunction, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			schedule, err := targetSchedule(target, dag)
+			if err != nil {
+				return err
+			}
+			target.Function = function
+			input, err := json.Marshal(map[string]string{
+				"__callType":       "schedule",
+				"__moduleName":     schedule.ModuleName,
+				"__functionToCall": schedule.FunctionName,
+			})
+			if err != nil {
+				return err
+			}
+			target.Input = string(input)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.EventBridgeTarget, *resources.EcsService]{
+		Configure: func(target *resources.EventBridgeTarget, service *resources.EcsService, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			schedule, err := targetSchedule(target, dag)
+			if err != nil {
+				return err
+			}
+			taskDef := service.TaskDefinition
+			if taskDef == nil || taskDef.ExecutionRole == nil {
+				return fmt.Errorf("cannot configure event bridge target %s -> ecs service %s, missing task definition or execution role", target.Id(), service.Id())
+			}
+			var role *resources.IamRole
+			for _, res := range dag.GetDownstreamResources(target) {
+				if r, ok := res.(*resources.IamRole); ok {
+					role = r
+				}
+			}
+			if role == nil {
+				return fmt.Errorf("cannot configure event bridge target %s -> ecs service %s, missing role", target.Id(), service.Id())
+			}
+			target.EcsService = service
+			target.Role = role
+
+			role.AssumeRolePolicyDoc = resources.EVENTS_ASSUMER_ROLE_POLICY
+			runTaskPolicy := &resources.PolicyDocument{
+				Version: resources.VERSION,
+				Statement: []resources.StatementEntry{
+					{
+						Effect:   "Allow",
+						Action:   []string{"ecs:RunTask"},
+						Resource: []construct.IaCValue{{ResourceId: taskDef.Id(), Property: resources.ARN_IAC_VALUE}},
+					},
+					{
+						Effect:   "Allow",
+						Action:   []string{"iam:PassRole"},
+						Resource: []construct.IaCValue{{ResourceId: taskDef.ExecutionRole.Id(), Property: resources.ARN_IAC_VALUE}},
+					},
+				},
+			}
+			role.InlinePolicies = append(role.InlinePolicies, resources.NewIamInlinePolicy(fmt.Sprintf("%s-runtask", target.Name), role.ConstructRefs.CloneWith(service.ConstructRefs), runTaskPolicy))
+
+			input, err := json.Marshal(map[string]any{
+				"containerOverrides": []any{
+					map[string]any{
+						"name": taskDef.Name,
+						"environment": []map[string]string{
+							{"name": scheduleModuleEnvVar, "value": schedule.ModuleName},
+							{"name": scheduleFunctionEnvVar, "value": schedule.FunctionName},
+						},
+					},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			target.Input = string(input)
+			return nil
+		},
+	},
+)
+
+// targetSchedule returns the schedule construct that the target's rule was expanded from
+func targetSchedule(target *resources.EventBridgeTarget, dag *construct.ResourceGraph) (*types.Schedule, error) {
+	rule := target.Rule
+	if rule == nil {
+		for _, res := range dag.GetDownstreamResources(target) {
+			if r, ok := res.(*resources.EventBridgeRule); ok {
+				rule = r
+			}
+		}
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("event bridge target %s is not fully operational yet, missing rule", target.Id())
+	}
+	for _, ref := range rule.ConstructRefs {
+		if schedule, ok := ref.(*types.Schedule); ok {
+			return schedule, nil
+		}
+	}
+	return nil, fmt.Errorf("event bridge rule %s was not created from a schedule", rule.Id())
+}
@@ -33,6 +33,15 @@ var IamKB = knowledgebase.Build(
 		},
 		DirectEdgeOnly: true,
 	},
+	knowledgebase.EdgeBuilder[*resources.IamRole, *resources.SsmParameter]{
+		Configure: func(role *resources.IamRole, param *resources.SsmParameter, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			paramPolicyDoc := resources.CreateAllowPolicyDocument([]string{"ssm:GetParameter"}, []construct.IaCValue{{ResourceId: param.Id(), Property: resources.ARN_IAC_VALUE}})
+			inlinePol := resources.NewIamInlinePolicy(fmt.Sprintf("%s-parameterpolicy", param.Name), role.ConstructRefs.CloneWith(param.ConstructRefs), paramPolicyDoc)
+			role.InlinePolicies = append(role.InlinePolicies, inlinePol)
+			return nil
+		},
+		DirectEdgeOnly: true,
+	},
 	knowledgebase.EdgeBuilder[*resources.IamRole, *resources.IamPolicy]{
 		Configure: func(role *resources.IamRole, policy *resources.IamPolicy, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
 			role.AddManagedPolicy(construct.IaCValue{ResourceId: policy.Id(), Property: resources.ARN_IAC_VALUE})
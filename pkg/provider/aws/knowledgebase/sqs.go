@@ -0,0 +1,111 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+var SqsKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.IamRole, *resources.SqsQueue]{
+		Configure: func(role *resources.IamRole, queue *resources.SqsQueue, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			actions := []string{"sqs:SendMessage", "sqs:ReceiveMessage", "sqs:DeleteMessage", "sqs:GetQueueAttributes", "sqs:ChangeMessageVisibility"}
+			policyResources := []construct.IaCValue{{ResourceId: queue.Id(), Property: resources.ARN_IAC_VALUE}}
+			doc := resources.CreateAllowPolicyDocument(actions, policyResources)
+			inlinePol := resources.NewIamInlinePolicy(fmt.Sprintf("%s-sqs-policy", queue.Name), role.ConstructRefs.CloneWith(queue.ConstructRefs), doc)
+			role.InlinePolicies = append(role.InlinePolicies, inlinePol)
+			return nil
+		},
+		DirectEdgeOnly: true,
+	},
+	knowledgebase.EdgeBuilder[*resources.LambdaFunction, *resources.SqsQueue]{
+		Configure: func(function *resources.LambdaFunction, queue *resources.SqsQueue, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if err := queue.Configure(resources.SqsQueueConfigureParams{}); err != nil {
+				return err
+			}
+			if function.EnvironmentVariables == nil {
+				function.EnvironmentVariables = map[string]construct.IaCValue{}
+			}
+			setQueueUrlEnvVar(function.EnvironmentVariables, queue)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.EcsService, *resources.SqsQueue]{
+		Configure: func(service *resources.EcsService, queue *resources.SqsQueue, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if err := queue.Configure(resources.SqsQueueConfigureParams{}); err != nil {
+				return err
+			}
+			return setTaskQueueUrlEnvVar(service, queue)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.SqsQueue, *resources.LambdaFunction]{
+		Configure: func(queue *resources.SqsQueue, function *resources.LambdaFunction, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if err := queue.Configure(resources.SqsQueueConfigureParams{}); err != nil {
+				return err
+			}
+			// SQS requires the visibility timeout to be at least the timeout of the function it triggers
+			if function.Timeout > queue.VisibilityTimeout {
+				queue.VisibilityTimeout = function.Timeout
+			}
+			queue.EnsureDeadLetterQueue(dag)
+			if function.EnvironmentVariables == nil {
+				function.EnvironmentVariables = map[string]construct.IaCValue{}
+			}
+			setQueueUrlEnvVar(function.EnvironmentVariables, queue)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.SqsQueue, *resources.EcsService]{
+		Configure: func(queue *resources.SqsQueue, service *resources.EcsService, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if err := queue.Configure(resources.SqsQueueConfigureParams{}); err != nil {
+				return err
+			}
+			queue.EnsureDeadLetterQueue(dag)
+			return setTaskQueueUrlEnvVar(service, queue)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.SqsQueue, *resources.SqsQueue]{
+		DirectEdgeOnly: true,
+	},
+	knowledgebase.EdgeBuilder[*resources.LambdaEventSourceMapping, *resources.SqsQueue]{
+		Configure: func(mapping *resources.LambdaEventSourceMapping, queue *resources.SqsQueue, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			mapping.Queue = queue
+			if queueConstruct := queue.QueueConstruct(); queueConstruct != nil && queueConstruct.BatchSize > 0 {
+				mapping.BatchSize = queueConstruct.BatchSize
+			}
+			// the dispatcher reports the messages whose consumer failed, so that the rest of the batch is not retried
+			mapping.FunctionResponseTypes = []string{"ReportBatchItemFailures"}
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.LambdaEventSourceMapping, *resources.LambdaFunction]{
+		Configure: func(mapping *resources.LambdaEventSourceMapping, function *resources.LambdaFunction, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			mapping.Function = function
+			return nil
+		},
+	},
+)
+
+// setQueueUrlEnvVar sets the environment variable which the queue runtime reads the queue's url from
+func setQueueUrlEnvVar(envVars map[string]construct.IaCValue, queue *resources.SqsQueue) {
+	name := queue.Name
+	if queueConstruct := queue.QueueConstruct(); queueConstruct != nil {
+		name = queueConstruct.Name
+	}
+	envVars[types.QueueUrlEnvVarName(name)] = construct.IaCValue{ResourceId: queue.Id(), Property: resources.QUEUE_URL_IAC_VALUE}
+}
+
+func setTaskQueueUrlEnvVar(service *resources.EcsService, queue *resources.SqsQueue) error {
+	taskDef := service.TaskDefinition
+	if taskDef == nil {
+		return fmt.Errorf("cannot configure ecs service %s -> sqs queue %s, missing task definition", service.Id(), queue.Id())
+	}
+	if taskDef.EnvironmentVariables == nil {
+		taskDef.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	setQueueUrlEnvVar(taskDef.EnvironmentVariables, queue)
+	return nil
+}
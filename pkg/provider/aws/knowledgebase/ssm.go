@@ -0,0 +1,38 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+// ssmSecretsBackend tells the secret runtime to read secrets from SSM parameters
+var ssmSecretsBackend = construct.IaCValue{Property: types.KLOTHO_SECRETS_BACKEND_SSM}
+
+var SsmKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.LambdaFunction, *resources.SsmParameter]{
+		Configure: func(function *resources.LambdaFunction, param *resources.SsmParameter, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if function.EnvironmentVariables == nil {
+				function.EnvironmentVariables = map[string]construct.IaCValue{}
+			}
+			function.EnvironmentVariables[types.KLOTHO_SECRETS_BACKEND] = ssmSecretsBackend
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.EcsService, *resources.SsmParameter]{
+		Configure: func(service *resources.EcsService, param *resources.SsmParameter, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			taskDef := service.TaskDefinition
+			if taskDef == nil {
+				return fmt.Errorf("cannot configure ecs service %s -> ssm parameter %s, missing task definition", service.Id(), param.Id())
+			}
+			if taskDef.EnvironmentVariables == nil {
+				taskDef.EnvironmentVariables = map[string]construct.IaCValue{}
+			}
+			taskDef.EnvironmentVariables[types.KLOTHO_SECRETS_BACKEND] = ssmSecretsBackend
+			return nil
+		},
+	},
+)
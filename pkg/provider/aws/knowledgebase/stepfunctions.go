@@ -0,0 +1,52 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+var StepFunctionsKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.SfnStateMachine, *resources.LambdaFunction]{
+		Configure: func(machine *resources.SfnStateMachine, function *resources.LambdaFunction, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			role := machine.EnsureRole(dag)
+			doc := resources.CreateAllowPolicyDocument(
+				[]string{"lambda:InvokeFunction"},
+				[]construct.IaCValue{{ResourceId: function.Id(), Property: resources.ARN_IAC_VALUE}},
+			)
+			inlinePol := resources.NewIamInlinePolicy(fmt.Sprintf("%s-%s-invoke", machine.Name, function.Name), role.ConstructRefs.CloneWith(function.ConstructRefs), doc)
+			role.InlinePolicies = append(role.InlinePolicies, inlinePol)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.SfnStateMachine, *resources.IamRole]{
+		DirectEdgeOnly: true,
+	},
+	knowledgebase.EdgeBuilder[*resources.LambdaFunction, *resources.SfnStateMachine]{
+		Configure: func(function *resources.LambdaFunction, machine *resources.SfnStateMachine, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			if function.EnvironmentVariables == nil {
+				function.EnvironmentVariables = map[string]construct.IaCValue{}
+			}
+			name := machine.Name
+			if workflow := machine.WorkflowConstruct(); workflow != nil {
+				name = workflow.Name
+			}
+			function.EnvironmentVariables[types.WorkflowArnEnvVarName(name)] = construct.IaCValue{ResourceId: machine.Id(), Property: resources.ARN_IAC_VALUE}
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.IamRole, *resources.SfnStateMachine]{
+		Configure: func(role *resources.IamRole, machine *resources.SfnStateMachine, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			doc := resources.CreateAllowPolicyDocument(
+				[]string{"states:StartExecution", "states:StartSyncExecution"},
+				[]construct.IaCValue{{ResourceId: machine.Id(), Property: resources.ARN_IAC_VALUE}},
+			)
+			role.InlinePolicies = append(role.InlinePolicies, resources.NewIamInlinePolicy(fmt.Sprintf("%s-sfn-policy", machine.Name), role.ConstructRefs.CloneWith(machine.ConstructRefs), doc))
+			return nil
+		},
+		DirectEdgeOnly: true,
+	},
+)
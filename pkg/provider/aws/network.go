@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+)
+
+// ImportNetwork adds the vpc, subnets and security groups of an existing network to the construct graph, so that the
+// engine places the application's resources into them instead of creating a network for them. It returns the ids of
+// the resources which it added, mapped to the ids of the existing resources they are imported from.
+func ImportNetwork(network config.Network, dag *construct.ConstructGraph) (map[construct.ResourceId]string, error) {
+	if !strings.HasPrefix(network.Vpc, "vpc-") {
+		return nil, fmt.Errorf("invalid vpc id '%s' for the network", network.Vpc)
+	}
+	if len(network.Subnets) == 0 {
+		return nil, fmt.Errorf("the network of vpc %s has no subnets to place resources in", network.Vpc)
+	}
+
+	imported := make(map[construct.ResourceId]string)
+	vpc := &resources.Vpc{Name: network.Vpc, ConstructRefs: make(construct.BaseConstructSet)}
+	dag.AddConstruct(vpc)
+	imported[vpc.Id()] = network.Vpc
+
+	var errs multierr.Error
+	seen := make(map[string]bool)
+	for _, s := range network.Subnets {
+		if !strings.HasPrefix(s.Id, "subnet-") {
+			errs.Append(fmt.Errorf("invalid subnet id '%s' for the network of vpc %s", s.Id, network.Vpc))
+			continue
+		}
+		if s.Type != resources.PrivateSubnet && s.Type != resources.PublicSubnet {
+			errs.Append(fmt.Errorf("subnet %s has type '%s', but must be either %s or %s", s.Id, s.Type, resources.PrivateSubnet, resources.PublicSubnet))
+			continue
+		}
+		if seen[s.Id] {
+			errs.Append(fmt.Errorf("subnet %s is imported more than once", s.Id))
+			continue
+		}
+		seen[s.Id] = true
+		subnet := &resources.Subnet{Name: s.Id, ConstructRefs: make(construct.BaseConstructSet), Vpc: vpc, Type: s.Type}
+		dag.AddConstruct(subnet)
+		dag.AddDependency(subnet.Id(), vpc.Id())
+		imported[subnet.Id()] = s.Id
+	}
+	for _, id := range network.SecurityGroups {
+		if !strings.HasPrefix(id, "sg-") {
+			errs.Append(fmt.Errorf("invalid security group id '%s' for the network of vpc %s", id, network.Vpc))
+			continue
+		}
+		if seen[id] {
+			errs.Append(fmt.Errorf("security group %s is imported more than once", id))
+			continue
+		}
+		seen[id] = true
+		sg := &resources.SecurityGroup{Name: id, ConstructRefs: make(construct.BaseConstructSet), Vpc: vpc}
+		dag.AddConstruct(sg)
+		dag.AddDependency(sg.Id(), vpc.Id())
+		imported[sg.Id()] = id
+	}
+	return imported, errs.ErrOrNil()
+}
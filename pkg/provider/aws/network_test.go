@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportNetwork(t *testing.T) {
+	vpc := &resources.Vpc{Name: "vpc-1"}
+	tests := []struct {
+		name     string
+		network  config.Network
+		want     map[construct.ResourceId]string
+		wantDeps []construct.ResourceId
+		wantErr  bool
+	}{
+		{
+			name: "vpc with subnets and security groups",
+			network: config.Network{
+				Vpc: "vpc-1",
+				Subnets: []config.Subnet{
+					{Id: "subnet-1", Type: resources.PrivateSubnet},
+					{Id: "subnet-2", Type: resources.PublicSubnet},
+				},
+				SecurityGroups: []string{"sg-1"},
+			},
+			want: map[construct.ResourceId]string{
+				vpc.Id(): "vpc-1",
+				(&resources.Subnet{Name: "subnet-1", Vpc: vpc, Type: resources.PrivateSubnet}).Id(): "subnet-1",
+				(&resources.Subnet{Name: "subnet-2", Vpc: vpc, Type: resources.PublicSubnet}).Id():  "subnet-2",
+				(&resources.SecurityGroup{Name: "sg-1", Vpc: vpc}).Id():                             "sg-1",
+			},
+			wantDeps: []construct.ResourceId{
+				(&resources.Subnet{Name: "subnet-1", Vpc: vpc, Type: resources.PrivateSubnet}).Id(),
+				(&resources.Subnet{Name: "subnet-2", Vpc: vpc, Type: resources.PublicSubnet}).Id(),
+				(&resources.SecurityGroup{Name: "sg-1", Vpc: vpc}).Id(),
+			},
+		},
+		{
+			name:    "invalid vpc id",
+			network: config.Network{Vpc: "my-vpc", Subnets: []config.Subnet{{Id: "subnet-1", Type: resources.PrivateSubnet}}},
+			wantErr: true,
+		},
+		{
+			name:    "no subnets",
+			network: config.Network{Vpc: "vpc-1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid subnet type",
+			network: config.Network{Vpc: "vpc-1", Subnets: []config.Subnet{{Id: "subnet-1", Type: resources.IsolatedSubnet}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate subnet",
+			network: config.Network{Vpc: "vpc-1", Subnets: []config.Subnet{
+				{Id: "subnet-1", Type: resources.PrivateSubnet},
+				{Id: "subnet-1", Type: resources.PublicSubnet},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid security group id",
+			network: config.Network{Vpc: "vpc-1", Subnets: []config.Subnet{{Id: "subnet-1", Type: resources.PrivateSubnet}}, SecurityGroups: []string{"default"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewConstructGraph()
+			got, err := ImportNetwork(tt.network, dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got)
+			for id := range tt.want {
+				assert.NotNil(dag.GetConstruct(id), "missing %s", id)
+			}
+			for _, id := range tt.wantDeps {
+				assert.NotNil(dag.GetDependency(id, vpc.Id()), "missing dependency %s -> %s", id, vpc.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"go.uber.org/zap"
+)
+
+// ObservabilityPlugin attaches the alarms of the knowledge base's alarm rules to the resources of the application, routes
+// them to an SNS topic which the configured emails are subscribed to, and adds a dashboard of the alarms. When tracing is
+// enabled, it also configures where the execution units export the spans of their instrumented runtimes.
+type ObservabilityPlugin struct {
+	Config *config.Application
+}
+
+const (
+	otelEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// otelCollectorImage is the AWS Distro for OpenTelemetry collector, whose default ECS configuration receives OTLP
+	// on localhost and exports the traces to X-Ray
+	otelCollectorImage    = "public.ecr.aws/aws-observability/aws-otel-collector:v0.35.0"
+	otelCollectorConfig   = "--config=/etc/ecs/ecs-default-config.yaml"
+	otelCollectorEndpoint = "http://localhost:4318"
+	otelCollectorBuildArg = "OTEL_COLLECTOR_LAYER_URL"
+	xrayWritePolicy       = "arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess"
+)
+
+// otelCollectorLayers are the AWS Distro for OpenTelemetry collector's lambda layers, of the same release as
+// otelCollectorImage, whose extension receives OTLP on localhost and exports the traces to X-Ray. They are published
+// in each commercial region, and the layers of other regions are configured by the observability's collector. The
+// runtimes' lambda dockerfiles add the layer to the image from its build argument.
+var otelCollectorLayers = map[string]string{
+	"amd64": "arn:aws:lambda:{region}:901920570463:layer:aws-otel-collector-amd64-ver-0-90-1:1",
+	"arm64": "arn:aws:lambda:{region}:901920570463:layer:aws-otel-collector-arm64-ver-0-90-1:1",
+}
+
+var dashboardNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+func (p ObservabilityPlugin) Name() string {
+	return "observability"
+}
+
+func (p ObservabilityPlugin) Translate(result *construct.ConstructGraph, dag *construct.ResourceGraph) error {
+	observability := p.Config.Observability
+	if observability == nil {
+		return nil
+	}
+	if err := observability.Validate(knowledgebase.AlarmRuleNames()); err != nil {
+		return err
+	}
+	if observability.Tracing {
+		p.configureTracing(dag)
+	}
+	if !observability.Alarms {
+		return nil
+	}
+	return p.addAlarms(dag)
+}
+
+// configureTracing points the execution units at the endpoint which they export their spans to. Without a configured
+// endpoint, ECS tasks export to a collector sidecar and lambda functions to the collector's extension, which is built
+// into their image from the collector's layer.
+func (p ObservabilityPlugin) configureTracing(dag *construct.ResourceGraph) {
+	endpoint := p.Config.Observability.TracingEndpoint
+	for _, res := range dag.ListResources() {
+		if !hasExecutionUnit(res.BaseConstructRefs()) {
+			continue
+		}
+		switch res := res.(type) {
+		case *resources.LambdaFunction:
+			if res.EnvironmentVariables == nil {
+				res.EnvironmentVariables = make(map[string]construct.IaCValue)
+			}
+			if endpoint != "" {
+				res.EnvironmentVariables[otelEndpointEnvVar] = construct.IaCValue{Property: endpoint}
+				continue
+			}
+			if res.Image == nil {
+				zap.S().Warnf("%s will not export its spans, since it has no image to add the collector to", res.Id())
+				continue
+			}
+			res.EnvironmentVariables[otelEndpointEnvVar] = construct.IaCValue{Property: otelCollectorEndpoint}
+			if !hasLambdaLayer(res.Image, otelCollectorBuildArg) {
+				res.Image.LambdaLayers = append(res.Image.LambdaLayers, p.collectorLayer())
+			}
+			if res.Role != nil {
+				res.Role.AddAwsManagedPolicies([]string{xrayWritePolicy})
+			}
+		case *resources.EcsTaskDefinition:
+			if res.EnvironmentVariables == nil {
+				res.EnvironmentVariables = make(map[string]construct.IaCValue)
+			}
+			if endpoint != "" {
+				res.EnvironmentVariables[otelEndpointEnvVar] = construct.IaCValue{Property: endpoint}
+				continue
+			}
+			res.EnvironmentVariables[otelEndpointEnvVar] = construct.IaCValue{Property: otelCollectorEndpoint}
+			res.Sidecars = append(res.Sidecars, resources.EcsContainer{
+				Name:    "aws-otel-collector",
+				Image:   p.collectorImage(),
+				Command: []string{otelCollectorConfig},
+			})
+			if res.ExecutionRole != nil {
+				res.ExecutionRole.AddAwsManagedPolicies([]string{xrayWritePolicy})
+			}
+		}
+	}
+}
+
+// collectorImage returns the image of the collector which runs as a sidecar of tasks
+func (p ObservabilityPlugin) collectorImage() string {
+	if collector := p.Config.Observability.Collector; collector != nil && collector.Image != "" {
+		return collector.Image
+	}
+	return otelCollectorImage
+}
+
+// collectorLayer returns the layer of the collector which is added to the images of functions, with a version for
+// each of the default layers and those which the collector configures.
+func (p ObservabilityPlugin) collectorLayer() resources.LambdaLayer {
+	arns := make(map[string]string)
+	for key, arn := range otelCollectorLayers {
+		arns[key] = arn
+	}
+	if collector := p.Config.Observability.Collector; collector != nil {
+		for key, arn := range collector.Layers {
+			arns[key] = arn
+		}
+	}
+	layer := resources.LambdaLayer{BuildArg: otelCollectorBuildArg}
+	keys := collectionutil.Keys(arns)
+	sort.Strings(keys)
+	for _, key := range keys {
+		version := resources.LambdaLayerVersion{Architecture: key, Arn: arns[key]}
+		if region, arch, ok := strings.Cut(key, "/"); ok {
+			version.Region, version.Architecture = region, arch
+		}
+		layer.Versions = append(layer.Versions, version)
+	}
+	return layer
+}
+
+func hasLambdaLayer(image *resources.EcrImage, buildArg string) bool {
+	for _, layer := range image.LambdaLayers {
+		if layer.BuildArg == buildArg {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ObservabilityPlugin) addAlarms(dag *construct.ResourceGraph) error {
+	observability := p.Config.Observability
+	resourcesByType := make(map[string][]construct.Resource)
+	for _, res := range dag.ListResources() {
+		resourcesByType[res.Id().Type] = append(resourcesByType[res.Id().Type], res)
+	}
+
+	appName := p.Config.QualifiedAppName()
+	topic := &resources.SnsTopic{
+		Name:          fmt.Sprintf("%s-alarms", appName),
+		ConstructRefs: construct.BaseConstructSetOf(),
+	}
+	topicArn := construct.IaCValue{ResourceId: topic.Id(), Property: resources.ARN_IAC_VALUE}
+
+	var alarms []*resources.CloudwatchAlarm
+	for _, rule := range knowledgebase.AlarmRules {
+		targets := resourcesByType[rule.ResourceType]
+		sort.Slice(targets, func(i, j int) bool { return targets[i].Id().String() < targets[j].Id().String() })
+		for _, res := range targets {
+			dimensions := rule.Dimensions(res)
+			if dimensions == nil {
+				continue
+			}
+			threshold := rule.Threshold
+			if override, ok := observability.Thresholds[rule.Name]; ok {
+				threshold = override
+			}
+			alarm := &resources.CloudwatchAlarm{
+				Name:               fmt.Sprintf("%s-%s", res.Id().Name, rule.Name),
+				ConstructRefs:      res.BaseConstructRefs().Clone(),
+				AlarmDescription:   fmt.Sprintf("%s: %s", res.Id().Name, rule.Description),
+				Namespace:          rule.Namespace,
+				MetricName:         rule.MetricName,
+				Dimensions:         dimensions,
+				Statistic:          rule.Statistic,
+				Period:             rule.Period,
+				EvaluationPeriods:  rule.EvaluationPeriods,
+				Threshold:          threshold,
+				ComparisonOperator: rule.ComparisonOperator,
+				TreatMissingData:   "notBreaching",
+				AlarmActions:       []construct.IaCValue{topicArn},
+			}
+			dag.AddDependency(alarm, res)
+			dag.AddDependency(alarm, topic)
+			alarms = append(alarms, alarm)
+		}
+	}
+	if len(alarms) == 0 {
+		return nil
+	}
+
+	for _, email := range observability.AlarmEmails {
+		subscription := &resources.SnsSubscription{
+			Name:          fmt.Sprintf("%s-%s", topic.Name, email),
+			ConstructRefs: construct.BaseConstructSetOf(),
+			Endpoint:      construct.IaCValue{Property: email},
+			Protocol:      "email",
+			Topic:         topic,
+		}
+		dag.AddDependency(subscription, topic)
+	}
+
+	if observability.Dashboard {
+		region := resources.NewRegion()
+		if existing, ok := dag.GetResource(region.Id()).(*resources.Region); ok {
+			region = existing
+		}
+		dashboard := &resources.CloudwatchDashboard{
+			Name:          appName,
+			ConstructRefs: construct.BaseConstructSetOf(),
+			DashboardName: dashboardNameSanitizer.ReplaceAllString(appName, "-"),
+			Region:        region,
+			Alarms:        alarms,
+		}
+		dag.AddDependency(dashboard, region)
+		for _, alarm := range alarms {
+			dag.AddDependency(dashboard, alarm)
+		}
+	}
+	return nil
+}
+
+func hasExecutionUnit(refs construct.BaseConstructSet) bool {
+	for _, ref := range refs {
+		if _, ok := ref.(*types.ExecutionUnit); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObservabilityTranslate(t *testing.T) {
+	function := &resources.LambdaFunction{Name: "main"}
+	queue := &resources.SqsQueue{Name: "jobs"}
+	alb := &resources.LoadBalancer{Name: "alb", Type: "application"}
+	nlb := &resources.LoadBalancer{Name: "nlb", Type: "network"}
+	topic := &resources.SnsTopic{Name: "app-alarms"}
+
+	tests := []struct {
+		name          string
+		observability *config.Observability
+		wantAlarms    map[string]float64
+		wantEmails    []string
+		wantDashboard bool
+		wantErr       bool
+	}{
+		{
+			name:          "no observability",
+			observability: nil,
+		},
+		{
+			name:          "alarms disabled",
+			observability: &config.Observability{},
+		},
+		{
+			name:          "alarms for each rule of the resources",
+			observability: &config.Observability{Alarms: true, AlarmEmails: []string{"ops@example.com"}},
+			wantAlarms: map[string]float64{
+				"main-lambda_errors":          1,
+				"main-lambda_throttles":       1,
+				"alb-alb_5xx":                 10,
+				"alb-alb_target_5xx":          10,
+				"jobs-sqs_oldest_message_age": 900,
+			},
+			wantEmails: []string{"ops@example.com"},
+		},
+		{
+			name:          "threshold override and dashboard",
+			observability: &config.Observability{Alarms: true, Dashboard: true, Thresholds: map[string]float64{"lambda_errors": 5}},
+			wantAlarms: map[string]float64{
+				"main-lambda_errors":          5,
+				"main-lambda_throttles":       1,
+				"alb-alb_5xx":                 10,
+				"alb-alb_target_5xx":          10,
+				"jobs-sqs_oldest_message_age": 900,
+			},
+			wantDashboard: true,
+		},
+		{
+			name:          "unknown threshold",
+			observability: &config.Observability{Alarms: true, Thresholds: map[string]float64{"unknown": 5}},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			for _, res := range []construct.Resource{function, queue, alb, nlb} {
+				dag.AddResource(res)
+			}
+			cfg := &config.Application{AppName: "app", Observability: tt.observability}
+			err := ObservabilityPlugin{Config: cfg}.Translate(construct.NewConstructGraph(), dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+
+			alarms := construct.GetResources[*resources.CloudwatchAlarm](dag)
+			thresholds := make(map[string]float64)
+			for _, alarm := range alarms {
+				thresholds[alarm.Name] = alarm.Threshold
+				assert.Equal([]construct.IaCValue{{ResourceId: topic.Id(), Property: resources.ARN_IAC_VALUE}}, alarm.AlarmActions)
+				assert.NotNil(dag.GetDependency(alarm.Id(), topic.Id()))
+				assert.Len(dag.GetDownstreamResources(alarm), 2)
+			}
+			if len(tt.wantAlarms) == 0 {
+				assert.Empty(alarms)
+			} else {
+				assert.Equal(tt.wantAlarms, thresholds)
+			}
+
+			var emails []string
+			for _, sub := range construct.GetResources[*resources.SnsSubscription](dag) {
+				assert.Equal("email", sub.Protocol)
+				emails = append(emails, sub.Endpoint.Property)
+			}
+			assert.Equal(tt.wantEmails, emails)
+
+			dashboards := construct.GetResources[*resources.CloudwatchDashboard](dag)
+			if !tt.wantDashboard {
+				assert.Empty(dashboards)
+				return
+			}
+			if assert.Len(dashboards, 1) {
+				assert.Equal("app", dashboards[0].DashboardName)
+				assert.Len(dashboards[0].Alarms, len(tt.wantAlarms))
+				assert.NotNil(dashboards[0].Region)
+			}
+		})
+	}
+}
+
+func Test_ObservabilityTracing(t *testing.T) {
+	unit := &types.ExecutionUnit{Name: "main"}
+	tests := []struct {
+		name         string
+		endpoint     string
+		collector    *config.TracingCollector
+		wantEnv      map[string]construct.IaCValue
+		wantLambda   map[string]construct.IaCValue
+		wantSidecars []resources.EcsContainer
+		wantLayers   []resources.LambdaLayer
+	}{
+		{
+			name:       "collector sidecar and layer",
+			wantEnv:    map[string]construct.IaCValue{otelEndpointEnvVar: {Property: otelCollectorEndpoint}},
+			wantLambda: map[string]construct.IaCValue{otelEndpointEnvVar: {Property: otelCollectorEndpoint}},
+			wantSidecars: []resources.EcsContainer{
+				{Name: "aws-otel-collector", Image: otelCollectorImage, Command: []string{otelCollectorConfig}},
+			},
+			wantLayers: []resources.LambdaLayer{{
+				BuildArg: otelCollectorBuildArg,
+				Versions: []resources.LambdaLayerVersion{
+					{Architecture: "amd64", Arn: "arn:aws:lambda:{region}:901920570463:layer:aws-otel-collector-amd64-ver-0-90-1:1"},
+					{Architecture: "arm64", Arn: "arn:aws:lambda:{region}:901920570463:layer:aws-otel-collector-arm64-ver-0-90-1:1"},
+				},
+			}},
+		},
+		{
+			name: "configured collector",
+			collector: &config.TracingCollector{
+				Image: "example.com/otel-collector:1.0.0",
+				Layers: map[string]string{
+					"arm64":               "arn:aws:lambda:{region}:123456789012:layer:collector-arm64:3",
+					"us-gov-west-1/amd64": "arn:aws-us-gov:lambda:us-gov-west-1:123456789012:layer:collector-amd64:2",
+				},
+			},
+			wantEnv:    map[string]construct.IaCValue{otelEndpointEnvVar: {Property: otelCollectorEndpoint}},
+			wantLambda: map[string]construct.IaCValue{otelEndpointEnvVar: {Property: otelCollectorEndpoint}},
+			wantSidecars: []resources.EcsContainer{
+				{Name: "aws-otel-collector", Image: "example.com/otel-collector:1.0.0", Command: []string{otelCollectorConfig}},
+			},
+			wantLayers: []resources.LambdaLayer{{
+				BuildArg: otelCollectorBuildArg,
+				Versions: []resources.LambdaLayerVersion{
+					{Architecture: "amd64", Arn: "arn:aws:lambda:{region}:901920570463:layer:aws-otel-collector-amd64-ver-0-90-1:1"},
+					{Architecture: "arm64", Arn: "arn:aws:lambda:{region}:123456789012:layer:collector-arm64:3"},
+					{Region: "us-gov-west-1", Architecture: "amd64", Arn: "arn:aws-us-gov:lambda:us-gov-west-1:123456789012:layer:collector-amd64:2"},
+				},
+			}},
+		},
+		{
+			name:       "endpoint",
+			endpoint:   "https://otel.example.com",
+			wantEnv:    map[string]construct.IaCValue{otelEndpointEnvVar: {Property: "https://otel.example.com"}},
+			wantLambda: map[string]construct.IaCValue{otelEndpointEnvVar: {Property: "https://otel.example.com"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			role := &resources.IamRole{Name: "role"}
+			taskDef := &resources.EcsTaskDefinition{Name: "main", ConstructRefs: construct.BaseConstructSetOf(unit), ExecutionRole: role}
+			functionRole := &resources.IamRole{Name: "main-role"}
+			image := &resources.EcrImage{Name: "main"}
+			function := &resources.LambdaFunction{Name: "main", ConstructRefs: construct.BaseConstructSetOf(unit), Role: functionRole, Image: image}
+			other := &resources.LambdaFunction{Name: "rotation"}
+			dag := construct.NewResourceGraph()
+			for _, res := range []construct.Resource{taskDef, function, other} {
+				dag.AddResource(res)
+			}
+
+			cfg := &config.Application{AppName: "app", Observability: &config.Observability{Tracing: true, TracingEndpoint: tt.endpoint, Collector: tt.collector}}
+			if !assert.NoError(ObservabilityPlugin{Config: cfg}.Translate(construct.NewConstructGraph(), dag)) {
+				return
+			}
+			assert.Equal(tt.wantEnv, taskDef.EnvironmentVariables)
+			assert.Equal(tt.wantSidecars, taskDef.Sidecars)
+			if tt.wantSidecars != nil {
+				assert.Contains(role.AwsManagedPolicies, xrayWritePolicy)
+			}
+			assert.Equal(tt.wantLambda, function.EnvironmentVariables)
+			assert.Equal(tt.wantLayers, image.LambdaLayers)
+			if tt.wantLayers != nil {
+				assert.Contains(functionRole.AwsManagedPolicies, xrayWritePolicy)
+			}
+			assert.Empty(other.EnvironmentVariables)
+			assert.Empty(construct.GetResources[*resources.CloudwatchAlarm](dag))
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"github.com/klothoplatform/klotho/pkg/provider/imports"
+	awsSanitizer "github.com/klothoplatform/klotho/pkg/sanitization/aws"
+)
+
+// RegionsPlugin replicates the data of the application into the configured replica regions, as DynamoDB global tables,
+// S3 buckets which are replicated into buckets of the replica regions and Aurora global databases. When the application
+// is one side of an active-passive deployment, it also routes the records of its custom domains by failover, health
+// checking the primary.
+//
+// A replicated orm database becomes an Aurora global database, whose primary cluster takes the place of the database
+// and whose secondary clusters are deployed into the default networks of the replica regions. In an active-passive
+// deployment, every orm database becomes a global database instead. The primary environment creates it and replicates
+// its credentials into the replica regions, and the secondary environment joins it with a secondary cluster in its
+// own network, which connects with those credentials. Once the global database fails over to the secondary's region,
+// that cluster is the one which is written to.
+type RegionsPlugin struct {
+	Config *config.Application
+}
+
+// auroraInstanceClass is the class of the instances of global databases, which must be memory optimized
+const auroraInstanceClass = "db.r6g.large"
+
+// auroraEngines are the engines of the global databases which replace the instances of each engine
+var auroraEngines = map[string]string{
+	"postgres": "aurora-postgresql",
+	"mysql":    "aurora-mysql",
+}
+
+func (p RegionsPlugin) Name() string {
+	return "regions"
+}
+
+func (p RegionsPlugin) Translate(result *construct.ConstructGraph, dag *construct.ResourceGraph) error {
+	regions := p.Config.Regions
+	if regions == nil {
+		return nil
+	}
+	if err := regions.Validate(); err != nil {
+		return err
+	}
+	replicas := append([]string{}, regions.Replicas...)
+	sort.Strings(replicas)
+	var databases []*resources.RdsInstance
+	if len(replicas) > 0 {
+		targets, err := p.replicatedResources(dag)
+		if err != nil {
+			return err
+		}
+		for _, res := range targets {
+			switch res := res.(type) {
+			case *resources.DynamodbTable:
+				res.Replicas = replicas
+			case *resources.S3Bucket:
+				replicateBucket(dag, res, replicas)
+			case *resources.RdsInstance:
+				databases = append(databases, res)
+			}
+		}
+	}
+
+	// in an active-passive deployment, every database becomes a global database which both environments share
+	switch regions.Failover {
+	case "":
+		for _, instance := range databases {
+			if _, err := p.replicateDatabase(dag, instance, replicas); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case config.FailoverPrimary:
+		for _, instance := range p.managedRdsInstances(dag) {
+			if len(replicas) == 0 {
+				return fmt.Errorf("failover of database %s requires the region of the secondary environment in the replicas, which its credentials are replicated into", instance.Id())
+			}
+			cluster, err := p.replicateDatabase(dag, instance, nil)
+			if err != nil {
+				return err
+			}
+			replicateCredentials(dag, cluster, p.credentialsSecretName(instance), replicas)
+		}
+
+	case config.FailoverSecondary:
+		for _, instance := range p.managedRdsInstances(dag) {
+			if err := p.joinGlobalDatabase(dag, instance); err != nil {
+				return err
+			}
+		}
+	}
+	return p.routeFailover(dag)
+}
+
+// managedRdsInstances returns the rds instances which the application manages, sorted by id
+func (p RegionsPlugin) managedRdsInstances(dag *construct.ResourceGraph) []*resources.RdsInstance {
+	var instances []*resources.RdsInstance
+	for _, instance := range construct.GetResources[*resources.RdsInstance](dag) {
+		if _, imported := p.Config.Imports[instance.Id()]; !imported {
+			instances = append(instances, instance)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Id().String() < instances[j].Id().String() })
+	return instances
+}
+
+// replicatedResources returns the tables, buckets and databases which are replicated, sorted by id. Imported resources
+// are not managed by the application, so they are never replicated.
+func (p RegionsPlugin) replicatedResources(dag *construct.ResourceGraph) ([]construct.Resource, error) {
+	replicable := func(res construct.Resource) bool {
+		if _, imported := p.Config.Imports[res.Id()]; imported {
+			return false
+		}
+		switch res.(type) {
+		case *resources.DynamodbTable, *resources.S3Bucket, *resources.RdsInstance:
+			return true
+		}
+		return false
+	}
+
+	var targets []construct.Resource
+	if len(p.Config.Regions.Constructs) == 0 {
+		for _, res := range dag.ListResources() {
+			if replicable(res) {
+				targets = append(targets, res)
+			}
+		}
+	} else {
+		seen := make(map[construct.ResourceId]bool)
+		for _, id := range p.Config.Regions.Constructs {
+			found := false
+			var replicated []construct.Resource
+			for _, res := range dag.ListResources() {
+				if !res.BaseConstructRefs().Has(id) {
+					continue
+				}
+				found = true
+				if replicable(res) {
+					replicated = append(replicated, res)
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("replicated construct %s was not found", id)
+			}
+			if len(replicated) == 0 {
+				return nil, fmt.Errorf("construct %s has no resources which can be replicated", id)
+			}
+			for _, res := range replicated {
+				if !seen[res.Id()] {
+					seen[res.Id()] = true
+					targets = append(targets, res)
+				}
+			}
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Id().String() < targets[j].Id().String() })
+	return targets, nil
+}
+
+// regionProvider returns the provider of the region, adding it to the graph if it is not already
+func regionProvider(dag *construct.ResourceGraph, region string) *resources.RegionProvider {
+	provider, ok := construct.GetResource[*resources.RegionProvider](dag, resources.NewRegionProvider(region).Id())
+	if !ok {
+		provider = resources.NewRegionProvider(region)
+		dag.AddResource(provider)
+	}
+	return provider
+}
+
+// replicateBucket adds a versioned bucket to each of the regions, deployed by the region's provider, and replicates
+// the objects of bucket into them.
+func replicateBucket(dag *construct.ResourceGraph, bucket *resources.S3Bucket, regions []string) {
+	bucket.Versioning = true
+	var destinations []*resources.S3Bucket
+	for _, region := range regions {
+		provider := regionProvider(dag, region)
+		replica := &resources.S3Bucket{
+			Name:          awsSanitizer.S3BucketSanitizer.Apply(fmt.Sprintf("%s-%s", bucket.Name, region)),
+			ConstructRefs: bucket.ConstructRefs.Clone(),
+			ForceDestroy:  bucket.ForceDestroy,
+			Versioning:    true,
+			Provider:      provider,
+		}
+		dag.AddDependency(replica, provider)
+		destinations = append(destinations, replica)
+	}
+
+	role := &resources.IamRole{
+		Name:                awsSanitizer.IamRoleSanitizer.Apply(fmt.Sprintf("%s-replication", bucket.Name)),
+		ConstructRefs:       bucket.ConstructRefs.Clone(),
+		AssumeRolePolicyDoc: resources.S3_ASSUMER_ROLE_POLICY,
+	}
+	role.InlinePolicies = append(role.InlinePolicies, resources.NewIamInlinePolicy(
+		fmt.Sprintf("%s-replication", bucket.Name),
+		bucket.ConstructRefs.Clone(),
+		resources.ReplicationPolicy(bucket, destinations),
+	))
+	dag.AddDependency(role, bucket)
+
+	replication := &resources.S3BucketReplication{
+		Name:          bucket.Name,
+		ConstructRefs: bucket.ConstructRefs.Clone(),
+		Bucket:        bucket,
+		Destinations:  destinations,
+		Role:          role,
+	}
+	dag.AddDependency(replication, bucket)
+	dag.AddDependency(replication, role)
+	for _, destination := range destinations {
+		dag.AddDependency(role, destination)
+		dag.AddDependency(replication, destination)
+	}
+}
+
+// globalDatabaseName returns the name of the global database of the instance. The environments of an active-passive
+// deployment name their resources differently, so it is named by the application and the instance's orm instead.
+func (p RegionsPlugin) globalDatabaseName(instance *resources.RdsInstance) string {
+	for _, ref := range instance.ConstructRefs {
+		if orm, ok := ref.(*types.Orm); ok {
+			return awsSanitizer.RdsInstanceSanitizer.Apply(fmt.Sprintf("%s-%s", p.Config.AppName, orm.Name))
+		}
+	}
+	return instance.Name
+}
+
+// credentialsSecretName returns the name of the secret which the credentials of the instance's global database are
+// replicated with
+func (p RegionsPlugin) credentialsSecretName(instance *resources.RdsInstance) string {
+	return awsSanitizer.SecretSanitizer.Apply(fmt.Sprintf("%s-credentials", p.globalDatabaseName(instance)))
+}
+
+// replicateDatabase replaces the instance with the primary cluster of an Aurora global database, and adds a secondary
+// cluster of it to each of the regions. The secondary clusters are deployed into the default networks of their
+// regions, since the application's network is only in its own region.
+func (p RegionsPlugin) replicateDatabase(dag *construct.ResourceGraph, instance *resources.RdsInstance, regions []string) (*resources.RdsCluster, error) {
+	engine, ok := auroraEngines[instance.Engine]
+	if !ok {
+		return nil, fmt.Errorf("database %s cannot be replicated, since its engine '%s' has no aurora global database", instance.Id(), instance.Engine)
+	}
+	global := &resources.RdsGlobalCluster{
+		Name:          p.globalDatabaseName(instance),
+		ConstructRefs: instance.ConstructRefs.Clone(),
+		Engine:        engine,
+		EngineVersion: instance.EngineVersion,
+		DatabaseName:  instance.DatabaseName,
+	}
+	primary := &resources.RdsCluster{
+		Name:                             instance.Name,
+		ConstructRefs:                    instance.ConstructRefs.Clone(),
+		GlobalCluster:                    global,
+		Engine:                           engine,
+		EngineVersion:                    instance.EngineVersion,
+		DatabaseName:                     instance.DatabaseName,
+		IamDatabaseAuthenticationEnabled: instance.IamDatabaseAuthenticationEnabled,
+		SubnetGroup:                      instance.SubnetGroup,
+		SecurityGroups:                   instance.SecurityGroups,
+		SkipFinalSnapshot:                instance.SkipFinalSnapshot,
+		CredentialsFile:                  instance.CredentialsFile,
+		CredentialsPath:                  instance.CredentialsPath,
+		Tags:                             instance.Tags,
+	}
+	if err := replaceDatabase(dag, instance, primary); err != nil {
+		return nil, err
+	}
+	dag.AddDependency(primary, global)
+	addClusterInstance(dag, primary)
+
+	for _, region := range regions {
+		provider := regionProvider(dag, region)
+		secondary := &resources.RdsCluster{
+			Name:                             awsSanitizer.RdsInstanceSanitizer.Apply(fmt.Sprintf("%s-%s", instance.Name, region)),
+			ConstructRefs:                    instance.ConstructRefs.Clone(),
+			GlobalCluster:                    global,
+			Engine:                           engine,
+			EngineVersion:                    instance.EngineVersion,
+			DatabaseName:                     instance.DatabaseName,
+			IamDatabaseAuthenticationEnabled: instance.IamDatabaseAuthenticationEnabled,
+			SkipFinalSnapshot:                instance.SkipFinalSnapshot,
+			Provider:                         provider,
+			Tags:                             instance.Tags,
+		}
+		dag.AddDependency(secondary, global)
+		dag.AddDependency(secondary, provider)
+		// a secondary cluster can only join the global database once its primary cluster is created
+		dag.AddDependency(secondary, primary)
+		addClusterInstance(dag, secondary)
+	}
+	return primary, nil
+}
+
+// joinGlobalDatabase replaces the instance of a secondary environment with a secondary cluster, in the instance's
+// network, of the global database which the primary environment created. The cluster connects with the credentials
+// of the global database, which the primary environment replicated into the secondary's region.
+func (p RegionsPlugin) joinGlobalDatabase(dag *construct.ResourceGraph, instance *resources.RdsInstance) error {
+	engine, ok := auroraEngines[instance.Engine]
+	if !ok {
+		return fmt.Errorf("database %s cannot fail over, since its engine '%s' has no aurora global database", instance.Id(), instance.Engine)
+	}
+	for _, res := range dag.GetUpstreamResources(instance) {
+		switch res.(type) {
+		case *resources.RdsProxyTargetGroup, *resources.SecretVersion:
+			return fmt.Errorf("database %s of the secondary environment cannot be used through %s, since the credentials of its global database belong to the primary environment", instance.Id(), res.Id())
+		}
+	}
+	global := &resources.RdsGlobalCluster{
+		Name:          p.globalDatabaseName(instance),
+		ConstructRefs: instance.ConstructRefs.Clone(),
+		Engine:        engine,
+		EngineVersion: instance.EngineVersion,
+		DatabaseName:  instance.DatabaseName,
+	}
+	dag.AddDependency(global, &imports.Imported{ID: global.Name})
+	secondary := &resources.RdsCluster{
+		Name:                             instance.Name,
+		ConstructRefs:                    instance.ConstructRefs.Clone(),
+		GlobalCluster:                    global,
+		Engine:                           engine,
+		EngineVersion:                    instance.EngineVersion,
+		DatabaseName:                     instance.DatabaseName,
+		IamDatabaseAuthenticationEnabled: instance.IamDatabaseAuthenticationEnabled,
+		SubnetGroup:                      instance.SubnetGroup,
+		SecurityGroups:                   instance.SecurityGroups,
+		SkipFinalSnapshot:                instance.SkipFinalSnapshot,
+		CredentialsSecret:                p.credentialsSecretName(instance),
+		Tags:                             instance.Tags,
+	}
+	if err := replaceDatabase(dag, instance, secondary); err != nil {
+		return err
+	}
+	dag.AddDependency(secondary, global)
+	addClusterInstance(dag, secondary)
+	return nil
+}
+
+// replaceDatabase puts the cluster in the place of the instance, moving the instance's dependencies and the references
+// to it over to the cluster
+func replaceDatabase(dag *construct.ResourceGraph, instance *resources.RdsInstance, cluster *resources.RdsCluster) error {
+	upstream := dag.GetUpstreamDependencies(instance)
+	downstream := dag.GetDownstreamDependencies(instance)
+	if err := dag.RemoveResourceAndEdges(instance); err != nil {
+		return err
+	}
+	dag.AddResource(cluster)
+	for _, dep := range upstream {
+		switch source := dep.Source.(type) {
+		case *resources.RdsProxyTargetGroup:
+			source.RdsInstance = nil
+			source.RdsCluster = cluster
+		case *resources.SecretVersion:
+			source.RdsInstance = nil
+			source.RdsCluster = cluster
+		}
+		dag.AddDependencyWithData(dep.Source, cluster, dep.Properties.Data)
+	}
+	for _, dep := range downstream {
+		dag.AddDependencyWithData(cluster, dep.Destination, dep.Properties.Data)
+	}
+	newIds := map[construct.ResourceId]construct.ResourceId{instance.Id(): cluster.Id()}
+	for _, res := range dag.ListResources() {
+		renameReferences(reflect.ValueOf(res), newIds, true)
+	}
+	return nil
+}
+
+// addClusterInstance adds the instance which serves the cluster, in the cluster's region
+func addClusterInstance(dag *construct.ResourceGraph, cluster *resources.RdsCluster) {
+	instance := &resources.RdsClusterInstance{
+		Name:          awsSanitizer.RdsInstanceSanitizer.Apply(fmt.Sprintf("%s-0", cluster.Name)),
+		ConstructRefs: cluster.ConstructRefs.Clone(),
+		Cluster:       cluster,
+		Engine:        cluster.Engine,
+		EngineVersion: cluster.EngineVersion,
+		InstanceClass: auroraInstanceClass,
+		Provider:      cluster.Provider,
+		Tags:          cluster.Tags,
+	}
+	dag.AddDependency(instance, cluster)
+	if cluster.Provider != nil {
+		dag.AddDependency(instance, cluster.Provider)
+	}
+}
+
+// replicateCredentials stores the credentials of the cluster in a secret which is replicated into the regions, so that
+// the secondary clusters of other deployments can connect with them
+func replicateCredentials(dag *construct.ResourceGraph, cluster *resources.RdsCluster, name string, regions []string) {
+	secret := &resources.Secret{
+		Name:          name,
+		ConstructRefs: cluster.ConstructRefs.Clone(),
+		Replicas:      regions,
+	}
+	version := &resources.SecretVersion{
+		Name:          name,
+		ConstructRefs: cluster.ConstructRefs.Clone(),
+		Secret:        secret,
+		Path:          cluster.CredentialsPath,
+		Type:          "string",
+		RdsCluster:    cluster,
+	}
+	dag.AddDependency(version, secret)
+	dag.AddDependency(version, cluster)
+}
+
+// routeFailover routes the records of the application's gateway domains by failover. The primary's records are health
+// checked against the stage which their domain serves, so that the secondary's records are answered when it is
+// unhealthy. Both deployments validate their certificates with the same records, so those may overwrite each other.
+func (p RegionsPlugin) routeFailover(dag *construct.ResourceGraph) error {
+	failover := p.Config.Regions.Failover
+	found := false
+	for _, res := range dag.ListResources() {
+		record, ok := res.(*resources.Route53Record)
+		if !ok {
+			continue
+		}
+		if record.Certificate != nil {
+			record.AllowOverwrite = true
+			continue
+		}
+		domain := dag.GetResource(record.AliasTarget.ResourceId)
+		switch domain.(type) {
+		case *resources.ApiDomainName, *resources.HttpApiDomainName:
+		default:
+			continue
+		}
+		found = true
+		record.Failover = strings.ToUpper(failover)
+		record.SetIdentifier = failover
+		if failover != config.FailoverPrimary {
+			continue
+		}
+		stage, path, err := domainStage(dag, domain)
+		if err != nil {
+			return err
+		}
+		healthCheck := &resources.Route53HealthCheck{
+			Name:             fmt.Sprintf("%s-health", record.Name),
+			ConstructRefs:    record.ConstructRefs.Clone(),
+			Type:             "HTTPS",
+			Endpoint:         construct.IaCValue{ResourceId: stage.Id(), Property: resources.STAGE_INVOKE_URL_IAC_VALUE},
+			Port:             443,
+			FailureThreshold: 3,
+			RequestInterval:  30,
+			ResourcePath:     path + p.Config.Regions.HealthCheckPath,
+		}
+		if healthCheck.ResourcePath == "" {
+			healthCheck.ResourcePath = "/"
+		}
+		record.HealthCheck = healthCheck
+		dag.AddDependency(record, healthCheck)
+		dag.AddDependency(healthCheck, stage)
+	}
+	if !found {
+		return fmt.Errorf("failover requires a gateway with a custom domain")
+	}
+	return nil
+}
+
+// domainStage returns the stage which domain serves and the path which the stage is served at on its api's own domain
+func domainStage(dag *construct.ResourceGraph, domain construct.Resource) (construct.Resource, string, error) {
+	for _, res := range dag.GetUpstreamResources(domain) {
+		switch mapping := res.(type) {
+		case *resources.ApiBasePathMapping:
+			return mapping.Stage, "/" + mapping.Stage.StageName, nil
+		case *resources.HttpApiMapping:
+			if mapping.Stage.StageName == "$default" {
+				return mapping.Stage, "", nil
+			}
+			return mapping.Stage, "/" + mapping.Stage.StageName, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no stage is mapped to the domain %s", domain.Id())
+}
@@ -0,0 +1,433 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"github.com/klothoplatform/klotho/pkg/provider/imports"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RegionsReplication(t *testing.T) {
+	users := &types.Kv{Name: "users"}
+	uploads := &types.Fs{Name: "uploads"}
+	orm := &types.Orm{Name: "orm"}
+
+	tests := []struct {
+		name         string
+		regions      config.Regions
+		imports      map[construct.ResourceId]string
+		withOrm      bool
+		ormEngine    string
+		wantReplicas []string
+		wantBuckets  []string
+		wantClusters []string
+		wantErr      bool
+	}{
+		{
+			name:         "replicates every table and bucket",
+			regions:      config.Regions{Replicas: []string{"us-west-2", "eu-west-1"}},
+			wantReplicas: []string{"eu-west-1", "us-west-2"},
+			wantBuckets:  []string{"app-uploads-eu-west-1", "app-uploads-us-west-2"},
+		},
+		{
+			name:         "replicates the listed constructs",
+			regions:      config.Regions{Replicas: []string{"us-west-2"}, Constructs: []construct.ResourceId{users.Id()}},
+			withOrm:      true,
+			wantReplicas: []string{"us-west-2"},
+		},
+		{
+			name:        "imported resources are not replicated",
+			regions:     config.Regions{Replicas: []string{"us-west-2"}},
+			imports:     map[construct.ResourceId]string{{Provider: "aws", Type: "dynamodb_table", Name: "app-users"}: "users"},
+			wantBuckets: []string{"app-uploads-us-west-2"},
+		},
+		{
+			name:         "replicates the listed orm as a global database",
+			regions:      config.Regions{Replicas: []string{"us-west-2"}, Constructs: []construct.ResourceId{orm.Id()}},
+			withOrm:      true,
+			wantClusters: []string{"app-orm", "app-orm-us-west-2"},
+		},
+		{
+			name:         "replicates every construct with an orm",
+			regions:      config.Regions{Replicas: []string{"us-west-2"}},
+			withOrm:      true,
+			wantReplicas: []string{"us-west-2"},
+			wantBuckets:  []string{"app-uploads-us-west-2"},
+			wantClusters: []string{"app-orm", "app-orm-us-west-2"},
+		},
+		{
+			name:      "orm engine without a global database",
+			regions:   config.Regions{Replicas: []string{"us-west-2"}},
+			withOrm:   true,
+			ormEngine: "sqlserver-ex",
+			wantErr:   true,
+		},
+		{
+			name:         "imported orm database",
+			regions:      config.Regions{Replicas: []string{"us-west-2"}},
+			imports:      map[construct.ResourceId]string{{Provider: "aws", Type: "rds_instance", Name: "app-orm"}: "orm"},
+			withOrm:      true,
+			wantReplicas: []string{"us-west-2"},
+			wantBuckets:  []string{"app-uploads-us-west-2"},
+		},
+		{
+			name: "construct which does not exist",
+			regions: config.Regions{
+				Replicas:   []string{"us-west-2"},
+				Constructs: []construct.ResourceId{(&types.Kv{Name: "missing"}).Id()},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regions",
+			regions: config.Regions{Replicas: []string{"west"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			table := &resources.DynamodbTable{Name: "app-users", ConstructRefs: construct.BaseConstructSetOf(users)}
+			bucket := &resources.S3Bucket{Name: "app-uploads", ConstructRefs: construct.BaseConstructSetOf(uploads), ForceDestroy: true}
+			dag := construct.NewResourceGraph()
+			for _, res := range []construct.Resource{table, bucket} {
+				dag.AddResource(res)
+			}
+			if tt.withOrm {
+				engine := tt.ormEngine
+				if engine == "" {
+					engine = "postgres"
+				}
+				dag.AddResource(&resources.RdsInstance{Name: "app-orm", ConstructRefs: construct.BaseConstructSetOf(orm), Engine: engine})
+			}
+
+			cfg := &config.Application{AppName: "app", Regions: &tt.regions, Imports: tt.imports}
+			err := RegionsPlugin{Config: cfg}.Translate(construct.NewConstructGraph(), dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantReplicas, table.Replicas)
+			var clusters []string
+			for _, cluster := range construct.GetResources[*resources.RdsCluster](dag) {
+				clusters = append(clusters, cluster.Name)
+			}
+			assert.ElementsMatch(tt.wantClusters, clusters)
+
+			replication, ok := construct.GetResource[*resources.S3BucketReplication](dag, (&resources.S3BucketReplication{Name: bucket.Name}).Id())
+			if len(tt.wantBuckets) == 0 {
+				assert.False(ok)
+				assert.False(bucket.Versioning)
+				return
+			}
+			if !assert.True(ok) {
+				return
+			}
+			assert.True(bucket.Versioning)
+			assert.Equal(bucket, replication.Bucket)
+			var names []string
+			for _, replica := range replication.Destinations {
+				names = append(names, replica.Name)
+				assert.True(replica.Versioning)
+				assert.True(replica.ForceDestroy)
+				assert.NotNil(dag.GetDependency(replica.Id(), replica.Provider.Id()))
+				assert.NotNil(dag.GetDependency(replication.Id(), replica.Id()))
+				assert.NotNil(dag.GetDependency(replication.Role.Id(), replica.Id()))
+			}
+			assert.Equal(tt.wantBuckets, names)
+			assert.Equal(resources.S3_ASSUMER_ROLE_POLICY, replication.Role.AssumeRolePolicyDoc)
+			assert.Len(replication.Role.InlinePolicies, 1)
+			assert.NotNil(dag.GetDependency(replication.Id(), replication.Role.Id()))
+		})
+	}
+}
+
+func Test_RegionsFailover(t *testing.T) {
+	tests := []struct {
+		name            string
+		failover        string
+		healthCheckPath string
+		stageName       string
+		withDomain      bool
+		withOrm         bool
+		wantPath        string
+		wantErr         bool
+	}{
+		{
+			name:            "primary is health checked",
+			failover:        config.FailoverPrimary,
+			healthCheckPath: "/health",
+			stageName:       "stage",
+			withDomain:      true,
+			wantPath:        "/stage/health",
+		},
+		{
+			name:       "primary without a health check path",
+			failover:   config.FailoverPrimary,
+			stageName:  "$default",
+			withDomain: true,
+			wantPath:   "/",
+		},
+		{
+			name:       "secondary",
+			failover:   config.FailoverSecondary,
+			stageName:  "stage",
+			withDomain: true,
+		},
+		{
+			name:     "no custom domain",
+			failover: config.FailoverPrimary,
+			wantErr:  true,
+		},
+		{
+			name:       "orm database without a replica region",
+			failover:   config.FailoverPrimary,
+			stageName:  "stage",
+			withDomain: true,
+			withOrm:    true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			api := &resources.HttpApi{Name: "app-api"}
+			stage := &resources.HttpApiStage{Name: "app-api-stage", Api: api, StageName: tt.stageName}
+			dag.AddDependency(stage, api)
+			var record, validation *resources.Route53Record
+			if tt.withDomain {
+				certificate := &resources.AcmCertificate{Name: "api-example-com", DomainName: "api.example.com"}
+				domain := &resources.HttpApiDomainName{Name: "api-example-com", DomainName: "api.example.com"}
+				mapping := &resources.HttpApiMapping{Name: "api-example-com", Api: api, Stage: stage, DomainName: domain}
+				dag.AddDependency(mapping, domain)
+				dag.AddDependency(mapping, stage)
+				record = &resources.Route53Record{
+					Name:        "example-com-api.example.com",
+					DomainName:  "api.example.com",
+					Type:        "A",
+					AliasTarget: construct.IaCValue{ResourceId: domain.Id(), Property: resources.ALIAS_TARGET_IAC_VALUE},
+				}
+				dag.AddDependency(record, domain)
+				validation = &resources.Route53Record{Name: "api-example-com-validation", Certificate: certificate}
+				dag.AddDependency(validation, certificate)
+			}
+			if tt.withOrm {
+				dag.AddResource(&resources.RdsInstance{Name: "app-orm"})
+			}
+
+			cfg := &config.Application{AppName: "app", Regions: &config.Regions{Failover: tt.failover, HealthCheckPath: tt.healthCheckPath}}
+			err := RegionsPlugin{Config: cfg}.Translate(construct.NewConstructGraph(), dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.failover, record.SetIdentifier)
+			assert.True(validation.AllowOverwrite)
+			assert.Empty(validation.Failover)
+			if tt.failover == config.FailoverSecondary {
+				assert.Equal("SECONDARY", record.Failover)
+				assert.Nil(record.HealthCheck)
+				return
+			}
+			assert.Equal("PRIMARY", record.Failover)
+			if !assert.NotNil(record.HealthCheck) {
+				return
+			}
+			assert.Equal(tt.wantPath, record.HealthCheck.ResourcePath)
+			assert.Equal(construct.IaCValue{ResourceId: stage.Id(), Property: resources.STAGE_INVOKE_URL_IAC_VALUE}, record.HealthCheck.Endpoint)
+			assert.NotNil(dag.GetDependency(record.Id(), record.HealthCheck.Id()))
+			assert.NotNil(dag.GetDependency(record.HealthCheck.Id(), stage.Id()))
+		})
+	}
+}
+
+func Test_RegionsGlobalDatabase(t *testing.T) {
+	orm := &types.Orm{Name: "orm"}
+
+	tests := []struct {
+		name         string
+		regions      config.Regions
+		withProxy    bool
+		wantPrimary  bool
+		wantRegions  []string
+		wantSecret   []string
+		wantImported bool
+		wantErr      bool
+	}{
+		{
+			name:        "replicated database",
+			regions:     config.Regions{Replicas: []string{"us-west-2", "eu-west-1"}},
+			withProxy:   true,
+			wantPrimary: true,
+			wantRegions: []string{"eu-west-1", "us-west-2"},
+		},
+		{
+			name:        "primary environment",
+			regions:     config.Regions{Replicas: []string{"us-west-2"}, Failover: config.FailoverPrimary},
+			withProxy:   true,
+			wantPrimary: true,
+			wantSecret:  []string{"us-west-2"},
+		},
+		{
+			name:         "secondary environment",
+			regions:      config.Regions{Primary: "us-west-2", Failover: config.FailoverSecondary},
+			wantImported: true,
+		},
+		{
+			name:      "secondary environment with a proxy",
+			regions:   config.Regions{Primary: "us-west-2", Failover: config.FailoverSecondary},
+			withProxy: true,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			subnetGroup := &resources.RdsSubnetGroup{Name: "app-prod-orm"}
+			sg := &resources.SecurityGroup{Name: "app-prod"}
+			instance := &resources.RdsInstance{
+				Name:            "app-prod-orm",
+				ConstructRefs:   construct.BaseConstructSetOf(orm),
+				SubnetGroup:     subnetGroup,
+				SecurityGroups:  []*resources.SecurityGroup{sg},
+				DatabaseName:    "appprodorm",
+				Engine:          "postgres",
+				EngineVersion:   "13.7",
+				CredentialsPath: "secrets/app-prod-orm",
+			}
+			role := &resources.IamRole{Name: "app-prod-main", InlinePolicies: []*resources.IamInlinePolicy{
+				resources.NewIamInlinePolicy("app-prod-main-orm", nil, instance.GetConnectionPolicyDocument()),
+			}}
+			function := &resources.LambdaFunction{Name: "app-prod-main", Role: role, EnvironmentVariables: map[string]construct.IaCValue{
+				"ORM_PERSIST_ORM_CONNECTION": {ResourceId: instance.Id(), Property: string(types.CONNECTION_STRING)},
+			}}
+			dag := construct.NewResourceGraph()
+			dag.AddDependency(instance, subnetGroup)
+			dag.AddDependency(instance, sg)
+			dag.AddDependency(function, role)
+			dag.AddDependency(function, instance)
+			dag.AddDependency(role, instance)
+			var targetGroup *resources.RdsProxyTargetGroup
+			if tt.withProxy {
+				proxy := &resources.RdsProxy{Name: "app-prod-orm"}
+				targetGroup = &resources.RdsProxyTargetGroup{Name: "app-prod-orm", RdsInstance: instance, RdsProxy: proxy}
+				dag.AddDependency(targetGroup, proxy)
+				dag.AddDependency(targetGroup, instance)
+			}
+
+			if tt.regions.Failover != "" {
+				api := &resources.HttpApi{Name: "app-prod-api"}
+				stage := &resources.HttpApiStage{Name: "app-prod-api-stage", Api: api, StageName: "$default"}
+				domain := &resources.HttpApiDomainName{Name: "api-example-com", DomainName: "api.example.com"}
+				mapping := &resources.HttpApiMapping{Name: "api-example-com", Api: api, Stage: stage, DomainName: domain}
+				record := &resources.Route53Record{
+					Name:        "example-com-api.example.com",
+					AliasTarget: construct.IaCValue{ResourceId: domain.Id(), Property: resources.ALIAS_TARGET_IAC_VALUE},
+				}
+				dag.AddDependency(stage, api)
+				dag.AddDependency(mapping, domain)
+				dag.AddDependency(mapping, stage)
+				dag.AddDependency(record, domain)
+			}
+
+			cfg := &config.Application{AppName: "app", Environment: "prod", Regions: &tt.regions}
+			err := RegionsPlugin{Config: cfg}.Translate(construct.NewConstructGraph(), dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Nil(dag.GetResource(instance.Id()))
+			cluster, ok := construct.GetResource[*resources.RdsCluster](dag, (&resources.RdsCluster{Name: instance.Name}).Id())
+			if !assert.True(ok) {
+				return
+			}
+
+			// the cluster takes the place of the instance
+			assert.Equal(construct.IaCValue{ResourceId: cluster.Id(), Property: string(types.CONNECTION_STRING)}, function.EnvironmentVariables["ORM_PERSIST_ORM_CONNECTION"])
+			assert.Equal(cluster.Id(), role.InlinePolicies[0].Policy.Statement[0].Resource[0].ResourceId)
+			assert.NotNil(dag.GetDependency(function.Id(), cluster.Id()))
+			assert.NotNil(dag.GetDependency(role.Id(), cluster.Id()))
+			assert.NotNil(dag.GetDependency(cluster.Id(), subnetGroup.Id()))
+			assert.NotNil(dag.GetDependency(cluster.Id(), sg.Id()))
+			assert.Equal(subnetGroup, cluster.SubnetGroup)
+			assert.Equal([]*resources.SecurityGroup{sg}, cluster.SecurityGroups)
+			assert.Equal("aurora-postgresql", cluster.Engine)
+			assert.Equal("appprodorm", cluster.DatabaseName)
+			if targetGroup != nil {
+				assert.Nil(targetGroup.RdsInstance)
+				assert.Equal(cluster, targetGroup.RdsCluster)
+				assert.NotNil(dag.GetDependency(targetGroup.Id(), cluster.Id()))
+			}
+			writers := construct.GetUpstreamResourcesOfType[*resources.RdsClusterInstance](dag, cluster)
+			if assert.Len(writers, 1) {
+				assert.Equal("db.r6g.large", writers[0].InstanceClass)
+				assert.Equal("aurora-postgresql", writers[0].Engine)
+			}
+
+			// the global database is named the same by every environment
+			global := cluster.GlobalCluster
+			if !assert.NotNil(global) {
+				return
+			}
+			assert.Equal("app-orm", global.Name)
+			assert.NotNil(dag.GetDependency(cluster.Id(), global.Id()))
+			if tt.wantImported {
+				assert.NotNil(dag.GetDependency(global.Id(), (&imports.Imported{ID: "app-orm"}).Id()))
+				assert.Empty(cluster.CredentialsPath)
+				assert.Equal("app-orm-credentials", cluster.CredentialsSecret)
+			} else {
+				assert.Nil(dag.GetDependency(global.Id(), (&imports.Imported{ID: "app-orm"}).Id()))
+				assert.Equal("secrets/app-prod-orm", cluster.CredentialsPath)
+				assert.Empty(cluster.CredentialsSecret)
+			}
+
+			var regions []string
+			for _, secondary := range construct.GetUpstreamResourcesOfType[*resources.RdsCluster](dag, global) {
+				if secondary == cluster {
+					continue
+				}
+				regions = append(regions, secondary.Provider.Region)
+				assert.Empty(secondary.CredentialsPath)
+				assert.Nil(secondary.SubnetGroup)
+				assert.NotNil(dag.GetDependency(secondary.Id(), cluster.Id()))
+				readers := construct.GetUpstreamResourcesOfType[*resources.RdsClusterInstance](dag, secondary)
+				if assert.Len(readers, 1) {
+					assert.Equal(secondary.Provider, readers[0].Provider)
+				}
+			}
+			assert.ElementsMatch(tt.wantRegions, regions)
+
+			secret, ok := construct.GetResource[*resources.Secret](dag, (&resources.Secret{Name: "app-orm-credentials"}).Id())
+			if len(tt.wantSecret) == 0 {
+				assert.False(ok)
+				return
+			}
+			if !assert.True(ok) {
+				return
+			}
+			assert.Equal(tt.wantSecret, secret.Replicas)
+			versions := construct.GetUpstreamResourcesOfType[*resources.SecretVersion](dag, secret)
+			if assert.Len(versions, 1) {
+				assert.Equal(cluster, versions[0].RdsCluster)
+				assert.Equal("secrets/app-prod-orm", versions[0].Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider"
+)
+
+// ResourcePolicyPlugin applies the application's tagging and naming policy to its AWS resources. Every resource which
+// has a Tags field is tagged with the application's resource tags, and every resource is renamed by the naming
+// template unless its resource template preserves its name. Renamed resources still respect the name sanitization of
+// their resource template.
+//
+// Runtimes find the resources they use through environment variables whose values are rendered from the renamed
+// resources, such as a queue's url or a table's name. Resources which are instead found by a name derived at runtime
+// or baked in before the rename (lambda functions, sns topics, secrets, task definitions and the like) preserve their
+// names in their resource templates.
+type ResourcePolicyPlugin struct {
+	Config    *config.Application
+	Templates map[construct.ResourceId]*knowledgebase.ResourceTemplate
+}
+
+var (
+	tagsType          = reflect.TypeOf(map[string]string{})
+	iacValueType      = reflect.TypeOf(construct.IaCValue{})
+	resourceIdType    = reflect.TypeOf(construct.ResourceId{})
+	resourceInterface = reflect.TypeOf((*construct.Resource)(nil)).Elem()
+)
+
+func (p ResourcePolicyPlugin) Name() string {
+	return "resource_policy"
+}
+
+func (p ResourcePolicyPlugin) Translate(result *construct.ConstructGraph, dag *construct.ResourceGraph) error {
+	if err := p.Config.ValidateTagsAndNaming(); err != nil {
+		return err
+	}
+	p.applyTags(dag)
+	if p.Config.Naming == "" {
+		return nil
+	}
+	return p.applyNaming(dag)
+}
+
+// applyTags merges the application's resource tags into the Tags field of each AWS resource, keeping any tag which the
+// resource already set for itself.
+func (p ResourcePolicyPlugin) applyTags(dag *construct.ResourceGraph) {
+	for _, res := range dag.ListResources() {
+		if res.Id().Provider != provider.AWS {
+			continue
+		}
+		field := reflect.Indirect(reflect.ValueOf(res)).FieldByName("Tags")
+		if !field.IsValid() || field.Type() != tagsType || !field.CanSet() {
+			continue
+		}
+		tags := p.Config.ResourceTags()
+		for k, v := range field.Interface().(map[string]string) {
+			tags[k] = v
+		}
+		field.Set(reflect.ValueOf(tags))
+	}
+}
+
+// applyNaming renames the AWS resources by the naming template. Since a resource's id is derived from its name, the
+// graph is rebuilt with the new ids and the references to the renamed resources are updated to match.
+func (p ResourcePolicyPlugin) applyNaming(dag *construct.ResourceGraph) error {
+	resources := dag.ListResources()
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Id().String() < resources[j].Id().String()
+	})
+	names := make(map[construct.Resource]string)
+	for _, res := range resources {
+		id := res.Id()
+		if id.Provider != provider.AWS {
+			continue
+		}
+		template := p.Templates[construct.ResourceId{Provider: id.Provider, Type: id.Type}]
+		if template == nil || template.PreserveName {
+			continue
+		}
+		field := reflect.Indirect(reflect.ValueOf(res)).FieldByName("Name")
+		if !field.IsValid() || field.Kind() != reflect.String || !field.CanSet() {
+			continue
+		}
+		name, err := template.NameSanitization.Apply(p.Config.ResourceName(id.Type, field.String()))
+		if err != nil {
+			return fmt.Errorf("could not name resource %s: %w", id, err)
+		}
+		if name != field.String() {
+			names[res] = name
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	edges := dag.ListDependencies()
+	oldIds := make([]construct.ResourceId, len(resources))
+	for i, res := range resources {
+		oldIds[i] = res.Id()
+		if err := dag.RemoveResourceAndEdges(res); err != nil {
+			return err
+		}
+	}
+	for res, name := range names {
+		reflect.Indirect(reflect.ValueOf(res)).FieldByName("Name").SetString(name)
+	}
+	// a resource's id can change without it being renamed, such as a subnet whose id is namespaced by its renamed vpc
+	newIds := make(map[construct.ResourceId]construct.ResourceId)
+	for i, res := range resources {
+		if res.Id() != oldIds[i] {
+			newIds[oldIds[i]] = res.Id()
+		}
+	}
+	for _, res := range resources {
+		if existing := dag.GetResource(res.Id()); existing != nil {
+			return fmt.Errorf("naming template '%s' gives %s the same id as another resource", p.Config.Naming, res.Id())
+		}
+		dag.AddResource(res)
+	}
+	for _, res := range resources {
+		renameReferences(reflect.ValueOf(res), newIds, true)
+	}
+	for _, edge := range edges {
+		dag.AddDependencyWithData(edge.Source, edge.Destination, edge.Properties.Data)
+	}
+	return nil
+}
+
+// renameReferences updates the IaCValues and ResourceIds within v which refer to a renamed resource. It does not
+// descend into other resources, since they are updated on their own.
+func renameReferences(v reflect.Value, newIds map[construct.ResourceId]construct.ResourceId, root bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || (!root && v.Type().Implements(resourceInterface)) {
+			return
+		}
+		renameReferences(v.Elem(), newIds, false)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer {
+			renameReferences(elem, newIds, false)
+			return
+		}
+		if v.CanSet() {
+			copied := reflect.New(elem.Type()).Elem()
+			copied.Set(elem)
+			renameReferences(copied, newIds, false)
+			v.Set(copied)
+		}
+
+	case reflect.Struct:
+		switch v.Type() {
+		case iacValueType:
+			id := v.FieldByName("ResourceId")
+			if newId, ok := newIds[id.Interface().(construct.ResourceId)]; ok && id.CanSet() {
+				id.Set(reflect.ValueOf(newId))
+			}
+		case resourceIdType:
+			if newId, ok := newIds[v.Interface().(construct.ResourceId)]; ok && v.CanSet() {
+				v.Set(reflect.ValueOf(newId))
+			}
+		default:
+			for i := 0; i < v.NumField(); i++ {
+				if !v.Type().Field(i).IsExported() || v.Field(i).Type().Name() == "BaseConstructSet" {
+					continue
+				}
+				renameReferences(v.Field(i), newIds, false)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			renameReferences(v.Index(i), newIds, false)
+		}
+
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			renameReferences(value, newIds, false)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/aws/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResourcePolicyTags(t *testing.T) {
+	assert := assert.New(t)
+	function := &resources.LambdaFunction{Name: "app-main"}
+	lb := &resources.LoadBalancer{Name: "app-lb", Tags: map[string]string{"team": "platform"}}
+	region := resources.NewRegion()
+
+	dag := construct.NewResourceGraph()
+	for _, res := range []construct.Resource{function, lb, region} {
+		dag.AddResource(res)
+	}
+	cfg := &config.Application{AppName: "app", Environment: "prod", Tags: map[string]string{"team": "payments"}}
+	err := ResourcePolicyPlugin{Config: cfg, Templates: (&AWS{}).GetOperationalTemplates()}.Translate(construct.NewConstructGraph(), dag)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(map[string]string{"app": "app", "env": "prod", "team": "payments"}, function.Tags)
+	assert.Equal(map[string]string{"app": "app", "env": "prod", "team": "platform"}, lb.Tags)
+}
+
+func Test_ResourcePolicyNaming(t *testing.T) {
+	tests := []struct {
+		name      string
+		naming    string
+		queues    []string
+		wantQueue string
+		wantRole  string
+		wantErr   bool
+	}{
+		{
+			name:      "no naming template",
+			queues:    []string{"app-prod-jobs"},
+			wantQueue: "app-prod-jobs",
+			wantRole:  "app-prod-main-role",
+		},
+		{
+			name:      "renames by the template",
+			naming:    "{{env}}-{{type}}-{{name}}",
+			queues:    []string{"app-prod-jobs"},
+			wantQueue: "prod-sqs_queue-jobs",
+			wantRole:  "prod-iam_role-main-role",
+		},
+		{
+			name:      "sanitizes the rendered name",
+			naming:    "{{app}}.{{type}}.{{name}}",
+			queues:    []string{"app-prod-jobs"},
+			wantQueue: "app-sqs_queue-jobs",
+			wantRole:  "app.iam_role.main-role",
+		},
+		{
+			name:    "names which collide",
+			naming:  "{{app}}-{{name}}",
+			queues:  []string{"app-prod-jobs", "jobs"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			role := &resources.IamRole{Name: "app-prod-main-role"}
+			function := &resources.LambdaFunction{Name: "app-prod-main", Role: role}
+			region := resources.NewRegion()
+			dag := construct.NewResourceGraph()
+			dag.AddDependency(function, role)
+			var queue *resources.SqsQueue
+			for _, name := range tt.queues {
+				queue = &resources.SqsQueue{Name: name}
+				dag.AddDependency(function, queue)
+			}
+			function.EnvironmentVariables = map[string]construct.IaCValue{
+				"QUEUE_URL": {ResourceId: queue.Id(), Property: resources.QUEUE_URL_IAC_VALUE},
+			}
+			permission := &resources.LambdaPermission{
+				Name:     "app-prod-main-permission",
+				Function: function,
+				Source:   construct.IaCValue{ResourceId: queue.Id(), Property: resources.ARN_IAC_VALUE},
+			}
+			dag.AddDependency(permission, function)
+			dag.AddDependency(permission, queue)
+			dag.AddDependency(function, region)
+
+			cfg := &config.Application{AppName: "app", Environment: "prod", Naming: tt.naming}
+			err := ResourcePolicyPlugin{Config: cfg, Templates: (&AWS{}).GetOperationalTemplates()}.Translate(construct.NewConstructGraph(), dag)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantQueue, queue.Name)
+			assert.Equal(tt.wantRole, role.Name)
+			// lambda functions are looked up by their name at runtime, and the region has no template
+			assert.Equal("app-prod-main", function.Name)
+			assert.Equal("region", region.Name)
+
+			assert.Equal(queue.Id(), function.EnvironmentVariables["QUEUE_URL"].ResourceId)
+			assert.Equal(queue.Id(), permission.Source.ResourceId)
+			for _, res := range []construct.Resource{role, function, region, queue, permission} {
+				assert.Equal(res, dag.GetResource(res.Id()))
+			}
+			assert.NotNil(dag.GetDependency(function.Id(), queue.Id()))
+			assert.NotNil(dag.GetDependency(function.Id(), role.Id()))
+			assert.NotNil(dag.GetDependency(permission.Id(), queue.Id()))
+			assert.Len(dag.ListDependencies(), 5)
+		})
+	}
+}
+
+func Test_ResourcePolicyNamingNamespacedIds(t *testing.T) {
+	assert := assert.New(t)
+	vpc := &resources.Vpc{Name: "app-vpc"}
+	subnet := &resources.Subnet{Name: "app-private0", Vpc: vpc}
+	taskDef := &resources.EcsTaskDefinition{Name: "app-main"}
+	service := &resources.EcsService{Name: "app-main", TaskDefinition: taskDef, Subnets: []*resources.Subnet{subnet}}
+	policy := &resources.IamPolicy{
+		Name:   "app-network",
+		Policy: resources.CreateAllowPolicyDocument([]string{"ec2:CreateNetworkInterface"}, []construct.IaCValue{{ResourceId: subnet.Id(), Property: resources.ARN_IAC_VALUE}}),
+	}
+	dag := construct.NewResourceGraph()
+	dag.AddDependency(subnet, vpc)
+	dag.AddDependency(service, subnet)
+	dag.AddDependency(service, taskDef)
+	dag.AddDependency(policy, subnet)
+
+	cfg := &config.Application{AppName: "app", Environment: "prod", Naming: "{{env}}-{{name}}"}
+	err := ResourcePolicyPlugin{Config: cfg, Templates: (&AWS{}).GetOperationalTemplates()}.Translate(construct.NewConstructGraph(), dag)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("prod-app-vpc", vpc.Name)
+	assert.Equal("prod-app-vpc", subnet.Id().Namespace)
+	assert.Equal(subnet.Id(), policy.Policy.Statement[0].Resource[0].ResourceId)
+	// the task definition's name is also its container's name, which schedules and migrations refer to
+	assert.Equal("app-main", taskDef.Name)
+	for _, res := range []construct.Resource{vpc, subnet, taskDef, service, policy} {
+		assert.Equal(res, dag.GetResource(res.Id()))
+	}
+	assert.NotNil(dag.GetDependency(subnet.Id(), vpc.Id()))
+	assert.NotNil(dag.GetDependency(service.Id(), subnet.Id()))
+	assert.NotNil(dag.GetDependency(service.Id(), taskDef.Id()))
+}
@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+)
+
+const (
+	ACM_CERTIFICATE_TYPE            = "acm_certificate"
+	ACM_CERTIFICATE_VALIDATION_TYPE = "acm_certificate_validation"
+
+	// ALIAS_TARGET_IAC_VALUE is the target of the route53 alias records which point a custom domain at a resource
+	ALIAS_TARGET_IAC_VALUE = "alias_target"
+
+	// cloudfrontCertificateRegion is the only region which CloudFront distributions can use certificates from
+	cloudfrontCertificateRegion = "us-east-1"
+)
+
+var certificateSanitizer = aws.CertificateSanitizer
+
+type (
+	// AcmCertificate is a TLS certificate for DomainName which is validated through a DNS record. If Region is set,
+	// the certificate is created in that region instead of the application's.
+	AcmCertificate struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		DomainName    string
+		Region        string
+	}
+
+	// AcmCertificateValidation waits for Certificate to be validated by its validation Records
+	AcmCertificateValidation struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Certificate   *AcmCertificate
+		Records       []*Route53Record
+	}
+)
+
+// domainCertificate returns the validation of the certificate for domain in region, creating the certificate, its
+// validation record and the domain's hosted zone if they do not exist yet.
+func domainCertificate(dag *construct.ResourceGraph, domain *types.CustomDomain, region string, refs construct.BaseConstructSet) *AcmCertificateValidation {
+	name := domain.Name
+	if region != "" {
+		name = fmt.Sprintf("%s-%s", domain.Name, region)
+	}
+	validation := &AcmCertificateValidation{
+		Name:          certificateSanitizer.Apply(name),
+		ConstructRefs: refs.Clone(),
+	}
+	if existing, ok := construct.GetResource[*AcmCertificateValidation](dag, validation.Id()); ok {
+		existing.ConstructRefs.AddAll(refs)
+		existing.Certificate.ConstructRefs.AddAll(refs)
+		return existing
+	}
+
+	certificate := &AcmCertificate{
+		Name:          validation.Name,
+		ConstructRefs: refs.Clone(),
+		DomainName:    domain.Name,
+		Region:        region,
+	}
+	zone := domainZone(dag, domain, refs)
+	record := &Route53Record{
+		Name:          fmt.Sprintf("%s-%s-validation", zone.Name, domain.Name),
+		ConstructRefs: refs.Clone(),
+		Zone:          zone,
+		Certificate:   certificate,
+		TTL:           60,
+	}
+	validation.Certificate = certificate
+	validation.Records = []*Route53Record{record}
+	dag.AddDependency(record, zone)
+	dag.AddDependency(record, certificate)
+	dag.AddDependency(validation, certificate)
+	dag.AddDependency(validation, record)
+	return validation
+}
+
+// domainZone returns the existing hosted zone of domain, adding it if it is not in the graph yet
+func domainZone(dag *construct.ResourceGraph, domain *types.CustomDomain, refs construct.BaseConstructSet) *Route53HostedZone {
+	zone := &Route53HostedZone{
+		Name:             domain.Zone(),
+		ConstructRefs:    refs.Clone(),
+		ExistingZoneName: domain.Zone(),
+	}
+	if existing, ok := construct.GetResource[*Route53HostedZone](dag, zone.Id()); ok {
+		existing.ConstructRefs.AddAll(refs)
+		return existing
+	}
+	dag.AddResource(zone)
+	return zone
+}
+
+// domainAliasRecord creates the record which points domain at the alias target of target, if it does not exist yet
+func domainAliasRecord(dag *construct.ResourceGraph, domain *types.CustomDomain, target construct.Resource, refs construct.BaseConstructSet) *Route53Record {
+	zone := domainZone(dag, domain, refs)
+	record := &Route53Record{
+		Name:          fmt.Sprintf("%s-%s", zone.Name, domain.Name),
+		ConstructRefs: refs.Clone(),
+		DomainName:    domain.Name,
+		Zone:          zone,
+		Type:          "A",
+		AliasTarget:   construct.IaCValue{ResourceId: target.Id(), Property: ALIAS_TARGET_IAC_VALUE},
+	}
+	if existing, ok := construct.GetResource[*Route53Record](dag, record.Id()); ok {
+		existing.ConstructRefs.AddAll(refs)
+		return existing
+	}
+	dag.AddDependency(record, zone)
+	dag.AddDependency(record, target)
+	return record
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (certificate *AcmCertificate) BaseConstructRefs() construct.BaseConstructSet {
+	return certificate.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (certificate *AcmCertificate) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     ACM_CERTIFICATE_TYPE,
+		Name:     certificate.Name,
+	}
+}
+
+func (certificate *AcmCertificate) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (validation *AcmCertificateValidation) BaseConstructRefs() construct.BaseConstructSet {
+	return validation.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (validation *AcmCertificateValidation) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     ACM_CERTIFICATE_VALIDATION_TYPE,
+		Name:     validation.Name,
+	}
+}
+
+func (validation *AcmCertificateValidation) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
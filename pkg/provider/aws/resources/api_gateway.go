@@ -32,6 +32,7 @@ type (
 		Name             string
 		ConstructRefs    construct.BaseConstructSet `yaml:"-"`
 		BinaryMediaTypes []string
+		Tags             map[string]string
 	}
 
 	ApiResource struct {
@@ -50,6 +51,7 @@ type (
 		HttpMethod        string
 		RequestParameters map[string]bool
 		Authorization     string
+		Authorizer        *ApiAuthorizer
 	}
 
 	VpcLink struct {
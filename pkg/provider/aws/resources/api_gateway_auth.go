@@ -0,0 +1,314 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	API_GATEWAY_AUTHORIZER_TYPE = "api_authorizer"
+	HTTP_API_AUTHORIZER_TYPE    = "http_api_authorizer"
+
+	restApiIdentitySource = "method.request.header.Authorization"
+	httpApiIdentitySource = "$request.header.Authorization"
+	// httpApiAuthorizerPayloadFormatVersion sends lambda authorizers the request's headers, and lets them respond with
+	// `{"isAuthorized": true|false}` rather than an IAM policy
+	httpApiAuthorizerPayloadFormatVersion = "2.0"
+)
+
+type (
+	// ApiAuthorizer authorizes the requests to the methods of RestApi which use it, with either the tokens issued by
+	// UserPools or the response of Function.
+	ApiAuthorizer struct {
+		Name           string
+		ConstructRefs  construct.BaseConstructSet `yaml:"-"`
+		RestApi        *RestApi
+		Type           string
+		UserPools      []*CognitoUserPool
+		Function       *LambdaFunction
+		IdentitySource string
+	}
+
+	// HttpApiAuthorizer authorizes the requests to the routes of Api which use it, with either JWTs or the response of Function.
+	// The JWTs of a UserPool authorizer are issued by UserPool for UserPoolClient, otherwise they must match Issuer and Audience.
+	HttpApiAuthorizer struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		Api                  *HttpApi
+		Type                 string
+		Issuer               string
+		Audience             []string
+		UserPool             *CognitoUserPool
+		UserPoolClient       *CognitoUserPoolClient
+		Function             *LambdaFunction
+		IdentitySources      []string
+		PayloadFormatVersion string
+	}
+)
+
+// GatewayConstruct returns the gateway construct that the api was expanded from, if any
+func (api *RestApi) GatewayConstruct() *types.Gateway {
+	return gatewayConstruct(api.ConstructRefs)
+}
+
+// GatewayConstruct returns the gateway construct that the api was expanded from, if any
+func (api *HttpApi) GatewayConstruct() *types.Gateway {
+	return gatewayConstruct(api.ConstructRefs)
+}
+
+func gatewayConstruct(refs construct.BaseConstructSet) *types.Gateway {
+	for _, ref := range refs {
+		if gw, ok := ref.(*types.Gateway); ok {
+			return gw
+		}
+	}
+	return nil
+}
+
+var apiPathParameter = regexp.MustCompile(`{([^}+]+)\+?}`)
+
+// gatewayAuth returns the auth of the route of gw which path and verb belong to. The path may be in either the gateway's
+// or api gateway's path syntax.
+func gatewayAuth(gw *types.Gateway, path string, verb string) *types.GatewayAuth {
+	if gw == nil {
+		return nil
+	}
+	if path == "" {
+		path = "/"
+	}
+	return gw.AuthFor(apiPathParameter.ReplaceAllString(path, ":$1"), types.Verb(verb))
+}
+
+// unitFunction returns the lambda function that the execution unit named unitName was expanded to, if any
+func unitFunction(dag *construct.ResourceGraph, unitName string) *LambdaFunction {
+	unit := &types.ExecutionUnit{Name: unitName}
+	for _, function := range construct.GetResources[*LambdaFunction](dag) {
+		if function.ConstructRefs.Has(unit.Id()) {
+			return function
+		}
+	}
+	return nil
+}
+
+// MakeOperational sets the method's authorization from the auth of its route's gateway, creating the authorizer it uses.
+func (method *ApiMethod) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if method.RestApi == nil {
+		return fmt.Errorf("rest api is not set on method %s", method.Name)
+	}
+	route := "/"
+	if method.Resource != nil {
+		for _, integration := range construct.GetDownstreamResourcesOfType[*ApiIntegration](dag, method) {
+			route = integration.Route
+		}
+	}
+	auth := gatewayAuth(method.RestApi.GatewayConstruct(), route, method.HttpMethod)
+	if auth == nil {
+		if method.Authorization == "" {
+			method.Authorization = "NONE"
+		}
+		return nil
+	}
+
+	switch auth.Type {
+	case types.GatewayAuthIam:
+		method.Authorization = "AWS_IAM"
+		return nil
+	case types.GatewayAuthCognito:
+		method.Authorization = "COGNITO_USER_POOLS"
+	case types.GatewayAuthLambda:
+		method.Authorization = "CUSTOM"
+	default:
+		return fmt.Errorf("rest api %s does not support %s auth, use an http_api instead", method.RestApi.Name, auth.Type)
+	}
+	authorizer, err := method.RestApi.authorizer(dag, auth)
+	if err != nil {
+		return err
+	}
+	method.Authorizer = authorizer
+	dag.AddDependency(method, authorizer)
+	return nil
+}
+
+// authorizer returns the api's authorizer for auth, creating it if it does not exist yet
+func (api *RestApi) authorizer(dag *construct.ResourceGraph, auth *types.GatewayAuth) (*ApiAuthorizer, error) {
+	authorizer := &ApiAuthorizer{
+		ConstructRefs:  api.ConstructRefs.Clone(),
+		RestApi:        api,
+		IdentitySource: restApiIdentitySource,
+	}
+	switch auth.Type {
+	case types.GatewayAuthCognito:
+		authorizer.Name = apiResourceSanitizer.Apply(fmt.Sprintf("%s-cognito", api.Name))
+		authorizer.Type = "COGNITO_USER_POOLS"
+	case types.GatewayAuthLambda:
+		authorizer.Name = apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", api.Name, auth.ExecUnitName))
+		authorizer.Type = "TOKEN"
+	}
+	if existing, ok := construct.GetResource[*ApiAuthorizer](dag, authorizer.Id()); ok {
+		existing.ConstructRefs.AddAll(api.ConstructRefs)
+		return existing, nil
+	}
+
+	switch auth.Type {
+	case types.GatewayAuthCognito:
+		pool := userPool(dag, api.Name, api.ConstructRefs)
+		authorizer.UserPools = []*CognitoUserPool{pool}
+		dag.AddDependency(authorizer, pool)
+	case types.GatewayAuthLambda:
+		function := unitFunction(dag, auth.ExecUnitName)
+		if function == nil {
+			return nil, fmt.Errorf("execution unit %s must be a lambda function to authorize the requests of rest api %s", auth.ExecUnitName, api.Name)
+		}
+		authorizer.Function = function
+		dag.AddDependency(authorizer, function)
+	}
+	dag.AddDependency(api, authorizer)
+	return authorizer, nil
+}
+
+// MakeOperational sets the route's authorization from the auth of its gateway, creating the authorizer it uses.
+func (route *HttpApiRoute) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if route.Api == nil {
+		return fmt.Errorf("http api is not set on route %s", route.Name)
+	}
+	path := "/"
+	if route.Integration != nil && route.Integration.Route != "" {
+		path = route.Integration.Route
+	}
+	auth := gatewayAuth(route.Api.GatewayConstruct(), path, types.VerbAny.String())
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case types.GatewayAuthIam:
+		route.AuthorizationType = "AWS_IAM"
+		return nil
+	case types.GatewayAuthJwt, types.GatewayAuthCognito:
+		route.AuthorizationType = "JWT"
+	case types.GatewayAuthLambda:
+		route.AuthorizationType = "CUSTOM"
+	}
+	authorizer, err := route.Api.authorizer(dag, auth)
+	if err != nil {
+		return err
+	}
+	route.Authorizer = authorizer
+	dag.AddDependency(route, authorizer)
+	return nil
+}
+
+// authorizer returns the api's authorizer for auth, creating it if it does not exist yet
+func (api *HttpApi) authorizer(dag *construct.ResourceGraph, auth *types.GatewayAuth) (*HttpApiAuthorizer, error) {
+	authorizer := &HttpApiAuthorizer{
+		ConstructRefs:   api.ConstructRefs.Clone(),
+		Api:             api,
+		IdentitySources: []string{httpApiIdentitySource},
+	}
+	switch auth.Type {
+	case types.GatewayAuthJwt:
+		authorizer.Name = apiResourceSanitizer.Apply(fmt.Sprintf("%s-jwt", api.Name))
+		authorizer.Type = "JWT"
+		authorizer.Issuer = auth.Issuer
+		authorizer.Audience = auth.Audience
+	case types.GatewayAuthCognito:
+		authorizer.Name = apiResourceSanitizer.Apply(fmt.Sprintf("%s-cognito", api.Name))
+		authorizer.Type = "JWT"
+	case types.GatewayAuthLambda:
+		authorizer.Name = apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", api.Name, auth.ExecUnitName))
+		authorizer.Type = "REQUEST"
+		authorizer.PayloadFormatVersion = httpApiAuthorizerPayloadFormatVersion
+	}
+	if existing, ok := construct.GetResource[*HttpApiAuthorizer](dag, authorizer.Id()); ok {
+		existing.ConstructRefs.AddAll(api.ConstructRefs)
+		return existing, nil
+	}
+
+	switch auth.Type {
+	case types.GatewayAuthCognito:
+		pool := userPool(dag, api.Name, api.ConstructRefs)
+		client := &CognitoUserPoolClient{
+			Name:          pool.Name,
+			ConstructRefs: api.ConstructRefs.Clone(),
+			UserPool:      pool,
+		}
+		if existing, ok := construct.GetResource[*CognitoUserPoolClient](dag, client.Id()); ok {
+			client = existing
+		} else {
+			dag.AddDependency(client, pool)
+		}
+		authorizer.UserPool = pool
+		authorizer.UserPoolClient = client
+		dag.AddDependency(authorizer, pool)
+		dag.AddDependency(authorizer, client)
+	case types.GatewayAuthLambda:
+		function := unitFunction(dag, auth.ExecUnitName)
+		if function == nil {
+			return nil, fmt.Errorf("execution unit %s must be a lambda function to authorize the requests of http api %s", auth.ExecUnitName, api.Name)
+		}
+		authorizer.Function = function
+		dag.AddDependency(authorizer, function)
+	}
+	dag.AddDependency(api, authorizer)
+	return authorizer, nil
+}
+
+// userPool returns the user pool named name, creating it if it does not exist yet
+func userPool(dag *construct.ResourceGraph, name string, refs construct.BaseConstructSet) *CognitoUserPool {
+	pool := &CognitoUserPool{
+		Name:          name,
+		ConstructRefs: refs.Clone(),
+	}
+	if existing, ok := construct.GetResource[*CognitoUserPool](dag, pool.Id()); ok {
+		existing.ConstructRefs.AddAll(refs)
+		return existing
+	}
+	dag.AddResource(pool)
+	return pool
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (authorizer *ApiAuthorizer) BaseConstructRefs() construct.BaseConstructSet {
+	return authorizer.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (authorizer *ApiAuthorizer) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     API_GATEWAY_AUTHORIZER_TYPE,
+		Name:     authorizer.Name,
+	}
+}
+
+func (authorizer *ApiAuthorizer) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (authorizer *HttpApiAuthorizer) BaseConstructRefs() construct.BaseConstructSet {
+	return authorizer.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (authorizer *HttpApiAuthorizer) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_AUTHORIZER_TYPE,
+		Name:     authorizer.Name,
+	}
+}
+
+func (authorizer *HttpApiAuthorizer) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
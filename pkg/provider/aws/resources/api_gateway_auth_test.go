@@ -0,0 +1,226 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApiMethodMakeOperational(t *testing.T) {
+	tests := []struct {
+		name              string
+		auth              *types.GatewayAuth
+		routeAuth         map[string]*types.GatewayAuth
+		wantAuthorization string
+		wantAuthorizer    *ApiAuthorizer
+		wantErr           bool
+	}{
+		{
+			name:              "public gateway",
+			wantAuthorization: "NONE",
+		},
+		{
+			name:              "iam auth",
+			auth:              &types.GatewayAuth{Type: types.GatewayAuthIam},
+			wantAuthorization: "AWS_IAM",
+		},
+		{
+			name:              "cognito auth",
+			auth:              &types.GatewayAuth{Type: types.GatewayAuthCognito},
+			wantAuthorization: "COGNITO_USER_POOLS",
+			wantAuthorizer: &ApiAuthorizer{
+				Name:           "api-cognito",
+				Type:           "COGNITO_USER_POOLS",
+				UserPools:      []*CognitoUserPool{{Name: "api"}},
+				IdentitySource: restApiIdentitySource,
+			},
+		},
+		{
+			name:              "lambda auth",
+			auth:              &types.GatewayAuth{Type: types.GatewayAuthLambda, ExecUnitName: "authorizer"},
+			wantAuthorization: "CUSTOM",
+			wantAuthorizer: &ApiAuthorizer{
+				Name:           "api-authorizer",
+				Type:           "TOKEN",
+				Function:       &LambdaFunction{Name: "authorizer"},
+				IdentitySource: restApiIdentitySource,
+			},
+		},
+		{
+			name:              "route overrides gateway auth",
+			auth:              &types.GatewayAuth{Type: types.GatewayAuthIam},
+			routeAuth:         map[string]*types.GatewayAuth{"/users/:id": {Type: types.GatewayAuthNone}},
+			wantAuthorization: "NONE",
+		},
+		{
+			name:    "lambda auth from unit which is not a lambda",
+			auth:    &types.GatewayAuth{Type: types.GatewayAuthLambda, ExecUnitName: "other"},
+			wantErr: true,
+		},
+		{
+			name:    "jwt auth",
+			auth:    &types.GatewayAuth{Type: types.GatewayAuthJwt, Issuer: "https://issuer", Audience: []string{"api"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			gw := &types.Gateway{Name: "gw", Auth: tt.auth, RouteAuth: tt.routeAuth}
+			api := &RestApi{Name: "api", ConstructRefs: construct.BaseConstructSetOf(gw)}
+			resource := &ApiResource{Name: "resource", RestApi: api}
+			method := &ApiMethod{Name: "method", RestApi: api, Resource: resource, HttpMethod: "ANY"}
+			integration := &ApiIntegration{Name: "integration", RestApi: api, Method: method, Route: "/users/{id}"}
+			dag.AddDependency(api, method)
+			dag.AddDependency(method, integration)
+			authorizerFunction := &LambdaFunction{Name: "authorizer", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "authorizer"})}
+			dag.AddResource(authorizerFunction)
+
+			err := method.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantAuthorization, method.Authorization)
+			if tt.wantAuthorizer == nil {
+				assert.Nil(method.Authorizer)
+				return
+			}
+			if !assert.NotNil(method.Authorizer) {
+				return
+			}
+			assert.Equal(tt.wantAuthorizer.Name, method.Authorizer.Name)
+			assert.Equal(tt.wantAuthorizer.Type, method.Authorizer.Type)
+			assert.Equal(tt.wantAuthorizer.IdentitySource, method.Authorizer.IdentitySource)
+			assert.Equal(api, method.Authorizer.RestApi)
+			assert.NotNil(dag.GetDependency(method.Id(), method.Authorizer.Id()))
+			assert.NotNil(dag.GetDependency(api.Id(), method.Authorizer.Id()))
+			assert.Len(method.Authorizer.UserPools, len(tt.wantAuthorizer.UserPools))
+			for i, pool := range tt.wantAuthorizer.UserPools {
+				assert.Equal(pool.Name, method.Authorizer.UserPools[i].Name)
+			}
+			if tt.wantAuthorizer.Function != nil {
+				assert.Equal(authorizerFunction, method.Authorizer.Function)
+				assert.NotNil(dag.GetDependency(method.Authorizer.Id(), authorizerFunction.Id()))
+			}
+		})
+	}
+}
+
+func Test_HttpApiRouteMakeOperational(t *testing.T) {
+	tests := []struct {
+		name                  string
+		auth                  *types.GatewayAuth
+		wantAuthorizationType string
+		wantAuthorizer        *HttpApiAuthorizer
+		wantErr               bool
+	}{
+		{
+			name: "public gateway",
+		},
+		{
+			name:                  "iam auth",
+			auth:                  &types.GatewayAuth{Type: types.GatewayAuthIam},
+			wantAuthorizationType: "AWS_IAM",
+		},
+		{
+			name:                  "jwt auth",
+			auth:                  &types.GatewayAuth{Type: types.GatewayAuthJwt, Issuer: "https://issuer", Audience: []string{"api"}},
+			wantAuthorizationType: "JWT",
+			wantAuthorizer: &HttpApiAuthorizer{
+				Name:            "api-jwt",
+				Type:            "JWT",
+				Issuer:          "https://issuer",
+				Audience:        []string{"api"},
+				IdentitySources: []string{httpApiIdentitySource},
+			},
+		},
+		{
+			name:                  "cognito auth",
+			auth:                  &types.GatewayAuth{Type: types.GatewayAuthCognito},
+			wantAuthorizationType: "JWT",
+			wantAuthorizer: &HttpApiAuthorizer{
+				Name:            "api-cognito",
+				Type:            "JWT",
+				UserPool:        &CognitoUserPool{Name: "api"},
+				UserPoolClient:  &CognitoUserPoolClient{Name: "api"},
+				IdentitySources: []string{httpApiIdentitySource},
+			},
+		},
+		{
+			name:                  "lambda auth",
+			auth:                  &types.GatewayAuth{Type: types.GatewayAuthLambda, ExecUnitName: "authorizer"},
+			wantAuthorizationType: "CUSTOM",
+			wantAuthorizer: &HttpApiAuthorizer{
+				Name:                 "api-authorizer",
+				Type:                 "REQUEST",
+				Function:             &LambdaFunction{Name: "authorizer"},
+				IdentitySources:      []string{httpApiIdentitySource},
+				PayloadFormatVersion: httpApiAuthorizerPayloadFormatVersion,
+			},
+		},
+		{
+			name:    "lambda auth from unit which is not a lambda",
+			auth:    &types.GatewayAuth{Type: types.GatewayAuthLambda, ExecUnitName: "other"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			gw := &types.Gateway{Name: "gw", Auth: tt.auth}
+			api := &HttpApi{Name: "api", ConstructRefs: construct.BaseConstructSetOf(gw)}
+			integration := &HttpApiIntegration{Name: "integration", Api: api}
+			route := &HttpApiRoute{Name: "route", Api: api, RouteKey: "$default", Integration: integration}
+			dag.AddDependency(api, route)
+			dag.AddDependency(route, integration)
+			authorizerFunction := &LambdaFunction{Name: "authorizer", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "authorizer"})}
+			dag.AddResource(authorizerFunction)
+
+			err := route.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantAuthorizationType, route.AuthorizationType)
+			if tt.wantAuthorizer == nil {
+				assert.Nil(route.Authorizer)
+				return
+			}
+			authorizer := route.Authorizer
+			if !assert.NotNil(authorizer) {
+				return
+			}
+			assert.Equal(tt.wantAuthorizer.Name, authorizer.Name)
+			assert.Equal(tt.wantAuthorizer.Type, authorizer.Type)
+			assert.Equal(tt.wantAuthorizer.Issuer, authorizer.Issuer)
+			assert.Equal(tt.wantAuthorizer.Audience, authorizer.Audience)
+			assert.Equal(tt.wantAuthorizer.IdentitySources, authorizer.IdentitySources)
+			assert.Equal(tt.wantAuthorizer.PayloadFormatVersion, authorizer.PayloadFormatVersion)
+			assert.NotNil(dag.GetDependency(route.Id(), authorizer.Id()))
+			assert.NotNil(dag.GetDependency(api.Id(), authorizer.Id()))
+			if tt.wantAuthorizer.UserPool != nil {
+				if assert.NotNil(authorizer.UserPool) && assert.NotNil(authorizer.UserPoolClient) {
+					assert.Equal(tt.wantAuthorizer.UserPool.Name, authorizer.UserPool.Name)
+					assert.Equal(authorizer.UserPool, authorizer.UserPoolClient.UserPool)
+					assert.NotNil(dag.GetDependency(authorizer.UserPoolClient.Id(), authorizer.UserPool.Id()))
+				}
+			}
+			if tt.wantAuthorizer.Function != nil {
+				assert.Equal(authorizerFunction, authorizer.Function)
+			}
+		})
+	}
+}
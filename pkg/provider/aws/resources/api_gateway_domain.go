@@ -0,0 +1,205 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	API_DOMAIN_NAME_TYPE       = "api_domain_name"
+	API_BASE_PATH_MAPPING_TYPE = "api_base_path_mapping"
+	HTTP_API_DOMAIN_NAME_TYPE  = "http_api_domain_name"
+	HTTP_API_MAPPING_TYPE      = "http_api_mapping"
+)
+
+type (
+	// ApiDomainName is a regional custom domain which serves rest apis over the certificate of Certificate
+	ApiDomainName struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		DomainName    string
+		Certificate   *AcmCertificateValidation
+	}
+
+	// ApiBasePathMapping serves Stage of RestApi at the root of DomainName
+	ApiBasePathMapping struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		RestApi       *RestApi
+		Stage         *ApiStage
+		DomainName    *ApiDomainName
+	}
+
+	// HttpApiDomainName is a regional custom domain which serves http apis over the certificate of Certificate
+	HttpApiDomainName struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		DomainName    string
+		Certificate   *AcmCertificateValidation
+	}
+
+	// HttpApiMapping serves Stage of Api at the root of DomainName
+	HttpApiMapping struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Api           *HttpApi
+		Stage         *HttpApiStage
+		DomainName    *HttpApiDomainName
+	}
+)
+
+// MakeOperational serves the stage at the custom domain of its api's gateway, if the gateway has one.
+func (stage *ApiStage) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if stage.RestApi == nil {
+		return fmt.Errorf("rest api is not set on stage %s", stage.Name)
+	}
+	gw := stage.RestApi.GatewayConstruct()
+	if gw == nil || gw.Domain == nil {
+		return nil
+	}
+	refs := stage.RestApi.ConstructRefs
+	domain := &ApiDomainName{
+		Name:          certificateSanitizer.Apply(gw.Domain.Name),
+		ConstructRefs: refs.Clone(),
+		DomainName:    gw.Domain.Name,
+	}
+	if existing, ok := construct.GetResource[*ApiDomainName](dag, domain.Id()); ok {
+		domain = existing
+		domain.ConstructRefs.AddAll(refs)
+	} else {
+		domain.Certificate = domainCertificate(dag, gw.Domain, "", refs)
+		dag.AddDependency(domain, domain.Certificate)
+	}
+
+	mapping := &ApiBasePathMapping{
+		Name:          apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", stage.Name, domain.Name)),
+		ConstructRefs: refs.Clone(),
+		RestApi:       stage.RestApi,
+		Stage:         stage,
+		DomainName:    domain,
+	}
+	if _, ok := construct.GetResource[*ApiBasePathMapping](dag, mapping.Id()); !ok {
+		dag.AddDependency(mapping, domain)
+		dag.AddDependency(mapping, stage)
+		dag.AddDependency(mapping, stage.RestApi)
+	}
+	domainAliasRecord(dag, gw.Domain, domain, refs)
+	return nil
+}
+
+// domainMapping serves the stage at the custom domain of its api's gateway, if the gateway has one.
+func (stage *HttpApiStage) domainMapping(dag *construct.ResourceGraph) {
+	gw := stage.Api.GatewayConstruct()
+	if gw == nil || gw.Domain == nil {
+		return
+	}
+	refs := stage.Api.ConstructRefs
+	domain := &HttpApiDomainName{
+		Name:          certificateSanitizer.Apply(gw.Domain.Name),
+		ConstructRefs: refs.Clone(),
+		DomainName:    gw.Domain.Name,
+	}
+	if existing, ok := construct.GetResource[*HttpApiDomainName](dag, domain.Id()); ok {
+		domain = existing
+		domain.ConstructRefs.AddAll(refs)
+	} else {
+		domain.Certificate = domainCertificate(dag, gw.Domain, "", refs)
+		dag.AddDependency(domain, domain.Certificate)
+	}
+
+	mapping := &HttpApiMapping{
+		Name:          apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", stage.Name, domain.Name)),
+		ConstructRefs: refs.Clone(),
+		Api:           stage.Api,
+		Stage:         stage,
+		DomainName:    domain,
+	}
+	if _, ok := construct.GetResource[*HttpApiMapping](dag, mapping.Id()); !ok {
+		dag.AddDependency(mapping, domain)
+		dag.AddDependency(mapping, stage)
+		dag.AddDependency(mapping, stage.Api)
+	}
+	domainAliasRecord(dag, gw.Domain, domain, refs)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (domain *ApiDomainName) BaseConstructRefs() construct.BaseConstructSet {
+	return domain.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (domain *ApiDomainName) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     API_DOMAIN_NAME_TYPE,
+		Name:     domain.Name,
+	}
+}
+
+func (domain *ApiDomainName) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (mapping *ApiBasePathMapping) BaseConstructRefs() construct.BaseConstructSet {
+	return mapping.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (mapping *ApiBasePathMapping) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     API_BASE_PATH_MAPPING_TYPE,
+		Name:     mapping.Name,
+	}
+}
+
+func (mapping *ApiBasePathMapping) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (domain *HttpApiDomainName) BaseConstructRefs() construct.BaseConstructSet {
+	return domain.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (domain *HttpApiDomainName) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_DOMAIN_NAME_TYPE,
+		Name:     domain.Name,
+	}
+}
+
+func (domain *HttpApiDomainName) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (mapping *HttpApiMapping) BaseConstructRefs() construct.BaseConstructSet {
+	return mapping.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (mapping *HttpApiMapping) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_MAPPING_TYPE,
+		Name:     mapping.Name,
+	}
+}
+
+func (mapping *HttpApiMapping) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
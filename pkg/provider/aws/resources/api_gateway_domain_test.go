@@ -0,0 +1,161 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApiStageMakeOperational(t *testing.T) {
+	tests := []struct {
+		name     string
+		domain   *types.CustomDomain
+		wantDeps []string
+	}{
+		{
+			name: "no custom domain",
+		},
+		{
+			name:   "custom domain",
+			domain: &types.CustomDomain{Name: "api.example.com"},
+			wantDeps: []string{
+				"aws:api_domain_name:api-example-com -> aws:acm_certificate_validation:api-example-com",
+				"aws:acm_certificate_validation:api-example-com -> aws:acm_certificate:api-example-com",
+				"aws:acm_certificate_validation:api-example-com -> aws:route53_record:example.com-api.example.com-validation",
+				"aws:route53_record:example.com-api.example.com-validation -> aws:route53_hosted_zone:example.com",
+				"aws:route53_record:example.com-api.example.com-validation -> aws:acm_certificate:api-example-com",
+				"aws:api_base_path_mapping:stage-api-example-com -> aws:api_domain_name:api-example-com",
+				"aws:api_base_path_mapping:stage-api-example-com -> aws:api_stage:stage",
+				"aws:api_base_path_mapping:stage-api-example-com -> aws:rest_api:api",
+				"aws:route53_record:example.com-api.example.com -> aws:route53_hosted_zone:example.com",
+				"aws:route53_record:example.com-api.example.com -> aws:api_domain_name:api-example-com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			gw := &types.Gateway{Name: "gw", Domain: tt.domain}
+			api := &RestApi{Name: "api", ConstructRefs: construct.BaseConstructSetOf(gw)}
+			stage := &ApiStage{Name: "stage", RestApi: api}
+			dag.AddDependency(stage, api)
+
+			err := stage.MakeOperational(dag, "app", nil)
+			if !assert.NoError(err) {
+				return
+			}
+			var deps []string
+			for _, dep := range dag.ListDependencies() {
+				if dep.Source == stage {
+					continue
+				}
+				deps = append(deps, dep.Source.Id().String()+" -> "+dep.Destination.Id().String())
+			}
+			assert.ElementsMatch(tt.wantDeps, deps)
+			if tt.domain == nil {
+				return
+			}
+			record, ok := construct.GetResource[*Route53Record](dag, construct.ResourceId{Provider: AWS_PROVIDER, Type: ROUTE_53_RECORD_TYPE, Name: "example.com-api.example.com"})
+			if assert.True(ok) {
+				assert.Equal("A", record.Type)
+				assert.Equal(ALIAS_TARGET_IAC_VALUE, record.AliasTarget.Property)
+			}
+			zone, ok := construct.GetResource[*Route53HostedZone](dag, construct.ResourceId{Provider: AWS_PROVIDER, Type: ROUTE_53_HOSTED_ZONE_TYPE, Name: "example.com"})
+			if assert.True(ok) {
+				assert.Equal("example.com", zone.ExistingZoneName)
+			}
+		})
+	}
+}
+
+func Test_HttpApiStageMakeOperational(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	gw := &types.Gateway{Name: "gw", Domain: &types.CustomDomain{Name: "api.example.com"}}
+	api := &HttpApi{Name: "api", ConstructRefs: construct.BaseConstructSetOf(gw)}
+	stage := &HttpApiStage{Name: "stage", Api: api}
+	dag.AddDependency(stage, api)
+
+	err := stage.MakeOperational(dag, "app", nil)
+	if !assert.NoError(err) {
+		return
+	}
+	mapping, ok := construct.GetResource[*HttpApiMapping](dag, construct.ResourceId{Provider: AWS_PROVIDER, Type: HTTP_API_MAPPING_TYPE, Name: "stage-api-example-com"})
+	if !assert.True(ok) {
+		return
+	}
+	assert.Equal(api, mapping.Api)
+	assert.Equal(stage, mapping.Stage)
+	if assert.NotNil(mapping.DomainName.Certificate) {
+		assert.Equal("api.example.com", mapping.DomainName.Certificate.Certificate.DomainName)
+		assert.Empty(mapping.DomainName.Certificate.Certificate.Region)
+	}
+}
+
+func Test_CloudfrontDistributionMakeOperational(t *testing.T) {
+	tests := []struct {
+		name            string
+		units           []*types.StaticUnit
+		wantAliases     []string
+		wantCertificate bool
+		wantErr         bool
+	}{
+		{
+			name:  "no custom domain",
+			units: []*types.StaticUnit{{Name: "site"}},
+		},
+		{
+			name:            "custom domain",
+			units:           []*types.StaticUnit{{Name: "site", Domain: &types.CustomDomain{Name: "www.example.com"}}},
+			wantAliases:     []string{"www.example.com"},
+			wantCertificate: true,
+		},
+		{
+			name: "multiple custom domains",
+			units: []*types.StaticUnit{
+				{Name: "site", Domain: &types.CustomDomain{Name: "www.example.com"}},
+				{Name: "docs", Domain: &types.CustomDomain{Name: "docs.example.com"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			refs := make(construct.BaseConstructSet)
+			for _, unit := range tt.units {
+				refs.Add(unit)
+			}
+			distro := &CloudfrontDistribution{Name: "distro", ConstructRefs: refs}
+			dag.AddResource(distro)
+
+			err := distro.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.NotNil(distro.DefaultCacheBehavior)
+			assert.NotNil(distro.Restrictions)
+			assert.Equal(tt.wantAliases, distro.Aliases)
+			assert.Equal(!tt.wantCertificate, distro.CloudfrontDefaultCertificate)
+			if !tt.wantCertificate {
+				assert.Nil(distro.Certificate)
+				return
+			}
+			if assert.NotNil(distro.Certificate) {
+				assert.Equal(cloudfrontCertificateRegion, distro.Certificate.Certificate.Region)
+				assert.NotNil(dag.GetDependency(distro.Id(), distro.Certificate.Id()))
+			}
+		})
+	}
+}
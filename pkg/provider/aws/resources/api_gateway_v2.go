@@ -0,0 +1,399 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	HTTP_API_TYPE             = "http_api"
+	HTTP_API_INTEGRATION_TYPE = "http_api_integration"
+	HTTP_API_ROUTE_TYPE       = "http_api_route"
+	HTTP_API_STAGE_TYPE       = "http_api_stage"
+	HTTP_API_VPC_LINK_TYPE    = "http_api_vpc_link"
+
+	LISTENER_ARN_IAC_VALUE = "listener_arn"
+
+	WEBSOCKET_API_TYPE         = "websocket_api"
+	WEBSOCKET_INTEGRATION_TYPE = "websocket_integration"
+	WEBSOCKET_ROUTE_TYPE       = "websocket_route"
+	WEBSOCKET_STAGE_TYPE       = "websocket_stage"
+
+	WEBSOCKET_MANAGEMENT_ENDPOINT_IAC_VALUE = "websocket_management_endpoint"
+
+	defaultRouteSelectionExpression = "$request.body.action"
+	// defaultHttpApiRouteKey matches every request which no other route of an HTTP api matches
+	defaultHttpApiRouteKey = "$default"
+	// defaultHttpApiStageName is served from the root of the api's url, rather than under the stage's name
+	defaultHttpApiStageName = "$default"
+	// defaultPayloadFormatVersion sends lambda functions the same events as REST api proxy integrations
+	defaultPayloadFormatVersion = "1.0"
+)
+
+// webSocketRouteKeys are the routes which every WebSocket integration handles: the dispatcher of the integrated unit
+// receives connections, disconnections and all messages, regardless of the route selection expression.
+var webSocketRouteKeys = []string{"$connect", "$disconnect", "$default"}
+
+type (
+	// HttpApi is an API Gateway HTTP (v2) api. It only supports proxy integrations, but has lower cost and latency than a RestApi.
+	HttpApi struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Tags          map[string]string
+	}
+
+	HttpApiIntegration struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		Api                  *HttpApi
+		Type                 string
+		IntegrationMethod    string
+		ConnectionType       string
+		VpcLink              *HttpApiVpcLink
+		PayloadFormatVersion string
+		Uri                  construct.IaCValue
+		Route                string
+	}
+
+	HttpApiRoute struct {
+		Name              string
+		ConstructRefs     construct.BaseConstructSet `yaml:"-"`
+		Api               *HttpApi
+		RouteKey          string
+		Integration       *HttpApiIntegration
+		AuthorizationType string
+		Authorizer        *HttpApiAuthorizer
+	}
+
+	HttpApiStage struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Api           *HttpApi
+		StageName     string
+	}
+
+	// HttpApiVpcLink connects HTTP api integrations to the listeners of private load balancers
+	HttpApiVpcLink struct {
+		Name           string
+		ConstructRefs  construct.BaseConstructSet `yaml:"-"`
+		Subnets        []*Subnet
+		SecurityGroups []*SecurityGroup
+	}
+
+	WebSocketApi struct {
+		Name                     string
+		ConstructRefs            construct.BaseConstructSet `yaml:"-"`
+		RouteSelectionExpression string
+	}
+
+	WebSocketIntegration struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Api           *WebSocketApi
+		Type          string
+		Uri           construct.IaCValue
+	}
+
+	WebSocketRoute struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Api           *WebSocketApi
+		RouteKey      string
+		Integration   *WebSocketIntegration
+	}
+
+	WebSocketStage struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Api           *WebSocketApi
+		StageName     string
+	}
+)
+
+// MakeOperational creates the route which sends the api's requests to the integration. The only integration of an api
+// receives all of its requests, like the `/:proxy*` route of a RestApi.
+func (integration *HttpApiIntegration) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if integration.Api == nil {
+		return fmt.Errorf("http api is not set on integration %s", integration.Name)
+	}
+	if integration.PayloadFormatVersion == "" && integration.Type == "AWS_PROXY" {
+		integration.PayloadFormatVersion = defaultPayloadFormatVersion
+	}
+
+	routeKey := defaultHttpApiRouteKey
+	if integration.Route != "" {
+		routeKey = fmt.Sprintf("ANY %s", convertPath(integration.Route, true))
+	} else if len(construct.GetDownstreamResourcesOfType[*HttpApiIntegration](dag, integration.Api)) > 1 {
+		return fmt.Errorf("integration %s must set a route since http api %s has multiple integrations", integration.Name, integration.Api.Name)
+	}
+	route := &HttpApiRoute{
+		Name:          apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", integration.Name, strings.Trim(routeKey, "$"))),
+		ConstructRefs: integration.ConstructRefs.Clone(),
+		Api:           integration.Api,
+		RouteKey:      routeKey,
+		Integration:   integration,
+	}
+	if existing, ok := construct.GetResource[*HttpApiRoute](dag, route.Id()); ok {
+		existing.ConstructRefs.AddAll(integration.ConstructRefs)
+		return nil
+	}
+	dag.AddDependency(integration.Api, route)
+	dag.AddDependency(route, integration)
+	return nil
+}
+
+func (stage *HttpApiStage) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if stage.Api == nil {
+		return fmt.Errorf("http api is not set on stage %s", stage.Name)
+	}
+	if stage.StageName == "" {
+		stage.StageName = defaultHttpApiStageName
+	}
+	stage.domainMapping(dag)
+	return nil
+}
+
+func (api *WebSocketApi) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if api.RouteSelectionExpression == "" {
+		api.RouteSelectionExpression = defaultRouteSelectionExpression
+	}
+	return nil
+}
+
+// GatewayConstruct returns the websocket gateway construct that the api was expanded from, if any
+func (api *WebSocketApi) GatewayConstruct() *types.WebSocketGateway {
+	for _, ref := range api.ConstructRefs {
+		if gw, ok := ref.(*types.WebSocketGateway); ok {
+			return gw
+		}
+	}
+	return nil
+}
+
+// MakeOperational creates the routes which send the api's connections, disconnections and messages to the integration.
+func (integration *WebSocketIntegration) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if integration.Api == nil {
+		return fmt.Errorf("websocket api is not set on integration %s", integration.Name)
+	}
+	for _, routeKey := range webSocketRouteKeys {
+		route := &WebSocketRoute{
+			Name:          apiResourceSanitizer.Apply(fmt.Sprintf("%s-%s", integration.Name, strings.TrimPrefix(routeKey, "$"))),
+			ConstructRefs: integration.ConstructRefs.Clone(),
+			Api:           integration.Api,
+			RouteKey:      routeKey,
+			Integration:   integration,
+		}
+		if existing, ok := construct.GetResource[*WebSocketRoute](dag, route.Id()); ok {
+			existing.ConstructRefs.AddAll(integration.ConstructRefs)
+			continue
+		}
+		dag.AddDependency(integration.Api, route)
+		dag.AddDependency(route, integration)
+	}
+	return nil
+}
+
+func (stage *WebSocketStage) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if stage.Api == nil {
+		return fmt.Errorf("websocket api is not set on stage %s", stage.Name)
+	}
+	if stage.StageName == "" {
+		stage.StageName = "stage"
+	}
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (api *HttpApi) BaseConstructRefs() construct.BaseConstructSet {
+	return api.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (api *HttpApi) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_TYPE,
+		Name:     api.Name,
+	}
+}
+
+func (api *HttpApi) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresNoDownstream:   true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (integration *HttpApiIntegration) BaseConstructRefs() construct.BaseConstructSet {
+	return integration.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (integration *HttpApiIntegration) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_INTEGRATION_TYPE,
+		Name:     integration.Name,
+	}
+}
+
+func (integration *HttpApiIntegration) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   false,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (route *HttpApiRoute) BaseConstructRefs() construct.BaseConstructSet {
+	return route.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (route *HttpApiRoute) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_ROUTE_TYPE,
+		Name:     route.Name,
+	}
+}
+
+func (route *HttpApiRoute) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (stage *HttpApiStage) BaseConstructRefs() construct.BaseConstructSet {
+	return stage.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (stage *HttpApiStage) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_STAGE_TYPE,
+		Name:     stage.Name,
+	}
+}
+
+func (stage *HttpApiStage) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (link *HttpApiVpcLink) BaseConstructRefs() construct.BaseConstructSet {
+	return link.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (link *HttpApiVpcLink) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     HTTP_API_VPC_LINK_TYPE,
+		Name:     link.Name,
+	}
+}
+
+func (link *HttpApiVpcLink) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (api *WebSocketApi) BaseConstructRefs() construct.BaseConstructSet {
+	return api.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (api *WebSocketApi) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     WEBSOCKET_API_TYPE,
+		Name:     api.Name,
+	}
+}
+
+func (api *WebSocketApi) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresNoDownstream:   true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (integration *WebSocketIntegration) BaseConstructRefs() construct.BaseConstructSet {
+	return integration.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (integration *WebSocketIntegration) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     WEBSOCKET_INTEGRATION_TYPE,
+		Name:     integration.Name,
+	}
+}
+
+func (integration *WebSocketIntegration) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   false,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (route *WebSocketRoute) BaseConstructRefs() construct.BaseConstructSet {
+	return route.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (route *WebSocketRoute) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     WEBSOCKET_ROUTE_TYPE,
+		Name:     route.Name,
+	}
+}
+
+func (route *WebSocketRoute) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (stage *WebSocketStage) BaseConstructRefs() construct.BaseConstructSet {
+	return stage.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (stage *WebSocketStage) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     WEBSOCKET_STAGE_TYPE,
+		Name:     stage.Name,
+	}
+}
+
+func (stage *WebSocketStage) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: false,
+	}
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WebSocketIntegrationMakeOperational(t *testing.T) {
+	tests := []struct {
+		name           string
+		existingRoutes []*WebSocketRoute
+		wantRouteKeys  []string
+		wantErr        bool
+	}{
+		{
+			name:          "creates connect, disconnect and default routes",
+			wantRouteKeys: []string{"$connect", "$disconnect", "$default"},
+		},
+		{
+			name:           "reuses existing routes",
+			existingRoutes: []*WebSocketRoute{{Name: "integration-connect", RouteKey: "$connect"}},
+			wantRouteKeys:  []string{"$connect", "$disconnect", "$default"},
+		},
+		{
+			name:    "missing api",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			api := &WebSocketApi{Name: "api"}
+			integration := &WebSocketIntegration{Name: "integration", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "main"})}
+			if !tt.wantErr {
+				integration.Api = api
+			}
+			dag.AddDependency(api, integration)
+			for _, route := range tt.existingRoutes {
+				route.ConstructRefs = make(construct.BaseConstructSet)
+				dag.AddResource(route)
+			}
+
+			err := integration.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			routes := construct.GetResources[*WebSocketRoute](dag)
+			var routeKeys []string
+			for _, route := range routes {
+				routeKeys = append(routeKeys, route.RouteKey)
+				assert.Len(route.ConstructRefs, 1)
+			}
+			assert.ElementsMatch(tt.wantRouteKeys, routeKeys)
+		})
+	}
+}
+
+func Test_HttpApiIntegrationMakeOperational(t *testing.T) {
+	tests := []struct {
+		name               string
+		integration        *HttpApiIntegration
+		otherIntegration   bool
+		wantRouteKey       string
+		wantPayloadVersion string
+		wantErr            bool
+	}{
+		{
+			name:               "only integration gets default route",
+			integration:        &HttpApiIntegration{Name: "integration", Type: "AWS_PROXY"},
+			wantRouteKey:       "$default",
+			wantPayloadVersion: "1.0",
+		},
+		{
+			name:         "integration with route",
+			integration:  &HttpApiIntegration{Name: "integration", Type: "HTTP_PROXY", Route: "/users/:id/:proxy*"},
+			wantRouteKey: "ANY /users/{id}/{proxy+}",
+		},
+		{
+			name:             "multiple integrations without routes",
+			integration:      &HttpApiIntegration{Name: "integration", Type: "AWS_PROXY"},
+			otherIntegration: true,
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			api := &HttpApi{Name: "api"}
+			tt.integration.Api = api
+			tt.integration.ConstructRefs = make(construct.BaseConstructSet)
+			dag.AddDependency(api, tt.integration)
+			if tt.otherIntegration {
+				dag.AddDependency(api, &HttpApiIntegration{Name: "other", Api: api})
+			}
+
+			err := tt.integration.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			routes := construct.GetResources[*HttpApiRoute](dag)
+			if !assert.Len(routes, 1) {
+				return
+			}
+			assert.Equal(tt.wantRouteKey, routes[0].RouteKey)
+			assert.Equal(tt.integration, routes[0].Integration)
+			assert.Equal(tt.wantPayloadVersion, tt.integration.PayloadFormatVersion)
+		})
+	}
+}
@@ -9,6 +9,7 @@ type (
 		Image                *EcrImage
 		InstanceRole         *IamRole
 		EnvironmentVariables map[string]construct.IaCValue
+		Tags                 map[string]string
 	}
 )
 
@@ -3,7 +3,10 @@ package resources
 import (
 	"fmt"
 
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
 	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
 	"github.com/pkg/errors"
 )
@@ -28,6 +31,10 @@ type (
 		DefaultCacheBehavior         *DefaultCacheBehavior
 		Restrictions                 *Restrictions
 		DefaultRootObject            string
+		// Aliases are the custom domains which the distribution serves, over the certificate of Certificate
+		Aliases     []string
+		Certificate *AcmCertificateValidation
+		Tags        map[string]string
 	}
 
 	DefaultCacheBehavior struct {
@@ -117,6 +124,46 @@ func (distro *CloudfrontDistribution) Create(dag *construct.ResourceGraph, param
 	return nil
 }
 
+// MakeOperational sets the distribution's defaults, and serves it at the custom domains of the static units it was
+// created for. CloudFront only uses certificates from us-east-1, so the certificates are created there.
+func (distro *CloudfrontDistribution) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if distro.DefaultCacheBehavior == nil {
+		distro.DefaultCacheBehavior = &DefaultCacheBehavior{
+			AllowedMethods: []string{"DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"},
+			CachedMethods:  []string{"HEAD", "GET"},
+			ForwardedValues: ForwardedValues{
+				QueryString: true,
+				Cookies:     Cookies{Forward: "none"},
+			},
+			DefaultTtl:           3600,
+			MaxTtl:               86400,
+			ViewerProtocolPolicy: "allow-all",
+		}
+	}
+	if distro.Restrictions == nil {
+		distro.Restrictions = &Restrictions{GeoRestriction: GeoRestriction{RestrictionType: "none"}}
+	}
+
+	for _, ref := range distro.ConstructRefs {
+		unit, ok := ref.(*types.StaticUnit)
+		if !ok || unit.Domain == nil || collectionutil.Contains(distro.Aliases, unit.Domain.Name) {
+			continue
+		}
+		if distro.Certificate != nil {
+			return fmt.Errorf("cloudfront distribution %s can only serve one custom domain, but static unit %s has domain %s", distro.Name, unit.Name, unit.Domain.Name)
+		}
+		distro.Certificate = domainCertificate(dag, unit.Domain, cloudfrontCertificateRegion, distro.ConstructRefs)
+		distro.Aliases = append(distro.Aliases, unit.Domain.Name)
+		distro.CloudfrontDefaultCertificate = false
+		dag.AddDependency(distro, distro.Certificate)
+		domainAliasRecord(dag, unit.Domain, distro, distro.ConstructRefs)
+	}
+	if distro.Certificate == nil {
+		distro.CloudfrontDefaultCertificate = true
+	}
+	return nil
+}
+
 // BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
 func (distro *CloudfrontDistribution) BaseConstructRefs() construct.BaseConstructSet {
 	return distro.ConstructRefs
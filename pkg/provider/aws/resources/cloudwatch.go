@@ -7,7 +7,11 @@ import (
 	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
 )
 
-const LOG_GROUP_TYPE = "log_group"
+const (
+	LOG_GROUP_TYPE            = "log_group"
+	CLOUDWATCH_ALARM_TYPE     = "cloudwatch_alarm"
+	CLOUDWATCH_DASHBOARD_TYPE = "cloudwatch_dashboard"
+)
 
 var logGroupSanitizer = aws.CloudwatchLogGroupSanitizer
 
@@ -17,6 +21,35 @@ type (
 		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
 		LogGroupName    string
 		RetentionInDays int
+		Tags            map[string]string
+	}
+
+	// CloudwatchAlarm watches a metric of a resource, selected by its dimensions, and notifies its actions once the metric
+	// breaches the threshold
+	CloudwatchAlarm struct {
+		Name               string
+		ConstructRefs      construct.BaseConstructSet `yaml:"-"`
+		AlarmDescription   string
+		Namespace          string
+		MetricName         string
+		Dimensions         map[string]construct.IaCValue
+		Statistic          string
+		Period             int
+		EvaluationPeriods  int
+		Threshold          float64
+		ComparisonOperator string
+		TreatMissingData   string
+		AlarmActions       []construct.IaCValue
+		Tags               map[string]string
+	}
+
+	// CloudwatchDashboard shows the state and the metric of each of its alarms
+	CloudwatchDashboard struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		DashboardName string
+		Region        *Region
+		Alarms        []*CloudwatchAlarm
 	}
 )
 
@@ -60,3 +93,43 @@ func (lg *LogGroup) DeleteContext() construct.DeleteContext {
 		RequiresNoDownstream: false,
 	}
 }
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (alarm *CloudwatchAlarm) BaseConstructRefs() construct.BaseConstructSet {
+	return alarm.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (alarm *CloudwatchAlarm) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     CLOUDWATCH_ALARM_TYPE,
+		Name:     alarm.Name,
+	}
+}
+
+func (alarm *CloudwatchAlarm) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (dashboard *CloudwatchDashboard) BaseConstructRefs() construct.BaseConstructSet {
+	return dashboard.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (dashboard *CloudwatchDashboard) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     CLOUDWATCH_DASHBOARD_TYPE,
+		Name:     dashboard.Name,
+	}
+}
+
+func (dashboard *CloudwatchDashboard) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
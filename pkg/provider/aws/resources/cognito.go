@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+const (
+	COGNITO_USER_POOL_TYPE        = "cognito_user_pool"
+	COGNITO_USER_POOL_CLIENT_TYPE = "cognito_user_pool_client"
+)
+
+type (
+	// CognitoUserPool is the directory of users which sign in to an application
+	CognitoUserPool struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Tags          map[string]string
+	}
+
+	// CognitoUserPoolClient is the app client which users of UserPool sign in through. Its id is the audience of the
+	// tokens the pool issues.
+	CognitoUserPoolClient struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		UserPool      *CognitoUserPool
+	}
+)
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (pool *CognitoUserPool) BaseConstructRefs() construct.BaseConstructSet {
+	return pool.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (pool *CognitoUserPool) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     COGNITO_USER_POOL_TYPE,
+		Name:     pool.Name,
+	}
+}
+
+func (pool *CognitoUserPool) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (client *CognitoUserPoolClient) BaseConstructRefs() construct.BaseConstructSet {
+	return client.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (client *CognitoUserPoolClient) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     COGNITO_USER_POOL_CLIENT_TYPE,
+		Name:     client.Name,
+	}
+}
+
+func (client *CognitoUserPoolClient) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -17,6 +17,9 @@ type (
 		BillingMode   string
 		HashKey       string
 		RangeKey      string
+		// Replicas are the regions which the table is replicated into as a global table
+		Replicas []string
+		Tags     map[string]string
 	}
 
 	DynamodbTableAttribute struct {
@@ -22,6 +22,7 @@ type (
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		ForceDelete   bool
+		Tags          map[string]string
 	}
 
 	EcrImage struct {
@@ -34,6 +35,24 @@ type (
 		ExtraOptions  []string
 		// BaseImage is used to denote the base image for the dockerfile that will be pulled before building in the generated IaC
 		BaseImage string
+		// LambdaLayers are the lambda layers whose content the dockerfile adds to the image, since functions which are
+		// deployed as images cannot use layers. The build receives the url of each layer's content as its BuildArg.
+		LambdaLayers []LambdaLayer
+	}
+
+	// LambdaLayer is a lambda layer, whose version is chosen by the region which the image is deployed to and the
+	// architecture which it is built for
+	LambdaLayer struct {
+		BuildArg string
+		// Versions are the layer's versions. A version without a Region applies to each region which has no version of
+		// its own, and "{region}" in its Arn is replaced by the region.
+		Versions []LambdaLayerVersion
+	}
+
+	LambdaLayerVersion struct {
+		Region       string
+		Architecture string
+		Arn          string
 	}
 )
 
@@ -36,6 +36,16 @@ type (
 		PortMappings            []PortMapping
 		RequiresCompatibilities []string
 		EfsVolumes              []*EcsEfsVolume
+		// Sidecars are the containers which run alongside the task's container
+		Sidecars []EcsContainer
+		Tags     map[string]string
+	}
+
+	// EcsContainer is a container of a task definition, other than the one which runs the task's image
+	EcsContainer struct {
+		Name    string
+		Image   string
+		Command []string
 	}
 
 	EcsEfsVolume struct {
@@ -61,6 +71,7 @@ type (
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		//TODO: add support for cluster configuration
+		Tags map[string]string
 	}
 
 	EcsService struct {
@@ -76,6 +87,7 @@ type (
 		SecurityGroups           []*SecurityGroup
 		Subnets                  []*Subnet
 		TaskDefinition           *EcsTaskDefinition
+		Tags                     map[string]string
 	}
 
 	EcsServiceDeploymentCircuitBreaker struct {
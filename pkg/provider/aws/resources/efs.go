@@ -42,6 +42,7 @@ type (
 		AvailabilityZoneName *construct.IaCValue
 		// CreationToken is the creation token of the EfsFileSystem
 		CreationToken string
+		Tags          map[string]string
 	}
 
 	EfsMountTarget struct {
@@ -109,6 +109,7 @@ type (
 		Subnets        []*Subnet
 		SecurityGroups []*SecurityGroup
 		Kubeconfig     *kubernetes.Kubeconfig `yaml:"-"`
+		Tags           map[string]string
 	}
 
 	EksFargateProfile struct {
@@ -17,6 +17,7 @@ type (
 		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
 		NodeType        string
 		NumCacheNodes   int
+		Tags            map[string]string
 	}
 
 	ElasticacheSubnetgroup struct {
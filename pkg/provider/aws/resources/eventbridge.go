@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+const (
+	EVENT_BRIDGE_RULE_TYPE   = "event_bridge_rule"
+	EVENT_BRIDGE_TARGET_TYPE = "event_bridge_target"
+)
+
+type (
+	EventBridgeRule struct {
+		Name               string
+		ConstructRefs      construct.BaseConstructSet `yaml:"-"`
+		ScheduleExpression string
+	}
+
+	EventBridgeTarget struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Rule          *EventBridgeRule
+		// Function is set when the target is a lambda function
+		Function *LambdaFunction
+		// EcsService is set when the target runs a one-off task from the service's task definition
+		EcsService *EcsService
+		// Role is the role EventBridge assumes to run ECS tasks
+		Role  *IamRole
+		Input string
+	}
+)
+
+type EventBridgeRuleConfigureParams struct {
+}
+
+// Configure sets the rule's schedule expression from the schedule construct it was expanded from
+func (rule *EventBridgeRule) Configure(params EventBridgeRuleConfigureParams) error {
+	if rule.ScheduleExpression != "" {
+		return nil
+	}
+	for _, ref := range rule.ConstructRefs {
+		schedule, ok := ref.(*types.Schedule)
+		if !ok {
+			continue
+		}
+		expression, err := ScheduleExpression(schedule)
+		if err != nil {
+			return err
+		}
+		rule.ScheduleExpression = expression
+	}
+	return nil
+}
+
+// ScheduleExpression converts the schedule's cron or rate into an EventBridge schedule expression.
+// Standard 5-field cron expressions are converted to EventBridge's 6-field format.
+func ScheduleExpression(schedule *types.Schedule) (string, error) {
+	if schedule.Rate != "" {
+		value, unit, err := types.ParseRate(schedule.Rate)
+		if err != nil {
+			return "", err
+		}
+		if value != 1 {
+			unit += "s"
+		}
+		return fmt.Sprintf("rate(%d %s)", value, unit), nil
+	}
+
+	fields := strings.Fields(schedule.Cron)
+	switch len(fields) {
+	case 6:
+	case 5:
+		dayOfWeek, err := eventBridgeDaysOfWeek(fields[4])
+		if err != nil {
+			return "", fmt.Errorf("invalid cron expression '%s' for schedule %s: %w", schedule.Cron, schedule.Name, err)
+		}
+		fields[4] = dayOfWeek
+		fields = append(fields, "*")
+	default:
+		return "", fmt.Errorf("invalid cron expression '%s' for schedule %s", schedule.Cron, schedule.Name)
+	}
+	// EventBridge requires exactly one of day-of-month and day-of-week to be '?'
+	dayOfMonth, dayOfWeek := fields[2], fields[4]
+	if dayOfMonth != "?" && dayOfWeek != "?" {
+		switch {
+		case dayOfWeek == "*":
+			fields[4] = "?"
+		case dayOfMonth == "*":
+			fields[2] = "?"
+		default:
+			return "", fmt.Errorf("invalid cron expression '%s' for schedule %s: day-of-month and day-of-week cannot both be set", schedule.Cron, schedule.Name)
+		}
+	}
+	return fmt.Sprintf("cron(%s)", strings.Join(fields, " ")), nil
+}
+
+// eventBridgeDaysOfWeek converts the day-of-week field of a standard cron expression, which numbers the days of the week
+// from 0 (Sunday) to 7 (Sunday again), to EventBridge's, which numbers them from 1 (Sunday) to 7 (Saturday). A range
+// which ends on day 7 is split in two, and values which are listed more than once after the conversion are kept once.
+func eventBridgeDaysOfWeek(field string) (string, error) {
+	var values []string
+	add := func(value string) {
+		if !collectionutil.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	for _, value := range strings.Split(field, ",") {
+		days, step, hasStep := strings.Cut(value, "/")
+		if hasStep {
+			step = "/" + step
+		}
+		start, end, isRange := strings.Cut(days, "-")
+		startDay, err := strconv.Atoi(start)
+		if err != nil {
+			// '*', '?' and named days are the same in both formats
+			add(value)
+			continue
+		}
+		if !isRange {
+			add(fmt.Sprintf("%d%s", startDay%7+1, step))
+			continue
+		}
+		endDay, err := strconv.Atoi(end)
+		if err != nil {
+			return "", fmt.Errorf("invalid day-of-week range '%s'", days)
+		}
+		if endDay != 7 {
+			add(fmt.Sprintf("%d-%d%s", startDay%7+1, endDay%7+1, step))
+			continue
+		}
+		if hasStep {
+			return "", fmt.Errorf("day-of-week range '%s' cannot end on day 7 with a step", value)
+		}
+		if startDay == 0 {
+			add("*")
+			continue
+		}
+		add(fmt.Sprintf("%d-7", startDay+1))
+		add("1")
+	}
+	return strings.Join(values, ","), nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rule *EventBridgeRule) BaseConstructRefs() construct.BaseConstructSet {
+	return rule.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rule *EventBridgeRule) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     EVENT_BRIDGE_RULE_TYPE,
+		Name:     rule.Name,
+	}
+}
+
+func (rule *EventBridgeRule) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstreamOrDownstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (target *EventBridgeTarget) BaseConstructRefs() construct.BaseConstructSet {
+	return target.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (target *EventBridgeTarget) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     EVENT_BRIDGE_TARGET_TYPE,
+		Name:     target.Name,
+	}
+}
+
+func (target *EventBridgeTarget) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScheduleExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule types.Schedule
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "rate",
+			schedule: types.Schedule{Rate: "5 minutes"},
+			want:     "rate(5 minutes)",
+		},
+		{
+			name:     "singular rate",
+			schedule: types.Schedule{Rate: "1 hours"},
+			want:     "rate(1 hour)",
+		},
+		{
+			name:     "standard cron every day",
+			schedule: types.Schedule{Cron: "0 3 * * *"},
+			want:     "cron(0 3 * * ? *)",
+		},
+		{
+			name:     "standard cron day of week",
+			schedule: types.Schedule{Cron: "30 9 * * 1-5"},
+			want:     "cron(30 9 ? * 2-6 *)",
+		},
+		{
+			name:     "standard cron sunday",
+			schedule: types.Schedule{Cron: "0 0 * * 0,7"},
+			want:     "cron(0 0 ? * 1 *)",
+		},
+		{
+			name:     "standard cron range to sunday",
+			schedule: types.Schedule{Cron: "0 0 * * 5-7"},
+			want:     "cron(0 0 ? * 6-7,1 *)",
+		},
+		{
+			name:     "standard cron step",
+			schedule: types.Schedule{Cron: "0 0 * * 1-5/2"},
+			want:     "cron(0 0 ? * 2-6/2 *)",
+		},
+		{
+			name:     "standard cron named days",
+			schedule: types.Schedule{Cron: "0 0 * * SAT,SUN"},
+			want:     "cron(0 0 ? * SAT,SUN *)",
+		},
+		{
+			name:     "aws cron is unchanged",
+			schedule: types.Schedule{Cron: "0 12 ? * MON-FRI *"},
+			want:     "cron(0 12 ? * MON-FRI *)",
+		},
+		{
+			name:     "day of month and week",
+			schedule: types.Schedule{Cron: "0 0 1 * 1"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := ScheduleExpression(&tt.schedule)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_EventBridgeRuleConfigure(t *testing.T) {
+	assert := assert.New(t)
+	schedule := &types.Schedule{Name: "nightly", Rate: "1 day"}
+	rule := &EventBridgeRule{Name: "rule", ConstructRefs: construct.BaseConstructSetOf(schedule)}
+
+	err := rule.Configure(EventBridgeRuleConfigureParams{})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("rate(1 day)", rule.ScheduleExpression)
+}
@@ -61,6 +61,19 @@ var EC2_ASSUMER_ROLE_POLICY = &PolicyDocument{
 	},
 }
 
+var EVENTS_ASSUMER_ROLE_POLICY = &PolicyDocument{
+	Version: VERSION,
+	Statement: []StatementEntry{
+		{
+			Action: []string{"sts:AssumeRole"},
+			Principal: &Principal{
+				Service: "events.amazonaws.com",
+			},
+			Effect: "Allow",
+		},
+	},
+}
+
 var EKS_FARGATE_ASSUME_ROLE_POLICY = &PolicyDocument{
 	Version: VERSION,
 	Statement: []StatementEntry{
@@ -95,6 +108,7 @@ type (
 		ManagedPolicies     []construct.IaCValue
 		AwsManagedPolicies  []string
 		InlinePolicies      []*IamInlinePolicy
+		Tags                map[string]string
 	}
 
 	IamPolicy struct {
@@ -132,6 +146,8 @@ type (
 		StringEquals map[construct.IaCValue]string
 		StringLike   map[construct.IaCValue]string
 		Null         map[construct.IaCValue]string
+		// ArnEquals compares condition keys to the arns of resources, such as the `aws:SourceArn` of a service principal
+		ArnEquals map[construct.IaCValue]construct.IaCValue
 	}
 
 	OpenIdConnectProvider struct {
@@ -411,12 +427,20 @@ func (c Condition) MarshalYAML() (interface{}, error) {
 		Key construct.IaCValue
 		Val string
 	}
+	type arnEntry struct {
+		Key construct.IaCValue
+		Val construct.IaCValue
+	}
 	type condition struct {
 		StringEquals []mapEntry
 		StringLike   []mapEntry
 		Null         []mapEntry
+		ArnEquals    []arnEntry
 	}
 	intermediate := condition{}
+	for k, v := range c.ArnEquals {
+		intermediate.ArnEquals = append(intermediate.ArnEquals, arnEntry{k, v})
+	}
 	for k, v := range c.StringEquals {
 		intermediate.StringEquals = append(intermediate.StringEquals, mapEntry{k, v})
 	}
@@ -434,10 +458,15 @@ func (c *Condition) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		key construct.IaCValue
 		val string
 	}
+	type arnEntry struct {
+		key construct.IaCValue
+		val construct.IaCValue
+	}
 	type condition struct {
 		StringEquals []mapEntry
 		StringLike   []mapEntry
 		Null         []mapEntry
+		ArnEquals    []arnEntry
 	}
 	intermediate := condition{}
 	err := unmarshal(&intermediate)
@@ -456,6 +485,12 @@ func (c *Condition) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	for _, entry := range intermediate.Null {
 		c.Null[entry.key] = entry.val
 	}
+	if len(intermediate.ArnEquals) > 0 {
+		c.ArnEquals = map[construct.IaCValue]construct.IaCValue{}
+	}
+	for _, entry := range intermediate.ArnEquals {
+		c.ArnEquals[entry.key] = entry.val
+	}
 	return nil
 }
 
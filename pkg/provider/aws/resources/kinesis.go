@@ -20,6 +20,7 @@ type (
 		ShardCount           int
 		StreamEncryption     *StreamEncryption
 		StreamModeDetails    StreamModeDetails
+		Tags                 map[string]string
 	}
 
 	StreamEncryption struct {
@@ -25,6 +25,7 @@ type (
 		KeyUsage            string
 		MultiRegion         bool
 		PendingWindowInDays int
+		Tags                map[string]string
 	}
 
 	KmsAlias struct {
@@ -8,8 +8,9 @@ import (
 )
 
 const (
-	LAMBDA_FUNCTION_TYPE   = "lambda_function"
-	LAMBDA_PERMISSION_TYPE = "lambda_permission"
+	LAMBDA_FUNCTION_TYPE             = "lambda_function"
+	LAMBDA_PERMISSION_TYPE           = "lambda_permission"
+	LAMBDA_EVENT_SOURCE_MAPPING_TYPE = "lambda_event_source_mapping"
 )
 
 var lambdaFunctionSanitizer = aws.LambdaFunctionSanitizer
@@ -27,6 +28,7 @@ type (
 		Timeout              int
 		MemorySize           int
 		EfsAccessPoint       *EfsAccessPoint
+		Tags                 map[string]string
 	}
 
 	LambdaPermission struct {
@@ -37,6 +39,17 @@ type (
 		Source        construct.IaCValue
 		Action        string
 	}
+
+	// LambdaEventSourceMapping invokes Function with batches of the messages received from Queue
+	LambdaEventSourceMapping struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Function      *LambdaFunction
+		Queue         *SqsQueue
+		BatchSize     int
+		// FunctionResponseTypes lets the function report which messages of a batch failed, so that only they are retried
+		FunctionResponseTypes []string
+	}
 )
 
 type LambdaCreateParams struct {
@@ -129,3 +142,23 @@ func (permission *LambdaPermission) DeleteContext() construct.DeleteContext {
 		RequiresNoUpstream: true,
 	}
 }
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (mapping *LambdaEventSourceMapping) BaseConstructRefs() construct.BaseConstructSet {
+	return mapping.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (mapping *LambdaEventSourceMapping) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     LAMBDA_EVENT_SOURCE_MAPPING_TYPE,
+		Name:     mapping.Name,
+	}
+}
+
+func (mapping *LambdaEventSourceMapping) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -18,6 +18,7 @@ const (
 	LISTENER_TYPE                 = "load_balancer_listener"
 	NLB_INTEGRATION_URI_IAC_VALUE = "nlb_uri"
 	TARGET_GROUP_ARN_IAC_VALUE    = "target_group_arn"
+	ARN_SUFFIX_IAC_VALUE          = "arn_suffix"
 )
 
 type (
@@ -7,15 +7,24 @@ import (
 func ListAll() []construct.Resource {
 	return []construct.Resource{
 		&AccountId{},
+		&AcmCertificate{},
+		&AcmCertificateValidation{},
+		&ApiBasePathMapping{},
 		&ApiDeployment{},
+		&ApiDomainName{},
 		&AMI{},
 		&ApiIntegration{},
 		&ApiMethod{},
+		&ApiAuthorizer{},
 		&ApiResource{},
 		&ApiStage{},
 		&AppRunnerService{},
 		&AvailabilityZones{},
 		&CloudfrontDistribution{},
+		&CloudwatchAlarm{},
+		&CloudwatchDashboard{},
+		&CognitoUserPool{},
+		&CognitoUserPoolClient{},
 		&DynamodbTable{},
 		&EcrImage{},
 		&EcrRepository{},
@@ -33,6 +42,16 @@ func ListAll() []construct.Resource {
 		&ElasticIp{},
 		&ElasticacheCluster{},
 		&ElasticacheSubnetgroup{},
+		&EventBridgeRule{},
+		&EventBridgeTarget{},
+		&HttpApi{},
+		&HttpApiAuthorizer{},
+		&HttpApiDomainName{},
+		&HttpApiIntegration{},
+		&HttpApiMapping{},
+		&HttpApiRoute{},
+		&HttpApiStage{},
+		&HttpApiVpcLink{},
 		&IamPolicy{},
 		&IamRole{},
 		&InstanceProfile{},
@@ -42,6 +61,7 @@ func ListAll() []construct.Resource {
 		&KmsAlias{},
 		&KmsKey{},
 		&KmsReplicaKey{},
+		&LambdaEventSourceMapping{},
 		&LambdaFunction{},
 		&LambdaPermission{},
 		&Listener{},
@@ -52,10 +72,14 @@ func ListAll() []construct.Resource {
 		&OriginAccessIdentity{},
 		&PrivateDnsNamespace{},
 		&RdsInstance{},
+		&RdsGlobalCluster{},
+		&RdsCluster{},
+		&RdsClusterInstance{},
 		&RdsProxyTargetGroup{},
 		&RdsProxy{},
 		&RdsSubnetGroup{},
 		&Region{},
+		&RegionProvider{},
 		&RestApi{},
 		&RolePolicyAttachment{},
 		&RouteTable{},
@@ -65,8 +89,14 @@ func ListAll() []construct.Resource {
 		&S3BucketPolicy{},
 		&S3Bucket{},
 		&S3Object{},
+		&S3BucketNotification{},
+		&S3BucketReplication{},
+		&OrmMigration{},
 		&SecretVersion{},
 		&Secret{},
+		&SecretRotation{},
+		&RdsRotationFunction{},
+		&SsmParameter{},
 		&SecurityGroup{},
 		&SesEmailIdentity{},
 		&SnsTopic{},
@@ -75,9 +105,14 @@ func ListAll() []construct.Resource {
 		&Subnet{Type: PublicSubnet},
 		&SqsQueuePolicy{},
 		&SqsQueue{},
+		&SfnStateMachine{},
 		&TargetGroup{},
 		&VpcEndpoint{},
 		&VpcLink{},
 		&Vpc{},
+		&WebSocketApi{},
+		&WebSocketIntegration{},
+		&WebSocketRoute{},
+		&WebSocketStage{},
 	}
 }
@@ -0,0 +1,155 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	ORM_MIGRATION_TYPE = "orm_migration"
+
+	// migrationFunctionTimeout is the longest a lambda function can run, so that migrations have as long as possible
+	migrationFunctionTimeout = 900
+
+	// ORM_MIGRATION_COMMAND_ENV_VAR is the environment variable of the migration function which holds the command that
+	// its dispatcher runs. Only that function sets it, so the migrations cannot be run through the unit's own function.
+	ORM_MIGRATION_COMMAND_ENV_VAR = "KLOTHO_MIGRATION_COMMAND"
+)
+
+type (
+	// OrmMigration runs the Command of an orm's migrations once per change to the image which contains them.
+	// Lambda execution units run it in Function, a copy of the unit's function which is updated before the migrations run;
+	// ECS execution units run it in a one-off task of the unit's TaskDefinition.
+	OrmMigration struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Command       string
+		Region        *Region
+		Function      *LambdaFunction
+		Cluster       *EcsCluster
+		// TaskDefinition and ContainerName are the task definition and container of the ECS service which runs the unit
+		TaskDefinition *EcsTaskDefinition
+		ContainerName  string
+		Subnets        []*Subnet
+		SecurityGroups []*SecurityGroup
+		AssignPublicIp bool
+	}
+)
+
+// OrmMigrationConstruct returns the orm migration construct that the migration was expanded from, if any
+func (migration *OrmMigration) OrmMigrationConstruct() *types.OrmMigration {
+	for _, ref := range migration.ConstructRefs {
+		if m, ok := ref.(*types.OrmMigration); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// MakeOperational sets up the migration to run from the image of the lambda function or ECS service downstream of it,
+// which is the compute of the execution unit that runs the migrations.
+func (migration *OrmMigration) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if m := migration.OrmMigrationConstruct(); m != nil {
+		migration.Command = m.Command
+	}
+	if migration.Command == "" {
+		return fmt.Errorf("orm migration %s has no command", migration.Name)
+	}
+
+	functions := construct.GetDownstreamResourcesOfType[*LambdaFunction](dag, migration)
+	services := construct.GetDownstreamResourcesOfType[*EcsService](dag, migration)
+	switch {
+	case len(functions) == 1 && len(services) == 0:
+		return migration.runInFunction(dag, functions[0])
+	case len(services) == 1 && len(functions) == 0:
+		return migration.runInTask(dag, services[0])
+	default:
+		return fmt.Errorf("orm migration %s must run in exactly one lambda function or ecs service, found %d functions and %d services", migration.Name, len(functions), len(services))
+	}
+}
+
+// runInFunction creates the function which runs the migrations: a copy of `function` which the migration depends on
+// (through an upstream edge, so that `function` is only updated after the migrations run). The command is set in the
+// copy's environment rather than sent by the migration, so that invoking either function cannot run any other command.
+func (migration *OrmMigration) runInFunction(dag *construct.ResourceGraph, function *LambdaFunction) error {
+	if function.Image == nil || function.Role == nil {
+		return fmt.Errorf("lambda function %s of orm migration %s has no image or role", function.Name, migration.Name)
+	}
+	migrationFunction := &LambdaFunction{
+		Name:          lambdaFunctionSanitizer.Apply(fmt.Sprintf("%s-migrate", function.Name)),
+		ConstructRefs: migration.ConstructRefs.Clone(),
+	}
+	if existing, ok := construct.GetResource[*LambdaFunction](dag, migrationFunction.Id()); ok {
+		migrationFunction = existing
+	}
+	migrationFunction.Role = function.Role
+	migrationFunction.Image = function.Image
+	migrationFunction.EnvironmentVariables = make(map[string]construct.IaCValue, len(function.EnvironmentVariables)+1)
+	for name, value := range function.EnvironmentVariables {
+		migrationFunction.EnvironmentVariables[name] = value
+	}
+	migrationFunction.EnvironmentVariables[ORM_MIGRATION_COMMAND_ENV_VAR] = construct.IaCValue{Property: migration.Command}
+	migrationFunction.Subnets = function.Subnets
+	migrationFunction.SecurityGroups = function.SecurityGroups
+	migrationFunction.MemorySize = function.MemorySize
+	migrationFunction.EfsAccessPoint = function.EfsAccessPoint
+	migrationFunction.Timeout = migrationFunctionTimeout
+
+	dag.AddDependency(migrationFunction, migration)
+	dag.AddDependency(migrationFunction, function.Role)
+	dag.AddDependency(migrationFunction, function.Image)
+	for _, subnet := range function.Subnets {
+		dag.AddDependency(migrationFunction, subnet)
+	}
+	for _, sg := range function.SecurityGroups {
+		dag.AddDependency(migrationFunction, sg)
+	}
+	migration.Function = migrationFunction
+	return nil
+}
+
+// runInTask runs the migrations in a task of the service's task definition, on the service's cluster and network
+func (migration *OrmMigration) runInTask(dag *construct.ResourceGraph, service *EcsService) error {
+	if service.TaskDefinition == nil || service.Cluster == nil {
+		return fmt.Errorf("ecs service %s of orm migration %s has no task definition or cluster", service.Name, migration.Name)
+	}
+	migration.Cluster = service.Cluster
+	migration.TaskDefinition = service.TaskDefinition
+	migration.ContainerName = service.TaskDefinition.Name
+	migration.Subnets = service.Subnets
+	migration.SecurityGroups = service.SecurityGroups
+	migration.AssignPublicIp = service.AssignPublicIp
+
+	dag.AddDependency(migration, service.Cluster)
+	dag.AddDependency(migration, service.TaskDefinition)
+	for _, subnet := range service.Subnets {
+		dag.AddDependency(migration, subnet)
+	}
+	for _, sg := range service.SecurityGroups {
+		dag.AddDependency(migration, sg)
+	}
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (migration *OrmMigration) BaseConstructRefs() construct.BaseConstructSet {
+	return migration.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (migration *OrmMigration) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     ORM_MIGRATION_TYPE,
+		Name:     migration.Name,
+	}
+}
+
+func (migration *OrmMigration) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OrmMigrationMakeOperational_Lambda(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	ormMigration := &types.OrmMigration{Name: "db", Tool: types.MigrationToolAlembic, Command: "alembic upgrade head"}
+	migration := &OrmMigration{Name: "orm_migration-db", ConstructRefs: construct.BaseConstructSetOf(ormMigration)}
+	role := &IamRole{Name: "api-role"}
+	image := &EcrImage{Name: "api-image"}
+	subnet := &Subnet{Name: "private1"}
+	sg := &SecurityGroup{Name: "sg"}
+	function := &LambdaFunction{
+		Name:                 "app-api",
+		Role:                 role,
+		Image:                image,
+		Subnets:              []*Subnet{subnet},
+		SecurityGroups:       []*SecurityGroup{sg},
+		MemorySize:           512,
+		Timeout:              180,
+		EnvironmentVariables: map[string]construct.IaCValue{"DB_PERSIST_ORM_CONNECTION": {ResourceId: construct.ResourceId{Provider: AWS_PROVIDER, Type: RDS_INSTANCE_TYPE, Name: "db"}, Property: "connection_string"}},
+	}
+	dag.AddDependency(migration, function)
+
+	if !assert.NoError(migration.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	assert.Equal("alembic upgrade head", migration.Command)
+	migrationFunction := migration.Function
+	if !assert.NotNil(migrationFunction) {
+		return
+	}
+	assert.Equal("app-api-migrate", migrationFunction.Name)
+	assert.Equal(role, migrationFunction.Role)
+	assert.Equal(image, migrationFunction.Image)
+	assert.Equal(map[string]construct.IaCValue{
+		"DB_PERSIST_ORM_CONNECTION":   function.EnvironmentVariables["DB_PERSIST_ORM_CONNECTION"],
+		ORM_MIGRATION_COMMAND_ENV_VAR: {Property: "alembic upgrade head"},
+	}, migrationFunction.EnvironmentVariables)
+	assert.NotContains(function.EnvironmentVariables, ORM_MIGRATION_COMMAND_ENV_VAR)
+	assert.Equal([]*Subnet{subnet}, migrationFunction.Subnets)
+	assert.Equal([]*SecurityGroup{sg}, migrationFunction.SecurityGroups)
+	assert.Equal(512, migrationFunction.MemorySize)
+	assert.Equal(migrationFunctionTimeout, migrationFunction.Timeout)
+	assert.NotNil(dag.GetDependency(migrationFunction.Id(), migration.Id()))
+	assert.NotNil(dag.GetDependency(migrationFunction.Id(), role.Id()))
+	assert.NotNil(dag.GetDependency(migrationFunction.Id(), image.Id()))
+	assert.Nil(migration.TaskDefinition)
+
+	// making the migration operational again reuses the function
+	if !assert.NoError(migration.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	assert.Len(construct.GetResources[*LambdaFunction](dag), 2)
+}
+
+func Test_OrmMigrationMakeOperational_Ecs(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	ormMigration := &types.OrmMigration{Name: "db", Tool: types.MigrationToolPrisma, Command: "npx prisma migrate deploy"}
+	migration := &OrmMigration{Name: "orm_migration-db", ConstructRefs: construct.BaseConstructSetOf(ormMigration)}
+	cluster := &EcsCluster{Name: "cluster"}
+	taskDefinition := &EcsTaskDefinition{Name: "app-api"}
+	subnet := &Subnet{Name: "private1"}
+	sg := &SecurityGroup{Name: "sg"}
+	service := &EcsService{
+		Name:           "app-api",
+		Cluster:        cluster,
+		TaskDefinition: taskDefinition,
+		Subnets:        []*Subnet{subnet},
+		SecurityGroups: []*SecurityGroup{sg},
+	}
+	dag.AddDependency(migration, service)
+
+	if !assert.NoError(migration.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	assert.Equal("npx prisma migrate deploy", migration.Command)
+	assert.Equal(cluster, migration.Cluster)
+	assert.Equal(taskDefinition, migration.TaskDefinition)
+	assert.Equal("app-api", migration.ContainerName)
+	assert.Equal([]*Subnet{subnet}, migration.Subnets)
+	assert.Equal([]*SecurityGroup{sg}, migration.SecurityGroups)
+	assert.NotNil(dag.GetDependency(migration.Id(), cluster.Id()))
+	assert.NotNil(dag.GetDependency(migration.Id(), taskDefinition.Id()))
+	assert.Nil(migration.Function)
+}
+
+func Test_OrmMigrationMakeOperational_NoCompute(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	migration := &OrmMigration{
+		Name:          "orm_migration-db",
+		ConstructRefs: construct.BaseConstructSetOf(&types.OrmMigration{Name: "db", Command: "alembic upgrade head"}),
+	}
+	dag.AddResource(migration)
+
+	assert.Error(migration.MakeOperational(dag, "app", nil))
+}
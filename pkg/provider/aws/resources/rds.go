@@ -34,10 +34,13 @@ var (
 )
 
 const (
-	RDS_INSTANCE_TYPE      = "rds_instance"
-	RDS_SUBNET_GROUP_TYPE  = "rds_subnet_group"
-	RDS_PROXY_TYPE         = "rds_proxy"
-	RDS_PROXY_TARGET_GROUP = "rds_proxy_target_group"
+	RDS_INSTANCE_TYPE         = "rds_instance"
+	RDS_SUBNET_GROUP_TYPE     = "rds_subnet_group"
+	RDS_PROXY_TYPE            = "rds_proxy"
+	RDS_PROXY_TARGET_GROUP    = "rds_proxy_target_group"
+	RDS_GLOBAL_CLUSTER_TYPE   = "rds_global_cluster"
+	RDS_CLUSTER_TYPE          = "rds_cluster"
+	RDS_CLUSTER_INSTANCE_TYPE = "rds_cluster_instance"
 
 	RDS_CONNECTION_ARN_IAC_VALUE = "rds_connection_arn"
 )
@@ -60,6 +63,7 @@ type (
 		AllocatedStorage                 int
 		CredentialsFile                  io.File `yaml:"-"`
 		CredentialsPath                  string
+		Tags                             map[string]string
 	}
 
 	// RdsSubnetGroup represents an AWS RDS subnet group
@@ -82,6 +86,7 @@ type (
 		SecurityGroups    []*SecurityGroup
 		Subnets           []*Subnet
 		Auths             []*ProxyAuth `render:"document"`
+		Tags              map[string]string
 	}
 
 	// ProxyAuth represents an authorization configuration for an AWS RDS Proxy instance
@@ -93,14 +98,63 @@ type (
 
 	// RdsProxyTargetGroup represents an AWS RDS proxy target group
 	RdsProxyTargetGroup struct {
-		Name                            string
-		ConstructRefs                   construct.BaseConstructSet `yaml:"-"`
-		RdsInstance                     *RdsInstance
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		RdsInstance   *RdsInstance
+		// RdsCluster is targeted instead of RdsInstance when the database is an Aurora cluster
+		RdsCluster                      *RdsCluster
 		RdsProxy                        *RdsProxy
 		TargetGroupName                 string
 		ConnectionPoolConfigurationInfo *ConnectionPoolConfigurationInfo `render:"document"`
 	}
 
+	// RdsGlobalCluster is an Aurora global database, which replicates the data of its primary cluster into the
+	// secondary clusters of other regions
+	RdsGlobalCluster struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Engine        string
+		EngineVersion string
+		DatabaseName  string
+	}
+
+	// RdsCluster is an Aurora db cluster of a global database. The primary cluster is created with the credentials
+	// and database of the global database, while a secondary cluster is a read-only replica of it until the global
+	// database fails over to the secondary's region.
+	RdsCluster struct {
+		Name                             string
+		ConstructRefs                    construct.BaseConstructSet `yaml:"-"`
+		GlobalCluster                    *RdsGlobalCluster
+		Engine                           string
+		EngineVersion                    string
+		DatabaseName                     string
+		IamDatabaseAuthenticationEnabled bool
+		SubnetGroup                      *RdsSubnetGroup
+		SecurityGroups                   []*SecurityGroup
+		SkipFinalSnapshot                bool
+		CredentialsFile                  io.File `yaml:"-"`
+		CredentialsPath                  string
+		// CredentialsSecret is the name of the secret which holds the credentials of a secondary cluster whose global
+		// database was created by another deployment, in place of CredentialsPath
+		CredentialsSecret string
+		// Provider deploys the cluster into the region of the provider, rather than the region of the stack
+		Provider *RegionProvider
+		Tags     map[string]string
+	}
+
+	// RdsClusterInstance is a db instance of an Aurora cluster, which is the writer of a primary cluster and a reader
+	// of a secondary cluster
+	RdsClusterInstance struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Cluster       *RdsCluster
+		Engine        string
+		EngineVersion string
+		InstanceClass string
+		Provider      *RegionProvider
+		Tags          map[string]string
+	}
+
 	// ConnectionPoolConfigurationInfo represents the connection pool configuration within a RDS proxy target group
 	ConnectionPoolConfigurationInfo struct {
 		ConnectionBorrowTimeout   int
@@ -337,6 +391,76 @@ func (rds *RdsProxyTargetGroup) DeleteContext() construct.DeleteContext {
 	}
 }
 
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rds *RdsGlobalCluster) BaseConstructRefs() construct.BaseConstructSet {
+	return rds.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rds *RdsGlobalCluster) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     RDS_GLOBAL_CLUSTER_TYPE,
+		Name:     rds.Name,
+	}
+}
+
+func (rds *RdsGlobalCluster) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rds *RdsCluster) BaseConstructRefs() construct.BaseConstructSet {
+	return rds.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rds *RdsCluster) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     RDS_CLUSTER_TYPE,
+		Name:     rds.Name,
+	}
+}
+
+func (rds *RdsCluster) GetOutputFiles() []io.File {
+	if rds.CredentialsFile == nil {
+		return nil
+	}
+	return []io.File{rds.CredentialsFile}
+}
+
+func (rds *RdsCluster) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresNoDownstream:   true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rds *RdsClusterInstance) BaseConstructRefs() construct.BaseConstructSet {
+	return rds.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rds *RdsClusterInstance) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     RDS_CLUSTER_INSTANCE_TYPE,
+		Name:     rds.Name,
+	}
+}
+
+func (rds *RdsClusterInstance) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
 func (rds *RdsInstance) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
 	// Set a default database name to ensure we actually create a database on the instance
 	if rds.DatabaseName == "" {
@@ -19,6 +19,13 @@ type (
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 	}
+
+	// RegionProvider deploys the resources which use it into Region, rather than the region of the stack
+	RegionProvider struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Region        string
+	}
 )
 
 const (
@@ -28,6 +35,7 @@ const (
 	REGION_TYPE             = "region"
 	AVAILABILITY_ZONES_TYPE = "availability_zones"
 	ACCOUNT_ID_TYPE         = "account_id"
+	REGION_PROVIDER_TYPE    = "region_provider"
 	ARN_IAC_VALUE           = "arn"
 )
 
@@ -112,3 +120,31 @@ func (id *AccountId) DeleteContext() construct.DeleteContext {
 		RequiresNoUpstream: true,
 	}
 }
+
+func NewRegionProvider(region string) *RegionProvider {
+	return &RegionProvider{
+		Name:          region,
+		ConstructRefs: construct.BaseConstructSetOf(),
+		Region:        region,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (provider *RegionProvider) BaseConstructRefs() construct.BaseConstructSet {
+	return provider.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (provider *RegionProvider) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     REGION_PROVIDER_TYPE,
+		Name:     provider.Name,
+	}
+}
+
+func (provider *RegionProvider) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -18,6 +18,8 @@ type (
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		Vpcs          []*Vpc
 		ForceDestroy  bool
+		// ExistingZoneName is the domain of an existing public zone, which is looked up rather than created
+		ExistingZoneName string
 	}
 
 	Route53Record struct {
@@ -29,6 +31,17 @@ type (
 		Records       []construct.IaCValue
 		HealthCheck   *Route53HealthCheck
 		TTL           int
+		// Certificate is the certificate which the record validates. Its validation option sets the record's name, type and value.
+		Certificate *AcmCertificate
+		// AliasTarget is the resource which an alias record points at, instead of Records
+		AliasTarget construct.IaCValue
+		// Failover is PRIMARY or SECONDARY for a record which is routed by failover, along with the record of the same name
+		// whose SetIdentifier differs
+		Failover      string
+		SetIdentifier string
+		// AllowOverwrite lets the record replace one of the same name, such as the validation record of a certificate
+		// which another deployment of the application also validates
+		AllowOverwrite bool
 	}
 
 	Route53HealthCheck struct {
@@ -42,6 +55,8 @@ type (
 		Port             int
 		RequestInterval  int
 		ResourcePath     string
+		// Endpoint is the resource whose domain the health check requests, instead of Fqdn
+		Endpoint construct.IaCValue
 	}
 )
 
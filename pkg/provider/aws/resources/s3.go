@@ -26,6 +26,11 @@ type (
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		ForceDestroy  bool
 		IndexDocument string
+		// Versioning keeps the versions of the bucket's objects, which replication requires of both of its buckets
+		Versioning bool
+		// Provider deploys the bucket into the region of the provider, rather than the region of the stack
+		Provider *RegionProvider
+		Tags     map[string]string
 	}
 
 	S3Object struct {
@@ -0,0 +1,192 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	S3_BUCKET_NOTIFICATION_TYPE = "s3_bucket_notification"
+
+	s3ServicePrincipal = "s3.amazonaws.com"
+)
+
+// s3EventTypes are the S3 event types of each kind of fs event
+var s3EventTypes = map[string]string{
+	types.FsEventWrite:  "s3:ObjectCreated:*",
+	types.FsEventDelete: "s3:ObjectRemoved:*",
+}
+
+type (
+	// S3BucketNotification sends the events of Bucket to lambda functions and sqs queues.
+	// A bucket has a single notification configuration, so all of its destinations are in the same S3BucketNotification.
+	S3BucketNotification struct {
+		Name            string
+		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
+		Bucket          *S3Bucket
+		LambdaFunctions []S3LambdaNotification
+		Queues          []S3QueueNotification
+	}
+
+	// S3LambdaNotification invokes a lambda function with the bucket's events
+	S3LambdaNotification struct {
+		Id                string
+		LambdaFunctionArn construct.IaCValue
+		Events            []string
+		FilterPrefix      string
+		FilterSuffix      string
+	}
+
+	// S3QueueNotification sends the bucket's events to an sqs queue
+	S3QueueNotification struct {
+		Id           string
+		QueueArn     construct.IaCValue
+		Events       []string
+		FilterPrefix string
+		FilterSuffix string
+	}
+)
+
+// FsEventConstruct returns the fs event construct that the notification was expanded from, if any
+func (notification *S3BucketNotification) FsEventConstruct() *types.FsEvent {
+	for _, ref := range notification.ConstructRefs {
+		if event, ok := ref.(*types.FsEvent); ok {
+			return event
+		}
+	}
+	return nil
+}
+
+// MakeOperational sends the events of each subscription of the notification's fs event to the lambda function of the
+// subscribed execution unit. Subscriptions whose function is triggered by a queue downstream of the notification are
+// sent to that queue instead, which buffers the events for the function.
+func (notification *S3BucketNotification) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if notification.Bucket == nil {
+		return fmt.Errorf("bucket notification %s has no bucket", notification.Name)
+	}
+	event := notification.FsEventConstruct()
+	if event == nil {
+		return nil
+	}
+
+	functions := make(map[string]*LambdaFunction)
+	queues := make(map[string]*SqsQueue)
+	for _, res := range dag.GetDownstreamResources(notification) {
+		switch res := res.(type) {
+		case *LambdaFunction:
+			for _, unit := range lambdaExecutionUnits(res) {
+				functions[unit] = res
+			}
+		case *SqsQueue:
+			for _, function := range construct.GetDownstreamResourcesOfType[*LambdaFunction](dag, res) {
+				for _, unit := range lambdaExecutionUnits(function) {
+					queues[unit] = res
+				}
+			}
+		}
+	}
+
+	notification.LambdaFunctions = nil
+	notification.Queues = nil
+	for _, sub := range event.Subscriptions {
+		var events []string
+		for _, e := range sub.Events {
+			events = append(events, s3EventTypes[e])
+		}
+		if function, ok := functions[sub.ExecUnitName]; ok {
+			notification.LambdaFunctions = append(notification.LambdaFunctions, S3LambdaNotification{
+				Id:                sub.Id(),
+				LambdaFunctionArn: construct.IaCValue{ResourceId: function.Id(), Property: ARN_IAC_VALUE},
+				Events:            events,
+				FilterPrefix:      sub.Prefix,
+				FilterSuffix:      sub.Suffix,
+			})
+			notification.grantInvoke(dag, function)
+		} else if queue, ok := queues[sub.ExecUnitName]; ok {
+			notification.Queues = append(notification.Queues, S3QueueNotification{
+				Id:           sub.Id(),
+				QueueArn:     construct.IaCValue{ResourceId: queue.Id(), Property: ARN_IAC_VALUE},
+				Events:       events,
+				FilterPrefix: sub.Prefix,
+				FilterSuffix: sub.Suffix,
+			})
+			notification.grantSendMessage(dag, queue)
+		} else {
+			return fmt.Errorf("execution unit %s subscribed to the events of fs %s must be a lambda function", sub.ExecUnitName, event.Name)
+		}
+	}
+	return nil
+}
+
+// grantInvoke limits the lambda permission between the notification and the function to the events of the notification's bucket
+func (notification *S3BucketNotification) grantInvoke(dag *construct.ResourceGraph, function *LambdaFunction) {
+	for _, permission := range construct.GetDownstreamResourcesOfType[*LambdaPermission](dag, notification) {
+		if permission.Function != function && dag.GetDependency(permission.Id(), function.Id()) == nil {
+			continue
+		}
+		permission.Source = construct.IaCValue{ResourceId: notification.Bucket.Id(), Property: ARN_IAC_VALUE}
+	}
+}
+
+// grantSendMessage lets the notification's bucket send messages to the queue, through a queue policy which the
+// notification depends on so that the policy exists before S3 validates the destination
+func (notification *S3BucketNotification) grantSendMessage(dag *construct.ResourceGraph, queue *SqsQueue) {
+	policy := &SqsQueuePolicy{
+		Name:          fmt.Sprintf("%s-policy", queue.Name),
+		ConstructRefs: notification.ConstructRefs.Clone(),
+	}
+	if existing, ok := construct.GetResource[*SqsQueuePolicy](dag, policy.Id()); ok {
+		policy = existing
+	}
+	policy.Queues = []*SqsQueue{queue}
+	policy.PolicyDocument = &PolicyDocument{
+		Version: VERSION,
+		Statement: []StatementEntry{
+			{
+				Effect:    "Allow",
+				Action:    []string{"sqs:SendMessage"},
+				Principal: &Principal{Service: s3ServicePrincipal},
+				Resource:  []construct.IaCValue{{ResourceId: queue.Id(), Property: ARN_IAC_VALUE}},
+				Condition: &Condition{ArnEquals: map[construct.IaCValue]construct.IaCValue{
+					{Property: "aws:SourceArn"}: {ResourceId: notification.Bucket.Id(), Property: ARN_IAC_VALUE},
+				}},
+			},
+		},
+	}
+	dag.AddDependency(policy, queue)
+	dag.AddDependency(notification, policy)
+}
+
+// lambdaExecutionUnits returns the names of the execution units that the function runs
+func lambdaExecutionUnits(function *LambdaFunction) []string {
+	var units []string
+	for _, ref := range function.ConstructRefs {
+		if unit, ok := ref.(*types.ExecutionUnit); ok {
+			units = append(units, unit.Name)
+		}
+	}
+	return units
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (notification *S3BucketNotification) BaseConstructRefs() construct.BaseConstructSet {
+	return notification.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (notification *S3BucketNotification) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     S3_BUCKET_NOTIFICATION_TYPE,
+		Name:     notification.Name,
+	}
+}
+
+func (notification *S3BucketNotification) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
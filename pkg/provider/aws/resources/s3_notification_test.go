@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_S3BucketNotificationMakeOperational(t *testing.T) {
+	event := &types.FsEvent{
+		Name: "uploads",
+		Subscriptions: []types.FsSubscription{
+			{ExecUnitName: "thumbnails", ModuleName: "src/thumbnails", FunctionName: "onUpload", Events: []string{types.FsEventWrite}, Prefix: "images/"},
+			{ExecUnitName: "audit", ModuleName: "src/audit", FunctionName: "onChange", Events: []string{types.FsEventWrite, types.FsEventDelete}},
+		},
+	}
+	tests := []struct {
+		name        string
+		buffered    bool
+		wantLambdas []S3LambdaNotification
+		wantQueues  []S3QueueNotification
+		wantErr     bool
+	}{
+		{
+			name: "lambda destinations",
+			wantLambdas: []S3LambdaNotification{
+				{
+					Id:                "src/thumbnails#onUpload",
+					LambdaFunctionArn: construct.IaCValue{ResourceId: construct.ResourceId{Provider: AWS_PROVIDER, Type: LAMBDA_FUNCTION_TYPE, Name: "thumbnails"}, Property: ARN_IAC_VALUE},
+					Events:            []string{"s3:ObjectCreated:*"},
+					FilterPrefix:      "images/",
+				},
+				{
+					Id:                "src/audit#onChange",
+					LambdaFunctionArn: construct.IaCValue{ResourceId: construct.ResourceId{Provider: AWS_PROVIDER, Type: LAMBDA_FUNCTION_TYPE, Name: "audit"}, Property: ARN_IAC_VALUE},
+					Events:            []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+				},
+			},
+		},
+		{
+			name:     "queue buffers the events of a function",
+			buffered: true,
+			wantLambdas: []S3LambdaNotification{
+				{
+					Id:                "src/thumbnails#onUpload",
+					LambdaFunctionArn: construct.IaCValue{ResourceId: construct.ResourceId{Provider: AWS_PROVIDER, Type: LAMBDA_FUNCTION_TYPE, Name: "thumbnails"}, Property: ARN_IAC_VALUE},
+					Events:            []string{"s3:ObjectCreated:*"},
+					FilterPrefix:      "images/",
+				},
+			},
+			wantQueues: []S3QueueNotification{
+				{
+					Id:       "src/audit#onChange",
+					QueueArn: construct.IaCValue{ResourceId: construct.ResourceId{Provider: AWS_PROVIDER, Type: SQS_QUEUE_TYPE, Name: "audit-events"}, Property: ARN_IAC_VALUE},
+					Events:   []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			bucket := &S3Bucket{Name: "uploads"}
+			notification := &S3BucketNotification{Name: "uploads", ConstructRefs: construct.BaseConstructSetOf(event), Bucket: bucket}
+			dag.AddDependency(notification, bucket)
+
+			thumbnails := &LambdaFunction{Name: "thumbnails", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "thumbnails"})}
+			permission := &LambdaPermission{Name: "thumbnails-permission", Function: thumbnails}
+			dag.AddDependency(notification, permission)
+			dag.AddDependency(permission, thumbnails)
+			dag.AddDependency(notification, thumbnails)
+
+			audit := &LambdaFunction{Name: "audit", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "audit"})}
+			var queue *SqsQueue
+			if tt.buffered {
+				queue = &SqsQueue{Name: "audit-events"}
+				dag.AddDependency(notification, queue)
+				dag.AddDependency(queue, audit)
+			} else {
+				dag.AddDependency(notification, audit)
+			}
+
+			err := notification.MakeOperational(dag, "app", nil)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantLambdas, notification.LambdaFunctions)
+			assert.Equal(tt.wantQueues, notification.Queues)
+			assert.Equal(construct.IaCValue{ResourceId: bucket.Id(), Property: ARN_IAC_VALUE}, permission.Source)
+
+			policies := construct.GetResources[*SqsQueuePolicy](dag)
+			if !tt.buffered {
+				assert.Empty(policies)
+				return
+			}
+			if !assert.Len(policies, 1) {
+				return
+			}
+			policy := policies[0]
+			assert.Equal([]*SqsQueue{queue}, policy.Queues)
+			assert.NotNil(dag.GetDependency(notification.Id(), policy.Id()))
+			assert.NotNil(dag.GetDependency(policy.Id(), queue.Id()))
+			statement := policy.PolicyDocument.Statement[0]
+			assert.Equal(s3ServicePrincipal, statement.Principal.Service)
+			assert.Equal(construct.IaCValue{ResourceId: bucket.Id(), Property: ARN_IAC_VALUE}, statement.Condition.ArnEquals[construct.IaCValue{Property: "aws:SourceArn"}])
+		})
+	}
+}
+
+func Test_S3BucketNotificationMakeOperational_NotLambda(t *testing.T) {
+	assert := assert.New(t)
+
+	event := &types.FsEvent{
+		Name:          "uploads",
+		Subscriptions: []types.FsSubscription{{ExecUnitName: "main", ModuleName: "index", FunctionName: "onUpload", Events: []string{types.FsEventWrite}}},
+	}
+	dag := construct.NewResourceGraph()
+	bucket := &S3Bucket{Name: "uploads"}
+	notification := &S3BucketNotification{Name: "uploads", ConstructRefs: construct.BaseConstructSetOf(event), Bucket: bucket}
+	dag.AddDependency(notification, bucket)
+
+	assert.Error(notification.MakeOperational(dag, "app", nil))
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+const (
+	S3_BUCKET_REPLICATION_TYPE = "s3_bucket_replication"
+)
+
+var S3_ASSUMER_ROLE_POLICY = &PolicyDocument{
+	Version: VERSION,
+	Statement: []StatementEntry{
+		{
+			Action: []string{"sts:AssumeRole"},
+			Principal: &Principal{
+				Service: s3ServicePrincipal,
+			},
+			Effect: "Allow",
+		},
+	},
+}
+
+type (
+	// S3BucketReplication replicates the objects of Bucket into each of the Destinations, which are usually in other
+	// regions, using the permissions of Role.
+	// A bucket has a single replication configuration, so all of its destinations are in the same S3BucketReplication.
+	S3BucketReplication struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Bucket        *S3Bucket
+		Destinations  []*S3Bucket
+		Role          *IamRole
+	}
+)
+
+// ReplicationPolicy is the policy which lets S3 read the objects of source and replicate them into destinations.
+// The buckets are encrypted with their region's AWS managed key, so the policy also decrypts and encrypts with it.
+func ReplicationPolicy(source *S3Bucket, destinations []*S3Bucket) *PolicyDocument {
+	var replicas []construct.IaCValue
+	for _, destination := range destinations {
+		replicas = append(replicas, construct.IaCValue{ResourceId: destination.Id(), Property: ALL_BUCKET_DIRECTORY_IAC_VALUE})
+	}
+	return &PolicyDocument{
+		Version: VERSION,
+		Statement: []StatementEntry{
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetReplicationConfiguration", "s3:ListBucket"},
+				Resource: []construct.IaCValue{{ResourceId: source.Id(), Property: ARN_IAC_VALUE}},
+			},
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetObjectVersionForReplication", "s3:GetObjectVersionAcl", "s3:GetObjectVersionTagging"},
+				Resource: []construct.IaCValue{{ResourceId: source.Id(), Property: ALL_BUCKET_DIRECTORY_IAC_VALUE}},
+			},
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:ReplicateObject", "s3:ReplicateDelete", "s3:ReplicateTags"},
+				Resource: replicas,
+			},
+			{
+				Effect:   "Allow",
+				Action:   []string{"kms:Decrypt", "kms:Encrypt"},
+				Resource: []construct.IaCValue{{Property: "*"}},
+			},
+		},
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (replication *S3BucketReplication) BaseConstructRefs() construct.BaseConstructSet {
+	return replication.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (replication *S3BucketReplication) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     S3_BUCKET_REPLICATION_TYPE,
+		Name:     replication.Name,
+	}
+}
+
+func (replication *S3BucketReplication) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -11,6 +11,9 @@ type (
 	Secret struct {
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		// Replicas are the regions which the secret is replicated into
+		Replicas []string
+		Tags     map[string]string
 	}
 
 	SecretVersion struct {
@@ -20,6 +23,11 @@ type (
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		Name          string
 		Type          string
+		// RdsInstance is the instance whose credentials the version holds. Its connection details are stored with the
+		// credentials, as the rotation functions of RDS credentials require.
+		RdsInstance *RdsInstance
+		// RdsCluster is the cluster whose credentials the version holds, when the database is an Aurora cluster
+		RdsCluster *RdsCluster
 	}
 )
 
@@ -0,0 +1,181 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	SECRET_ROTATION_TYPE       = "secret_rotation"
+	RDS_ROTATION_FUNCTION_TYPE = "rds_rotation_function"
+
+	// rdsRotationApplicationPrefix is the serverless application repository account which hosts the rotation functions
+	// that AWS provides for RDS credentials
+	rdsRotationApplicationPrefix = "arn:aws:serverlessrepo:us-east-1:297356227824:applications/"
+)
+
+// rdsRotationApplications are the names of the single user rotation applications of each RDS engine
+var rdsRotationApplications = map[string]string{
+	"postgres": "SecretsManagerRDSPostgreSQLRotationSingleUser",
+	"mysql":    "SecretsManagerRDSMySQLRotationSingleUser",
+	"mariadb":  "SecretsManagerRDSMariaDBRotationSingleUser",
+}
+
+type (
+	// SecretRotation rotates Secret every AutomaticallyAfterDays days, with the lambda function RotationLambdaArn or,
+	// for the credentials of an RDS instance, with RotationFunction.
+	SecretRotation struct {
+		Name                   string
+		ConstructRefs          construct.BaseConstructSet `yaml:"-"`
+		Secret                 *Secret
+		RotationLambdaArn      string
+		RotationFunction       *RdsRotationFunction
+		AutomaticallyAfterDays int
+	}
+
+	// RdsRotationFunction is the rotation function that AWS hosts for the credentials of an RDS engine, deployed from
+	// the serverless application repository into the subnets and security groups of the instance it rotates.
+	RdsRotationFunction struct {
+		Name           string
+		ConstructRefs  construct.BaseConstructSet `yaml:"-"`
+		ApplicationId  string
+		Region         *Region
+		Subnets        []*Subnet
+		SecurityGroups []*SecurityGroup
+	}
+)
+
+// MakeOperational rotates the secret if the construct it was expanded from has a rotation, or if it holds the
+// credentials of an RDS instance whose orm has one.
+func (secret *Secret) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	rotation, instance := secret.rotation(dag)
+	if rotation == nil {
+		return nil
+	}
+	secretRotation := &SecretRotation{
+		Name:          fmt.Sprintf("%s-rotation", secret.Name),
+		ConstructRefs: secret.ConstructRefs.Clone(),
+	}
+	if existing, ok := construct.GetResource[*SecretRotation](dag, secretRotation.Id()); ok {
+		secretRotation = existing
+	}
+	secretRotation.Secret = secret
+	secretRotation.AutomaticallyAfterDays = rotation.AfterDays
+	secretRotation.RotationLambdaArn = rotation.LambdaArn
+	dag.AddDependency(secretRotation, secret)
+	for _, version := range construct.GetDownstreamResourcesOfType[*SecretVersion](dag, secret) {
+		dag.AddDependency(secretRotation, version)
+	}
+	if rotation.LambdaArn != "" {
+		return nil
+	}
+	if instance == nil {
+		return fmt.Errorf("secret %s must be rotated by a lambda function", secret.Name)
+	}
+	function, err := rdsRotationFunction(dag, secret, instance)
+	if err != nil {
+		return err
+	}
+	secretRotation.RotationFunction = function
+	dag.AddDependency(secretRotation, function)
+	return nil
+}
+
+// rotation returns the rotation of the secret, and the RDS instance whose credentials it holds if the rotation is of
+// an orm's credentials
+func (secret *Secret) rotation(dag *construct.ResourceGraph) (*types.SecretRotation, *RdsInstance) {
+	for _, ref := range secret.ConstructRefs {
+		switch ref := ref.(type) {
+		case *types.Secrets:
+			if ref.Rotation != nil {
+				return ref.Rotation, nil
+			}
+		case *types.Config:
+			if ref.Rotation != nil {
+				return ref.Rotation, nil
+			}
+		}
+	}
+	for _, version := range construct.GetDownstreamResourcesOfType[*SecretVersion](dag, secret) {
+		for _, instance := range construct.GetDownstreamResourcesOfType[*RdsInstance](dag, version) {
+			for _, ref := range instance.ConstructRefs {
+				if orm, ok := ref.(*types.Orm); ok && orm.Rotation != nil {
+					return orm.Rotation, instance
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// rdsRotationFunction creates the rotation function of the instance's engine, which runs in the instance's network
+// so that it can connect to it
+func rdsRotationFunction(dag *construct.ResourceGraph, secret *Secret, instance *RdsInstance) (*RdsRotationFunction, error) {
+	application, ok := rdsRotationApplications[instance.Engine]
+	if !ok {
+		return nil, fmt.Errorf("credentials of rds instance %s cannot be rotated: unsupported engine '%s'", instance.Name, instance.Engine)
+	}
+	function := &RdsRotationFunction{
+		Name:          lambdaFunctionSanitizer.Apply(fmt.Sprintf("%s-rotation", secret.Name)),
+		ConstructRefs: secret.ConstructRefs.CloneWith(instance.ConstructRefs),
+	}
+	if existing, ok := construct.GetResource[*RdsRotationFunction](dag, function.Id()); ok {
+		function = existing
+	}
+	function.ApplicationId = rdsRotationApplicationPrefix + application
+	function.SecurityGroups = instance.SecurityGroups
+	if instance.SubnetGroup != nil {
+		function.Subnets = instance.SubnetGroup.Subnets
+	}
+	dag.AddResource(function)
+	for _, subnet := range function.Subnets {
+		dag.AddDependency(function, subnet)
+	}
+	for _, sg := range function.SecurityGroups {
+		dag.AddDependency(function, sg)
+	}
+	return function, nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rotation *SecretRotation) BaseConstructRefs() construct.BaseConstructSet {
+	return rotation.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rotation *SecretRotation) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     SECRET_ROTATION_TYPE,
+		Name:     rotation.Name,
+	}
+}
+
+func (rotation *SecretRotation) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (function *RdsRotationFunction) BaseConstructRefs() construct.BaseConstructSet {
+	return function.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (function *RdsRotationFunction) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     RDS_ROTATION_FUNCTION_TYPE,
+		Name:     function.Name,
+	}
+}
+
+func (function *RdsRotationFunction) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SecretMakeOperational_Lambda(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	secrets := &types.Secrets{Name: "creds", Rotation: &types.SecretRotation{AfterDays: 30, LambdaArn: "arn:aws:lambda:us-east-1:123456789012:function:rotate"}}
+	secret := &Secret{Name: "secret-creds", ConstructRefs: construct.BaseConstructSetOf(secrets)}
+	version := &SecretVersion{Name: "secret-creds", Secret: secret}
+	dag.AddDependency(secret, version)
+
+	if !assert.NoError(secret.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	rotation, ok := construct.GetResource[*SecretRotation](dag, construct.ResourceId{Provider: AWS_PROVIDER, Type: SECRET_ROTATION_TYPE, Name: "secret-creds-rotation"})
+	if !assert.True(ok) {
+		return
+	}
+	assert.Equal(secret, rotation.Secret)
+	assert.Equal(30, rotation.AutomaticallyAfterDays)
+	assert.Equal("arn:aws:lambda:us-east-1:123456789012:function:rotate", rotation.RotationLambdaArn)
+	assert.Nil(rotation.RotationFunction)
+	assert.NotNil(dag.GetDependency(rotation.Id(), secret.Id()))
+	assert.NotNil(dag.GetDependency(rotation.Id(), version.Id()))
+	assert.Empty(construct.GetResources[*RdsRotationFunction](dag))
+}
+
+func Test_SecretMakeOperational_RdsCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	orm := &types.Orm{Name: "db", Rotation: &types.SecretRotation{AfterDays: 90}}
+	subnet := &Subnet{Name: "private1"}
+	sg := &SecurityGroup{Name: "sg"}
+	instance := &RdsInstance{
+		Name:           "db",
+		Engine:         "postgres",
+		ConstructRefs:  construct.BaseConstructSetOf(orm),
+		SubnetGroup:    &RdsSubnetGroup{Name: "db", Subnets: []*Subnet{subnet}},
+		SecurityGroups: []*SecurityGroup{sg},
+	}
+	secret := &Secret{Name: "proxy-credentials"}
+	version := &SecretVersion{Name: "proxy-credentials", Secret: secret}
+	dag.AddDependency(secret, version)
+	dag.AddDependency(version, instance)
+
+	if !assert.NoError(secret.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	rotations := construct.GetResources[*SecretRotation](dag)
+	if !assert.Len(rotations, 1) {
+		return
+	}
+	rotation := rotations[0]
+	assert.Equal(90, rotation.AutomaticallyAfterDays)
+	assert.Empty(rotation.RotationLambdaArn)
+	function := rotation.RotationFunction
+	if !assert.NotNil(function) {
+		return
+	}
+	assert.Equal("arn:aws:serverlessrepo:us-east-1:297356227824:applications/SecretsManagerRDSPostgreSQLRotationSingleUser", function.ApplicationId)
+	assert.Equal([]*Subnet{subnet}, function.Subnets)
+	assert.Equal([]*SecurityGroup{sg}, function.SecurityGroups)
+	assert.NotNil(dag.GetDependency(rotation.Id(), function.Id()))
+	assert.NotNil(dag.GetDependency(function.Id(), subnet.Id()))
+	assert.NotNil(dag.GetDependency(function.Id(), sg.Id()))
+
+	// making the secret operational again reuses the rotation and its function
+	if !assert.NoError(secret.MakeOperational(dag, "app", nil)) {
+		return
+	}
+	assert.Len(construct.GetResources[*SecretRotation](dag), 1)
+	assert.Len(construct.GetResources[*RdsRotationFunction](dag), 1)
+}
+
+func Test_SecretMakeOperational_UnsupportedEngine(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	orm := &types.Orm{Name: "db", Rotation: &types.SecretRotation{AfterDays: 90}}
+	instance := &RdsInstance{Name: "db", Engine: "sqlserver-ex", ConstructRefs: construct.BaseConstructSetOf(orm)}
+	secret := &Secret{Name: "proxy-credentials"}
+	version := &SecretVersion{Name: "proxy-credentials", Secret: secret}
+	dag.AddDependency(secret, version)
+	dag.AddDependency(version, instance)
+
+	assert.Error(secret.MakeOperational(dag, "app", nil))
+}
+
+func Test_SecretMakeOperational_NoRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	dag := construct.NewResourceGraph()
+	secret := &Secret{Name: "secret-creds", ConstructRefs: construct.BaseConstructSetOf(&types.Secrets{Name: "creds"})}
+	dag.AddResource(secret)
+
+	assert.NoError(secret.MakeOperational(dag, "app", nil))
+	assert.Len(dag.ListResources(), 1)
+}
@@ -13,6 +13,7 @@ type (
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		IngressRules  []SecurityGroupRule
 		EgressRules   []SecurityGroupRule
+		Tags          map[string]string
 	}
 	SecurityGroupRule struct {
 		Description string
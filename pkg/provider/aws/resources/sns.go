@@ -7,6 +7,7 @@ type (
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		FifoTopic     bool
+		Tags          map[string]string
 	}
 
 	SnsSubscription struct {
@@ -1,6 +1,9 @@
 package resources
 
-import "github.com/klothoplatform/klotho/pkg/construct"
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
 
 type (
 	SqsQueue struct {
@@ -9,12 +12,14 @@ type (
 		FifoQueue          bool
 		DelaySeconds       int
 		MaximumMessageSize int
-		RedrivePolicy      RedrivePolicy
+		RedrivePolicy      *RedrivePolicy
 		VisibilityTimeout  int
+		Tags               map[string]string
 	}
 
+	// RedrivePolicy moves messages to the DeadLetterTarget queue once they have been received MaxReceiveCount times
 	RedrivePolicy struct {
-		DeadLetterTargetArn string
+		DeadLetterTargetArn construct.IaCValue
 		MaxReceiveCount     int
 	}
 
@@ -29,8 +34,66 @@ type (
 const (
 	SQS_QUEUE_TYPE        = "sqs_queue"
 	SQS_QUEUE_POLICY_TYPE = "sqs_queue_policy"
+
+	QUEUE_URL_IAC_VALUE = "queue_url"
+
+	// deadLetterQueueSuffix is appended to the name of a queue to name its dead-letter queue
+	deadLetterQueueSuffix = "-dlq"
 )
 
+type SqsQueueConfigureParams struct {
+}
+
+// Configure applies the settings of the queue construct that the sqs queue was expanded from
+func (q *SqsQueue) Configure(params SqsQueueConfigureParams) error {
+	if queue := q.QueueConstruct(); queue != nil {
+		q.FifoQueue = queue.Fifo
+		if queue.VisibilityTimeout > q.VisibilityTimeout {
+			q.VisibilityTimeout = queue.VisibilityTimeout
+		}
+	}
+	return nil
+}
+
+// QueueConstruct returns the queue construct that the sqs queue was expanded from, if any
+func (q *SqsQueue) QueueConstruct() *types.Queue {
+	for _, ref := range q.ConstructRefs {
+		if queue, ok := ref.(*types.Queue); ok {
+			return queue
+		}
+	}
+	return nil
+}
+
+// EnsureDeadLetterQueue creates the dead-letter queue for the sqs queue when its queue construct enables one,
+// and sets the queue's redrive policy to move messages to it.
+// The dead-letter queue does not reference the queue construct, so that it is never mistaken for the construct's queue.
+func (q *SqsQueue) EnsureDeadLetterQueue(dag *construct.ResourceGraph) {
+	queue := q.QueueConstruct()
+	if queue == nil || queue.MaxReceiveCount <= 0 {
+		return
+	}
+	refs := construct.BaseConstructSetOf()
+	for _, ref := range q.ConstructRefs {
+		if _, ok := ref.(*types.Queue); !ok {
+			refs.Add(ref)
+		}
+	}
+	dlq := &SqsQueue{
+		Name:          q.Name + deadLetterQueueSuffix,
+		ConstructRefs: refs,
+		FifoQueue:     queue.Fifo,
+	}
+	if existing, ok := dag.GetResource(dlq.Id()).(*SqsQueue); ok {
+		dlq = existing
+	}
+	dag.AddDependency(q, dlq)
+	q.RedrivePolicy = &RedrivePolicy{
+		DeadLetterTargetArn: construct.IaCValue{ResourceId: dlq.Id(), Property: ARN_IAC_VALUE},
+		MaxReceiveCount:     queue.MaxReceiveCount,
+	}
+}
+
 func (q *SqsQueue) BaseConstructRefs() construct.BaseConstructSet {
 	return q.ConstructRefs
 }
@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SqsQueueConfigure(t *testing.T) {
+	tests := []struct {
+		name  string
+		queue *SqsQueue
+		want  *SqsQueue
+	}{
+		{
+			name:  "applies queue construct",
+			queue: &SqsQueue{Name: "orders", ConstructRefs: construct.BaseConstructSetOf(&types.Queue{Name: "orders", Fifo: true, VisibilityTimeout: 60})},
+			want:  &SqsQueue{Name: "orders", FifoQueue: true, VisibilityTimeout: 60},
+		},
+		{
+			name:  "keeps longer visibility timeout",
+			queue: &SqsQueue{Name: "orders", VisibilityTimeout: 900, ConstructRefs: construct.BaseConstructSetOf(&types.Queue{Name: "orders", VisibilityTimeout: 60})},
+			want:  &SqsQueue{Name: "orders", VisibilityTimeout: 900},
+		},
+		{
+			name:  "no queue construct",
+			queue: &SqsQueue{Name: "orders", VisibilityTimeout: 30},
+			want:  &SqsQueue{Name: "orders", VisibilityTimeout: 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := tt.queue.Configure(SqsQueueConfigureParams{})
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want.FifoQueue, tt.queue.FifoQueue)
+			assert.Equal(tt.want.VisibilityTimeout, tt.queue.VisibilityTimeout)
+		})
+	}
+}
+
+func Test_SqsQueueEnsureDeadLetterQueue(t *testing.T) {
+	tests := []struct {
+		name            string
+		queue           *types.Queue
+		wantRedrive     *RedrivePolicy
+		wantDeadLetters bool
+	}{
+		{
+			name:            "dead-letter queue enabled",
+			queue:           &types.Queue{Name: "orders", Fifo: true, MaxReceiveCount: 5},
+			wantRedrive:     &RedrivePolicy{DeadLetterTargetArn: construct.IaCValue{ResourceId: (&SqsQueue{Name: "orders-dlq"}).Id(), Property: ARN_IAC_VALUE}, MaxReceiveCount: 5},
+			wantDeadLetters: true,
+		},
+		{
+			name:  "dead-letter queue disabled",
+			queue: &types.Queue{Name: "orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			dag := construct.NewResourceGraph()
+			unit := &types.ExecutionUnit{Name: "worker"}
+			queue := &SqsQueue{Name: "orders", ConstructRefs: construct.BaseConstructSetOf(tt.queue, unit)}
+			dag.AddResource(queue)
+
+			queue.EnsureDeadLetterQueue(dag)
+			// ensuring it again must not create a second dead-letter queue
+			queue.EnsureDeadLetterQueue(dag)
+
+			assert.Equal(tt.wantRedrive, queue.RedrivePolicy)
+			dlq, _ := dag.GetResource((&SqsQueue{Name: "orders-dlq"}).Id()).(*SqsQueue)
+			if !tt.wantDeadLetters {
+				assert.Nil(dlq)
+				return
+			}
+			if !assert.NotNil(dlq) {
+				return
+			}
+			assert.Equal(tt.queue.Fifo, dlq.FifoQueue)
+			assert.Nil(dlq.QueueConstruct())
+			assert.Equal(construct.BaseConstructSetOf(unit), dlq.ConstructRefs)
+			assert.NotNil(dag.GetDependency(queue.Id(), dlq.Id()))
+			// the dead-letter queue has no dead-letter queue of its own
+			dlq.EnsureDeadLetterQueue(dag)
+			assert.Nil(dlq.RedrivePolicy)
+			assert.Len(dag.ListResources(), 2)
+		})
+	}
+}
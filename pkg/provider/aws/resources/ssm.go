@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const SSM_PARAMETER_TYPE = "ssm_parameter"
+
+type (
+	// SsmParameter is an SSM Parameter Store SecureString parameter, which is an alternative to Secret for storing
+	// secrets. Its value is read from the local file at Path.
+	SsmParameter struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ParameterName string
+		Path          string
+		Tags          map[string]string
+	}
+)
+
+// MakeOperational names the parameter after the secret of the persist_secrets construct it was expanded from, which is
+// where the secret runtime reads it from. A parameter holds a single secret, so the construct must have exactly one.
+func (param *SsmParameter) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if param.ParameterName != "" {
+		return nil
+	}
+	for _, ref := range param.ConstructRefs {
+		secrets, ok := ref.(*types.Secrets)
+		if !ok {
+			continue
+		}
+		if len(secrets.Secrets) != 1 {
+			return fmt.Errorf("ssm parameter %s must store exactly one secret, but persist_secrets %s has %d", param.Name, secrets.Name, len(secrets.Secrets))
+		}
+		param.ParameterName = fmt.Sprintf("/%s/%s", appName, secrets.Secrets[0])
+		if param.Path == "" {
+			param.Path = secrets.Secrets[0]
+		}
+		return nil
+	}
+	return fmt.Errorf("ssm parameter %s has no ParameterName", param.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (param *SsmParameter) BaseConstructRefs() construct.BaseConstructSet {
+	return param.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (param *SsmParameter) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     SSM_PARAMETER_TYPE,
+		Name:     param.Name,
+	}
+}
+
+func (param *SsmParameter) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
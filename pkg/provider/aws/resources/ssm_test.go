@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SsmParameterMakeOperational(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    *SsmParameter
+		wantName string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:     "named after the secret",
+			param:    &SsmParameter{Name: "ssm_parameter-creds", ConstructRefs: construct.BaseConstructSetOf(&types.Secrets{Name: "creds", Secrets: []string{"secrets/api-key"}})},
+			wantName: "/app/secrets/api-key",
+			wantPath: "secrets/api-key",
+		},
+		{
+			name:     "configured path",
+			param:    &SsmParameter{Name: "ssm_parameter-creds", Path: "local/api-key", ConstructRefs: construct.BaseConstructSetOf(&types.Secrets{Name: "creds", Secrets: []string{"api-key"}})},
+			wantName: "/app/api-key",
+			wantPath: "local/api-key",
+		},
+		{
+			name:    "multiple secrets",
+			param:   &SsmParameter{Name: "ssm_parameter-creds", ConstructRefs: construct.BaseConstructSetOf(&types.Secrets{Name: "creds", Secrets: []string{"a", "b"}})},
+			wantErr: true,
+		},
+		{
+			name:    "no secrets construct",
+			param:   &SsmParameter{Name: "ssm_parameter-creds"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			dag.AddResource(tt.param)
+
+			err := tt.param.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantName, tt.param.ParameterName)
+			assert.Equal(tt.wantPath, tt.param.Path)
+		})
+	}
+}
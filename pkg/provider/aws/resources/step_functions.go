@@ -0,0 +1,202 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+)
+
+const (
+	SFN_STATE_MACHINE_TYPE = "sfn_state_machine"
+
+	// expressStateMachineType runs executions synchronously, so that the workflow's result is returned to the orchestrator function
+	expressStateMachineType = "EXPRESS"
+	lambdaInvokeResource    = "arn:aws:states:::lambda:invoke"
+)
+
+var STATES_ASSUMER_ROLE_POLICY = &PolicyDocument{
+	Version: VERSION,
+	Statement: []StatementEntry{
+		{
+			Action: []string{"sts:AssumeRole"},
+			Principal: &Principal{
+				Service: "states.amazonaws.com",
+			},
+			Effect: "Allow",
+		},
+	},
+}
+
+type (
+	// SfnStateMachine runs the steps of a workflow, as described by its Amazon States Language Definition
+	SfnStateMachine struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Type          string
+		Role          *IamRole
+		Definition    string
+		Tags          map[string]string
+	}
+
+	// StateMachineDefinition is the Amazon States Language document of a state machine
+	StateMachineDefinition struct {
+		Comment string                       `json:"Comment,omitempty"`
+		StartAt string                       `json:"StartAt"`
+		States  map[string]StateMachineState `json:"States"`
+	}
+
+	StateMachineState struct {
+		Type       string              `json:"Type"`
+		Resource   string              `json:"Resource,omitempty"`
+		Parameters map[string]any      `json:"Parameters,omitempty"`
+		OutputPath string              `json:"OutputPath,omitempty"`
+		Retry      []StateMachineRetry `json:"Retry,omitempty"`
+		Next       string              `json:"Next,omitempty"`
+		End        bool                `json:"End,omitempty"`
+	}
+
+	StateMachineRetry struct {
+		ErrorEquals     []string `json:"ErrorEquals"`
+		IntervalSeconds int      `json:"IntervalSeconds"`
+		MaxAttempts     int      `json:"MaxAttempts"`
+		BackoffRate     float64  `json:"BackoffRate"`
+	}
+)
+
+// lambdaInvokeRetry retries the transient errors of invoking a lambda function, rather than the errors of the function itself
+var lambdaInvokeRetry = StateMachineRetry{
+	ErrorEquals:     []string{"Lambda.ServiceException", "Lambda.AWSLambdaException", "Lambda.SdkClientException", "Lambda.TooManyRequestsException"},
+	IntervalSeconds: 2,
+	MaxAttempts:     6,
+	BackoffRate:     2,
+}
+
+// WorkflowConstruct returns the workflow construct that the state machine was expanded from, if any
+func (machine *SfnStateMachine) WorkflowConstruct() *types.Workflow {
+	for _, ref := range machine.ConstructRefs {
+		if workflow, ok := ref.(*types.Workflow); ok {
+			return workflow
+		}
+	}
+	return nil
+}
+
+// EnsureRole returns the role which the state machine runs as, creating it if it does not exist yet
+func (machine *SfnStateMachine) EnsureRole(dag *construct.ResourceGraph) *IamRole {
+	if machine.Role != nil {
+		return machine.Role
+	}
+	role := &IamRole{}
+	_ = role.Create(dag, RoleCreateParams{Name: fmt.Sprintf("%s-role", machine.Name), Refs: machine.ConstructRefs})
+	if existing, ok := construct.GetResource[*IamRole](dag, role.Id()); ok {
+		role = existing
+	}
+	role.AssumeRolePolicyDoc = STATES_ASSUMER_ROLE_POLICY
+	machine.Role = role
+	dag.AddDependency(machine, role)
+	return role
+}
+
+// MakeOperational generates the state machine's definition from the steps of its workflow, which each invoke the
+// lambda function of their execution unit.
+func (machine *SfnStateMachine) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if machine.Type == "" {
+		machine.Type = expressStateMachineType
+	}
+	machine.EnsureRole(dag)
+
+	workflow := machine.WorkflowConstruct()
+	if workflow == nil {
+		if machine.Definition == "" {
+			return fmt.Errorf("state machine %s has no definition and was not created from a workflow", machine.Name)
+		}
+		return nil
+	}
+	functions := make(map[string]*LambdaFunction)
+	for _, function := range construct.GetDownstreamResourcesOfType[*LambdaFunction](dag, machine) {
+		for _, ref := range function.ConstructRefs {
+			if unit, ok := ref.(*types.ExecutionUnit); ok {
+				functions[unit.Name] = function
+			}
+		}
+	}
+	definition, err := WorkflowDefinition(workflow, functions)
+	if err != nil {
+		return err
+	}
+	machine.Definition = definition
+	return nil
+}
+
+// WorkflowDefinition generates the Amazon States Language definition of workflow, in which each step is a task that
+// invokes the lambda function of its execution unit in `functions` with either the result of the previous step or the
+// input of the workflow.
+func WorkflowDefinition(workflow *types.Workflow, functions map[string]*LambdaFunction) (string, error) {
+	if len(workflow.Steps) == 0 {
+		return "", fmt.Errorf("workflow %s has no steps", workflow.Name)
+	}
+	definition := StateMachineDefinition{
+		Comment: fmt.Sprintf("klotho workflow %s", workflow.Name),
+		StartAt: workflow.StepName(0),
+		States:  make(map[string]StateMachineState),
+	}
+	for i, step := range workflow.Steps {
+		function, ok := functions[step.ExecUnitName]
+		if !ok {
+			return "", fmt.Errorf("execution unit %s of workflow %s must be a lambda function to run the step %s#%s", step.ExecUnitName, workflow.Name, step.ModuleName, step.FunctionName)
+		}
+		input := "$"
+		if step.TakesWorkflowInput {
+			input = "$$.Execution.Input"
+		}
+		state := StateMachineState{
+			Type:     "Task",
+			Resource: lambdaInvokeResource,
+			Parameters: map[string]any{
+				"FunctionName": function.Name,
+				"Payload": map[string]any{
+					"__callType":       "workflow",
+					"__moduleName":     step.ModuleName,
+					"__functionToCall": step.FunctionName,
+					"__input.$":        input,
+				},
+			},
+			OutputPath: "$.Payload",
+			Retry:      []StateMachineRetry{lambdaInvokeRetry},
+		}
+		if i == len(workflow.Steps)-1 {
+			state.End = true
+		} else {
+			state.Next = workflow.StepName(i + 1)
+		}
+		definition.States[workflow.StepName(i)] = state
+	}
+	b, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (machine *SfnStateMachine) BaseConstructRefs() construct.BaseConstructSet {
+	return machine.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (machine *SfnStateMachine) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: AWS_PROVIDER,
+		Type:     SFN_STATE_MACHINE_TYPE,
+		Name:     machine.Name,
+	}
+}
+
+func (machine *SfnStateMachine) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WorkflowDefinition(t *testing.T) {
+	workflow := &types.Workflow{
+		Name: "checkout",
+		Steps: []types.WorkflowStep{
+			{ExecUnitName: "billing", ModuleName: "src/billing", FunctionName: "charge"},
+			{ExecUnitName: "shipping", ModuleName: "src/shipping", FunctionName: "ship", TakesWorkflowInput: true},
+		},
+	}
+	tests := []struct {
+		name      string
+		functions map[string]*LambdaFunction
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "steps invoke the lambda of their unit",
+			functions: map[string]*LambdaFunction{
+				"billing":  {Name: "app-billing"},
+				"shipping": {Name: "app-shipping"},
+			},
+			want: `{"Comment":"klotho workflow checkout","StartAt":"1-billing-charge","States":{` +
+				`"1-billing-charge":{"Type":"Task","Resource":"arn:aws:states:::lambda:invoke","Parameters":{"FunctionName":"app-billing","Payload":{"__callType":"workflow","__functionToCall":"charge","__input.$":"$","__moduleName":"src/billing"}},"OutputPath":"$.Payload","Retry":[{"ErrorEquals":["Lambda.ServiceException","Lambda.AWSLambdaException","Lambda.SdkClientException","Lambda.TooManyRequestsException"],"IntervalSeconds":2,"MaxAttempts":6,"BackoffRate":2}],"Next":"2-shipping-ship"},` +
+				`"2-shipping-ship":{"Type":"Task","Resource":"arn:aws:states:::lambda:invoke","Parameters":{"FunctionName":"app-shipping","Payload":{"__callType":"workflow","__functionToCall":"ship","__input.$":"$$.Execution.Input","__moduleName":"src/shipping"}},"OutputPath":"$.Payload","Retry":[{"ErrorEquals":["Lambda.ServiceException","Lambda.AWSLambdaException","Lambda.SdkClientException","Lambda.TooManyRequestsException"],"IntervalSeconds":2,"MaxAttempts":6,"BackoffRate":2}],"End":true}}}`,
+		},
+		{
+			name: "step unit is not a lambda",
+			functions: map[string]*LambdaFunction{
+				"billing": {Name: "app-billing"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := WorkflowDefinition(workflow, tt.functions)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.JSONEq(tt.want, got)
+		})
+	}
+}
+
+func Test_SfnStateMachineMakeOperational(t *testing.T) {
+	assert := assert.New(t)
+
+	workflow := &types.Workflow{
+		Name:  "checkout",
+		Steps: []types.WorkflowStep{{ExecUnitName: "billing", ModuleName: "src/billing", FunctionName: "charge"}},
+	}
+	dag := construct.NewResourceGraph()
+	machine := &SfnStateMachine{Name: "checkout", ConstructRefs: construct.BaseConstructSetOf(workflow)}
+	function := &LambdaFunction{Name: "app-billing", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "billing"})}
+	dag.AddDependency(machine, function)
+
+	err := machine.MakeOperational(dag, "app", nil)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(expressStateMachineType, machine.Type)
+	if assert.NotNil(machine.Role) {
+		assert.Equal(STATES_ASSUMER_ROLE_POLICY, machine.Role.AssumeRolePolicyDoc)
+		assert.NotNil(dag.GetDependency(machine.Id(), machine.Role.Id()))
+	}
+	assert.Contains(machine.Definition, `"FunctionName":"app-billing"`)
+}
@@ -39,21 +39,25 @@ type (
 		CidrBlock          string
 		EnableDnsSupport   bool
 		EnableDnsHostnames bool
+		Tags               map[string]string
 	}
 	ElasticIp struct {
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Tags          map[string]string
 	}
 	InternetGateway struct {
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		Vpc           *Vpc
+		Tags          map[string]string
 	}
 	NatGateway struct {
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		ElasticIp     *ElasticIp
 		Subnet        *Subnet
+		Tags          map[string]string
 	}
 	Subnet struct {
 		Name                string
@@ -63,6 +67,7 @@ type (
 		Type                string
 		AvailabilityZone    construct.IaCValue
 		MapPublicIpOnLaunch bool
+		Tags                map[string]string
 	}
 	VpcEndpoint struct {
 		Name             string
@@ -74,12 +79,14 @@ type (
 		Subnets          []*Subnet
 		RouteTables      []*RouteTable
 		SecurityGroupIds []construct.IaCValue
+		Tags             map[string]string
 	}
 	RouteTable struct {
 		Name          string
 		ConstructRefs construct.BaseConstructSet `yaml:"-"`
 		Vpc           *Vpc
 		Routes        []*RouteTableRoute
+		Tags          map[string]string
 	}
 	RouteTableRoute struct {
 		CidrBlock    string
@@ -0,0 +1,53 @@
+package azure
+
+import (
+	"github.com/klothoplatform/klotho/pkg/config"
+)
+
+// Enums for the types we allow in the azure provider so that we can reuse the same string within the provider
+const (
+	ContainerApps = "container_apps"
+	Functions     = "functions"
+	Postgres      = "postgres"
+	RedisCache    = "redis_cache"
+	CosmosDb      = "cosmos_db"
+	BlobStorage   = "blob_storage"
+	KeyVault      = "key_vault"
+	ServiceBus    = "service_bus"
+	FrontDoor     = "front_door"
+	StaticWebsite = "static_website"
+)
+
+var defaultConfig = config.Defaults{
+	ExecutionUnit: config.KindDefaults{
+		Type: ContainerApps,
+	},
+	StaticUnit: config.KindDefaults{
+		Type: StaticWebsite,
+	},
+	Expose: config.KindDefaults{
+		Type: FrontDoor,
+	},
+	PubSub: config.KindDefaults{
+		Type: ServiceBus,
+	},
+	PersistKv: config.KindDefaults{
+		Type: CosmosDb,
+	},
+	PersistFs: config.KindDefaults{
+		Type: BlobStorage,
+	},
+	PersistSecrets: config.KindDefaults{
+		Type: KeyVault,
+	},
+	PersistOrm: config.KindDefaults{
+		Type: Postgres,
+	},
+	PersistRedisNode: config.KindDefaults{
+		Type: RedisCache,
+	},
+}
+
+func (a *Azure) GetDefaultConfig() config.Defaults {
+	return defaultConfig
+}
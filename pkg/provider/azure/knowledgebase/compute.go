@@ -0,0 +1,115 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+)
+
+// ComputeKB sets the environment variables which the runtimes of units read the resources they use from, other than
+// those of the unit's own environment variables.
+var ComputeKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.ContainerApp, *resources.KeyVault]{
+		Configure: func(app *resources.ContainerApp, vault *resources.KeyVault, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setVaultEnvironmentVariable(app, vault)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.FunctionApp, *resources.KeyVault]{
+		Configure: func(app *resources.FunctionApp, vault *resources.KeyVault, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setVaultEnvironmentVariable(app, vault)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ContainerApp, *resources.ServiceBusTopic]{
+		Configure: func(app *resources.ContainerApp, topic *resources.ServiceBusTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setTopicEnvironmentVariables(app, topic)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.FunctionApp, *resources.ServiceBusTopic]{
+		Configure: func(app *resources.FunctionApp, topic *resources.ServiceBusTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setTopicEnvironmentVariables(app, topic)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ContainerApp, *resources.RedisCache]{
+		Configure: func(app *resources.ContainerApp, cache *resources.RedisCache, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setRedisPasswordEnvironmentVariable(app, cache)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.FunctionApp, *resources.RedisCache]{
+		Configure: func(app *resources.FunctionApp, cache *resources.RedisCache, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setRedisPasswordEnvironmentVariable(app, cache)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceBusTopic, *resources.ServiceBusSubscription]{
+		Configure: func(topic *resources.ServiceBusTopic, sub *resources.ServiceBusSubscription, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sub.Topic = topic
+			return nil
+		},
+		DeploymentOrderReversed: true,
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceBusSubscription, *resources.ContainerApp]{
+		Configure: func(sub *resources.ServiceBusSubscription, app *resources.ContainerApp, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			topics := construct.GetUpstreamResourcesOfType[*resources.ServiceBusTopic](dag, sub)
+			if len(topics) != 1 {
+				return fmt.Errorf("subscription %s must have exactly one topic, found %d", sub.Id(), len(topics))
+			}
+			sub.Topic = topics[0]
+			ps, err := pubsubConstruct(sub.Topic)
+			if err != nil {
+				return err
+			}
+			// the dispatcher receives the messages of the subscription in the background, which needs a running replica
+			app.MinReplicas = 1
+			app.SetEnvironmentVariable(
+				resources.PubsubSubscriptionEnvVarName(ps.Name),
+				construct.IaCValue{ResourceId: sub.Id(), Property: resources.TOPIC_SUBSCRIPTION_IAC_VALUE},
+			)
+			app.SetEnvironmentVariable(
+				resources.SERVICE_BUS_NAMESPACE_ENV_VAR,
+				construct.IaCValue{ResourceId: sub.Topic.Id(), Property: resources.SERVICE_BUS_HOST_IAC_VALUE},
+			)
+			if identity := app.GetIdentity(); identity != nil {
+				identity.AddRoleAssignment(resources.SERVICE_BUS_DATA_RECEIVER_ROLE, sub.Topic)
+			}
+			return nil
+		},
+		DeploymentOrderReversed: true,
+	},
+)
+
+func setVaultEnvironmentVariable(res resources.ComputeResource, vault *resources.KeyVault) error {
+	res.SetEnvironmentVariable(
+		resources.KEY_VAULT_URL_ENV_VAR,
+		construct.IaCValue{ResourceId: vault.Id(), Property: resources.VAULT_URI_IAC_VALUE},
+	)
+	return nil
+}
+
+func setTopicEnvironmentVariables(res resources.ComputeResource, topic *resources.ServiceBusTopic) error {
+	ps, err := pubsubConstruct(topic)
+	if err != nil {
+		return err
+	}
+	res.SetEnvironmentVariable(
+		resources.PubsubTopicEnvVarName(ps.Name),
+		construct.IaCValue{ResourceId: topic.Id(), Property: resources.NAME_IAC_VALUE},
+	)
+	res.SetEnvironmentVariable(
+		resources.SERVICE_BUS_NAMESPACE_ENV_VAR,
+		construct.IaCValue{ResourceId: topic.Id(), Property: resources.SERVICE_BUS_HOST_IAC_VALUE},
+	)
+	return nil
+}
+
+func setRedisPasswordEnvironmentVariable(res resources.ComputeResource, cache *resources.RedisCache) error {
+	node, err := redisNodeConstruct(cache)
+	if err != nil {
+		return err
+	}
+	res.SetEnvironmentVariable(
+		resources.RedisPasswordEnvVarName(node.Name),
+		construct.IaCValue{ResourceId: cache.Id(), Property: resources.PASSWORD_IAC_VALUE},
+	)
+	return nil
+}
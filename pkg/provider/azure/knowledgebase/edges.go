@@ -0,0 +1,38 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+)
+
+func GetAzureKnowledgeBase() (knowledgebase.EdgeKB, error) {
+	kbsToUse := []knowledgebase.EdgeKB{
+		ComputeKB,
+		IamKB,
+		FrontDoorKB,
+	}
+	return knowledgebase.MergeKBs(kbsToUse)
+}
+
+// pubsubConstruct returns the pubsub construct which the topic was expanded from
+func pubsubConstruct(topic *resources.ServiceBusTopic) (*types.PubSub, error) {
+	for _, ref := range topic.ConstructRefs {
+		if ps, ok := ref.(*types.PubSub); ok {
+			return ps, nil
+		}
+	}
+	return nil, fmt.Errorf("topic %s was not expanded from a pubsub", topic.Id())
+}
+
+// redisNodeConstruct returns the redis_node persist which the cache was expanded from
+func redisNodeConstruct(cache *resources.RedisCache) (*types.RedisNode, error) {
+	for _, ref := range cache.ConstructRefs {
+		if node, ok := ref.(*types.RedisNode); ok {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("redis cache %s was not expanded from a redis_node persist", cache.Id())
+}
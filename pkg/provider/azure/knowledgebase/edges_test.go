@@ -0,0 +1,215 @@
+package knowledgebase
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfigureEdge(t *testing.T) {
+	ps := &types.PubSub{Name: "events"}
+	node := &types.RedisNode{Name: "cache"}
+	topic := func() *resources.ServiceBusTopic {
+		return &resources.ServiceBusTopic{Name: "events", ConstructRefs: construct.BaseConstructSetOf(ps)}
+	}
+	vault := &resources.KeyVault{Name: "secrets"}
+	cache := &resources.RedisCache{Name: "cache", ConstructRefs: construct.BaseConstructSetOf(node)}
+	container := &resources.StorageContainer{Name: "uploads"}
+	cosmos := &resources.CosmosDbAccount{Name: "kv"}
+
+	cases := []struct {
+		name     string
+		source   construct.Resource
+		dest     construct.Resource
+		existing []construct.Resource
+		check    func(assert *assert.Assertions, source construct.Resource, dest construct.Resource)
+		wantErr  string
+	}{
+		{
+			name:   "container app reads key vault",
+			source: &resources.ContainerApp{Name: "api"},
+			dest:   vault,
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				resources.KEY_VAULT_URL_ENV_VAR: {ResourceId: vault.Id(), Property: resources.VAULT_URI_IAC_VALUE},
+			}),
+		},
+		{
+			name:   "function app reads key vault",
+			source: &resources.FunctionApp{Name: "api"},
+			dest:   vault,
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				resources.KEY_VAULT_URL_ENV_VAR: {ResourceId: vault.Id(), Property: resources.VAULT_URI_IAC_VALUE},
+			}),
+		},
+		{
+			name:   "container app publishes to topic",
+			source: &resources.ContainerApp{Name: "api"},
+			dest:   topic(),
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				resources.PubsubTopicEnvVarName(ps.Name): {ResourceId: topic().Id(), Property: resources.NAME_IAC_VALUE},
+				resources.SERVICE_BUS_NAMESPACE_ENV_VAR:  {ResourceId: topic().Id(), Property: resources.SERVICE_BUS_HOST_IAC_VALUE},
+			}),
+		},
+		{
+			name:   "function app publishes to topic",
+			source: &resources.FunctionApp{Name: "api"},
+			dest:   topic(),
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				resources.PubsubTopicEnvVarName(ps.Name): {ResourceId: topic().Id(), Property: resources.NAME_IAC_VALUE},
+				resources.SERVICE_BUS_NAMESPACE_ENV_VAR:  {ResourceId: topic().Id(), Property: resources.SERVICE_BUS_HOST_IAC_VALUE},
+			}),
+		},
+		{
+			name:    "topic not expanded from a pubsub",
+			source:  &resources.ContainerApp{Name: "api"},
+			dest:    &resources.ServiceBusTopic{Name: "events"},
+			wantErr: "was not expanded from a pubsub",
+		},
+		{
+			name:   "container app uses redis cache",
+			source: &resources.ContainerApp{Name: "api"},
+			dest:   cache,
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				"CACHE_PERSIST_REDIS_PASSWORD": {ResourceId: cache.Id(), Property: resources.PASSWORD_IAC_VALUE},
+			}),
+		},
+		{
+			name:   "function app uses redis cache",
+			source: &resources.FunctionApp{Name: "api"},
+			dest:   cache,
+			check: hasEnvironmentVariables(map[string]construct.IaCValue{
+				"CACHE_PERSIST_REDIS_PASSWORD": {ResourceId: cache.Id(), Property: resources.PASSWORD_IAC_VALUE},
+			}),
+		},
+		{
+			name:    "redis cache not expanded from a redis_node",
+			source:  &resources.ContainerApp{Name: "api"},
+			dest:    &resources.RedisCache{Name: "cache"},
+			wantErr: "was not expanded from a redis_node persist",
+		},
+		{
+			name:   "topic of subscription",
+			source: topic(),
+			dest:   &resources.ServiceBusSubscription{Name: "events-api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(source, dest.(*resources.ServiceBusSubscription).Topic)
+			},
+		},
+		{
+			name:     "subscription delivers to container app",
+			source:   &resources.ServiceBusSubscription{Name: "events-api"},
+			dest:     &resources.ContainerApp{Name: "api", Identity: &resources.ManagedIdentity{Name: "api"}},
+			existing: []construct.Resource{topic()},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				sub := source.(*resources.ServiceBusSubscription)
+				app := dest.(*resources.ContainerApp)
+				if !assert.NotNil(sub.Topic) {
+					return
+				}
+				assert.Equal(1, app.MinReplicas)
+				assert.Equal(map[string]construct.IaCValue{
+					resources.PubsubSubscriptionEnvVarName(ps.Name): {ResourceId: sub.Id(), Property: resources.TOPIC_SUBSCRIPTION_IAC_VALUE},
+					resources.SERVICE_BUS_NAMESPACE_ENV_VAR:         {ResourceId: sub.Topic.Id(), Property: resources.SERVICE_BUS_HOST_IAC_VALUE},
+				}, app.EnvironmentVariables)
+				assert.Equal([]resources.RoleAssignment{
+					{RoleDefinitionId: resources.SERVICE_BUS_DATA_RECEIVER_ROLE, Scope: construct.IaCValue{ResourceId: sub.Topic.Id(), Property: resources.ID_IAC_VALUE}},
+				}, app.Identity.RoleAssignments)
+			},
+		},
+		{
+			name:    "subscription without a topic",
+			source:  &resources.ServiceBusSubscription{Name: "events-api"},
+			dest:    &resources.ContainerApp{Name: "api"},
+			wantErr: "must have exactly one topic, found 0",
+		},
+		{
+			name:   "identity uses storage container",
+			source: &resources.ManagedIdentity{Name: "api"},
+			dest:   container,
+			check:  hasRoleAssignment(resources.STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE),
+		},
+		{
+			name:   "identity reads key vault",
+			source: &resources.ManagedIdentity{Name: "api"},
+			dest:   vault,
+			check:  hasRoleAssignment(resources.KEY_VAULT_SECRETS_USER_ROLE),
+		},
+		{
+			name:   "identity publishes to topic",
+			source: &resources.ManagedIdentity{Name: "api"},
+			dest:   topic(),
+			check:  hasRoleAssignment(resources.SERVICE_BUS_DATA_SENDER_ROLE),
+		},
+		{
+			name:   "identity uses cosmos db account",
+			source: &resources.ManagedIdentity{Name: "api"},
+			dest:   cosmos,
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				identity := source.(*resources.ManagedIdentity)
+				assert.Empty(identity.RoleAssignments)
+				assert.Equal([]*resources.ManagedIdentity{identity}, dest.(*resources.CosmosDbAccount).DataContributors)
+			},
+		},
+		{
+			name:   "front door endpoint of container app",
+			source: &resources.FrontDoorEndpoint{Name: "gw"},
+			dest:   &resources.ContainerApp{Name: "api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.True(dest.(*resources.ContainerApp).ExternalIngress)
+			},
+		},
+		{
+			name:   "front door endpoint of function app",
+			source: &resources.FrontDoorEndpoint{Name: "gw"},
+			dest:   &resources.FunctionApp{Name: "api"},
+			check:  func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			kb, err := GetAzureKnowledgeBase()
+			if !assert.NoError(err) {
+				return
+			}
+			dag := construct.NewResourceGraph()
+			dag.AddDependency(tt.source, tt.dest)
+			for _, res := range tt.existing {
+				dag.AddDependency(res, tt.source)
+			}
+			err = kb.ConfigureEdge(dag.GetDependency(tt.source.Id(), tt.dest.Id()), dag)
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			tt.check(assert, tt.source, tt.dest)
+		})
+	}
+}
+
+func hasEnvironmentVariables(want map[string]construct.IaCValue) func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+	return func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+		switch res := source.(type) {
+		case *resources.ContainerApp:
+			assert.Equal(want, res.EnvironmentVariables)
+		case *resources.FunctionApp:
+			assert.Equal(want, res.EnvironmentVariables)
+		default:
+			assert.Failf("not a compute resource", "%s", source.Id())
+		}
+	}
+}
+
+func hasRoleAssignment(role string) func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+	return func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+		assert.Equal([]resources.RoleAssignment{
+			{RoleDefinitionId: role, Scope: construct.IaCValue{ResourceId: dest.Id(), Property: resources.ID_IAC_VALUE}},
+		}, source.(*resources.ManagedIdentity).RoleAssignments)
+	}
+}
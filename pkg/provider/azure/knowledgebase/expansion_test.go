@@ -0,0 +1,152 @@
+package knowledgebase_test
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine"
+	"github.com/klothoplatform/klotho/pkg/engine/constraints"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/azure"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Expansion(t *testing.T) {
+	kv := &types.Kv{Name: "events"}
+	fs := &types.Fs{Name: "uploads"}
+	orm := &types.Orm{Name: "db"}
+	secrets := &types.Secrets{Name: "sec", Secrets: []string{"api-key.txt"}}
+
+	cases := []struct {
+		name     string
+		unitType string
+		want     []string
+		check    func(assert *assert.Assertions, dag *construct.ResourceGraph, compute resources.ComputeResource)
+	}{
+		{
+			name:     "container app",
+			unitType: resources.CONTAINER_APP_TYPE,
+			want: []string{
+				"container_app",
+				"container_app_environment",
+				"container_image",
+				"container_registry",
+				"cosmosdb_account",
+				"front_door_endpoint",
+				"front_door_profile",
+				"key_vault",
+				"managed_identity",
+				"postgres_flexible_server",
+				"resource_group",
+				"storage_account",
+				"storage_container",
+			},
+			check: func(assert *assert.Assertions, dag *construct.ResourceGraph, compute resources.ComputeResource) {
+				app := compute.(*resources.ContainerApp)
+				assert.True(app.ExternalIngress)
+				assert.Equal(resources.CONTAINER_APP_PORT, app.Port)
+				assert.NotNil(app.Image)
+				assert.NotNil(app.Environment)
+				assert.Contains(app.EnvironmentVariables, resources.KEY_VAULT_URL_ENV_VAR)
+			},
+		},
+		{
+			name:     "function app",
+			unitType: resources.FUNCTION_APP_TYPE,
+			want: []string{
+				"app_service_plan",
+				"cosmosdb_account",
+				"front_door_endpoint",
+				"front_door_profile",
+				"function_app",
+				"key_vault",
+				"managed_identity",
+				"postgres_flexible_server",
+				"resource_group",
+				"storage_account",
+				"storage_account",
+				"storage_container",
+			},
+			check: func(assert *assert.Assertions, dag *construct.ResourceGraph, compute resources.ComputeResource) {
+				app := compute.(*resources.FunctionApp)
+				assert.Equal("node", app.Runtime)
+				assert.Equal("api", app.Source)
+				assert.NotNil(app.Plan)
+				assert.NotNil(app.StorageAccount)
+				assert.Contains(app.EnvironmentVariables, resources.KEY_VAULT_URL_ENV_VAR)
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			kb, err := knowledgebase.GetAzureKnowledgeBase()
+			if !assert.NoError(err) {
+				return
+			}
+			a := &azure.Azure{AppName: "app"}
+			e := engine.NewEngine(map[string]provider.Provider{a.Name(): a}, kb, types.ListAllConstructs())
+
+			unit := &types.ExecutionUnit{Name: "api"}
+			unit.Add(&io.RawFile{FPath: "package.json"})
+			gw := &types.Gateway{Name: "gw"}
+			cg := construct.NewConstructGraph()
+			cg.AddConstruct(unit)
+			for _, c := range []construct.Construct{kv, fs, orm, secrets} {
+				cg.AddConstruct(c)
+				cg.AddDependency(unit.Id(), c.Id())
+			}
+			cg.AddConstruct(gw)
+			cg.AddDependency(gw.Id(), unit.Id())
+			e.LoadContext(cg, map[constraints.ConstraintScope][]constraints.Constraint{
+				constraints.ConstructConstraintScope: {
+					&constraints.ConstructConstraint{Operator: constraints.EqualsConstraintOperator, Target: unit.Id(), Type: tt.unitType},
+				},
+			}, "app")
+			dag, err := e.Run()
+			if !assert.NoError(err) {
+				return
+			}
+			var got []string
+			var compute resources.ComputeResource
+			for _, res := range dag.ListResources() {
+				assert.Equal(a.Name(), res.Id().Provider)
+				got = append(got, res.Id().Type)
+				if c, ok := res.(resources.ComputeResource); ok {
+					compute = c
+				}
+			}
+			assert.ElementsMatch(tt.want, got)
+			if !assert.NotNil(compute) {
+				return
+			}
+			tt.check(assert, dag, compute)
+
+			vaults := construct.GetResources[*resources.KeyVault](dag)
+			containers := construct.GetResources[*resources.StorageContainer](dag)
+			accounts := construct.GetResources[*resources.CosmosDbAccount](dag)
+			if !assert.Len(vaults, 1) || !assert.Len(containers, 1) || !assert.Len(accounts, 1) {
+				return
+			}
+			assert.Equal([]resources.KeyVaultSecret{{Path: "api-key.txt", SecretName: "api-key-txt"}}, vaults[0].Secrets)
+
+			identity := compute.GetIdentity()
+			if !assert.NotNil(identity) {
+				return
+			}
+			assert.ElementsMatch([]resources.RoleAssignment{
+				{RoleDefinitionId: resources.STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE, Scope: construct.IaCValue{ResourceId: containers[0].Id(), Property: resources.ID_IAC_VALUE}},
+				{RoleDefinitionId: resources.KEY_VAULT_SECRETS_USER_ROLE, Scope: construct.IaCValue{ResourceId: vaults[0].Id(), Property: resources.ID_IAC_VALUE}},
+			}, identity.RoleAssignments)
+			assert.Equal([]*resources.ManagedIdentity{identity}, accounts[0].DataContributors)
+			// the account's role assignments reference the identity, so it must be deployed after it
+			order := e.GetDeploymentOrderGraph(dag)
+			assert.NotNil(order.GetDependency(accounts[0].Id(), identity.Id()))
+			assert.Nil(order.GetDependency(identity.Id(), accounts[0].Id()))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+)
+
+var FrontDoorKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.FrontDoorEndpoint, *resources.ContainerApp]{
+		Configure: func(endpoint *resources.FrontDoorEndpoint, app *resources.ContainerApp, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			// Front Door reaches its origins over the internet, so the app must accept requests from outside its environment
+			app.ExternalIngress = true
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.FrontDoorEndpoint, *resources.FunctionApp]{},
+	knowledgebase.EdgeBuilder[*resources.FrontDoorEndpoint, *resources.StorageContainer]{},
+)
@@ -0,0 +1,38 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+)
+
+// IamKB assigns the managed identities of compute resources the roles they need on each of the resources they use.
+var IamKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.ManagedIdentity, *resources.StorageContainer]{
+		Configure: func(identity *resources.ManagedIdentity, container *resources.StorageContainer, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			identity.AddRoleAssignment(resources.STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE, container)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ManagedIdentity, *resources.KeyVault]{
+		Configure: func(identity *resources.ManagedIdentity, vault *resources.KeyVault, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			identity.AddRoleAssignment(resources.KEY_VAULT_SECRETS_USER_ROLE, vault)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ManagedIdentity, *resources.ServiceBusTopic]{
+		Configure: func(identity *resources.ManagedIdentity, topic *resources.ServiceBusTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			identity.AddRoleAssignment(resources.SERVICE_BUS_DATA_SENDER_ROLE, topic)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ManagedIdentity, *resources.CosmosDbAccount]{
+		Configure: func(identity *resources.ManagedIdentity, account *resources.CosmosDbAccount, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			// Cosmos DB authorizes access to its data with its own role assignments, rather than those of the resource
+			account.AddDataContributor(identity)
+			return nil
+		},
+		// the account's role assignments reference the identity, so the identity is deployed first
+		DeploymentOrderReversed: true,
+	},
+)
@@ -0,0 +1,107 @@
+package azure
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"reflect"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Azure expands the application's constructs to Azure resources, which are deployed to the subscription and
+	// location of the pulumi stack's `azure-native` configuration.
+	Azure struct {
+		AppName string
+	}
+)
+
+func (a *Azure) Name() string { return provider.AZURE }
+
+func (a *Azure) ListResources() []construct.Resource {
+	return resources.ListAll()
+}
+
+// CreateConstructFromId creates a resource from an id, but does not mutate the graph in any manner
+func (a *Azure) CreateConstructFromId(id construct.ResourceId, dag *construct.ConstructGraph) (construct.BaseConstruct, error) {
+	typeToResource := make(map[string]construct.Resource)
+	for _, res := range resources.ListAll() {
+		typeToResource[res.Id().Type] = res
+	}
+	res, ok := typeToResource[id.Type]
+	if !ok {
+		return nil, fmt.Errorf("unable to find resource of type %s", id.Type)
+	}
+	newResource := reflect.New(reflect.TypeOf(res).Elem()).Interface()
+	resource, ok := newResource.(construct.Resource)
+	if !ok {
+		return nil, fmt.Errorf("item %s of type %T is not of type construct.Resource", id, newResource)
+	}
+	reflect.ValueOf(resource).Elem().FieldByName("Name").SetString(id.Name)
+	return resource, nil
+}
+
+//go:embed resources/templates/*
+var azureTemplates embed.FS
+
+func (a *Azure) GetOperationalTemplates() map[construct.ResourceId]*knowledgebase.ResourceTemplate {
+	templates := map[construct.ResourceId]*knowledgebase.ResourceTemplate{}
+	if err := fs.WalkDir(azureTemplates, ".", func(path string, d fs.DirEntry, nerr error) error {
+		if d.IsDir() {
+			return nil
+		}
+		content, err := azureTemplates.ReadFile(fmt.Sprintf("resources/templates/%s", d.Name()))
+		if err != nil {
+			panic(err)
+		}
+		resTemplate := &knowledgebase.ResourceTemplate{}
+		err = yaml.Unmarshal(content, resTemplate)
+		if err != nil {
+			panic(err)
+		}
+		id := construct.ResourceId{Provider: provider.AZURE, Type: resTemplate.Type}
+		if templates[id] != nil {
+			panic(fmt.Errorf("duplicate template for type %s", resTemplate.Type))
+		}
+		templates[id] = resTemplate
+		return nil
+	}); err != nil {
+		return templates
+	}
+	return templates
+}
+
+//go:embed edges/*
+var azureEdgeTemplates embed.FS
+
+func (a *Azure) GetEdgeTemplates() map[string]*knowledgebase.EdgeTemplate {
+	templates := map[string]*knowledgebase.EdgeTemplate{}
+	if err := fs.WalkDir(azureEdgeTemplates, ".", func(path string, d fs.DirEntry, nerr error) error {
+		if d.IsDir() {
+			return nil
+		}
+		content, err := azureEdgeTemplates.ReadFile(fmt.Sprintf("edges/%s", d.Name()))
+		if err != nil {
+			panic(err)
+		}
+		resTemplate := &knowledgebase.EdgeTemplate{}
+		err = yaml.Unmarshal(content, resTemplate)
+		if err != nil {
+			panic(err)
+		}
+		templateKey := resTemplate.Key()
+		if templates[templateKey] != nil {
+			panic(fmt.Errorf("duplicate template for type %s", templateKey))
+		}
+		templates[templateKey] = resTemplate
+		return nil
+	}); err != nil {
+		return templates
+	}
+	return templates
+}
@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/azure/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOperationalTemplates(t *testing.T) {
+	assert := assert.New(t)
+	a := &Azure{AppName: "app"}
+
+	templates := a.GetOperationalTemplates()
+	for id, template := range templates {
+		assert.Equal(provider.AZURE, id.Provider)
+		_, err := a.CreateConstructFromId(construct.ResourceId{Provider: provider.AZURE, Type: template.Type, Name: "test"}, nil)
+		assert.NoError(err, "template of %s", template.Type)
+	}
+	for _, res := range resources.ListAll() {
+		assert.Contains(templates, construct.ResourceId{Provider: provider.AZURE, Type: res.Id().Type}, "resource %s has no template", res.Id().Type)
+	}
+}
+
+// Test_GetEdgeTemplates checks that the resources of each edge template exist, that the fields which its expansion
+// references exist on its resources, and that each of the edges it expands to has a template of its own.
+func Test_GetEdgeTemplates(t *testing.T) {
+	a := &Azure{AppName: "app"}
+	templates := a.GetEdgeTemplates()
+	assert.Len(t, templates, 45)
+
+	for key, template := range templates {
+		t.Run(key, func(t *testing.T) {
+			assert := assert.New(t)
+			endpoints := map[construct.ResourceId]construct.Resource{}
+			for _, id := range append([]construct.ResourceId{template.Source, template.Destination}, template.Expansion.Resources...) {
+				res, err := a.CreateConstructFromId(construct.ResourceId{Provider: id.Provider, Type: id.Type, Name: "test"}, nil)
+				if !assert.NoError(err) {
+					return
+				}
+				endpoints[id] = res.(construct.Resource)
+			}
+			for _, dep := range template.Expansion.Dependencies {
+				source := resolveEndpoint(assert, endpoints, dep.Source)
+				destination := resolveEndpoint(assert, endpoints, dep.Destination)
+				if source == nil || destination == nil {
+					continue
+				}
+				if source.Id().Type == template.Source.Type && destination.Id().Type == template.Destination.Type {
+					continue
+				}
+				edgeKey := source.Id().QualifiedTypeName() + ":-" + destination.Id().QualifiedTypeName() + ":"
+				assert.Contains(templates, edgeKey, "expands to an edge without a template")
+			}
+		})
+	}
+}
+
+// resolveEndpoint returns the resource which the endpoint of an expanded dependency refers to, which is either one of
+// the template's resources or the resource in a field of one of them, after a '#'.
+func resolveEndpoint(assert *assert.Assertions, endpoints map[construct.ResourceId]construct.Resource, id construct.ResourceId) construct.Resource {
+	owner, field, isField := strings.Cut(id.Name, "#")
+	res, ok := endpoints[construct.ResourceId{Provider: id.Provider, Type: id.Type, Name: owner}]
+	if !assert.True(ok, "%s is not a resource of the template", id) || !isField {
+		return res
+	}
+	value := reflect.ValueOf(res).Elem().FieldByName(field)
+	if !assert.True(value.IsValid(), "%s has no field %s", res.Id().Type, field) {
+		return nil
+	}
+	fieldRes, ok := reflect.New(value.Type().Elem()).Interface().(construct.Resource)
+	if !assert.True(ok, "field %s of %s is not a resource", field, res.Id().Type) {
+		return nil
+	}
+	return fieldRes
+}
+
+func Test_CreateConstructFromId(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      construct.ResourceId
+		want    construct.Resource
+		wantErr bool
+	}{
+		{
+			name: "container app",
+			id:   construct.ResourceId{Provider: provider.AZURE, Type: resources.CONTAINER_APP_TYPE, Name: "api"},
+			want: &resources.ContainerApp{Name: "api"},
+		},
+		{
+			name: "key vault",
+			id:   construct.ResourceId{Provider: provider.AZURE, Type: resources.KEY_VAULT_TYPE, Name: "secrets"},
+			want: &resources.KeyVault{Name: "secrets"},
+		},
+		{
+			name:    "unknown type",
+			id:      construct.ResourceId{Provider: provider.AZURE, Type: "lambda_function", Name: "api"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			a := &Azure{AppName: "app"}
+
+			got, err := a.CreateConstructFromId(tt.id, nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
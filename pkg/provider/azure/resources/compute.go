@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+const (
+	// ID_IAC_VALUE and NAME_IAC_VALUE are the properties of a resource's output by the same name
+	ID_IAC_VALUE   = "id"
+	NAME_IAC_VALUE = "name"
+
+	// PUBSUB_TOPIC_ENV_VAR_PREFIX and PUBSUB_SUBSCRIPTION_ENV_VAR_PREFIX prefix the environment variables which hold
+	// the names of the topics that a unit publishes to and the subscriptions that it receives messages from
+	PUBSUB_TOPIC_ENV_VAR_PREFIX        = "KLOTHO_PUBSUB_TOPIC_"
+	PUBSUB_SUBSCRIPTION_ENV_VAR_PREFIX = "KLOTHO_PUBSUB_SUBSCRIPTION_"
+	// SERVICE_BUS_NAMESPACE_ENV_VAR holds the host of the Service Bus namespace of the topics a unit uses
+	SERVICE_BUS_NAMESPACE_ENV_VAR = "KLOTHO_SERVICE_BUS_NAMESPACE"
+	// KEY_VAULT_URL_ENV_VAR holds the url of the Key Vault which a unit reads its secrets from
+	KEY_VAULT_URL_ENV_VAR = "KLOTHO_KEY_VAULT_URL"
+	// REDIS_PASSWORD_ENV_VAR_NAME_SUFFIX suffixes the environment variable which holds the access key of a redis cache,
+	// after the name of the redis_node persist it was expanded from
+	REDIS_PASSWORD_ENV_VAR_NAME_SUFFIX = "_PERSIST_REDIS_PASSWORD"
+)
+
+type (
+	// ComputeResource is a resource which runs an execution unit, with the identity of its managed identity
+	ComputeResource interface {
+		construct.Resource
+		Unit() *types.ExecutionUnit
+		GetIdentity() *ManagedIdentity
+		SetEnvironmentVariable(name string, value construct.IaCValue)
+	}
+)
+
+// executionUnit returns the execution unit within refs
+func executionUnit(refs construct.BaseConstructSet) *types.ExecutionUnit {
+	for _, ref := range refs {
+		if unit, ok := ref.(*types.ExecutionUnit); ok {
+			return unit
+		}
+	}
+	return nil
+}
+
+// resolveEnvironmentVariables resolves the environment variables which the unit's runtime reads its dependencies from
+// to the properties of the resources downstream of res which those dependencies were expanded to.
+func resolveEnvironmentVariables(dag *construct.ResourceGraph, res ComputeResource, unit *types.ExecutionUnit) error {
+	for _, envVar := range unit.EnvironmentVariables {
+		if envVar.GetConstruct() == nil {
+			continue
+		}
+		var target construct.Resource
+		for _, downstream := range dag.GetDownstreamResources(res) {
+			if downstream.BaseConstructRefs().Has(envVar.GetConstruct().Id()) {
+				target = downstream
+				break
+			}
+		}
+		if target == nil {
+			return fmt.Errorf("%s has no resource for environment variable %s of %s", res.Id(), envVar.GetName(), envVar.GetConstruct().Id())
+		}
+		res.SetEnvironmentVariable(envVar.GetName(), construct.IaCValue{ResourceId: target.Id(), Property: envVar.GetValue()})
+	}
+	return nil
+}
+
+// PubsubTopicEnvVarName is the name of the environment variable that holds the name of the topic of the pubsub with
+// the given id.
+func PubsubTopicEnvVarName(id string) string {
+	return PUBSUB_TOPIC_ENV_VAR_PREFIX + envVarSuffix(id)
+}
+
+// PubsubSubscriptionEnvVarName is the name of the environment variable that holds the topic and subscription which a
+// unit receives the messages of the pubsub with the given id from.
+func PubsubSubscriptionEnvVarName(id string) string {
+	return PUBSUB_SUBSCRIPTION_ENV_VAR_PREFIX + envVarSuffix(id)
+}
+
+// RedisPasswordEnvVarName is the name of the environment variable that holds the access key of the cache of the
+// redis_node persist with the given name.
+func RedisPasswordEnvVarName(name string) string {
+	return strings.ToUpper(name) + REDIS_PASSWORD_ENV_VAR_NAME_SUFFIX
+}
+
+// envVarSuffix matches how the runtimes derive the environment variable of a pubsub from its id
+func envVarSuffix(id string) string {
+	return strings.ToUpper(sanitization.EnvVarKeySanitizer.Apply(id))
+}
@@ -0,0 +1,140 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	CONTAINER_APP_TYPE             = "container_app"
+	CONTAINER_APP_ENVIRONMENT_TYPE = "container_app_environment"
+
+	// FQDN_IAC_VALUE is the host name which a compute resource's ingress serves requests on
+	FQDN_IAC_VALUE = "fqdn"
+
+	// CONTAINER_APP_PORT is the port which the ingress of a container app sends requests to, which the exposed app of
+	// a unit listens on
+	CONTAINER_APP_PORT = 3000
+)
+
+type (
+	// ContainerApp runs an execution unit as an app of a Container Apps environment, from the image that its language
+	// runtime's Dockerfile builds.
+	ContainerApp struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup        *ResourceGroup
+		Environment          *ContainerAppEnvironment
+		Image                *ContainerImage
+		Identity             *ManagedIdentity
+		Port                 int
+		EnvironmentVariables map[string]construct.IaCValue
+		// ExternalIngress serves the app's requests to the internet, which a Front Door in front of it requires
+		ExternalIngress bool
+		// MinReplicas keeps replicas running without requests, so that they can receive the messages of
+		// subscriptions in the background
+		MinReplicas int
+	}
+
+	// ContainerAppEnvironment is the environment which the app's container apps run in
+	ContainerAppEnvironment struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+	}
+)
+
+// MakeOperational resolves the environment variables which the unit's runtime reads its dependencies from to the
+// properties of the resources they were expanded to.
+func (app *ContainerApp) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	unit := app.Unit()
+	if unit == nil {
+		return fmt.Errorf("container app %s has no execution unit", app.Name)
+	}
+	if app.Port == 0 {
+		app.Port = CONTAINER_APP_PORT
+	}
+	if app.EnvironmentVariables == nil {
+		app.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	return resolveEnvironmentVariables(dag, app, unit)
+}
+
+// Registry returns the registry which the app pulls its image from
+func (app *ContainerApp) Registry() *ContainerRegistry {
+	if app.Image == nil {
+		return nil
+	}
+	return app.Image.Registry
+}
+
+func (app *ContainerApp) Unit() *types.ExecutionUnit {
+	return executionUnit(app.ConstructRefs)
+}
+
+func (app *ContainerApp) GetIdentity() *ManagedIdentity {
+	return app.Identity
+}
+
+func (app *ContainerApp) SetEnvironmentVariable(name string, value construct.IaCValue) {
+	if app.EnvironmentVariables == nil {
+		app.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	app.EnvironmentVariables[name] = value
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the app from
+func (app *ContainerApp) ResourceName() string {
+	return azure.ContainerAppSanitizer.Apply(app.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (app *ContainerApp) BaseConstructRefs() construct.BaseConstructSet {
+	return app.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (app *ContainerApp) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     CONTAINER_APP_TYPE,
+		Name:     app.Name,
+	}
+}
+
+func (app *ContainerApp) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the environment from
+func (env *ContainerAppEnvironment) ResourceName() string {
+	return azure.ContainerAppSanitizer.Apply(env.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (env *ContainerAppEnvironment) BaseConstructRefs() construct.BaseConstructSet {
+	return env.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (env *ContainerAppEnvironment) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     CONTAINER_APP_ENVIRONMENT_TYPE,
+		Name:     env.Name,
+	}
+}
+
+func (env *ContainerAppEnvironment) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ContainerAppMakeOperational(t *testing.T) {
+	fs := &types.Fs{Name: "uploads"}
+	container := &StorageContainer{Name: "uploads", ConstructRefs: construct.BaseConstructSetOf(fs)}
+
+	tests := []struct {
+		name      string
+		app       *ContainerApp
+		envVars   types.EnvironmentVariables
+		existing  []construct.Resource
+		wantPort  int
+		wantEnv   map[string]construct.IaCValue
+		wantError bool
+	}{
+		{
+			name:     "defaults the port",
+			app:      containerAppFor("api"),
+			wantPort: CONTAINER_APP_PORT,
+			wantEnv:  map[string]construct.IaCValue{},
+		},
+		{
+			name:     "keeps the port",
+			app:      &ContainerApp{Name: "api", Port: 8080, ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "api"})},
+			wantPort: 8080,
+			wantEnv:  map[string]construct.IaCValue{},
+		},
+		{
+			name:     "resolves environment variables",
+			app:      containerAppFor("api"),
+			envVars:  types.EnvironmentVariables{types.GenerateBucketEnvVar(fs)},
+			existing: []construct.Resource{container},
+			wantPort: CONTAINER_APP_PORT,
+			wantEnv: map[string]construct.IaCValue{
+				"UPLOADS_BUCKET_NAME": {ResourceId: container.Id(), Property: string(types.BUCKET_NAME)},
+			},
+		},
+		{
+			name:      "environment variable without a resource",
+			app:       containerAppFor("api"),
+			envVars:   types.EnvironmentVariables{types.GenerateBucketEnvVar(fs)},
+			wantError: true,
+		},
+		{
+			name:      "no execution unit",
+			app:       &ContainerApp{Name: "api"},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			if unit := tt.app.Unit(); unit != nil {
+				unit.EnvironmentVariables = tt.envVars
+			}
+			dag := construct.NewResourceGraph()
+			dag.AddResource(tt.app)
+			for _, res := range tt.existing {
+				dag.AddDependency(tt.app, res)
+			}
+
+			err := tt.app.MakeOperational(dag, "app", nil)
+			if tt.wantError {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantPort, tt.app.Port)
+			assert.Equal(tt.wantEnv, tt.app.EnvironmentVariables)
+		})
+	}
+}
+
+func Test_ContainerAppRegistry(t *testing.T) {
+	assert := assert.New(t)
+	registry := &ContainerRegistry{Name: "registry"}
+
+	assert.Nil((&ContainerApp{Name: "api"}).Registry())
+	assert.Equal(registry, (&ContainerApp{Name: "api", Image: &ContainerImage{Name: "api", Registry: registry}}).Registry())
+}
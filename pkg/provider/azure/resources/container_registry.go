@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	CONTAINER_REGISTRY_TYPE = "container_registry"
+	CONTAINER_IMAGE_TYPE    = "container_image"
+)
+
+type (
+	// ContainerRegistry is the Azure Container Registry which the images of the app's units are pushed to. Container
+	// Apps pull from it with its admin credentials.
+	ContainerRegistry struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+	}
+
+	// ContainerImage is the image of an execution unit, built from the unit's directory with the Dockerfile that its
+	// language runtime generated.
+	ContainerImage struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Registry      *ContainerRegistry
+		ImageName     string
+		Context       string
+		Dockerfile    string
+	}
+)
+
+// MakeOperational builds the image from the directory of the unit which the compute resource upstream of it runs
+func (image *ContainerImage) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if image.Context != "" {
+		return nil
+	}
+	for _, res := range dag.GetUpstreamResources(image) {
+		compute, ok := res.(ComputeResource)
+		if !ok {
+			continue
+		}
+		unit := compute.Unit()
+		if unit == nil {
+			continue
+		}
+		image.Context = unit.Name
+		image.Dockerfile = path.Join(unit.Name, "Dockerfile")
+		image.ImageName = azure.ResourceNameSanitizer.Apply(unit.Name)
+		return nil
+	}
+	return fmt.Errorf("container image %s has no execution unit to build", image.Name)
+}
+
+// ResourceGroup returns the resource group of the registry which the image is pushed to
+func (image *ContainerImage) ResourceGroup() *ResourceGroup {
+	if image.Registry == nil {
+		return nil
+	}
+	return image.Registry.ResourceGroup
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the registry from
+func (registry *ContainerRegistry) ResourceName() string {
+	return azure.StorageAccountSanitizer.Apply(registry.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (registry *ContainerRegistry) BaseConstructRefs() construct.BaseConstructSet {
+	return registry.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (registry *ContainerRegistry) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     CONTAINER_REGISTRY_TYPE,
+		Name:     registry.Name,
+	}
+}
+
+func (registry *ContainerRegistry) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (image *ContainerImage) BaseConstructRefs() construct.BaseConstructSet {
+	return image.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (image *ContainerImage) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     CONTAINER_IMAGE_TYPE,
+		Name:     image.Name,
+	}
+}
+
+func (image *ContainerImage) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	COSMOSDB_ACCOUNT_TYPE = "cosmosdb_account"
+
+	// COSMOSDB_DATABASE_NAME and COSMOSDB_CONTAINER_NAME are the database and container which the kv runtime stores
+	// the entries of its maps in
+	COSMOSDB_DATABASE_NAME  = "klotho"
+	COSMOSDB_CONTAINER_NAME = "kv"
+)
+
+type (
+	// CosmosDbAccount is a serverless Cosmos DB account which holds the entries of the app's kv maps in the
+	// COSMOSDB_CONTAINER_NAME container. Only the managed identities of DataContributors can access its data, since
+	// the account's keys are disabled.
+	CosmosDbAccount struct {
+		Name             string
+		ConstructRefs    construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup    *ResourceGroup
+		DataContributors []*ManagedIdentity
+	}
+)
+
+// AddDataContributor grants the identity the data contributor role on the account's data, which Cosmos DB manages
+// apart from the role assignments of the resource.
+func (account *CosmosDbAccount) AddDataContributor(identity *ManagedIdentity) {
+	for _, contributor := range account.DataContributors {
+		if contributor == identity {
+			return
+		}
+	}
+	account.DataContributors = append(account.DataContributors, identity)
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the account from
+func (account *CosmosDbAccount) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(account.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (account *CosmosDbAccount) BaseConstructRefs() construct.BaseConstructSet {
+	return account.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (account *CosmosDbAccount) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     COSMOSDB_ACCOUNT_TYPE,
+		Name:     account.Name,
+	}
+}
+
+func (account *CosmosDbAccount) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
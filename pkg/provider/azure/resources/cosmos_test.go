@@ -0,0 +1,20 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddDataContributor(t *testing.T) {
+	assert := assert.New(t)
+	api := &ManagedIdentity{Name: "api"}
+	worker := &ManagedIdentity{Name: "worker"}
+	account := &CosmosDbAccount{Name: "kv"}
+
+	account.AddDataContributor(api)
+	account.AddDataContributor(worker)
+	account.AddDataContributor(api)
+
+	assert.Equal([]*ManagedIdentity{api, worker}, account.DataContributors)
+}
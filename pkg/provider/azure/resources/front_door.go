@@ -0,0 +1,220 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	FRONT_DOOR_PROFILE_TYPE  = "front_door_profile"
+	FRONT_DOOR_ENDPOINT_TYPE = "front_door_endpoint"
+
+	defaultFrontDoorSku = "Standard_AzureFrontDoor"
+)
+
+type (
+	// FrontDoorProfile is the Front Door profile of the app's endpoints
+	FrontDoorProfile struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+		SkuName       string
+	}
+
+	// FrontDoorEndpoint serves a gateway or static unit over https. Each of its routes forwards the requests which
+	// match its patterns to the origin of the unit which handles them. When CustomDomain is set, the endpoint also serves
+	// the domain with a managed certificate, once the domain's DNS records are validated.
+	FrontDoorEndpoint struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Profile       *FrontDoorProfile
+		Routes        []FrontDoorRoute
+		CustomDomain  string
+	}
+
+	FrontDoorRoute struct {
+		Name       string
+		Patterns   []string
+		OriginHost construct.IaCValue
+	}
+)
+
+var pathDynamicSegment = regexp.MustCompile(`(^|/)[:*]`)
+
+// MakeOperational routes the paths of the gateway which the endpoint was expanded from to the compute resource of the
+// unit which handles each of them. Endpoints which serve a static unit are routed when they are created.
+func (endpoint *FrontDoorEndpoint) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if len(endpoint.Routes) > 0 {
+		return nil
+	}
+	gw := endpoint.GatewayConstruct()
+	if gw == nil {
+		return fmt.Errorf("front door endpoint %s has no routes", endpoint.Name)
+	}
+	if gw.Auth != nil && gw.Auth.Type != types.GatewayAuthNone || len(gw.RouteAuth) > 0 {
+		return fmt.Errorf("gateway %s: authorizers are not supported by azure front door", gw.Name)
+	}
+	if gw.Domain != nil {
+		endpoint.CustomDomain = gw.Domain.Name
+	}
+
+	origins := make(map[string]ComputeResource)
+	for _, res := range dag.GetDownstreamResources(endpoint) {
+		if compute, ok := res.(ComputeResource); ok {
+			if unit := compute.Unit(); unit != nil {
+				origins[unit.Name] = compute
+			}
+		}
+	}
+	routes, err := frontDoorRoutes(gw.Routes, origins)
+	if err != nil {
+		return err
+	}
+	endpoint.Routes = routes
+	return nil
+}
+
+// frontDoorRoutes groups the patterns which the routes of a gateway match by the unit which handles them. Front Door
+// only matches paths by prefix, so a route with parameters matches every path under its static prefix.
+func frontDoorRoutes(routes []types.Route, origins map[string]ComputeResource) ([]FrontDoorRoute, error) {
+	units := make(map[string]string)
+	for _, route := range routes {
+		if _, ok := origins[route.ExecUnitName]; !ok {
+			return nil, fmt.Errorf("no compute resource found for execution unit %s of route %s", route.ExecUnitName, route.Path)
+		}
+		pattern := convertPath(route.Path)
+		if existing, ok := units[pattern]; ok && existing != route.ExecUnitName {
+			return nil, fmt.Errorf("route %s is handled by more than one execution unit, which azure front door cannot route between", route.Path)
+		}
+		units[pattern] = route.ExecUnitName
+	}
+
+	patterns := make(map[string][]string)
+	for pattern, unit := range units {
+		patterns[unit] = append(patterns[unit], pattern)
+	}
+	var result []FrontDoorRoute
+	for unit, unitPatterns := range patterns {
+		sort.Strings(unitPatterns)
+		result = append(result, FrontDoorRoute{
+			Name:       unit,
+			Patterns:   unitPatterns,
+			OriginHost: construct.IaCValue{ResourceId: origins[unit].Id(), Property: FQDN_IAC_VALUE},
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// convertPath converts an express path into a Front Door route pattern. Paths with parameters or wildcards are
+// truncated to the static prefix before their first dynamic segment, followed by a wildcard.
+func convertPath(path string) string {
+	loc := pathDynamicSegment.FindStringIndex(path)
+	if loc == nil {
+		return path
+	}
+	return strings.TrimSuffix(path[:loc[0]], "/") + "/*"
+}
+
+// GatewayConstruct returns the gateway construct that the endpoint was expanded from, if any
+func (endpoint *FrontDoorEndpoint) GatewayConstruct() *types.Gateway {
+	for _, ref := range endpoint.ConstructRefs {
+		if gw, ok := ref.(*types.Gateway); ok {
+			return gw
+		}
+	}
+	return nil
+}
+
+func (profile *FrontDoorProfile) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if profile.SkuName == "" {
+		profile.SkuName = defaultFrontDoorSku
+	}
+	return nil
+}
+
+// frontDoorProfile returns the app's Front Door profile, creating it in the resource group if it does not exist yet
+func frontDoorProfile(dag *construct.ResourceGraph, rg *ResourceGroup, refs construct.BaseConstructSet) *FrontDoorProfile {
+	for _, res := range dag.ListResources() {
+		if profile, ok := res.(*FrontDoorProfile); ok {
+			profile.ConstructRefs.AddAll(refs)
+			return profile
+		}
+	}
+	profile := &FrontDoorProfile{
+		Name:          "front-door",
+		ConstructRefs: refs.Clone(),
+		ResourceGroup: rg,
+		SkuName:       defaultFrontDoorSku,
+	}
+	dag.AddDependenciesReflect(profile)
+	return profile
+}
+
+// ResourceGroup returns the resource group of the endpoint's profile
+func (endpoint *FrontDoorEndpoint) ResourceGroup() *ResourceGroup {
+	if endpoint.Profile == nil {
+		return nil
+	}
+	return endpoint.Profile.ResourceGroup
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the profile from
+func (profile *FrontDoorProfile) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(profile.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (profile *FrontDoorProfile) BaseConstructRefs() construct.BaseConstructSet {
+	return profile.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (profile *FrontDoorProfile) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     FRONT_DOOR_PROFILE_TYPE,
+		Name:     profile.Name,
+	}
+}
+
+func (profile *FrontDoorProfile) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the endpoint from
+func (endpoint *FrontDoorEndpoint) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(endpoint.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (endpoint *FrontDoorEndpoint) BaseConstructRefs() construct.BaseConstructSet {
+	return endpoint.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (endpoint *FrontDoorEndpoint) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     FRONT_DOOR_ENDPOINT_TYPE,
+		Name:     endpoint.Name,
+	}
+}
+
+func (endpoint *FrontDoorEndpoint) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_convertPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/", want: "/"},
+		{path: "/users", want: "/users"},
+		{path: "/users/:id", want: "/users/*"},
+		{path: "/users/:id/posts/:post", want: "/users/*"},
+		{path: "/files/:rest*", want: "/files/*"},
+		{path: "/static/*", want: "/static/*"},
+		{path: "/:id", want: "/*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, convertPath(tt.path))
+		})
+	}
+}
+
+func Test_FrontDoorEndpointMakeOperational(t *testing.T) {
+	mainApp := containerAppFor("main")
+	usersApp := containerAppFor("users")
+
+	tests := []struct {
+		name       string
+		gw         *types.Gateway
+		apps       []*ContainerApp
+		wantRoutes []FrontDoorRoute
+		wantDomain string
+		wantErr    bool
+	}{
+		{
+			name: "groups patterns by unit",
+			gw: &types.Gateway{
+				Name: "api",
+				Routes: []types.Route{
+					{Path: "/users/:id", Verb: "get", ExecUnitName: "users"},
+					{Path: "/", Verb: "get", ExecUnitName: "main"},
+					{Path: "/users", Verb: "post", ExecUnitName: "users"},
+					{Path: "/users/:id", Verb: "delete", ExecUnitName: "users"},
+				},
+			},
+			apps: []*ContainerApp{mainApp, usersApp},
+			wantRoutes: []FrontDoorRoute{
+				{Name: "main", Patterns: []string{"/"}, OriginHost: construct.IaCValue{ResourceId: mainApp.Id(), Property: FQDN_IAC_VALUE}},
+				{Name: "users", Patterns: []string{"/users", "/users/*"}, OriginHost: construct.IaCValue{ResourceId: usersApp.Id(), Property: FQDN_IAC_VALUE}},
+			},
+		},
+		{
+			name: "domain is served by the endpoint",
+			gw: &types.Gateway{
+				Name:   "api",
+				Routes: []types.Route{{Path: "/", Verb: "get", ExecUnitName: "main"}},
+				Domain: &types.CustomDomain{Name: "api.example.com"},
+			},
+			apps: []*ContainerApp{mainApp},
+			wantRoutes: []FrontDoorRoute{
+				{Name: "main", Patterns: []string{"/"}, OriginHost: construct.IaCValue{ResourceId: mainApp.Id(), Property: FQDN_IAC_VALUE}},
+			},
+			wantDomain: "api.example.com",
+		},
+		{
+			name: "same pattern handled by different units",
+			gw: &types.Gateway{
+				Name: "api",
+				Routes: []types.Route{
+					{Path: "/users/:id", Verb: "get", ExecUnitName: "main"},
+					{Path: "/users/:name", Verb: "post", ExecUnitName: "users"},
+				},
+			},
+			apps:    []*ContainerApp{mainApp, usersApp},
+			wantErr: true,
+		},
+		{
+			name:    "missing compute resource",
+			gw:      &types.Gateway{Name: "api", Routes: []types.Route{{Path: "/", Verb: "get", ExecUnitName: "main"}}},
+			apps:    []*ContainerApp{usersApp},
+			wantErr: true,
+		},
+		{
+			name: "authorizers are unsupported",
+			gw: &types.Gateway{
+				Name:   "api",
+				Routes: []types.Route{{Path: "/", Verb: "get", ExecUnitName: "main"}},
+				Auth:   &types.GatewayAuth{Type: types.GatewayAuthJwt},
+			},
+			apps:    []*ContainerApp{mainApp},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			endpoint := &FrontDoorEndpoint{Name: "api", ConstructRefs: construct.BaseConstructSetOf(tt.gw)}
+			dag := construct.NewResourceGraph()
+			dag.AddResource(endpoint)
+			for _, app := range tt.apps {
+				dag.AddDependency(endpoint, app)
+			}
+
+			err := endpoint.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantRoutes, endpoint.Routes)
+			assert.Equal(tt.wantDomain, endpoint.CustomDomain)
+		})
+	}
+}
+
+func containerAppFor(unitName string) *ContainerApp {
+	return &ContainerApp{
+		Name:          unitName,
+		ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: unitName}),
+	}
+}
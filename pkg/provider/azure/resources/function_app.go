@@ -0,0 +1,153 @@
+package resources
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	FUNCTION_APP_TYPE     = "function_app"
+	APP_SERVICE_PLAN_TYPE = "app_service_plan"
+
+	nodeFunctionRuntimeVersion = "18"
+	consumptionPlanSku         = "Y1"
+	consumptionPlanTier        = "Dynamic"
+)
+
+type (
+	// FunctionApp runs an execution unit as an Azure Functions app on a consumption plan. The app runs from a package
+	// of the unit's directory, whose dispatcher registers a function that handles all of the app's http requests.
+	FunctionApp struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup        *ResourceGroup
+		Plan                 *AppServicePlan
+		StorageAccount       *StorageAccount
+		Identity             *ManagedIdentity
+		Runtime              string
+		RuntimeVersion       string
+		Source               string
+		EnvironmentVariables map[string]construct.IaCValue
+	}
+
+	// AppServicePlan is the plan which the app's function apps are hosted on
+	AppServicePlan struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+		SkuName       string
+		SkuTier       string
+	}
+)
+
+// MakeOperational runs the function app from the unit's directory, and resolves the environment variables which the
+// unit's runtime reads its dependencies from to the properties of the resources they were expanded to.
+func (app *FunctionApp) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	unit := app.Unit()
+	if unit == nil {
+		return fmt.Errorf("function app %s has no execution unit", app.Name)
+	}
+	if app.Runtime == "" {
+		if !isJavascriptUnit(unit) {
+			return fmt.Errorf("execution unit %s cannot run as a function app, since only javascript units are supported", unit.Name)
+		}
+		app.Runtime = "node"
+		app.RuntimeVersion = nodeFunctionRuntimeVersion
+	}
+	if app.Source == "" {
+		app.Source = unit.Name
+	}
+	if app.EnvironmentVariables == nil {
+		app.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	return resolveEnvironmentVariables(dag, app, unit)
+}
+
+func (plan *AppServicePlan) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if plan.SkuName == "" {
+		plan.SkuName = consumptionPlanSku
+		plan.SkuTier = consumptionPlanTier
+	}
+	return nil
+}
+
+func isJavascriptUnit(unit *types.ExecutionUnit) bool {
+	for path := range unit.Files() {
+		if filepath.Base(path) == "package.json" {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *FunctionApp) Unit() *types.ExecutionUnit {
+	return executionUnit(app.ConstructRefs)
+}
+
+func (app *FunctionApp) GetIdentity() *ManagedIdentity {
+	return app.Identity
+}
+
+func (app *FunctionApp) SetEnvironmentVariable(name string, value construct.IaCValue) {
+	if app.EnvironmentVariables == nil {
+		app.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	app.EnvironmentVariables[name] = value
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the app from
+func (app *FunctionApp) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(app.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (app *FunctionApp) BaseConstructRefs() construct.BaseConstructSet {
+	return app.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (app *FunctionApp) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     FUNCTION_APP_TYPE,
+		Name:     app.Name,
+	}
+}
+
+func (app *FunctionApp) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the plan from
+func (plan *AppServicePlan) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(plan.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (plan *AppServicePlan) BaseConstructRefs() construct.BaseConstructSet {
+	return plan.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (plan *AppServicePlan) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     APP_SERVICE_PLAN_TYPE,
+		Name:     plan.Name,
+	}
+}
+
+func (plan *AppServicePlan) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
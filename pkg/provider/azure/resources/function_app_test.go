@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FunctionAppMakeOperational(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []string
+		app         *FunctionApp
+		wantRuntime string
+		wantVersion string
+		wantSource  string
+		wantErr     bool
+	}{
+		{
+			name:        "javascript unit",
+			files:       []string{"package.json", "index.js"},
+			app:         &FunctionApp{Name: "api"},
+			wantRuntime: "node",
+			wantVersion: nodeFunctionRuntimeVersion,
+			wantSource:  "api",
+		},
+		{
+			name:        "keeps its runtime and source",
+			files:       []string{"main.py"},
+			app:         &FunctionApp{Name: "api", Runtime: "python", RuntimeVersion: "3.11", Source: "build/api"},
+			wantRuntime: "python",
+			wantVersion: "3.11",
+			wantSource:  "build/api",
+		},
+		{
+			name:    "python unit",
+			files:   []string{"main.py", "requirements.txt"},
+			app:     &FunctionApp{Name: "api"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			unit := &types.ExecutionUnit{Name: "api"}
+			for _, f := range tt.files {
+				unit.Add(&io.RawFile{FPath: f})
+			}
+			tt.app.ConstructRefs = construct.BaseConstructSetOf(unit)
+			dag := construct.NewResourceGraph()
+			dag.AddResource(tt.app)
+
+			err := tt.app.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantRuntime, tt.app.Runtime)
+			assert.Equal(tt.wantVersion, tt.app.RuntimeVersion)
+			assert.Equal(tt.wantSource, tt.app.Source)
+			assert.NotNil(tt.app.EnvironmentVariables)
+		})
+	}
+}
+
+func Test_AppServicePlanMakeOperational(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     *AppServicePlan
+		wantName string
+		wantTier string
+	}{
+		{
+			name:     "consumption plan",
+			plan:     &AppServicePlan{Name: "plan"},
+			wantName: consumptionPlanSku,
+			wantTier: consumptionPlanTier,
+		},
+		{
+			name:     "keeps its sku",
+			plan:     &AppServicePlan{Name: "plan", SkuName: "EP1", SkuTier: "ElasticPremium"},
+			wantName: "EP1",
+			wantTier: "ElasticPremium",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := tt.plan.MakeOperational(construct.NewResourceGraph(), "app", nil)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantName, tt.plan.SkuName)
+			assert.Equal(tt.wantTier, tt.plan.SkuTier)
+		})
+	}
+}
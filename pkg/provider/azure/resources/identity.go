@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	MANAGED_IDENTITY_TYPE = "managed_identity"
+
+	// The ids of the built-in role definitions which managed identities are assigned on the resources they use
+	STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE = "ba92f5b4-2d11-453d-a403-e96b0029c9fe"
+	KEY_VAULT_SECRETS_USER_ROLE        = "4633458b-17de-408a-b874-0445c86b69e6"
+	SERVICE_BUS_DATA_SENDER_ROLE       = "69a216fc-b8fb-44d8-bc22-1f3c2cd27a39"
+	SERVICE_BUS_DATA_RECEIVER_ROLE     = "4f6d3b9b-027b-4f4c-9142-0e5a2a2247e0"
+)
+
+type (
+	// ManagedIdentity is the user-assigned identity which a compute resource runs as. Roles are assigned to it on each
+	// of the resources which the compute resource uses.
+	ManagedIdentity struct {
+		Name            string
+		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup   *ResourceGroup
+		RoleAssignments []RoleAssignment
+	}
+
+	// RoleAssignment assigns the built-in role with the id RoleDefinitionId on the resource Scope
+	RoleAssignment struct {
+		RoleDefinitionId string
+		Scope            construct.IaCValue
+	}
+)
+
+// AddRoleAssignment assigns the role to the identity on the resource res, if it isn't already
+func (identity *ManagedIdentity) AddRoleAssignment(roleDefinitionId string, res construct.Resource) {
+	assignment := RoleAssignment{
+		RoleDefinitionId: roleDefinitionId,
+		Scope:            construct.IaCValue{ResourceId: res.Id(), Property: ID_IAC_VALUE},
+	}
+	for _, existing := range identity.RoleAssignments {
+		if existing == assignment {
+			return
+		}
+	}
+	identity.RoleAssignments = append(identity.RoleAssignments, assignment)
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the identity from
+func (identity *ManagedIdentity) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(identity.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (identity *ManagedIdentity) BaseConstructRefs() construct.BaseConstructSet {
+	return identity.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (identity *ManagedIdentity) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     MANAGED_IDENTITY_TYPE,
+		Name:     identity.Name,
+	}
+}
+
+func (identity *ManagedIdentity) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
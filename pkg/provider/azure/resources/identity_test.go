@@ -0,0 +1,24 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddRoleAssignment(t *testing.T) {
+	assert := assert.New(t)
+	identity := &ManagedIdentity{Name: "api"}
+	account := &CosmosDbAccount{Name: "kv"}
+	container := &StorageContainer{Name: "uploads"}
+
+	identity.AddRoleAssignment(STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE, container)
+	identity.AddRoleAssignment(KEY_VAULT_SECRETS_USER_ROLE, account)
+	identity.AddRoleAssignment(STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE, container)
+
+	assert.Equal([]RoleAssignment{
+		{RoleDefinitionId: STORAGE_BLOB_DATA_CONTRIBUTOR_ROLE, Scope: construct.IaCValue{ResourceId: container.Id(), Property: ID_IAC_VALUE}},
+		{RoleDefinitionId: KEY_VAULT_SECRETS_USER_ROLE, Scope: construct.IaCValue{ResourceId: account.Id(), Property: ID_IAC_VALUE}},
+	}, identity.RoleAssignments)
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	KEY_VAULT_TYPE = "key_vault"
+
+	// VAULT_URI_IAC_VALUE is the url which the secrets of a vault are read from
+	VAULT_URI_IAC_VALUE = "vault_uri"
+)
+
+type (
+	// KeyVault is the Key Vault of a secrets construct, which holds a secret for each of the construct's paths. The
+	// initial value of each secret is read from the file of the same path when it exists at deploy time. Access to the
+	// vault is authorized with role assignments.
+	KeyVault struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+		Secrets       []KeyVaultSecret
+	}
+
+	KeyVaultSecret struct {
+		Path       string
+		SecretName string
+	}
+)
+
+// MakeOperational creates a secret for each of the paths of the secrets construct which the vault was expanded from.
+// The names of the secrets are derived from the paths the same way the secret runtime derives them.
+func (vault *KeyVault) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if len(vault.Secrets) > 0 {
+		return nil
+	}
+	var paths []string
+	for _, ref := range vault.ConstructRefs {
+		if s, ok := ref.(*types.Secrets); ok {
+			paths = append(paths, s.Secrets...)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		vault.Secrets = append(vault.Secrets, KeyVaultSecret{
+			Path:       path,
+			SecretName: azure.KeyVaultSecretNameSanitizer.Apply(path),
+		})
+	}
+	return nil
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the vault from
+func (vault *KeyVault) ResourceName() string {
+	return azure.KeyVaultSanitizer.Apply(vault.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (vault *KeyVault) BaseConstructRefs() construct.BaseConstructSet {
+	return vault.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (vault *KeyVault) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     KEY_VAULT_TYPE,
+		Name:     vault.Name,
+	}
+}
+
+func (vault *KeyVault) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KeyVaultMakeOperational(t *testing.T) {
+	tests := []struct {
+		name  string
+		vault *KeyVault
+		want  []KeyVaultSecret
+	}{
+		{
+			name: "secret for each path",
+			vault: &KeyVault{Name: "secrets", ConstructRefs: construct.BaseConstructSetOf(
+				&types.Secrets{Name: "secrets", Secrets: []string{"keys/private.pem", "api_key.txt"}},
+			)},
+			want: []KeyVaultSecret{
+				{Path: "api_key.txt", SecretName: "api-key-txt"},
+				{Path: "keys/private.pem", SecretName: "keys-private-pem"},
+			},
+		},
+		{
+			name: "paths of every secrets construct",
+			vault: &KeyVault{Name: "secrets", ConstructRefs: construct.BaseConstructSetOf(
+				&types.Secrets{Name: "a", Secrets: []string{"b.txt"}},
+				&types.Secrets{Name: "b", Secrets: []string{"a.txt"}},
+			)},
+			want: []KeyVaultSecret{
+				{Path: "a.txt", SecretName: "a-txt"},
+				{Path: "b.txt", SecretName: "b-txt"},
+			},
+		},
+		{
+			name: "keeps its secrets",
+			vault: &KeyVault{
+				Name:          "secrets",
+				ConstructRefs: construct.BaseConstructSetOf(&types.Secrets{Name: "secrets", Secrets: []string{"api_key.txt"}}),
+				Secrets:       []KeyVaultSecret{{Path: "api_key.txt", SecretName: "key"}},
+			},
+			want: []KeyVaultSecret{{Path: "api_key.txt", SecretName: "key"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			dag := construct.NewResourceGraph()
+			dag.AddResource(tt.vault)
+
+			err := tt.vault.MakeOperational(dag, "app", nil)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, tt.vault.Secrets)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package resources
+
+import "github.com/klothoplatform/klotho/pkg/construct"
+
+// ListAll returns an empty instance of each of the resources of the azure provider
+func ListAll() []construct.Resource {
+	return []construct.Resource{
+		&AppServicePlan{},
+		&ContainerApp{},
+		&ContainerAppEnvironment{},
+		&ContainerImage{},
+		&ContainerRegistry{},
+		&CosmosDbAccount{},
+		&FrontDoorEndpoint{},
+		&FrontDoorProfile{},
+		&FunctionApp{},
+		&KeyVault{},
+		&ManagedIdentity{},
+		&PostgresFlexibleServer{},
+		&RedisCache{},
+		&ResourceGroup{},
+		&ServiceBusNamespace{},
+		&ServiceBusSubscription{},
+		&ServiceBusTopic{},
+		&StorageAccount{},
+		&StorageContainer{},
+	}
+}
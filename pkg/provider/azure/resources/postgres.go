@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	POSTGRES_FLEXIBLE_SERVER_TYPE = "postgres_flexible_server"
+
+	defaultPostgresVersion       = "15"
+	defaultPostgresSkuName       = "Standard_B1ms"
+	defaultPostgresSkuTier       = "Burstable"
+	defaultPostgresStorageSizeGb = 32
+)
+
+type (
+	// PostgresFlexibleServer is an Azure Database for PostgreSQL flexible server which backs the orm it was expanded
+	// from, along with the database that the orm connects to. The credentials of its administrator are generated at
+	// compile time and read by the IaC from CredentialsPath, like those of an rds instance.
+	PostgresFlexibleServer struct {
+		Name            string
+		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup   *ResourceGroup
+		Version         string
+		SkuName         string
+		SkuTier         string
+		StorageSizeGb   int
+		DatabaseName    string
+		CredentialsFile io.File `yaml:"-"`
+		CredentialsPath string
+	}
+)
+
+// MakeOperational names the database after the orm it was expanded from and generates the credentials of the server's
+// administrator
+func (server *PostgresFlexibleServer) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if server.Version == "" {
+		server.Version = defaultPostgresVersion
+	}
+	if server.SkuName == "" {
+		server.SkuName = defaultPostgresSkuName
+		server.SkuTier = defaultPostgresSkuTier
+	}
+	if server.StorageSizeGb == 0 {
+		server.StorageSizeGb = defaultPostgresStorageSizeGb
+	}
+	if server.CredentialsPath == "" {
+		credsPath := fmt.Sprintf("secrets/%s", server.Name)
+		server.CredentialsFile = &io.RawFile{
+			FPath:   credsPath,
+			Content: []byte(fmt.Sprintf("{\n\"username\": \"%s\",\n\"password\": \"%s\"\n}", generateUsername(), generatePassword())),
+		}
+		server.CredentialsPath = credsPath
+	}
+	if server.DatabaseName != "" {
+		return nil
+	}
+	for _, ref := range server.ConstructRefs {
+		if orm, ok := ref.(*types.Orm); ok {
+			server.DatabaseName = aws.RdsDBNameSanitizer.Apply(orm.Name)
+			return nil
+		}
+	}
+	return fmt.Errorf("postgres flexible server %s has no DatabaseName", server.Name)
+}
+
+func (server *PostgresFlexibleServer) GetOutputFiles() []io.File {
+	return []io.File{server.CredentialsFile}
+}
+
+// generateUsername generates a random username for the server's administrator, which must start with a letter
+func generateUsername() string {
+	return "klo" + randomString(9)
+}
+
+// generatePassword generates a random password for the server's administrator, which must contain characters of at
+// least three of the categories uppercase, lowercase and digits.
+func generatePassword() string {
+	return "Kl0" + randomString(16)
+}
+
+func randomString(length int) string {
+	chars := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789")
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			panic(err.Error())
+		}
+		b.WriteRune(chars[num.Int64()])
+	}
+	return b.String()
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the server from
+func (server *PostgresFlexibleServer) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(server.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (server *PostgresFlexibleServer) BaseConstructRefs() construct.BaseConstructSet {
+	return server.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (server *PostgresFlexibleServer) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     POSTGRES_FLEXIBLE_SERVER_TYPE,
+		Name:     server.Name,
+	}
+}
+
+func (server *PostgresFlexibleServer) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	REDIS_CACHE_TYPE = "redis_cache"
+
+	// PASSWORD_IAC_VALUE is the primary access key of a cache
+	PASSWORD_IAC_VALUE = "password"
+
+	defaultRedisSkuName  = "Basic"
+	defaultRedisFamily   = "C"
+	defaultRedisCapacity = 0
+)
+
+type (
+	// RedisCache is an Azure Cache for Redis instance which backs the redis_node persist it was expanded from. Clients
+	// connect to it over TLS with its primary access key.
+	RedisCache struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+		SkuName       string
+		Family        string
+		Capacity      int
+	}
+)
+
+func (cache *RedisCache) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if cache.SkuName == "" {
+		cache.SkuName = defaultRedisSkuName
+		cache.Family = defaultRedisFamily
+		cache.Capacity = defaultRedisCapacity
+	}
+	return nil
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the cache from
+func (cache *RedisCache) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(cache.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (cache *RedisCache) BaseConstructRefs() construct.BaseConstructSet {
+	return cache.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (cache *RedisCache) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     REDIS_CACHE_TYPE,
+		Name:     cache.Name,
+	}
+}
+
+func (cache *RedisCache) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
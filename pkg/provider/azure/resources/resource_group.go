@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	RESOURCE_GROUP_TYPE = "resource_group"
+)
+
+type (
+	// ResourceGroup is the resource group which the app's resources are created in, in the location of the pulumi
+	// stack's `azure-native` configuration.
+	ResourceGroup struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+	}
+)
+
+// ResourceName returns the name which pulumi derives the physical name of the resource group from
+func (rg *ResourceGroup) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(rg.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rg *ResourceGroup) BaseConstructRefs() construct.BaseConstructSet {
+	return rg.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rg *ResourceGroup) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     RESOURCE_GROUP_TYPE,
+		Name:     rg.Name,
+	}
+}
+
+func (rg *ResourceGroup) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
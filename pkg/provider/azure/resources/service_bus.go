@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	SERVICE_BUS_NAMESPACE_TYPE    = "service_bus_namespace"
+	SERVICE_BUS_TOPIC_TYPE        = "service_bus_topic"
+	SERVICE_BUS_SUBSCRIPTION_TYPE = "service_bus_subscription"
+
+	// SERVICE_BUS_HOST_IAC_VALUE is the fully qualified host of the namespace of a topic
+	SERVICE_BUS_HOST_IAC_VALUE = "service_bus_host"
+	// TOPIC_SUBSCRIPTION_IAC_VALUE is the topic and name of a subscription, separated by a slash
+	TOPIC_SUBSCRIPTION_IAC_VALUE = "topic_subscription"
+)
+
+type (
+	// ServiceBusNamespace is the Service Bus namespace of the app's topics
+	ServiceBusNamespace struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+	}
+
+	// ServiceBusTopic is the Service Bus topic which the events of a pubsub construct are published to
+	ServiceBusTopic struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Namespace     *ServiceBusNamespace
+	}
+
+	// ServiceBusSubscription is the subscription of a subscriber to a topic, which the container app dispatcher
+	// receives the events of the topic from
+	ServiceBusSubscription struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Topic         *ServiceBusTopic
+	}
+)
+
+// ResourceGroup returns the resource group of the topic's namespace
+func (topic *ServiceBusTopic) ResourceGroup() *ResourceGroup {
+	if topic.Namespace == nil {
+		return nil
+	}
+	return topic.Namespace.ResourceGroup
+}
+
+// Namespace returns the namespace of the subscription's topic
+func (sub *ServiceBusSubscription) Namespace() *ServiceBusNamespace {
+	if sub.Topic == nil {
+		return nil
+	}
+	return sub.Topic.Namespace
+}
+
+// ResourceGroup returns the resource group of the namespace of the subscription's topic
+func (sub *ServiceBusSubscription) ResourceGroup() *ResourceGroup {
+	if sub.Topic == nil {
+		return nil
+	}
+	return sub.Topic.ResourceGroup()
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the namespace from
+func (namespace *ServiceBusNamespace) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(namespace.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (namespace *ServiceBusNamespace) BaseConstructRefs() construct.BaseConstructSet {
+	return namespace.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (namespace *ServiceBusNamespace) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     SERVICE_BUS_NAMESPACE_TYPE,
+		Name:     namespace.Name,
+	}
+}
+
+func (namespace *ServiceBusNamespace) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the topic from
+func (topic *ServiceBusTopic) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(topic.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (topic *ServiceBusTopic) BaseConstructRefs() construct.BaseConstructSet {
+	return topic.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (topic *ServiceBusTopic) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     SERVICE_BUS_TOPIC_TYPE,
+		Name:     topic.Name,
+	}
+}
+
+func (topic *ServiceBusTopic) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the subscription from
+func (sub *ServiceBusSubscription) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(sub.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (sub *ServiceBusSubscription) BaseConstructRefs() construct.BaseConstructSet {
+	return sub.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (sub *ServiceBusSubscription) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     SERVICE_BUS_SUBSCRIPTION_TYPE,
+		Name:     sub.Name,
+	}
+}
+
+func (sub *ServiceBusSubscription) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ServiceBusSubscriptionNamespace(t *testing.T) {
+	group := &ResourceGroup{Name: "app"}
+	namespace := &ServiceBusNamespace{Name: "app", ResourceGroup: group}
+
+	tests := []struct {
+		name          string
+		sub           *ServiceBusSubscription
+		wantNamespace *ServiceBusNamespace
+		wantGroup     *ResourceGroup
+	}{
+		{
+			name:          "namespace of its topic",
+			sub:           &ServiceBusSubscription{Name: "events-api", Topic: &ServiceBusTopic{Name: "events", Namespace: namespace}},
+			wantNamespace: namespace,
+			wantGroup:     group,
+		},
+		{
+			name: "topic without a namespace",
+			sub:  &ServiceBusSubscription{Name: "events-api", Topic: &ServiceBusTopic{Name: "events"}},
+		},
+		{
+			name: "no topic",
+			sub:  &ServiceBusSubscription{Name: "events-api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.wantNamespace, tt.sub.Namespace())
+			assert.Equal(tt.wantGroup, tt.sub.ResourceGroup())
+		})
+	}
+}
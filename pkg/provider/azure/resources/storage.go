@@ -0,0 +1,138 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/azure"
+)
+
+const (
+	STORAGE_ACCOUNT_TYPE   = "storage_account"
+	STORAGE_CONTAINER_TYPE = "storage_container"
+
+	// WEB_HOST_IAC_VALUE is the host of the static website endpoint of a storage account
+	WEB_HOST_IAC_VALUE = "web_host"
+
+	defaultIndexDocument = "index.html"
+)
+
+type (
+	// StorageAccount is a storage account, which either holds a container of the app or the packages and state of its
+	// function apps
+	StorageAccount struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		ResourceGroup *ResourceGroup
+	}
+
+	// StorageContainer is a blob container, which either backs an fs of the app or serves the files of a static unit.
+	// The files of a static unit are uploaded to the static website of the container's account, which a Front Door
+	// endpoint serves over https.
+	StorageContainer struct {
+		Name           string
+		ConstructRefs  construct.BaseConstructSet `yaml:"-"`
+		StorageAccount *StorageAccount
+		StaticWebsite  bool
+		IndexDocument  string
+		SourceDir      string
+	}
+)
+
+// MakeOperational enables the static website of the container's account when it was expanded from a static unit, and
+// creates the Front Door endpoint which serves it.
+func (container *StorageContainer) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if container.StaticWebsite || container.StorageAccount == nil {
+		return nil
+	}
+	for _, ref := range container.ConstructRefs {
+		unit, ok := ref.(*types.StaticUnit)
+		if !ok {
+			continue
+		}
+		container.StaticWebsite = true
+		container.SourceDir = unit.Name
+		container.IndexDocument = unit.IndexDocument
+		if container.IndexDocument == "" {
+			container.IndexDocument = defaultIndexDocument
+		}
+
+		endpoint := &FrontDoorEndpoint{
+			Name:          container.Name + "-cdn",
+			ConstructRefs: container.ConstructRefs.Clone(),
+			Profile:       frontDoorProfile(dag, container.StorageAccount.ResourceGroup, container.ConstructRefs),
+			Routes: []FrontDoorRoute{{
+				Name:       container.Name,
+				Patterns:   []string{"/*"},
+				OriginHost: construct.IaCValue{ResourceId: container.StorageAccount.Id(), Property: WEB_HOST_IAC_VALUE},
+			}},
+		}
+		if unit.Domain != nil {
+			endpoint.CustomDomain = unit.Domain.Name
+		}
+		dag.AddDependenciesReflect(endpoint)
+		dag.AddDependency(endpoint, container)
+		return nil
+	}
+	return nil
+}
+
+// ResourceGroup returns the resource group of the container's account
+func (container *StorageContainer) ResourceGroup() *ResourceGroup {
+	if container.StorageAccount == nil {
+		return nil
+	}
+	return container.StorageAccount.ResourceGroup
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the account from
+func (account *StorageAccount) ResourceName() string {
+	return azure.StorageAccountSanitizer.Apply(account.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (account *StorageAccount) BaseConstructRefs() construct.BaseConstructSet {
+	return account.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (account *StorageAccount) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     STORAGE_ACCOUNT_TYPE,
+		Name:     account.Name,
+	}
+}
+
+func (account *StorageAccount) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the container from
+func (container *StorageContainer) ResourceName() string {
+	return azure.ResourceNameSanitizer.Apply(container.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (container *StorageContainer) BaseConstructRefs() construct.BaseConstructSet {
+	return container.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (container *StorageContainer) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.AZURE,
+		Type:     STORAGE_CONTAINER_TYPE,
+		Name:     container.Name,
+	}
+}
+
+func (container *StorageContainer) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
@@ -0,0 +1,49 @@
+package gcp
+
+import (
+	"github.com/klothoplatform/klotho/pkg/config"
+)
+
+// Enums for the types we allow in the gcp provider so that we can reuse the same string within the provider
+const (
+	CloudRun       = "cloud_run"
+	CloudFunctions = "cloud_functions"
+	CloudSql       = "cloud_sql"
+	Memorystore    = "memorystore"
+	Firestore      = "firestore"
+	Gcs            = "gcs"
+	SecretManager  = "secret_manager"
+	PubSub         = "pubsub"
+	LoadBalancer   = "load_balancer"
+)
+
+var defaultConfig = config.Defaults{
+	ExecutionUnit: config.KindDefaults{
+		Type: CloudRun,
+	},
+	Expose: config.KindDefaults{
+		Type: LoadBalancer,
+	},
+	PubSub: config.KindDefaults{
+		Type: PubSub,
+	},
+	PersistKv: config.KindDefaults{
+		Type: Firestore,
+	},
+	PersistFs: config.KindDefaults{
+		Type: Gcs,
+	},
+	PersistSecrets: config.KindDefaults{
+		Type: SecretManager,
+	},
+	PersistOrm: config.KindDefaults{
+		Type: CloudSql,
+	},
+	PersistRedisNode: config.KindDefaults{
+		Type: Memorystore,
+	},
+}
+
+func (g *GCP) GetDefaultConfig() config.Defaults {
+	return defaultConfig
+}
@@ -0,0 +1,76 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+)
+
+var CloudRunKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.CloudRunService, *resources.SqlDatabaseInstance]{
+		Configure: func(service *resources.CloudRunService, instance *resources.SqlDatabaseInstance, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			service.AddSqlInstance(instance)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.CloudFunction, *resources.SqlDatabaseInstance]{
+		Configure: func(function *resources.CloudFunction, instance *resources.SqlDatabaseInstance, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return fmt.Errorf("cloud function %s cannot connect to cloud sql instance %s, use a cloud run service for its execution unit", function.Name, instance.Name)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.CloudRunService, *resources.PubsubTopic]{
+		Configure: func(service *resources.CloudRunService, topic *resources.PubsubTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setTopicEnvironmentVariable(service, topic)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.CloudFunction, *resources.PubsubTopic]{
+		Configure: func(function *resources.CloudFunction, topic *resources.PubsubTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			return setTopicEnvironmentVariable(function, topic)
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.PubsubTopic, *resources.PubsubSubscription]{
+		Configure: func(topic *resources.PubsubTopic, sub *resources.PubsubSubscription, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sub.Topic = topic
+			return nil
+		},
+		DeploymentOrderReversed: true,
+	},
+	knowledgebase.EdgeBuilder[*resources.PubsubSubscription, *resources.CloudRunService]{
+		Configure: func(sub *resources.PubsubSubscription, service *resources.CloudRunService, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			topics := construct.GetUpstreamResourcesOfType[*resources.PubsubTopic](dag, sub)
+			if len(topics) != 1 {
+				return fmt.Errorf("subscription %s must have exactly one topic, found %d", sub.Id(), len(topics))
+			}
+			sub.Topic = topics[0]
+			ps, err := pubsubConstruct(sub.Topic)
+			if err != nil {
+				return err
+			}
+			// the dispatcher pulls the subscription in the background, which needs cpu outside of requests
+			service.CpuAlwaysAllocated = true
+			service.SetEnvironmentVariable(
+				resources.PubsubSubscriptionEnvVarName(ps.Name),
+				construct.IaCValue{ResourceId: sub.Id(), Property: resources.NAME_IAC_VALUE},
+			)
+			if sa := service.GetServiceAccount(); sa != nil {
+				sa.AddRole("roles/pubsub.subscriber")
+			}
+			return nil
+		},
+		DeploymentOrderReversed: true,
+	},
+)
+
+func setTopicEnvironmentVariable(res resources.ComputeResource, topic *resources.PubsubTopic) error {
+	ps, err := pubsubConstruct(topic)
+	if err != nil {
+		return err
+	}
+	res.SetEnvironmentVariable(
+		resources.PubsubTopicEnvVarName(ps.Name),
+		construct.IaCValue{ResourceId: topic.Id(), Property: resources.NAME_IAC_VALUE},
+	)
+	return nil
+}
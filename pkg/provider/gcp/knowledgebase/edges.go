@@ -0,0 +1,28 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+)
+
+func GetGcpKnowledgeBase() (knowledgebase.EdgeKB, error) {
+	kbsToUse := []knowledgebase.EdgeKB{
+		CloudRunKB,
+		IamKB,
+		LoadBalancerKB,
+	}
+	return knowledgebase.MergeKBs(kbsToUse)
+}
+
+// pubsubConstruct returns the pubsub construct which the topic was expanded from
+func pubsubConstruct(topic *resources.PubsubTopic) (*types.PubSub, error) {
+	for _, ref := range topic.ConstructRefs {
+		if ps, ok := ref.(*types.PubSub); ok {
+			return ps, nil
+		}
+	}
+	return nil, fmt.Errorf("topic %s was not expanded from a pubsub", topic.Id())
+}
@@ -0,0 +1,181 @@
+package knowledgebase
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfigureEdge(t *testing.T) {
+	ps := &types.PubSub{Name: "events"}
+	topic := func() *resources.PubsubTopic {
+		return &resources.PubsubTopic{Name: "events", ConstructRefs: construct.BaseConstructSetOf(ps)}
+	}
+	cases := []struct {
+		name     string
+		source   construct.Resource
+		dest     construct.Resource
+		existing []construct.Resource
+		check    func(assert *assert.Assertions, source construct.Resource, dest construct.Resource)
+		wantErr  string
+	}{
+		{
+			name:   "cloud run service mounts sql instance",
+			source: &resources.CloudRunService{Name: "api"},
+			dest:   &resources.SqlDatabaseInstance{Name: "db"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal([]*resources.SqlDatabaseInstance{dest.(*resources.SqlDatabaseInstance)}, source.(*resources.CloudRunService).SqlInstances)
+			},
+		},
+		{
+			name:    "cloud function cannot use sql instance",
+			source:  &resources.CloudFunction{Name: "api"},
+			dest:    &resources.SqlDatabaseInstance{Name: "db"},
+			wantErr: "cloud function api cannot connect to cloud sql instance db, use a cloud run service for its execution unit",
+		},
+		{
+			name:   "cloud run service publishes to topic",
+			source: &resources.CloudRunService{Name: "api"},
+			dest:   topic(),
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(map[string]construct.IaCValue{
+					resources.PubsubTopicEnvVarName(ps.Name): {ResourceId: dest.Id(), Property: resources.NAME_IAC_VALUE},
+				}, source.(*resources.CloudRunService).EnvironmentVariables)
+			},
+		},
+		{
+			name:   "cloud function publishes to topic",
+			source: &resources.CloudFunction{Name: "api"},
+			dest:   topic(),
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(map[string]construct.IaCValue{
+					resources.PubsubTopicEnvVarName(ps.Name): {ResourceId: dest.Id(), Property: resources.NAME_IAC_VALUE},
+				}, source.(*resources.CloudFunction).EnvironmentVariables)
+			},
+		},
+		{
+			name:    "topic not expanded from a pubsub",
+			source:  &resources.CloudRunService{Name: "api"},
+			dest:    &resources.PubsubTopic{Name: "events"},
+			wantErr: "was not expanded from a pubsub",
+		},
+		{
+			name:   "topic of subscription",
+			source: topic(),
+			dest:   &resources.PubsubSubscription{Name: "events-api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(source, dest.(*resources.PubsubSubscription).Topic)
+			},
+		},
+		{
+			name:     "subscription pushes to cloud run service",
+			source:   &resources.PubsubSubscription{Name: "events-api"},
+			dest:     &resources.CloudRunService{Name: "api", ServiceAccount: &resources.ServiceAccount{Name: "api"}},
+			existing: []construct.Resource{topic()},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				sub := source.(*resources.PubsubSubscription)
+				service := dest.(*resources.CloudRunService)
+				assert.NotNil(sub.Topic)
+				assert.True(service.CpuAlwaysAllocated)
+				assert.Equal(map[string]construct.IaCValue{
+					resources.PubsubSubscriptionEnvVarName(ps.Name): {ResourceId: sub.Id(), Property: resources.NAME_IAC_VALUE},
+				}, service.EnvironmentVariables)
+				assert.Equal([]string{"roles/pubsub.subscriber"}, service.ServiceAccount.Roles)
+			},
+		},
+		{
+			name:    "subscription without a topic",
+			source:  &resources.PubsubSubscription{Name: "events-api"},
+			dest:    &resources.CloudRunService{Name: "api"},
+			wantErr: "must have exactly one topic, found 0",
+		},
+		{
+			name:   "service account uses sql instance",
+			source: &resources.ServiceAccount{Name: "api"},
+			dest:   &resources.SqlDatabaseInstance{Name: "db"},
+			check:  hasRoles("roles/cloudsql.client"),
+		},
+		{
+			name:   "service account uses firestore",
+			source: &resources.ServiceAccount{Name: "api"},
+			dest:   &resources.FirestoreDatabase{Name: "kv"},
+			check:  hasRoles("roles/datastore.user"),
+		},
+		{
+			name:   "service account uses bucket",
+			source: &resources.ServiceAccount{Name: "api"},
+			dest:   &resources.StorageBucket{Name: "fs"},
+			check:  hasRoles("roles/storage.objectAdmin"),
+		},
+		{
+			name:   "service account uses secrets",
+			source: &resources.ServiceAccount{Name: "api"},
+			dest:   &resources.SecretManagerSecrets{Name: "secrets"},
+			check:  hasRoles("roles/secretmanager.secretAccessor"),
+		},
+		{
+			name:   "service account publishes to topic",
+			source: &resources.ServiceAccount{Name: "api"},
+			dest:   topic(),
+			check:  hasRoles("roles/pubsub.publisher"),
+		},
+		{
+			name:   "backend service of network endpoint group",
+			source: &resources.BackendService{Name: "gw"},
+			dest:   &resources.RegionNetworkEndpointGroup{Name: "api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal([]*resources.RegionNetworkEndpointGroup{dest.(*resources.RegionNetworkEndpointGroup)}, source.(*resources.BackendService).Backends)
+			},
+		},
+		{
+			name:   "network endpoint group of cloud run service",
+			source: &resources.RegionNetworkEndpointGroup{Name: "api"},
+			dest:   &resources.CloudRunService{Name: "api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(dest, source.(*resources.RegionNetworkEndpointGroup).CloudRun)
+				assert.True(dest.(*resources.CloudRunService).AllowUnauthenticated)
+			},
+		},
+		{
+			name:   "network endpoint group of cloud function",
+			source: &resources.RegionNetworkEndpointGroup{Name: "api"},
+			dest:   &resources.CloudFunction{Name: "api"},
+			check: func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+				assert.Equal(dest, source.(*resources.RegionNetworkEndpointGroup).CloudFunction)
+				assert.True(dest.(*resources.CloudFunction).AllowUnauthenticated)
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			kb, err := GetGcpKnowledgeBase()
+			if !assert.NoError(err) {
+				return
+			}
+			dag := construct.NewResourceGraph()
+			dag.AddDependency(tt.source, tt.dest)
+			for _, res := range tt.existing {
+				dag.AddDependency(res, tt.source)
+			}
+			err = kb.ConfigureEdge(dag.GetDependency(tt.source.Id(), tt.dest.Id()), dag)
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			tt.check(assert, tt.source, tt.dest)
+		})
+	}
+}
+
+func hasRoles(roles ...string) func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+	return func(assert *assert.Assertions, source construct.Resource, dest construct.Resource) {
+		assert.Equal(roles, source.(*resources.ServiceAccount).Roles)
+	}
+}
@@ -0,0 +1,156 @@
+package knowledgebase_test
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine"
+	"github.com/klothoplatform/klotho/pkg/engine/constraints"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Expansion(t *testing.T) {
+	kv := &types.Kv{Name: "events"}
+	fs := &types.Fs{Name: "uploads"}
+	orm := &types.Orm{Name: "db"}
+	secret := &types.Secrets{Name: "sec", Secrets: []string{"api-key.txt"}}
+
+	cases := []struct {
+		name         string
+		unitType     string
+		dependencies []construct.Construct
+		want         []string
+		check        func(assert *assert.Assertions, dag *construct.ResourceGraph)
+		wantErr      string
+	}{
+		{
+			name:         "cloud run service",
+			unitType:     resources.CLOUD_RUN_SERVICE_TYPE,
+			dependencies: []construct.Construct{kv, fs, orm, secret},
+			want: []string{
+				"artifact_registry_image",
+				"artifact_registry_repository",
+				"backend_service",
+				"cloud_run_service",
+				"firestore_database",
+				"global_address",
+				"global_forwarding_rule",
+				"region_network_endpoint_group",
+				"secret_manager_secrets",
+				"service_account",
+				"sql_database_instance",
+				"storage_bucket",
+				"target_http_proxy",
+				"url_map",
+			},
+			check: func(assert *assert.Assertions, dag *construct.ResourceGraph) {
+				services := construct.GetResources[*resources.CloudRunService](dag)
+				if !assert.Len(services, 1) {
+					return
+				}
+				service := services[0]
+				assert.True(service.AllowUnauthenticated)
+				assert.Len(service.SqlInstances, 1)
+				assert.NotNil(service.Image)
+				if assert.NotNil(service.ServiceAccount) {
+					assert.ElementsMatch([]string{
+						"roles/cloudsql.client",
+						"roles/datastore.user",
+						"roles/storage.objectAdmin",
+						"roles/secretmanager.secretAccessor",
+					}, service.ServiceAccount.Roles)
+				}
+				secrets := construct.GetResources[*resources.SecretManagerSecrets](dag)
+				if assert.Len(secrets, 1) {
+					assert.Equal([]resources.ManagedSecret{{Path: "api-key.txt", SecretId: resources.SecretId("app", "api-key.txt")}}, secrets[0].Secrets)
+				}
+			},
+		},
+		{
+			name:         "cloud function",
+			unitType:     resources.CLOUD_FUNCTION_TYPE,
+			dependencies: []construct.Construct{kv, fs},
+			want: []string{
+				"backend_service",
+				"cloud_function",
+				"firestore_database",
+				"global_address",
+				"global_forwarding_rule",
+				"region_network_endpoint_group",
+				"service_account",
+				"storage_bucket",
+				"storage_bucket",
+				"storage_bucket_object",
+				"target_http_proxy",
+				"url_map",
+			},
+			check: func(assert *assert.Assertions, dag *construct.ResourceGraph) {
+				functions := construct.GetResources[*resources.CloudFunction](dag)
+				if !assert.Len(functions, 1) {
+					return
+				}
+				function := functions[0]
+				assert.True(function.AllowUnauthenticated)
+				assert.Equal("nodejs18", function.Runtime)
+				if assert.NotNil(function.ServiceAccount) {
+					assert.ElementsMatch([]string{"roles/datastore.user", "roles/storage.objectAdmin"}, function.ServiceAccount.Roles)
+				}
+			},
+		},
+		{
+			name:         "cloud function cannot use sql",
+			unitType:     resources.CLOUD_FUNCTION_TYPE,
+			dependencies: []construct.Construct{orm},
+			wantErr:      "cannot connect to cloud sql instance",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			kb, err := knowledgebase.GetGcpKnowledgeBase()
+			if !assert.NoError(err) {
+				return
+			}
+			g := &gcp.GCP{AppName: "app"}
+			e := engine.NewEngine(map[string]provider.Provider{g.Name(): g}, kb, types.ListAllConstructs())
+
+			unit := &types.ExecutionUnit{Name: "api"}
+			unit.Add(&io.RawFile{FPath: "package.json"})
+			gw := &types.Gateway{Name: "gw"}
+			cg := construct.NewConstructGraph()
+			cg.AddConstruct(unit)
+			for _, c := range tt.dependencies {
+				cg.AddConstruct(c)
+				cg.AddDependency(unit.Id(), c.Id())
+			}
+			cg.AddConstruct(gw)
+			cg.AddDependency(gw.Id(), unit.Id())
+			e.LoadContext(cg, map[constraints.ConstraintScope][]constraints.Constraint{
+				constraints.ConstructConstraintScope: {
+					&constraints.ConstructConstraint{Operator: constraints.EqualsConstraintOperator, Target: unit.Id(), Type: tt.unitType},
+				},
+			}, "app")
+			dag, err := e.Run()
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			var got []string
+			for _, res := range dag.ListResources() {
+				assert.Equal(g.Name(), res.Id().Provider)
+				got = append(got, res.Id().Type)
+			}
+			assert.ElementsMatch(tt.want, got)
+			tt.check(assert, dag)
+		})
+	}
+}
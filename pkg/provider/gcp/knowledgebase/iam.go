@@ -0,0 +1,42 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+)
+
+// IamKB grants the service accounts of compute resources the roles they need to use the resources they depend on.
+// Roles are granted on the project, so a unit can use every resource of a type once it uses one of them.
+var IamKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.SqlDatabaseInstance]{
+		Configure: func(sa *resources.ServiceAccount, instance *resources.SqlDatabaseInstance, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sa.AddRole("roles/cloudsql.client")
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.FirestoreDatabase]{
+		Configure: func(sa *resources.ServiceAccount, db *resources.FirestoreDatabase, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sa.AddRole("roles/datastore.user")
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.StorageBucket]{
+		Configure: func(sa *resources.ServiceAccount, bucket *resources.StorageBucket, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sa.AddRole("roles/storage.objectAdmin")
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.SecretManagerSecrets]{
+		Configure: func(sa *resources.ServiceAccount, secrets *resources.SecretManagerSecrets, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sa.AddRole("roles/secretmanager.secretAccessor")
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.PubsubTopic]{
+		Configure: func(sa *resources.ServiceAccount, topic *resources.PubsubTopic, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			sa.AddRole("roles/pubsub.publisher")
+			return nil
+		},
+	},
+)
@@ -0,0 +1,32 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+)
+
+var LoadBalancerKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.UrlMap, *resources.BackendService]{},
+	knowledgebase.EdgeBuilder[*resources.BackendService, *resources.RegionNetworkEndpointGroup]{
+		Configure: func(backend *resources.BackendService, neg *resources.RegionNetworkEndpointGroup, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			backend.AddBackend(neg)
+			return nil
+		},
+	},
+	// the load balancer invokes its serverless backends without credentials, so they must allow unauthenticated requests
+	knowledgebase.EdgeBuilder[*resources.RegionNetworkEndpointGroup, *resources.CloudRunService]{
+		Configure: func(neg *resources.RegionNetworkEndpointGroup, service *resources.CloudRunService, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			neg.CloudRun = service
+			service.AllowUnauthenticated = true
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.RegionNetworkEndpointGroup, *resources.CloudFunction]{
+		Configure: func(neg *resources.RegionNetworkEndpointGroup, function *resources.CloudFunction, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			neg.CloudFunction = function
+			function.AllowUnauthenticated = true
+			return nil
+		},
+	},
+)
@@ -0,0 +1,87 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOperationalTemplates(t *testing.T) {
+	assert := assert.New(t)
+	g := &GCP{AppName: "app"}
+
+	templates := g.GetOperationalTemplates()
+	for id, template := range templates {
+		assert.Equal(provider.GCP, id.Provider)
+		_, err := g.CreateConstructFromId(construct.ResourceId{Provider: provider.GCP, Type: template.Type, Name: "test"}, nil)
+		assert.NoError(err, "template of %s", template.Type)
+	}
+	for _, res := range resources.ListAll() {
+		assert.Contains(templates, construct.ResourceId{Provider: provider.GCP, Type: res.Id().Type}, "resource %s has no template", res.Id().Type)
+	}
+}
+
+func Test_GetEdgeTemplates(t *testing.T) {
+	assert := assert.New(t)
+	g := &GCP{AppName: "app"}
+
+	templates := g.GetEdgeTemplates()
+	assert.NotEmpty(templates)
+	for key, template := range templates {
+		for _, id := range []construct.ResourceId{template.Source, template.Destination} {
+			assert.Equal(provider.GCP, id.Provider, key)
+			res, err := g.CreateConstructFromId(construct.ResourceId{Provider: id.Provider, Type: id.Type, Name: "test"}, nil)
+			if assert.NoError(err, key) {
+				assert.Equal("test", res.Id().Name, key)
+			}
+		}
+		for _, res := range template.Expansion.Resources {
+			_, err := g.CreateConstructFromId(res, nil)
+			assert.NoError(err, key)
+		}
+	}
+}
+
+func Test_CreateConstructFromId(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      construct.ResourceId
+		want    construct.Resource
+		wantErr bool
+	}{
+		{
+			name: "cloud run service",
+			id:   construct.ResourceId{Provider: provider.GCP, Type: resources.CLOUD_RUN_SERVICE_TYPE, Name: "api"},
+			want: &resources.CloudRunService{Name: "api"},
+		},
+		{
+			name: "pubsub topic",
+			id:   construct.ResourceId{Provider: provider.GCP, Type: resources.PUBSUB_TOPIC_TYPE, Name: "events"},
+			want: &resources.PubsubTopic{Name: "events"},
+		},
+		{
+			name:    "unknown type",
+			id:      construct.ResourceId{Provider: provider.GCP, Type: "lambda_function", Name: "api"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			g := &GCP{AppName: "app"}
+
+			got, err := g.CreateConstructFromId(tt.id, nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	ARTIFACT_REGISTRY_REPOSITORY_TYPE = "artifact_registry_repository"
+	ARTIFACT_REGISTRY_IMAGE_TYPE      = "artifact_registry_image"
+)
+
+type (
+	// ArtifactRegistryRepository is the docker repository which the images of the app's units are pushed to
+	ArtifactRegistryRepository struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		RepositoryId  string
+	}
+
+	// ArtifactRegistryImage is the image of an execution unit, built from the unit's directory with the Dockerfile
+	// that its language runtime generated.
+	ArtifactRegistryImage struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Repository    *ArtifactRegistryRepository
+		ImageName     string
+		Context       string
+		Dockerfile    string
+	}
+)
+
+// MakeOperational names the repository after the app, so that the images of each of its units share it
+func (repo *ArtifactRegistryRepository) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if repo.RepositoryId == "" {
+		repo.RepositoryId = gcp.ResourceNameSanitizer.Apply(appName)
+	}
+	return nil
+}
+
+// MakeOperational builds the image from the directory of the unit which the compute resource upstream of it runs
+func (image *ArtifactRegistryImage) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if image.Context != "" {
+		return nil
+	}
+	for _, res := range dag.GetUpstreamResources(image) {
+		compute, ok := res.(ComputeResource)
+		if !ok {
+			continue
+		}
+		unit := compute.Unit()
+		if unit == nil {
+			continue
+		}
+		image.Context = unit.Name
+		image.Dockerfile = path.Join(unit.Name, "Dockerfile")
+		image.ImageName = gcp.ResourceNameSanitizer.Apply(unit.Name)
+		return nil
+	}
+	return fmt.Errorf("artifact registry image %s has no execution unit to build", image.Name)
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the repository from
+func (repo *ArtifactRegistryRepository) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(repo.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (repo *ArtifactRegistryRepository) BaseConstructRefs() construct.BaseConstructSet {
+	return repo.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (repo *ArtifactRegistryRepository) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     ARTIFACT_REGISTRY_REPOSITORY_TYPE,
+		Name:     repo.Name,
+	}
+}
+
+func (repo *ArtifactRegistryRepository) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (image *ArtifactRegistryImage) BaseConstructRefs() construct.BaseConstructSet {
+	return image.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (image *ArtifactRegistryImage) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     ARTIFACT_REGISTRY_IMAGE_TYPE,
+		Name:     image.Name,
+	}
+}
+
+func (image *ArtifactRegistryImage) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
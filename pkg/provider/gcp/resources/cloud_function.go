@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	CLOUD_FUNCTION_TYPE = "cloud_function"
+
+	// CLOUD_FUNCTION_ENTRY_POINT is the function exported by the dispatcher of a unit which Cloud Functions invokes
+	CLOUD_FUNCTION_ENTRY_POINT = "handler"
+	// CLOUD_FUNCTION_SOURCE is the dispatcher of a unit, which the function is built from instead of the unit's main module
+	CLOUD_FUNCTION_SOURCE = "klotho_runtime/dispatcher.js"
+
+	nodejsFunctionRuntime         = "nodejs18"
+	defaultFunctionMemory         = "512M"
+	defaultFunctionTimeoutSeconds = 180
+)
+
+type (
+	// CloudFunction runs an execution unit as a 2nd gen Cloud Function, built from an archive of the unit's
+	// directory.
+	CloudFunction struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		Runtime              string
+		EntryPoint           string
+		Source               *StorageBucketObject
+		ServiceAccount       *ServiceAccount
+		EnvironmentVariables map[string]construct.IaCValue
+		BuildEnvironment     map[string]string
+		VpcConnector         *VpcAccessConnector
+		AvailableMemory      string
+		TimeoutSeconds       int
+		// AllowUnauthenticated lets anyone invoke the function, which a load balancer in front of it requires
+		AllowUnauthenticated bool
+	}
+)
+
+// MakeOperational builds the function from the dispatcher of the unit's runtime, and resolves the environment
+// variables which the runtime reads its dependencies from to the properties of the resources they were expanded to.
+func (function *CloudFunction) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	unit := function.Unit()
+	if unit == nil {
+		return fmt.Errorf("cloud function %s has no execution unit", function.Name)
+	}
+	if function.Runtime == "" {
+		runtime, err := functionRuntime(unit)
+		if err != nil {
+			return err
+		}
+		function.Runtime = runtime
+	}
+	if function.EntryPoint == "" {
+		function.EntryPoint = CLOUD_FUNCTION_ENTRY_POINT
+	}
+	if function.AvailableMemory == "" {
+		function.AvailableMemory = defaultFunctionMemory
+	}
+	if function.TimeoutSeconds == 0 {
+		function.TimeoutSeconds = defaultFunctionTimeoutSeconds
+	}
+	if function.BuildEnvironment == nil {
+		function.BuildEnvironment = map[string]string{"GOOGLE_FUNCTION_SOURCE": CLOUD_FUNCTION_SOURCE}
+	}
+	if function.EnvironmentVariables == nil {
+		function.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	ensureServiceAccountName(function, appName, unit)
+	return resolveEnvironmentVariables(dag, function, unit)
+}
+
+// functionRuntime returns the Cloud Functions runtime for the language of the unit. Only JavaScript units have a
+// dispatcher which can be invoked as a function.
+func functionRuntime(unit *types.ExecutionUnit) (string, error) {
+	for path := range unit.Files() {
+		if filepath.Base(path) == "package.json" {
+			return nodejsFunctionRuntime, nil
+		}
+	}
+	return "", fmt.Errorf("execution unit %s cannot run as a cloud function, since only javascript units are supported", unit.Name)
+}
+
+func (function *CloudFunction) Unit() *types.ExecutionUnit {
+	return executionUnit(function.ConstructRefs)
+}
+
+func (function *CloudFunction) GetServiceAccount() *ServiceAccount {
+	return function.ServiceAccount
+}
+
+func (function *CloudFunction) SetEnvironmentVariable(name string, value construct.IaCValue) {
+	if function.EnvironmentVariables == nil {
+		function.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	function.EnvironmentVariables[name] = value
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the function from
+func (function *CloudFunction) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(function.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (function *CloudFunction) BaseConstructRefs() construct.BaseConstructSet {
+	return function.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (function *CloudFunction) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     CLOUD_FUNCTION_TYPE,
+		Name:     function.Name,
+	}
+}
+
+func (function *CloudFunction) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: true,
+	}
+}
@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	CLOUD_RUN_SERVICE_TYPE = "cloud_run_service"
+
+	// CLOUD_RUN_PORT is the port which Cloud Run sends requests to, which the exposed app of a unit listens on
+	CLOUD_RUN_PORT = 3000
+)
+
+type (
+	// CloudRunService runs an execution unit as a Cloud Run service, from the image that its language runtime's
+	// Dockerfile builds.
+	CloudRunService struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		Image                *ArtifactRegistryImage
+		ServiceAccount       *ServiceAccount
+		Port                 int
+		EnvironmentVariables map[string]construct.IaCValue
+		// SqlInstances are the Cloud SQL instances which are mounted into the service's containers at /cloudsql
+		SqlInstances []*SqlDatabaseInstance
+		VpcConnector *VpcAccessConnector
+		// AllowUnauthenticated lets anyone invoke the service, which a load balancer in front of it requires
+		AllowUnauthenticated bool
+		// CpuAlwaysAllocated keeps the cpu of idle instances allocated, so that they can pull the messages of
+		// subscriptions in the background
+		CpuAlwaysAllocated bool
+	}
+)
+
+// MakeOperational resolves the environment variables which the unit's runtime reads its dependencies from to the
+// properties of the resources they were expanded to.
+func (service *CloudRunService) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	unit := service.Unit()
+	if unit == nil {
+		return fmt.Errorf("cloud run service %s has no execution unit", service.Name)
+	}
+	if service.Port == 0 {
+		service.Port = CLOUD_RUN_PORT
+	}
+	if service.EnvironmentVariables == nil {
+		service.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	ensureServiceAccountName(service, appName, unit)
+	return resolveEnvironmentVariables(dag, service, unit)
+}
+
+// AddSqlInstance mounts the Cloud SQL instance into the service's containers
+func (service *CloudRunService) AddSqlInstance(instance *SqlDatabaseInstance) {
+	if !collectionutil.Contains(service.SqlInstances, instance) {
+		service.SqlInstances = append(service.SqlInstances, instance)
+	}
+}
+
+func (service *CloudRunService) Unit() *types.ExecutionUnit {
+	return executionUnit(service.ConstructRefs)
+}
+
+func (service *CloudRunService) GetServiceAccount() *ServiceAccount {
+	return service.ServiceAccount
+}
+
+func (service *CloudRunService) SetEnvironmentVariable(name string, value construct.IaCValue) {
+	if service.EnvironmentVariables == nil {
+		service.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	service.EnvironmentVariables[name] = value
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the service from
+func (service *CloudRunService) ResourceName() string {
+	return gcp.CloudRunServiceSanitizer.Apply(service.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (service *CloudRunService) BaseConstructRefs() construct.BaseConstructSet {
+	return service.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (service *CloudRunService) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     CLOUD_RUN_SERVICE_TYPE,
+		Name:     service.Name,
+	}
+}
+
+func (service *CloudRunService) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:   true,
+		RequiresNoDownstream: true,
+	}
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	FIRESTORE_DATABASE_TYPE = "firestore_database"
+)
+
+type (
+	// FirestoreDatabase is a native mode Firestore database, which the keyvalue runtime stores each of the
+	// app's maps in as a collection
+	FirestoreDatabase struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		DatabaseName  string
+	}
+)
+
+// MakeOperational names the database after the app, since the runtime looks the database up by name
+func (db *FirestoreDatabase) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if db.DatabaseName == "" {
+		db.DatabaseName = gcp.ResourceNameSanitizer.Apply(appName + "-kv")
+	}
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (db *FirestoreDatabase) BaseConstructRefs() construct.BaseConstructSet {
+	return db.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (db *FirestoreDatabase) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     FIRESTORE_DATABASE_TYPE,
+		Name:     db.Name,
+	}
+}
+
+func (db *FirestoreDatabase) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
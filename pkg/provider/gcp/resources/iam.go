@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	SERVICE_ACCOUNT_TYPE = "service_account"
+)
+
+type (
+	// ServiceAccount is the identity which a compute resource runs as. Roles are granted to it on the project, for
+	// each of the kinds of resources which the compute resource uses.
+	ServiceAccount struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		AccountId     string
+		Roles         []string
+	}
+)
+
+// AddRole grants the role to the service account on the project
+func (sa *ServiceAccount) AddRole(role string) {
+	if !collectionutil.Contains(sa.Roles, role) {
+		sa.Roles = append(sa.Roles, role)
+	}
+}
+
+// ResourceName returns the name of the service account's resource in the generated IaC
+func (sa *ServiceAccount) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(sa.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (sa *ServiceAccount) BaseConstructRefs() construct.BaseConstructSet {
+	return sa.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (sa *ServiceAccount) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     SERVICE_ACCOUNT_TYPE,
+		Name:     sa.Name,
+	}
+}
+
+func (sa *ServiceAccount) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,31 @@
+package resources
+
+import "github.com/klothoplatform/klotho/pkg/construct"
+
+// ListAll returns an empty instance of each of the resources of the gcp provider
+func ListAll() []construct.Resource {
+	return []construct.Resource{
+		&ArtifactRegistryImage{},
+		&ArtifactRegistryRepository{},
+		&BackendService{},
+		&CloudFunction{},
+		&CloudRunService{},
+		&FirestoreDatabase{},
+		&GlobalAddress{},
+		&GlobalForwardingRule{},
+		&ManagedSslCertificate{},
+		&PubsubSubscription{},
+		&PubsubTopic{},
+		&RedisInstance{},
+		&RegionNetworkEndpointGroup{},
+		&SecretManagerSecrets{},
+		&ServiceAccount{},
+		&SqlDatabaseInstance{},
+		&StorageBucket{},
+		&StorageBucketObject{},
+		&TargetHttpProxy{},
+		&TargetHttpsProxy{},
+		&UrlMap{},
+		&VpcAccessConnector{},
+	}
+}
@@ -0,0 +1,532 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	URL_MAP_TYPE                       = "url_map"
+	BACKEND_SERVICE_TYPE               = "backend_service"
+	REGION_NETWORK_ENDPOINT_GROUP_TYPE = "region_network_endpoint_group"
+	GLOBAL_ADDRESS_TYPE                = "global_address"
+	TARGET_HTTP_PROXY_TYPE             = "target_http_proxy"
+	TARGET_HTTPS_PROXY_TYPE            = "target_https_proxy"
+	MANAGED_SSL_CERTIFICATE_TYPE       = "managed_ssl_certificate"
+	GLOBAL_FORWARDING_RULE_TYPE        = "global_forwarding_rule"
+
+	SELF_LINK_IAC_VALUE = "self_link"
+)
+
+type (
+	// UrlMap routes the requests of an external HTTPS load balancer to the backend service of the unit which
+	// handles each route of the gateway it was expanded from. Requests which match no route go to DefaultService.
+	UrlMap struct {
+		Name           string
+		ConstructRefs  construct.BaseConstructSet `yaml:"-"`
+		DefaultService *BackendService
+		RouteRules     []UrlMapRouteRule
+	}
+
+	UrlMapRouteRule struct {
+		Priority   int
+		MatchRules []UrlMapMatchRule
+		Service    construct.IaCValue
+	}
+
+	// UrlMapMatchRule matches either the full path of a request or a path template
+	UrlMapMatchRule struct {
+		FullPathMatch     string
+		PathTemplateMatch string
+	}
+
+	// BackendService balances the requests routed to it across its serverless network endpoint groups
+	BackendService struct {
+		Name                string
+		ConstructRefs       construct.BaseConstructSet `yaml:"-"`
+		LoadBalancingScheme string
+		Protocol            string
+		Backends            []*RegionNetworkEndpointGroup
+	}
+
+	// RegionNetworkEndpointGroup is a serverless network endpoint group of a Cloud Run service or Cloud Function
+	RegionNetworkEndpointGroup struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		CloudRun      *CloudRunService
+		CloudFunction *CloudFunction
+	}
+
+	// GlobalAddress is the static IP address which the forwarding rules of a load balancer listen on
+	GlobalAddress struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+	}
+
+	TargetHttpProxy struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		UrlMap        *UrlMap
+	}
+
+	TargetHttpsProxy struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		UrlMap        *UrlMap
+		Certificate   *ManagedSslCertificate
+	}
+
+	// ManagedSslCertificate is a Google-managed certificate of the custom domain of a gateway. The certificate is only
+	// provisioned once the domain's DNS records point at the load balancer's address.
+	ManagedSslCertificate struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Domains       []string
+	}
+
+	// GlobalForwardingRule forwards the requests to IpAddress on PortRange to the target proxy Target
+	GlobalForwardingRule struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		IpAddress     *GlobalAddress
+		Target        construct.IaCValue
+		PortRange     string
+	}
+)
+
+var (
+	pathParamRegex      = regexp.MustCompile(`:([^/*]+)\*`)
+	pathParamRegexNoWc  = regexp.MustCompile(`:([^/]+)`)
+	pathWildcardSegment = regexp.MustCompile(`(^|/)\*($|/)`)
+)
+
+// MakeOperational routes each route of the gateway to the backend service of the unit which handles it, and creates
+// the frontend of the load balancer which serves the url map.
+func (urlMap *UrlMap) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	gw := urlMap.GatewayConstruct()
+	if gw == nil {
+		return fmt.Errorf("url map %s has no gateway", urlMap.Name)
+	}
+	if gw.Auth != nil && gw.Auth.Type != types.GatewayAuthNone || len(gw.RouteAuth) > 0 {
+		return fmt.Errorf("gateway %s: authorizers are not supported by the gcp load balancer", gw.Name)
+	}
+
+	backends := urlMap.unitBackends(dag)
+	if len(backends) == 0 {
+		return fmt.Errorf("url map %s has no backend services", urlMap.Name)
+	}
+	rules, err := routeRules(gw.Routes, backends)
+	if err != nil {
+		return err
+	}
+	urlMap.RouteRules = rules
+	if urlMap.DefaultService == nil {
+		urlMap.DefaultService = defaultService(gw.Routes, backends)
+	}
+	dag.AddDependenciesReflect(urlMap)
+
+	urlMap.createFrontend(dag, gw)
+	return nil
+}
+
+// GatewayConstruct returns the gateway construct that the url map was expanded from, if any
+func (urlMap *UrlMap) GatewayConstruct() *types.Gateway {
+	for _, ref := range urlMap.ConstructRefs {
+		if gw, ok := ref.(*types.Gateway); ok {
+			return gw
+		}
+	}
+	return nil
+}
+
+// unitBackends returns the backend service of each execution unit which the url map routes requests to
+func (urlMap *UrlMap) unitBackends(dag *construct.ResourceGraph) map[string]*BackendService {
+	backends := make(map[string]*BackendService)
+	for _, res := range dag.GetDownstreamResources(urlMap) {
+		backend, ok := res.(*BackendService)
+		if !ok {
+			continue
+		}
+		for _, neg := range backend.Backends {
+			if unit := neg.Unit(); unit != nil {
+				backends[unit.Name] = backend
+			}
+		}
+	}
+	return backends
+}
+
+// routeRules converts the routes of a gateway into the route rules of a url map. Static paths have precedence over
+// those with parameters, since a url map evaluates its route rules in order of their priority.
+func routeRules(routes []types.Route, backends map[string]*BackendService) ([]UrlMapRouteRule, error) {
+	services := make(map[string]*BackendService)
+	for _, route := range routes {
+		backend, ok := backends[route.ExecUnitName]
+		if !ok {
+			return nil, fmt.Errorf("no backend service found for execution unit %s of route %s", route.ExecUnitName, route.Path)
+		}
+		if existing, ok := services[route.Path]; ok && existing != backend {
+			return nil, fmt.Errorf("route %s is handled by more than one execution unit, which the gcp load balancer cannot route by verb", route.Path)
+		}
+		services[route.Path] = backend
+	}
+
+	paths := make([]string, 0, len(services))
+	for path := range services {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		iStatic, jStatic := isStaticPath(paths[i]), isStaticPath(paths[j])
+		if iStatic != jStatic {
+			return iStatic
+		}
+		return paths[i] < paths[j]
+	})
+
+	var rules []UrlMapRouteRule
+	for i, path := range paths {
+		match := UrlMapMatchRule{}
+		if isStaticPath(path) {
+			match.FullPathMatch = path
+		} else {
+			match.PathTemplateMatch = convertPath(path)
+		}
+		rules = append(rules, UrlMapRouteRule{
+			Priority:   i + 1,
+			MatchRules: []UrlMapMatchRule{match},
+			Service:    construct.IaCValue{ResourceId: services[path].Id(), Property: ID_IAC_VALUE},
+		})
+	}
+	return rules, nil
+}
+
+// defaultService returns the backend service of the unit which handles the root of the gateway, falling back to
+// the first backend service by name
+func defaultService(routes []types.Route, backends map[string]*BackendService) *BackendService {
+	for _, route := range routes {
+		if route.Path == "/" {
+			if backend, ok := backends[route.ExecUnitName]; ok {
+				return backend
+			}
+		}
+	}
+	var first *BackendService
+	for _, backend := range backends {
+		if first == nil || backend.Name < first.Name {
+			first = backend
+		}
+	}
+	return first
+}
+
+func isStaticPath(path string) bool {
+	return !strings.ContainsAny(path, ":*")
+}
+
+// convertPath converts an express path into a url map path template.
+//
+// The path will be manipulated so that:
+//   - any path parameters will be replaced by a variable that matches a single segment
+//   - any wildcard path parameters will be replaced by a variable that matches the rest of the path
+//   - any wildcard segments will match the rest of the path
+func convertPath(path string) string {
+	path = pathParamRegex.ReplaceAllString(path, "{$1=**}")
+	path = pathParamRegexNoWc.ReplaceAllString(path, "{$1}")
+	path = pathWildcardSegment.ReplaceAllString(path, "$1**$2")
+	return strings.ReplaceAll(path, "//", "/")
+}
+
+// createFrontend creates the address, proxies and forwarding rules which serve the url map over http, and over https
+// for the custom domain of the gateway if it has one
+func (urlMap *UrlMap) createFrontend(dag *construct.ResourceGraph, gw *types.Gateway) {
+	refs := urlMap.ConstructRefs.Clone()
+	address := &GlobalAddress{Name: urlMap.Name + "-address", ConstructRefs: refs}
+	if existing, ok := construct.GetResource[*GlobalAddress](dag, address.Id()); ok {
+		address = existing
+	} else {
+		dag.AddResource(address)
+	}
+
+	httpProxy := &TargetHttpProxy{Name: urlMap.Name + "-http", ConstructRefs: refs, UrlMap: urlMap}
+	if _, ok := construct.GetResource[*TargetHttpProxy](dag, httpProxy.Id()); !ok {
+		dag.AddDependenciesReflect(httpProxy)
+		dag.AddDependenciesReflect(&GlobalForwardingRule{
+			Name:          urlMap.Name + "-http",
+			ConstructRefs: refs,
+			IpAddress:     address,
+			Target:        construct.IaCValue{ResourceId: httpProxy.Id(), Property: SELF_LINK_IAC_VALUE},
+			PortRange:     "80",
+		})
+	}
+
+	if gw.Domain == nil {
+		return
+	}
+	httpsProxy := &TargetHttpsProxy{Name: urlMap.Name + "-https", ConstructRefs: refs, UrlMap: urlMap}
+	if _, ok := construct.GetResource[*TargetHttpsProxy](dag, httpsProxy.Id()); ok {
+		return
+	}
+	httpsProxy.Certificate = &ManagedSslCertificate{
+		Name:          urlMap.Name + "-certificate",
+		ConstructRefs: refs,
+		Domains:       []string{gw.Domain.Name},
+	}
+	dag.AddResource(httpsProxy.Certificate)
+	dag.AddDependenciesReflect(httpsProxy)
+	dag.AddDependenciesReflect(&GlobalForwardingRule{
+		Name:          urlMap.Name + "-https",
+		ConstructRefs: refs,
+		IpAddress:     address,
+		Target:        construct.IaCValue{ResourceId: httpsProxy.Id(), Property: SELF_LINK_IAC_VALUE},
+		PortRange:     "443",
+	})
+}
+
+func (backend *BackendService) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if backend.LoadBalancingScheme == "" {
+		backend.LoadBalancingScheme = "EXTERNAL_MANAGED"
+	}
+	if backend.Protocol == "" {
+		backend.Protocol = "HTTPS"
+	}
+	if len(backend.Backends) == 0 {
+		return fmt.Errorf("backend service %s has no network endpoint groups", backend.Name)
+	}
+	return nil
+}
+
+// AddBackend adds the network endpoint group to the backends of the service, if it isn't already one of them
+func (backend *BackendService) AddBackend(neg *RegionNetworkEndpointGroup) {
+	for _, b := range backend.Backends {
+		if b == neg {
+			return
+		}
+	}
+	backend.Backends = append(backend.Backends, neg)
+}
+
+func (neg *RegionNetworkEndpointGroup) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if neg.CloudRun == nil && neg.CloudFunction == nil {
+		return fmt.Errorf("region network endpoint group %s has no serverless backend", neg.Name)
+	}
+	return nil
+}
+
+// Unit returns the execution unit of the serverless backend of the endpoint group
+func (neg *RegionNetworkEndpointGroup) Unit() *types.ExecutionUnit {
+	if neg.CloudRun != nil {
+		return neg.CloudRun.Unit()
+	}
+	if neg.CloudFunction != nil {
+		return neg.CloudFunction.Unit()
+	}
+	return nil
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the url map from
+func (urlMap *UrlMap) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(urlMap.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (urlMap *UrlMap) BaseConstructRefs() construct.BaseConstructSet {
+	return urlMap.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (urlMap *UrlMap) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     URL_MAP_TYPE,
+		Name:     urlMap.Name,
+	}
+}
+
+func (urlMap *UrlMap) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the backend service from
+func (backend *BackendService) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(backend.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (backend *BackendService) BaseConstructRefs() construct.BaseConstructSet {
+	return backend.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (backend *BackendService) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     BACKEND_SERVICE_TYPE,
+		Name:     backend.Name,
+	}
+}
+
+func (backend *BackendService) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the endpoint group from
+func (neg *RegionNetworkEndpointGroup) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(neg.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (neg *RegionNetworkEndpointGroup) BaseConstructRefs() construct.BaseConstructSet {
+	return neg.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (neg *RegionNetworkEndpointGroup) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     REGION_NETWORK_ENDPOINT_GROUP_TYPE,
+		Name:     neg.Name,
+	}
+}
+
+func (neg *RegionNetworkEndpointGroup) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the address from
+func (address *GlobalAddress) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(address.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (address *GlobalAddress) BaseConstructRefs() construct.BaseConstructSet {
+	return address.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (address *GlobalAddress) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     GLOBAL_ADDRESS_TYPE,
+		Name:     address.Name,
+	}
+}
+
+func (address *GlobalAddress) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the proxy from
+func (proxy *TargetHttpProxy) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(proxy.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (proxy *TargetHttpProxy) BaseConstructRefs() construct.BaseConstructSet {
+	return proxy.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (proxy *TargetHttpProxy) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     TARGET_HTTP_PROXY_TYPE,
+		Name:     proxy.Name,
+	}
+}
+
+func (proxy *TargetHttpProxy) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the proxy from
+func (proxy *TargetHttpsProxy) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(proxy.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (proxy *TargetHttpsProxy) BaseConstructRefs() construct.BaseConstructSet {
+	return proxy.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (proxy *TargetHttpsProxy) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     TARGET_HTTPS_PROXY_TYPE,
+		Name:     proxy.Name,
+	}
+}
+
+func (proxy *TargetHttpsProxy) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the certificate from
+func (cert *ManagedSslCertificate) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(cert.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (cert *ManagedSslCertificate) BaseConstructRefs() construct.BaseConstructSet {
+	return cert.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (cert *ManagedSslCertificate) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     MANAGED_SSL_CERTIFICATE_TYPE,
+		Name:     cert.Name,
+	}
+}
+
+func (cert *ManagedSslCertificate) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the forwarding rule from
+func (rule *GlobalForwardingRule) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(rule.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (rule *GlobalForwardingRule) BaseConstructRefs() construct.BaseConstructSet {
+	return rule.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (rule *GlobalForwardingRule) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     GLOBAL_FORWARDING_RULE_TYPE,
+		Name:     rule.Name,
+	}
+}
+
+func (rule *GlobalForwardingRule) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,149 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_convertPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/users", want: "/users"},
+		{path: "/users/:id", want: "/users/{id}"},
+		{path: "/users/:id/posts/:post", want: "/users/{id}/posts/{post}"},
+		{path: "/files/:rest*", want: "/files/{rest=**}"},
+		{path: "/static/*", want: "/static/**"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, convertPath(tt.path))
+		})
+	}
+}
+
+func Test_UrlMapMakeOperational(t *testing.T) {
+	mainBackend := backendFor("main")
+	usersBackend := backendFor("users")
+
+	tests := []struct {
+		name          string
+		gw            *types.Gateway
+		backends      []*BackendService
+		wantRules     []UrlMapRouteRule
+		wantDefault   *BackendService
+		wantResources []construct.ResourceId
+		wantErr       bool
+	}{
+		{
+			name: "routes static paths before templates",
+			gw: &types.Gateway{
+				Name: "api",
+				Routes: []types.Route{
+					{Path: "/users/:id", Verb: "get", ExecUnitName: "users"},
+					{Path: "/", Verb: "get", ExecUnitName: "main"},
+					{Path: "/users", Verb: "post", ExecUnitName: "users"},
+				},
+			},
+			backends: []*BackendService{mainBackend, usersBackend},
+			wantRules: []UrlMapRouteRule{
+				{Priority: 1, MatchRules: []UrlMapMatchRule{{FullPathMatch: "/"}}, Service: construct.IaCValue{ResourceId: mainBackend.Id(), Property: ID_IAC_VALUE}},
+				{Priority: 2, MatchRules: []UrlMapMatchRule{{FullPathMatch: "/users"}}, Service: construct.IaCValue{ResourceId: usersBackend.Id(), Property: ID_IAC_VALUE}},
+				{Priority: 3, MatchRules: []UrlMapMatchRule{{PathTemplateMatch: "/users/{id}"}}, Service: construct.IaCValue{ResourceId: usersBackend.Id(), Property: ID_IAC_VALUE}},
+			},
+			wantDefault: mainBackend,
+			wantResources: []construct.ResourceId{
+				(&GlobalAddress{Name: "api-address"}).Id(),
+				(&TargetHttpProxy{Name: "api-http"}).Id(),
+				(&GlobalForwardingRule{Name: "api-http"}).Id(),
+			},
+		},
+		{
+			name: "domain creates https frontend",
+			gw: &types.Gateway{
+				Name:   "api",
+				Routes: []types.Route{{Path: "/users", Verb: "get", ExecUnitName: "users"}},
+				Domain: &types.CustomDomain{Name: "api.example.com"},
+			},
+			backends: []*BackendService{usersBackend},
+			wantRules: []UrlMapRouteRule{
+				{Priority: 1, MatchRules: []UrlMapMatchRule{{FullPathMatch: "/users"}}, Service: construct.IaCValue{ResourceId: usersBackend.Id(), Property: ID_IAC_VALUE}},
+			},
+			wantDefault: usersBackend,
+			wantResources: []construct.ResourceId{
+				(&TargetHttpsProxy{Name: "api-https"}).Id(),
+				(&ManagedSslCertificate{Name: "api-certificate"}).Id(),
+				(&GlobalForwardingRule{Name: "api-https"}).Id(),
+			},
+		},
+		{
+			name: "same path handled by different units",
+			gw: &types.Gateway{
+				Name: "api",
+				Routes: []types.Route{
+					{Path: "/users", Verb: "get", ExecUnitName: "main"},
+					{Path: "/users", Verb: "post", ExecUnitName: "users"},
+				},
+			},
+			backends: []*BackendService{mainBackend, usersBackend},
+			wantErr:  true,
+		},
+		{
+			name:     "missing backend",
+			gw:       &types.Gateway{Name: "api", Routes: []types.Route{{Path: "/", Verb: "get", ExecUnitName: "main"}}},
+			backends: []*BackendService{usersBackend},
+			wantErr:  true,
+		},
+		{
+			name: "authorizers are unsupported",
+			gw: &types.Gateway{
+				Name:   "api",
+				Routes: []types.Route{{Path: "/", Verb: "get", ExecUnitName: "main"}},
+				Auth:   &types.GatewayAuth{Type: types.GatewayAuthJwt},
+			},
+			backends: []*BackendService{mainBackend},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			urlMap := &UrlMap{Name: "api", ConstructRefs: construct.BaseConstructSetOf(tt.gw)}
+			dag := construct.NewResourceGraph()
+			dag.AddResource(urlMap)
+			for _, backend := range tt.backends {
+				dag.AddDependency(urlMap, backend)
+			}
+
+			err := urlMap.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.wantRules, urlMap.RouteRules)
+			assert.Equal(tt.wantDefault, urlMap.DefaultService)
+			for _, id := range tt.wantResources {
+				assert.NotNil(dag.GetResource(id), "missing %s", id)
+			}
+		})
+	}
+}
+
+func backendFor(unitName string) *BackendService {
+	service := &CloudRunService{
+		Name:          "cloud-run-" + unitName,
+		ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: unitName}),
+	}
+	return &BackendService{
+		Name:     unitName,
+		Backends: []*RegionNetworkEndpointGroup{{Name: unitName, CloudRun: service}},
+	}
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	REDIS_INSTANCE_TYPE       = "redis_instance"
+	VPC_ACCESS_CONNECTOR_TYPE = "vpc_access_connector"
+
+	defaultRedisTier         = "BASIC"
+	defaultRedisMemorySizeGb = 1
+
+	// defaultConnectorNetwork is the network which Memorystore instances are created in when their network isn't set
+	defaultConnectorNetwork     = "default"
+	defaultConnectorIpCidrRange = "10.8.0.0/28"
+)
+
+type (
+	// RedisInstance is a Memorystore for Redis instance, which is only reachable from the project's network
+	RedisInstance struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Tier          string
+		MemorySizeGb  int
+	}
+
+	// VpcAccessConnector connects serverless compute resources to the project's network, so that they can reach
+	// the resources which are only reachable from within it
+	VpcAccessConnector struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Network       string
+		IpCidrRange   string
+	}
+)
+
+func (redis *RedisInstance) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if redis.Tier == "" {
+		redis.Tier = defaultRedisTier
+	}
+	if redis.MemorySizeGb == 0 {
+		redis.MemorySizeGb = defaultRedisMemorySizeGb
+	}
+	return nil
+}
+
+func (connector *VpcAccessConnector) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if connector.Network == "" {
+		connector.Network = defaultConnectorNetwork
+	}
+	if connector.IpCidrRange == "" {
+		connector.IpCidrRange = defaultConnectorIpCidrRange
+	}
+	return nil
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the instance from
+func (redis *RedisInstance) ResourceName() string {
+	return gcp.RedisInstanceSanitizer.Apply(redis.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (redis *RedisInstance) BaseConstructRefs() construct.BaseConstructSet {
+	return redis.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (redis *RedisInstance) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     REDIS_INSTANCE_TYPE,
+		Name:     redis.Name,
+	}
+}
+
+func (redis *RedisInstance) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the physical name of the connector, which is short enough that pulumi cannot add a suffix to it
+func (connector *VpcAccessConnector) ResourceName() string {
+	return gcp.VpcAccessConnectorSanitizer.Apply(connector.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (connector *VpcAccessConnector) BaseConstructRefs() construct.BaseConstructSet {
+	return connector.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (connector *VpcAccessConnector) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     VPC_ACCESS_CONNECTOR_TYPE,
+		Name:     connector.Name,
+	}
+}
+
+func (connector *VpcAccessConnector) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
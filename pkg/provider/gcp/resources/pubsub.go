@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	PUBSUB_TOPIC_TYPE        = "pubsub_topic"
+	PUBSUB_SUBSCRIPTION_TYPE = "pubsub_subscription"
+
+	defaultAckDeadlineSeconds = 60
+)
+
+type (
+	// PubsubTopic is the Pub/Sub topic which the events of a pubsub construct are published to
+	PubsubTopic struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+	}
+
+	// PubsubSubscription is a pull subscription of a subscriber to a topic, which the Cloud Run dispatcher
+	// pulls the events of the topic from
+	PubsubSubscription struct {
+		Name               string
+		ConstructRefs      construct.BaseConstructSet `yaml:"-"`
+		Topic              *PubsubTopic
+		AckDeadlineSeconds int
+	}
+)
+
+func (sub *PubsubSubscription) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if sub.AckDeadlineSeconds == 0 {
+		sub.AckDeadlineSeconds = defaultAckDeadlineSeconds
+	}
+	return nil
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the topic from
+func (topic *PubsubTopic) ResourceName() string {
+	return gcp.PubsubTopicSanitizer.Apply(topic.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (topic *PubsubTopic) BaseConstructRefs() construct.BaseConstructSet {
+	return topic.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (topic *PubsubTopic) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     PUBSUB_TOPIC_TYPE,
+		Name:     topic.Name,
+	}
+}
+
+func (topic *PubsubTopic) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the subscription from
+func (sub *PubsubSubscription) ResourceName() string {
+	return gcp.PubsubTopicSanitizer.Apply(sub.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (sub *PubsubSubscription) BaseConstructRefs() construct.BaseConstructSet {
+	return sub.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (sub *PubsubSubscription) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     PUBSUB_SUBSCRIPTION_TYPE,
+		Name:     sub.Name,
+	}
+}
+
+func (sub *PubsubSubscription) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
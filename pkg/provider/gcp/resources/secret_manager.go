@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"sort"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	SECRET_MANAGER_SECRETS_TYPE = "secret_manager_secrets"
+)
+
+type (
+	// SecretManagerSecrets is the set of Secret Manager secrets of a secrets construct. The initial version of each
+	// secret is read from the file of the same path when it exists at deploy time.
+	SecretManagerSecrets struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Secrets       []ManagedSecret
+	}
+
+	ManagedSecret struct {
+		Path     string
+		SecretId string
+	}
+)
+
+// MakeOperational creates a secret for each of the paths of the secrets construct which the resource was expanded from.
+// The ids of the secrets are derived from the paths the same way the secret runtime derives them.
+func (secrets *SecretManagerSecrets) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if len(secrets.Secrets) > 0 {
+		return nil
+	}
+	var paths []string
+	for _, ref := range secrets.ConstructRefs {
+		if s, ok := ref.(*types.Secrets); ok {
+			paths = append(paths, s.Secrets...)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		secrets.Secrets = append(secrets.Secrets, ManagedSecret{
+			Path:     path,
+			SecretId: SecretId(appName, path),
+		})
+	}
+	return nil
+}
+
+// SecretId returns the id of the Secret Manager secret for the path of a secret of the app
+func SecretId(appName string, path string) string {
+	return gcp.SecretIdSanitizer.Apply(appName + "-" + path)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (secrets *SecretManagerSecrets) BaseConstructRefs() construct.BaseConstructSet {
+	return secrets.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (secrets *SecretManagerSecrets) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     SECRET_MANAGER_SECRETS_TYPE,
+		Name:     secrets.Name,
+	}
+}
+
+func (secrets *SecretManagerSecrets) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
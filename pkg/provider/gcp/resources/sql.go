@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/io"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	SQL_DATABASE_INSTANCE_TYPE = "sql_database_instance"
+
+	// SQL_SOCKET_DIR is the directory which Cloud Run mounts the unix sockets of a service's Cloud SQL instances into
+	SQL_SOCKET_DIR = "/cloudsql"
+
+	defaultSqlDatabaseVersion = "POSTGRES_15"
+	defaultSqlTier            = "db-f1-micro"
+)
+
+type (
+	// SqlDatabaseInstance is a Cloud SQL for PostgreSQL instance which backs the orm it was expanded from, along with the
+	// database and user that the orm connects with. The user's credentials are generated at compile time and read by
+	// the IaC from CredentialsPath, like those of an rds instance.
+	SqlDatabaseInstance struct {
+		Name            string
+		ConstructRefs   construct.BaseConstructSet `yaml:"-"`
+		DatabaseVersion string
+		Tier            string
+		DatabaseName    string
+		CredentialsFile io.File `yaml:"-"`
+		CredentialsPath string
+	}
+)
+
+// MakeOperational names the database after the orm it was expanded from and generates the credentials of its user
+func (instance *SqlDatabaseInstance) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if instance.DatabaseVersion == "" {
+		instance.DatabaseVersion = defaultSqlDatabaseVersion
+	}
+	if instance.Tier == "" {
+		instance.Tier = defaultSqlTier
+	}
+	if instance.CredentialsPath == "" {
+		credsPath := fmt.Sprintf("secrets/%s", instance.Name)
+		instance.CredentialsFile = &io.RawFile{
+			FPath:   credsPath,
+			Content: []byte(fmt.Sprintf("{\n\"username\": \"%s\",\n\"password\": \"%s\"\n}", generateUsername(), generatePassword())),
+		}
+		instance.CredentialsPath = credsPath
+	}
+	if instance.DatabaseName != "" {
+		return nil
+	}
+	for _, ref := range instance.ConstructRefs {
+		if orm, ok := ref.(*types.Orm); ok {
+			instance.DatabaseName = aws.RdsDBNameSanitizer.Apply(orm.Name)
+			return nil
+		}
+	}
+	return fmt.Errorf("sql database instance %s has no DatabaseName", instance.Name)
+}
+
+func (instance *SqlDatabaseInstance) GetOutputFiles() []io.File {
+	return []io.File{instance.CredentialsFile}
+}
+
+// generateUsername generates a random username for the database's user, which must start with a letter
+func generateUsername() string {
+	return "klo" + randomString(9)
+}
+
+// generatePassword generates a random password for the database's user
+func generatePassword() string {
+	return randomString(16)
+}
+
+func randomString(length int) string {
+	chars := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789")
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			panic(err.Error())
+		}
+		b.WriteRune(chars[num.Int64()])
+	}
+	return b.String()
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the instance from
+func (instance *SqlDatabaseInstance) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(instance.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (instance *SqlDatabaseInstance) BaseConstructRefs() construct.BaseConstructSet {
+	return instance.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (instance *SqlDatabaseInstance) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     SQL_DATABASE_INSTANCE_TYPE,
+		Name:     instance.Name,
+	}
+}
+
+func (instance *SqlDatabaseInstance) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
@@ -0,0 +1,106 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/gcp"
+)
+
+const (
+	STORAGE_BUCKET_TYPE        = "storage_bucket"
+	STORAGE_BUCKET_OBJECT_TYPE = "storage_bucket_object"
+
+	defaultBucketLocation = "US"
+)
+
+type (
+	// StorageBucket is a Cloud Storage bucket, which either backs an fs of the app or holds the sources of its
+	// Cloud Functions
+	StorageBucket struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Location      string
+		ForceDestroy  bool
+	}
+
+	// StorageBucketObject is an archive of the directory of an execution unit, which a Cloud Function is built from
+	StorageBucketObject struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Bucket        *StorageBucket
+		Source        string
+	}
+)
+
+func (bucket *StorageBucket) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if bucket.Location == "" {
+		bucket.Location = defaultBucketLocation
+		bucket.ForceDestroy = true
+	}
+	return nil
+}
+
+// MakeOperational sets the source of the object to the directory of the unit of the function which is built from it
+func (object *StorageBucketObject) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if object.Source != "" {
+		return nil
+	}
+	for _, res := range dag.GetUpstreamResources(object) {
+		if function, ok := res.(*CloudFunction); ok {
+			if unit := function.Unit(); unit != nil {
+				object.Source = unit.Name
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("storage bucket object %s has no cloud function to archive the source of", object.Name)
+}
+
+// ResourceName returns the name which pulumi derives the physical name of the bucket from
+func (bucket *StorageBucket) ResourceName() string {
+	return gcp.ResourceNameSanitizer.Apply(bucket.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (bucket *StorageBucket) BaseConstructRefs() construct.BaseConstructSet {
+	return bucket.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (bucket *StorageBucket) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     STORAGE_BUCKET_TYPE,
+		Name:     bucket.Name,
+	}
+}
+
+func (bucket *StorageBucket) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (object *StorageBucketObject) BaseConstructRefs() construct.BaseConstructSet {
+	return object.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (object *StorageBucketObject) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.GCP,
+		Type:     STORAGE_BUCKET_OBJECT_TYPE,
+		Name:     object.Name,
+	}
+}
+
+func (object *StorageBucketObject) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+)
+
+const (
+	Ingress = "ingress"
+)
+
+var defaultConfig = config.Defaults{
+	ExecutionUnit: config.KindDefaults{
+		Type: resources.DEPLOYMENT_TYPE,
+	},
+	Expose: config.KindDefaults{
+		Type: Ingress,
+	},
+	PersistSecrets: config.KindDefaults{
+		Type: resources.SECRET_TYPE,
+	},
+	PersistOrm: config.KindDefaults{
+		Type: resources.POSTGRES_STATEFUL_SET_TYPE,
+	},
+	PersistRedisNode: config.KindDefaults{
+		Type: resources.REDIS_STATEFUL_SET_TYPE,
+	},
+}
+
+func (k *KubernetesProvider) GetDefaultConfig() config.Defaults {
+	return defaultConfig
+}
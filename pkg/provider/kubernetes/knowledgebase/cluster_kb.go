@@ -0,0 +1,98 @@
+package knowledgebase
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/kubernetes/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const secretsDirEnvVar = "KLOTHO_SECRETS_DIR"
+
+// ClusterKB holds the edges of an app which is deployed to an existing cluster, where every construct runs within the
+// cluster itself rather than on the resources of a cloud provider
+var ClusterKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.Deployment, *resources.Cluster]{
+		Configure: func(deployment *resources.Deployment, cluster *resources.Cluster, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			container, err := unitContainer(deployment)
+			if err != nil {
+				return err
+			}
+			container.Image = cluster.ImageName(data.AppName, resources.DeploymentUnit(deployment).Name)
+			container.ImagePullPolicy = corev1.PullIfNotPresent
+			container.Ports = []corev1.ContainerPort{
+				{Name: "http", ContainerPort: resources.UNIT_HTTP_PORT, Protocol: corev1.ProtocolTCP},
+				{Name: "proxy", ContainerPort: resources.UNIT_PROXY_PORT, Protocol: corev1.ProtocolTCP},
+			}
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.ServiceAccount, *resources.Cluster]{},
+	knowledgebase.EdgeBuilder[*resources.Ingress, *resources.Cluster]{},
+	knowledgebase.EdgeBuilder[*resources.PostgresStatefulSet, *resources.Cluster]{},
+	knowledgebase.EdgeBuilder[*resources.RedisStatefulSet, *resources.Cluster]{},
+	knowledgebase.EdgeBuilder[*resources.Secret, *resources.Cluster]{},
+	knowledgebase.EdgeBuilder[*resources.Ingress, *resources.Deployment]{},
+	knowledgebase.EdgeBuilder[*resources.Deployment, *resources.Deployment]{},
+	knowledgebase.EdgeBuilder[*resources.Deployment, *resources.PostgresStatefulSet]{},
+	knowledgebase.EdgeBuilder[*resources.Deployment, *resources.RedisStatefulSet]{},
+	knowledgebase.EdgeBuilder[*resources.Deployment, *resources.Secret]{
+		Configure: func(deployment *resources.Deployment, secret *resources.Secret, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			container, err := unitContainer(deployment)
+			if err != nil {
+				return err
+			}
+			volumeName := secret.ObjectName()
+			podSpec := &deployment.Object.Spec.Template.Spec
+			for _, volume := range podSpec.Volumes {
+				if volume.Name == volumeName {
+					return nil
+				}
+			}
+			// Every key of the secret is mounted as the file of the same name, and the secret's keys are its paths
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name:         volumeName,
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret.ObjectName()}},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: resources.SECRETS_DIR,
+				ReadOnly:  true,
+			})
+			setEnvVar(container, types.KLOTHO_SECRETS_BACKEND, types.KLOTHO_SECRETS_BACKEND_FILE)
+			setEnvVar(container, secretsDirEnvVar, resources.SECRETS_DIR)
+			return nil
+		},
+	},
+)
+
+// unitContainer returns the container which runs the deployment's execution unit, adding it to the deployment if it
+// does not have one yet
+func unitContainer(deployment *resources.Deployment) (*corev1.Container, error) {
+	if deployment.Object == nil {
+		return nil, fmt.Errorf("%s has no object", deployment.Id())
+	}
+	unit := resources.DeploymentUnit(deployment)
+	if unit == nil {
+		return nil, fmt.Errorf("%s has no execution unit", deployment.Id())
+	}
+	podSpec := &deployment.Object.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = []corev1.Container{{Name: resources.UnitServiceName(unit.Name)}}
+	}
+	return &podSpec.Containers[0], nil
+}
+
+// setEnvVar sets an environment variable of the container to a literal value
+func setEnvVar(container *corev1.Container, name string, value string) {
+	for i, env := range container.Env {
+		if env.Name == name {
+			container.Env[i].Value = value
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
@@ -14,8 +14,12 @@ import (
 )
 
 type (
+	// KubernetesProvider provides the resources which run an app on a kubernetes cluster. It is either the provider of
+	// the resources which run on the cluster of a cloud provider, or, when Standalone, the provider of the whole app,
+	// which it deploys to an existing cluster.
 	KubernetesProvider struct {
-		AppName string
+		AppName    string
+		Standalone bool
 	}
 )
 
@@ -23,11 +27,14 @@ func (k *KubernetesProvider) Name() string {
 	return "kubernetes"
 }
 func (k *KubernetesProvider) ListResources() []construct.Resource {
+	if k.Standalone {
+		return resources.ListClusterResources()
+	}
 	return resources.ListAll()
 }
 func (k *KubernetesProvider) CreateConstructFromId(id construct.ResourceId, dag *construct.ConstructGraph) (construct.BaseConstruct, error) {
 	typeToResource := make(map[string]construct.Resource)
-	for _, res := range resources.ListAll() {
+	for _, res := range append(resources.ListAll(), resources.ListClusterResources()...) {
 		typeToResource[res.Id().Type] = res
 	}
 	res, ok := typeToResource[id.Type]
@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/kubernetes"
+)
+
+const (
+	CLUSTER_TYPE = "cluster"
+)
+
+type (
+	// Cluster is an existing cluster which the app is deployed to, rather than one that a cloud provider creates. It is
+	// reached with the kubeconfig at Kubeconfig, or the default kubeconfig of the deployer when that is empty, using
+	// Context or the kubeconfig's current context. The images of the execution units are pushed to ImageRegistry when
+	// it is set, otherwise the cluster must be able to run the images which were built locally (such as kind or
+	// minikube clusters with the images loaded into them).
+	Cluster struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Kubeconfig    string
+		Context       string
+		Namespace     string
+		ImageRegistry string
+	}
+)
+
+// BaseConstructRefs returns a slice containing the ids of any Klotho constructs is correlated to
+func (cluster *Cluster) BaseConstructRefs() construct.BaseConstructSet { return cluster.ConstructRefs }
+
+func (cluster *Cluster) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.KUBERNETES,
+		Type:     CLUSTER_TYPE,
+		Name:     cluster.Name,
+	}
+}
+
+func (cluster *Cluster) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+// ImageName returns the image which the cluster runs the execution unit from
+func (cluster *Cluster) ImageName(appName string, unitName string) string {
+	image := fmt.Sprintf("%s-%s:latest", kubernetes.MetadataNameSanitizer.Apply(appName), kubernetes.MetadataNameSanitizer.Apply(unitName))
+	if cluster.ImageRegistry == "" {
+		return image
+	}
+	return strings.TrimSuffix(cluster.ImageRegistry, "/") + "/" + image
+}
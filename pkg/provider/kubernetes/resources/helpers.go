@@ -55,3 +55,17 @@ func ListAll() []construct.Resource {
 		&TargetGroupBinding{},
 	}
 }
+
+// ListClusterResources returns the resources which an app deployed to an existing cluster is expanded to. They run
+// everything within the cluster, so none of them depend on the resources of a cloud provider.
+func ListClusterResources() []construct.Resource {
+	return []construct.Resource{
+		&Cluster{},
+		&Deployment{},
+		&Ingress{},
+		&PostgresStatefulSet{},
+		&RedisStatefulSet{},
+		&Secret{},
+		&ServiceAccount{},
+	}
+}
@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	networking "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	INGRESS_TYPE = "ingress"
+)
+
+type (
+	// Ingress serves the routes of the gateway it was expanded from, forwarding each to the service of the unit which
+	// handles it. It is served by the cluster's ingress controller of IngressClassName, or its default ingress class
+	// when that is empty.
+	Ingress struct {
+		Name             string
+		ConstructRefs    construct.BaseConstructSet `yaml:"-"`
+		Object           *networking.Ingress
+		FilePath         string
+		Cluster          construct.ResourceId
+		IngressClassName string
+	}
+)
+
+var pathDynamicSegment = regexp.MustCompile(`(^|/)[:*]`)
+
+// MakeOperational routes the paths of the gateway to the deployments of the units which handle them. The deployments
+// are those downstream of the ingress, which the gateway's dependencies on its units were expanded to.
+func (ingress *Ingress) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if ingress.Cluster.IsZero() {
+		return fmt.Errorf("ingress %s has no cluster", ingress.Name)
+	}
+	var gw *types.Gateway
+	for _, ref := range ingress.ConstructRefs {
+		if g, ok := ref.(*types.Gateway); ok {
+			gw = g
+		}
+	}
+	if gw == nil {
+		return fmt.Errorf("ingress %s has no gateway", ingress.Name)
+	}
+	if gw.Auth != nil && gw.Auth.Type != types.GatewayAuthNone || len(gw.RouteAuth) > 0 {
+		return fmt.Errorf("gateway %s: authorizers are not supported by kubernetes ingresses", gw.Name)
+	}
+
+	deployments := make(map[string]*Deployment)
+	for _, deployment := range construct.GetDownstreamResourcesOfType[*Deployment](dag, ingress) {
+		if unit := DeploymentUnit(deployment); unit != nil {
+			deployments[unit.Name] = deployment
+		}
+	}
+	paths, err := ingressPaths(gw.Routes, deployments)
+	if err != nil {
+		return err
+	}
+
+	if ingress.Object == nil {
+		ingress.Object = &networking.Ingress{
+			TypeMeta: v1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+		}
+	}
+	SetDefaultObjectMeta(ingress, ingress.Object.GetObjectMeta())
+	if ingress.IngressClassName != "" {
+		ingress.Object.Spec.IngressClassName = &ingress.IngressClassName
+	}
+	rule := networking.IngressRule{
+		IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{Paths: paths}},
+	}
+	if gw.Domain != nil {
+		rule.Host = gw.Domain.Name
+	}
+	ingress.Object.Spec.Rules = []networking.IngressRule{rule}
+	ingress.FilePath = ManifestFilePath(ingress)
+	return nil
+}
+
+// ingressPaths returns the paths of the ingress for the routes of a gateway. Ingresses do not match paths by their
+// parameters or methods, so routes with parameters match every path under their static prefix, and each path is
+// forwarded to a single unit whatever the methods of its routes.
+func ingressPaths(routes []types.Route, deployments map[string]*Deployment) ([]networking.HTTPIngressPath, error) {
+	type ingressPath struct {
+		path     string
+		pathType networking.PathType
+	}
+	units := make(map[ingressPath]string)
+	for _, route := range routes {
+		if _, ok := deployments[route.ExecUnitName]; !ok {
+			return nil, fmt.Errorf("no deployment found for execution unit %s of route %s", route.ExecUnitName, route.Path)
+		}
+		path, pathType := convertPath(route.Path)
+		key := ingressPath{path: path, pathType: pathType}
+		if existing, ok := units[key]; ok && existing != route.ExecUnitName {
+			return nil, fmt.Errorf("route %s is handled by more than one execution unit, which kubernetes ingresses cannot route between", route.Path)
+		}
+		units[key] = route.ExecUnitName
+	}
+
+	var paths []networking.HTTPIngressPath
+	for key, unit := range units {
+		pathType := key.pathType
+		paths = append(paths, networking.HTTPIngressPath{
+			Path:     key.path,
+			PathType: &pathType,
+			Backend: networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: UnitServiceName(unit),
+					Port: networking.ServiceBackendPort{Number: UNIT_HTTP_PORT},
+				},
+			},
+		})
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Path != paths[j].Path {
+			return paths[i].Path < paths[j].Path
+		}
+		return *paths[i].PathType < *paths[j].PathType
+	})
+	return paths, nil
+}
+
+// convertPath converts an express path into the path of an ingress. Static paths match exactly, while paths with
+// parameters or wildcards match the static prefix before their first dynamic segment.
+func convertPath(path string) (string, networking.PathType) {
+	loc := pathDynamicSegment.FindStringIndex(path)
+	if loc == nil {
+		return path, networking.PathTypeExact
+	}
+	prefix := strings.TrimSuffix(path[:loc[0]], "/")
+	if prefix == "" {
+		prefix = "/"
+	}
+	return prefix, networking.PathTypePrefix
+}
+
+// BaseConstructRefs returns a slice containing the ids of any Klotho constructs is correlated to
+func (ingress *Ingress) BaseConstructRefs() construct.BaseConstructSet { return ingress.ConstructRefs }
+
+func (ingress *Ingress) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.KUBERNETES,
+		Type:     INGRESS_TYPE,
+		Name:     ingress.Name,
+	}
+}
+
+func (ingress *Ingress) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
+
+func (ingress *Ingress) GetObject() v1.Object {
+	return ingress.Object
+}
+
+func (ingress *Ingress) Kind() string {
+	return ingress.Object.Kind
+}
+
+func (ingress *Ingress) Path() string {
+	return ingress.FilePath
+}
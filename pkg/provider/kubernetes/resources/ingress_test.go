@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+)
+
+func Test_convertPath(t *testing.T) {
+	tests := []struct {
+		path         string
+		wantPath     string
+		wantPathType networking.PathType
+	}{
+		{path: "/", wantPath: "/", wantPathType: networking.PathTypeExact},
+		{path: "/users", wantPath: "/users", wantPathType: networking.PathTypeExact},
+		{path: "/users/:id", wantPath: "/users", wantPathType: networking.PathTypePrefix},
+		{path: "/users/:id/posts", wantPath: "/users", wantPathType: networking.PathTypePrefix},
+		{path: "/:id", wantPath: "/", wantPathType: networking.PathTypePrefix},
+		{path: "/files/*", wantPath: "/files", wantPathType: networking.PathTypePrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert := assert.New(t)
+			path, pathType := convertPath(tt.path)
+			assert.Equal(tt.wantPath, path)
+			assert.Equal(tt.wantPathType, pathType)
+		})
+	}
+}
+
+func Test_IngressMakeOperational(t *testing.T) {
+	main := &Deployment{Name: "deployment-main", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "main"})}
+	users := &Deployment{Name: "deployment-users", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "users"})}
+	exact := networking.PathTypeExact
+	prefix := networking.PathTypePrefix
+
+	tests := []struct {
+		name        string
+		routes      []types.Route
+		deployments []*Deployment
+		want        []networking.HTTPIngressPath
+		wantErr     bool
+	}{
+		{
+			name: "routes to the services of the units",
+			routes: []types.Route{
+				{Path: "/", Verb: "get", ExecUnitName: "main"},
+				{Path: "/users/:id", Verb: "get", ExecUnitName: "users"},
+				{Path: "/users/:id", Verb: "post", ExecUnitName: "users"},
+			},
+			deployments: []*Deployment{main, users},
+			want: []networking.HTTPIngressPath{
+				{Path: "/", PathType: &exact, Backend: serviceBackend("main")},
+				{Path: "/users", PathType: &prefix, Backend: serviceBackend("users")},
+			},
+		},
+		{
+			name: "path handled by different units",
+			routes: []types.Route{
+				{Path: "/users/:id", Verb: "get", ExecUnitName: "main"},
+				{Path: "/users/:name", Verb: "post", ExecUnitName: "users"},
+			},
+			deployments: []*Deployment{main, users},
+			wantErr:     true,
+		},
+		{
+			name:        "missing deployment",
+			routes:      []types.Route{{Path: "/", Verb: "get", ExecUnitName: "users"}},
+			deployments: []*Deployment{main},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			gw := &types.Gateway{Name: "api", Routes: tt.routes}
+			ingress := &Ingress{Name: "ingress-api", ConstructRefs: construct.BaseConstructSetOf(gw), Cluster: construct.ResourceId{Name: "cluster"}}
+			dag := construct.NewResourceGraph()
+			dag.AddResource(ingress)
+			for _, deployment := range tt.deployments {
+				dag.AddDependency(ingress, deployment)
+			}
+
+			err := ingress.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal("ingress-api", ingress.Object.Name)
+			assert.Equal(tt.want, ingress.Object.Spec.Rules[0].HTTP.Paths)
+		})
+	}
+}
+
+func serviceBackend(unit string) networking.IngressBackend {
+	return networking.IngressBackend{
+		Service: &networking.IngressServiceBackend{
+			Name: unit,
+			Port: networking.ServiceBackendPort{Number: UNIT_HTTP_PORT},
+		},
+	}
+}
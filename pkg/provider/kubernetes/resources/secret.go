@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/kubernetes"
+)
+
+const (
+	SECRET_TYPE = "secret"
+
+	// SECRETS_DIR is the directory which the secrets of a pod are mounted into
+	SECRETS_DIR = "/run/secrets"
+)
+
+type (
+	// Secret holds the secrets of the persist_secrets construct it was expanded from. It is created from the files at
+	// each of Paths, relative to the output directory, when the app is deployed so that their contents never end up in
+	// the manifests. The pods which use it mount each path at the same path within SECRETS_DIR.
+	Secret struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Paths         []string
+		Cluster       construct.ResourceId
+	}
+)
+
+// MakeOperational holds the secrets of the persist_secrets construct it was expanded from
+func (secret *Secret) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if secret.Cluster.IsZero() {
+		return fmt.Errorf("secret %s has no cluster", secret.Name)
+	}
+	if len(secret.Paths) > 0 {
+		return nil
+	}
+	for _, ref := range secret.ConstructRefs {
+		if secrets, ok := ref.(*types.Secrets); ok {
+			secret.Paths = append(secret.Paths, secrets.Secrets...)
+		}
+	}
+	if len(secret.Paths) == 0 {
+		return fmt.Errorf("secret %s has no Paths", secret.Name)
+	}
+	return nil
+}
+
+// ObjectName returns the name of the secret within the cluster
+func (secret *Secret) ObjectName() string {
+	return kubernetes.MetadataNameSanitizer.Apply(secret.Name)
+}
+
+// Key returns the key of the secret's data which holds the file at path
+func (secret *Secret) Key(path string) string {
+	return kubernetes.SecretKeySanitizer.Apply(path)
+}
+
+// BaseConstructRefs returns a slice containing the ids of any Klotho constructs is correlated to
+func (secret *Secret) BaseConstructRefs() construct.BaseConstructSet { return secret.ConstructRefs }
+
+func (secret *Secret) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.KUBERNETES,
+		Type:     SECRET_TYPE,
+		Name:     secret.Name,
+	}
+}
+
+func (secret *Secret) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,294 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+	"github.com/klothoplatform/klotho/pkg/sanitization/kubernetes"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	POSTGRES_STATEFUL_SET_TYPE = "postgres_stateful_set"
+	REDIS_STATEFUL_SET_TYPE    = "redis_stateful_set"
+
+	POSTGRES_PORT = 5432
+	REDIS_PORT    = 6379
+
+	defaultPostgresImage = "postgres:15-alpine"
+	defaultPostgresUser  = "postgres"
+	defaultRedisImage    = "redis:7-alpine"
+	defaultStorageSize   = "1Gi"
+	dataVolumeName       = "data"
+)
+
+type (
+	// StatefulService is a service which backs a persist construct from within the cluster. It runs as a stateful set
+	// of a single replica, whose data outlives the pod in a persistent volume, behind a headless service which gives the
+	// pod a stable host name. The units which use it read its properties from their environment variables.
+	StatefulService interface {
+		ManifestFile
+		ServiceObject() *corev1.Service
+		GetProperty(property string) (string, error)
+	}
+
+	// PostgresStatefulSet is a postgres database which backs the orms it was expanded from. It is only reachable from
+	// within the cluster, so its credentials are fixed rather than generated, which also keeps them the same as those
+	// its volume was initialized with when the app is compiled again.
+	PostgresStatefulSet struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Object        *apps.StatefulSet
+		FilePath      string
+		Cluster       construct.ResourceId
+		Image         string
+		DatabaseName  string
+		Username      string
+		Password      string
+		StorageSize   string
+	}
+
+	// RedisStatefulSet is a redis server which backs the redis nodes it was expanded from
+	RedisStatefulSet struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Object        *apps.StatefulSet
+		FilePath      string
+		Cluster       construct.ResourceId
+		Image         string
+		StorageSize   string
+	}
+)
+
+// MakeOperational runs the database, named after the orm it was expanded from like the database of an rds instance
+func (db *PostgresStatefulSet) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if db.Cluster.IsZero() {
+		return fmt.Errorf("postgres stateful set %s has no cluster", db.Name)
+	}
+	if db.Image == "" {
+		db.Image = defaultPostgresImage
+	}
+	if db.Username == "" {
+		db.Username = defaultPostgresUser
+	}
+	if db.Password == "" {
+		db.Password = defaultPostgresUser
+	}
+	if db.DatabaseName == "" {
+		for _, ref := range db.ConstructRefs {
+			if orm, ok := ref.(*types.Orm); ok {
+				db.DatabaseName = aws.RdsDBNameSanitizer.Apply(orm.Name)
+				break
+			}
+		}
+	}
+	if db.DatabaseName == "" {
+		return fmt.Errorf("postgres stateful set %s has no DatabaseName", db.Name)
+	}
+	if db.Object == nil {
+		set, err := newStatefulSet(db, corev1.Container{
+			Name:  "postgres",
+			Image: db.Image,
+			Env: []corev1.EnvVar{
+				{Name: "POSTGRES_USER", Value: db.Username},
+				{Name: "POSTGRES_PASSWORD", Value: db.Password},
+				{Name: "POSTGRES_DB", Value: db.DatabaseName},
+				// the volume's root holds lost+found on most storage classes, which initdb refuses to use
+				{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			},
+			Ports:        []corev1.ContainerPort{{Name: "postgres", ContainerPort: POSTGRES_PORT, Protocol: corev1.ProtocolTCP}},
+			VolumeMounts: []corev1.VolumeMount{{Name: dataVolumeName, MountPath: "/var/lib/postgresql/data"}},
+		}, db.StorageSize)
+		if err != nil {
+			return err
+		}
+		db.Object = set
+	}
+	db.FilePath = ManifestFilePath(db)
+	return nil
+}
+
+// GetProperty returns the connection string of the database, which the orm runtimes connect with
+func (db *PostgresStatefulSet) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.CONNECTION_STRING):
+		return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", db.Username, db.Password, kubernetes.MetadataNameSanitizer.Apply(db.Name), POSTGRES_PORT, db.DatabaseName), nil
+	}
+	return "", fmt.Errorf("unsupported property %s of postgres stateful set %s", property, db.Name)
+}
+
+func (db *PostgresStatefulSet) ServiceObject() *corev1.Service {
+	return headlessService(db.Object, POSTGRES_PORT)
+}
+
+// BaseConstructRefs returns a slice containing the ids of any Klotho constructs is correlated to
+func (db *PostgresStatefulSet) BaseConstructRefs() construct.BaseConstructSet {
+	return db.ConstructRefs
+}
+
+func (db *PostgresStatefulSet) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.KUBERNETES,
+		Type:     POSTGRES_STATEFUL_SET_TYPE,
+		Name:     db.Name,
+	}
+}
+
+func (db *PostgresStatefulSet) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+func (db *PostgresStatefulSet) GetObject() v1.Object {
+	return db.Object
+}
+
+func (db *PostgresStatefulSet) Kind() string {
+	return db.Object.Kind
+}
+
+func (db *PostgresStatefulSet) Path() string {
+	return db.FilePath
+}
+
+// MakeOperational runs the redis server, which persists its data with append-only files
+func (redis *RedisStatefulSet) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if redis.Cluster.IsZero() {
+		return fmt.Errorf("redis stateful set %s has no cluster", redis.Name)
+	}
+	if redis.Image == "" {
+		redis.Image = defaultRedisImage
+	}
+	if redis.Object == nil {
+		set, err := newStatefulSet(redis, corev1.Container{
+			Name:         "redis",
+			Image:        redis.Image,
+			Args:         []string{"--appendonly", "yes"},
+			Ports:        []corev1.ContainerPort{{Name: "redis", ContainerPort: REDIS_PORT, Protocol: corev1.ProtocolTCP}},
+			VolumeMounts: []corev1.VolumeMount{{Name: dataVolumeName, MountPath: "/data"}},
+		}, redis.StorageSize)
+		if err != nil {
+			return err
+		}
+		redis.Object = set
+	}
+	redis.FilePath = ManifestFilePath(redis)
+	return nil
+}
+
+// GetProperty returns the host and port which the redis runtimes connect to
+func (redis *RedisStatefulSet) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.HOST):
+		return kubernetes.MetadataNameSanitizer.Apply(redis.Name), nil
+	case string(types.PORT):
+		return strconv.Itoa(REDIS_PORT), nil
+	}
+	return "", fmt.Errorf("unsupported property %s of redis stateful set %s", property, redis.Name)
+}
+
+func (redis *RedisStatefulSet) ServiceObject() *corev1.Service {
+	return headlessService(redis.Object, REDIS_PORT)
+}
+
+// BaseConstructRefs returns a slice containing the ids of any Klotho constructs is correlated to
+func (redis *RedisStatefulSet) BaseConstructRefs() construct.BaseConstructSet {
+	return redis.ConstructRefs
+}
+
+func (redis *RedisStatefulSet) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.KUBERNETES,
+		Type:     REDIS_STATEFUL_SET_TYPE,
+		Name:     redis.Name,
+	}
+}
+
+func (redis *RedisStatefulSet) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream:     true,
+		RequiresExplicitDelete: true,
+	}
+}
+
+func (redis *RedisStatefulSet) GetObject() v1.Object {
+	return redis.Object
+}
+
+func (redis *RedisStatefulSet) Kind() string {
+	return redis.Object.Kind
+}
+
+func (redis *RedisStatefulSet) Path() string {
+	return redis.FilePath
+}
+
+// newStatefulSet returns a stateful set of a single replica of the container, with a volume claim of storageSize for
+// the container's data volume. The stateful set is governed by the headless service of the same name.
+func newStatefulSet(res construct.Resource, container corev1.Container, storageSize string) (*apps.StatefulSet, error) {
+	if storageSize == "" {
+		storageSize = defaultStorageSize
+	}
+	storage, err := resource.ParseQuantity(storageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid StorageSize %s of %s: %w", storageSize, res.Id(), err)
+	}
+	replicas := int32(1)
+	set := &apps.StatefulSet{
+		TypeMeta: v1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+	}
+	SetDefaultObjectMeta(res, set.GetObjectMeta())
+	labels := map[string]string{KLOTHO_ID_LABEL: kubernetes.LabelValueSanitizer.Apply(res.Id().String())}
+	set.Spec = apps.StatefulSetSpec{
+		ServiceName: set.Name,
+		Replicas:    &replicas,
+		Selector:    &v1.LabelSelector{MatchLabels: labels},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: v1.ObjectMeta{Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{container}},
+		},
+		VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+			ObjectMeta: v1.ObjectMeta{Name: dataVolumeName},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: storage},
+				},
+			},
+		}},
+	}
+	return set, nil
+}
+
+// headlessService returns the service which governs the stateful set, which resolves to its pod by the stateful set's
+// name within the cluster
+func headlessService(set *apps.StatefulSet, port int32) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      set.Name,
+			Namespace: set.Namespace,
+			Labels:    set.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  set.Spec.Selector.MatchLabels,
+			Ports: []corev1.ServicePort{{
+				Port:       port,
+				TargetPort: intstr.FromInt(int(port)),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
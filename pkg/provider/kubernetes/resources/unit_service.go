@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/sanitization/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// UNIT_HTTP_PORT is the port which the dispatcher of a unit serves its exposed routes on
+	UNIT_HTTP_PORT = 3000
+	// UNIT_PROXY_PORT is the port which the dispatcher of a unit serves the calls other units proxy to it on
+	UNIT_PROXY_PORT = 3001
+)
+
+// DeploymentUnit returns the execution unit which the deployment was expanded from, if any
+func DeploymentUnit(deployment *Deployment) *types.ExecutionUnit {
+	for _, ref := range deployment.ConstructRefs {
+		if unit, ok := ref.(*types.ExecutionUnit); ok {
+			return unit
+		}
+	}
+	return nil
+}
+
+// UnitServiceName returns the name of the service of an execution unit, which is also the host name that other units
+// proxy calls to it by within the cluster
+func UnitServiceName(unitName string) string {
+	return kubernetes.MetadataNameSanitizer.Apply(unitName)
+}
+
+// UnitService returns the service which routes both the exposed routes and the proxied calls of the deployment's unit
+// to its pods
+func UnitService(deployment *Deployment, unit *types.ExecutionUnit) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      UnitServiceName(unit.Name),
+			Namespace: deployment.Object.Namespace,
+			Labels:    deployment.Object.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: KlothoIdSelector(deployment.Object.Spec.Template.GetObjectMeta()),
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: UNIT_HTTP_PORT, TargetPort: intstr.FromInt(UNIT_HTTP_PORT), Protocol: corev1.ProtocolTCP},
+				{Name: "proxy", Port: UNIT_PROXY_PORT, TargetPort: intstr.FromInt(UNIT_PROXY_PORT), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
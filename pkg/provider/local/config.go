@@ -0,0 +1,44 @@
+package local
+
+import (
+	"github.com/klothoplatform/klotho/pkg/config"
+)
+
+// Enums for the types we allow in the local provider so that we can reuse the same string within the provider
+const (
+	Container     = "container"
+	Postgres      = "postgres"
+	Redis         = "redis"
+	Minio         = "minio"
+	DynamodbLocal = "dynamodb_local"
+	SecretFile    = "secret_file"
+	Nginx         = "nginx"
+)
+
+var defaultConfig = config.Defaults{
+	ExecutionUnit: config.KindDefaults{
+		Type: Container,
+	},
+	Expose: config.KindDefaults{
+		Type: Nginx,
+	},
+	PersistKv: config.KindDefaults{
+		Type: DynamodbLocal,
+	},
+	PersistFs: config.KindDefaults{
+		Type: Minio,
+	},
+	PersistSecrets: config.KindDefaults{
+		Type: SecretFile,
+	},
+	PersistOrm: config.KindDefaults{
+		Type: Postgres,
+	},
+	PersistRedisNode: config.KindDefaults{
+		Type: Redis,
+	},
+}
+
+func (l *LocalProvider) GetDefaultConfig() config.Defaults {
+	return defaultConfig
+}
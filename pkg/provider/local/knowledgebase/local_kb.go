@@ -0,0 +1,67 @@
+package knowledgebase
+
+import (
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider/local/resources"
+)
+
+const (
+	// s3EndpointEnvVar and dynamodbEndpointEnvVar override the endpoints which the AWS SDKs send requests to a service
+	// with, so that the runtimes reach the local emulators instead
+	s3EndpointEnvVar       = "AWS_ENDPOINT_URL_S3"
+	dynamodbEndpointEnvVar = "AWS_ENDPOINT_URL_DYNAMODB"
+
+	secretsDirEnvVar = "KLOTHO_SECRETS_DIR"
+)
+
+var LocalKB = knowledgebase.Build(
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.Container]{},
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.Postgres]{},
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.Redis]{},
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.Minio]{
+		Configure: func(container *resources.Container, minio *resources.Minio, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			setAwsEmulatorEnvVar(container, s3EndpointEnvVar, minio.Endpoint())
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.DynamodbLocal]{
+		Configure: func(container *resources.Container, db *resources.DynamodbLocal, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			setAwsEmulatorEnvVar(container, dynamodbEndpointEnvVar, db.Endpoint())
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.Container, *resources.SecretFile]{
+		Configure: func(container *resources.Container, secret *resources.SecretFile, dag *construct.ResourceGraph, data knowledgebase.EdgeData) error {
+			for _, s := range container.Secrets {
+				if s == secret {
+					return nil
+				}
+			}
+			container.Secrets = append(container.Secrets, secret)
+			setEnvVar(container, types.KLOTHO_SECRETS_BACKEND, types.KLOTHO_SECRETS_BACKEND_FILE)
+			setEnvVar(container, secretsDirEnvVar, resources.SECRETS_DIR)
+			return nil
+		},
+	},
+	knowledgebase.EdgeBuilder[*resources.Router, *resources.Container]{},
+)
+
+// setAwsEmulatorEnvVar points the container's AWS SDK clients of a service at the local emulator of it, with the
+// region and credentials which the emulators accept
+func setAwsEmulatorEnvVar(container *resources.Container, endpointEnvVar string, endpoint string) {
+	setEnvVar(container, endpointEnvVar, endpoint)
+	setEnvVar(container, "AWS_REGION", resources.LOCAL_REGION)
+	setEnvVar(container, "AWS_TARGET_REGION", resources.LOCAL_REGION)
+	setEnvVar(container, "AWS_ACCESS_KEY_ID", resources.LOCAL_ACCESS_KEY_ID)
+	setEnvVar(container, "AWS_SECRET_ACCESS_KEY", resources.LOCAL_SECRET_ACCESS_KEY)
+}
+
+// setEnvVar sets an environment variable of the container to a literal value
+func setEnvVar(container *resources.Container, name string, value string) {
+	if container.EnvironmentVariables == nil {
+		container.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	container.EnvironmentVariables[name] = construct.IaCValue{Property: value}
+}
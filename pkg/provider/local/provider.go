@@ -0,0 +1,82 @@
+package local
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"reflect"
+
+	"github.com/klothoplatform/klotho/pkg/construct"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/provider/local/resources"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// LocalProvider runs the whole application on the developer's machine, with each construct expanded to a
+	// container of a docker compose project.
+	LocalProvider struct {
+		AppName string
+	}
+)
+
+func (l *LocalProvider) Name() string { return provider.LOCAL }
+
+func (l *LocalProvider) ListResources() []construct.Resource {
+	return resources.ListAll()
+}
+
+// CreateConstructFromId creates a resource from an id, but does not mutate the graph in any manner
+func (l *LocalProvider) CreateConstructFromId(id construct.ResourceId, dag *construct.ConstructGraph) (construct.BaseConstruct, error) {
+	typeToResource := make(map[string]construct.Resource)
+	for _, res := range resources.ListAll() {
+		typeToResource[res.Id().Type] = res
+	}
+	res, ok := typeToResource[id.Type]
+	if !ok {
+		return nil, fmt.Errorf("unable to find resource of type %s", id.Type)
+	}
+	newResource := reflect.New(reflect.TypeOf(res).Elem()).Interface()
+	resource, ok := newResource.(construct.Resource)
+	if !ok {
+		return nil, fmt.Errorf("item %s of type %T is not of type construct.Resource", id, newResource)
+	}
+	reflect.ValueOf(resource).Elem().FieldByName("Name").SetString(id.Name)
+	return resource, nil
+}
+
+//go:embed resources/templates/*
+var localTemplates embed.FS
+
+func (l *LocalProvider) GetOperationalTemplates() map[construct.ResourceId]*knowledgebase.ResourceTemplate {
+	templates := map[construct.ResourceId]*knowledgebase.ResourceTemplate{}
+	if err := fs.WalkDir(localTemplates, ".", func(path string, d fs.DirEntry, nerr error) error {
+		if d.IsDir() {
+			return nil
+		}
+		content, err := localTemplates.ReadFile(fmt.Sprintf("resources/templates/%s", d.Name()))
+		if err != nil {
+			panic(err)
+		}
+		resTemplate := &knowledgebase.ResourceTemplate{}
+		err = yaml.Unmarshal(content, resTemplate)
+		if err != nil {
+			panic(err)
+		}
+		id := construct.ResourceId{Provider: provider.LOCAL, Type: resTemplate.Type}
+		if templates[id] != nil {
+			panic(fmt.Errorf("duplicate template for type %s", resTemplate.Type))
+		}
+		templates[id] = resTemplate
+		return nil
+	}); err != nil {
+		return templates
+	}
+	return templates
+}
+
+// GetEdgeTemplates returns no templates, since the edges between local resources are defined by the local knowledge base
+func (l *LocalProvider) GetEdgeTemplates() map[string]*knowledgebase.EdgeTemplate {
+	return make(map[string]*knowledgebase.EdgeTemplate)
+}
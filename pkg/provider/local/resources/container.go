@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/collectionutil"
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/docker"
+)
+
+const (
+	CONTAINER_TYPE = "container"
+
+	// CONTAINER_PORT is the port which the dispatcher of a container unit serves its exposed routes on
+	CONTAINER_PORT = 3000
+
+	// LOCAL_REGION, LOCAL_ACCESS_KEY_ID and LOCAL_SECRET_ACCESS_KEY are what the AWS SDKs of the runtimes sign their
+	// requests to the local emulators of AWS services with, which the emulators are configured to accept.
+	LOCAL_REGION            = "us-east-1"
+	LOCAL_ACCESS_KEY_ID     = "klotho"
+	LOCAL_SECRET_ACCESS_KEY = "klotho-local"
+)
+
+type (
+	// Container runs an execution unit as a compose service, built from the unit's output directory with the
+	// Dockerfile that its language runtime generated.
+	Container struct {
+		Name                 string
+		ConstructRefs        construct.BaseConstructSet `yaml:"-"`
+		Context              string
+		Port                 int
+		Aliases              []string
+		EnvironmentVariables map[string]construct.IaCValue
+		Secrets              []*SecretFile
+	}
+
+	// PropertyResource is a local resource which other services can reference the properties of, such as the
+	// connection string of a postgres database. The values are known at compile time, since every resource runs within
+	// the same compose project.
+	PropertyResource interface {
+		construct.Resource
+		GetProperty(property string) (string, error)
+	}
+)
+
+// ServiceName returns the name of the compose service which runs the resource, which is also its host name within the
+// compose project's network
+func ServiceName(res construct.Resource) string {
+	return docker.ServiceNameSanitizer.Apply(res.Id().Name)
+}
+
+// MakeOperational builds the container from the unit it was expanded from, and resolves the environment variables
+// which the unit's runtime reads its dependencies from to the properties of the resources they were expanded to.
+func (container *Container) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	unit := container.unit()
+	if unit == nil {
+		return fmt.Errorf("container %s has no execution unit", container.Name)
+	}
+	if container.Context == "" {
+		container.Context = unit.Name
+	}
+	if container.Port == 0 {
+		container.Port = CONTAINER_PORT
+	}
+	// Other units proxy calls to the unit by its name, so it must resolve to this container
+	alias := docker.ServiceNameSanitizer.Apply(unit.Name)
+	if !collectionutil.Contains(container.Aliases, alias) {
+		container.Aliases = append(container.Aliases, alias)
+	}
+	if container.EnvironmentVariables == nil {
+		container.EnvironmentVariables = map[string]construct.IaCValue{}
+	}
+	for _, envVar := range unit.EnvironmentVariables {
+		if envVar.GetConstruct() == nil {
+			continue
+		}
+		res := findResourceOfConstruct(dag, container, envVar.GetConstruct())
+		if res == nil {
+			return fmt.Errorf("container %s has no resource for environment variable %s of %s", container.Name, envVar.GetName(), envVar.GetConstruct().Id())
+		}
+		container.EnvironmentVariables[envVar.GetName()] = construct.IaCValue{ResourceId: res.Id(), Property: envVar.GetValue()}
+	}
+	return nil
+}
+
+func (container *Container) unit() *types.ExecutionUnit {
+	for _, ref := range container.ConstructRefs {
+		if unit, ok := ref.(*types.ExecutionUnit); ok {
+			return unit
+		}
+	}
+	return nil
+}
+
+// findResourceOfConstruct returns the resource downstream of the container which the construct was expanded to
+func findResourceOfConstruct(dag *construct.ResourceGraph, container *Container, c construct.Construct) construct.Resource {
+	for _, res := range dag.GetDownstreamResources(container) {
+		if res.BaseConstructRefs().Has(c.Id()) {
+			return res
+		}
+	}
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (container *Container) BaseConstructRefs() construct.BaseConstructSet {
+	return container.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (container *Container) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     CONTAINER_TYPE,
+		Name:     container.Name,
+	}
+}
+
+func (container *Container) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ContainerMakeOperational(t *testing.T) {
+	orm := &types.Orm{Name: "users"}
+	redisNode := &types.RedisNode{Name: "cache"}
+	unit := &types.ExecutionUnit{Name: "Main"}
+	unit.EnvironmentVariables.Add(types.GenerateOrmConnStringEnvVar(orm))
+	unit.EnvironmentVariables.Add(types.GenerateRedisHostEnvVar(redisNode))
+	unit.EnvironmentVariables.Add(types.GenerateRedisPortEnvVar(redisNode))
+
+	tests := []struct {
+		name         string
+		container    *Container
+		dependencies []construct.Resource
+		want         *Container
+		wantErr      bool
+	}{
+		{
+			name:      "resolves environment variables",
+			container: &Container{Name: "container-main", ConstructRefs: construct.BaseConstructSetOf(unit)},
+			dependencies: []construct.Resource{
+				&Postgres{Name: "postgres-users", ConstructRefs: construct.BaseConstructSetOf(orm)},
+				&Redis{Name: "redis-cache", ConstructRefs: construct.BaseConstructSetOf(redisNode)},
+			},
+			want: &Container{
+				Name:    "container-main",
+				Context: "Main",
+				Port:    CONTAINER_PORT,
+				Aliases: []string{"main"},
+				EnvironmentVariables: map[string]construct.IaCValue{
+					"USERS_PERSIST_ORM_CONNECTION": {ResourceId: construct.ResourceId{Provider: "local", Type: POSTGRES_TYPE, Name: "postgres-users"}, Property: string(types.CONNECTION_STRING)},
+					"CACHE_PERSIST_REDIS_HOST":     {ResourceId: construct.ResourceId{Provider: "local", Type: REDIS_TYPE, Name: "redis-cache"}, Property: string(types.HOST)},
+					"CACHE_PERSIST_REDIS_PORT":     {ResourceId: construct.ResourceId{Provider: "local", Type: REDIS_TYPE, Name: "redis-cache"}, Property: string(types.PORT)},
+				},
+			},
+		},
+		{
+			name:      "missing dependency",
+			container: &Container{Name: "container-main", ConstructRefs: construct.BaseConstructSetOf(unit)},
+			dependencies: []construct.Resource{
+				&Postgres{Name: "postgres-users", ConstructRefs: construct.BaseConstructSetOf(orm)},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "no execution unit",
+			container: &Container{Name: "container-main"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dag := construct.NewResourceGraph()
+			dag.AddResource(tt.container)
+			for _, dep := range tt.dependencies {
+				dag.AddDependency(tt.container, dep)
+			}
+
+			err := tt.container.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want.Context, tt.container.Context)
+			assert.Equal(tt.want.Port, tt.container.Port)
+			assert.Equal(tt.want.Aliases, tt.container.Aliases)
+			assert.Equal(tt.want.EnvironmentVariables, tt.container.EnvironmentVariables)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+)
+
+const (
+	DYNAMODB_LOCAL_TYPE = "dynamodb_local"
+
+	DYNAMODB_LOCAL_PORT = 8000
+
+	defaultDynamodbLocalImage = "amazon/dynamodb-local:latest"
+)
+
+type (
+	// DynamodbLocal is a DynamoDB Local server which holds the table of the kv it was expanded from. The kv runtimes
+	// reach it through the DynamoDB client of the AWS SDK, with the endpoint overridden.
+	DynamodbLocal struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Image         string
+		TableName     string
+		// HashKey and RangeKey are the string attributes which the kv runtimes key items by
+		HashKey  string
+		RangeKey string
+	}
+)
+
+// MakeOperational names the table after the kv it was expanded from, like a dynamodb table, and keys it the way the
+// kv runtimes key their items.
+func (db *DynamodbLocal) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if db.Image == "" {
+		db.Image = defaultDynamodbLocalImage
+	}
+	if db.HashKey == "" {
+		db.HashKey = "pk"
+	}
+	if db.RangeKey == "" {
+		db.RangeKey = "sk"
+	}
+	if db.TableName != "" {
+		return nil
+	}
+	for _, ref := range db.ConstructRefs {
+		if kv, ok := ref.(*types.Kv); ok {
+			db.TableName = aws.DynamoDBTableSanitizer.Apply(fmt.Sprintf("%s-%s", appName, kv.Name))
+			return nil
+		}
+	}
+	return fmt.Errorf("dynamodb local %s has no TableName", db.Name)
+}
+
+// Endpoint returns the url which DynamoDB clients reach the server at
+func (db *DynamodbLocal) Endpoint() string {
+	return fmt.Sprintf("http://%s:%d", ServiceName(db), DYNAMODB_LOCAL_PORT)
+}
+
+// GetProperty returns the name of the table which the kv runtimes store items in
+func (db *DynamodbLocal) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.KV_DYNAMODB_TABLE_NAME):
+		return db.TableName, nil
+	}
+	return "", fmt.Errorf("unsupported property %s of dynamodb local %s", property, db.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (db *DynamodbLocal) BaseConstructRefs() construct.BaseConstructSet {
+	return db.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (db *DynamodbLocal) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     DYNAMODB_LOCAL_TYPE,
+		Name:     db.Name,
+	}
+}
+
+func (db *DynamodbLocal) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
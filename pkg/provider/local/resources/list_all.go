@@ -0,0 +1,17 @@
+package resources
+
+import (
+	"github.com/klothoplatform/klotho/pkg/construct"
+)
+
+func ListAll() []construct.Resource {
+	return []construct.Resource{
+		&Container{},
+		&DynamodbLocal{},
+		&Minio{},
+		&Postgres{},
+		&Redis{},
+		&Router{},
+		&SecretFile{},
+	}
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+)
+
+const (
+	MINIO_TYPE = "minio"
+
+	MINIO_PORT = 9000
+
+	defaultMinioImage = "minio/minio:latest"
+)
+
+type (
+	// Minio is an S3 compatible MinIO server which holds the bucket of the fs it was expanded from. The fs runtimes
+	// reach it through the S3 client of the AWS SDK, with the endpoint overridden.
+	Minio struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Image         string
+		BucketName    string
+	}
+)
+
+// MakeOperational names the bucket after the fs it was expanded from, like an s3 bucket.
+func (minio *Minio) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if minio.Image == "" {
+		minio.Image = defaultMinioImage
+	}
+	if minio.BucketName != "" {
+		return nil
+	}
+	for _, ref := range minio.ConstructRefs {
+		if fs, ok := ref.(*types.Fs); ok {
+			minio.BucketName = aws.S3BucketSanitizer.Apply(fmt.Sprintf("%s-%s", appName, fs.Name))
+			return nil
+		}
+	}
+	return fmt.Errorf("minio %s has no BucketName", minio.Name)
+}
+
+// Endpoint returns the url which S3 clients reach the server at. The SDKs address buckets by virtual host, so the
+// server's domain is also the suffix of the hosts of its buckets.
+func (minio *Minio) Endpoint() string {
+	return fmt.Sprintf("http://%s:%d", ServiceName(minio), MINIO_PORT)
+}
+
+// GetProperty returns the name of the bucket which the fs runtimes store files in
+func (minio *Minio) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.BUCKET_NAME):
+		return minio.BucketName, nil
+	}
+	return "", fmt.Errorf("unsupported property %s of minio %s", property, minio.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (minio *Minio) BaseConstructRefs() construct.BaseConstructSet {
+	return minio.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (minio *Minio) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     MINIO_TYPE,
+		Name:     minio.Name,
+	}
+}
+
+func (minio *Minio) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+	"github.com/klothoplatform/klotho/pkg/sanitization/aws"
+)
+
+const (
+	POSTGRES_TYPE = "postgres"
+
+	POSTGRES_PORT = 5432
+
+	defaultPostgresImage = "postgres:15-alpine"
+	defaultPostgresUser  = "postgres"
+)
+
+type (
+	// Postgres is a postgres database container which backs the orms it was expanded from. Its credentials never leave
+	// the compose project, so they are fixed rather than generated.
+	Postgres struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Image         string
+		DatabaseName  string
+		Username      string
+		Password      string
+	}
+)
+
+// MakeOperational names the database after the orm it was expanded from, like the database of an rds instance.
+func (db *Postgres) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if db.Image == "" {
+		db.Image = defaultPostgresImage
+	}
+	if db.Username == "" {
+		db.Username = defaultPostgresUser
+	}
+	if db.Password == "" {
+		db.Password = defaultPostgresUser
+	}
+	if db.DatabaseName != "" {
+		return nil
+	}
+	for _, ref := range db.ConstructRefs {
+		if orm, ok := ref.(*types.Orm); ok {
+			db.DatabaseName = aws.RdsDBNameSanitizer.Apply(orm.Name)
+			return nil
+		}
+	}
+	return fmt.Errorf("postgres %s has no DatabaseName", db.Name)
+}
+
+// GetProperty returns the connection string of the database, which the orm runtimes connect with
+func (db *Postgres) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.CONNECTION_STRING):
+		return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", db.Username, db.Password, ServiceName(db), POSTGRES_PORT, db.DatabaseName), nil
+	}
+	return "", fmt.Errorf("unsupported property %s of postgres %s", property, db.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (db *Postgres) BaseConstructRefs() construct.BaseConstructSet {
+	return db.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (db *Postgres) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     POSTGRES_TYPE,
+		Name:     db.Name,
+	}
+}
+
+func (db *Postgres) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+)
+
+const (
+	REDIS_TYPE = "redis"
+
+	REDIS_PORT = 6379
+
+	defaultRedisImage = "redis:7-alpine"
+)
+
+type (
+	// Redis is a redis container which backs the redis nodes it was expanded from
+	Redis struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Image         string
+	}
+)
+
+// MakeOperational defaults the image which the container runs
+func (redis *Redis) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if redis.Image == "" {
+		redis.Image = defaultRedisImage
+	}
+	return nil
+}
+
+// GetProperty returns the host and port which the redis runtimes connect to
+func (redis *Redis) GetProperty(property string) (string, error) {
+	switch property {
+	case string(types.HOST):
+		return ServiceName(redis), nil
+	case string(types.PORT):
+		return strconv.Itoa(REDIS_PORT), nil
+	}
+	return "", fmt.Errorf("unsupported property %s of redis %s", property, redis.Name)
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (redis *Redis) BaseConstructRefs() construct.BaseConstructSet {
+	return redis.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (redis *Redis) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     REDIS_TYPE,
+		Name:     redis.Name,
+	}
+}
+
+func (redis *Redis) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
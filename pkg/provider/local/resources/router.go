@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+)
+
+const (
+	ROUTER_TYPE = "router"
+
+	defaultRouterImage = "nginx:alpine"
+)
+
+type (
+	// Router is an nginx reverse proxy which serves the routes of the gateway it was expanded from on HostPort, and
+	// forwards each to the container of the unit which handles it.
+	Router struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Image         string
+		HostPort      int
+		Routes        []RouterRoute
+	}
+
+	RouterRoute struct {
+		// Path uses Express's route path syntax, like the routes of the gateway
+		Path string
+		// Verb is the HTTP method of the route, or ANY for all of them
+		Verb   string
+		Target *Container
+	}
+)
+
+// MakeOperational routes each of the gateway's routes to the container of its unit. The containers are those
+// downstream of the router, which the gateway's dependencies on its units were expanded to.
+func (router *Router) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if router.Image == "" {
+		router.Image = defaultRouterImage
+	}
+	containers := map[string]*Container{}
+	for _, container := range construct.GetDownstreamResourcesOfType[*Container](dag, router) {
+		if unit := container.unit(); unit != nil {
+			containers[unit.Name] = container
+		}
+	}
+	var routes []RouterRoute
+	for _, ref := range router.ConstructRefs {
+		gw, ok := ref.(*types.Gateway)
+		if !ok {
+			continue
+		}
+		for _, route := range gw.Routes {
+			target, ok := containers[route.ExecUnitName]
+			if !ok {
+				return fmt.Errorf("router %s has no container for unit %s of route %s %s", router.Name, route.ExecUnitName, route.Verb, route.Path)
+			}
+			routes = append(routes, RouterRoute{
+				Path:   route.Path,
+				Verb:   strings.ToUpper(route.Verb.String()),
+				Target: target,
+			})
+		}
+	}
+	router.Routes = routes
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (router *Router) BaseConstructRefs() construct.BaseConstructSet {
+	return router.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (router *Router) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     ROUTER_TYPE,
+		Name:     router.Name,
+	}
+}
+
+func (router *Router) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RouterMakeOperational(t *testing.T) {
+	main := &Container{Name: "container-main", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "main"})}
+	users := &Container{Name: "container-users", ConstructRefs: construct.BaseConstructSetOf(&types.ExecutionUnit{Name: "users"})}
+	gw := &types.Gateway{
+		Name: "api",
+		Routes: []types.Route{
+			{Path: "/", Verb: "get", ExecUnitName: "main"},
+			{Path: "/users/:id", Verb: "post", ExecUnitName: "users"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		containers []*Container
+		want       []RouterRoute
+		wantErr    bool
+	}{
+		{
+			name:       "routes to the containers of the units",
+			containers: []*Container{main, users},
+			want: []RouterRoute{
+				{Path: "/", Verb: "GET", Target: main},
+				{Path: "/users/:id", Verb: "POST", Target: users},
+			},
+		},
+		{
+			name:       "missing container",
+			containers: []*Container{main},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			router := &Router{Name: "router-api", ConstructRefs: construct.BaseConstructSetOf(gw)}
+			dag := construct.NewResourceGraph()
+			dag.AddResource(router)
+			for _, container := range tt.containers {
+				dag.AddDependency(router, container)
+			}
+
+			err := router.MakeOperational(dag, "app", nil)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, router.Routes)
+		})
+	}
+}
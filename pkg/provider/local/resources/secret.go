@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/engine/classification"
+	"github.com/klothoplatform/klotho/pkg/provider"
+)
+
+const (
+	SECRET_FILE_TYPE = "secret_file"
+
+	// SECRETS_DIR is the directory which compose mounts the secrets of a service into
+	SECRETS_DIR = "/run/secrets"
+)
+
+type (
+	// SecretFile mounts the secrets of the persist_secrets construct it was expanded from into the containers which
+	// use them. Each of Paths is read from the local file at that path, relative to the compose file, and mounted at
+	// the same path within SECRETS_DIR.
+	SecretFile struct {
+		Name          string
+		ConstructRefs construct.BaseConstructSet `yaml:"-"`
+		Paths         []string
+	}
+)
+
+// MakeOperational mounts the secrets of the persist_secrets construct it was expanded from
+func (secret *SecretFile) MakeOperational(dag *construct.ResourceGraph, appName string, classifier classification.Classifier) error {
+	if len(secret.Paths) > 0 {
+		return nil
+	}
+	for _, ref := range secret.ConstructRefs {
+		if secrets, ok := ref.(*types.Secrets); ok {
+			secret.Paths = append(secret.Paths, secrets.Secrets...)
+		}
+	}
+	if len(secret.Paths) == 0 {
+		return fmt.Errorf("secret file %s has no Paths", secret.Name)
+	}
+	return nil
+}
+
+// BaseConstructRefs returns AnnotationKey of the klotho resource the cloud resource is correlated to
+func (secret *SecretFile) BaseConstructRefs() construct.BaseConstructSet {
+	return secret.ConstructRefs
+}
+
+// Id returns the id of the cloud resource
+func (secret *SecretFile) Id() construct.ResourceId {
+	return construct.ResourceId{
+		Provider: provider.LOCAL,
+		Type:     SECRET_FILE_TYPE,
+		Name:     secret.Name,
+	}
+}
+
+func (secret *SecretFile) DeleteContext() construct.DeleteContext {
+	return construct.DeleteContext{
+		RequiresNoUpstream: true,
+	}
+}
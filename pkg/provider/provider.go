@@ -35,4 +35,7 @@ const (
 	AWS        = "aws"
 	DOCKER     = "docker"
 	KUBERNETES = "kubernetes"
+	LOCAL      = "local"
+	GCP        = "gcp"
+	AZURE      = "azure"
 )
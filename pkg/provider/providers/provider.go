@@ -8,18 +8,28 @@ import (
 	"github.com/klothoplatform/klotho/pkg/provider"
 	"github.com/klothoplatform/klotho/pkg/provider/aws"
 	awsknowledgebase "github.com/klothoplatform/klotho/pkg/provider/aws/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/azure"
+	azureknowledgebase "github.com/klothoplatform/klotho/pkg/provider/azure/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/gcp"
+	gcpknowledgebase "github.com/klothoplatform/klotho/pkg/provider/gcp/knowledgebase"
 	"github.com/klothoplatform/klotho/pkg/provider/kubernetes"
+	kubernetesknowledgebase "github.com/klothoplatform/klotho/pkg/provider/kubernetes/knowledgebase"
+	"github.com/klothoplatform/klotho/pkg/provider/local"
+	localknowledgebase "github.com/klothoplatform/klotho/pkg/provider/local/knowledgebase"
 )
 
 func GetProvider(cfg *config.Application) (provider.Provider, error) {
 	switch cfg.Provider {
-	case "gcp", "azure":
-		// TODO GCP and Azure is hacked to be the same as AWS so we can generate a topology diagram, but the compilation won't work.
-		fallthrough
 	case "aws":
-		return &aws.AWS{AppName: cfg.AppName}, nil
+		return &aws.AWS{AppName: cfg.QualifiedAppName()}, nil
+	case "azure":
+		return &azure.Azure{AppName: cfg.QualifiedAppName()}, nil
+	case "gcp":
+		return &gcp.GCP{AppName: cfg.QualifiedAppName()}, nil
 	case "kubernetes":
-		return &kubernetes.KubernetesProvider{AppName: cfg.AppName}, nil
+		return &kubernetes.KubernetesProvider{AppName: cfg.QualifiedAppName(), Standalone: true}, nil
+	case "local":
+		return &local.LocalProvider{AppName: cfg.QualifiedAppName()}, nil
 	}
 
 	return nil, fmt.Errorf("could not get provider: %v", cfg.Provider)
@@ -27,13 +37,16 @@ func GetProvider(cfg *config.Application) (provider.Provider, error) {
 
 func GetKnowledgeBase(cfg *config.Application) (knowledgebase.EdgeKB, error) {
 	switch cfg.Provider {
-	case "gcp", "azure":
-		// TODO GCP and Azure is hacked to be the same as AWS so we can generate a topology diagram, but the compilation won't work.
-		fallthrough
 	case "aws":
 		return awsknowledgebase.GetAwsKnowledgeBase()
+	case "azure":
+		return azureknowledgebase.GetAzureKnowledgeBase()
+	case "gcp":
+		return gcpknowledgebase.GetGcpKnowledgeBase()
 	case "kubernetes":
-		return knowledgebase.EdgeKB{}, nil
+		return knowledgebase.MergeKBs([]knowledgebase.EdgeKB{kubernetesknowledgebase.KubernetesKB, kubernetesknowledgebase.ClusterKB})
+	case "local":
+		return localknowledgebase.LocalKB, nil
 	}
 	return knowledgebase.EdgeKB{}, fmt.Errorf("could not get provider: %v", cfg.Provider)
 }
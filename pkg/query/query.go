@@ -26,7 +26,7 @@ func Exec(lang types.SourceLanguage, c *sitter.Node, q string) NextMatchFunc {
 		}
 	}
 
-	query, ok := queryCache.GetQuery(lang.ID, q)
+	query, ok := queryCache.GetQuery(lang.Sitter, q)
 	if !ok {
 		var err error
 		query, err = sitter.NewQuery([]byte(q), lang.Sitter)
@@ -34,7 +34,7 @@ func Exec(lang types.SourceLanguage, c *sitter.Node, q string) NextMatchFunc {
 			// Panic because this is a programmer error with the query string.
 			panic(klotho_errors.WrapErrf(err, "Error constructing query for %s", q))
 		}
-		queryCache.AddQuery(lang.ID, q, query)
+		queryCache.AddQuery(lang.Sitter, q, query)
 	}
 
 	cursor := sitter.NewQueryCursor()
@@ -68,14 +68,15 @@ func Collect[T any](f NextFunc[T]) []T {
 	return results
 }
 
-// Cache stores queries by language ID in a threadsafe manner
+// Cache stores queries by grammar in a threadsafe manner. Queries are keyed by grammar rather than by language ID
+// because a language may parse files with more than one grammar (e.g. javascript and typescript).
 type Cache struct {
-	queriesByLang async.ConcurrentMap[types.LanguageId, *async.ConcurrentMap[string, *sitter.Query]]
+	queriesByLang async.ConcurrentMap[*sitter.Language, *async.ConcurrentMap[string, *sitter.Query]]
 }
 
 // AddQuery adds a new query to the cache
-func (m *Cache) AddQuery(lang types.LanguageId, name string, query *sitter.Query) {
-	m.queriesByLang.Compute(lang, func(k types.LanguageId, v *async.ConcurrentMap[string, *sitter.Query]) (*async.ConcurrentMap[string, *sitter.Query], bool) {
+func (m *Cache) AddQuery(lang *sitter.Language, name string, query *sitter.Query) {
+	m.queriesByLang.Compute(lang, func(k *sitter.Language, v *async.ConcurrentMap[string, *sitter.Query]) (*async.ConcurrentMap[string, *sitter.Query], bool) {
 		if v == nil {
 			v = &async.ConcurrentMap[string, *sitter.Query]{}
 		}
@@ -84,8 +85,8 @@ func (m *Cache) AddQuery(lang types.LanguageId, name string, query *sitter.Query
 	})
 }
 
-// GetQuery gets the *sitter.Query instance associated with the provided language ID and name combination
-func (m *Cache) GetQuery(lang types.LanguageId, name string) (*sitter.Query, bool) {
+// GetQuery gets the *sitter.Query instance associated with the provided grammar and name combination
+func (m *Cache) GetQuery(lang *sitter.Language, name string) (*sitter.Query, bool) {
 	if lCache, ok := m.queriesByLang.Get(lang); ok {
 		return lCache.Get(name)
 	}
@@ -0,0 +1,16 @@
+package aws
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// CertificateSanitizer returns a sanitized certificate name when applied.
+var CertificateSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9_-]+`),
+			Replacement: "-",
+		},
+	}, 64)
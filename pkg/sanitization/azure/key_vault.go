@@ -0,0 +1,17 @@
+package azure
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// KeyVaultSecretNameSanitizer returns a sanitized Key Vault secret name when applied. The secret runtimes derive the
+// same name from the path of the secret they read, so the name is never shortened.
+var KeyVaultSecretNameSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9-]`),
+			Replacement: "-",
+		},
+	}, 0)
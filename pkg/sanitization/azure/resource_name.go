@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// resourceNameRules sanitize a name to lowercase letters, numbers and hyphens, starting with a letter and not ending
+// with a hyphen, which most Azure resources can be named with.
+var resourceNameRules = []sanitization.Rule{
+	{
+		Pattern:     regexp.MustCompile(`[^a-zA-Z0-9-]+`),
+		Lowercase:   true,
+		Replacement: "-",
+	},
+	// must start with a letter
+	{
+		Pattern:     regexp.MustCompile(`^[^a-z]+`),
+		Replacement: "",
+	},
+	// must not end with a hyphen
+	{
+		Pattern:     regexp.MustCompile(`-+$`),
+		Replacement: "",
+	},
+	{
+		Pattern:     regexp.MustCompile(`--+`),
+		Replacement: "-",
+	},
+}
+
+// ResourceNameSanitizer returns a sanitized name for Azure resources named with lowercase letters, numbers and
+// hyphens, such as resource groups, databases and Service Bus entities.
+var ResourceNameSanitizer = sanitization.NewSanitizer(resourceNameRules, 63)
+
+// ContainerAppSanitizer returns a sanitized Container Apps app or environment name when applied.
+var ContainerAppSanitizer = sanitization.NewSanitizer(resourceNameRules, 32)
+
+// KeyVaultSanitizer returns a sanitized Key Vault name when applied.
+var KeyVaultSanitizer = sanitization.NewSanitizer(resourceNameRules, 24)
+
+// StorageAccountSanitizer returns a sanitized name for the resources that can only be named with lowercase letters and
+// numbers, such as storage accounts and container registries, when applied.
+var StorageAccountSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9]+`),
+			Lowercase:   true,
+			Replacement: "",
+		},
+	}, 24)
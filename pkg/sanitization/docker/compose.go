@@ -0,0 +1,23 @@
+package docker
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// ServiceNameSanitizer returns a sanitized Docker Compose service name when applied.
+var ServiceNameSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			// must only contain lowercase letters, numbers and hyphens, since it is also a host name
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9-]`),
+			Lowercase:   true,
+			Replacement: "-",
+		},
+		{
+			// must start with a letter or number
+			Pattern:     regexp.MustCompile(`^[^a-z0-9]+`),
+			Replacement: "",
+		},
+	}, 63)
@@ -0,0 +1,21 @@
+package gcp
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// PubsubTopicSanitizer returns a sanitized Pub/Sub topic or subscription name when applied.
+var PubsubTopicSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9_.~+%-]`),
+			Replacement: "-",
+		},
+		// must start with a letter
+		{
+			Pattern:     regexp.MustCompile(`^[^a-zA-Z]+`),
+			Replacement: "",
+		},
+	}, 255)
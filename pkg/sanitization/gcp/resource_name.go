@@ -0,0 +1,47 @@
+package gcp
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// resourceNameRules sanitize a name to the RFC 1035 label that most GCP resources are named with: lowercase letters,
+// numbers and hyphens, starting with a letter and not ending with a hyphen.
+var resourceNameRules = []sanitization.Rule{
+	{
+		Pattern:     regexp.MustCompile(`[^a-zA-Z0-9-]+`),
+		Lowercase:   true,
+		Replacement: "-",
+	},
+	// must start with a letter
+	{
+		Pattern:     regexp.MustCompile(`^[^a-z]+`),
+		Replacement: "",
+	},
+	// must not end with a hyphen
+	{
+		Pattern:     regexp.MustCompile(`-+$`),
+		Replacement: "",
+	},
+	{
+		Pattern:     regexp.MustCompile(`--+`),
+		Replacement: "-",
+	},
+}
+
+// ResourceNameSanitizer returns a sanitized name for GCP resources named with an RFC 1035 label, such as the
+// resources of the compute and artifact registry apis.
+var ResourceNameSanitizer = sanitization.NewSanitizer(resourceNameRules, 63)
+
+// CloudRunServiceSanitizer returns a sanitized Cloud Run service name when applied.
+var CloudRunServiceSanitizer = sanitization.NewSanitizer(resourceNameRules, 49)
+
+// ServiceAccountIdSanitizer returns a sanitized service account id when applied.
+var ServiceAccountIdSanitizer = sanitization.NewSanitizer(resourceNameRules, 30)
+
+// RedisInstanceSanitizer returns a sanitized Memorystore instance id when applied.
+var RedisInstanceSanitizer = sanitization.NewSanitizer(resourceNameRules, 40)
+
+// VpcAccessConnectorSanitizer returns a sanitized Serverless VPC Access connector name when applied.
+var VpcAccessConnectorSanitizer = sanitization.NewSanitizer(resourceNameRules, 25)
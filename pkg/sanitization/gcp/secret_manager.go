@@ -0,0 +1,17 @@
+package gcp
+
+import (
+	"regexp"
+
+	"github.com/klothoplatform/klotho/pkg/sanitization"
+)
+
+// SecretIdSanitizer returns a sanitized Secret Manager secret id when applied. The secret runtimes derive the same id
+// from the path of the secret they read, so the id is never shortened.
+var SecretIdSanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^a-zA-Z0-9_-]`),
+			Replacement: "_",
+		},
+	}, 0)
@@ -111,3 +111,12 @@ var LabelValueSanitizer = sanitization.NewSanitizer(
 			Replacement: "",
 		},
 	}, 63)
+
+// SecretKeySanitizer returns a sanitized key of the data of a secret or config map when applied.
+var SecretKeySanitizer = sanitization.NewSanitizer(
+	[]sanitization.Rule{
+		{
+			Pattern:     regexp.MustCompile(`[^-._a-zA-Z0-9]+`),
+			Replacement: "_",
+		},
+	}, 253)
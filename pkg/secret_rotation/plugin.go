@@ -0,0 +1,67 @@
+package secretrotation
+
+import (
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/klothoplatform/klotho/pkg/multierr"
+	"go.uber.org/zap"
+)
+
+type (
+	// SecretRotations sets the `types.SecretRotation` of the secrets, secret configs and orms whose configuration has a
+	// rotation. Orms rotate the credentials of their database.
+	//
+	// It must run after the language plugins, which create the constructs.
+	SecretRotations struct {
+		Config *config.Application
+	}
+)
+
+func (p SecretRotations) Name() string { return "SecretRotations" }
+
+func (p SecretRotations) Transform(input *types.InputFiles, fileDeps *types.FileDependencies, constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, secrets := range construct.GetConstructsOfType[*types.Secrets](constructGraph) {
+		rotation, err := newRotation(p.Config.GetPersistSecrets(secrets.Name).Rotation, true)
+		if err != nil {
+			errs.Append(fmt.Errorf("invalid rotation for persist_secrets %s: %w", secrets.Name, err))
+			continue
+		}
+		secrets.Rotation = rotation
+	}
+	for _, cfg := range construct.GetConstructsOfType[*types.Config](constructGraph) {
+		rotationCfg := p.Config.GetConfig(cfg.Name).Rotation
+		if rotationCfg != nil && !cfg.Secret {
+			errs.Append(fmt.Errorf("invalid rotation for config %s: only secret configs can be rotated", cfg.Name))
+			continue
+		}
+		rotation, err := newRotation(rotationCfg, true)
+		if err != nil {
+			errs.Append(fmt.Errorf("invalid rotation for config %s: %w", cfg.Name, err))
+			continue
+		}
+		cfg.Rotation = rotation
+	}
+	for _, orm := range construct.GetConstructsOfType[*types.Orm](constructGraph) {
+		rotation, err := newRotation(p.Config.GetPersistOrm(orm.Name).Rotation, false)
+		if err != nil {
+			errs.Append(fmt.Errorf("invalid rotation for persist_orm %s: %w", orm.Name, err))
+			continue
+		}
+		if rotation != nil {
+			zap.S().Warnf("persist_orm %s rotates its credentials, but connection strings hold the credentials they were deployed with, so connections which use them fail after a rotation", orm.Name)
+		}
+		orm.Rotation = rotation
+	}
+	return errs.ErrOrNil()
+}
+
+func newRotation(cfg *config.SecretRotation, requireLambda bool) (*types.SecretRotation, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	return types.NewSecretRotation(cfg.Days, cfg.LambdaArn, requireLambda)
+}
@@ -0,0 +1,100 @@
+package secretrotation
+
+import (
+	"testing"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
+	"github.com/klothoplatform/klotho/pkg/config"
+	"github.com/klothoplatform/klotho/pkg/construct"
+	"github.com/stretchr/testify/assert"
+)
+
+const rotationLambda = "arn:aws:lambda:us-east-1:123456789012:function:rotate"
+
+func Test_SecretRotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		construct construct.Construct
+		cfg       config.Application
+		want      *types.SecretRotation
+		wantErr   bool
+	}{
+		{
+			name:      "no rotation",
+			construct: &types.Secrets{Name: "creds"},
+		},
+		{
+			name:      "secrets rotated by lambda",
+			construct: &types.Secrets{Name: "creds"},
+			cfg: config.Application{PersistSecrets: map[string]*config.Persist{
+				"creds": {Rotation: &config.SecretRotation{Days: 30, LambdaArn: rotationLambda}},
+			}},
+			want: &types.SecretRotation{AfterDays: 30, LambdaArn: rotationLambda},
+		},
+		{
+			name:      "secrets without lambda",
+			construct: &types.Secrets{Name: "creds"},
+			cfg: config.Application{PersistSecrets: map[string]*config.Persist{
+				"creds": {Rotation: &config.SecretRotation{Days: 30}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:      "secret config",
+			construct: &types.Config{Name: "api-key", Secret: true},
+			cfg: config.Application{Config: map[string]*config.Config{
+				"api-key": {Rotation: &config.SecretRotation{Days: 7, LambdaArn: rotationLambda}},
+			}},
+			want: &types.SecretRotation{AfterDays: 7, LambdaArn: rotationLambda},
+		},
+		{
+			name:      "non-secret config",
+			construct: &types.Config{Name: "settings"},
+			cfg: config.Application{Config: map[string]*config.Config{
+				"settings": {Rotation: &config.SecretRotation{Days: 7, LambdaArn: rotationLambda}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:      "orm credentials use the hosted function",
+			construct: &types.Orm{Name: "db"},
+			cfg: config.Application{PersistOrm: map[string]*config.Persist{
+				"db": {Rotation: &config.SecretRotation{Days: 90}},
+			}},
+			want: &types.SecretRotation{AfterDays: 90},
+		},
+		{
+			name:      "days out of range",
+			construct: &types.Orm{Name: "db"},
+			cfg: config.Application{PersistOrm: map[string]*config.Persist{
+				"db": {Rotation: &config.SecretRotation{Days: 0}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			graph := construct.NewConstructGraph()
+			graph.AddConstruct(tt.construct)
+
+			err := SecretRotations{Config: &tt.cfg}.Transform(&types.InputFiles{}, &types.FileDependencies{}, graph)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			switch c := tt.construct.(type) {
+			case *types.Secrets:
+				assert.Equal(tt.want, c.Rotation)
+			case *types.Config:
+				assert.Equal(tt.want, c.Rotation)
+			case *types.Orm:
+				assert.Equal(tt.want, c.Rotation)
+			}
+		})
+	}
+}
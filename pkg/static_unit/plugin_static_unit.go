@@ -48,7 +48,8 @@ func (p StaticUnitSplit) Transform(input *types.InputFiles, fileDeps *types.File
 					errs.Append(cause)
 				}
 				newUnit := &types.StaticUnit{
-					Name: cap.ID,
+					Name:   cap.ID,
+					Domain: p.Config.GetStaticUnit(cap.ID).CustomDomain(),
 				}
 
 				indexDocument, ok := cap.Directives.String("index_document")
@@ -76,6 +76,17 @@ func (p *ConstructValidation) handleResources(constructGraph *construct.Construc
 	errs.Append(err)
 	err = validateNoDuplicateIds[*types.Config](constructGraph)
 	errs.Append(err)
+	err = validateNoDuplicateIds[*types.Schedule](constructGraph)
+	errs.Append(err)
+	err = validateNoDuplicateIds[*types.Queue](constructGraph)
+	errs.Append(err)
+	err = validateNoDuplicateIds[*types.Workflow](constructGraph)
+	errs.Append(err)
+	err = validateNoDuplicateIds[*types.FsEvent](constructGraph)
+	errs.Append(err)
+	errs.Append(validateFsEventsArePersisted(constructGraph))
+	err = validateNoDuplicateIds[*types.WebSocketGateway](constructGraph)
+	errs.Append(err)
 	return errs.ErrOrNil()
 }
 
@@ -195,6 +206,14 @@ func (p *ConstructValidation) validateConfigOverrideResourcesExist(constructGrap
 			log.Warnf("Unknown config resource in config override, \"%s\".", unit)
 		}
 	}
+
+	for queue := range p.UserConfigOverrides.Queues {
+		resources := constructGraph.GetResourcesOfCapability(annotation.QueueCapability)
+		resource := getResourceById(queue, resources)
+		if resource == nil {
+			log.Warnf("Unknown queue in config override, \"%s\".", queue)
+		}
+	}
 }
 
 func (p *ConstructValidation) checkAnnotationForResource(annot *types.Annotation, constructGraph *construct.ConstructGraph, log *zap.SugaredLogger) construct.Construct {
@@ -213,6 +232,14 @@ func (p *ConstructValidation) checkAnnotationForResource(annot *types.Annotation
 		resources = append(constructGraph.GetResourcesOfCapability(annotation.PubSubCapability), resources...)
 	case annotation.ConfigCapability:
 		resources = append(constructGraph.GetResourcesOfCapability(annotation.ConfigCapability), resources...)
+	case annotation.ScheduleCapability:
+		resources = append(constructGraph.GetResourcesOfCapability(annotation.ScheduleCapability), resources...)
+	case annotation.QueueCapability:
+		resources = append(constructGraph.GetResourcesOfCapability(annotation.QueueCapability), resources...)
+	case annotation.WorkflowCapability:
+		resources = append(constructGraph.GetResourcesOfCapability(annotation.WorkflowCapability), resources...)
+	case annotation.FsEventCapability:
+		resources = append(constructGraph.GetResourcesOfCapability(annotation.FsEventCapability), resources...)
 	case annotation.AssetCapability:
 	default:
 		log.Warnf("Unknown annotation capability %s.", annot.Capability.Name)
@@ -249,3 +276,22 @@ func validateNoDuplicateIds[T construct.Construct](constructGraph *construct.Con
 	}
 	return nil
 }
+
+// validateFsEventsArePersisted ensures that the fs of each fs event is persisted by an execution unit,
+// rather than only being referenced by the `@klotho::fs_event` annotations subscribed to it.
+func validateFsEventsArePersisted(constructGraph *construct.ConstructGraph) error {
+	var errs multierr.Error
+	for _, event := range construct.GetConstructsOfType[*types.FsEvent](constructGraph) {
+		fs := &types.Fs{Name: event.Name}
+		persisted := false
+		for _, upstream := range constructGraph.GetUpstreamConstructs(fs) {
+			if _, ok := upstream.(*types.ExecutionUnit); ok {
+				persisted = true
+			}
+		}
+		if !persisted {
+			errs.Append(fmt.Errorf(`fs events are subscribed to "%s", which is not a persisted fs`, event.Name))
+		}
+	}
+	return errs.ErrOrNil()
+}